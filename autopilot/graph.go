@@ -126,6 +126,119 @@ func (d *databaseChannelGraph) ForEachNode(cb func(Node) error) error {
 	})
 }
 
+// ChannelGraphSource is a source of the channel graph that can be traversed
+// without a database transaction, such as the in-memory copy of the graph
+// maintained by the channel router.
+type ChannelGraphSource interface {
+	// ForEachNode calls the passed callback for every node within the
+	// graph.
+	ForEachNode(func(*channeldb.LightningNode) error) error
+
+	// ForEachNodeChannel calls the passed callback for every channel of
+	// the target node that the node has advertised a policy for. The
+	// first policy is the outgoing policy of the node, while the second
+	// is the incoming policy of the node at the other end of the channel.
+	ForEachNodeChannel(nodePub [33]byte, cb func(*channeldb.ChannelEdgeInfo,
+		*channeldb.ChannelEdgePolicy,
+		*channeldb.ChannelEdgePolicy) error) error
+}
+
+// sourceChannelGraph wraps a ChannelGraphSource with the necessary API to
+// properly implement the autopilot.ChannelGraph interface.
+type sourceChannelGraph struct {
+	source ChannelGraphSource
+}
+
+// A compile time assertion to ensure sourceChannelGraph meets the
+// autopilot.ChannelGraph interface.
+var _ ChannelGraph = (*sourceChannelGraph)(nil)
+
+// ChannelGraphFromSource returns an instance of the autopilot.ChannelGraph
+// backed by the passed source of the channel graph.
+func ChannelGraphFromSource(source ChannelGraphSource) ChannelGraph {
+	return &sourceChannelGraph{
+		source: source,
+	}
+}
+
+// sourceNode is a wrapper struct around a channeldb.LightningNode obtained
+// from a ChannelGraphSource. The wrapper method implement the autopilot.Node
+// interface.
+type sourceNode struct {
+	source ChannelGraphSource
+
+	node *channeldb.LightningNode
+}
+
+// A compile time assertion to ensure sourceNode meets the autopilot.Node
+// interface.
+var _ Node = (*sourceNode)(nil)
+
+// PubKey is the identity public key of the node.
+//
+// NOTE: Part of the autopilot.Node interface.
+func (s sourceNode) PubKey() *btcec.PublicKey {
+	pubKey, _ := s.node.PubKey()
+	return pubKey
+}
+
+// Addrs returns a slice of publicly reachable public TCP addresses that the
+// peer is known to be listening on.
+//
+// NOTE: Part of the autopilot.Node interface.
+func (s sourceNode) Addrs() []net.Addr {
+	return s.node.Addresses
+}
+
+// ForEachChannel is a higher-order function that will be used to iterate
+// through all edges emanating from/to the target node.
+//
+// NOTE: Part of the autopilot.Node interface.
+func (s sourceNode) ForEachChannel(cb func(ChannelEdge) error) error {
+	return s.source.ForEachNodeChannel(s.node.PubKeyBytes, func(
+		ei *channeldb.ChannelEdgeInfo,
+		ep, _ *channeldb.ChannelEdgePolicy) error {
+
+		pubkey, _ := ep.Node.PubKey()
+		edge := ChannelEdge{
+			Channel: Channel{
+				ChanID:    lnwire.NewShortChanIDFromInt(ep.ChannelID),
+				Capacity:  ei.Capacity,
+				FundedAmt: ei.Capacity,
+				Node:      NewNodeID(pubkey),
+			},
+			Peer: sourceNode{
+				source: s.source,
+				node:   ep.Node,
+			},
+		}
+
+		return cb(edge)
+	})
+}
+
+// ForEachNode is a higher-order function that should be called once for each
+// connected node within the channel graph. If the passed callback returns an
+// error, then execution should be terminated.
+//
+// NOTE: Part of the autopilot.ChannelGraph interface.
+func (s *sourceChannelGraph) ForEachNode(cb func(Node) error) error {
+	return s.source.ForEachNode(func(n *channeldb.LightningNode) error {
+		// We'll skip over any node that doesn't have any advertised
+		// addresses. As we won't be able to reach them to actually
+		// open any channels.
+		if len(n.Addresses) == 0 {
+			return nil
+		}
+
+		node := sourceNode{
+			source: s.source,
+			node:   n,
+		}
+		return cb(node)
+	})
+}
+
 // addRandChannel creates a new channel two target nodes. This function is
 // meant to aide in the generation of random graphs for use within test cases
 // the exercise the autopilot package.
@@ -0,0 +1,454 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+const (
+	// defaultChanDisableTimeout is the default duration that the link of
+	// a channel must be inactive before we announce the channel as
+	// disabled to the network.
+	defaultChanDisableTimeout = 20 * time.Minute
+
+	// defaultChanStatusSampleInterval is the default interval at which
+	// the status of the links of all our channels is sampled.
+	defaultChanStatusSampleInterval = time.Minute
+)
+
+// chanStatus is the status of one of our channels as advertised to the
+// network.
+type chanStatus uint8
+
+const (
+	// chanStatusUnknown indicates that we don't know yet whether the
+	// channel is advertised as enabled or disabled. This is the case for
+	// all channels after a restart.
+	chanStatusUnknown chanStatus = iota
+
+	// chanStatusEnabled indicates that the channel is advertised as
+	// enabled.
+	chanStatusEnabled
+
+	// chanStatusPendingDisabled indicates that the channel's link has
+	// become inactive, and that the channel will be advertised as disabled
+	// unless the link becomes active again within the grace period.
+	chanStatusPendingDisabled
+
+	// chanStatusDisabled indicates that the channel is advertised as
+	// disabled.
+	chanStatusDisabled
+)
+
+// String returns a human readable version of the channel status.
+func (s chanStatus) String() string {
+	switch s {
+	case chanStatusUnknown:
+		return "unknown"
+	case chanStatusEnabled:
+		return "enabled"
+	case chanStatusPendingDisabled:
+		return "pending-disabled"
+	case chanStatusDisabled:
+		return "disabled"
+	default:
+		return fmt.Sprintf("unknown status %d", uint8(s))
+	}
+}
+
+// chanStatusAction is an action that can be requested manually for a
+// channel, overriding the status derived from the liveness of its peer.
+type chanStatusAction uint8
+
+const (
+	// chanStatusActionEnable forces the channel to be advertised as
+	// enabled.
+	chanStatusActionEnable chanStatusAction = iota
+
+	// chanStatusActionDisable forces the channel to be advertised as
+	// disabled.
+	chanStatusActionDisable
+
+	// chanStatusActionAuto hands the status of the channel back to the
+	// manager, so it's once again derived from the liveness of its peer.
+	chanStatusActionAuto
+)
+
+// chanStatusConfig houses the interfaces and parameters required by the
+// chanStatusManager to carry out its duties.
+type chanStatusConfig struct {
+	// DisableTimeout is the duration that the link of a channel must be
+	// inactive before the channel is advertised as disabled.
+	DisableTimeout time.Duration
+
+	// SampleInterval is the interval at which the status of the links of
+	// all our channels is sampled.
+	SampleInterval time.Duration
+
+	// FetchChannels returns all of our channels, including the pending
+	// ones.
+	FetchChannels func() ([]*channeldb.OpenChannel, error)
+
+	// IsChannelActive returns true if the link of the target channel is
+	// active and able to forward HTLCs.
+	IsChannelActive func(lnwire.ChannelID) bool
+
+	// ApplyChannelStatus signs, commits, and broadcasts a new update for
+	// our direction of the target channel with the disabled flag set
+	// accordingly. It must be a no-op if our latest update already has
+	// the requested status.
+	ApplyChannelStatus func(chanID lnwire.ShortChannelID,
+		disabled bool) error
+}
+
+// managedChannel tracks the status of a single public channel of ours.
+type managedChannel struct {
+	shortChanID lnwire.ShortChannelID
+	chanID      lnwire.ChannelID
+	peer        [33]byte
+
+	status chanStatus
+
+	// inactiveSince is the time at which the link of the channel was
+	// first observed to be inactive. It's only meaningful while the
+	// channel is pending to be disabled.
+	inactiveSince time.Time
+
+	// manual is true if the status of the channel was set manually, in
+	// which case it won't be changed by the manager.
+	manual bool
+}
+
+// chanStatusRequest is a request to manually change the status of a channel.
+type chanStatusRequest struct {
+	chanPoint wire.OutPoint
+	action    chanStatusAction
+
+	errResp chan error
+}
+
+// chanStatusManager is responsible for advertising our public channels as
+// disabled to the network once their links have been inactive for a grace
+// period, for example because the peer went offline, and for re-enabling
+// them once their links are active again. This prevents the rest of the
+// network from routing payments into channels that are unable to forward
+// them. Additionally, the status of a channel can be set manually.
+//
+// NOTE: Manual overrides aren't persisted, so all channels are once again
+// managed automatically after a restart.
+type chanStatusManager struct {
+	started sync.Once
+	stopped sync.Once
+
+	cfg *chanStatusConfig
+
+	// now returns the current time. It's a variable in order to allow
+	// tests to control the passing of time.
+	now func() time.Time
+
+	// chans is the set of public channels that are being managed, keyed
+	// by their funding outpoint. It's only accessed by the statusHandler
+	// goroutine.
+	chans map[wire.OutPoint]*managedChannel
+
+	// disconnects maps the peers that disconnected since the last sample
+	// to the time at which they did. It's guarded by disconnectsMtx, such
+	// that peers can be marked without waiting on the statusHandler.
+	disconnects    map[[33]byte]time.Time
+	disconnectsMtx sync.Mutex
+
+	statusRequests chan *chanStatusRequest
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// newChanStatusManager creates a new chanStatusManager backed by the passed
+// config.
+func newChanStatusManager(cfg *chanStatusConfig) *chanStatusManager {
+	return &chanStatusManager{
+		cfg:            cfg,
+		now:            time.Now,
+		chans:          make(map[wire.OutPoint]*managedChannel),
+		disconnects:    make(map[[33]byte]time.Time),
+		statusRequests: make(chan *chanStatusRequest),
+		quit:           make(chan struct{}),
+	}
+}
+
+// Start launches the goroutine that samples the status of our channels.
+func (m *chanStatusManager) Start() error {
+	m.started.Do(func() {
+		m.wg.Add(1)
+		go m.statusHandler()
+	})
+	return nil
+}
+
+// Stop signals the chanStatusManager to exit, and blocks until it has.
+func (m *chanStatusManager) Stop() error {
+	m.stopped.Do(func() {
+		close(m.quit)
+		m.wg.Wait()
+	})
+	return nil
+}
+
+// PeerDisconnected notifies the chanStatusManager that the passed peer has
+// disconnected. The grace period of all the peer's channels starts right
+// away, rather than at the next sample. The call never blocks, as the peer is
+// only marked, and its channels are updated at the next sample.
+func (m *chanStatusManager) PeerDisconnected(pub *btcec.PublicKey) {
+	var peer [33]byte
+	copy(peer[:], pub.SerializeCompressed())
+
+	m.disconnectsMtx.Lock()
+	defer m.disconnectsMtx.Unlock()
+
+	if _, ok := m.disconnects[peer]; !ok {
+		m.disconnects[peer] = m.now()
+	}
+}
+
+// UpdateChanStatus manually sets the status of the channel identified by the
+// passed funding outpoint. The enable and disable actions override the status
+// derived from the liveness of the channel's peer until the auto action is
+// requested.
+func (m *chanStatusManager) UpdateChanStatus(chanPoint wire.OutPoint,
+	action chanStatusAction) error {
+
+	errChan := make(chan error, 1)
+	req := &chanStatusRequest{
+		chanPoint: chanPoint,
+		action:    action,
+		errResp:   errChan,
+	}
+
+	select {
+	case m.statusRequests <- req:
+	case <-m.quit:
+		return fmt.Errorf("chanStatusManager shutting down")
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-m.quit:
+		return fmt.Errorf("chanStatusManager shutting down")
+	}
+}
+
+// statusHandler is the main goroutine of the chanStatusManager. It
+// periodically samples the links of all our channels, and handles manual
+// status requests.
+//
+// NOTE: This MUST be run as a goroutine.
+func (m *chanStatusManager) statusHandler() {
+	defer m.wg.Done()
+
+	sampleTicker := time.NewTicker(m.cfg.SampleInterval)
+	defer sampleTicker.Stop()
+
+	m.sampleChannels()
+
+	for {
+		select {
+		case <-sampleTicker.C:
+			m.sampleChannels()
+
+		case req := <-m.statusRequests:
+			req.errResp <- m.handleStatusRequest(req)
+
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// refreshChannels synchronizes the set of managed channels with our current
+// set of confirmed public channels.
+func (m *chanStatusManager) refreshChannels() error {
+	channels, err := m.cfg.FetchChannels()
+	if err != nil {
+		return err
+	}
+
+	current := make(map[wire.OutPoint]struct{})
+	for _, channel := range channels {
+		// Only confirmed public channels are advertised to the
+		// network, so they're the only ones we need to manage.
+		if channel.IsPending ||
+			channel.ChannelFlags&lnwire.FFAnnounceChannel == 0 {
+
+			continue
+		}
+
+		chanPoint := channel.FundingOutpoint
+		current[chanPoint] = struct{}{}
+
+		if _, ok := m.chans[chanPoint]; ok {
+			continue
+		}
+
+		managed := &managedChannel{
+			shortChanID: channel.ShortChanID,
+			chanID:      lnwire.NewChanIDFromOutPoint(&chanPoint),
+		}
+		copy(managed.peer[:], channel.IdentityPub.SerializeCompressed())
+		m.chans[chanPoint] = managed
+	}
+
+	// Any channel that we no longer have has been closed, so we can stop
+	// managing it.
+	for chanPoint := range m.chans {
+		if _, ok := current[chanPoint]; !ok {
+			delete(m.chans, chanPoint)
+		}
+	}
+
+	return nil
+}
+
+// sampleChannels samples the links of all our public channels, and updates
+// their advertised status accordingly.
+func (m *chanStatusManager) sampleChannels() {
+	if err := m.refreshChannels(); err != nil {
+		srvrLog.Errorf("Unable to fetch channels to sample: %v", err)
+		return
+	}
+
+	// The grace period of the channels of the peers that disconnected
+	// since the last sample started at the time they disconnected.
+	m.disconnectsMtx.Lock()
+	disconnects := m.disconnects
+	m.disconnects = make(map[[33]byte]time.Time)
+	m.disconnectsMtx.Unlock()
+
+	for peer, disconnectedAt := range disconnects {
+		m.markPeerInactive(peer, disconnectedAt)
+	}
+
+	now := m.now()
+	for chanPoint, managed := range m.chans {
+		if managed.manual {
+			continue
+		}
+
+		active := m.cfg.IsChannelActive(managed.chanID)
+		switch {
+		// The link of the channel is active, so we'll make sure it's
+		// advertised as enabled. Applying the status is a no-op if the
+		// channel was only pending to be disabled.
+		case active && managed.status != chanStatusEnabled:
+			m.applyStatus(chanPoint, managed, chanStatusEnabled)
+
+		// The link has just been found to be inactive, so the grace
+		// period of the channel starts now. As we don't know how a
+		// channel is advertised after a restart, we'll also treat it
+		// as pending to be disabled, which gives its peer the chance
+		// to reconnect first.
+		case !active && (managed.status == chanStatusEnabled ||
+			managed.status == chanStatusUnknown):
+
+			managed.status = chanStatusPendingDisabled
+			managed.inactiveSince = now
+
+		// The link has been inactive for the full grace period, so
+		// we'll advertise the channel as disabled.
+		case !active && managed.status == chanStatusPendingDisabled &&
+			now.Sub(managed.inactiveSince) >= m.cfg.DisableTimeout:
+
+			m.applyStatus(chanPoint, managed, chanStatusDisabled)
+		}
+	}
+}
+
+// markPeerInactive starts the grace period of all the enabled channels with
+// the passed peer at the given time.
+func (m *chanStatusManager) markPeerInactive(peer [33]byte, since time.Time) {
+	for _, managed := range m.chans {
+		if managed.manual || managed.peer != peer {
+			continue
+		}
+
+		if managed.status == chanStatusEnabled ||
+			managed.status == chanStatusUnknown {
+
+			managed.status = chanStatusPendingDisabled
+			managed.inactiveSince = since
+		}
+	}
+}
+
+// handleStatusRequest applies a manual status request.
+func (m *chanStatusManager) handleStatusRequest(req *chanStatusRequest) error {
+	// The channel may have been opened since we last sampled, so we'll
+	// refresh our set of channels if we don't know of it.
+	managed, ok := m.chans[req.chanPoint]
+	if !ok {
+		if err := m.refreshChannels(); err != nil {
+			return err
+		}
+
+		managed, ok = m.chans[req.chanPoint]
+		if !ok {
+			return fmt.Errorf("unable to find public channel %v",
+				req.chanPoint)
+		}
+	}
+
+	switch req.action {
+	case chanStatusActionEnable:
+		managed.manual = true
+		return m.applyStatus(req.chanPoint, managed, chanStatusEnabled)
+
+	case chanStatusActionDisable:
+		managed.manual = true
+		return m.applyStatus(req.chanPoint, managed, chanStatusDisabled)
+
+	case chanStatusActionAuto:
+		// The status of the channel will be brought in line with its
+		// link at the next sample.
+		managed.manual = false
+		return nil
+
+	default:
+		return fmt.Errorf("unknown channel status action: %v",
+			req.action)
+	}
+}
+
+// applyStatus advertises the passed status for the channel. The status of
+// the channel is only updated if the new update was successfully applied.
+func (m *chanStatusManager) applyStatus(chanPoint wire.OutPoint,
+	managed *managedChannel, status chanStatus) error {
+
+	err := m.cfg.ApplyChannelStatus(
+		managed.shortChanID, status == chanStatusDisabled,
+	)
+	switch {
+	// Until the channel has been announced, we have no policy to update,
+	// so we'll skip the channel and try again at the next sample.
+	case err == discovery.ErrNoOwnPolicy:
+		srvrLog.Debugf("Skipping channel(%v), as our policy isn't "+
+			"announced yet", chanPoint)
+		return err
+
+	case err != nil:
+		srvrLog.Errorf("Unable to announce channel(%v) as %v: %v",
+			chanPoint, status, err)
+		return err
+	}
+
+	srvrLog.Infof("Announced channel(%v) as %v", chanPoint, status)
+
+	managed.status = status
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestChanStatusManager tests that channels are only disabled once their
+// links have been inactive for the full grace period, that they're re-enabled
+// once their links are active again, that manual overrides are honoured, and
+// that channels without an announced policy of ours are skipped.
+func TestChanStatusManager(t *testing.T) {
+	t.Parallel()
+
+	const disableTimeout = 20 * time.Minute
+
+	peerKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	otherKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	newChannel := func(index uint32, peer *btcec.PublicKey,
+		flags lnwire.FundingFlag, pending bool) *channeldb.OpenChannel {
+
+		return &channeldb.OpenChannel{
+			FundingOutpoint: wire.OutPoint{Index: index},
+			ShortChanID:     lnwire.NewShortChanIDFromInt(uint64(index)),
+			IsPending:       pending,
+			ChannelFlags:    flags,
+			IdentityPub:     peer,
+		}
+	}
+
+	chan1 := newChannel(1, peerKey.PubKey(), lnwire.FFAnnounceChannel, false)
+	chan2 := newChannel(2, otherKey.PubKey(), lnwire.FFAnnounceChannel, false)
+	privateChan := newChannel(3, peerKey.PubKey(), 0, false)
+	pendingChan := newChannel(
+		4, peerKey.PubKey(), lnwire.FFAnnounceChannel, true,
+	)
+	unannouncedChan := newChannel(
+		5, otherKey.PubKey(), lnwire.FFAnnounceChannel, false,
+	)
+	channels := []*channeldb.OpenChannel{
+		chan1, chan2, privateChan, pendingChan, unannouncedChan,
+	}
+
+	active := make(map[lnwire.ChannelID]bool)
+	setActive := func(c *channeldb.OpenChannel, isActive bool) {
+		active[lnwire.NewChanIDFromOutPoint(&c.FundingOutpoint)] = isActive
+	}
+
+	// The advertised status of each channel, as updated by the manager.
+	disabled := make(map[lnwire.ShortChannelID]bool)
+	var numUpdates int
+
+	mgr := newChanStatusManager(&chanStatusConfig{
+		DisableTimeout: disableTimeout,
+		SampleInterval: time.Minute,
+		FetchChannels: func() ([]*channeldb.OpenChannel, error) {
+			return channels, nil
+		},
+		IsChannelActive: func(chanID lnwire.ChannelID) bool {
+			return active[chanID]
+		},
+		ApplyChannelStatus: func(chanID lnwire.ShortChannelID,
+			isDisabled bool) error {
+
+			if chanID == unannouncedChan.ShortChanID {
+				return discovery.ErrNoOwnPolicy
+			}
+
+			numUpdates++
+			disabled[chanID] = isDisabled
+			return nil
+		},
+	})
+
+	now := time.Unix(1000000, 0)
+	mgr.now = func() time.Time {
+		return now
+	}
+
+	assertStatus := func(c *channeldb.OpenChannel, expected chanStatus) {
+		t.Helper()
+
+		managed, ok := mgr.chans[c.FundingOutpoint]
+		if !ok {
+			t.Fatalf("channel %v isn't managed", c.FundingOutpoint)
+		}
+		if managed.status != expected {
+			t.Fatalf("expected status %v for channel %v, instead "+
+				"got %v", expected, c.FundingOutpoint,
+				managed.status)
+		}
+	}
+	assertDisabled := func(c *channeldb.OpenChannel, expected bool) {
+		t.Helper()
+
+		if disabled[c.ShortChanID] != expected {
+			t.Fatalf("expected channel %v to have disabled=%v",
+				c.FundingOutpoint, expected)
+		}
+	}
+
+	// Initially, the first channel is active while the second one isn't.
+	// Only public confirmed channels should be managed, and the active one
+	// should be enabled right away. The unannounced channel is active as
+	// well, but it should be skipped until our policy is announced.
+	setActive(chan1, true)
+	setActive(unannouncedChan, true)
+	mgr.sampleChannels()
+
+	if len(mgr.chans) != 3 {
+		t.Fatalf("expected 3 managed channels, instead got %v",
+			len(mgr.chans))
+	}
+	assertStatus(chan1, chanStatusEnabled)
+	assertDisabled(chan1, false)
+	assertStatus(chan2, chanStatusPendingDisabled)
+	assertStatus(unannouncedChan, chanStatusUnknown)
+
+	// Once the grace period has passed, the inactive channel should be
+	// disabled.
+	now = now.Add(disableTimeout)
+	mgr.sampleChannels()
+	assertStatus(chan2, chanStatusDisabled)
+	assertDisabled(chan2, true)
+
+	// When the peer of the first channel disconnects, its grace period
+	// should start at the time of the disconnect rather than at the next
+	// sample. Marking the peer must not block, even though the manager's
+	// goroutine isn't running.
+	setActive(chan1, false)
+	mgr.PeerDisconnected(peerKey.PubKey())
+	now = now.Add(disableTimeout / 2)
+	mgr.sampleChannels()
+	assertStatus(chan1, chanStatusPendingDisabled)
+
+	// If the link becomes active again within the grace period, the
+	// channel shouldn't be disabled.
+	setActive(chan1, true)
+	mgr.sampleChannels()
+	assertStatus(chan1, chanStatusEnabled)
+	assertDisabled(chan1, false)
+
+	// Otherwise, it should be disabled once the grace period has passed.
+	setActive(chan1, false)
+	mgr.PeerDisconnected(peerKey.PubKey())
+	now = now.Add(disableTimeout - time.Second)
+	mgr.sampleChannels()
+	assertStatus(chan1, chanStatusPendingDisabled)
+
+	now = now.Add(time.Second)
+	mgr.sampleChannels()
+	assertStatus(chan1, chanStatusDisabled)
+	assertDisabled(chan1, true)
+
+	// Once the link of the second channel is active again, it should be
+	// re-enabled.
+	setActive(chan2, true)
+	mgr.sampleChannels()
+	assertStatus(chan2, chanStatusEnabled)
+	assertDisabled(chan2, false)
+
+	// A channel that was manually disabled should stay disabled, even
+	// though its link is active.
+	req := &chanStatusRequest{
+		chanPoint: chan2.FundingOutpoint,
+		action:    chanStatusActionDisable,
+	}
+	if err := mgr.handleStatusRequest(req); err != nil {
+		t.Fatalf("unable to disable channel: %v", err)
+	}
+	mgr.sampleChannels()
+	assertStatus(chan2, chanStatusDisabled)
+	assertDisabled(chan2, true)
+
+	// Handing the channel back to the manager should re-enable it.
+	req.action = chanStatusActionAuto
+	if err := mgr.handleStatusRequest(req); err != nil {
+		t.Fatalf("unable to hand back channel: %v", err)
+	}
+	mgr.sampleChannels()
+	assertStatus(chan2, chanStatusEnabled)
+	assertDisabled(chan2, false)
+
+	// Requests for unmanaged channels should fail.
+	req.chanPoint = privateChan.FundingOutpoint
+	if err := mgr.handleStatusRequest(req); err == nil {
+		t.Fatalf("expected request for private channel to fail")
+	}
+
+	// Finally, closed channels should no longer be managed.
+	channels = []*channeldb.OpenChannel{chan2}
+	updatesBefore := numUpdates
+	mgr.sampleChannels()
+	if _, ok := mgr.chans[chan1.FundingOutpoint]; ok {
+		t.Fatalf("closed channel is still managed")
+	}
+	if numUpdates != updatesBefore {
+		t.Fatalf("unexpected status update after channel was closed")
+	}
+}
@@ -40,6 +40,28 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of the database where invoices carry an
+			// explicit state, and all open invoices are tracked
+			// within the pending invoice index.
+			number:    1,
+			migration: migrateInvoicePendingIndex,
+		},
+		{
+			// The version of the database where every invoice is
+			// assigned an add index, and every settled invoice a
+			// settle index, allowing invoice events to be queried
+			// as a time series.
+			number:    2,
+			migration: migrateInvoiceTimeSeries,
+		},
+		{
+			// The version of the database where all successful
+			// payments are tracked by the payment control tower,
+			// along with their HTLC attempts.
+			number:    3,
+			migration: migrateOutgoingPayments,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -31,10 +31,73 @@ var (
 	// payment hash already exists.
 	ErrDuplicateInvoice = fmt.Errorf("invoice with payment hash already exists")
 
+	// ErrInvoiceAlreadySettled is returned when the invoice is already
+	// settled.
+	ErrInvoiceAlreadySettled = fmt.Errorf("invoice already settled")
+
+	// ErrInvoiceAlreadyCanceled is returned when the invoice is already
+	// canceled.
+	ErrInvoiceAlreadyCanceled = fmt.Errorf("invoice already canceled")
+
+	// ErrInvoiceAlreadyAccepted is returned when an attempt is made to
+	// expire an invoice for which an HTLC has already been accepted.
+	ErrInvoiceAlreadyAccepted = fmt.Errorf("invoice already accepted")
+
+	// ErrInvoiceExpired is returned when the invoice has expired.
+	ErrInvoiceExpired = fmt.Errorf("invoice expired")
+
+	// ErrInvoiceNotAccepted is returned when a hold invoice is settled
+	// before an HTLC paying to it has been accepted.
+	ErrInvoiceNotAccepted = fmt.Errorf("invoice has no accepted htlc")
+
+	// ErrInvoicePreimageKnown is returned when an attempt is made to
+	// settle an invoice with an externally supplied preimage, while the
+	// preimage of the invoice is already known.
+	ErrInvoicePreimageKnown = fmt.Errorf("invoice preimage already known")
+
 	// ErrNoPaymentsCreated is returned when bucket of payments hasn't been
 	// created.
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
 
+	// ErrAlreadyPaid signals we have already paid this payment hash.
+	ErrAlreadyPaid = fmt.Errorf("invoice is already paid")
+
+	// ErrPaymentInFlight signals that a payment to this payment hash is
+	// already in flight.
+	ErrPaymentInFlight = fmt.Errorf("payment is in transition")
+
+	// ErrPaymentNotInitiated is returned if the payment wasn't initiated
+	// with the payment control tower.
+	ErrPaymentNotInitiated = fmt.Errorf("payment isn't initiated")
+
+	// ErrPaymentAlreadySucceeded is returned in the event we attempt to
+	// change the status of a payment that has already succeeded.
+	ErrPaymentAlreadySucceeded = fmt.Errorf("payment is already succeeded")
+
+	// ErrPaymentAlreadyFailed is returned in the event we attempt to make
+	// a new attempt for a payment that has already been failed.
+	ErrPaymentAlreadyFailed = fmt.Errorf("payment has already failed")
+
+	// ErrUnknownPaymentStatus is returned when we do not recognize the
+	// existing state of a payment.
+	ErrUnknownPaymentStatus = fmt.Errorf("unknown payment status")
+
+	// ErrNoSequenceNumber is returned if we look up a payment which does
+	// not have a sequence number.
+	ErrNoSequenceNumber = fmt.Errorf("sequence number not found")
+
+	// ErrAttemptNotFound is returned when a targeted HTLC attempt of a
+	// payment can't be found.
+	ErrAttemptNotFound = fmt.Errorf("htlc attempt not found")
+
+	// ErrAttemptAlreadyExists is returned when an HTLC attempt is
+	// registered with an attempt ID that is already in use.
+	ErrAttemptAlreadyExists = fmt.Errorf("htlc attempt already exists")
+
+	// ErrAttemptAlreadyResolved is returned when an attempt is made to
+	// settle or fail an HTLC attempt that has already been resolved.
+	ErrAttemptAlreadyResolved = fmt.Errorf("htlc attempt already resolved")
+
 	// ErrNodeNotFound is returned when node bucket exists, but node with
 	// specific identity can't be found.
 	ErrNodeNotFound = fmt.Errorf("link node with target identity not found")
@@ -75,6 +138,10 @@ var (
 	// channel id can't be added because it already exist.
 	ErrEdgeAlreadyExist = fmt.Errorf("edge already exist")
 
+	// ErrZombieEdgeNotFound is returned when an edge that's expected to be
+	// marked as a zombie isn't within the zombie index.
+	ErrZombieEdgeNotFound = fmt.Errorf("zombie edge not found")
+
 	// ErrNodeAliasNotFound is returned when alias for node can't be found.
 	ErrNodeAliasNotFound = fmt.Errorf("alias for node not found")
 
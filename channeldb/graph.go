@@ -82,6 +82,16 @@ var (
 	// maps: outPoint -> chanID
 	channelPointBucket = []byte("chan-index")
 
+	// zombieBucket is an index of all the channels within the graph that
+	// are considered zombies, as neither of their nodes has updated their
+	// policy in a long time. The edges of a zombie channel are retained,
+	// so it can be resurrected once a fresh update for it arrives, but
+	// they're omitted from all traversals of the graph. This bucket
+	// resides within the edgeBucket above.
+	//
+	// maps: chanID -> pubKey1 || pubKey2
+	zombieBucket = []byte("zombie-index")
+
 	// graphMetaBucket is a top-level bucket which stores various meta-deta
 	// related to the on-disk channel graph. Data stored in this bucket
 	// includes the block to which the graph has been synced to, the total
@@ -161,8 +171,13 @@ func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdgeInfo, *ChannelEdgePoli
 
 		// For each edge pair within the edge index, we fetch each edge
 		// itself and also the node information in order to fully
-		// populated the object.
+		// populated the object. Zombie channels are skipped, as
+		// they're no longer considered part of the graph.
 		return edgeIndex.ForEach(func(chanID, edgeInfoBytes []byte) error {
+			if isZombieEdge(edges, chanID) {
+				return nil
+			}
+
 			infoReader := bytes.NewReader(edgeInfoBytes)
 			edgeInfo, err := deserializeChanEdgeInfo(infoReader)
 			if err != nil {
@@ -917,6 +932,14 @@ func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
 		}
 	}
 
+	// If the channel was marked as a zombie, then it no longer needs
+	// to be tracked as one once it's been deleted.
+	if zombieIndex := edges.Bucket(zombieBucket); zombieIndex != nil {
+		if err := zombieIndex.Delete(chanID); err != nil {
+			return err
+		}
+	}
+
 	// Finally, with the edge data deleted, we can purge the
 	// information from the two edge indexes.
 	if err := edgeIndex.Delete(chanID); err != nil {
@@ -1186,6 +1209,13 @@ func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
 		// another node's edges, so we can terminate our scan.
 		edgeCursor := edges.Cursor()
 		for nodeEdge, edgeInfo := edgeCursor.Seek(nodeStart[:]); bytes.HasPrefix(nodeEdge, nodePub); nodeEdge, edgeInfo = edgeCursor.Next() {
+			// Zombie channels are no longer considered part of
+			// the graph, so we'll skip them.
+			chanID := nodeEdge[33:]
+			if isZombieEdge(edges, chanID) {
+				continue
+			}
+
 			// If the prefix still matches, then the value is the
 			// raw edge information. So we can now serialize the
 			// edge info and fetch the outgoing node in order to
@@ -1198,7 +1228,6 @@ func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
 			toEdgePolicy.db = l.db
 			toEdgePolicy.Node.db = l.db
 
-			chanID := nodeEdge[33:]
 			edgeInfo, err := fetchChanEdgeInfo(edgeIndex, chanID)
 			if err != nil {
 				return err
@@ -1771,6 +1800,448 @@ func (c *ChannelGraph) ChannelView() ([]wire.OutPoint, error) {
 	return chanPoints, nil
 }
 
+// ChannelEdge represents the complete set of information for a channel edge
+// in the known channel graph. This struct couples the core information of the
+// edge as well as each of the known advertised edge policies.
+type ChannelEdge struct {
+	// Info contains all the static information describing the channel.
+	Info *ChannelEdgeInfo
+
+	// Policy1 points to the "first" edge policy of the channel containing
+	// the dynamic information required to properly route through the edge.
+	Policy1 *ChannelEdgePolicy
+
+	// Policy2 points to the "second" edge policy of the channel containing
+	// the dynamic information required to properly route through the edge.
+	Policy2 *ChannelEdgePolicy
+}
+
+// HighestChanID returns the "highest" known channel ID in the channel graph.
+// This represents the "newest" channel from the PoV of the chain. This method
+// can be used by peers to quickly determine if they're graphs are in sync. If
+// the graph doesn't contain any channels, then zero is returned.
+func (c *ChannelGraph) HighestChanID() (uint64, error) {
+	var cid uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// As the keys of the edge index are the big-endian encoded
+		// channel ID's, the last key within the index is the highest
+		// channel ID we know of.
+		lastChanID, _ := edgeIndex.Cursor().Last()
+		if lastChanID == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cid = byteOrder.Uint64(lastChanID)
+		return nil
+	})
+	if err != nil && err != ErrGraphNoEdgesFound {
+		return 0, err
+	}
+
+	return cid, nil
+}
+
+// ChanUpdatesInHorizon returns all the known channel edges which have at
+// least one edge that has an update timestamp within the specified horizon.
+// Only channels that have been publicly announced, and thus have a complete
+// authentication proof, are returned.
+//
+// NOTE: There is no index of the edges by their update time, so this requires
+// a traversal of the entire set of channels within the graph.
+func (c *ChannelGraph) ChanUpdatesInHorizon(startTime,
+	endTime time.Time) ([]ChannelEdge, error) {
+
+	inHorizon := func(policy *ChannelEdgePolicy) bool {
+		if policy == nil {
+			return false
+		}
+
+		return !policy.LastUpdate.Before(startTime) &&
+			!policy.LastUpdate.After(endTime)
+	}
+
+	var edgesInHorizon []ChannelEdge
+	err := c.ForEachChannel(func(info *ChannelEdgeInfo,
+		policy1, policy2 *ChannelEdgePolicy) error {
+
+		if info.AuthProof == nil {
+			return nil
+		}
+
+		if !inHorizon(policy1) && !inHorizon(policy2) {
+			return nil
+		}
+
+		edgesInHorizon = append(edgesInHorizon, ChannelEdge{
+			Info:    info,
+			Policy1: policy1,
+			Policy2: policy2,
+		})
+
+		return nil
+	})
+	switch {
+	case err == ErrGraphNoEdgesFound:
+		fallthrough
+	case err == ErrGraphNotFound:
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return edgesInHorizon, nil
+}
+
+// NodeUpdatesInHorizon returns all the known lightning node which have an
+// update timestamp within the passed range. Only nodes for which we've
+// received a node announcement are returned.
+//
+// NOTE: There is no index of the nodes by their update time, so this requires
+// a traversal of the entire set of nodes within the graph.
+func (c *ChannelGraph) NodeUpdatesInHorizon(startTime,
+	endTime time.Time) ([]LightningNode, error) {
+
+	var nodesInHorizon []LightningNode
+	err := c.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+
+		if node.LastUpdate.Before(startTime) ||
+			node.LastUpdate.After(endTime) {
+
+			return nil
+		}
+
+		nodesInHorizon = append(nodesInHorizon, *node)
+		return nil
+	})
+	switch {
+	case err == ErrGraphNotFound:
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return nodesInHorizon, nil
+}
+
+// FilterKnownChanIDs takes a set of channel IDs and return the subset of chan
+// ID's that we don't know of in the passed set. In other words, we perform a
+// set difference of our set of chan ID's and the ones passed in. This method
+// can be used by callers to determine the set of channels another peer knows
+// of that we don't.
+func (c *ChannelGraph) FilterKnownChanIDs(chanIDs []uint64) ([]uint64, error) {
+	var newChanIDs []uint64
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		// We'll run through the set of chanIDs and collate only the
+		// set of channel that are unable to be found within our db.
+		var cidBytes [8]byte
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			if v := edgeIndex.Get(cidBytes[:]); v == nil {
+				newChanIDs = append(newChanIDs, cid)
+			}
+		}
+
+		return nil
+	})
+	switch {
+	// If we don't know of any edges yet, then we'll return the entire set
+	// of chan IDs specified.
+	case err == ErrGraphNoEdgesFound:
+		return chanIDs, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return newChanIDs, nil
+}
+
+// FilterChannelRange returns the channel ID's of all known channels which
+// were mined in a block height within the passed range. This method can be
+// used to quickly share with a peer the set of channels we know of within a
+// particular range to catch them up after a period of time offline.
+func (c *ChannelGraph) FilterChannelRange(startHeight,
+	endHeight uint32) ([]uint64, error) {
+
+	var chanIDs []uint64
+
+	startChanID := &lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+	}
+
+	endChanID := lnwire.ShortChannelID{
+		BlockHeight: endHeight,
+		TxIndex:     math.MaxUint32 & 0x00ffffff,
+		TxPosition:  math.MaxUint16,
+	}
+
+	// As we need to perform a range scan, we'll convert the starting and
+	// ending height to their corresponding values when encoded using short
+	// channel ID's.
+	var chanIDStart, chanIDEnd [8]byte
+	byteOrder.PutUint64(chanIDStart[:], startChanID.ToUint64())
+	byteOrder.PutUint64(chanIDEnd[:], endChanID.ToUint64())
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		cursor := edgeIndex.Cursor()
+
+		// We'll now iterate through the database, and find each
+		// channel ID that resides within the specified range. Zombie
+		// channels are skipped, as we don't want to advertise them.
+		for k, _ := cursor.Seek(chanIDStart[:]); k != nil &&
+			bytes.Compare(k, chanIDEnd[:]) <= 0; k, _ = cursor.Next() {
+
+			if isZombieEdge(edges, k) {
+				continue
+			}
+
+			chanIDs = append(chanIDs, byteOrder.Uint64(k))
+		}
+
+		return nil
+	})
+	switch {
+	// If we don't know of any channels yet, then there's nothing to
+	// filter, so we'll return an empty slice.
+	case err == ErrGraphNoEdgesFound:
+		return chanIDs, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// FetchChanInfos returns the set of channel edges that correspond to the
+// passed channel ID's. If an edge in the query is unknown to the database, it
+// will be skipped and the result will contain only those edges that exist at
+// the time of the query. This can be used to respond to peer queries that are
+// seeking to fill in gaps in their view of the channel graph.
+func (c *ChannelGraph) FetchChanInfos(chanIDs []uint64) ([]ChannelEdge, error) {
+	var chanEdges []ChannelEdge
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrGraphNoEdgesFound
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return ErrGraphNoEdgesFound
+		}
+
+		var cidBytes [8]byte
+		for _, cid := range chanIDs {
+			byteOrder.PutUint64(cidBytes[:], cid)
+
+			// Zombie channels are no longer considered part of
+			// the graph, so we won't hand them out.
+			if isZombieEdge(edges, cidBytes[:]) {
+				continue
+			}
+
+			// First, we'll fetch the static edge information. If
+			// the edge is unknown, we will skip the edge and
+			// continue gathering all known edges.
+			edgeInfo, err := fetchChanEdgeInfo(
+				edgeIndex, cidBytes[:],
+			)
+			switch {
+			case err == ErrEdgeNotFound:
+				continue
+			case err != nil:
+				return err
+			}
+
+			// With the static information obtained, we'll now
+			// fetch the dynamic policy info.
+			edge1, edge2, err := fetchChanEdgePolicies(
+				edgeIndex, edges, nodes, cidBytes[:], c.db,
+			)
+			if err != nil {
+				return err
+			}
+
+			chanEdges = append(chanEdges, ChannelEdge{
+				Info:    &edgeInfo,
+				Policy1: edge1,
+				Policy2: edge2,
+			})
+		}
+
+		return nil
+	})
+	switch {
+	case err == ErrGraphNoEdgesFound:
+		fallthrough
+	case err == ErrGraphNotFound:
+		return nil, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	return chanEdges, nil
+}
+
+// MarkEdgeZombie marks the channel with the passed ID as a zombie. The edges
+// of a zombie channel are retained within the database, but the channel is
+// omitted from all traversals of the graph until it's marked as live again.
+// If the channel is unknown, then ErrEdgeNotFound is returned.
+func (c *ChannelGraph) MarkEdgeZombie(chanID uint64) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		if err != nil {
+			return err
+		}
+		edgeIndex, err := edges.CreateBucketIfNotExists(edgeIndexBucket)
+		if err != nil {
+			return err
+		}
+		zombieIndex, err := edges.CreateBucketIfNotExists(zombieBucket)
+		if err != nil {
+			return err
+		}
+
+		var cidBytes [8]byte
+		byteOrder.PutUint64(cidBytes[:], chanID)
+
+		// The edge index maps the channel ID to the public keys of
+		// both of its nodes, followed by the rest of the edge info.
+		edgeInfo := edgeIndex.Get(cidBytes[:])
+		if edgeInfo == nil {
+			return ErrEdgeNotFound
+		}
+
+		return zombieIndex.Put(cidBytes[:], edgeInfo[:66])
+	})
+}
+
+// MarkEdgeLive removes the channel with the passed ID from the zombie index,
+// which makes it part of the graph once again. If the channel isn't a zombie,
+// then ErrZombieEdgeNotFound is returned.
+func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return ErrZombieEdgeNotFound
+		}
+		zombieIndex := edges.Bucket(zombieBucket)
+		if zombieIndex == nil {
+			return ErrZombieEdgeNotFound
+		}
+
+		var cidBytes [8]byte
+		byteOrder.PutUint64(cidBytes[:], chanID)
+
+		if zombieIndex.Get(cidBytes[:]) == nil {
+			return ErrZombieEdgeNotFound
+		}
+
+		return zombieIndex.Delete(cidBytes[:])
+	})
+}
+
+// IsZombieEdge returns true if the channel with the passed ID has been marked
+// as a zombie.
+func (c *ChannelGraph) IsZombieEdge(chanID uint64) (bool, error) {
+	var isZombie bool
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+
+		var cidBytes [8]byte
+		byteOrder.PutUint64(cidBytes[:], chanID)
+
+		isZombie = isZombieEdge(edges, cidBytes[:])
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isZombie, nil
+}
+
+// NumZombies returns the number of channels within the graph that are
+// currently marked as zombies.
+func (c *ChannelGraph) NumZombies() (uint64, error) {
+	var numZombies uint64
+	err := c.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		zombieIndex := edges.Bucket(zombieBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		return zombieIndex.ForEach(func(_, _ []byte) error {
+			numZombies++
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numZombies, nil
+}
+
+// isZombieEdge returns true if the channel with the passed serialized ID is
+// present within the zombie index nested in the passed edge bucket.
+func isZombieEdge(edges *bolt.Bucket, chanID []byte) bool {
+	zombieIndex := edges.Bucket(zombieBucket)
+	if zombieIndex == nil {
+		return false
+	}
+
+	return zombieIndex.Get(chanID) != nil
+}
+
 // NewChannelEdgePolicy returns a new blank ChannelEdgePolicy.
 func (c *ChannelGraph) NewChannelEdgePolicy() *ChannelEdgePolicy {
 	return &ChannelEdgePolicy{db: c.db}
@@ -1164,6 +1164,194 @@ func TestGraphPruning(t *testing.T) {
 	}
 }
 
+// createChannelEdge creates a new announced channel edge between the two
+// passed nodes, which was mined at the given location within the chain.
+func createChannelEdge(node1, node2 *LightningNode, height, txIndex uint32,
+	txPosition uint16, outPointIndex uint32) ChannelEdgeInfo {
+
+	shortChanID := lnwire.ShortChannelID{
+		BlockHeight: height,
+		TxIndex:     txIndex,
+		TxPosition:  txPosition,
+	}
+	outpoint := wire.OutPoint{
+		Hash:  rev,
+		Index: outPointIndex,
+	}
+
+	edgeInfo := ChannelEdgeInfo{
+		ChannelID: shortChanID.ToUint64(),
+		ChainHash: key,
+		AuthProof: &ChannelAuthProof{
+			NodeSig1Bytes:    testSig.Serialize(),
+			NodeSig2Bytes:    testSig.Serialize(),
+			BitcoinSig1Bytes: testSig.Serialize(),
+			BitcoinSig2Bytes: testSig.Serialize(),
+		},
+		ChannelPoint: outpoint,
+		Capacity:     9000,
+	}
+
+	edgeInfo.NodeKey1Bytes = node1.PubKeyBytes
+	edgeInfo.NodeKey2Bytes = node2.PubKeyBytes
+	edgeInfo.BitcoinKey1Bytes = node1.PubKeyBytes
+	edgeInfo.BitcoinKey2Bytes = node2.PubKeyBytes
+
+	return edgeInfo
+}
+
+// TestChannelRangeQueries tests that the set of methods used to answer the
+// gossip queries of our peers properly reflect the set of known channels.
+func TestChannelRangeQueries(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// With an empty graph, the highest channel ID should be zero, and all
+	// channel ID's should be reported as unknown.
+	highestChanID, err := graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != 0 {
+		t.Fatalf("expected zero chan ID, instead got %v",
+			highestChanID)
+	}
+	queryChanIDs := []uint64{1, 2, 3}
+	newChanIDs, err := graph.FilterKnownChanIDs(queryChanIDs)
+	if err != nil {
+		t.Fatalf("unable to filter chan IDs: %v", err)
+	}
+	if !reflect.DeepEqual(newChanIDs, queryChanIDs) {
+		t.Fatalf("expected all chan IDs to be unknown, instead got %v",
+			newChanIDs)
+	}
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node1); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node2); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	// We'll now add a series of channels, each mined at a distinct block
+	// height. The last one uses the maximum tx index and position in order
+	// to ensure the range scans cover the entire block.
+	const numChans = 10
+	var chanIDs []uint64
+	for i := uint32(0); i < numChans; i++ {
+		var (
+			txIndex    uint32
+			txPosition uint16
+		)
+		if i == numChans-1 {
+			txIndex = math.MaxUint32 & 0x00ffffff
+			txPosition = math.MaxUint16
+		}
+
+		edgeInfo := createChannelEdge(
+			node1, node2, 100+i, txIndex, txPosition, i,
+		)
+		if err := graph.AddChannelEdge(&edgeInfo); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		chanIDs = append(chanIDs, edgeInfo.ChannelID)
+	}
+
+	// The highest chan ID should now be that of the last channel added.
+	highestChanID, err = graph.HighestChanID()
+	if err != nil {
+		t.Fatalf("unable to fetch highest chan ID: %v", err)
+	}
+	if highestChanID != chanIDs[numChans-1] {
+		t.Fatalf("expected highest chan ID %v, instead got %v",
+			chanIDs[numChans-1], highestChanID)
+	}
+
+	// Querying for a range of block heights should only return the
+	// channels within the range, including both of its ends.
+	rangeTests := []struct {
+		startHeight uint32
+		endHeight   uint32
+		chanIDs     []uint64
+	}{
+		{
+			startHeight: 0,
+			endHeight:   99,
+			chanIDs:     nil,
+		},
+		{
+			startHeight: 102,
+			endHeight:   104,
+			chanIDs:     chanIDs[2:5],
+		},
+		{
+			startHeight: 105,
+			endHeight:   math.MaxUint32 & 0x00ffffff,
+			chanIDs:     chanIDs[5:],
+		},
+	}
+	for i, test := range rangeTests {
+		resp, err := graph.FilterChannelRange(
+			test.startHeight, test.endHeight,
+		)
+		if err != nil {
+			t.Fatalf("unable to filter chan range: %v", err)
+		}
+		if !reflect.DeepEqual(resp, test.chanIDs) {
+			t.Fatalf("test #%v: expected chan IDs %v, instead "+
+				"got %v", i, test.chanIDs, resp)
+		}
+	}
+
+	// Filtering a mix of known and unknown channel ID's should only
+	// return the unknown ones.
+	unknownChanIDs := []uint64{
+		chanIDs[numChans-1] + 1, chanIDs[numChans-1] + 2,
+	}
+	queryChanIDs = append(chanIDs[:3:3], unknownChanIDs...)
+	newChanIDs, err = graph.FilterKnownChanIDs(queryChanIDs)
+	if err != nil {
+		t.Fatalf("unable to filter chan IDs: %v", err)
+	}
+	if !reflect.DeepEqual(newChanIDs, unknownChanIDs) {
+		t.Fatalf("expected chan IDs %v, instead got %v",
+			unknownChanIDs, newChanIDs)
+	}
+
+	// Finally, fetching the channel infos for the same query should skip
+	// the unknown channels, and return the remaining in order.
+	chanEdges, err := graph.FetchChanInfos(queryChanIDs)
+	if err != nil {
+		t.Fatalf("unable to fetch chan infos: %v", err)
+	}
+	if len(chanEdges) != 3 {
+		t.Fatalf("expected 3 chan infos, instead got %v",
+			len(chanEdges))
+	}
+	for i, chanEdge := range chanEdges {
+		if chanEdge.Info.ChannelID != chanIDs[i] {
+			t.Fatalf("expected chan ID %v, instead got %v",
+				chanIDs[i], chanEdge.Info.ChannelID)
+		}
+	}
+}
+
 // compareNodes is used to compare two LightningNodes while excluding the
 // Features struct, which cannot be compared as the semantics for reserializing
 // the featuresMap have not been defined.
@@ -1241,3 +1429,169 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 	}
 	return nil
 }
+
+// TestGraphZombieIndex tests that channels marked as zombies are omitted from
+// the traversals of the graph, and that they can be brought back to life.
+func TestGraphZombieIndex(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	// Marking an unknown channel as a zombie should fail.
+	if err := graph.MarkEdgeZombie(1); err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, instead got %v", err)
+	}
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node1); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	if err := graph.AddLightningNode(node2); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	edge := createChannelEdge(node1, node2, 100, 0, 0, 0)
+	if err := graph.AddChannelEdge(&edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+	chanID := edge.ChannelID
+
+	// countChans returns the number of channels visited by a traversal of
+	// the entire graph, and of the channels of the first node.
+	countChans := func() (int, int) {
+		var numChans, numNodeChans int
+		err := graph.ForEachChannel(func(*ChannelEdgeInfo,
+			*ChannelEdgePolicy, *ChannelEdgePolicy) error {
+
+			numChans++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse graph: %v", err)
+		}
+
+		err = node1.ForEachChannel(nil, func(*bolt.Tx,
+			*ChannelEdgeInfo, *ChannelEdgePolicy,
+			*ChannelEdgePolicy) error {
+
+			numNodeChans++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse node channels: %v", err)
+		}
+
+		return numChans, numNodeChans
+	}
+
+	// Add a policy for the first node, so the channel is visited when
+	// traversing its channels.
+	policy := randEdgePolicy(chanID, edge.ChannelPoint, db)
+	policy.Flags = 0
+	if err := graph.UpdateEdgePolicy(policy); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+
+	if numChans, numNodeChans := countChans(); numChans != 1 ||
+		numNodeChans != 1 {
+
+		t.Fatalf("expected channel to be visited, instead got %v "+
+			"and %v channels", numChans, numNodeChans)
+	}
+
+	// Now, we'll mark the channel as a zombie. It should no longer be
+	// visited by any traversal, or handed out in response to queries,
+	// although it should still be known.
+	if err := graph.MarkEdgeZombie(chanID); err != nil {
+		t.Fatalf("unable to mark edge as zombie: %v", err)
+	}
+	isZombie, err := graph.IsZombieEdge(chanID)
+	if err != nil {
+		t.Fatalf("unable to query zombie index: %v", err)
+	}
+	if !isZombie {
+		t.Fatalf("expected channel to be a zombie")
+	}
+	numZombies, err := graph.NumZombies()
+	if err != nil {
+		t.Fatalf("unable to count zombies: %v", err)
+	}
+	if numZombies != 1 {
+		t.Fatalf("expected 1 zombie, instead got %v", numZombies)
+	}
+
+	if numChans, numNodeChans := countChans(); numChans != 0 ||
+		numNodeChans != 0 {
+
+		t.Fatalf("expected zombie to be skipped, instead got %v "+
+			"and %v channels", numChans, numNodeChans)
+	}
+	chanIDs, err := graph.FilterChannelRange(0, math.MaxUint32)
+	if err != nil {
+		t.Fatalf("unable to filter channel range: %v", err)
+	}
+	if len(chanIDs) != 0 {
+		t.Fatalf("expected no channels in range, instead got %v",
+			chanIDs)
+	}
+	chanInfos, err := graph.FetchChanInfos([]uint64{chanID})
+	if err != nil {
+		t.Fatalf("unable to fetch chan infos: %v", err)
+	}
+	if len(chanInfos) != 0 {
+		t.Fatalf("expected no chan infos, instead got %v",
+			len(chanInfos))
+	}
+	newChanIDs, err := graph.FilterKnownChanIDs([]uint64{chanID})
+	if err != nil {
+		t.Fatalf("unable to filter chan IDs: %v", err)
+	}
+	if len(newChanIDs) != 0 {
+		t.Fatalf("expected zombie to be known, instead got %v",
+			newChanIDs)
+	}
+
+	// Bringing the channel back to life should make it visible once
+	// again.
+	if err := graph.MarkEdgeLive(chanID); err != nil {
+		t.Fatalf("unable to mark edge as live: %v", err)
+	}
+	if err := graph.MarkEdgeLive(chanID); err != ErrZombieEdgeNotFound {
+		t.Fatalf("expected ErrZombieEdgeNotFound, instead got %v", err)
+	}
+	if numChans, numNodeChans := countChans(); numChans != 1 ||
+		numNodeChans != 1 {
+
+		t.Fatalf("expected channel to be visited, instead got %v "+
+			"and %v channels", numChans, numNodeChans)
+	}
+
+	// Finally, deleting a zombie channel should remove it from the zombie
+	// index as well.
+	if err := graph.MarkEdgeZombie(chanID); err != nil {
+		t.Fatalf("unable to mark edge as zombie: %v", err)
+	}
+	if err := graph.DeleteChannelEdge(&edge.ChannelPoint); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	isZombie, err = graph.IsZombieEdge(chanID)
+	if err != nil {
+		t.Fatalf("unable to query zombie index: %v", err)
+	}
+	if isZombie {
+		t.Fatalf("expected deleted channel to no longer be a zombie")
+	}
+}
@@ -70,14 +70,14 @@ func TestInvoiceWorkflow(t *testing.T) {
 	// Add the invoice to the database, this should succeed as there aren't
 	// any existing invoices within the database with the same payment
 	// hash.
-	if err := db.AddInvoice(fakeInvoice); err != nil {
+	paymentHash := sha256.Sum256(fakeInvoice.Terms.PaymentPreimage[:])
+	if err := db.AddInvoice(fakeInvoice, paymentHash); err != nil {
 		t.Fatalf("unable to find invoice: %v", err)
 	}
 
 	// Attempt to retrieve the invoice which was just added to the
 	// database. It should be found, and the invoice returned should be
 	// identical to the one created above.
-	paymentHash := sha256.Sum256(fakeInvoice.Terms.PaymentPreimage[:])
 	dbInvoice, err := db.LookupInvoice(paymentHash)
 	if err != nil {
 		t.Fatalf("unable to find invoice: %v", err)
@@ -88,7 +88,7 @@ func TestInvoiceWorkflow(t *testing.T) {
 	}
 
 	// Settle the invoice, the version retrieved from the database should
-	// now have the settled bit toggle to true and a non-default
+	// now be in the settled state and have a non-default
 	// SettledDate
 	if err := db.SettleInvoice(paymentHash); err != nil {
 		t.Fatalf("unable to settle invoice: %v", err)
@@ -97,7 +97,7 @@ func TestInvoiceWorkflow(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch invoice: %v", err)
 	}
-	if !dbInvoice2.Terms.Settled {
+	if dbInvoice2.Terms.State != ContractSettled {
 		t.Fatalf("invoice should now be settled but isn't")
 	}
 
@@ -107,7 +107,8 @@ func TestInvoiceWorkflow(t *testing.T) {
 
 	// Attempt to insert generated above again, this should fail as
 	// duplicates are rejected by the processing logic.
-	if err := db.AddInvoice(fakeInvoice); err != ErrDuplicateInvoice {
+	err = db.AddInvoice(fakeInvoice, paymentHash)
+	if err != ErrDuplicateInvoice {
 		t.Fatalf("invoice insertion should fail due to duplication, "+
 			"instead %v", err)
 	}
@@ -130,7 +131,8 @@ func TestInvoiceWorkflow(t *testing.T) {
 			t.Fatalf("unable to create invoice: %v", err)
 		}
 
-		if err := db.AddInvoice(invoice); err != nil {
+		paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		if err := db.AddInvoice(invoice, paymentHash); err != nil {
 			t.Fatalf("unable to add invoice %v", err)
 		}
 
@@ -155,3 +157,481 @@ func TestInvoiceWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// TestHoldInvoiceWorkflow tests that an invoice added with only a payment hash
+// can be accepted, and then either settled with the externally supplied
+// preimage or canceled.
+func TestHoldInvoiceWorkflow(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Create two hold invoices, for which only the payment hash is known
+	// at the time they're added.
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	var preimages [2][32]byte
+	var hashes [2][32]byte
+	for i := range preimages {
+		invoice, err := randInvoice(amt)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		preimages[i] = invoice.Terms.PaymentPreimage
+		hashes[i] = sha256.Sum256(preimages[i][:])
+
+		invoice.Terms.PaymentPreimage = UnknownPreimage
+		if err := db.AddInvoice(invoice, hashes[i]); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+	}
+
+	// The invoice can't be settled before an HTLC paying to it has been
+	// accepted.
+	if _, err := db.SettleHoldInvoice(preimages[0]); err != ErrInvoiceNotAccepted {
+		t.Fatalf("expected ErrInvoiceNotAccepted, got %v", err)
+	}
+
+	// Accept the first invoice, doing so twice should be a noop.
+	for i := 0; i < 2; i++ {
+		invoice, err := db.AcceptInvoice(hashes[0])
+		if err != nil {
+			t.Fatalf("unable to accept invoice: %v", err)
+		}
+		if invoice.Terms.State != ContractAccepted {
+			t.Fatalf("expected invoice to be accepted, is %v",
+				invoice.Terms.State)
+		}
+	}
+
+	// Settling the invoice should now store the preimage along with it.
+	if _, err := db.SettleHoldInvoice(preimages[0]); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	invoice, err := db.LookupInvoice(hashes[0])
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if invoice.Terms.State != ContractSettled {
+		t.Fatalf("expected invoice to be settled, is %v",
+			invoice.Terms.State)
+	}
+	if invoice.Terms.PaymentPreimage != preimages[0] {
+		t.Fatalf("expected preimage %x, got %x", preimages[0],
+			invoice.Terms.PaymentPreimage)
+	}
+	if invoice.SettleDate.IsZero() {
+		t.Fatalf("invoice should have non-zero SettledDate but isn't")
+	}
+
+	// A settled invoice can neither be accepted nor canceled.
+	if _, err := db.AcceptInvoice(hashes[0]); err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+	if _, err := db.CancelInvoice(hashes[0]); err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+
+	// Cancel the second invoice, after which it can no longer be accepted
+	// nor settled.
+	if _, err := db.AcceptInvoice(hashes[1]); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	invoice, err = db.CancelInvoice(hashes[1])
+	if err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	if invoice.Terms.State != ContractCanceled {
+		t.Fatalf("expected invoice to be canceled, is %v",
+			invoice.Terms.State)
+	}
+	if _, err := db.AcceptInvoice(hashes[1]); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+	if _, err := db.SettleHoldInvoice(preimages[1]); err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+
+	// Neither of the invoices should be returned as pending.
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(pending))
+	}
+}
+
+// TestExpireInvoice tests that open invoices can be expired, after which they
+// can no longer be paid, and that invoices for which an HTLC has been accepted
+// can't be expired.
+func TestExpireInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	var hashes [2][32]byte
+	for i := range hashes {
+		invoice, err := randInvoice(amt)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		hashes[i] = sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+		invoice.Terms.PaymentPreimage = UnknownPreimage
+		if err := db.AddInvoice(invoice, hashes[i]); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+	}
+
+	// Both invoices should initially be pending.
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending invoices, got %v", len(pending))
+	}
+
+	// Expire the first invoice, doing so twice should be a noop.
+	for i := 0; i < 2; i++ {
+		invoice, err := db.ExpireInvoice(hashes[0])
+		if err != nil {
+			t.Fatalf("unable to expire invoice: %v", err)
+		}
+		if invoice.Terms.State != ContractExpired {
+			t.Fatalf("expected invoice to be expired, is %v",
+				invoice.Terms.State)
+		}
+	}
+
+	// An expired invoice can no longer be accepted, settled or canceled.
+	if _, err := db.AcceptInvoice(hashes[0]); err != ErrInvoiceExpired {
+		t.Fatalf("expected ErrInvoiceExpired, got %v", err)
+	}
+	if err := db.SettleInvoice(hashes[0]); err != ErrInvoiceExpired {
+		t.Fatalf("expected ErrInvoiceExpired, got %v", err)
+	}
+	if _, err := db.CancelInvoice(hashes[0]); err != ErrInvoiceExpired {
+		t.Fatalf("expected ErrInvoiceExpired, got %v", err)
+	}
+
+	// Once an HTLC paying to the second invoice has been accepted, it
+	// should no longer expire.
+	if _, err := db.AcceptInvoice(hashes[1]); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	if _, err := db.ExpireInvoice(hashes[1]); err != ErrInvoiceAlreadyAccepted {
+		t.Fatalf("expected ErrInvoiceAlreadyAccepted, got %v", err)
+	}
+
+	// Only the accepted invoice should still be pending.
+	pending, err = db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Terms.State != ContractAccepted {
+		t.Fatalf("expected accepted invoice to be pending, got %v",
+			spew.Sdump(pending))
+	}
+}
+
+// TestInvoiceTimeSeries tests that newly added invoices and newly settled
+// invoices are assigned increasing add and settle indexes, and that the
+// invoices added or settled since a particular index can be queried.
+func TestInvoiceTimeSeries(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Querying the indexes before any invoices have been added should
+	// return no invoices.
+	added, err := db.InvoicesAddedSince(1)
+	if err != nil {
+		t.Fatalf("unable to query add index: %v", err)
+	}
+	if len(added) != 0 {
+		t.Fatalf("expected no invoices, got %v", len(added))
+	}
+
+	// We'll start by adding a set of invoices, each of which should be
+	// assigned the next add index.
+	const numInvoices = 10
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	hashes := make([][32]byte, numInvoices)
+	for i := 0; i < numInvoices; i++ {
+		invoice, err := randInvoice(amt)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+
+		hashes[i] = sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		if err := db.AddInvoice(invoice, hashes[i]); err != nil {
+			t.Fatalf("unable to add invoice %v", err)
+		}
+
+		if invoice.AddIndex != uint64(i+1) {
+			t.Fatalf("expected add index %v, got %v", i+1,
+				invoice.AddIndex)
+		}
+	}
+
+	// A zero add index signals that the caller isn't interested in any
+	// past invoices.
+	added, err = db.InvoicesAddedSince(0)
+	if err != nil {
+		t.Fatalf("unable to query add index: %v", err)
+	}
+	if len(added) != 0 {
+		t.Fatalf("expected no invoices, got %v", len(added))
+	}
+
+	// Otherwise, only the invoices with a greater add index should be
+	// returned, in the order they were added.
+	const sinceAddIndex = 4
+	added, err = db.InvoicesAddedSince(sinceAddIndex)
+	if err != nil {
+		t.Fatalf("unable to query add index: %v", err)
+	}
+	if len(added) != numInvoices-sinceAddIndex {
+		t.Fatalf("expected %v invoices, got %v",
+			numInvoices-sinceAddIndex, len(added))
+	}
+	for i, invoice := range added {
+		if invoice.AddIndex != uint64(sinceAddIndex+i+1) {
+			t.Fatalf("expected add index %v, got %v",
+				sinceAddIndex+i+1, invoice.AddIndex)
+		}
+	}
+
+	// Next, we'll settle the invoices in reverse order, such that each
+	// one is assigned the next settle index.
+	for i := numInvoices - 1; i >= 0; i-- {
+		if err := db.SettleInvoice(hashes[i]); err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+
+		invoice, err := db.LookupInvoice(hashes[i])
+		if err != nil {
+			t.Fatalf("unable to fetch invoice: %v", err)
+		}
+		if invoice.SettleIndex != uint64(numInvoices-i) {
+			t.Fatalf("expected settle index %v, got %v",
+				numInvoices-i, invoice.SettleIndex)
+		}
+	}
+
+	// Settling an invoice a second time shouldn't assign it a new settle
+	// index.
+	if err := db.SettleInvoice(hashes[0]); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	invoice, err := db.LookupInvoice(hashes[0])
+	if err != nil {
+		t.Fatalf("unable to fetch invoice: %v", err)
+	}
+	if invoice.SettleIndex != numInvoices {
+		t.Fatalf("expected settle index %v, got %v", numInvoices,
+			invoice.SettleIndex)
+	}
+
+	// Finally, only the invoices with a greater settle index should be
+	// returned, in the order they were settled.
+	const sinceSettleIndex = 7
+	settled, err := db.InvoicesSettledSince(sinceSettleIndex)
+	if err != nil {
+		t.Fatalf("unable to query settle index: %v", err)
+	}
+	if len(settled) != numInvoices-sinceSettleIndex {
+		t.Fatalf("expected %v invoices, got %v",
+			numInvoices-sinceSettleIndex, len(settled))
+	}
+	for i, invoice := range settled {
+		if invoice.SettleIndex != uint64(sinceSettleIndex+i+1) {
+			t.Fatalf("expected settle index %v, got %v",
+				sinceSettleIndex+i+1, invoice.SettleIndex)
+		}
+		if invoice.AddIndex != uint64(numInvoices-sinceSettleIndex-i) {
+			t.Fatalf("expected add index %v, got %v",
+				numInvoices-sinceSettleIndex-i, invoice.AddIndex)
+		}
+	}
+}
+
+// TestQueryInvoices ensures that the invoices within the database can be
+// queried in both directions, starting at an index offset, limited in number
+// and filtered by their state and creation date.
+func TestQueryInvoices(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Querying the database before any invoices have been added should
+	// return an empty slice.
+	resp, err := db.QueryInvoices(InvoiceQuery{})
+	if err != nil {
+		t.Fatalf("unable to query invoices: %v", err)
+	}
+	if len(resp.Invoices) != 0 {
+		t.Fatalf("expected no invoices, got %v", len(resp.Invoices))
+	}
+
+	// We'll add a set of invoices, each created one second after the
+	// other, and settle every other one of them. As a result, all invoices
+	// with an odd add index remain pending.
+	const numInvoices = 10
+	amt := lnwire.NewMSatFromSatoshis(1000)
+	startTime := time.Unix(time.Now().Unix(), 0)
+	for i := 0; i < numInvoices; i++ {
+		invoice, err := randInvoice(amt)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		invoice.CreationDate = startTime.Add(time.Duration(i) * time.Second)
+
+		paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		if err := db.AddInvoice(invoice, paymentHash); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		if i%2 == 0 {
+			continue
+		}
+		if err := db.SettleInvoice(paymentHash); err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		query InvoiceQuery
+
+		// expected is the set of add indexes of the invoices that
+		// should be returned, in order.
+		expected []uint64
+	}{
+		{
+			name:     "all invoices",
+			query:    InvoiceQuery{},
+			expected: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name: "forward with offset and limit",
+			query: InvoiceQuery{
+				IndexOffset:    3,
+				NumMaxInvoices: 4,
+			},
+			expected: []uint64{4, 5, 6, 7},
+		},
+		{
+			name: "forward past the last invoice",
+			query: InvoiceQuery{
+				IndexOffset: numInvoices,
+			},
+			expected: nil,
+		},
+		{
+			name: "reversed from the last invoice",
+			query: InvoiceQuery{
+				NumMaxInvoices: 3,
+				Reversed:       true,
+			},
+			expected: []uint64{8, 9, 10},
+		},
+		{
+			name: "reversed with offset and limit",
+			query: InvoiceQuery{
+				IndexOffset:    5,
+				NumMaxInvoices: 2,
+				Reversed:       true,
+			},
+			expected: []uint64{3, 4},
+		},
+		{
+			name: "reversed with offset past the last invoice",
+			query: InvoiceQuery{
+				IndexOffset:    numInvoices + 5,
+				NumMaxInvoices: 2,
+				Reversed:       true,
+			},
+			expected: []uint64{9, 10},
+		},
+		{
+			name: "pending only",
+			query: InvoiceQuery{
+				IndexOffset:    2,
+				NumMaxInvoices: 2,
+				PendingOnly:    true,
+			},
+			expected: []uint64{3, 5},
+		},
+		{
+			name: "pending only reversed",
+			query: InvoiceQuery{
+				PendingOnly: true,
+				Reversed:    true,
+			},
+			expected: []uint64{1, 3, 5, 7, 9},
+		},
+		{
+			name: "creation date range",
+			query: InvoiceQuery{
+				CreationDateStart: startTime.Add(2 * time.Second),
+				CreationDateEnd:   startTime.Add(4 * time.Second),
+			},
+			expected: []uint64{3, 4, 5},
+		},
+	}
+
+	for _, test := range testCases {
+		resp, err := db.QueryInvoices(test.query)
+		if err != nil {
+			t.Fatalf("%v: unable to query invoices: %v", test.name,
+				err)
+		}
+
+		if len(resp.Invoices) != len(test.expected) {
+			t.Fatalf("%v: expected %v invoices, got %v", test.name,
+				len(test.expected), len(resp.Invoices))
+		}
+
+		for i, invoice := range resp.Invoices {
+			if invoice.AddIndex != test.expected[i] {
+				t.Fatalf("%v: expected add index %v at "+
+					"position %v, got %v", test.name,
+					test.expected[i], i, invoice.AddIndex)
+			}
+		}
+
+		if len(test.expected) == 0 {
+			continue
+		}
+
+		firstIndex := test.expected[0]
+		lastIndex := test.expected[len(test.expected)-1]
+		if resp.FirstIndexOffset != firstIndex {
+			t.Fatalf("%v: expected first index offset %v, got %v",
+				test.name, firstIndex, resp.FirstIndexOffset)
+		}
+		if resp.LastIndexOffset != lastIndex {
+			t.Fatalf("%v: expected last index offset %v, got %v",
+				test.name, lastIndex, resp.LastIndexOffset)
+		}
+	}
+}
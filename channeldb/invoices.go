@@ -28,6 +28,27 @@ var (
 	// them fully.
 	invoiceIndexBucket = []byte("paymenthashes")
 
+	// pendingInvoiceIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all invoices that are either open or
+	// accepted by their invoice ID. This index allows the pending invoices
+	// to be retrieved without scanning the entire invoice bucket.
+	pendingInvoiceIndexBucket = []byte("pending-invoice-index")
+
+	// addIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all invoices by their add index. The add
+	// index is a monotonically increasing sequence number that's assigned
+	// to each invoice as it's added, mapping to the invoice ID. This index
+	// allows callers to retrieve all invoices added since a particular
+	// point in time.
+	addIndexBucket = []byte("invoice-add-index")
+
+	// settleIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all settled invoices by their settle
+	// index. Similar to the add index, the settle index is a monotonically
+	// increasing sequence number that's assigned to each invoice as it's
+	// settled, mapping to the invoice ID.
+	settleIndexBucket = []byte("invoice-settle-index")
+
 	// numInvoicesKey is the name of key which houses the auto-incrementing
 	// invoice ID which is essentially used as a primary key. With each
 	// invoice inserted, the primary key is incremented by one. This key is
@@ -52,6 +73,59 @@ const (
 	MaxPaymentRequestSize = 4096
 )
 
+// UnknownPreimage is an all-zeroes preimage that indicates that the preimage
+// for this invoice is not yet known. Invoices carrying it are hold invoices:
+// HTLCs paying to them are accepted and held until the preimage is supplied
+// externally, or the invoice is canceled.
+var UnknownPreimage [32]byte
+
+// ContractState describes the state the invoice is in.
+type ContractState uint8
+
+const (
+	// ContractOpen means the invoice has only been created.
+	ContractOpen ContractState = 0
+
+	// ContractSettled means the htlc is settled and the invoice has been
+	// paid.
+	ContractSettled ContractState = 1
+
+	// ContractCanceled means the invoice has been canceled.
+	ContractCanceled ContractState = 2
+
+	// ContractAccepted means the HTLC has been accepted but not settled
+	// yet, as the preimage of the hold invoice isn't known yet.
+	ContractAccepted ContractState = 3
+
+	// ContractExpired means the invoice expired before an HTLC paying to
+	// it was accepted.
+	ContractExpired ContractState = 4
+)
+
+// String returns a human readable identifier for the ContractState type.
+func (c ContractState) String() string {
+	switch c {
+	case ContractOpen:
+		return "Open"
+	case ContractSettled:
+		return "Settled"
+	case ContractCanceled:
+		return "Canceled"
+	case ContractAccepted:
+		return "Accepted"
+	case ContractExpired:
+		return "Expired"
+	}
+
+	return "Unknown"
+}
+
+// IsPending returns true if the invoice can still be paid, meaning that it's
+// either open or an HTLC paying to it is being held.
+func (c ContractState) IsPending() bool {
+	return c == ContractOpen || c == ContractAccepted
+}
+
 // ContractTerm is a companion struct to the Invoice struct. This struct houses
 // the necessary conditions required before the invoice can be considered fully
 // settled by the payee.
@@ -65,9 +139,10 @@ type ContractTerm struct {
 	// HTLC which can be satisfied by the above preimage.
 	Value lnwire.MilliSatoshi
 
-	// Settled indicates if this particular contract term has been fully
-	// settled by the payer.
-	Settled bool
+	// State describes the state the invoice is in. The state is stored
+	// within the same byte that formerly held the settled flag, so
+	// existing invoices map onto ContractOpen and ContractSettled.
+	State ContractState
 }
 
 // Invoice is a payment invoice generated by a payee in order to request
@@ -76,8 +151,8 @@ type ContractTerm struct {
 // existing financial system within PayPal, etc.  Invoices are added to the
 // database when a payment is requested, then can be settled manually once the
 // payment is received at the upper layer. For record keeping purposes,
-// invoices are never deleted from the database, instead a bit is toggled
-// denoting the invoice has been fully settled. Within the database, all
+// invoices are never deleted from the database, instead their state is
+// updated once they've been fully settled or canceled. Within the database, all
 // invoices must have a unique payment hash which is generated by taking the
 // sha256 of the payment
 // preimage.
@@ -110,6 +185,25 @@ type Invoice struct {
 	// TODO(roasbeef): later allow for multiple terms to fulfill the final
 	// invoice: payment fragmentation, etc.
 	Terms ContractTerm
+
+	// AddIndex is an auto-incrementing integer that acts as a
+	// monotonically increasing sequence number for all invoices created.
+	// Clients can then use this field as a "checkpoint" of sorts when
+	// implementing a streaming RPC to notify consumers of instances where
+	// an invoice has been added before they re-connected.
+	//
+	// NOTE: This index starts at 1.
+	AddIndex uint64
+
+	// SettleIndex is an auto-incrementing integer that acts as a
+	// monotonically increasing sequence number for all settled invoices.
+	// Clients can then use this field as a "checkpoint" of sorts when
+	// implementing a streaming RPC to notify consumers of instances where
+	// an invoice has been settled before they re-connected.
+	//
+	// NOTE: This index starts at 1, and is zero for invoices that haven't
+	// been settled.
+	SettleIndex uint64
 }
 
 func validateInvoice(i *Invoice) error {
@@ -133,8 +227,10 @@ func validateInvoice(i *Invoice) error {
 // AddInvoice inserts the targeted invoice into the database. If the invoice
 // has *any* payment hashes which already exists within the database, then the
 // insertion will be aborted and rejected due to the strict policy banning any
-// duplicate payment hashes.
-func (d *DB) AddInvoice(i *Invoice) error {
+// duplicate payment hashes. The payment hash is passed in explicitly, as the
+// preimage of a hold invoice isn't known at the time it's added. On success,
+// the add index assigned to the invoice is set within the passed invoice.
+func (d *DB) AddInvoice(i *Invoice, paymentHash [32]byte) error {
 	if err := validateInvoice(i); err != nil {
 		return err
 	}
@@ -151,7 +247,6 @@ func (d *DB) AddInvoice(i *Invoice) error {
 
 		// Ensure that an invoice an identical payment hash doesn't
 		// already exist within the index.
-		paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
 		if invoiceIndex.Get(paymentHash[:]) != nil {
 			return ErrDuplicateInvoice
 		}
@@ -170,7 +265,8 @@ func (d *DB) AddInvoice(i *Invoice) error {
 			invoiceNum = byteOrder.Uint32(invoiceCounter)
 		}
 
-		return putInvoice(invoices, invoiceIndex, i, invoiceNum)
+		return putInvoice(invoices, invoiceIndex, i, invoiceNum,
+			paymentHash)
 	})
 }
 
@@ -217,8 +313,8 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 }
 
 // FetchAllInvoices returns all invoices currently stored within the database.
-// If the pendingOnly param is true, then only unsettled invoices will be
-// returned, skipping all invoices that are fully settled.
+// If the pendingOnly param is true, then only open or accepted invoices will be
+// returned, skipping all invoices that are fully settled, canceled or expired.
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
@@ -228,6 +324,27 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 			return ErrNoInvoicesCreated
 		}
 
+		// If only the pending invoices were requested, then we'll
+		// consult the pending invoice index, rather than scanning the
+		// entire invoice bucket.
+		if pendingOnly {
+			pendingIndex := invoiceB.Bucket(pendingInvoiceIndexBucket)
+			if pendingIndex == nil {
+				return nil
+			}
+
+			return pendingIndex.ForEach(func(k, _ []byte) error {
+				invoice, err := fetchInvoice(k, invoiceB)
+				if err != nil {
+					return err
+				}
+
+				invoices = append(invoices, invoice)
+
+				return nil
+			})
+		}
+
 		// Iterate through the entire key space of the top-level
 		// invoice bucket. If key with a non-nil value stores the next
 		// invoice ID which maps to the corresponding invoice.
@@ -242,10 +359,6 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 				return err
 			}
 
-			if pendingOnly && invoice.Terms.Settled {
-				return nil
-			}
-
 			invoices = append(invoices, invoice)
 
 			return nil
@@ -258,6 +371,259 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	return invoices, nil
 }
 
+// InvoiceQuery represents a query to the invoice database. The query allows a
+// caller to retrieve all invoices starting from a particular add index and
+// limit the number of results returned.
+type InvoiceQuery struct {
+	// IndexOffset is the offset within the add indices to start at. This
+	// can be used to start the response at a particular invoice. The
+	// invoice at the offset itself is excluded from the response.
+	IndexOffset uint64
+
+	// NumMaxInvoices is the maximum number of invoices that should be
+	// returned in the response. A value of zero means that there is no
+	// limit.
+	NumMaxInvoices uint64
+
+	// PendingOnly, if set, returns only the invoices that are either open
+	// or accepted.
+	PendingOnly bool
+
+	// Reversed, if set, indicates that the invoices returned should start
+	// from the IndexOffset and go backwards. If the IndexOffset is zero,
+	// then the query starts at the most recently added invoice.
+	Reversed bool
+
+	// CreationDateStart, if non-zero, excludes all invoices that were
+	// created before this time.
+	CreationDateStart time.Time
+
+	// CreationDateEnd, if non-zero, excludes all invoices that were
+	// created after this time.
+	CreationDateEnd time.Time
+}
+
+// InvoiceSlice is the response to a invoice query. It includes the original
+// query, the set of invoices that match the query, and an integer which
+// represents the offset index of the last item in the set of returned invoices.
+// This integer allows callers to resume their query using this offset in the
+// event that the query's response exceeds the maximum number of returnable
+// invoices.
+type InvoiceSlice struct {
+	InvoiceQuery
+
+	// Invoices is the set of invoices that matched the query above. The
+	// invoices are always ordered by their add index, regardless of the
+	// direction of the query.
+	Invoices []*Invoice
+
+	// FirstIndexOffset is the index of the first element in the set of
+	// returned invoices above. Callers can use this to resume their query
+	// in the event that the slice has too many events to fit into a single
+	// response.
+	FirstIndexOffset uint64
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned invoices above. Callers can use this to resume their query
+	// in the event that the slice has too many events to fit into a single
+	// response.
+	LastIndexOffset uint64
+}
+
+// matches returns true if the passed invoice satisfies the filters of the
+// query.
+func (q *InvoiceQuery) matches(invoice *Invoice) bool {
+	if q.PendingOnly && !invoice.Terms.State.IsPending() {
+		return false
+	}
+	if !q.CreationDateStart.IsZero() &&
+		invoice.CreationDate.Before(q.CreationDateStart) {
+
+		return false
+	}
+	if !q.CreationDateEnd.IsZero() &&
+		invoice.CreationDate.After(q.CreationDateEnd) {
+
+		return false
+	}
+
+	return true
+}
+
+// QueryInvoices allows a caller to query the invoice database for invoices
+// within the specified add index range. Rather than loading all invoices into
+// memory, the add index is traversed starting at the offset of the query, and
+// the traversal stops as soon as the maximum number of matching invoices has
+// been collected.
+func (d *DB) QueryInvoices(q InvoiceQuery) (InvoiceSlice, error) {
+	resp := InvoiceSlice{
+		InvoiceQuery: q,
+	}
+
+	err := d.View(func(tx *bolt.Tx) error {
+		// If the bucket wasn't found, then there aren't any invoices
+		// within the database yet, so we can simply exit.
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return ErrNoInvoicesCreated
+		}
+		addIndex := invoices.Bucket(addIndexBucket)
+		if addIndex == nil {
+			return ErrNoInvoicesCreated
+		}
+
+		// We'll position the cursor at the first invoice past the
+		// index offset, taking the direction of the query into
+		// account.
+		c := addIndex.Cursor()
+		next := c.Next
+		var k, v []byte
+		switch {
+		case q.Reversed && q.IndexOffset == 0:
+			next = c.Prev
+			k, v = c.Last()
+
+		case q.Reversed:
+			var offset [8]byte
+			byteOrder.PutUint64(offset[:], q.IndexOffset)
+
+			// Seeking positions the cursor at the offset itself,
+			// or the first index past it, so we'll step back
+			// once. If the seek went past the end of the index,
+			// then we start at the last invoice instead.
+			next = c.Prev
+			if k, _ = c.Seek(offset[:]); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+
+		default:
+			var offset [8]byte
+			byteOrder.PutUint64(offset[:], q.IndexOffset+1)
+			k, v = c.Seek(offset[:])
+		}
+
+		for ; k != nil; k, v = next() {
+			if q.NumMaxInvoices != 0 &&
+				uint64(len(resp.Invoices)) >= q.NumMaxInvoices {
+
+				break
+			}
+
+			invoice, err := fetchInvoice(v, invoices)
+			if err != nil {
+				return err
+			}
+
+			if !q.matches(invoice) {
+				continue
+			}
+
+			resp.Invoices = append(resp.Invoices, invoice)
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrNoInvoicesCreated {
+		return resp, err
+	}
+
+	// If we iterated backwards, then we'll reverse the order of the
+	// invoices, such that they're always ordered by their add index.
+	if q.Reversed {
+		numInvoices := len(resp.Invoices)
+		for i := 0; i < numInvoices/2; i++ {
+			opposite := numInvoices - i - 1
+			resp.Invoices[i], resp.Invoices[opposite] =
+				resp.Invoices[opposite], resp.Invoices[i]
+		}
+	}
+
+	// Finally, we'll record the indexes of the first and last invoices
+	// returned, such that the caller can resume the query from either end.
+	if len(resp.Invoices) > 0 {
+		resp.FirstIndexOffset = resp.Invoices[0].AddIndex
+		resp.LastIndexOffset = resp.Invoices[len(resp.Invoices)-1].AddIndex
+	}
+
+	return resp, nil
+}
+
+// InvoicesAddedSince can be used by callers to seek into the event time series
+// of all the invoices added in the database. The specified sinceAddIndex
+// should be the highest add index that the caller knows of. This method will
+// return all invoices with an add index greater than the specified
+// sinceAddIndex.
+//
+// NOTE: The index starts from 1, as a result, specifying a value of zero is a
+// noop.
+func (d *DB) InvoicesAddedSince(sinceAddIndex uint64) ([]*Invoice, error) {
+	return d.invoicesSince(addIndexBucket, sinceAddIndex)
+}
+
+// InvoicesSettledSince can be used by callers to seek into the event time
+// series of all the invoices settled in the database. The specified
+// sinceSettleIndex should be the highest settle index that the caller knows
+// of. This method will return all invoices with a settle index greater than
+// the specified sinceSettleIndex.
+//
+// NOTE: The index starts from 1, as a result, specifying a value of zero is a
+// noop.
+func (d *DB) InvoicesSettledSince(sinceSettleIndex uint64) ([]*Invoice, error) {
+	return d.invoicesSince(settleIndexBucket, sinceSettleIndex)
+}
+
+// invoicesSince returns all invoices within the target index bucket that have
+// an index greater than the passed index, ordered by their index.
+func (d *DB) invoicesSince(indexBucket []byte,
+	sinceIndex uint64) ([]*Invoice, error) {
+
+	// An index of zero signals that the caller isn't interested in any
+	// past events, so we can exit early.
+	if sinceIndex == 0 {
+		return nil, nil
+	}
+
+	var invoices []*Invoice
+	err := d.View(func(tx *bolt.Tx) error {
+		invoiceB := tx.Bucket(invoiceBucket)
+		if invoiceB == nil {
+			return ErrNoInvoicesCreated
+		}
+
+		index := invoiceB.Bucket(indexBucket)
+		if index == nil {
+			return nil
+		}
+
+		// We'll seek to the first index past the one specified, and
+		// fetch all the invoices from there on.
+		var startIndex [8]byte
+		byteOrder.PutUint64(startIndex[:], sinceIndex+1)
+
+		c := index.Cursor()
+		for k, v := c.Seek(startIndex[:]); k != nil; k, v = c.Next() {
+			invoice, err := fetchInvoice(v, invoiceB)
+			if err != nil {
+				return err
+			}
+
+			invoices = append(invoices, invoice)
+		}
+
+		return nil
+	})
+	switch {
+	case err == ErrNoInvoicesCreated:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
 // SettleInvoice attempts to mark an invoice corresponding to the passed
 // payment hash as fully settled. If an invoice matching the passed payment
 // hash doesn't existing within the database, then the action will fail with a
@@ -284,8 +650,192 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 	})
 }
 
+// AcceptInvoice marks the hold invoice corresponding to the passed payment
+// hash as accepted, meaning that an HTLC paying to it has been received and is
+// being held until the invoice is either settled or canceled. Accepting an
+// invoice that is already accepted is a noop. The updated invoice is returned.
+func (d *DB) AcceptInvoice(paymentHash [32]byte) (*Invoice, error) {
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices, invoiceNum, err := fetchInvoiceNum(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch invoice.Terms.State {
+		case ContractAccepted:
+			return nil
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		case ContractExpired:
+			return ErrInvoiceExpired
+		}
+
+		invoice.Terms.State = ContractAccepted
+
+		return updateInvoice(invoices, invoiceNum, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice that pays to the hash
+// of the passed preimage. The preimage is stored along with the invoice, such
+// that any HTLCs paying to it can be settled. The updated invoice is returned.
+func (d *DB) SettleHoldInvoice(preimage [32]byte) (*Invoice, error) {
+	paymentHash := sha256.Sum256(preimage[:])
+
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices, invoiceNum, err := fetchInvoiceNum(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case invoice.Terms.State == ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case invoice.Terms.State == ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		case invoice.Terms.State == ContractExpired:
+			return ErrInvoiceExpired
+		case invoice.Terms.PaymentPreimage != UnknownPreimage:
+			return ErrInvoicePreimageKnown
+		case invoice.Terms.State != ContractAccepted:
+			return ErrInvoiceNotAccepted
+		}
+
+		invoice.Terms.PaymentPreimage = preimage
+		invoice.Terms.State = ContractSettled
+		invoice.SettleDate = time.Now()
+
+		return updateInvoice(invoices, invoiceNum, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// CancelInvoice attempts to cancel the invoice corresponding to the passed
+// payment hash. Once canceled, any HTLCs paying to the invoice are failed back
+// to the sender. Settled or expired invoices can't be canceled, while
+// canceling an invoice twice is a noop. The updated invoice is returned.
+func (d *DB) CancelInvoice(paymentHash [32]byte) (*Invoice, error) {
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices, invoiceNum, err := fetchInvoiceNum(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch invoice.Terms.State {
+		case ContractCanceled:
+			return nil
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractExpired:
+			return ErrInvoiceExpired
+		}
+
+		invoice.Terms.State = ContractCanceled
+
+		return updateInvoice(invoices, invoiceNum, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// ExpireInvoice marks the open invoice corresponding to the passed payment
+// hash as expired, after which any HTLCs paying to it will be rejected.
+// Invoices for which an HTLC has already been accepted can't be expired, as
+// they're resolved by either settling or canceling them. Expiring an invoice
+// twice is a noop. The updated invoice is returned.
+func (d *DB) ExpireInvoice(paymentHash [32]byte) (*Invoice, error) {
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices, invoiceNum, err := fetchInvoiceNum(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		switch invoice.Terms.State {
+		case ContractExpired:
+			return nil
+		case ContractAccepted:
+			return ErrInvoiceAlreadyAccepted
+		case ContractSettled:
+			return ErrInvoiceAlreadySettled
+		case ContractCanceled:
+			return ErrInvoiceAlreadyCanceled
+		}
+
+		invoice.Terms.State = ContractExpired
+
+		return updateInvoice(invoices, invoiceNum, invoice)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// fetchInvoiceNum returns the invoice bucket along with the invoice number of
+// the invoice paying to the passed payment hash.
+func fetchInvoiceNum(tx *bolt.Tx, paymentHash [32]byte) (*bolt.Bucket,
+	[]byte, error) {
+
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil, nil, ErrNoInvoicesCreated
+	}
+	invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+	if invoiceIndex == nil {
+		return nil, nil, ErrNoInvoicesCreated
+	}
+
+	// Check the invoice index to see if an invoice paying to this hash
+	// exists within the DB.
+	invoiceNum := invoiceIndex.Get(paymentHash[:])
+	if invoiceNum == nil {
+		return nil, nil, ErrInvoiceNotFound
+	}
+
+	return invoices, invoiceNum, nil
+}
+
 func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
-	i *Invoice, invoiceNum uint32) error {
+	i *Invoice, invoiceNum uint32, paymentHash [32]byte) error {
 
 	// Create the invoice key which is just the big-endian representation
 	// of the invoice number.
@@ -304,15 +854,45 @@ func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
 	// Add the payment hash to the invoice index. This will let us quickly
 	// identify if we can settle an incoming payment, and also to possibly
 	// allow a single invoice to have multiple payment installations.
-	paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
 	if err := invoiceIndex.Put(paymentHash[:], invoiceKey[:]); err != nil {
 		return err
 	}
 
+	// Newly added invoices are open, so we'll also add the invoice to the
+	// pending invoice index.
+	pendingIndex, err := invoices.CreateBucketIfNotExists(
+		pendingInvoiceIndexBucket,
+	)
+	if err != nil {
+		return err
+	}
+	if err := pendingIndex.Put(invoiceKey[:], nil); err != nil {
+		return err
+	}
+
+	// Next, we'll assign the invoice the next add index, and map the index
+	// to the invoice ID within the add index.
+	addIndex, err := invoices.CreateBucketIfNotExists(addIndexBucket)
+	if err != nil {
+		return err
+	}
+	nextAddSeqNo, err := addIndex.NextSequence()
+	if err != nil {
+		return err
+	}
+
+	var seqNoBytes [8]byte
+	byteOrder.PutUint64(seqNoBytes[:], nextAddSeqNo)
+	if err := addIndex.Put(seqNoBytes[:], invoiceKey[:]); err != nil {
+		return err
+	}
+
+	i.AddIndex = nextAddSeqNo
+
 	// Finally, serialize the invoice itself to be written to the disk.
 	var buf bytes.Buffer
 	if err := serializeInvoice(&buf, i); err != nil {
-		return nil
+		return err
 	}
 
 	return invoices.Put(invoiceKey[:], buf.Bytes())
@@ -357,7 +937,16 @@ func serializeInvoice(w io.Writer, i *Invoice) error {
 		return err
 	}
 
-	if err := binary.Write(w, byteOrder, i.Terms.Settled); err != nil {
+	if err := binary.Write(w, byteOrder, i.Terms.State); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], i.AddIndex)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	byteOrder.PutUint64(scratch[:], i.SettleIndex)
+	if _, err := w.Write(scratch[:]); err != nil {
 		return err
 	}
 
@@ -376,6 +965,30 @@ func fetchInvoice(invoiceNum []byte, invoices *bolt.Bucket) (*Invoice, error) {
 }
 
 func deserializeInvoice(r io.Reader) (*Invoice, error) {
+	invoice, err := deserializeInvoiceBase(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	invoice.AddIndex = byteOrder.Uint64(scratch[:])
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	invoice.SettleIndex = byteOrder.Uint64(scratch[:])
+
+	return invoice, nil
+}
+
+// deserializeInvoiceBase reads all fields of a serialized invoice up to and
+// including its state. These are the fields that were stored before the add
+// and settle indexes were introduced, which allows the database migrations to
+// read invoices written in the older format.
+func deserializeInvoiceBase(r io.Reader) (*Invoice, error) {
 	var err error
 	invoice := &Invoice{}
 
@@ -419,7 +1032,7 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	}
 	invoice.Terms.Value = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
 
-	if err := binary.Read(r, byteOrder, &invoice.Terms.Settled); err != nil {
+	if err := binary.Read(r, byteOrder, &invoice.Terms.State); err != nil {
 		return nil, err
 	}
 
@@ -434,16 +1047,69 @@ func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
 
 	// Add idempotency to duplicate settles, return here to avoid
 	// overwriting the previous info.
-	if invoice.Terms.Settled {
+	switch invoice.Terms.State {
+	case ContractSettled:
 		return nil
+	case ContractCanceled:
+		return ErrInvoiceAlreadyCanceled
+	case ContractExpired:
+		return ErrInvoiceExpired
 	}
 
-	invoice.Terms.Settled = true
+	invoice.Terms.State = ContractSettled
 	invoice.SettleDate = time.Now()
 
+	return updateInvoice(invoices, invoiceNum, invoice)
+}
+
+// updateInvoice serializes the passed invoice and writes it back to disk under
+// the given invoice number. The pending invoice index is updated to reflect the
+// state of the invoice, and newly settled invoices are assigned the next
+// settle index.
+func updateInvoice(invoices *bolt.Bucket, invoiceNum []byte,
+	invoice *Invoice) error {
+
+	if invoice.Terms.State == ContractSettled && invoice.SettleIndex == 0 {
+		settleIndex, err := invoices.CreateBucketIfNotExists(
+			settleIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+		nextSettleSeqNo, err := settleIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqNoBytes [8]byte
+		byteOrder.PutUint64(seqNoBytes[:], nextSettleSeqNo)
+		err = settleIndex.Put(seqNoBytes[:], invoiceNum)
+		if err != nil {
+			return err
+		}
+
+		invoice.SettleIndex = nextSettleSeqNo
+	}
+
+	pendingIndex, err := invoices.CreateBucketIfNotExists(
+		pendingInvoiceIndexBucket,
+	)
+	if err != nil {
+		return err
+	}
+
+	if invoice.Terms.State.IsPending() {
+		err = pendingIndex.Put(invoiceNum, nil)
+	} else {
+		err = pendingIndex.Delete(invoiceNum)
+	}
+	if err != nil {
+		return err
+	}
+
 	var buf bytes.Buffer
 	if err := serializeInvoice(&buf, invoice); err != nil {
-		return nil
+		return err
 	}
 
 	return invoices.Put(invoiceNum[:], buf.Bytes())
@@ -1 +1,449 @@
 package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	"github.com/coreos/bbolt"
+)
+
+// migrateInvoicePendingIndex is the migration function that adds the pending
+// invoice index to the invoice bucket. Invoices used to only carry a settled
+// bit, which was stored in the same byte as the state of the invoice, so
+// existing invoices are either open or settled. All open invoices are added to
+// the new index, such that the pending invoices can be retrieved without
+// scanning the entire invoice bucket.
+func migrateInvoicePendingIndex(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		// If the invoice bucket doesn't exist yet, then no invoices
+		// have been created, and the index will be created along with
+		// the first invoice.
+		return nil
+	}
+
+	log.Infof("Populating pending invoice index")
+
+	// We'll first gather the keys of all pending invoices, as the invoice
+	// bucket can't be modified while we iterate over it.
+	var pendingKeys [][]byte
+	err := invoices.ForEach(func(k, v []byte) error {
+		// Skip the sub-buckets within the invoice bucket.
+		if v == nil {
+			return nil
+		}
+
+		invoice, err := deserializeInvoiceBase(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		if invoice.Terms.State.IsPending() {
+			key := make([]byte, len(k))
+			copy(key, k)
+			pendingKeys = append(pendingKeys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	pendingIndex, err := invoices.CreateBucketIfNotExists(
+		pendingInvoiceIndexBucket,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range pendingKeys {
+		if err := pendingIndex.Put(key, nil); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Added %v invoices to the pending invoice index",
+		len(pendingKeys))
+
+	return nil
+}
+
+// migrateInvoiceTimeSeries is the migration function that assigns an add index
+// to all existing invoices, and a settle index to all settled invoices. Add
+// indexes are assigned in the order the invoices were created, while settle
+// indexes are assigned in the order the invoices were settled. Each invoice is
+// re-serialized to include its indexes, and the add and settle index buckets
+// are populated accordingly. As payments embed the invoice they paid, all
+// payments are re-serialized in the indexed payment layout as well.
+func migrateInvoiceTimeSeries(tx *bolt.Tx) error {
+	if err := migratePaymentLayout(tx); err != nil {
+		return err
+	}
+
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		// If the invoice bucket doesn't exist yet, then no invoices
+		// have been created, and the indexes will be created along
+		// with the first invoice.
+		return nil
+	}
+
+	log.Infof("Migrating invoice database to new time series format")
+
+	// As the invoice bucket can't be modified while we iterate over it,
+	// we'll first read all existing invoices in the older format. The
+	// invoices are keyed by their invoice ID, so they're iterated over in
+	// the order they were created.
+	type invoiceEntry struct {
+		key     []byte
+		invoice *Invoice
+	}
+	var entries []*invoiceEntry
+	err := invoices.ForEach(func(k, v []byte) error {
+		// Skip the sub-buckets within the invoice bucket.
+		if v == nil {
+			return nil
+		}
+
+		invoice, err := deserializeInvoiceBase(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, len(k))
+		copy(key, k)
+		entries = append(entries, &invoiceEntry{
+			key:     key,
+			invoice: invoice,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	addIndex, err := invoices.CreateBucketIfNotExists(addIndexBucket)
+	if err != nil {
+		return err
+	}
+	settleIndex, err := invoices.CreateBucketIfNotExists(settleIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	// With all the invoices read, we'll assign each invoice the next add
+	// index, while collecting the settled invoices.
+	var settled []*invoiceEntry
+	for _, entry := range entries {
+		nextAddSeqNo, err := addIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqNoBytes [8]byte
+		byteOrder.PutUint64(seqNoBytes[:], nextAddSeqNo)
+		if err := addIndex.Put(seqNoBytes[:], entry.key); err != nil {
+			return err
+		}
+
+		entry.invoice.AddIndex = nextAddSeqNo
+
+		if entry.invoice.Terms.State == ContractSettled {
+			settled = append(settled, entry)
+		}
+	}
+
+	// The settled invoices are assigned their settle index in the order
+	// they were settled.
+	sort.SliceStable(settled, func(i, j int) bool {
+		return settled[i].invoice.SettleDate.Before(
+			settled[j].invoice.SettleDate,
+		)
+	})
+	for _, entry := range settled {
+		nextSettleSeqNo, err := settleIndex.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqNoBytes [8]byte
+		byteOrder.PutUint64(seqNoBytes[:], nextSettleSeqNo)
+		if err := settleIndex.Put(seqNoBytes[:], entry.key); err != nil {
+			return err
+		}
+
+		entry.invoice.SettleIndex = nextSettleSeqNo
+	}
+
+	// Finally, we'll write all invoices back to disk in the new format.
+	for _, entry := range entries {
+		var buf bytes.Buffer
+		if err := serializeInvoice(&buf, entry.invoice); err != nil {
+			return err
+		}
+
+		if err := invoices.Put(entry.key, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Migration to invoice time series index complete, "+
+		"%v invoices added, %v settled", len(entries), len(settled))
+
+	return nil
+}
+
+// migratePaymentLayout re-serializes all payments within the payments bucket
+// in the indexed payment layout, which prefixes each payment with its layout
+// version. Payments aren't assigned an add or settle index of their own, so
+// the indexes of their embedded invoices are left unset.
+func migratePaymentLayout(tx *bolt.Tx) error {
+	payments := tx.Bucket(paymentBucket)
+	if payments == nil {
+		return nil
+	}
+
+	log.Infof("Migrating payments to indexed payment layout")
+
+	// As the payments bucket can't be modified while we iterate over it,
+	// we'll first read all existing payments in the older layout.
+	type paymentEntry struct {
+		key     []byte
+		payment *OutgoingPayment
+	}
+	var entries []*paymentEntry
+	err := payments.ForEach(func(k, v []byte) error {
+		// Skip any sub-buckets within the payments bucket.
+		if v == nil {
+			return nil
+		}
+
+		payment, err := deserializeOutgoingPaymentBase(
+			bytes.NewReader(v),
+		)
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, len(k))
+		copy(key, k)
+		entries = append(entries, &paymentEntry{
+			key:     key,
+			payment: payment,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		var buf bytes.Buffer
+		err := serializeOutgoingPayment(&buf, entry.payment)
+		if err != nil {
+			return err
+		}
+
+		if err := payments.Put(entry.key, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Migration to indexed payment layout complete, %v "+
+		"payments migrated", len(entries))
+
+	return nil
+}
+
+// migrateOutgoingPayments is the migration function that moves all successful
+// payments stored in the legacy payments bucket into the payments root bucket,
+// where payments are tracked by the payment control tower along with each of
+// their HTLC attempts. Legacy payments only recorded the path of the
+// successful route, so each of them is converted into a payment with a single
+// settled attempt along that path. Payments already tracked by the control
+// tower are left untouched, as their record is more complete. Afterwards, the
+// legacy payments bucket is removed. All payments end up within the payments
+// index, which allows them to be traversed by their sequence number.
+func migrateOutgoingPayments(tx *bolt.Tx) error {
+	// The payments already tracked by the control tower predate the
+	// payments index, so we'll add them to it first.
+	if err := indexTrackedPayments(tx); err != nil {
+		return err
+	}
+
+	oldPayments := tx.Bucket(paymentBucket)
+	if oldPayments == nil {
+		return nil
+	}
+
+	log.Infof("Migrating outgoing payments to new payments bucket")
+
+	// The legacy payments are keyed by their sequence number, so they're
+	// iterated over, and assigned their new sequence number, in the order
+	// they were made.
+	var legacyPayments []*OutgoingPayment
+	err := oldPayments.ForEach(func(k, v []byte) error {
+		// Skip any sub-buckets within the payments bucket.
+		if v == nil {
+			return nil
+		}
+
+		payment, err := deserializeOutgoingPayment(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		legacyPayments = append(legacyPayments, payment)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	payments, err := tx.CreateBucketIfNotExists(paymentsRootBucket)
+	if err != nil {
+		return err
+	}
+
+	var numMigrated int
+	for _, p := range legacyPayments {
+		paymentHash := sha256.Sum256(p.PaymentPreimage[:])
+		if payments.Bucket(paymentHash[:]) != nil {
+			log.Debugf("Skipping legacy payment %x already tracked "+
+				"by the control tower", paymentHash)
+			continue
+		}
+
+		creationInfo := &PaymentCreationInfo{
+			PaymentHash:    paymentHash,
+			Value:          p.Terms.Value,
+			CreationDate:   p.CreationDate,
+			PaymentRequest: p.PaymentRequest,
+		}
+
+		hops := make([]*PaymentHop, len(p.Path))
+		for i, pub := range p.Path {
+			hops[i] = &PaymentHop{
+				PubKeyBytes: pub,
+			}
+		}
+		attempt := &HTLCAttemptInfo{
+			Route: PaymentRoute{
+				TotalTimeLock: p.TimeLockLength,
+				TotalAmount:   p.Terms.Value + p.Fee,
+				TotalFees:     p.Fee,
+				Hops:          hops,
+			},
+			AttemptTime: p.CreationDate,
+		}
+		settleInfo := &HTLCSettleInfo{
+			Preimage:   p.PaymentPreimage,
+			SettleTime: p.CreationDate,
+		}
+
+		var infoBytes, attemptBytes, settleBytes bytes.Buffer
+		err := serializePaymentCreationInfo(&infoBytes, creationInfo)
+		if err != nil {
+			return err
+		}
+		err = serializeHTLCAttemptInfo(&attemptBytes, attempt)
+		if err != nil {
+			return err
+		}
+		err = serializeHTLCSettleInfo(&settleBytes, settleInfo)
+		if err != nil {
+			return err
+		}
+
+		bucket, err := payments.CreateBucket(paymentHash[:])
+		if err != nil {
+			return err
+		}
+
+		sequenceNum, err := payments.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], sequenceNum)
+		if err := bucket.Put(paymentSequenceKey, seqBytes[:]); err != nil {
+			return err
+		}
+		if err := indexPayment(tx, sequenceNum, paymentHash); err != nil {
+			return err
+		}
+		err = bucket.Put(paymentCreationInfoKey, infoBytes.Bytes())
+		if err != nil {
+			return err
+		}
+
+		htlcsBucket, err := bucket.CreateBucket(paymentHtlcsBucket)
+		if err != nil {
+			return err
+		}
+
+		var attemptKey [8]byte
+		byteOrder.PutUint64(attemptKey[:], attempt.AttemptID)
+		htlcBucket, err := htlcsBucket.CreateBucket(attemptKey[:])
+		if err != nil {
+			return err
+		}
+
+		err = htlcBucket.Put(htlcAttemptInfoKey, attemptBytes.Bytes())
+		if err != nil {
+			return err
+		}
+		err = htlcBucket.Put(htlcSettleInfoKey, settleBytes.Bytes())
+		if err != nil {
+			return err
+		}
+
+		numMigrated++
+	}
+
+	if err := tx.DeleteBucket(paymentBucket); err != nil {
+		return err
+	}
+
+	log.Infof("Migration of outgoing payments complete, %v of %v "+
+		"payments migrated", numMigrated, len(legacyPayments))
+
+	return nil
+}
+
+// indexTrackedPayments adds all payments within the payments root bucket to
+// the payments index.
+func indexTrackedPayments(tx *bolt.Tx) error {
+	payments := tx.Bucket(paymentsRootBucket)
+	if payments == nil {
+		return nil
+	}
+
+	return payments.ForEach(func(k, _ []byte) error {
+		bucket := payments.Bucket(k)
+		if bucket == nil {
+			// We only expect sub-buckets to be found in this
+			// top-level bucket.
+			return fmt.Errorf("non bucket element in payments " +
+				"bucket")
+		}
+
+		seqBytes := bucket.Get(paymentSequenceKey)
+		if seqBytes == nil {
+			return ErrNoSequenceNumber
+		}
+
+		var paymentHash [32]byte
+		copy(paymentHash[:], k)
+
+		return indexPayment(
+			tx, byteOrder.Uint64(seqBytes), paymentHash,
+		)
+	})
+}
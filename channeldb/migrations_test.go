@@ -0,0 +1,515 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestInvoicePendingIndexMigration asserts that the migration populates the
+// pending invoice index with all open invoices, skipping the settled ones.
+func TestInvoicePendingIndexMigration(t *testing.T) {
+	t.Parallel()
+
+	const numInvoices = 5
+	amt := lnwire.NewMSatFromSatoshis(1000)
+
+	// Populate the database with a set of invoices, settling every other
+	// one. Afterwards, remove the pending invoice index to mimic a
+	// database created before the index was introduced.
+	beforeMigrationFunc := func(d *DB) {
+		for i := 0; i < numInvoices; i++ {
+			invoice, err := randInvoice(amt)
+			if err != nil {
+				t.Fatalf("unable to create invoice: %v", err)
+			}
+
+			hash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+			if err := d.AddInvoice(invoice, hash); err != nil {
+				t.Fatalf("unable to add invoice: %v", err)
+			}
+
+			if i%2 == 1 {
+				continue
+			}
+			if err := d.SettleInvoice(hash); err != nil {
+				t.Fatalf("unable to settle invoice: %v", err)
+			}
+		}
+
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoices := tx.Bucket(invoiceBucket)
+			return invoices.DeleteBucket(pendingInvoiceIndexBucket)
+		})
+		if err != nil {
+			t.Fatalf("unable to delete pending index: %v", err)
+		}
+	}
+
+	// After the migration, only the open invoices should be returned as
+	// pending.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		pending, err := d.FetchAllInvoices(true)
+		if err != nil {
+			t.Fatalf("unable to fetch pending invoices: %v", err)
+		}
+		if len(pending) != numInvoices/2 {
+			t.Fatalf("expected %v pending invoices, got %v",
+				numInvoices/2, len(pending))
+		}
+		for _, invoice := range pending {
+			if invoice.Terms.State != ContractOpen {
+				t.Fatalf("expected open invoice, got %v",
+					invoice.Terms.State)
+			}
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateInvoicePendingIndex,
+		false)
+}
+
+// TestInvoiceTimeSeriesMigration asserts that the migration assigns add
+// indexes to all invoices in the order they were created, and settle indexes
+// to the settled invoices in the order they were settled.
+func TestInvoiceTimeSeriesMigration(t *testing.T) {
+	t.Parallel()
+
+	const numInvoices = 6
+	amt := lnwire.NewMSatFromSatoshis(1000)
+
+	var hashes [][32]byte
+
+	// Populate the database with a set of invoices, and settle every other
+	// one in reverse order. Afterwards, remove the indexes and rewrite the
+	// invoices without them to mimic a database created before the indexes
+	// were introduced.
+	beforeMigrationFunc := func(d *DB) {
+		for i := 0; i < numInvoices; i++ {
+			invoice, err := randInvoice(amt)
+			if err != nil {
+				t.Fatalf("unable to create invoice: %v", err)
+			}
+
+			hash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+			if err := d.AddInvoice(invoice, hash); err != nil {
+				t.Fatalf("unable to add invoice: %v", err)
+			}
+			hashes = append(hashes, hash)
+		}
+
+		for i := numInvoices - 1; i >= 0; i -= 2 {
+			if err := d.SettleInvoice(hashes[i]); err != nil {
+				t.Fatalf("unable to settle invoice: %v", err)
+			}
+		}
+
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoices := tx.Bucket(invoiceBucket)
+			err := invoices.DeleteBucket(addIndexBucket)
+			if err != nil {
+				return err
+			}
+			err = invoices.DeleteBucket(settleIndexBucket)
+			if err != nil {
+				return err
+			}
+
+			// Strip the trailing add and settle index from each of
+			// the serialized invoices.
+			legacyInvoices := make(map[string][]byte)
+			err = invoices.ForEach(func(k, v []byte) error {
+				if v == nil {
+					return nil
+				}
+
+				legacy := make([]byte, len(v)-16)
+				copy(legacy, v)
+				legacyInvoices[string(k)] = legacy
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for k, v := range legacyInvoices {
+				if err := invoices.Put([]byte(k), v); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to strip invoice indexes: %v", err)
+		}
+	}
+
+	// After the migration, the invoices should carry their indexes, and
+	// the index buckets should be populated.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		for i, hash := range hashes {
+			invoice, err := d.LookupInvoice(hash)
+			if err != nil {
+				t.Fatalf("unable to lookup invoice: %v", err)
+			}
+
+			if invoice.AddIndex != uint64(i+1) {
+				t.Fatalf("expected add index %v, got %v",
+					i+1, invoice.AddIndex)
+			}
+
+			// The last invoice was settled first, so it should
+			// have the lowest settle index.
+			var expectedSettleIndex uint64
+			if i%2 == 1 {
+				expectedSettleIndex = uint64(numInvoices-i+1) / 2
+			}
+			if invoice.SettleIndex != expectedSettleIndex {
+				t.Fatalf("expected settle index %v for "+
+					"invoice %v, got %v",
+					expectedSettleIndex, i,
+					invoice.SettleIndex)
+			}
+		}
+
+		added, err := d.InvoicesAddedSince(1)
+		if err != nil {
+			t.Fatalf("unable to query add index: %v", err)
+		}
+		if len(added) != numInvoices-1 {
+			t.Fatalf("expected %v invoices, got %v",
+				numInvoices-1, len(added))
+		}
+
+		settled, err := d.InvoicesSettledSince(1)
+		if err != nil {
+			t.Fatalf("unable to query settle index: %v", err)
+		}
+		if len(settled) != numInvoices/2-1 {
+			t.Fatalf("expected %v invoices, got %v",
+				numInvoices/2-1, len(settled))
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateInvoiceTimeSeries,
+		false)
+}
+
+// TestPaymentLayoutMigration asserts that the invoice time series migration
+// rewrites payments stored without the indexes of their embedded invoice in
+// the indexed payment layout.
+func TestPaymentLayoutMigration(t *testing.T) {
+	t.Parallel()
+
+	const numPayments = 4
+
+	var payments []*OutgoingPayment
+
+	// Populate the payments bucket with a set of payments in the layout
+	// used before invoices carried their indexes, which lacks both the
+	// layout version and the indexes.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			bucket, err := tx.CreateBucketIfNotExists(
+				paymentBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < numPayments; i++ {
+				payment, err := makeRandomFakePayment()
+				if err != nil {
+					return err
+				}
+
+				var b, inv bytes.Buffer
+				err = serializeOutgoingPayment(&b, payment)
+				if err != nil {
+					return err
+				}
+				err = serializeInvoice(&inv, &payment.Invoice)
+				if err != nil {
+					return err
+				}
+
+				// Strip the layout version preceding the
+				// invoice, and the indexes trailing it.
+				paymentBytes := b.Bytes()
+				indexEnd := 1 + inv.Len()
+				var legacy []byte
+				legacy = append(
+					legacy, paymentBytes[1:indexEnd-16]...,
+				)
+				legacy = append(
+					legacy, paymentBytes[indexEnd:]...,
+				)
+
+				var key [8]byte
+				byteOrder.PutUint64(key[:], uint64(i+1))
+				if err := bucket.Put(key[:], legacy); err != nil {
+					return err
+				}
+
+				payments = append(payments, payment)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to add legacy payments: %v", err)
+		}
+	}
+
+	// After the migration, all payments should be readable in the indexed
+	// payment layout.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		var migrated []*OutgoingPayment
+		err = d.View(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(paymentBucket)
+			return bucket.ForEach(func(k, v []byte) error {
+				payment, err := deserializeOutgoingPayment(
+					bytes.NewReader(v),
+				)
+				if err != nil {
+					return err
+				}
+
+				migrated = append(migrated, payment)
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatalf("unable to read migrated payments: %v", err)
+		}
+
+		if !reflect.DeepEqual(payments, migrated) {
+			t.Fatalf("payments don't match after migration: "+
+				"expected %v, got %v", spew.Sdump(payments),
+				spew.Sdump(migrated))
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateInvoiceTimeSeries,
+		false)
+}
+
+// TestOutgoingPaymentsMigration asserts that the migration moves all legacy
+// payments into the payments root bucket as succeeded payments, leaves
+// payments already tracked by the control tower untouched, and adds all of
+// them to the payments index.
+func TestOutgoingPaymentsMigration(t *testing.T) {
+	t.Parallel()
+
+	const numPayments = 4
+
+	var (
+		legacyPayments []*OutgoingPayment
+		trackedHash    [32]byte
+	)
+
+	// Populate the legacy payments bucket with a set of payments, the
+	// last of which is also tracked by the control tower already.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			payments, err := tx.CreateBucketIfNotExists(
+				paymentBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < numPayments; i++ {
+				payment, err := makeRandomFakePayment()
+				if err != nil {
+					return err
+				}
+
+				var b bytes.Buffer
+				err = serializeOutgoingPayment(&b, payment)
+				if err != nil {
+					return err
+				}
+				paymentBytes := b.Bytes()
+
+				seqNum, err := payments.NextSequence()
+				if err != nil {
+					return err
+				}
+
+				var key [8]byte
+				byteOrder.PutUint64(key[:], seqNum)
+				err = payments.Put(key[:], paymentBytes)
+				if err != nil {
+					return err
+				}
+
+				legacyPayments = append(legacyPayments, payment)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to add legacy payments: %v", err)
+		}
+
+		tracked := legacyPayments[numPayments-1]
+		trackedHash = sha256.Sum256(tracked.PaymentPreimage[:])
+		err = NewPaymentControl(d).InitPayment(
+			trackedHash, &PaymentCreationInfo{
+				PaymentHash: trackedHash,
+				Value:       tracked.Terms.Value,
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to init payment: %v", err)
+		}
+	}
+
+	// After the migration, every legacy payment should be found as a
+	// succeeded payment, in the order they were made, following the
+	// payment already tracked by the control tower.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		err = d.View(func(tx *bolt.Tx) error {
+			if tx.Bucket(paymentBucket) != nil {
+				t.Fatalf("legacy payments bucket not removed")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		payments, err := d.FetchPayments()
+		if err != nil {
+			t.Fatalf("unable to fetch payments: %v", err)
+		}
+		if len(payments) != numPayments {
+			t.Fatalf("expected %v payments, got %v", numPayments,
+				len(payments))
+		}
+
+		if payments[0].Info.PaymentHash != trackedHash ||
+			payments[0].Status != StatusInFlight {
+
+			t.Fatalf("tracked payment was modified")
+		}
+
+		// All payments should be found within the payments index, in
+		// the same order.
+		resp, err := d.QueryPayments(PaymentsQuery{
+			IncludeIncomplete: true,
+		})
+		if err != nil {
+			t.Fatalf("unable to query payments: %v", err)
+		}
+		if !reflect.DeepEqual(resp.Payments, payments) {
+			t.Fatalf("indexed payments don't match: expected %v, "+
+				"got %v", spew.Sdump(payments),
+				spew.Sdump(resp.Payments))
+		}
+
+		for i, payment := range payments[1:] {
+			legacy := legacyPayments[i]
+
+			hash := sha256.Sum256(legacy.PaymentPreimage[:])
+			if payment.Info.PaymentHash != hash {
+				t.Fatalf("expected payment %x at position %v, "+
+					"got %x", hash, i,
+					payment.Info.PaymentHash)
+			}
+			if payment.Status != StatusSucceeded {
+				t.Fatalf("expected status %v, got %v",
+					StatusSucceeded, payment.Status)
+			}
+			if payment.Info.Value != legacy.Terms.Value {
+				t.Fatalf("expected value %v, got %v",
+					legacy.Terms.Value, payment.Info.Value)
+			}
+			if !payment.Info.CreationDate.Equal(legacy.CreationDate) {
+				t.Fatalf("expected creation date %v, got %v",
+					legacy.CreationDate,
+					payment.Info.CreationDate)
+			}
+
+			settled := payment.SettledHTLC()
+			if settled.Settle.Preimage != legacy.PaymentPreimage {
+				t.Fatalf("preimage mismatch")
+			}
+
+			route := settled.Route
+			if route.TotalFees != legacy.Fee ||
+				route.TotalTimeLock != legacy.TimeLockLength {
+
+				t.Fatalf("route mismatch: %v", spew.Sdump(route))
+			}
+			if len(route.Hops) != len(legacy.Path) {
+				t.Fatalf("expected %v hops, got %v",
+					len(legacy.Path), len(route.Hops))
+			}
+			for j, hop := range route.Hops {
+				if hop.PubKeyBytes != legacy.Path[j] {
+					t.Fatalf("hop %v mismatch", j)
+				}
+			}
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateOutgoingPayments,
+		false)
+}
@@ -0,0 +1,171 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// missionControlBucket is the bucket that we'll use to store the
+	// history of mission control. Each key within the bucket is a
+	// monotonically increasing sequence number, and the value a serialized
+	// observation of forwarding an HTLC over a single channel.
+	missionControlBucket = []byte("mission-control")
+)
+
+// MissionControlResult is a single observation of whether a channel was able
+// to forward an HTLC of a particular amount, as learned from the outcome of a
+// payment attempt.
+type MissionControlResult struct {
+	// ChannelID is the channel the HTLC was forwarded over.
+	ChannelID lnwire.ShortChannelID
+
+	// Amount is the amount of the HTLC the channel was asked to forward.
+	Amount lnwire.MilliSatoshi
+
+	// Success is true if the channel forwarded the HTLC, and false if it
+	// failed to do so.
+	Success bool
+
+	// Timestamp is the time the outcome was observed.
+	Timestamp time.Time
+}
+
+// serializeMissionControlResult writes out the passed result to the
+// io.Writer.
+func serializeMissionControlResult(w io.Writer,
+	r *MissionControlResult) error {
+
+	return writeElements(
+		w, r.ChannelID, r.Amount, r.Success,
+		uint64(r.Timestamp.UnixNano()),
+	)
+}
+
+// deserializeMissionControlResult reads a result from the io.Reader.
+func deserializeMissionControlResult(r io.Reader) (*MissionControlResult,
+	error) {
+
+	var (
+		result    MissionControlResult
+		timestamp uint64
+	)
+	err := readElements(
+		r, &result.ChannelID, &result.Amount, &result.Success,
+		&timestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Timestamp = time.Unix(0, int64(timestamp))
+
+	return &result, nil
+}
+
+// AddMissionControlResult adds a result to the history of mission control.
+// Only the latest maxResults results are kept, so the oldest results are
+// removed once the history grows beyond that.
+func (d *DB) AddMissionControlResult(result *MissionControlResult,
+	maxResults uint64) error {
+
+	var b bytes.Buffer
+	if err := serializeMissionControlResult(&b, result); err != nil {
+		return err
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		results, err := tx.CreateBucketIfNotExists(
+			missionControlBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		seqNum, err := results.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], seqNum)
+
+		if err := results.Put(seqBytes[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		// As the keys are sequence numbers, the results that no longer
+		// fit within the history are the ones at the start of the
+		// bucket.
+		if seqNum <= maxResults {
+			return nil
+		}
+		cutoff := seqNum - maxResults
+
+		// We collect the keys to remove first, as deleting while
+		// iterating with a cursor would skip entries.
+		var staleKeys [][]byte
+		cursor := results.Cursor()
+		for k, _ := cursor.First(); k != nil; k, _ = cursor.Next() {
+			if byteOrder.Uint64(k) > cutoff {
+				break
+			}
+
+			staleKeys = append(staleKeys, append([]byte(nil), k...))
+		}
+
+		for _, k := range staleKeys {
+			if err := results.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// FetchMissionControlResults returns all results within the history of
+// mission control, in the order they were added.
+func (d *DB) FetchMissionControlResults() ([]*MissionControlResult, error) {
+	var results []*MissionControlResult
+
+	err := d.View(func(tx *bolt.Tx) error {
+		resultsBucket := tx.Bucket(missionControlBucket)
+		if resultsBucket == nil {
+			return nil
+		}
+
+		return resultsBucket.ForEach(func(k, v []byte) error {
+			result, err := deserializeMissionControlResult(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, result)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ResetMissionControlResults removes all results from the history of mission
+// control.
+func (d *DB) ResetMissionControlResults() error {
+	return d.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(missionControlBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		return nil
+	})
+}
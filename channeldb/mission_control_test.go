@@ -0,0 +1,78 @@
+package channeldb
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestMissionControlResults tests that results added to the history of
+// mission control are returned in the order they were added, that only the
+// latest results are kept, and that the history can be reset.
+func TestMissionControlResults(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Without any results added, an empty history should be returned.
+	results, err := db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results, got %v", len(results))
+	}
+
+	const (
+		numResults = 10
+		maxResults = 6
+	)
+	expected := make([]*MissionControlResult, numResults)
+	for i := 0; i < numResults; i++ {
+		result := &MissionControlResult{
+			ChannelID: lnwire.NewShortChanIDFromInt(
+				uint64(rand.Int63()),
+			),
+			Amount:    lnwire.MilliSatoshi(rand.Int63()),
+			Success:   i%2 == 0,
+			Timestamp: time.Unix(0, rand.Int63()),
+		}
+
+		err := db.AddMissionControlResult(result, maxResults)
+		if err != nil {
+			t.Fatalf("unable to add result: %v", err)
+		}
+		expected[i] = result
+	}
+
+	// Only the latest results should have been kept.
+	results, err = db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if !reflect.DeepEqual(expected[numResults-maxResults:], results) {
+		t.Fatalf("result mismatch: expected %v, got %v",
+			spew.Sdump(expected[numResults-maxResults:]),
+			spew.Sdump(results))
+	}
+
+	// After resetting the history, no results should remain.
+	if err := db.ResetMissionControlResults(); err != nil {
+		t.Fatalf("unable to reset results: %v", err)
+	}
+	results, err = db.FetchMissionControlResults()
+	if err != nil {
+		t.Fatalf("unable to fetch results: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results, got %v", len(results))
+	}
+}
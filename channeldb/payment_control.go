@@ -0,0 +1,1089 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// paymentsRootBucket is the name of the top-level bucket within the
+	// database that stores the lifecycle of all payments initiated by the
+	// daemon. Within this bucket, each payment has its own sub-bucket keyed
+	// by its payment hash.
+	//
+	// Bucket hierarchy:
+	//
+	// root-bucket
+	//      |
+	//      |-- <paymenthash>
+	//      |        |--sequence-key: <sequence number>
+	//      |        |--creation-info-key: <creation info>
+	//      |        |--fail-info-key: <(optional) fail info>
+	//      |        |
+	//      |        |--payment-htlcs-bucket
+	//      |                 |
+	//      |                 |-- <attempt id>
+	//      |                 |       |--htlc-attempt-info-key: <attempt info>
+	//      |                 |       |--htlc-settle-info-key: <(optional) settle info>
+	//      |                 |       |--htlc-fail-info-key: <(optional) fail info>
+	//      |                 |
+	//      |                 |-- <attempt id>
+	//      |                 |       |
+	//      |                ...     ...
+	//      |
+	//      |-- <paymenthash>
+	//      |        |
+	//     ...      ...
+	paymentsRootBucket = []byte("payments-root-bucket")
+
+	// paymentsIndexBucket is the name of the top-level bucket within the
+	// database that indexes all payments within the payments root bucket
+	// by their sequence number, such that payments can be traversed in the
+	// order they were initiated. Each entry maps the big-endian sequence
+	// number of a payment to its payment hash.
+	paymentsIndexBucket = []byte("payments-index-bucket")
+
+	// paymentSequenceKey is a key used in the payment's sub-bucket to
+	// store the sequence number of the payment.
+	paymentSequenceKey = []byte("payment-sequence-key")
+
+	// paymentCreationInfoKey is a key used in the payment's sub-bucket to
+	// store the creation info of the payment.
+	paymentCreationInfoKey = []byte("payment-creation-info")
+
+	// paymentFailInfoKey is a key used in the payment's sub-bucket to
+	// store the reason the payment failed, if it did.
+	paymentFailInfoKey = []byte("payment-fail-info")
+
+	// paymentHtlcsBucket is the name of the sub-bucket within the
+	// payment's bucket that stores all HTLC attempts made for the
+	// payment, keyed by their attempt ID.
+	paymentHtlcsBucket = []byte("payment-htlcs-bucket")
+
+	// htlcAttemptInfoKey is a key used in an HTLC attempt's bucket to
+	// store the info about the attempt that was made.
+	htlcAttemptInfoKey = []byte("htlc-attempt-info")
+
+	// htlcSettleInfoKey is a key used in an HTLC attempt's bucket to store
+	// the settle info, if the attempt was settled.
+	htlcSettleInfoKey = []byte("htlc-settle-info")
+
+	// htlcFailInfoKey is a key used in an HTLC attempt's bucket to store
+	// the failure info, if the attempt failed.
+	htlcFailInfoKey = []byte("htlc-fail-info")
+)
+
+// PaymentStatus represents the current status of a payment.
+type PaymentStatus byte
+
+const (
+	// StatusUnknown is the status where a payment has never been
+	// initiated and hence is unknown.
+	StatusUnknown PaymentStatus = 0
+
+	// StatusInFlight is the status where a payment has been initiated,
+	// but a response has not been received yet.
+	StatusInFlight PaymentStatus = 1
+
+	// StatusSucceeded is the status where a payment has been initiated
+	// and the payment was completed successfully.
+	StatusSucceeded PaymentStatus = 2
+
+	// StatusFailed is the status where a payment has been initiated and
+	// a failure result has come back.
+	StatusFailed PaymentStatus = 3
+)
+
+// String returns a human readable identifier for the PaymentStatus type.
+func (ps PaymentStatus) String() string {
+	switch ps {
+	case StatusUnknown:
+		return "Unknown"
+	case StatusInFlight:
+		return "In Flight"
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// FailureReason encodes the reason a payment ultimately failed.
+type FailureReason byte
+
+const (
+	// FailureReasonTimeout indicates that the payment did timeout before
+	// a successful payment attempt was made.
+	FailureReasonTimeout FailureReason = 0
+
+	// FailureReasonNoRoute indicates no successful route to the
+	// destination was found during path finding.
+	FailureReasonNoRoute FailureReason = 1
+
+	// FailureReasonError indicates that an unexpected error happened
+	// during payment, or that an HTLC attempt failed with an error that
+	// can't be recovered from.
+	FailureReasonError FailureReason = 2
+
+	// FailureReasonIncorrectPaymentDetails indicates that either the hash
+	// is unknown or the final cltv delta or amount is incorrect.
+	FailureReasonIncorrectPaymentDetails FailureReason = 3
+)
+
+// String returns a human readable FailureReason.
+func (r FailureReason) String() string {
+	switch r {
+	case FailureReasonTimeout:
+		return "timeout"
+	case FailureReasonNoRoute:
+		return "no_route"
+	case FailureReasonError:
+		return "error"
+	case FailureReasonIncorrectPaymentDetails:
+		return "incorrect_payment_details"
+	}
+
+	return "unknown"
+}
+
+// PaymentCreationInfo is the information necessary to have ready when
+// initiating a payment, moving it into state InFlight.
+type PaymentCreationInfo struct {
+	// PaymentHash is the hash this payment is paying to.
+	PaymentHash [32]byte
+
+	// Value is the amount we are paying.
+	Value lnwire.MilliSatoshi
+
+	// CreationDate is the time when this payment was initiated.
+	CreationDate time.Time
+
+	// PaymentRequest is the full payment request, if any.
+	PaymentRequest []byte
+}
+
+// PaymentHop is a single hop within the route of an HTLC attempt.
+type PaymentHop struct {
+	// PubKeyBytes is the raw bytes of the public key of the target node.
+	PubKeyBytes [33]byte
+
+	// ChannelID is the unique channel ID for the channel. The first 3
+	// bytes are the block height, the next 3 the index within the block,
+	// and the last 2 bytes are the output index for the channel.
+	ChannelID uint64
+
+	// OutgoingTimeLock is the timelock value that should be used when
+	// crafting the _outgoing_ HTLC from this hop.
+	OutgoingTimeLock uint32
+
+	// AmtToForward is the amount that this hop will forward to the next
+	// hop. This value is less than the value that the incoming HTLC
+	// carries as a fee will be subtracted by the hop.
+	AmtToForward lnwire.MilliSatoshi
+}
+
+// PaymentRoute is the route an HTLC attempt was sent along. It holds the
+// subset of the route found during path finding that's needed to reconstruct
+// the onion of the attempt, and to describe the attempt to the user.
+type PaymentRoute struct {
+	// TotalTimeLock is the cumulative (final) time lock across the entire
+	// route. This is the CLTV value that was extended to the first hop in
+	// the route.
+	TotalTimeLock uint32
+
+	// TotalAmount is the total amount of funds that was sent along this
+	// route, including the cumulative fees at each hop.
+	TotalAmount lnwire.MilliSatoshi
+
+	// TotalFees is the sum of the fees paid at each hop within the route.
+	TotalFees lnwire.MilliSatoshi
+
+	// Hops contains details concerning the specific forwarding details at
+	// each hop.
+	Hops []*PaymentHop
+}
+
+// HTLCAttemptInfo contains static information about a specific HTLC attempt
+// for a payment. This information is used by the router to handle any errors
+// coming back after an attempt is made, and to query the switch about the
+// status of the attempt.
+type HTLCAttemptInfo struct {
+	// AttemptID is the unique ID used for this attempt. This is also the
+	// ID the HTLC is known by within the switch.
+	AttemptID uint64
+
+	// SessionKey is the ephemeral key used for this attempt, which allows
+	// the onion of the attempt to be reconstructed in order to decrypt
+	// any failure that comes back.
+	SessionKey *btcec.PrivateKey
+
+	// Route is the route attempted to send the HTLC.
+	Route PaymentRoute
+
+	// AttemptTime is the time at which this HTLC was attempted.
+	AttemptTime time.Time
+}
+
+// HTLCSettleInfo encapsulates the information that augments an HTLC attempt
+// in the event that the HTLC is successful.
+type HTLCSettleInfo struct {
+	// Preimage is the preimage of a successful HTLC. This serves as a
+	// proof of payment.
+	Preimage [32]byte
+
+	// SettleTime is the time at which this HTLC was settled.
+	SettleTime time.Time
+}
+
+// HTLCFailReason is the reason an HTLC attempt failed.
+type HTLCFailReason byte
+
+const (
+	// HTLCFailUnknown is recorded for htlcs that failed with an unknown
+	// reason, such as a failure message returned by a node that isn't
+	// part of the route of the attempt.
+	HTLCFailUnknown HTLCFailReason = 0
+
+	// HTLCFailInternal is recorded for htlcs that failed because of an
+	// internal error, before a failure message was received from the
+	// network.
+	HTLCFailInternal HTLCFailReason = 1
+
+	// HTLCFailMessage is recorded for htlcs that failed with a network
+	// failure message.
+	HTLCFailMessage HTLCFailReason = 2
+)
+
+// String returns a human readable HTLCFailReason.
+func (r HTLCFailReason) String() string {
+	switch r {
+	case HTLCFailUnknown:
+		return "unknown"
+	case HTLCFailInternal:
+		return "internal"
+	case HTLCFailMessage:
+		return "message"
+	}
+
+	return "unknown"
+}
+
+// HTLCFailInfo encapsulates the information that augments an HTLC attempt in
+// the event that the HTLC fails.
+type HTLCFailInfo struct {
+	// FailTime is the time at which this HTLC was failed.
+	FailTime time.Time
+
+	// Reason is the failure reason for this HTLC.
+	Reason HTLCFailReason
+
+	// Message is the wire message that failed this HTLC. This field will
+	// be nil for HTLCs that failed with an internal error.
+	Message lnwire.FailureMessage
+
+	// FailureSourceIndex is the index of the node that generated the
+	// failure within the route of the attempt. Index zero is our own
+	// node, and index i is the node at the end of the i'th hop of the
+	// route.
+	FailureSourceIndex uint32
+}
+
+// HTLCAttempt contains information about a specific HTLC attempt for a given
+// payment. It contains the HTLCAttemptInfo used to send the HTLC, as well as
+// a timestamp and any known outcome of the attempt.
+type HTLCAttempt struct {
+	HTLCAttemptInfo
+
+	// Settle is the preimage of a successful payment. This serves as a
+	// proof of payment. It will only be non-nil for settled payments.
+	Settle *HTLCSettleInfo
+
+	// Failure will be non-nil if the HTLC failed.
+	Failure *HTLCFailInfo
+}
+
+// Payment is the record of a payment, as tracked by the payment control
+// tower. It holds the creation info of the payment along with all HTLC
+// attempts that were made for it, and the final outcome of the payment once
+// known.
+type Payment struct {
+	// SequenceNum is a unique identifier used to sort the payments in
+	// order of creation.
+	SequenceNum uint64
+
+	// Info holds all static information about this payment, and is
+	// populated when the payment is initiated.
+	Info *PaymentCreationInfo
+
+	// HTLCs holds the information about individual HTLCs that we send in
+	// order to make the payment, in the order they were attempted.
+	HTLCs []HTLCAttempt
+
+	// FailureReason is the failure reason code indicating the reason the
+	// payment failed. It is only non-nil once the payment has been failed.
+	FailureReason *FailureReason
+
+	// Status is the current PaymentStatus of this payment.
+	Status PaymentStatus
+}
+
+// InFlightHTLCs returns the HTLC attempts of the payment that haven't yet been
+// settled or failed.
+func (p *Payment) InFlightHTLCs() []HTLCAttempt {
+	var inflights []HTLCAttempt
+	for _, h := range p.HTLCs {
+		if h.Settle != nil || h.Failure != nil {
+			continue
+		}
+
+		inflights = append(inflights, h)
+	}
+
+	return inflights
+}
+
+// SettledHTLC returns the HTLC attempt that settled the payment, or nil if
+// the payment hasn't been settled.
+func (p *Payment) SettledHTLC() *HTLCAttempt {
+	for i := range p.HTLCs {
+		if p.HTLCs[i].Settle != nil {
+			return &p.HTLCs[i]
+		}
+	}
+
+	return nil
+}
+
+// PaymentControl implements persistence for payments and payment attempts.
+// Each payment is tracked from the moment it's initiated, through every HTLC
+// attempt made for it, until its final outcome is known. This allows the
+// outcome of a payment to be determined even if the daemon restarts while an
+// HTLC attempt is in flight, and prevents paying the same payment hash twice.
+type PaymentControl struct {
+	db *DB
+}
+
+// NewPaymentControl creates a new instance of the PaymentControl.
+func NewPaymentControl(db *DB) *PaymentControl {
+	return &PaymentControl{
+		db: db,
+	}
+}
+
+// InitPayment checks or records the given PaymentCreationInfo with the DB,
+// making sure it does not already exist as an in-flight or succeeded payment.
+// Payments that previously failed may be initiated again, in which case the
+// record of the failed payment is replaced.
+func (p *PaymentControl) InitPayment(paymentHash [32]byte,
+	info *PaymentCreationInfo) error {
+
+	var b bytes.Buffer
+	if err := serializePaymentCreationInfo(&b, info); err != nil {
+		return err
+	}
+	infoBytes := b.Bytes()
+
+	return p.db.Update(func(tx *bolt.Tx) error {
+		payments, err := tx.CreateBucketIfNotExists(paymentsRootBucket)
+		if err != nil {
+			return err
+		}
+
+		// If a payment to this hash already exists, then we'll check
+		// its status to determine whether it may be initiated again.
+		if bucket := payments.Bucket(paymentHash[:]); bucket != nil {
+			payment, err := fetchPayment(bucket)
+			if err != nil {
+				return err
+			}
+
+			switch payment.Status {
+
+			// We allow retrying failed payments, in which case
+			// we'll replace the record of the failed payment.
+			case StatusFailed:
+				err := payments.DeleteBucket(paymentHash[:])
+				if err != nil {
+					return err
+				}
+
+				err = unindexPayment(tx, payment.SequenceNum)
+				if err != nil {
+					return err
+				}
+
+			// We already have an in-flight payment to this hash.
+			case StatusInFlight:
+				return ErrPaymentInFlight
+
+			// The payment has already succeeded, so it can't be
+			// initiated again.
+			case StatusSucceeded:
+				return ErrAlreadyPaid
+
+			default:
+				return ErrUnknownPaymentStatus
+			}
+		}
+
+		bucket, err := payments.CreateBucket(paymentHash[:])
+		if err != nil {
+			return err
+		}
+
+		// Obtain a new sequence number for this payment, which allows
+		// payments to be ordered by the time they were initiated.
+		sequenceNum, err := payments.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], sequenceNum)
+		if err := bucket.Put(paymentSequenceKey, seqBytes[:]); err != nil {
+			return err
+		}
+
+		err = indexPayment(tx, sequenceNum, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(paymentCreationInfoKey, infoBytes)
+	})
+}
+
+// indexPayment adds the payment with the given sequence number to the
+// payments index.
+func indexPayment(tx *bolt.Tx, sequenceNum uint64,
+	paymentHash [32]byte) error {
+
+	index, err := tx.CreateBucketIfNotExists(paymentsIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	var seqBytes [8]byte
+	byteOrder.PutUint64(seqBytes[:], sequenceNum)
+	return index.Put(seqBytes[:], paymentHash[:])
+}
+
+// unindexPayment removes the payment with the given sequence number from the
+// payments index.
+func unindexPayment(tx *bolt.Tx, sequenceNum uint64) error {
+	index := tx.Bucket(paymentsIndexBucket)
+	if index == nil {
+		return nil
+	}
+
+	var seqBytes [8]byte
+	byteOrder.PutUint64(seqBytes[:], sequenceNum)
+	return index.Delete(seqBytes[:])
+}
+
+// RegisterAttempt atomically records the provided HTLCAttemptInfo to the DB.
+// This MUST be done before the HTLC is handed to the switch, such that the
+// attempt can be re-attached to after a restart. The updated payment is
+// returned.
+func (p *PaymentControl) RegisterAttempt(paymentHash [32]byte,
+	attempt *HTLCAttemptInfo) (*Payment, error) {
+
+	var b bytes.Buffer
+	if err := serializeHTLCAttemptInfo(&b, attempt); err != nil {
+		return nil, err
+	}
+	attemptBytes := b.Bytes()
+
+	var payment *Payment
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := fetchPaymentBucket(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		if err != nil {
+			return err
+		}
+
+		// New attempts can only be registered for payments that are
+		// still in flight, and haven't been failed by the router.
+		switch {
+		case payment.Status == StatusSucceeded:
+			return ErrPaymentAlreadySucceeded
+		case payment.FailureReason != nil:
+			return ErrPaymentAlreadyFailed
+		}
+
+		htlcsBucket, err := bucket.CreateBucketIfNotExists(
+			paymentHtlcsBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var attemptKey [8]byte
+		byteOrder.PutUint64(attemptKey[:], attempt.AttemptID)
+		if htlcsBucket.Bucket(attemptKey[:]) != nil {
+			return ErrAttemptAlreadyExists
+		}
+
+		htlcBucket, err := htlcsBucket.CreateBucket(attemptKey[:])
+		if err != nil {
+			return err
+		}
+
+		err = htlcBucket.Put(htlcAttemptInfoKey, attemptBytes)
+		if err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// SettleAttempt marks the given attempt settled with the preimage. If this is
+// the first settled attempt, the payment as a whole will be marked as
+// succeeded. The updated payment is returned.
+func (p *PaymentControl) SettleAttempt(paymentHash [32]byte,
+	attemptID uint64, settleInfo *HTLCSettleInfo) (*Payment, error) {
+
+	var b bytes.Buffer
+	if err := serializeHTLCSettleInfo(&b, settleInfo); err != nil {
+		return nil, err
+	}
+
+	return p.updateHtlcKey(paymentHash, attemptID, htlcSettleInfoKey, b.Bytes())
+}
+
+// FailAttempt marks the given payment attempt failed. The updated payment is
+// returned.
+func (p *PaymentControl) FailAttempt(paymentHash [32]byte,
+	attemptID uint64, failInfo *HTLCFailInfo) (*Payment, error) {
+
+	var b bytes.Buffer
+	if err := serializeHTLCFailInfo(&b, failInfo); err != nil {
+		return nil, err
+	}
+
+	return p.updateHtlcKey(paymentHash, attemptID, htlcFailInfoKey, b.Bytes())
+}
+
+// updateHtlcKey records the outcome of the given HTLC attempt under the
+// passed key, making sure the attempt hasn't been resolved already.
+func (p *PaymentControl) updateHtlcKey(paymentHash [32]byte,
+	attemptID uint64, key, value []byte) (*Payment, error) {
+
+	var payment *Payment
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := fetchPaymentBucket(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		htlcsBucket := bucket.Bucket(paymentHtlcsBucket)
+		if htlcsBucket == nil {
+			return ErrAttemptNotFound
+		}
+
+		var attemptKey [8]byte
+		byteOrder.PutUint64(attemptKey[:], attemptID)
+		htlcBucket := htlcsBucket.Bucket(attemptKey[:])
+		if htlcBucket == nil {
+			return ErrAttemptNotFound
+		}
+
+		// An attempt can only be resolved once.
+		if htlcBucket.Get(htlcSettleInfoKey) != nil ||
+			htlcBucket.Get(htlcFailInfoKey) != nil {
+
+			return ErrAttemptAlreadyResolved
+		}
+
+		if err := htlcBucket.Put(key, value); err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// Fail transitions a payment into the Failed state, and records the reason
+// the payment failed. After invoking this method, InitPayment should return
+// nil on its next call for this payment hash, allowing the switch to make a
+// subsequent payment. If any HTLC attempts are still in flight, then the
+// payment remains in flight until they're resolved. The updated payment is
+// returned.
+func (p *PaymentControl) Fail(paymentHash [32]byte,
+	reason FailureReason) (*Payment, error) {
+
+	var payment *Payment
+	err := p.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := fetchPaymentBucket(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		if err != nil {
+			return err
+		}
+
+		if payment.Status == StatusSucceeded {
+			return ErrPaymentAlreadySucceeded
+		}
+
+		err = bucket.Put(paymentFailInfoKey, []byte{byte(reason)})
+		if err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// FetchPayment returns information about a payment from the database.
+func (p *PaymentControl) FetchPayment(paymentHash [32]byte) (*Payment, error) {
+	var payment *Payment
+	err := p.db.View(func(tx *bolt.Tx) error {
+		bucket, err := fetchPaymentBucket(tx, paymentHash)
+		if err != nil {
+			return err
+		}
+
+		payment, err = fetchPayment(bucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// FetchInFlightPayments returns all payments with status InFlight.
+func (p *PaymentControl) FetchInFlightPayments() ([]*Payment, error) {
+	var inFlights []*Payment
+	err := p.db.View(func(tx *bolt.Tx) error {
+		payments := tx.Bucket(paymentsRootBucket)
+		if payments == nil {
+			return nil
+		}
+
+		return payments.ForEach(func(k, _ []byte) error {
+			bucket := payments.Bucket(k)
+			if bucket == nil {
+				return nil
+			}
+
+			payment, err := fetchPayment(bucket)
+			if err != nil {
+				return err
+			}
+
+			if payment.Status != StatusInFlight {
+				return nil
+			}
+
+			inFlights = append(inFlights, payment)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inFlights, nil
+}
+
+// fetchPaymentBucket returns the sub-bucket of the payment to the passed
+// payment hash, or ErrPaymentNotInitiated if no such payment exists.
+func fetchPaymentBucket(tx *bolt.Tx, paymentHash [32]byte) (*bolt.Bucket,
+	error) {
+
+	payments := tx.Bucket(paymentsRootBucket)
+	if payments == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+
+	bucket := payments.Bucket(paymentHash[:])
+	if bucket == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+
+	return bucket, nil
+}
+
+// fetchPayment reads the payment stored within the passed payment bucket,
+// and derives its current status.
+func fetchPayment(bucket *bolt.Bucket) (*Payment, error) {
+	payment := &Payment{}
+
+	seqBytes := bucket.Get(paymentSequenceKey)
+	if seqBytes == nil {
+		return nil, ErrNoSequenceNumber
+	}
+	payment.SequenceNum = byteOrder.Uint64(seqBytes)
+
+	infoBytes := bucket.Get(paymentCreationInfoKey)
+	if infoBytes == nil {
+		return nil, ErrPaymentNotInitiated
+	}
+	info, err := deserializePaymentCreationInfo(bytes.NewReader(infoBytes))
+	if err != nil {
+		return nil, err
+	}
+	payment.Info = info
+
+	if htlcsBucket := bucket.Bucket(paymentHtlcsBucket); htlcsBucket != nil {
+		payment.HTLCs, err = fetchHtlcAttempts(htlcsBucket)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if failBytes := bucket.Get(paymentFailInfoKey); len(failBytes) > 0 {
+		reason := FailureReason(failBytes[0])
+		payment.FailureReason = &reason
+	}
+
+	// With all the information read, we'll derive the status of the
+	// payment. A single settled attempt means the payment succeeded, while
+	// the payment is only considered failed once it has been failed and
+	// none of its attempts are still in flight.
+	switch {
+	case payment.SettledHTLC() != nil:
+		payment.Status = StatusSucceeded
+	case len(payment.InFlightHTLCs()) > 0:
+		payment.Status = StatusInFlight
+	case payment.FailureReason != nil:
+		payment.Status = StatusFailed
+	default:
+		payment.Status = StatusInFlight
+	}
+
+	return payment, nil
+}
+
+// fetchHtlcAttempts reads all HTLC attempts stored within the passed bucket,
+// ordered by their attempt ID.
+func fetchHtlcAttempts(bucket *bolt.Bucket) ([]HTLCAttempt, error) {
+	var htlcs []HTLCAttempt
+	err := bucket.ForEach(func(k, _ []byte) error {
+		htlcBucket := bucket.Bucket(k)
+		if htlcBucket == nil {
+			return nil
+		}
+
+		attemptBytes := htlcBucket.Get(htlcAttemptInfoKey)
+		if attemptBytes == nil {
+			return ErrAttemptNotFound
+		}
+		info, err := deserializeHTLCAttemptInfo(
+			bytes.NewReader(attemptBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		htlc := HTLCAttempt{
+			HTLCAttemptInfo: *info,
+		}
+
+		if settleBytes := htlcBucket.Get(htlcSettleInfoKey); settleBytes != nil {
+			htlc.Settle, err = deserializeHTLCSettleInfo(
+				bytes.NewReader(settleBytes),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if failBytes := htlcBucket.Get(htlcFailInfoKey); failBytes != nil {
+			htlc.Failure, err = deserializeHTLCFailInfo(
+				bytes.NewReader(failBytes),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		htlcs = append(htlcs, htlc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return htlcs, nil
+}
+
+// serializeTime writes the passed time as the number of nanoseconds since the
+// unix epoch. The zero time is written as zero.
+func serializeTime(w io.Writer, t time.Time) error {
+	var unixNano uint64
+	if !t.IsZero() {
+		unixNano = uint64(t.UnixNano())
+	}
+
+	return writeElement(w, unixNano)
+}
+
+// deserializeTime reads a time written by serializeTime.
+func deserializeTime(r io.Reader) (time.Time, error) {
+	var unixNano uint64
+	if err := readElement(r, &unixNano); err != nil {
+		return time.Time{}, err
+	}
+
+	if unixNano == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(0, int64(unixNano)), nil
+}
+
+func serializePaymentCreationInfo(w io.Writer, c *PaymentCreationInfo) error {
+	if err := writeElements(w, c.PaymentHash, c.Value); err != nil {
+		return err
+	}
+
+	if err := serializeTime(w, c.CreationDate); err != nil {
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, c.PaymentRequest)
+}
+
+func deserializePaymentCreationInfo(r io.Reader) (*PaymentCreationInfo, error) {
+	c := &PaymentCreationInfo{}
+
+	if err := readElements(r, &c.PaymentHash, &c.Value); err != nil {
+		return nil, err
+	}
+
+	var err error
+	c.CreationDate, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c.PaymentRequest, err = wire.ReadVarBytes(
+		r, 0, MaxPaymentRequestSize, "payreq",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func serializePaymentRoute(w io.Writer, route *PaymentRoute) error {
+	err := writeElements(w,
+		route.TotalTimeLock, route.TotalAmount, route.TotalFees,
+		uint32(len(route.Hops)),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, hop := range route.Hops {
+		if _, err := w.Write(hop.PubKeyBytes[:]); err != nil {
+			return err
+		}
+
+		err := writeElements(w,
+			hop.ChannelID, hop.OutgoingTimeLock, hop.AmtToForward,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializePaymentRoute(r io.Reader) (*PaymentRoute, error) {
+	route := &PaymentRoute{}
+
+	var numHops uint32
+	err := readElements(r,
+		&route.TotalTimeLock, &route.TotalAmount, &route.TotalFees,
+		&numHops,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	route.Hops = make([]*PaymentHop, numHops)
+	for i := uint32(0); i < numHops; i++ {
+		hop := &PaymentHop{}
+		if _, err := io.ReadFull(r, hop.PubKeyBytes[:]); err != nil {
+			return nil, err
+		}
+
+		err := readElements(r,
+			&hop.ChannelID, &hop.OutgoingTimeLock, &hop.AmtToForward,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		route.Hops[i] = hop
+	}
+
+	return route, nil
+}
+
+func serializeHTLCAttemptInfo(w io.Writer, a *HTLCAttemptInfo) error {
+	// Attempts migrated from the legacy payments bucket don't carry a
+	// session key, in which case an empty key is written.
+	var sessionKey []byte
+	if a.SessionKey != nil {
+		sessionKey = a.SessionKey.Serialize()
+	}
+
+	err := writeElements(w, a.AttemptID, sessionKey)
+	if err != nil {
+		return err
+	}
+
+	if err := serializeTime(w, a.AttemptTime); err != nil {
+		return err
+	}
+
+	return serializePaymentRoute(w, &a.Route)
+}
+
+func deserializeHTLCAttemptInfo(r io.Reader) (*HTLCAttemptInfo, error) {
+	a := &HTLCAttemptInfo{}
+
+	var sessionKey []byte
+	if err := readElements(r, &a.AttemptID, &sessionKey); err != nil {
+		return nil, err
+	}
+	if len(sessionKey) > 0 {
+		a.SessionKey, _ = btcec.PrivKeyFromBytes(
+			btcec.S256(), sessionKey,
+		)
+	}
+
+	var err error
+	a.AttemptTime, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := deserializePaymentRoute(r)
+	if err != nil {
+		return nil, err
+	}
+	a.Route = *route
+
+	return a, nil
+}
+
+func serializeHTLCSettleInfo(w io.Writer, s *HTLCSettleInfo) error {
+	if _, err := w.Write(s.Preimage[:]); err != nil {
+		return err
+	}
+
+	return serializeTime(w, s.SettleTime)
+}
+
+func deserializeHTLCSettleInfo(r io.Reader) (*HTLCSettleInfo, error) {
+	s := &HTLCSettleInfo{}
+	if _, err := io.ReadFull(r, s.Preimage[:]); err != nil {
+		return nil, err
+	}
+
+	var err error
+	s.SettleTime, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func serializeHTLCFailInfo(w io.Writer, f *HTLCFailInfo) error {
+	if err := serializeTime(w, f.FailTime); err != nil {
+		return err
+	}
+
+	// The failure message is written with the padding mandated by the
+	// wire protocol, and prefixed by its total length. Failures without
+	// a message are written as an empty byte slice.
+	var messageBytes bytes.Buffer
+	if f.Message != nil {
+		err := lnwire.EncodeFailure(&messageBytes, f.Message, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte{byte(f.Reason)}); err != nil {
+		return err
+	}
+
+	return writeElements(w, f.FailureSourceIndex, messageBytes.Bytes())
+}
+
+func deserializeHTLCFailInfo(r io.Reader) (*HTLCFailInfo, error) {
+	f := &HTLCFailInfo{}
+
+	var err error
+	f.FailTime, err = deserializeTime(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var reason [1]byte
+	if _, err := io.ReadFull(r, reason[:]); err != nil {
+		return nil, err
+	}
+	f.Reason = HTLCFailReason(reason[0])
+
+	var messageBytes []byte
+	err = readElements(r, &f.FailureSourceIndex, &messageBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messageBytes) > 0 {
+		f.Message, err = lnwire.DecodeFailure(
+			bytes.NewReader(messageBytes), 0,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
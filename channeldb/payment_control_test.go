@@ -0,0 +1,406 @@
+package channeldb
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+func genPreimage() ([32]byte, error) {
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		return preimage, err
+	}
+	return preimage, nil
+}
+
+func genInfo() (*PaymentCreationInfo, *HTLCAttemptInfo, [32]byte, error) {
+	preimage, err := genPreimage()
+	if err != nil {
+		return nil, nil, preimage, err
+	}
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, nil, preimage, err
+	}
+
+	var hop PaymentHop
+	copy(hop.PubKeyBytes[:], pubKey.SerializeCompressed())
+	hop.ChannelID = 12345
+	hop.OutgoingTimeLock = 111
+	hop.AmtToForward = 1000
+
+	rhash := sha256.Sum256(preimage[:])
+	creationInfo := &PaymentCreationInfo{
+		PaymentHash: rhash,
+		Value:       1000,
+		// Use single second precision to avoid false positive test
+		// failures due to the monotonic time component.
+		CreationDate:   time.Unix(time.Now().Unix(), 0),
+		PaymentRequest: []byte("hola"),
+	}
+	attempt := &HTLCAttemptInfo{
+		AttemptID:  1,
+		SessionKey: sessionKey,
+		Route: PaymentRoute{
+			TotalTimeLock: 123,
+			TotalAmount:   1010,
+			TotalFees:     10,
+			Hops:          []*PaymentHop{&hop},
+		},
+		AttemptTime: time.Unix(time.Now().Unix(), 0),
+	}
+
+	return creationInfo, attempt, preimage, nil
+}
+
+func assertPaymentStatus(t *testing.T, pControl *PaymentControl,
+	hash [32]byte, expStatus PaymentStatus) *Payment {
+
+	t.Helper()
+
+	payment, err := pControl.FetchPayment(hash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+
+	if payment.Status != expStatus {
+		t.Fatalf("payment status mismatch: expected %v, got %v",
+			expStatus, payment.Status)
+	}
+
+	return payment
+}
+
+// TestPaymentControlSwitchFail checks that payment status returns to Failed
+// status after failing, and that InitPayment allows another HTLC for the same
+// payment hash.
+func TestPaymentControlSwitchFail(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to init db: %v", err)
+	}
+	defer cleanup()
+
+	pControl := NewPaymentControl(db)
+
+	info, attempt, preimg, err := genInfo()
+	if err != nil {
+		t.Fatalf("unable to generate htlc message: %v", err)
+	}
+
+	// Sends base htlc message which initiate StatusInFlight.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != nil {
+		t.Fatalf("unable to send htlc message: %v", err)
+	}
+	assertPaymentStatus(t, pControl, info.PaymentHash, StatusInFlight)
+
+	// Fail the payment, which should moved it to Failed.
+	_, err = pControl.Fail(info.PaymentHash, FailureReasonNoRoute)
+	if err != nil {
+		t.Fatalf("unable to fail payment hash: %v", err)
+	}
+	payment := assertPaymentStatus(
+		t, pControl, info.PaymentHash, StatusFailed,
+	)
+	if *payment.FailureReason != FailureReasonNoRoute {
+		t.Fatalf("expected failure reason %v, got %v",
+			FailureReasonNoRoute, *payment.FailureReason)
+	}
+
+	// Sends the htlc again, which should succeed since the prior payment
+	// failed.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != nil {
+		t.Fatalf("unable to send htlc message: %v", err)
+	}
+	payment = assertPaymentStatus(
+		t, pControl, info.PaymentHash, StatusInFlight,
+	)
+	if payment.FailureReason != nil {
+		t.Fatalf("expected failure reason to be reset")
+	}
+
+	// Record a new attempt, and fail it. The payment should still be in
+	// flight, as it hasn't been failed by the router.
+	_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	failInfo := &HTLCFailInfo{
+		FailTime:           time.Unix(time.Now().Unix(), 0),
+		Reason:             HTLCFailMessage,
+		Message:            &lnwire.FailUnknownPaymentHash{},
+		FailureSourceIndex: 1,
+	}
+	_, err = pControl.FailAttempt(
+		info.PaymentHash, attempt.AttemptID, failInfo,
+	)
+	if err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+	payment = assertPaymentStatus(
+		t, pControl, info.PaymentHash, StatusInFlight,
+	)
+
+	// The failure of the attempt, including the failure message, should
+	// be returned exactly as written.
+	if len(payment.HTLCs) != 1 {
+		t.Fatalf("expected 1 htlc, got %v", len(payment.HTLCs))
+	}
+	if !reflect.DeepEqual(payment.HTLCs[0].Failure, failInfo) {
+		t.Fatalf("fail info mismatch: expected %v, got %v",
+			spew.Sdump(failInfo),
+			spew.Sdump(payment.HTLCs[0].Failure))
+	}
+
+	// An attempt can't be resolved twice.
+	_, err = pControl.FailAttempt(
+		info.PaymentHash, attempt.AttemptID, &HTLCFailInfo{},
+	)
+	if err != ErrAttemptAlreadyResolved {
+		t.Fatalf("expected ErrAttemptAlreadyResolved, got %v", err)
+	}
+
+	// Record another attempt, and settle it. This should move the payment
+	// into the Succeeded state.
+	attempt.AttemptID = 2
+	_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	payment, err = pControl.SettleAttempt(
+		info.PaymentHash, attempt.AttemptID,
+		&HTLCSettleInfo{Preimage: preimg},
+	)
+	if err != nil {
+		t.Fatalf("error shouldn't have been received, got: %v", err)
+	}
+	if payment.Status != StatusSucceeded {
+		t.Fatalf("expected status %v, got %v", StatusSucceeded,
+			payment.Status)
+	}
+	if len(payment.HTLCs) != 2 {
+		t.Fatalf("expected 2 htlcs, got %v", len(payment.HTLCs))
+	}
+	if payment.SettledHTLC().Settle.Preimage != preimg {
+		t.Fatalf("preimage mismatch")
+	}
+
+	// Attempt a final payment, which should now fail since the prior
+	// payment succeed.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != ErrAlreadyPaid {
+		t.Fatalf("unable to send htlc message: %v", err)
+	}
+}
+
+// TestPaymentControlSwitchDoubleSend checks the ability of payment control to
+// prevent double sending of htlc message, when message is in StatusInFlight.
+func TestPaymentControlSwitchDoubleSend(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to init db: %v", err)
+	}
+	defer cleanup()
+
+	pControl := NewPaymentControl(db)
+
+	info, attempt, _, err := genInfo()
+	if err != nil {
+		t.Fatalf("unable to generate htlc message: %v", err)
+	}
+
+	// Sends base htlc message which initiate base status and move it to
+	// StatusInFlight and verifies that it was changed.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != nil {
+		t.Fatalf("unable to send htlc message: %v", err)
+	}
+	assertPaymentStatus(t, pControl, info.PaymentHash, StatusInFlight)
+
+	// Try to initiate double sending of htlc message with the same
+	// payment hash, should result in error indicating that payment has
+	// already been sent.
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != ErrPaymentInFlight {
+		t.Fatalf("payment control wrong behaviour: " +
+			"double sending must trigger ErrPaymentInFlight error")
+	}
+
+	// Record an attempt, and fail the payment. As the attempt is still
+	// in flight, so is the payment.
+	_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	_, err = pControl.Fail(info.PaymentHash, FailureReasonTimeout)
+	if err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	assertPaymentStatus(t, pControl, info.PaymentHash, StatusInFlight)
+
+	err = pControl.InitPayment(info.PaymentHash, info)
+	if err != ErrPaymentInFlight {
+		t.Fatalf("payment control wrong behaviour: " +
+			"double sending must trigger ErrPaymentInFlight error")
+	}
+
+	// No new attempts can be made for a failed payment.
+	attempt.AttemptID++
+	_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != ErrPaymentAlreadyFailed {
+		t.Fatalf("expected ErrPaymentAlreadyFailed, got %v", err)
+	}
+}
+
+// TestPaymentControlFetchInFlight checks that the in-flight payments along
+// with their attempts can be retrieved from the database.
+func TestPaymentControlFetchInFlight(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to init db: %v", err)
+	}
+	defer cleanup()
+
+	pControl := NewPaymentControl(db)
+
+	// Without any payments, no in-flight payments should be returned.
+	inFlights, err := pControl.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in-flight payments: %v", err)
+	}
+	if len(inFlights) != 0 {
+		t.Fatalf("expected no in-flight payments, got %v",
+			len(inFlights))
+	}
+
+	// We'll create two payments, one which succeeds, and one which
+	// remains in flight.
+	settled, settledAttempt, preimg, err := genInfo()
+	if err != nil {
+		t.Fatalf("unable to generate htlc message: %v", err)
+	}
+	inFlight, inFlightAttempt, _, err := genInfo()
+	if err != nil {
+		t.Fatalf("unable to generate htlc message: %v", err)
+	}
+
+	for _, p := range []struct {
+		info    *PaymentCreationInfo
+		attempt *HTLCAttemptInfo
+	}{
+		{settled, settledAttempt},
+		{inFlight, inFlightAttempt},
+	} {
+		err := pControl.InitPayment(p.info.PaymentHash, p.info)
+		if err != nil {
+			t.Fatalf("unable to init payment: %v", err)
+		}
+		_, err = pControl.RegisterAttempt(p.info.PaymentHash, p.attempt)
+		if err != nil {
+			t.Fatalf("unable to register attempt: %v", err)
+		}
+	}
+
+	_, err = pControl.SettleAttempt(
+		settled.PaymentHash, settledAttempt.AttemptID,
+		&HTLCSettleInfo{Preimage: preimg},
+	)
+	if err != nil {
+		t.Fatalf("unable to settle attempt: %v", err)
+	}
+
+	inFlights, err = pControl.FetchInFlightPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch in-flight payments: %v", err)
+	}
+	if len(inFlights) != 1 {
+		t.Fatalf("expected 1 in-flight payment, got %v",
+			len(inFlights))
+	}
+
+	// The in-flight payment, along with its attempt, should be returned
+	// exactly as written.
+	payment := inFlights[0]
+	if !reflect.DeepEqual(payment.Info, inFlight) {
+		t.Fatalf("creation info mismatch: expected %v, got %v",
+			spew.Sdump(inFlight), spew.Sdump(payment.Info))
+	}
+	htlcs := payment.InFlightHTLCs()
+	if len(htlcs) != 1 {
+		t.Fatalf("expected 1 in-flight htlc, got %v", len(htlcs))
+	}
+	if !reflect.DeepEqual(&htlcs[0].HTLCAttemptInfo, inFlightAttempt) {
+		t.Fatalf("attempt info mismatch: expected %v, got %v",
+			spew.Sdump(inFlightAttempt),
+			spew.Sdump(htlcs[0].HTLCAttemptInfo))
+	}
+}
+
+// TestPaymentControlWithoutInit checks that attempts can't be registered or
+// resolved for payments that were never initiated.
+func TestPaymentControlWithoutInit(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to init db: %v", err)
+	}
+	defer cleanup()
+
+	pControl := NewPaymentControl(db)
+
+	info, attempt, preimg, err := genInfo()
+	if err != nil {
+		t.Fatalf("unable to generate htlc message: %v", err)
+	}
+
+	_, err = pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	_, err = pControl.SettleAttempt(
+		info.PaymentHash, attempt.AttemptID,
+		&HTLCSettleInfo{Preimage: preimg},
+	)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	_, err = pControl.Fail(info.PaymentHash, FailureReasonError)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	_, err = pControl.FetchPayment(info.PaymentHash)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	// Once initiated, resolving an unknown attempt should fail.
+	if err := pControl.InitPayment(info.PaymentHash, info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	_, err = pControl.FailAttempt(
+		info.PaymentHash, attempt.AttemptID, &HTLCFailInfo{},
+	)
+	if err != ErrAttemptNotFound {
+		t.Fatalf("expected ErrAttemptNotFound, got %v", err)
+	}
+}
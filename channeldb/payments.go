@@ -1,17 +1,20 @@
 package channeldb
 
 import (
-	"bytes"
-	"encoding/binary"
+	"fmt"
 	"io"
+	"sort"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
 var (
-	// paymentBucket is the name of the bucket within the database that
-	// stores all data related to payments.
+	// paymentBucket is the name of the legacy bucket within the database
+	// that stored all successful payments, before payments were tracked
+	// by the payment control tower. Its contents are migrated into the
+	// payments root bucket.
 	//
 	// Within the payments bucket, each invoice is keyed by its invoice ID
 	// which is a monotonically increasing uint64.  BoltDB's sequence
@@ -19,9 +22,17 @@ var (
 	paymentBucket = []byte("payments")
 )
 
+// paymentLayoutIndexed is the layout version prefixing every serialized
+// payment whose embedded invoice carries its add and settle index. Payments
+// stored before invoices were assigned these indexes have neither the version
+// prefix nor the indexes, and are rewritten in this layout by the migration
+// that introduced the indexes.
+const paymentLayoutIndexed byte = 1
+
 // OutgoingPayment represents a successful payment between the daemon and a
-// remote node. Details such as the total fee paid, and the time of the payment
-// are stored.
+// remote node, in the format successful payments were stored in before they
+// were tracked by the payment control tower. Details such as the total fee
+// paid, and the time of the payment are stored.
 type OutgoingPayment struct {
 	Invoice
 
@@ -42,96 +53,287 @@ type OutgoingPayment struct {
 	PaymentPreimage [32]byte
 }
 
-// AddPayment saves a successful payment to the database. It is assumed that
-// all payment are sent using unique payment hashes.
-func (db *DB) AddPayment(payment *OutgoingPayment) error {
-	// Validate the field of the inner voice within the outgoing payment,
-	// these must also adhere to the same constraints as regular invoices.
-	if err := validateInvoice(&payment.Invoice); err != nil {
-		return err
-	}
+// FetchPayments returns all payments tracked by the payment control tower,
+// regardless of their status, ordered by their sequence number.
+func (db *DB) FetchPayments() ([]*Payment, error) {
+	var payments []*Payment
 
-	// We first serialize the payment before starting the database
-	// transaction so we can avoid creating a DB payment in the case of a
-	// serialization error.
-	var b bytes.Buffer
-	if err := serializeOutgoingPayment(&b, payment); err != nil {
-		return err
-	}
-	paymentBytes := b.Bytes()
-
-	return db.Batch(func(tx *bolt.Tx) error {
-		payments, err := tx.CreateBucketIfNotExists(paymentBucket)
-		if err != nil {
-			return err
+	err := db.View(func(tx *bolt.Tx) error {
+		paymentsBucket := tx.Bucket(paymentsRootBucket)
+		if paymentsBucket == nil {
+			return nil
 		}
 
-		// Obtain the new unique sequence number for this payment.
-		paymentID, err := payments.NextSequence()
-		if err != nil {
-			return err
-		}
+		return paymentsBucket.ForEach(func(k, v []byte) error {
+			bucket := paymentsBucket.Bucket(k)
+			if bucket == nil {
+				// We only expect sub-buckets to be found in
+				// this top-level bucket.
+				return fmt.Errorf("non bucket element in " +
+					"payments bucket")
+			}
 
-		// We use BigEndian for keys as it orders keys in
-		// ascending order. This allows bucket scans to order payments
-		// in the order in which they were created.
-		paymentIDBytes := make([]byte, 8)
-		binary.BigEndian.PutUint64(paymentIDBytes, paymentID)
+			payment, err := fetchPayment(bucket)
+			if err != nil {
+				return err
+			}
 
-		return payments.Put(paymentIDBytes, paymentBytes)
+			payments = append(payments, payment)
+			return nil
+		})
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The payments are keyed by their payment hash, so we'll sort them by
+	// their sequence number to return them in the order they were
+	// created.
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].SequenceNum < payments[j].SequenceNum
+	})
+
+	return payments, nil
 }
 
-// FetchAllPayments returns all outgoing payments in DB.
-func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
-	var payments []*OutgoingPayment
+// PaymentsQuery represents a query to the payments database. The query allows
+// a caller to retrieve all payments starting from a particular payment index
+// and limit the number of results returned.
+type PaymentsQuery struct {
+	// IndexOffset is the offset within the payment indices to start at.
+	// This can be used to start the response at a particular payment. The
+	// payment at the offset itself is excluded from the response.
+	IndexOffset uint64
+
+	// MaxPayments is the maximum number of payments that should be
+	// returned in the response. A value of zero means that there is no
+	// limit.
+	MaxPayments uint64
+
+	// Reversed, if set, indicates that the payments returned should start
+	// from the IndexOffset and go backwards. If the IndexOffset is zero,
+	// then the query starts at the most recent payment.
+	Reversed bool
+
+	// CreationDateStart, if non-zero, excludes all payments that were
+	// created before this time.
+	CreationDateStart time.Time
+
+	// CreationDateEnd, if non-zero, excludes all payments that were
+	// created after this time.
+	CreationDateEnd time.Time
+
+	// IncludeIncomplete, if set, includes payments that are still in
+	// flight or have failed. Otherwise only succeeded payments are
+	// returned.
+	IncludeIncomplete bool
+}
+
+// PaymentsSlice is the response to a payments query. It includes the
+// original query, the set of payments that match the query, and the indexes
+// of the first and last payments returned, which allows callers to resume
+// their query in the event that the query's response exceeds the maximum
+// number of returnable payments.
+type PaymentsSlice struct {
+	PaymentsQuery
+
+	// Payments is the set of payments that matched the query above. The
+	// payments are always ordered by their index, regardless of the
+	// direction of the query.
+	Payments []*Payment
+
+	// FirstIndexOffset is the index of the first element in the set of
+	// returned payments above.
+	FirstIndexOffset uint64
+
+	// LastIndexOffset is the index of the last element in the set of
+	// returned payments above.
+	LastIndexOffset uint64
+}
+
+// matches returns true if the passed payment satisfies the filters of the
+// query.
+func (q *PaymentsQuery) matches(payment *Payment) bool {
+	if !q.IncludeIncomplete && payment.Status != StatusSucceeded {
+		return false
+	}
+	if !q.CreationDateStart.IsZero() &&
+		payment.Info.CreationDate.Before(q.CreationDateStart) {
+
+		return false
+	}
+	if !q.CreationDateEnd.IsZero() &&
+		payment.Info.CreationDate.After(q.CreationDateEnd) {
+
+		return false
+	}
+
+	return true
+}
+
+// QueryPayments allows a caller to query the payments database for payments
+// within the specified index range. The index of a payment is its sequence
+// number, so the payments index is traversed starting at the offset of the
+// query, and the traversal stops as soon as the maximum number of matching
+// payments has been collected.
+func (db *DB) QueryPayments(q PaymentsQuery) (PaymentsSlice, error) {
+	resp := PaymentsSlice{
+		PaymentsQuery: q,
+	}
 
 	err := db.View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(paymentBucket)
-		if bucket == nil {
-			return ErrNoPaymentsCreated
+		payments := tx.Bucket(paymentsRootBucket)
+		index := tx.Bucket(paymentsIndexBucket)
+		if payments == nil || index == nil {
+			return nil
 		}
 
-		return bucket.ForEach(func(k, v []byte) error {
-			// If the value is nil, then we ignore it as it may be
-			// a sub-bucket.
-			if v == nil {
-				return nil
+		// We'll position the cursor at the first payment past the
+		// index offset, taking the direction of the query into
+		// account.
+		c := index.Cursor()
+		next := c.Next
+		var k, v []byte
+		switch {
+		case q.Reversed && q.IndexOffset == 0:
+			next = c.Prev
+			k, v = c.Last()
+
+		case q.Reversed:
+			var offset [8]byte
+			byteOrder.PutUint64(offset[:], q.IndexOffset)
+
+			// Seeking positions the cursor at the offset itself,
+			// or the first index past it, so we'll step back
+			// once. If the seek went past the end of the index,
+			// then we start at the last payment instead.
+			next = c.Prev
+			if k, _ = c.Seek(offset[:]); k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
 			}
 
-			r := bytes.NewReader(v)
-			payment, err := deserializeOutgoingPayment(r)
+		default:
+			var offset [8]byte
+			byteOrder.PutUint64(offset[:], q.IndexOffset+1)
+			k, v = c.Seek(offset[:])
+		}
+
+		for ; k != nil; k, v = next() {
+			if q.MaxPayments != 0 &&
+				uint64(len(resp.Payments)) >= q.MaxPayments {
+
+				break
+			}
+
+			// The index maps the sequence number of each payment
+			// to its payment hash, which the payment's bucket is
+			// keyed by.
+			bucket := payments.Bucket(v)
+			if bucket == nil {
+				return fmt.Errorf("payment %x with index %v "+
+					"not found", v, byteOrder.Uint64(k))
+			}
+
+			payment, err := fetchPayment(bucket)
 			if err != nil {
 				return err
 			}
 
-			payments = append(payments, payment)
-			return nil
-		})
+			if !q.matches(payment) {
+				continue
+			}
+
+			resp.Payments = append(resp.Payments, payment)
+		}
+
+		return nil
 	})
 	if err != nil {
-		return nil, err
+		return resp, err
 	}
 
-	return payments, nil
+	// If we iterated backwards, then we'll reverse the order of the
+	// payments, such that they're always ordered by their index.
+	if q.Reversed {
+		numPayments := len(resp.Payments)
+		for i := 0; i < numPayments/2; i++ {
+			opposite := numPayments - i - 1
+			resp.Payments[i], resp.Payments[opposite] =
+				resp.Payments[opposite], resp.Payments[i]
+		}
+	}
+
+	// Finally, we'll record the indexes of the first and last payments
+	// returned, such that the caller can resume the query from either end.
+	if len(resp.Payments) > 0 {
+		resp.FirstIndexOffset = resp.Payments[0].SequenceNum
+		resp.LastIndexOffset =
+			resp.Payments[len(resp.Payments)-1].SequenceNum
+	}
+
+	return resp, nil
 }
 
-// DeleteAllPayments deletes all payments from DB.
+// DeleteAllPayments deletes all payments from DB, except for those that are
+// still in flight, as their outcome must still be recorded.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
-		err := tx.DeleteBucket(paymentBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
+		payments := tx.Bucket(paymentsRootBucket)
+		if payments == nil {
+			return nil
+		}
+
+		var deletePayments []*Payment
+		err := payments.ForEach(func(k, _ []byte) error {
+			bucket := payments.Bucket(k)
+			if bucket == nil {
+				// We only expect sub-buckets to be found in
+				// this top-level bucket.
+				return fmt.Errorf("non bucket element in " +
+					"payments bucket")
+			}
+
+			payment, err := fetchPayment(bucket)
+			if err != nil {
+				return err
+			}
+
+			if payment.Status == StatusInFlight {
+				return nil
+			}
+
+			deletePayments = append(deletePayments, payment)
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 
-		_, err = tx.CreateBucket(paymentBucket)
-		return err
+		for _, payment := range deletePayments {
+			hash := payment.Info.PaymentHash
+			if err := payments.DeleteBucket(hash[:]); err != nil {
+				return err
+			}
+
+			err := unindexPayment(tx, payment.SequenceNum)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
 func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 	var scratch [8]byte
 
+	if _, err := w.Write([]byte{paymentLayoutIndexed}); err != nil {
+		return err
+	}
+
 	if err := serializeInvoice(w, &p.Invoice); err != nil {
 		return err
 	}
@@ -169,22 +371,52 @@ func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 }
 
 func deserializeOutgoingPayment(r io.Reader) (*OutgoingPayment, error) {
-	var scratch [8]byte
-
-	p := &OutgoingPayment{}
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return nil, err
+	}
+	if version[0] != paymentLayoutIndexed {
+		return nil, fmt.Errorf("unknown payment layout version: %v",
+			version[0])
+	}
 
 	inv, err := deserializeInvoice(r)
 	if err != nil {
 		return nil, err
 	}
-	p.Invoice = *inv
+
+	return deserializePaymentDetails(r, inv)
+}
+
+// deserializeOutgoingPaymentBase deserializes a payment stored before invoices
+// were assigned an add and settle index, which lacks both the layout version
+// and the indexes of its embedded invoice.
+func deserializeOutgoingPaymentBase(r io.Reader) (*OutgoingPayment, error) {
+	inv, err := deserializeInvoiceBase(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return deserializePaymentDetails(r, inv)
+}
+
+// deserializePaymentDetails deserializes the fields of a payment that follow
+// its embedded invoice.
+func deserializePaymentDetails(r io.Reader,
+	inv *Invoice) (*OutgoingPayment, error) {
+
+	var scratch [8]byte
+
+	p := &OutgoingPayment{
+		Invoice: *inv,
+	}
 
 	if _, err := r.Read(scratch[:]); err != nil {
 		return nil, err
 	}
 	p.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
 
-	if _, err = r.Read(scratch[:4]); err != nil {
+	if _, err := r.Read(scratch[:4]); err != nil {
 		return nil, err
 	}
 	pathLen := byteOrder.Uint32(scratch[:4])
@@ -197,7 +429,7 @@ func deserializeOutgoingPayment(r io.Reader) (*OutgoingPayment, error) {
 	}
 	p.Path = path
 
-	if _, err = r.Read(scratch[:4]); err != nil {
+	if _, err := r.Read(scratch[:4]); err != nil {
 		return nil, err
 	}
 	p.TimeLockLength = byteOrder.Uint32(scratch[:4])
@@ -111,7 +111,9 @@ func TestOutgoingPaymentSerialization(t *testing.T) {
 		t.Fatalf("unable to serialize outgoing payment: %v", err)
 	}
 
-	newPayment, err := deserializeOutgoingPayment(&b)
+	newPayment, err := deserializeOutgoingPayment(
+		bytes.NewReader(b.Bytes()),
+	)
 	if err != nil {
 		t.Fatalf("unable to deserialize outgoing payment: %v", err)
 	}
@@ -125,7 +127,42 @@ func TestOutgoingPaymentSerialization(t *testing.T) {
 	}
 }
 
-func TestOutgoingPaymentWorkflow(t *testing.T) {
+// createTestPayment initiates a payment with the given creation info, and
+// drives it into the passed status. Succeeded and in-flight payments carry
+// the passed attempt, while failed payments are failed without any attempts.
+func createTestPayment(pControl *PaymentControl, info *PaymentCreationInfo,
+	attempt *HTLCAttemptInfo, preimage [32]byte,
+	status PaymentStatus) error {
+
+	if err := pControl.InitPayment(info.PaymentHash, info); err != nil {
+		return err
+	}
+
+	if status == StatusFailed {
+		_, err := pControl.Fail(info.PaymentHash, FailureReasonNoRoute)
+		return err
+	}
+
+	_, err := pControl.RegisterAttempt(info.PaymentHash, attempt)
+	if err != nil {
+		return err
+	}
+
+	if status == StatusInFlight {
+		return nil
+	}
+
+	_, err = pControl.SettleAttempt(
+		info.PaymentHash, attempt.AttemptID,
+		&HTLCSettleInfo{Preimage: preimage},
+	)
+	return err
+}
+
+// TestFetchAndDeletePayments checks that all payments are returned in the
+// order they were created regardless of their status, and that all but the
+// in-flight payments are deleted.
+func TestFetchAndDeletePayments(t *testing.T) {
 	t.Parallel()
 
 	db, cleanUp, err := makeTestDB()
@@ -134,64 +171,271 @@ func TestOutgoingPaymentWorkflow(t *testing.T) {
 		t.Fatalf("unable to make test db: %v", err)
 	}
 
-	fakePayment := makeFakePayment()
-	if err = db.AddPayment(fakePayment); err != nil {
-		t.Fatalf("unable to put payment in DB: %v", err)
+	payments, err := db.FetchPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments from DB: %v", err)
 	}
+	if len(payments) != 0 {
+		t.Fatalf("expected no payments, got %v", len(payments))
+	}
+
+	pControl := NewPaymentControl(db)
 
-	payments, err := db.FetchAllPayments()
+	statuses := []PaymentStatus{
+		StatusSucceeded, StatusFailed, StatusInFlight, StatusSucceeded,
+	}
+	var expectedHashes [][32]byte
+	for _, status := range statuses {
+		info, attempt, preimage, err := genInfo()
+		if err != nil {
+			t.Fatalf("unable to generate htlc message: %v", err)
+		}
+
+		err = createTestPayment(pControl, info, attempt, preimage, status)
+		if err != nil {
+			t.Fatalf("unable to create payment: %v", err)
+		}
+
+		expectedHashes = append(expectedHashes, info.PaymentHash)
+	}
+
+	payments, err = db.FetchPayments()
 	if err != nil {
 		t.Fatalf("unable to fetch payments from DB: %v", err)
 	}
+	if len(payments) != len(statuses) {
+		t.Fatalf("expected %v payments, got %v", len(statuses),
+			len(payments))
+	}
+	for i, payment := range payments {
+		if payment.Info.PaymentHash != expectedHashes[i] {
+			t.Fatalf("expected payment %x at position %v, got %x",
+				expectedHashes[i], i, payment.Info.PaymentHash)
+		}
+		if payment.Status != statuses[i] {
+			t.Fatalf("expected status %v at position %v, got %v",
+				statuses[i], i, payment.Status)
+		}
+	}
 
-	expectedPayments := []*OutgoingPayment{fakePayment}
-	if !reflect.DeepEqual(payments, expectedPayments) {
-		t.Fatalf("Wrong payments after reading from DB."+
-			"Got %v, want %v",
-			spew.Sdump(payments),
-			spew.Sdump(expectedPayments),
-		)
+	// Delete all payments. Only the in-flight payment should remain.
+	if err = db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments from DB: %v", err)
+	}
+
+	payments, err = db.FetchPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments after deletion: %v", err)
+	}
+	if len(payments) != 1 {
+		t.Fatalf("expected 1 payment after deletion, got %v",
+			len(payments))
+	}
+	if payments[0].Info.PaymentHash != expectedHashes[2] {
+		t.Fatalf("expected in-flight payment to remain")
+	}
+
+	// The deleted payments should have been removed from the payments
+	// index as well.
+	resp, err := db.QueryPayments(PaymentsQuery{
+		IncludeIncomplete: true,
+	})
+	if err != nil {
+		t.Fatalf("unable to query payments: %v", err)
+	}
+	if !reflect.DeepEqual(resp.Payments, payments) {
+		t.Fatalf("indexed payments don't match: expected %v, got %v",
+			spew.Sdump(payments), spew.Sdump(resp.Payments))
+	}
+}
+
+// TestQueryRetriedPayment checks that a failed payment that's initiated again
+// is only returned once by a payments query, at its new index.
+func TestQueryRetriedPayment(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
 	}
 
-	// Make some random payments
-	for i := 0; i < 5; i++ {
-		randomPayment, err := makeRandomFakePayment()
+	pControl := NewPaymentControl(db)
+
+	var infos []*PaymentCreationInfo
+	statuses := []PaymentStatus{StatusFailed, StatusSucceeded}
+	for _, status := range statuses {
+		info, attempt, preimage, err := genInfo()
 		if err != nil {
-			t.Fatalf("Internal error in tests: %v", err)
+			t.Fatalf("unable to generate htlc message: %v", err)
 		}
 
-		if err = db.AddPayment(randomPayment); err != nil {
-			t.Fatalf("unable to put payment in DB: %v", err)
+		err = createTestPayment(pControl, info, attempt, preimage, status)
+		if err != nil {
+			t.Fatalf("unable to create payment: %v", err)
 		}
 
-		expectedPayments = append(expectedPayments, randomPayment)
+		infos = append(infos, info)
 	}
 
-	payments, err = db.FetchAllPayments()
+	// Initiate the failed payment again, which should replace it.
+	err = pControl.InitPayment(infos[0].PaymentHash, infos[0])
 	if err != nil {
-		t.Fatalf("Can't get payments from DB: %v", err)
+		t.Fatalf("unable to init payment: %v", err)
 	}
 
-	if !reflect.DeepEqual(payments, expectedPayments) {
-		t.Fatalf("Wrong payments after reading from DB."+
-			"Got %v, want %v",
-			spew.Sdump(payments),
-			spew.Sdump(expectedPayments),
-		)
+	resp, err := db.QueryPayments(PaymentsQuery{
+		IncludeIncomplete: true,
+	})
+	if err != nil {
+		t.Fatalf("unable to query payments: %v", err)
+	}
+	if len(resp.Payments) != 2 {
+		t.Fatalf("expected 2 payments, got %v", len(resp.Payments))
 	}
 
-	// Delete all payments.
-	if err = db.DeleteAllPayments(); err != nil {
-		t.Fatalf("unable to delete payments from DB: %v", err)
+	retried := resp.Payments[1]
+	if retried.Info.PaymentHash != infos[0].PaymentHash ||
+		retried.Status != StatusInFlight || retried.SequenceNum != 3 {
+
+		t.Fatalf("unexpected retried payment: %v", spew.Sdump(retried))
+	}
+}
+
+// TestQueryPayments ensures that the payments within the database can be
+// queried in both directions, starting at an index offset, limited in number
+// and filtered by their creation date and status.
+func TestQueryPayments(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
 	}
 
-	// Check that there is no payments after deletion
-	paymentsAfterDeletion, err := db.FetchAllPayments()
+	// Querying the database before any payments have been added should
+	// return an empty slice.
+	resp, err := db.QueryPayments(PaymentsQuery{})
 	if err != nil {
-		t.Fatalf("Can't get payments after deletion: %v", err)
+		t.Fatalf("unable to query payments: %v", err)
 	}
-	if len(paymentsAfterDeletion) != 0 {
-		t.Fatalf("After deletion DB has %v payments, want %v",
-			len(paymentsAfterDeletion), 0)
+	if len(resp.Payments) != 0 {
+		t.Fatalf("expected no payments, got %v", len(resp.Payments))
+	}
+
+	// We'll add a set of succeeded payments, each created one second
+	// after the other, followed by a payment that remains in flight. The
+	// value of each payment is set to its expected index, such that we
+	// can identify the payments returned.
+	const numPayments = 10
+	pControl := NewPaymentControl(db)
+	startTime := time.Unix(time.Now().Unix(), 0)
+	for i := 0; i <= numPayments; i++ {
+		info, attempt, preimage, err := genInfo()
+		if err != nil {
+			t.Fatalf("unable to generate htlc message: %v", err)
+		}
+		info.CreationDate = startTime.Add(time.Duration(i) * time.Second)
+		info.Value = lnwire.MilliSatoshi(i + 1)
+
+		status := StatusSucceeded
+		if i == numPayments {
+			status = StatusInFlight
+		}
+
+		err = createTestPayment(pControl, info, attempt, preimage, status)
+		if err != nil {
+			t.Fatalf("unable to create payment: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name  string
+		query PaymentsQuery
+
+		// expected is the set of indexes of the payments that should
+		// be returned, in order.
+		expected []uint64
+	}{
+		{
+			name:     "all payments",
+			query:    PaymentsQuery{},
+			expected: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name: "forward with offset and limit",
+			query: PaymentsQuery{
+				IndexOffset: 2,
+				MaxPayments: 3,
+			},
+			expected: []uint64{3, 4, 5},
+		},
+		{
+			name: "reversed from the last payment",
+			query: PaymentsQuery{
+				MaxPayments: 2,
+				Reversed:    true,
+			},
+			expected: []uint64{9, 10},
+		},
+		{
+			name: "reversed with offset and limit",
+			query: PaymentsQuery{
+				IndexOffset: 4,
+				MaxPayments: 5,
+				Reversed:    true,
+			},
+			expected: []uint64{1, 2, 3},
+		},
+		{
+			name: "creation date range",
+			query: PaymentsQuery{
+				CreationDateStart: startTime.Add(5 * time.Second),
+				CreationDateEnd:   startTime.Add(7 * time.Second),
+			},
+			expected: []uint64{6, 7, 8},
+		},
+		{
+			name: "include incomplete",
+			query: PaymentsQuery{
+				IndexOffset:       8,
+				IncludeIncomplete: true,
+			},
+			expected: []uint64{9, 10, 11},
+		},
+	}
+
+	for _, test := range testCases {
+		resp, err := db.QueryPayments(test.query)
+		if err != nil {
+			t.Fatalf("%v: unable to query payments: %v", test.name,
+				err)
+		}
+
+		if len(resp.Payments) != len(test.expected) {
+			t.Fatalf("%v: expected %v payments, got %v", test.name,
+				len(test.expected), len(resp.Payments))
+		}
+
+		for i, payment := range resp.Payments {
+			value := uint64(payment.Info.Value)
+			if value != test.expected[i] {
+				t.Fatalf("%v: expected payment %v at "+
+					"position %v, got %v", test.name,
+					test.expected[i], i, value)
+			}
+		}
+
+		firstIndex := test.expected[0]
+		lastIndex := test.expected[len(test.expected)-1]
+		if resp.FirstIndexOffset != firstIndex {
+			t.Fatalf("%v: expected first index offset %v, got %v",
+				test.name, firstIndex, resp.FirstIndexOffset)
+		}
+		if resp.LastIndexOffset != lastIndex {
+			t.Fatalf("%v: expected last index offset %v, got %v",
+				test.name, lastIndex, resp.LastIndexOffset)
+		}
 	}
 }
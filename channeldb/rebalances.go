@@ -0,0 +1,129 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// rebalanceLogBucket is the bucket that we'll use to store the log of
+	// rebalances. A rebalance is a circular payment from the daemon to
+	// itself, which is kept apart from the regular outgoing payments. Each
+	// key within the bucket is a monotonically increasing sequence number,
+	// and the value the serialized rebalance.
+	rebalanceLogBucket = []byte("rebalance-log")
+)
+
+// Rebalance describes a completed circular payment, which shifted funds from
+// one of our channels to another one of our channels by paying ourselves.
+type Rebalance struct {
+	// PaymentHash is the payment hash of the internal invoice that was
+	// paid by the rebalance.
+	PaymentHash [32]byte
+
+	// OutgoingChanID is the channel that the payment left through. The
+	// local balance of this channel decreased by the amount plus the fee.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// IncomingChanID is the channel that the payment arrived through. The
+	// local balance of this channel increased by the amount.
+	IncomingChanID lnwire.ShortChannelID
+
+	// Amount is the amount that was shifted between the channels, which
+	// excludes the fee.
+	Amount lnwire.MilliSatoshi
+
+	// Fee is the total fee paid to the nodes along the route.
+	Fee lnwire.MilliSatoshi
+
+	// Timestamp is the time the rebalance was completed.
+	Timestamp time.Time
+}
+
+// serializeRebalance writes out the passed rebalance to the io.Writer.
+func serializeRebalance(w io.Writer, r *Rebalance) error {
+	return writeElements(
+		w, r.PaymentHash, r.OutgoingChanID, r.IncomingChanID,
+		r.Amount, r.Fee, uint64(r.Timestamp.UnixNano()),
+	)
+}
+
+// deserializeRebalance reads a rebalance from the io.Reader.
+func deserializeRebalance(r io.Reader) (*Rebalance, error) {
+	var (
+		rebalance Rebalance
+		timestamp uint64
+	)
+	err := readElements(
+		r, &rebalance.PaymentHash, &rebalance.OutgoingChanID,
+		&rebalance.IncomingChanID, &rebalance.Amount, &rebalance.Fee,
+		&timestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rebalance.Timestamp = time.Unix(0, int64(timestamp))
+
+	return &rebalance, nil
+}
+
+// AddRebalance adds a completed rebalance to the rebalance log.
+func (d *DB) AddRebalance(rebalance *Rebalance) error {
+	var b bytes.Buffer
+	if err := serializeRebalance(&b, rebalance); err != nil {
+		return err
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		rebalances, err := tx.CreateBucketIfNotExists(
+			rebalanceLogBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		seqNum, err := rebalances.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var seqBytes [8]byte
+		byteOrder.PutUint64(seqBytes[:], seqNum)
+
+		return rebalances.Put(seqBytes[:], b.Bytes())
+	})
+}
+
+// FetchRebalances returns all rebalances within the rebalance log, in the
+// order they were added.
+func (d *DB) FetchRebalances() ([]*Rebalance, error) {
+	var rebalances []*Rebalance
+
+	err := d.View(func(tx *bolt.Tx) error {
+		rebalanceLog := tx.Bucket(rebalanceLogBucket)
+		if rebalanceLog == nil {
+			return nil
+		}
+
+		return rebalanceLog.ForEach(func(k, v []byte) error {
+			rebalance, err := deserializeRebalance(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+
+			rebalances = append(rebalances, rebalance)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rebalances, nil
+}
@@ -0,0 +1,65 @@
+package channeldb
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestRebalanceLog tests that rebalances added to the rebalance log are
+// returned in the order they were added.
+func TestRebalanceLog(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Without any rebalances added, an empty log should be returned.
+	rebalances, err := db.FetchRebalances()
+	if err != nil {
+		t.Fatalf("unable to fetch rebalances: %v", err)
+	}
+	if len(rebalances) != 0 {
+		t.Fatalf("expected no rebalances, got %v", len(rebalances))
+	}
+
+	const numRebalances = 10
+	expected := make([]*Rebalance, numRebalances)
+	for i := 0; i < numRebalances; i++ {
+		rebalance := &Rebalance{
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(
+				uint64(rand.Int63()),
+			),
+			IncomingChanID: lnwire.NewShortChanIDFromInt(
+				uint64(rand.Int63()),
+			),
+			Amount:    lnwire.MilliSatoshi(rand.Int63()),
+			Fee:       lnwire.MilliSatoshi(rand.Int63()),
+			Timestamp: time.Unix(0, rand.Int63()),
+		}
+		if _, err := rand.Read(rebalance.PaymentHash[:]); err != nil {
+			t.Fatalf("unable to generate payment hash: %v", err)
+		}
+
+		if err := db.AddRebalance(rebalance); err != nil {
+			t.Fatalf("unable to add rebalance: %v", err)
+		}
+		expected[i] = rebalance
+	}
+
+	rebalances, err = db.FetchRebalances()
+	if err != nil {
+		t.Fatalf("unable to fetch rebalances: %v", err)
+	}
+	if !reflect.DeepEqual(expected, rebalances) {
+		t.Fatalf("rebalance mismatch: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(rebalances))
+	}
+}
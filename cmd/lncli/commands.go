@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -26,6 +27,7 @@ import (
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh/terminal"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -1437,6 +1439,119 @@ func listChannels(ctx *cli.Context) error {
 	return nil
 }
 
+var (
+	feeLimitFlag = cli.Int64Flag{
+		Name: "fee_limit",
+		Usage: "maximum fee allowed in satoshis when sending " +
+			"the payment",
+	}
+	feeLimitPercentFlag = cli.Int64Flag{
+		Name: "fee_limit_percent",
+		Usage: "percentage of the payment's amount used as the " +
+			"maximum fee allowed when sending the payment",
+	}
+	cltvLimitFlag = cli.Uint64Flag{
+		Name: "cltv_limit",
+		Usage: "the maximum time lock that may be used for this " +
+			"payment, expressed in blocks from the current height",
+	}
+	outgoingChanIDFlag = cli.Uint64Flag{
+		Name: "outgoing_chan_id",
+		Usage: "short channel id of the outgoing channel to use for " +
+			"the first hop of the payment",
+	}
+	lastHopFlag = cli.StringFlag{
+		Name: "last_hop",
+		Usage: "pubkey of the last hop (penultimate node in the " +
+			"path) to route through for this payment",
+	}
+	feeWeightFlag = cli.Float64Flag{
+		Name: "fee_weight",
+		Usage: "weight of the fee paid to a channel when finding a " +
+			"path, overriding the node's configured cost model",
+		Value: 1,
+	}
+	timeLockRiskFactorFlag = cli.Float64Flag{
+		Name: "time_lock_risk_factor",
+		Usage: "weight of the time lock delta of a channel when " +
+			"finding a path, overriding the node's configured " +
+			"cost model",
+		Value: 1,
+	}
+	attemptCostFlag = cli.Int64Flag{
+		Name: "attempt_cost",
+		Usage: "virtual cost in satoshis of a failed payment attempt " +
+			"when finding a path, overriding the node's " +
+			"configured cost model",
+		Value: 100,
+	}
+)
+
+// retrieveLastHop decodes the pubkey of the last hop passed using the
+// last_hop flag, returning nil if the flag isn't set.
+func retrieveLastHop(ctx *cli.Context) ([]byte, error) {
+	if !ctx.IsSet("last_hop") {
+		return nil, nil
+	}
+
+	lastHop, err := hex.DecodeString(ctx.String("last_hop"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode last_hop: %v", err)
+	}
+	if len(lastHop) != 33 {
+		return nil, fmt.Errorf("last_hop pubkey must be exactly 33 "+
+			"bytes, is instead %v", len(lastHop))
+	}
+
+	return lastHop, nil
+}
+
+// retrieveCostModel retrieves the cost model based on the different cost model
+// flags passed. If none of them is set, then nil is returned, which means the
+// node's configured cost model is used.
+func retrieveCostModel(ctx *cli.Context) *lnrpc.CostModel {
+	if !ctx.IsSet("fee_weight") && !ctx.IsSet("time_lock_risk_factor") &&
+		!ctx.IsSet("attempt_cost") {
+
+		return nil
+	}
+
+	return &lnrpc.CostModel{
+		FeeWeight:          ctx.Float64("fee_weight"),
+		TimeLockRiskFactor: ctx.Float64("time_lock_risk_factor"),
+		AttemptCost:        ctx.Int64("attempt_cost"),
+	}
+}
+
+// retrieveFeeLimit retrieves the fee limit based on the different fee limit
+// flags passed. If no fee limit flag is set, then nil is returned, which means
+// the fee isn't limited.
+func retrieveFeeLimit(ctx *cli.Context) (*lnrpc.FeeLimit, error) {
+	switch {
+	case ctx.IsSet("fee_limit") && ctx.IsSet("fee_limit_percent"):
+		return nil, fmt.Errorf("either fee_limit or fee_limit_percent " +
+			"can be set, but not both")
+
+	case ctx.IsSet("fee_limit"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_FixedMsat{
+				FixedMsat: ctx.Int64("fee_limit") * 1000,
+			},
+		}, nil
+
+	case ctx.IsSet("fee_limit_percent"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Percent{
+				Percent: ctx.Int64("fee_limit_percent"),
+			},
+		}, nil
+	}
+
+	// Since the fee limit flags aren't required, we don't return an error
+	// if they're not set.
+	return nil, nil
+}
+
 var sendPaymentCommand = cli.Command{
 	Name:  "sendpayment",
 	Usage: "Send a payment over lightning",
@@ -1460,6 +1575,18 @@ var sendPaymentCommand = cli.Command{
 	it'll use the hash of all zeroes. This mode allows one to quickly test
 	payment connectivity without having to create an invoice at the
 	destination.
+
+	If the --keysend flag is set, then the payment hash isn't required
+	either. Instead, a random preimage is generated and carried to the
+	destination within the onion, which allows paying a destination that
+	accepts keysend payments without an invoice.
+
+	The fees paid for the payment can be limited using either the
+	--fee_limit or --fee_limit_percent param, and the total time lock of
+	its route can be limited using the --cltv_limit param. The payment can
+	be forced to leave through a particular channel of ours using the
+	--outgoing_chan_id param, and to arrive through a particular node using
+	the --last_hop param.
 	`,
 	ArgsUsage: "dest amt payment_hash final_cltv_delta | --pay_req=[payment request]",
 	Flags: []cli.Flag{
@@ -1480,6 +1607,11 @@ var sendPaymentCommand = cli.Command{
 			Name:  "debug_send",
 			Usage: "use the debug rHash when sending the HTLC",
 		},
+		cli.BoolFlag{
+			Name: "keysend",
+			Usage: "send a keysend payment, carrying a random " +
+				"preimage to the destination",
+		},
 		cli.StringFlag{
 			Name:  "pay_req",
 			Usage: "a zpay32 encoded payment request to fulfill",
@@ -1488,6 +1620,14 @@ var sendPaymentCommand = cli.Command{
 			Name:  "final_cltv_delta",
 			Usage: "the number of blocks the last hop has to reveal the preimage",
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
+		outgoingChanIDFlag,
+		lastHopFlag,
+		feeWeightFlag,
+		timeLockRiskFactorFlag,
+		attemptCostFlag,
 	},
 	Action: sendPayment,
 }
@@ -1501,6 +1641,11 @@ func sendPayment(ctx *cli.Context) error {
 
 	var req *lnrpc.SendRequest
 	if ctx.IsSet("pay_req") {
+		if ctx.Bool("keysend") {
+			return fmt.Errorf("keysend payments can't be made to " +
+				"a payment request")
+		}
+
 		req = &lnrpc.SendRequest{
 			PaymentRequest: ctx.String("pay_req"),
 			Amt:            ctx.Int64("amt"),
@@ -1547,6 +1692,29 @@ func sendPayment(ctx *cli.Context) error {
 			Amt:  amount,
 		}
 
+		// A keysend payment pays to the hash of a preimage chosen by
+		// us, so no payment hash is provided.
+		if ctx.Bool("keysend") {
+			if ctx.Bool("debug_send") || ctx.IsSet("payment_hash") {
+				return fmt.Errorf("do not provide a payment " +
+					"hash with keysend")
+			}
+
+			preimage := make([]byte, 32)
+			if _, err := rand.Read(preimage); err != nil {
+				return err
+			}
+			req.KeysendPreimage = preimage
+
+			if ctx.IsSet("final_cltv_delta") {
+				req.FinalCltvDelta = int32(
+					ctx.Int64("final_cltv_delta"),
+				)
+			}
+
+			return sendPaymentRequest(ctx, req)
+		}
+
 		if ctx.Bool("debug_send") && (ctx.IsSet("payment_hash") || args.Present()) {
 			return fmt.Errorf("do not provide a payment hash with debug send")
 		} else if !ctx.Bool("debug_send") {
@@ -1590,6 +1758,20 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+	req.FeeLimit = feeLimit
+	req.CltvLimit = uint32(ctx.Uint64("cltv_limit"))
+	req.OutgoingChanId = ctx.Uint64("outgoing_chan_id")
+
+	req.LastHopPubkey, err = retrieveLastHop(ctx)
+	if err != nil {
+		return err
+	}
+	req.CostModel = retrieveCostModel(ctx)
+
 	paymentStream, err := client.SendPayment(context.Background())
 	if err != nil {
 		return err
@@ -1633,6 +1815,14 @@ var payInvoiceCommand = cli.Command{
 			Usage: "(optional) number of satoshis to fulfill the " +
 				"invoice",
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
+		outgoingChanIDFlag,
+		lastHopFlag,
+		feeWeightFlag,
+		timeLockRiskFactorFlag,
+		attemptCostFlag,
 	},
 	Action: actionDecorator(payInvoice),
 }
@@ -1659,6 +1849,217 @@ func payInvoice(ctx *cli.Context) error {
 	return sendPaymentRequest(ctx, req)
 }
 
+var sendToRouteCommand = cli.Command{
+	Name:  "sendtoroute",
+	Usage: "Send a payment over a predefined route.",
+	Description: `
+	Send a payment over Lightning using a specific set of routes.
+
+	One must either pass in the routes as a JSON string, or pass in "-"
+	to read them from stdin. The JSON may either be the output of the
+	queryroutes command, or a single route. The routes are attempted in
+	the order given, until the payment succeeds or all routes have failed.
+
+	    $ lncli queryroutes --dest=<dest> --amt=<amt> | \
+	          lncli sendtoroute --payment_hash=<pay_hash> --routes=-`,
+	ArgsUsage: "payment_hash routes",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash, pay_hash",
+			Usage: "the hash to use within the payment's HTLC",
+		},
+		cli.StringFlag{
+			Name: "routes, r",
+			Usage: "a json array string in the format of the response " +
+				"of queryroutes that denotes which routes to use, " +
+				"or \"-\" to read them from stdin",
+		},
+	},
+	Action: actionDecorator(sendToRoute),
+}
+
+func sendToRoute(ctx *cli.Context) error {
+	// Show command help if no arguments provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "sendtoroute")
+		return nil
+	}
+
+	args := ctx.Args()
+
+	var (
+		rHash []byte
+		err   error
+	)
+	switch {
+	case ctx.IsSet("payment_hash"):
+		rHash, err = hex.DecodeString(ctx.String("payment_hash"))
+	case args.Present():
+		rHash, err = hex.DecodeString(args.First())
+		args = args.Tail()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+	if err != nil {
+		return err
+	}
+	if len(rHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %d", len(rHash))
+	}
+
+	var jsonRoutes string
+	switch {
+	case ctx.IsSet("routes"):
+		jsonRoutes = ctx.String("routes")
+	case args.Present():
+		jsonRoutes = args.First()
+	default:
+		return fmt.Errorf("routes argument missing")
+	}
+
+	// If "-" is passed, then we'll read the routes from stdin.
+	if jsonRoutes == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		jsonRoutes = string(b)
+	}
+
+	// The routes may either be given in the format of the response of
+	// queryroutes, or as a single route.
+	routes := &lnrpc.QueryRoutesResponse{}
+	err = jsonpb.UnmarshalString(jsonRoutes, routes)
+	if err != nil || len(routes.Routes) == 0 {
+		route := &lnrpc.Route{}
+		if err := jsonpb.UnmarshalString(jsonRoutes, route); err != nil {
+			return fmt.Errorf("unable to unmarshal json string "+
+				"from incoming array of routes: %v", err)
+		}
+		routes.Routes = []*lnrpc.Route{route}
+	}
+
+	req := &lnrpc.SendToRouteRequest{
+		PaymentHash: rHash,
+		Routes:      routes.Routes,
+	}
+
+	return sendToRouteRequest(ctx, req)
+}
+
+func sendToRouteRequest(ctx *cli.Context, req *lnrpc.SendToRouteRequest) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	paymentStream, err := client.SendToRoute(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if err := paymentStream.Send(req); err != nil {
+		return err
+	}
+
+	resp, err := paymentStream.Recv()
+	if err != nil {
+		return err
+	}
+
+	paymentStream.CloseSend()
+
+	printJSON(struct {
+		E string       `json:"payment_error"`
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+	}{
+		E: resp.PaymentError,
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+	})
+
+	return nil
+}
+
+var rebalanceCommand = cli.Command{
+	Name:  "rebalance",
+	Usage: "Shift funds between two of our channels.",
+	Description: `
+	Shift funds from one of our channels to another one of our channels, by
+	paying ourselves over a circular route. The route leaves through the
+	channel given by --from_chan, and returns through the channel given by
+	--to_chan. The fee paid to the nodes along the route can be limited
+	using --max_fee.`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name:  "from_chan",
+			Usage: "the id of the channel the funds should leave through",
+		},
+		cli.Uint64Flag{
+			Name:  "to_chan",
+			Usage: "the id of the channel the funds should arrive through",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the number of satoshis to shift between the channels",
+		},
+		cli.Int64Flag{
+			Name: "max_fee",
+			Usage: "(optional) the maximum fee in satoshis that may be " +
+				"paid for the rebalance",
+		},
+	},
+	Action: actionDecorator(rebalance),
+}
+
+func rebalance(ctx *cli.Context) error {
+	// Show command help if no arguments provided.
+	if ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "rebalance")
+		return nil
+	}
+
+	switch {
+	case !ctx.IsSet("from_chan"):
+		return fmt.Errorf("from_chan argument missing")
+	case !ctx.IsSet("to_chan"):
+		return fmt.Errorf("to_chan argument missing")
+	case !ctx.IsSet("amt"):
+		return fmt.Errorf("amt argument missing")
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.RebalanceRequest{
+		OutgoingChanId: ctx.Uint64("from_chan"),
+		IncomingChanId: ctx.Uint64("to_chan"),
+		Amt:            ctx.Int64("amt"),
+		MaxFee:         ctx.Int64("max_fee"),
+	}
+
+	resp, err := client.Rebalance(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printJSON(struct {
+		E string       `json:"payment_error"`
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+		F int64        `json:"fee"`
+		M int64        `json:"fee_msat"`
+	}{
+		E: resp.PaymentError,
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+		F: resp.Fee,
+		M: resp.FeeMsat,
+	})
+
+	return nil
+}
+
 var addInvoiceCommand = cli.Command{
 	Name:  "addinvoice",
 	Usage: "Add a new invoice.",
@@ -1686,6 +2087,15 @@ var addInvoiceCommand = cli.Command{
 				"preimage. If not set, a random preimage will be " +
 				"created.",
 		},
+		cli.StringFlag{
+			Name: "hash",
+			Usage: "the hex-encoded payment hash (32 byte) of a " +
+				"hold invoice. HTLCs paying to it will be held " +
+				"until the invoice is settled with the preimage " +
+				"using settleinvoice, or canceled using " +
+				"cancelinvoice. Can't be used together with " +
+				"preimage.",
+		},
 		cli.Int64Flag{
 			Name:  "amt",
 			Usage: "the amt of satoshis in this invoice",
@@ -1709,6 +2119,12 @@ var addInvoiceCommand = cli.Command{
 				"specified an expiry of 3600 seconds (1 hour) " +
 				"is implied.",
 		},
+		cli.BoolFlag{
+			Name: "private",
+			Usage: "encode routing hints in the invoice for our " +
+				"private channels, allowing the payer to reach " +
+				"us through them",
+		},
 	},
 	Action: actionDecorator(addInvoice),
 }
@@ -1749,6 +2165,11 @@ func addInvoice(ctx *cli.Context) error {
 		return fmt.Errorf("unable to parse preimage: %v", err)
 	}
 
+	hash, err := hex.DecodeString(ctx.String("hash"))
+	if err != nil {
+		return fmt.Errorf("unable to parse hash: %v", err)
+	}
+
 	descHash, err = hex.DecodeString(ctx.String("description_hash"))
 	if err != nil {
 		return fmt.Errorf("unable to parse description_hash: %v", err)
@@ -1763,10 +2184,12 @@ func addInvoice(ctx *cli.Context) error {
 		Memo:            ctx.String("memo"),
 		Receipt:         receipt,
 		RPreimage:       preimage,
+		RHash:           hash,
 		Value:           amt,
 		DescriptionHash: descHash,
 		FallbackAddr:    ctx.String("fallback_addr"),
 		Expiry:          ctx.Int64("expiry"),
+		Private:         ctx.Bool("private"),
 	}
 
 	resp, err := client.AddInvoice(context.Background(), invoice)
@@ -1835,15 +2258,166 @@ func lookupInvoice(ctx *cli.Context) error {
 	return nil
 }
 
+var settleInvoiceCommand = cli.Command{
+	Name:  "settleinvoice",
+	Usage: "Settle an accepted hold invoice.",
+	Description: `
+	Settle a hold invoice for which an HTLC has been accepted, using the
+	preimage that hashes to the invoice's payment hash. All HTLCs that are
+	being held for the invoice will be settled with the preimage.`,
+	ArgsUsage: "preimage",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "preimage",
+			Usage: "the hex-encoded preimage (32 byte) of the hold " +
+				"invoice to settle",
+		},
+	},
+	Action: actionDecorator(settleInvoice),
+}
+
+func settleInvoice(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		preimage []byte
+		err      error
+	)
+
+	switch {
+	case ctx.IsSet("preimage"):
+		preimage, err = hex.DecodeString(ctx.String("preimage"))
+	case ctx.Args().Present():
+		preimage, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("preimage argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode preimage argument: %v", err)
+	}
+
+	req := &lnrpc.SettleInvoiceRequest{
+		Preimage: preimage,
+	}
+
+	resp, err := client.SettleInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
+var cancelInvoiceCommand = cli.Command{
+	Name:  "cancelinvoice",
+	Usage: "Cancel an open or accepted invoice.",
+	Description: `
+	Cancel an invoice that hasn't been settled yet. All HTLCs that are being
+	held for the invoice will be failed back to their senders, and any
+	HTLCs paying to it in the future will be rejected.`,
+	ArgsUsage: "rhash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "rhash",
+			Usage: "the 32 byte payment hash of the invoice to " +
+				"cancel, the hash should be a hex-encoded string",
+		},
+	},
+	Action: actionDecorator(cancelInvoice),
+}
+
+func cancelInvoice(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		rHash []byte
+		err   error
+	)
+
+	switch {
+	case ctx.IsSet("rhash"):
+		rHash, err = hex.DecodeString(ctx.String("rhash"))
+	case ctx.Args().Present():
+		rHash, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("rhash argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode rhash argument: %v", err)
+	}
+
+	req := &lnrpc.CancelInvoiceRequest{
+		PaymentHash: rHash,
+	}
+
+	resp, err := client.CancelInvoice(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
+
 var listInvoicesCommand = cli.Command{
 	Name:  "listinvoices",
 	Usage: "List all invoices currently stored.",
+	Description: `
+	List the invoices currently stored within the database. By default,
+	the 100 most recently added invoices are returned, callers can use the
+	--max_invoices param to modify this value.
+
+	The invoices can be paginated through their add index using the
+	--index_offset param. By default, the invoices that precede the offset
+	are returned, the --paginate_forwards flag can be used to return the
+	invoices that follow it instead. Each response contains the index of
+	its first and last invoice, which can be used as the offset of the next
+	query.
+
+	Finally, the invoices can be limited to a particular time range using
+	the --start_time and --end_time params, which are meant to be
+	expressed in seconds since the Unix epoch.
+	`,
 	Flags: []cli.Flag{
 		cli.BoolFlag{
 			Name: "pending_only",
 			Usage: "toggles if all invoices should be returned, or only " +
 				"those that are currently unsettled",
 		},
+		cli.Uint64Flag{
+			Name: "index_offset",
+			Usage: "the add index of the invoice at which the query " +
+				"starts, excluding the invoice itself",
+		},
+		cli.Uint64Flag{
+			Name:  "max_invoices",
+			Usage: "the max number of invoices to return",
+			Value: 100,
+		},
+		cli.BoolFlag{
+			Name: "paginate_forwards",
+			Usage: "if set, the invoices returned follow the " +
+				"index_offset, rather than preceding it",
+		},
+		cli.Uint64Flag{
+			Name: "start_time",
+			Usage: "if set, only invoices created after this time " +
+				"are returned, expressed in seconds since the " +
+				"unix epoch",
+		},
+		cli.Uint64Flag{
+			Name: "end_time",
+			Usage: "if set, only invoices created before this time " +
+				"are returned, expressed in seconds since the " +
+				"unix epoch",
+		},
 	},
 	Action: actionDecorator(listInvoices),
 }
@@ -1858,7 +2432,12 @@ func listInvoices(ctx *cli.Context) error {
 	}
 
 	req := &lnrpc.ListInvoiceRequest{
-		PendingOnly: pendingOnly,
+		PendingOnly:       pendingOnly,
+		IndexOffset:       ctx.Uint64("index_offset"),
+		NumMaxInvoices:    ctx.Uint64("max_invoices"),
+		Reversed:          !ctx.Bool("paginate_forwards"),
+		CreationDateStart: ctx.Uint64("start_time"),
+		CreationDateEnd:   ctx.Uint64("end_time"),
 	}
 
 	invoices, err := client.ListInvoices(context.Background(), req)
@@ -2067,8 +2646,62 @@ func drawChannelGraph(graph *lnrpc.ChannelGraph) error {
 }
 
 var listPaymentsCommand = cli.Command{
-	Name:   "listpayments",
-	Usage:  "List all outgoing payments",
+	Name:  "listpayments",
+	Usage: "List all outgoing payments",
+	Description: `
+	List the outgoing payments stored within the database. By default, the
+	100 most recent payments are returned, callers can use the
+	--max_payments param to modify this value.
+
+	The payments can be paginated through their index using the
+	--index_offset param. By default, the payments that precede the offset
+	are returned, the --paginate_forwards flag can be used to return the
+	payments that follow it instead. Each response contains the index of
+	its first and last payment, which can be used as the offset of the next
+	query.
+
+	The payments can be limited to a particular time range using the
+	--start_time and --end_time params, which are meant to be expressed in
+	seconds since the Unix epoch.
+
+	Finally, only succeeded payments are returned by default. The
+	--include_incomplete flag can be used to also return the payments that
+	are in flight or have failed.
+	`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "index_offset",
+			Usage: "the index of the payment at which the query " +
+				"starts, excluding the payment itself",
+		},
+		cli.Uint64Flag{
+			Name:  "max_payments",
+			Usage: "the max number of payments to return",
+			Value: 100,
+		},
+		cli.BoolFlag{
+			Name: "paginate_forwards",
+			Usage: "if set, the payments returned follow the " +
+				"index_offset, rather than preceding it",
+		},
+		cli.Uint64Flag{
+			Name: "start_time",
+			Usage: "if set, only payments created after this time " +
+				"are returned, expressed in seconds since the " +
+				"unix epoch",
+		},
+		cli.Uint64Flag{
+			Name: "end_time",
+			Usage: "if set, only payments created before this time " +
+				"are returned, expressed in seconds since the " +
+				"unix epoch",
+		},
+		cli.BoolFlag{
+			Name: "include_incomplete",
+			Usage: "if set, payments that are in flight or have " +
+				"failed are returned as well",
+		},
+	},
 	Action: actionDecorator(listPayments),
 }
 
@@ -2076,15 +2709,85 @@ func listPayments(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.ListPaymentsRequest{}
+	req := &lnrpc.ListPaymentsRequest{
+		IndexOffset:       ctx.Uint64("index_offset"),
+		MaxPayments:       ctx.Uint64("max_payments"),
+		Reversed:          !ctx.Bool("paginate_forwards"),
+		CreationDateStart: ctx.Uint64("start_time"),
+		CreationDateEnd:   ctx.Uint64("end_time"),
+		IncludeIncomplete: ctx.Bool("include_incomplete"),
+	}
 
 	payments, err := client.ListPayments(context.Background(), req)
 	if err != nil {
 		return err
 	}
 
-	printRespJSON(payments)
-	return nil
+	printRespJSON(payments)
+	return nil
+}
+
+var trackPaymentCommand = cli.Command{
+	Name:      "trackpayment",
+	Usage:     "Track the progress of an outgoing payment.",
+	ArgsUsage: "payment_hash",
+	Description: `
+	Prints the current state of the outgoing payment to the given payment
+	hash, followed by every update to it, until the payment has either
+	succeeded or failed.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "payment_hash",
+			Usage: "the 32 byte payment hash of the payment to " +
+				"track, the hash should be a hex-encoded string",
+		},
+	},
+	Action: actionDecorator(trackPayment),
+}
+
+func trackPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		paymentHash []byte
+		err         error
+	)
+
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash, err = hex.DecodeString(ctx.String("payment_hash"))
+	case ctx.Args().Present():
+		paymentHash, err = hex.DecodeString(ctx.Args().First())
+	default:
+		return fmt.Errorf("payment_hash argument missing")
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to decode payment_hash: %v", err)
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHash: paymentHash,
+	}
+
+	stream, err := client.TrackPayment(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		payment, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(payment)
+	}
 }
 
 var getChanInfoCommand = cli.Command{
@@ -2199,6 +2902,14 @@ var queryRoutesCommand = cli.Command{
 			Usage: "the max number of routes to be returned (default: 10)",
 			Value: 10,
 		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
+		outgoingChanIDFlag,
+		lastHopFlag,
+		feeWeightFlag,
+		timeLockRiskFactorFlag,
+		attemptCostFlag,
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -2238,10 +2949,25 @@ func queryRoutes(ctx *cli.Context) error {
 		return fmt.Errorf("amt argument missing")
 	}
 
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+
+	lastHop, err := retrieveLastHop(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey:    dest,
-		Amt:       amt,
-		NumRoutes: int32(ctx.Int("num_max_routes")),
+		PubKey:         dest,
+		Amt:            amt,
+		NumRoutes:      int32(ctx.Int("num_max_routes")),
+		FeeLimit:       feeLimit,
+		CltvLimit:      uint32(ctx.Uint64("cltv_limit")),
+		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+		LastHopPubkey:  lastHop,
+		CostModel:      retrieveCostModel(ctx),
 	}
 
 	route, err := client.QueryRoutes(ctxb, req)
@@ -2277,6 +3003,253 @@ func getNetworkInfo(ctx *cli.Context) error {
 	return nil
 }
 
+// maxGraphSnapshotSize is the largest graph snapshot that we'll send or
+// receive over gRPC.
+const maxGraphSnapshotSize = 200 * 1024 * 1024
+
+var exportGraphCommand = cli.Command{
+	Name:      "exportgraph",
+	Usage:     "Export a snapshot of the channel graph to a file.",
+	ArgsUsage: "output_file",
+	Description: `
+	Writes a compact snapshot of the public channel graph as known by the
+	node to the given file. The snapshot can be imported by another node
+	with importgraph in order to quickly bootstrap its view of the graph.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file to write the graph snapshot to",
+		},
+	},
+	Action: actionDecorator(exportGraph),
+}
+
+func exportGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var outputFile string
+	switch {
+	case ctx.IsSet("output_file"):
+		outputFile = ctx.String("output_file")
+	case ctx.Args().Present():
+		outputFile = ctx.Args().First()
+	default:
+		return fmt.Errorf("output_file argument missing")
+	}
+
+	req := &lnrpc.ExportGraphRequest{}
+	resp, err := client.ExportGraph(
+		ctxb, req, grpc.MaxCallRecvMsgSize(maxGraphSnapshotSize),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(outputFile, resp.Snapshot, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote graph snapshot of %v bytes to %v\n",
+		len(resp.Snapshot), outputFile)
+	return nil
+}
+
+var importGraphCommand = cli.Command{
+	Name:      "importgraph",
+	Usage:     "Import a snapshot of the channel graph from a file.",
+	ArgsUsage: "input_file",
+	Description: `
+	Imports a graph snapshot, as written by exportgraph, from the given
+	file. Each announcement within the snapshot is validated before being
+	applied to the channel graph, so the snapshot may come from an untrusted
+	source.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "input_file",
+			Usage: "the file to read the graph snapshot from",
+		},
+	},
+	Action: actionDecorator(importGraph),
+}
+
+func importGraph(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var inputFile string
+	switch {
+	case ctx.IsSet("input_file"):
+		inputFile = ctx.String("input_file")
+	case ctx.Args().Present():
+		inputFile = ctx.Args().First()
+	default:
+		return fmt.Errorf("input_file argument missing")
+	}
+
+	snapshot, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ImportGraphRequest{
+		Snapshot: snapshot,
+	}
+	resp, err := client.ImportGraph(
+		ctxb, req, grpc.MaxCallSendMsgSize(maxGraphSnapshotSize),
+	)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var probeCommand = cli.Command{
+	Name:  "probe",
+	Usage: "Probe whether an amount is able to reach a destination.",
+	Description: `
+	Sends HTLCs with a random payment hash along candidate routes to the
+	destination, to estimate whether a payment of the amount is able to
+	reach it without actually paying it. If a route lacks the liquidity to
+	carry the full amount, smaller amounts are probed to estimate the
+	amount each hop of the route is able to receive.`,
+	ArgsUsage: "dest amt",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "dest",
+			Usage: "the 33-byte hex-encoded public key for the payment " +
+				"destination",
+		},
+		cli.Int64Flag{
+			Name:  "amt",
+			Usage: "the amount to probe expressed in satoshis",
+		},
+		cli.Int64Flag{
+			Name:  "num_max_routes",
+			Usage: "the max number of routes to be probed (default: 3)",
+			Value: 3,
+		},
+		cli.Int64Flag{
+			Name: "final_cltv_delta",
+			Usage: "(optional) the number of blocks the last hop has " +
+				"to reveal the preimage",
+		},
+		feeLimitFlag,
+		feeLimitPercentFlag,
+		cltvLimitFlag,
+	},
+	Action: actionDecorator(probe),
+}
+
+func probe(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		dest string
+		amt  int64
+		err  error
+	)
+
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("dest"):
+		dest = ctx.String("dest")
+	case args.Present():
+		dest = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("dest argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("amt"):
+		amt = ctx.Int64("amt")
+	case args.Present():
+		amt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode amt argument: %v", err)
+		}
+	default:
+		return fmt.Errorf("amt argument missing")
+	}
+
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.ProbeRequest{
+		PubKey:         dest,
+		Amt:            amt,
+		NumRoutes:      int32(ctx.Int("num_max_routes")),
+		FeeLimit:       feeLimit,
+		CltvLimit:      uint32(ctx.Uint64("cltv_limit")),
+		FinalCltvDelta: int32(ctx.Int64("final_cltv_delta")),
+	}
+
+	resp, err := client.Probe(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var queryMissionControlCommand = cli.Command{
+	Name:  "querymc",
+	Usage: "Query the internal mission control state.",
+	Description: "Returns the channels and nodes that mission control " +
+		"learned about from past payment attempts, along with the " +
+		"estimated success probability of each channel.",
+	Action: actionDecorator(queryMissionControl),
+}
+
+func queryMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.QueryMissionControlRequest{}
+
+	snapshot, err := client.QueryMissionControl(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(snapshot)
+	return nil
+}
+
+var resetMissionControlCommand = cli.Command{
+	Name:  "resetmc",
+	Usage: "Reset the internal mission control state.",
+	Description: "Clears all mission control state, so that path " +
+		"finding starts over without any knowledge of past payment " +
+		"attempts.",
+	Action: actionDecorator(resetMissionControl),
+}
+
+func resetMissionControl(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ResetMissionControlRequest{}
+
+	_, err := client.ResetMissionControl(ctxb, req)
+	return err
+}
+
 var debugLevelCommand = cli.Command{
 	Name:  "debuglevel",
 	Usage: "Set the debug level.",
@@ -2680,6 +3653,182 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	return nil
 }
 
+var updateChanStatusCommand = cli.Command{
+	Name:      "updatechanstatus",
+	Usage:     "Manually enable or disable a channel.",
+	ArgsUsage: "chan_point action",
+	Description: `
+	Manually sets the status of a public channel, by broadcasting a channel
+	update with the disabled flag set accordingly. The action is one of:
+
+	  enable:  advertise the channel as enabled.
+	  disable: advertise the channel as disabled.
+	  auto:    derive the status of the channel from the liveness of its
+	           peer again, which is the default.
+
+	A status that was set with enable or disable is kept until auto is
+	requested, or until lnd is restarted.
+	Channel points are encoded as: funding_txid:output_index`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel whose status should be updated. " +
+				"Takes the form of: txid:output_index",
+		},
+		cli.StringFlag{
+			Name:  "action",
+			Usage: "the action to apply: enable, disable or auto",
+		},
+	},
+	Action: actionDecorator(updateChanStatus),
+}
+
+func updateChanStatus(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	args := ctx.Args()
+
+	var chanPointStr string
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+	case args.Present():
+		chanPointStr = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+
+	split := strings.Split(chanPointStr, ":")
+	if len(split) != 2 {
+		return fmt.Errorf("expecting chan_point to be in format of: " +
+			"txid:index")
+	}
+
+	index, err := strconv.ParseInt(split[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	var actionStr string
+	switch {
+	case ctx.IsSet("action"):
+		actionStr = ctx.String("action")
+	case args.Present():
+		actionStr = args.First()
+	default:
+		return fmt.Errorf("action argument missing")
+	}
+
+	var action lnrpc.UpdateChanStatusRequest_Action
+	switch strings.ToLower(actionStr) {
+	case "enable":
+		action = lnrpc.UpdateChanStatusRequest_ENABLE
+	case "disable":
+		action = lnrpc.UpdateChanStatusRequest_DISABLE
+	case "auto":
+		action = lnrpc.UpdateChanStatusRequest_AUTO
+	default:
+		return fmt.Errorf("invalid action %v, expected one of: "+
+			"enable, disable, auto", actionStr)
+	}
+
+	req := &lnrpc.UpdateChanStatusRequest{
+		ChanPoint: &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+				FundingTxidStr: split[0],
+			},
+			OutputIndex: uint32(index),
+		},
+		Action: action,
+	}
+
+	resp, err := client.UpdateChanStatus(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var updateNodeAnnouncementCommand = cli.Command{
+	Name:  "updatenodeannouncement",
+	Usage: "Update and broadcast our node announcement.",
+	Description: `
+	Updates the alias, color, advertised addresses and global feature bits
+	of our node announcement, then re-signs it and broadcasts it to the
+	network. Only the given fields are changed. The --add_address,
+	--remove_address, --set_feature_bit and --unset_feature_bit flags may
+	be specified multiple times.
+
+	Note that these changes aren't written to the configuration, so they'll
+	be overridden by it once lnd is restarted.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "alias",
+			Usage: "the new alias of the node",
+		},
+		cli.StringFlag{
+			Name:  "color",
+			Usage: "the new color of the node, in hex format (#rrggbb)",
+		},
+		cli.StringSliceFlag{
+			Name:  "add_address",
+			Usage: "an address to start advertising, in host:port form",
+		},
+		cli.StringSliceFlag{
+			Name:  "remove_address",
+			Usage: "a currently advertised address to stop advertising",
+		},
+		cli.IntSliceFlag{
+			Name:  "set_feature_bit",
+			Usage: "a global feature bit to set",
+		},
+		cli.IntSliceFlag{
+			Name:  "unset_feature_bit",
+			Usage: "a global feature bit to unset",
+		},
+	},
+	Action: actionDecorator(updateNodeAnnouncement),
+}
+
+func updateNodeAnnouncement(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.UpdateNodeAnnouncementRequest{
+		Alias:           ctx.String("alias"),
+		Color:           ctx.String("color"),
+		AddAddresses:    ctx.StringSlice("add_address"),
+		RemoveAddresses: ctx.StringSlice("remove_address"),
+	}
+
+	for _, bit := range ctx.IntSlice("set_feature_bit") {
+		if bit < 0 {
+			return fmt.Errorf("invalid feature bit: %v", bit)
+		}
+		req.SetFeatureBits = append(req.SetFeatureBits, uint32(bit))
+	}
+	for _, bit := range ctx.IntSlice("unset_feature_bit") {
+		if bit < 0 {
+			return fmt.Errorf("invalid feature bit: %v", bit)
+		}
+		req.UnsetFeatureBits = append(req.UnsetFeatureBits, uint32(bit))
+	}
+
+	resp, err := client.UpdateNodeAnnouncement(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var forwardingHistoryCommand = cli.Command{
 	Name:      "fwdinghistory",
 	Usage:     "Query the history of all forwarded htlcs",
@@ -209,16 +209,26 @@ func main() {
 		pendingChannelsCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
+		sendToRouteCommand,
+		rebalanceCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
+		settleInvoiceCommand,
+		cancelInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
 		listPaymentsCommand,
+		trackPaymentCommand,
 		describeGraphCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
 		getNetworkInfoCommand,
+		exportGraphCommand,
+		importGraphCommand,
+		probeCommand,
+		queryMissionControlCommand,
+		resetMissionControlCommand,
 		debugLevelCommand,
 		decodePayReqCommand,
 		listChainTxnsCommand,
@@ -227,6 +237,8 @@ func main() {
 		verifyMessageCommand,
 		feeReportCommand,
 		updateChannelPolicyCommand,
+		updateChanStatusCommand,
+		updateNodeAnnouncementCommand,
 		forwardingHistoryCommand,
 	}
 
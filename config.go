@@ -21,6 +21,7 @@ import (
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightningnetwork/lnd/brontide"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/torsvc"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcutil"
@@ -132,6 +133,12 @@ type autoPilotConfig struct {
 	MaxChannelSize int64   `long:"maxchansize" description:"The largest channel that the autopilot agent should create"`
 }
 
+type routingConfig struct {
+	FeeWeight          float64 `long:"feeweight" description:"The factor that the square of the fee paid for a channel is multiplied with when weighing the channels of a route"`
+	TimeLockRiskFactor float64 `long:"timelockriskfactor" description:"The weight of each block of time-lock delta of a channel when weighing the channels of a route"`
+	AttemptCost        int64   `long:"attemptcost" description:"The cost of a failed payment attempt in satoshis, which is weighed against the fees of a route to avoid unreliable channels"`
+}
+
 type torConfig struct {
 	Socks           string `long:"socks" description:"The port that Tor's exposed SOCKS5 proxy is listening on. Using Tor allows outbound-only connections (listening will be disabled) -- NOTE port must be between 1024 and 65535"`
 	DNS             string `long:"dns" description:"The DNS server as IP:PORT that Tor will use for SRV queries - NOTE must have TCP resolution enabled"`
@@ -187,6 +194,8 @@ type config struct {
 
 	Autopilot *autoPilotConfig `group:"autopilot" namespace:"autopilot"`
 
+	Routing *routingConfig `group:"routing" namespace:"routing"`
+
 	Tor *torConfig `group:"Tor" namespace:"tor"`
 
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
@@ -199,6 +208,10 @@ type config struct {
 	Color       string `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize int64  `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
 
+	ChanDisableTimeout time.Duration `long:"chandisabletimeout" description:"The duration that the link of a public channel must be inactive, for example because the peer is offline, before the channel is announced as disabled to the network. Valid time units are {s, m, h}."`
+
+	AcceptKeysend bool `long:"acceptkeysend" description:"If true, spontaneous payments made without an invoice (keysend) will be accepted, creating an invoice for each of them on the fly"`
+
 	net torsvc.Net
 }
 
@@ -262,10 +275,18 @@ func loadConfig() (*config, error) {
 			MinChannelSize: int64(minChanFundingSize),
 			MaxChannelSize: int64(maxFundingAmount),
 		},
-		TrickleDelay: defaultTrickleDelay,
-		Alias:        defaultAlias,
-		Color:        defaultColor,
-		MinChanSize:  int64(minChanFundingSize),
+		Routing: &routingConfig{
+			FeeWeight:          routing.DefaultCostModel.FeeWeight,
+			TimeLockRiskFactor: routing.DefaultCostModel.TimeLockRiskFactor,
+			AttemptCost: int64(
+				routing.DefaultCostModel.AttemptCost.ToSatoshis(),
+			),
+		},
+		TrickleDelay:       defaultTrickleDelay,
+		Alias:              defaultAlias,
+		Color:              defaultColor,
+		MinChanSize:        int64(minChanFundingSize),
+		ChanDisableTimeout: defaultChanDisableTimeout,
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -373,6 +394,36 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// Likewise, the weights of the routing cost model must be
+	// non-negative, as path finding relies on non-negative edge weights.
+	if cfg.Routing.FeeWeight < 0 {
+		str := "%s: routing.feeweight must be non-negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.Routing.TimeLockRiskFactor < 0 {
+		str := "%s: routing.timelockriskfactor must be non-negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.Routing.AttemptCost < 0 {
+		str := "%s: routing.attemptcost must be non-negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// A channel must be given some time to have its link reactivated
+	// before it's disabled.
+	if cfg.ChanDisableTimeout <= 0 {
+		str := "%s: chandisabletimeout must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
 	// Ensure that the specified values for the min and max channel size
 	// don't are within the bounds of the normal chan size constraints.
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
@@ -30,6 +30,11 @@ var (
 	// AnnounceSignatures messages, by persisting them until a send
 	// operation has succeeded.
 	messageStoreKey = []byte("message-store")
+
+	// ErrNoOwnPolicy is returned when the status of one of our channels
+	// is to be updated before we've announced our policy for it, which
+	// is the case until the channel has been announced.
+	ErrNoOwnPolicy = errors.New("no policy of ours found for channel")
 )
 
 // networkMsg couples a routing related wire message with the peer that
@@ -54,6 +59,17 @@ type chanPolicyUpdateRequest struct {
 	errResp chan error
 }
 
+// chanStatusUpdateRequest is a request that is sent to the gossiper when a
+// caller wishes to disable or re-enable one of our channels. A new
+// ChannelUpdate with the disabled flag set accordingly will be crafted to be
+// sent out during the next broadcast epoch.
+type chanStatusUpdateRequest struct {
+	chanID   lnwire.ShortChannelID
+	disabled bool
+
+	errResp chan error
+}
+
 // Config defines the configuration for the service. ALL elements within the
 // configuration MUST be non-nil for the service to carry out its duties.
 type Config struct {
@@ -121,6 +137,36 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// ChanSeries is an interfaces that provides access to a time series
+	// view of the current known channel graph. Each gossipSyncer enabled
+	// peer will utilize this in order to create and respond to channel
+	// graph time series queries.
+	ChanSeries ChannelGraphTimeSeries
+
+	// UpdateRateLimits are the limits on the rate at which we relay the
+	// channel updates we receive from the network. Updates that exceed
+	// these limits are still applied to our channel graph, but aren't
+	// relayed to our peers.
+	UpdateRateLimits UpdateRateLimits
+}
+
+// UpdateStats houses a set of counters that describe how the gossiper has
+// treated the channel updates it received from the network.
+type UpdateStats struct {
+	// NumStaleUpdates is the number of channel updates that were rejected
+	// before validating their signature, as we already knew of an update
+	// with the same or a newer timestamp.
+	NumStaleUpdates uint64
+
+	// NumChanRateLimited is the number of channel updates that weren't
+	// relayed, as the direction of the channel they belong to exceeded
+	// its rate limit.
+	NumChanRateLimited uint64
+
+	// NumPeerRateLimited is the number of channel updates that weren't
+	// relayed, as the peer that sent them exceeded its rate limit.
+	NumPeerRateLimited uint64
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -132,6 +178,12 @@ type Config struct {
 // incoming message are expected to be well formed and signed. Invalid messages
 // will be rejected by this struct.
 type AuthenticatedGossiper struct {
+	// The following counters track the channel updates that we've
+	// rejected or haven't relayed. They MUST be used atomically.
+	numStaleUpdates    uint64
+	numChanRateLimited uint64
+	numPeerRateLimited uint64
+
 	// Parameters which are needed to properly handle the start and stop of
 	// the service.
 	started uint32
@@ -180,6 +232,10 @@ type AuthenticatedGossiper struct {
 	// forwarding policy of a set of channels is sent over.
 	chanPolicyUpdates chan *chanPolicyUpdateRequest
 
+	// chanStatusUpdates is a channel that requests to disable or enable
+	// one of our channels are sent over.
+	chanStatusUpdates chan *chanStatusUpdateRequest
+
 	// bestHeight is the height of the block at the tip of the main chain
 	// as we know it.
 	bestHeight uint32
@@ -196,6 +252,19 @@ type AuthenticatedGossiper struct {
 	rejectMtx     sync.RWMutex
 	recentRejects map[uint64]struct{}
 
+	// peerSyncers keeps track of all the gossip syncers we're maintain for
+	// peers that understand this mode of operation. When we go to send out
+	// new updates, for all peers in the map, we'll send the messages
+	// directly to their gossiper, rather than broadcasting them. With this
+	// change, we ensure we filter out all updates properly.
+	syncerMtx   sync.RWMutex
+	peerSyncers map[routing.Vertex]*gossipSyncer
+
+	// updateLimiter limits the rate at which we relay the channel updates
+	// we receive from the network, for each direction of a channel as
+	// well as for each peer.
+	updateLimiter *updateRateLimiter
+
 	sync.Mutex
 }
 
@@ -213,14 +282,27 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		networkMsgs:             make(chan *networkMsg),
 		quit:                    make(chan struct{}),
 		chanPolicyUpdates:       make(chan *chanPolicyUpdateRequest),
+		chanStatusUpdates:       make(chan *chanStatusUpdateRequest),
 		prematureAnnouncements:  make(map[uint32][]*networkMsg),
 		prematureChannelUpdates: make(map[uint64][]*networkMsg),
 		waitingProofs:           storage,
 		channelMtx:              multimutex.NewMutex(),
 		recentRejects:           make(map[uint64]struct{}),
+		peerSyncers:             make(map[routing.Vertex]*gossipSyncer),
+		updateLimiter:           newUpdateRateLimiter(cfg.UpdateRateLimits),
 	}, nil
 }
 
+// UpdateStats returns the current values of the counters that describe how
+// the gossiper has treated the channel updates it received from the network.
+func (d *AuthenticatedGossiper) UpdateStats() UpdateStats {
+	return UpdateStats{
+		NumStaleUpdates:    atomic.LoadUint64(&d.numStaleUpdates),
+		NumChanRateLimited: atomic.LoadUint64(&d.numChanRateLimited),
+		NumPeerRateLimited: atomic.LoadUint64(&d.numPeerRateLimited),
+	}
+}
+
 // SynchronizeNode sends a message to the service indicating it should
 // synchronize lightning topology state with the target node. This method is to
 // be utilized when a node connections for the first time to provide it with
@@ -236,24 +318,6 @@ func (d *AuthenticatedGossiper) SynchronizeNode(pub *btcec.PublicKey) error {
 	// containing all the messages to be sent to the target peer.
 	var announceMessages []lnwire.Message
 
-	makeNodeAnn := func(n *channeldb.LightningNode) (*lnwire.NodeAnnouncement, error) {
-		alias, _ := lnwire.NewNodeAlias(n.Alias)
-
-		wireSig, err := lnwire.NewSigFromRawSignature(n.AuthSigBytes)
-		if err != nil {
-			return nil, err
-		}
-		return &lnwire.NodeAnnouncement{
-			Signature: wireSig,
-			Timestamp: uint32(n.LastUpdate.Unix()),
-			Addresses: n.Addresses,
-			NodeID:    n.PubKeyBytes,
-			Features:  n.Features.RawFeatureVector,
-			RGBColor:  n.Color,
-			Alias:     alias,
-		}, nil
-	}
-
 	// As peers are expecting channel announcements before node
 	// announcements, we first retrieve the initial announcement, as well as
 	// the latest channel update announcement for both of the directed edges
@@ -354,6 +418,29 @@ func (d *AuthenticatedGossiper) PropagateChanPolicyUpdate(
 	}
 }
 
+// PropagateChanStatusUpdate signals the AuthenticatedGossiper to disable or
+// re-enable our direction of the specified channel. If our latest update for
+// the channel already reflects the requested status, then this is a no-op.
+// Otherwise, a new update with the disabled flag set accordingly is signed,
+// committed to the graph, and broadcast to the network.
+func (d *AuthenticatedGossiper) PropagateChanStatusUpdate(
+	chanID lnwire.ShortChannelID, disabled bool) error {
+
+	errChan := make(chan error, 1)
+	statusUpdate := &chanStatusUpdateRequest{
+		chanID:   chanID,
+		disabled: disabled,
+		errResp:  errChan,
+	}
+
+	select {
+	case d.chanStatusUpdates <- statusUpdate:
+		return <-errChan
+	case <-d.quit:
+		return fmt.Errorf("AuthenticatedGossiper shutting down")
+	}
+}
+
 // Start spawns network messages handler goroutine and registers on new block
 // notifications in order to properly handle the premature announcements.
 func (d *AuthenticatedGossiper) Start() error {
@@ -402,6 +489,98 @@ func (d *AuthenticatedGossiper) Stop() {
 
 	close(d.quit)
 	d.wg.Wait()
+
+	// We'll stop our gossip syncers only after the main handler has
+	// exited, as it may still be filtering messages through them.
+	d.syncerMtx.RLock()
+	for _, syncer := range d.peerSyncers {
+		syncer.Stop()
+	}
+	d.syncerMtx.RUnlock()
+}
+
+// InitSyncState is called by outside sub-systems when a connection is
+// established to a new peer that understands how to perform channel range
+// queries. We'll allocate a new gossip syncer for it, and start any goroutines
+// needed to handle new queries. The recvUpdates bool indicates if we should
+// continue to receive real-time updates from the remote peer once we've synced
+// channel state.
+func (d *AuthenticatedGossiper) InitSyncState(syncPeer *btcec.PublicKey,
+	recvUpdates bool) error {
+
+	d.syncerMtx.Lock()
+	defer d.syncerMtx.Unlock()
+
+	// If we already have a syncer, then we'll exit early as we don't want
+	// to override it.
+	nodeID := routing.NewVertex(syncPeer)
+	if _, ok := d.peerSyncers[nodeID]; ok {
+		return nil
+	}
+
+	log.Infof("Creating new gossipSyncer for peer=%x", nodeID[:])
+
+	encoding := lnwire.EncodingSortedPlain
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:       d.cfg.ChainHash,
+		peerPub:         nodeID,
+		syncChanUpdates: recvUpdates,
+		channelSeries:   d.cfg.ChanSeries,
+		encodingType:    encoding,
+		chunkSize:       encodingTypeToChunkSize[encoding],
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			return d.cfg.SendToPeer(syncPeer, msgs...)
+		},
+	})
+	d.peerSyncers[nodeID] = syncer
+
+	return syncer.Start()
+}
+
+// PruneSyncState is called by outside sub-systems once a peer that we were
+// previously connected to has been disconnected. In this case we can stop the
+// existing gossipSyncer assigned to the peer and free up resources.
+func (d *AuthenticatedGossiper) PruneSyncState(peer *btcec.PublicKey) {
+	d.syncerMtx.Lock()
+
+	log.Infof("Removing gossipSyncer for peer=%x",
+		peer.SerializeCompressed())
+
+	vertex := routing.NewVertex(peer)
+	syncer, ok := d.peerSyncers[vertex]
+	if !ok {
+		d.syncerMtx.Unlock()
+		return
+	}
+
+	delete(d.peerSyncers, vertex)
+	d.syncerMtx.Unlock()
+
+	// The syncer may currently be blocked sending a message to the peer,
+	// so we'll stop it without blocking the caller.
+	go syncer.Stop()
+}
+
+// findGossipSyncer is a utility method used by the gossiper to locate the
+// gossip syncer for an inbound message so we can properly dispatch the
+// incoming message.
+func (d *AuthenticatedGossiper) findGossipSyncer(
+	pub *btcec.PublicKey) (*gossipSyncer, error) {
+
+	target := routing.NewVertex(pub)
+
+	d.syncerMtx.RLock()
+	syncer, ok := d.peerSyncers[target]
+	d.syncerMtx.RUnlock()
+
+	// If we're unable to find the peer, then we'll return an error as the
+	// peer didn't signal that it understands gossip queries.
+	if !ok {
+		return nil, fmt.Errorf("received gossip query from peer=%x "+
+			"without a gossipSyncer", target[:])
+	}
+
+	return syncer, nil
 }
 
 // ProcessRemoteAnnouncement sends a new remote announcement message along with
@@ -412,6 +591,29 @@ func (d *AuthenticatedGossiper) Stop() {
 func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	src *btcec.PublicKey) chan error {
 
+	// The gossip query messages aren't announcements, so rather than
+	// processing them within the main handler, we'll dispatch them
+	// directly to the gossip syncer of the peer that sent them.
+	switch msg.(type) {
+	case *lnwire.QueryShortChanIDs,
+		*lnwire.ReplyShortChanIDsEnd,
+		*lnwire.QueryChannelRange,
+		*lnwire.ReplyChannelRange,
+		*lnwire.GossipTimestampRange:
+
+		errChan := make(chan error, 1)
+
+		syncer, err := d.findGossipSyncer(src)
+		if err != nil {
+			log.Warnf("unable to find gossip syncer: %v", err)
+			errChan <- err
+			return errChan
+		}
+
+		errChan <- syncer.ProcessQueryMsg(msg)
+		return errChan
+	}
+
 	nMsg := &networkMsg{
 		msg:      msg,
 		isRemote: true,
@@ -879,6 +1081,26 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 			policyUpdate.errResp <- nil
 
+		// A request to disable or enable one of our channels has
+		// arrived. Just like policy updates, the resulting update is
+		// committed to the graph and added to the announcement batch.
+		case statusUpdate := <-d.chanStatusUpdates:
+			newChanUpdates, err := d.processChanStatusUpdate(
+				statusUpdate,
+			)
+			if err != nil && err != ErrNoOwnPolicy {
+				log.Errorf("Unable to craft status update: %v",
+					err)
+			}
+			if err != nil {
+				statusUpdate.errResp <- err
+				continue
+			}
+
+			announcements.AddMsgs(newChanUpdates...)
+
+			statusUpdate.errResp <- nil
+
 		case announcement := <-d.networkMsgs:
 			// Channel announcement signatures are the only message
 			// that we'll process serially.
@@ -993,12 +1215,39 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			log.Infof("Broadcasting batch of %v new announcements",
 				len(announcementBatch))
 
+			// The peers that have a gossip syncer will only
+			// receive the messages that pass their update horizon,
+			// so we'll hand the batch to each of their syncers to
+			// filter, and exclude them from the broadcast below.
+			d.syncerMtx.RLock()
+			syncerPeers := make(
+				map[routing.Vertex]struct{}, len(d.peerSyncers),
+			)
+			for peer, syncer := range d.peerSyncers {
+				syncerPeers[peer] = struct{}{}
+
+				d.wg.Add(1)
+				go func(syncer *gossipSyncer) {
+					defer d.wg.Done()
+					syncer.FilterGossipMsgs(
+						announcementBatch...,
+					)
+				}(syncer)
+			}
+			d.syncerMtx.RUnlock()
+
 			// If we have new things to announce then broadcast
 			// them to all our immediately connected peers.
 			for _, msgChunk := range announcementBatch {
-				err := d.cfg.Broadcast(
-					msgChunk.senders, msgChunk.msg,
-				)
+				skips := make(map[routing.Vertex]struct{})
+				for peer := range msgChunk.senders {
+					skips[peer] = struct{}{}
+				}
+				for peer := range syncerPeers {
+					skips[peer] = struct{}{}
+				}
+
+				err := d.cfg.Broadcast(skips, msgChunk.msg)
 				if err != nil {
 					log.Errorf("unable to send batch "+
 						"announcements: %v", err)
@@ -1017,6 +1266,11 @@ func (d *AuthenticatedGossiper) networkHandler() {
 					"channels: %v", err)
 			}
 
+			// We'll also use this opportunity to forget about the
+			// rate limits of channels and peers that have been
+			// quiet for a while.
+			d.updateLimiter.prune()
+
 		// The gossiper has been signalled to exit, to we exit our
 		// main loop so the wait group can be decremented.
 		case <-d.quit:
@@ -1193,6 +1447,79 @@ func (d *AuthenticatedGossiper) processChanPolicyUpdate(
 	return chanUpdates, nil
 }
 
+// processChanStatusUpdate generates a new channel update for our direction of
+// the channel targeted by the passed request, with the disabled flag set
+// according to the requested status. If our latest update already has the
+// requested status, then no new update is generated. Updates of private
+// channels are committed to the graph, but aren't broadcast.
+func (d *AuthenticatedGossiper) processChanStatusUpdate(
+	statusUpdate *chanStatusUpdateRequest) ([]networkMsg, error) {
+
+	chanInfo, e1, e2, err := d.cfg.Router.GetChannelByID(
+		statusUpdate.chanID,
+	)
+	switch {
+	case err == channeldb.ErrEdgeNotFound:
+		return nil, ErrNoOwnPolicy
+
+	case err != nil:
+		return nil, fmt.Errorf("unable to fetch channel %v: %v",
+			statusUpdate.chanID, err)
+	}
+
+	// Our direction of the channel is determined by our position within
+	// the channel announcement, so we'll use it to locate our policy.
+	var direction lnwire.ChanUpdateFlag
+	if !bytes.Equal(chanInfo.NodeKey1Bytes[:],
+		d.selfKey.SerializeCompressed()) {
+
+		direction = 1
+	}
+
+	var edge *channeldb.ChannelEdgePolicy
+	for _, e := range []*channeldb.ChannelEdgePolicy{e1, e2} {
+		if e != nil && e.Flags&lnwire.ChanUpdateDirection == direction {
+			edge = e
+		}
+	}
+	if edge == nil {
+		return nil, ErrNoOwnPolicy
+	}
+
+	isDisabled := edge.Flags&lnwire.ChanUpdateDisabled ==
+		lnwire.ChanUpdateDisabled
+	if isDisabled == statusUpdate.disabled {
+		return nil, nil
+	}
+
+	if statusUpdate.disabled {
+		edge.Flags |= lnwire.ChanUpdateDisabled
+	} else {
+		edge.Flags &^= lnwire.ChanUpdateDisabled
+	}
+
+	log.Debugf("Setting disabled=%v for channel %v",
+		statusUpdate.disabled, statusUpdate.chanID)
+
+	// Re-sign and update the backing ChannelGraphSource, and retrieve our
+	// ChannelUpdate to broadcast.
+	_, chanUpdate, err := d.updateChannel(chanInfo, edge)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the channel isn't announced to the network, then neither should
+	// its update.
+	if chanInfo.AuthProof == nil {
+		return nil, nil
+	}
+
+	return []networkMsg{{
+		peer: d.selfKey,
+		msg:  chanUpdate,
+	}}, nil
+}
+
 // processRejectedEdge examines a rejected edge to see if we can extract any
 // new announcements from it.  An edge will get rejected if we already added
 // the same edge without AuthProof to the graph. If the received announcement
@@ -1392,6 +1719,17 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			return nil
 		}
 
+		// If the channel has been marked as a zombie, then we'll
+		// reject the announcement, as only a fresh channel update can
+		// bring it back to life.
+		if d.cfg.Router.IsZombieEdge(msg.ShortChannelID) {
+			err := fmt.Errorf("ignoring ChannelAnnouncement for "+
+				"zombie chan_id=%v", msg.ShortChannelID.ToUint64())
+			log.Debug(err)
+			nMsg.err <- err
+			return nil
+		}
+
 		// At this point, we'll now ask the router if this is a stale
 		// update. If so we can skip all the processing below.
 		if d.cfg.Router.IsKnownEdge(msg.ShortChannelID) {
@@ -1605,12 +1943,19 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 
 		// Before we perform any of the expensive checks below, we'll
 		// make sure that the router doesn't already have a fresher
-		// announcement for this edge.
+		// announcement for this edge. This also rejects duplicates of
+		// updates we've already processed, as they carry the same
+		// timestamp.
 		timestamp := time.Unix(int64(msg.Timestamp), 0)
 		if d.cfg.Router.IsStaleEdgePolicy(
 			msg.ShortChannelID, timestamp, msg.Flags,
 		) {
 
+			atomic.AddUint64(&d.numStaleUpdates, 1)
+			log.Tracef("Ignoring stale ChannelUpdate(flags=%v) for "+
+				"short_chan_id=%v with timestamp %v", msg.Flags,
+				shortChanID, timestamp)
+
 			nMsg.err <- nil
 			return nil
 		}
@@ -1744,6 +2089,35 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			}
 		}
 
+		// Now that the update has been applied to our graph, we'll
+		// make sure that neither the direction of the channel nor the
+		// peer that sent it to us has exceeded its rate limit. If
+		// either has, then we won't relay the update, which prevents
+		// a peer flapping its policies from flooding the network.
+		if nMsg.isRemote {
+			sender := routing.NewVertex(nMsg.peer)
+			switch d.updateLimiter.allow(sender, msg) {
+			case rateLimitChannel:
+				atomic.AddUint64(&d.numChanRateLimited, 1)
+				log.Debugf("Not relaying ChannelUpdate(flags=%v) "+
+					"for short_chan_id=%v: channel rate "+
+					"limit exceeded", msg.Flags, shortChanID)
+
+				nMsg.err <- nil
+				return nil
+
+			case rateLimitPeer:
+				atomic.AddUint64(&d.numPeerRateLimited, 1)
+				log.Debugf("Not relaying ChannelUpdate(flags=%v) "+
+					"for short_chan_id=%v: rate limit of "+
+					"peer %x exceeded", msg.Flags, shortChanID,
+					sender[:])
+
+				nMsg.err <- nil
+				return nil
+			}
+		}
+
 		// Channel update announcement was successfully processed and
 		// now it can be broadcast to the rest of the network. However,
 		// we'll only broadcast the channel update announcement if it
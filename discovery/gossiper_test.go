@@ -111,6 +111,7 @@ type mockGraphSource struct {
 	nodes      []*channeldb.LightningNode
 	infos      map[uint64]*channeldb.ChannelEdgeInfo
 	edges      map[uint64][]*channeldb.ChannelEdgePolicy
+	zombies    map[uint64]struct{}
 	bestHeight uint32
 }
 
@@ -119,6 +120,7 @@ func newMockRouter(height uint32) *mockGraphSource {
 		bestHeight: height,
 		infos:      make(map[uint64]*channeldb.ChannelEdgeInfo),
 		edges:      make(map[uint64][]*channeldb.ChannelEdgePolicy),
+		zombies:    make(map[uint64]struct{}),
 	}
 }
 
@@ -219,6 +221,13 @@ func (r *mockGraphSource) IsKnownEdge(chanID lnwire.ShortChannelID) bool {
 	return ok
 }
 
+// IsZombieEdge returns true if the graph source has marked the passed channel
+// ID as a zombie.
+func (r *mockGraphSource) IsZombieEdge(chanID lnwire.ShortChannelID) bool {
+	_, ok := r.zombies[chanID.ToUint64()]
+	return ok
+}
+
 // IsStaleEdgePolicy returns true if the graph source has a channel edge for
 // the passed channel ID (and flags) that have a more recent timestamp.
 func (r *mockGraphSource) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
@@ -670,6 +679,278 @@ func TestProcessAnnouncement(t *testing.T) {
 	}
 }
 
+// TestRejectZombieChannelAnnouncement checks that channel announcements for
+// channels that have been marked as zombies are rejected by the gossiper.
+func TestRejectZombieChannelAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	// We'll mark the channel as a zombie within the router, which should
+	// cause the gossiper to reject its announcement.
+	ctx.router.zombies[ca.ShortChannelID.ToUint64()] = struct{}{}
+
+	select {
+	case err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+		ca, nodeKeyPriv1.PubKey(),
+	):
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote announcement not processed")
+	}
+	if err == nil {
+		t.Fatalf("expected zombie channel announcement to be " +
+			"rejected")
+	}
+
+	select {
+	case <-ctx.broadcastedMessage:
+		t.Fatal("zombie channel announcement was broadcast")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	if len(ctx.router.infos) != 0 {
+		t.Fatalf("zombie channel was added to router")
+	}
+}
+
+// TestChannelUpdateRateLimit checks that channel updates which exceed the
+// rate limit of their channel are applied to the graph but not relayed, and
+// that stale updates are rejected.
+func TestChannelUpdateRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	// We'll only allow a single update to be relayed for each direction
+	// of a channel.
+	ctx.gossiper.updateLimiter = newUpdateRateLimiter(UpdateRateLimits{
+		MaxChannelUpdateBurst: 1,
+		ChannelUpdateInterval: time.Hour,
+	})
+
+	nodePub := nodeKeyPriv1.PubKey()
+
+	processRemoteMsg := func(msg lnwire.Message) {
+		t.Helper()
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodePub,
+		):
+			if err != nil {
+				t.Fatalf("can't process remote announcement: "+
+					"%v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("remote announcement not processed")
+		}
+	}
+
+	assertBroadcast := func(expected bool) {
+		t.Helper()
+
+		select {
+		case <-ctx.broadcastedMessage:
+			if !expected {
+				t.Fatal("announcement was broadcast")
+			}
+		case <-time.After(2 * trickleDelay):
+			if expected {
+				t.Fatal("announcement wasn't broadcast")
+			}
+		}
+	}
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	processRemoteMsg(ca)
+	assertBroadcast(true)
+
+	// The first update should be relayed as usual.
+	timestamp := uint32(123456)
+	update1, err := createUpdateAnnouncement(0, 0, nodeKeyPriv1, timestamp)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	processRemoteMsg(update1)
+	assertBroadcast(true)
+
+	// The second update exceeds the rate limit of the channel, so while it
+	// should be applied to the graph, it shouldn't be relayed.
+	update2, err := createUpdateAnnouncement(
+		0, 0, nodeKeyPriv1, timestamp+1,
+	)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	processRemoteMsg(update2)
+	assertBroadcast(false)
+
+	if len(ctx.router.edges[ca.ShortChannelID.ToUint64()]) != 2 {
+		t.Fatalf("rate limited update wasn't added to router")
+	}
+
+	// Finally, a duplicate of the first update should be rejected as
+	// stale.
+	processRemoteMsg(update1)
+	assertBroadcast(false)
+
+	stats := ctx.gossiper.UpdateStats()
+	if stats.NumChanRateLimited != 1 {
+		t.Fatalf("expected 1 rate limited update, instead got %v",
+			stats.NumChanRateLimited)
+	}
+	if stats.NumStaleUpdates != 1 {
+		t.Fatalf("expected 1 stale update, instead got %v",
+			stats.NumStaleUpdates)
+	}
+}
+
+// TestChanStatusUpdate checks that requests to disable and re-enable one of
+// our channels result in properly signed channel updates being broadcast, and
+// that requests for the status the channel already has are ignored.
+func TestChanStatusUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	// The gossiper is backed by the first node, so it must be able to sign
+	// updates with its key.
+	ctx.gossiper.cfg.AnnSigner = &mockSigner{nodeKeyPriv1}
+
+	nodePub := nodeKeyPriv1.PubKey()
+
+	processRemoteMsg := func(msg lnwire.Message) {
+		t.Helper()
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodePub,
+		):
+			if err != nil {
+				t.Fatalf("can't process remote announcement: "+
+					"%v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("remote announcement not processed")
+		}
+	}
+
+	assertBroadcast := func() {
+		t.Helper()
+
+		select {
+		case <-ctx.broadcastedMessage:
+		case <-time.After(2 * trickleDelay):
+			t.Fatal("announcement wasn't broadcast")
+		}
+	}
+
+	assertStatusUpdate := func(expected bool, disabled bool) {
+		t.Helper()
+
+		select {
+		case msg := <-ctx.broadcastedMessage:
+			if !expected {
+				t.Fatal("status update was broadcast")
+			}
+
+			upd, ok := msg.msg.(*lnwire.ChannelUpdate)
+			if !ok {
+				t.Fatalf("expected channel update, instead "+
+					"got %T", msg.msg)
+			}
+			isDisabled := upd.Flags&lnwire.ChanUpdateDisabled ==
+				lnwire.ChanUpdateDisabled
+			if isDisabled != disabled {
+				t.Fatalf("expected disabled=%v, instead got "+
+					"%v", disabled, isDisabled)
+			}
+			if upd.Flags&lnwire.ChanUpdateDirection != 0 {
+				t.Fatalf("update for wrong direction: %v",
+					upd.Flags)
+			}
+			err := ValidateChannelUpdateAnn(nodePub, upd)
+			if err != nil {
+				t.Fatalf("invalid status update: %v", err)
+			}
+
+		case <-time.After(2 * trickleDelay):
+			if expected {
+				t.Fatal("status update wasn't broadcast")
+			}
+		}
+	}
+
+	// We'll start by adding the channel along with our policy to the
+	// graph.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	processRemoteMsg(ca)
+	assertBroadcast()
+
+	ua, err := createUpdateAnnouncement(0, 0, nodeKeyPriv1, 123456)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	processRemoteMsg(ua)
+	assertBroadcast()
+
+	// As the channel is currently enabled, requesting it to be enabled
+	// shouldn't result in a new update.
+	chanID := ca.ShortChannelID
+	if err := ctx.gossiper.PropagateChanStatusUpdate(chanID, false); err != nil {
+		t.Fatalf("unable to update channel status: %v", err)
+	}
+	assertStatusUpdate(false, false)
+
+	// Disabling the channel should result in a disabled update being
+	// broadcast, but only once.
+	if err := ctx.gossiper.PropagateChanStatusUpdate(chanID, true); err != nil {
+		t.Fatalf("unable to update channel status: %v", err)
+	}
+	assertStatusUpdate(true, true)
+
+	if err := ctx.gossiper.PropagateChanStatusUpdate(chanID, true); err != nil {
+		t.Fatalf("unable to update channel status: %v", err)
+	}
+	assertStatusUpdate(false, true)
+
+	// Re-enabling the channel should result in an enabled update.
+	if err := ctx.gossiper.PropagateChanStatusUpdate(chanID, false); err != nil {
+		t.Fatalf("unable to update channel status: %v", err)
+	}
+	assertStatusUpdate(true, false)
+
+	// Finally, requests for unknown channels should fail.
+	unknownChanID := lnwire.NewShortChanIDFromInt(chanID.ToUint64() + 1)
+	err = ctx.gossiper.PropagateChanStatusUpdate(unknownChanID, true)
+	if err == nil {
+		t.Fatalf("expected status update of unknown channel to fail")
+	}
+}
+
 // TestPrematureAnnouncement checks that premature announcements are
 // not propagated to the router subsystem until block with according
 // block height received.
@@ -0,0 +1,414 @@
+package discovery
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+const (
+	// GraphSnapshotVersion is the current version of the graph snapshot
+	// format. It's written within the header of every snapshot, and must
+	// be bumped whenever the format changes.
+	GraphSnapshotVersion uint8 = 1
+)
+
+var (
+	// graphSnapshotMagic is the sequence of bytes every graph snapshot
+	// starts with, which allows us to quickly reject files that aren't a
+	// graph snapshot.
+	graphSnapshotMagic = [4]byte{'l', 'n', 'g', 's'}
+
+	// ErrInvalidGraphSnapshot is returned when attempting to read a graph
+	// snapshot that doesn't start with the expected magic bytes.
+	ErrInvalidGraphSnapshot = errors.New("invalid graph snapshot")
+)
+
+// ErrUnknownGraphSnapshotVersion is a parametrized error that indicates that
+// we came across a graph snapshot of a version that we don't know of.
+func ErrUnknownGraphSnapshotVersion(version uint8) error {
+	return fmt.Errorf("unknown graph snapshot version: %v", version)
+}
+
+// WriteGraphSnapshot writes a snapshot of the passed signed announcements of
+// the channel graph of the given chain to the passed io.Writer. The snapshot
+// starts with a header of the magic bytes, the version of the format, and the
+// chain hash. The header is followed by a zlib compressed stream of the
+// announcements, each of which is prefixed by its length and encoded as on
+// the wire.
+func WriteGraphSnapshot(w io.Writer, chain chainhash.Hash,
+	msgs []lnwire.Message) error {
+
+	var header [4 + 1 + chainhash.HashSize]byte
+	copy(header[:4], graphSnapshotMagic[:])
+	header[4] = GraphSnapshotVersion
+	copy(header[5:], chain[:])
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+
+	zlibWriter := zlib.NewWriter(w)
+
+	var (
+		msgBuf bytes.Buffer
+		lenBuf [2]byte
+	)
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case *lnwire.ChannelAnnouncement, *lnwire.ChannelUpdate,
+			*lnwire.NodeAnnouncement:
+
+		default:
+			return fmt.Errorf("unable to write %v to graph "+
+				"snapshot", msg.MsgType())
+		}
+
+		msgBuf.Reset()
+		if _, err := lnwire.WriteMessage(&msgBuf, msg, 0); err != nil {
+			return err
+		}
+
+		if msgBuf.Len() > math.MaxUint16 {
+			return fmt.Errorf("%v exceeds max size of graph "+
+				"snapshot entry: %v", msg.MsgType(),
+				msgBuf.Len())
+		}
+
+		binary.BigEndian.PutUint16(lenBuf[:], uint16(msgBuf.Len()))
+		if _, err := zlibWriter.Write(lenBuf[:]); err != nil {
+			return err
+		}
+		if _, err := zlibWriter.Write(msgBuf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	// Now that we've written all the announcements, we'll ensure the
+	// compressed stream is flushed to the underlying writer.
+	return zlibWriter.Close()
+}
+
+// ReadGraphSnapshot reads a graph snapshot, as written by WriteGraphSnapshot,
+// from the passed io.Reader. The chain hash of the snapshot is returned along
+// with the announcements within it, in the order in which they were written.
+// The announcements are only parsed, so their signatures still need to be
+// validated by the caller.
+func ReadGraphSnapshot(r io.Reader) (chainhash.Hash, []lnwire.Message, error) {
+	var chain chainhash.Hash
+
+	var header [4 + 1 + chainhash.HashSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return chain, nil, err
+	}
+	if !bytes.Equal(header[:4], graphSnapshotMagic[:]) {
+		return chain, nil, ErrInvalidGraphSnapshot
+	}
+	if header[4] != GraphSnapshotVersion {
+		return chain, nil, ErrUnknownGraphSnapshotVersion(header[4])
+	}
+	copy(chain[:], header[5:])
+
+	zlibReader, err := zlib.NewReader(r)
+	if err != nil {
+		return chain, nil, fmt.Errorf("unable to create zlib "+
+			"reader: %v", err)
+	}
+	defer zlibReader.Close()
+
+	var (
+		msgs   []lnwire.Message
+		lenBuf [2]byte
+	)
+	for {
+		_, err := io.ReadFull(zlibReader, lenBuf[:])
+		switch {
+		case err == io.EOF:
+			return chain, msgs, nil
+
+		case err != nil:
+			return chain, nil, err
+		}
+
+		msgBytes := make([]byte, binary.BigEndian.Uint16(lenBuf[:]))
+		if _, err := io.ReadFull(zlibReader, msgBytes); err != nil {
+			return chain, nil, err
+		}
+
+		msg, err := lnwire.ReadMessage(bytes.NewReader(msgBytes), 0)
+		if err != nil {
+			return chain, nil, err
+		}
+
+		switch msg.(type) {
+		case *lnwire.ChannelAnnouncement, *lnwire.ChannelUpdate,
+			*lnwire.NodeAnnouncement:
+
+		default:
+			return chain, nil, fmt.Errorf("unexpected %v within "+
+				"graph snapshot", msg.MsgType())
+		}
+
+		msgs = append(msgs, msg)
+	}
+}
+
+// GraphImportStats summarizes the outcome of importing a set of announcements
+// into the channel graph.
+type GraphImportStats struct {
+	// NumChannels is the number of channels that were added to the graph.
+	NumChannels uint32
+
+	// NumUpdates is the number of channel updates that were applied to
+	// the graph.
+	NumUpdates uint32
+
+	// NumNodes is the number of node announcements that were applied to
+	// the graph.
+	NumNodes uint32
+
+	// NumSkipped is the number of announcements that were skipped, as we
+	// already knew of them, or of more recent versions of them.
+	NumSkipped uint32
+
+	// NumInvalid is the number of announcements that were rejected, as
+	// they failed validation.
+	NumInvalid uint32
+}
+
+// ExportGraph returns the signed announcements of all the public channels
+// within our channel graph, along with their latest updates and the
+// announcements of their nodes. The announcements are ordered such that each
+// channel announcement precedes its updates, and all node announcements
+// follow the channel announcements, which allows them to be imported in
+// order.
+func (d *AuthenticatedGossiper) ExportGraph() ([]lnwire.Message, error) {
+	return d.cfg.ChanSeries.UpdatesInHorizon(
+		d.cfg.ChainHash, time.Unix(0, 0), time.Unix(math.MaxUint32, 0),
+	)
+}
+
+// ImportGraph validates the passed set of announcements, and applies the
+// valid ones to our channel graph. Unlike announcements received from our
+// peers, the imported announcements aren't relayed to the rest of the
+// network. The announcements are expected to be ordered as returned by
+// ExportGraph.
+func (d *AuthenticatedGossiper) ImportGraph(
+	msgs []lnwire.Message) (*GraphImportStats, error) {
+
+	var stats GraphImportStats
+	for _, msg := range msgs {
+		var (
+			imported bool
+			err      error
+		)
+		switch msg := msg.(type) {
+		case *lnwire.ChannelAnnouncement:
+			imported, err = d.importChanAnn(msg)
+			if imported {
+				stats.NumChannels++
+			}
+
+		case *lnwire.ChannelUpdate:
+			imported, err = d.importChanUpdate(msg)
+			if imported {
+				stats.NumUpdates++
+			}
+
+		case *lnwire.NodeAnnouncement:
+			imported, err = d.importNodeAnn(msg)
+			if imported {
+				stats.NumNodes++
+			}
+
+		default:
+			return nil, fmt.Errorf("unable to import %v",
+				msg.MsgType())
+		}
+
+		switch {
+		case err != nil:
+			log.Debugf("Unable to import %v: %v", msg.MsgType(),
+				err)
+			stats.NumInvalid++
+
+		case !imported:
+			stats.NumSkipped++
+		}
+	}
+
+	log.Infof("Imported graph snapshot: %v channels, %v channel updates "+
+		"and %v nodes added, %v skipped, %v invalid", stats.NumChannels,
+		stats.NumUpdates, stats.NumNodes, stats.NumSkipped,
+		stats.NumInvalid)
+
+	return &stats, nil
+}
+
+// importChanAnn validates the passed channel announcement and adds the
+// channel to the graph. False is returned if the channel was already known.
+func (d *AuthenticatedGossiper) importChanAnn(
+	msg *lnwire.ChannelAnnouncement) (bool, error) {
+
+	if !bytes.Equal(msg.ChainHash[:], d.cfg.ChainHash[:]) {
+		return false, fmt.Errorf("channel announcement for unknown "+
+			"chain=%v", msg.ChainHash)
+	}
+
+	if d.cfg.Router.IsZombieEdge(msg.ShortChannelID) ||
+		d.cfg.Router.IsKnownEdge(msg.ShortChannelID) {
+
+		return false, nil
+	}
+
+	if err := ValidateChannelAnn(msg); err != nil {
+		return false, err
+	}
+
+	var featureBuf bytes.Buffer
+	if err := msg.Features.Encode(&featureBuf); err != nil {
+		return false, err
+	}
+
+	edge := &channeldb.ChannelEdgeInfo{
+		ChannelID:        msg.ShortChannelID.ToUint64(),
+		ChainHash:        msg.ChainHash,
+		NodeKey1Bytes:    msg.NodeID1,
+		NodeKey2Bytes:    msg.NodeID2,
+		BitcoinKey1Bytes: msg.BitcoinKey1,
+		BitcoinKey2Bytes: msg.BitcoinKey2,
+		AuthProof: &channeldb.ChannelAuthProof{
+			NodeSig1Bytes:    msg.NodeSig1.ToSignatureBytes(),
+			NodeSig2Bytes:    msg.NodeSig2.ToSignatureBytes(),
+			BitcoinSig1Bytes: msg.BitcoinSig1.ToSignatureBytes(),
+			BitcoinSig2Bytes: msg.BitcoinSig2.ToSignatureBytes(),
+		},
+		Features: featureBuf.Bytes(),
+	}
+
+	// The router will ensure that the channel's funding output exists
+	// and hasn't been spent before adding it to the graph.
+	d.channelMtx.Lock(msg.ShortChannelID.ToUint64())
+	defer d.channelMtx.Unlock(msg.ShortChannelID.ToUint64())
+	if err := d.cfg.Router.AddEdge(edge); err != nil {
+		if routing.IsError(err, routing.ErrOutdated, routing.ErrIgnored) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// importChanUpdate validates the passed channel update and applies it to
+// the graph. False is returned if we already know of a more recent update.
+func (d *AuthenticatedGossiper) importChanUpdate(
+	msg *lnwire.ChannelUpdate) (bool, error) {
+
+	if !bytes.Equal(msg.ChainHash[:], d.cfg.ChainHash[:]) {
+		return false, fmt.Errorf("channel update for unknown "+
+			"chain=%v", msg.ChainHash)
+	}
+
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+	if d.cfg.Router.IsStaleEdgePolicy(
+		msg.ShortChannelID, timestamp, msg.Flags,
+	) {
+
+		return false, nil
+	}
+
+	d.channelMtx.Lock(msg.ShortChannelID.ToUint64())
+	defer d.channelMtx.Unlock(msg.ShortChannelID.ToUint64())
+
+	// We'll need the public key of the node the update belongs to in
+	// order to verify its signature, so the channel must be known by now.
+	chanInfo, _, _, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
+	if err != nil {
+		return false, fmt.Errorf("unable to fetch channel %v: %v",
+			msg.ShortChannelID, err)
+	}
+
+	var pubKey *btcec.PublicKey
+	switch {
+	case msg.Flags&lnwire.ChanUpdateDirection == 0:
+		pubKey, err = chanInfo.NodeKey1()
+	case msg.Flags&lnwire.ChanUpdateDirection == 1:
+		pubKey, err = chanInfo.NodeKey2()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := ValidateChannelUpdateAnn(pubKey, msg); err != nil {
+		return false, err
+	}
+
+	update := &channeldb.ChannelEdgePolicy{
+		SigBytes:                  msg.Signature.ToSignatureBytes(),
+		ChannelID:                 msg.ShortChannelID.ToUint64(),
+		LastUpdate:                timestamp,
+		Flags:                     msg.Flags,
+		TimeLockDelta:             msg.TimeLockDelta,
+		MinHTLC:                   msg.HtlcMinimumMsat,
+		FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
+		FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
+	}
+	if err := d.cfg.Router.UpdateEdge(update); err != nil {
+		if routing.IsError(err, routing.ErrOutdated, routing.ErrIgnored) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// importNodeAnn validates the passed node announcement and applies it to the
+// graph. False is returned if we already know of a more recent announcement.
+func (d *AuthenticatedGossiper) importNodeAnn(
+	msg *lnwire.NodeAnnouncement) (bool, error) {
+
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+	if d.cfg.Router.IsStaleNode(msg.NodeID, timestamp) {
+		return false, nil
+	}
+
+	if err := ValidateNodeAnn(msg); err != nil {
+		return false, err
+	}
+
+	node := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           timestamp,
+		Addresses:            msg.Addresses,
+		PubKeyBytes:          msg.NodeID,
+		Alias:                msg.Alias.String(),
+		AuthSigBytes:         msg.Signature.ToSignatureBytes(),
+		Features: lnwire.NewFeatureVector(
+			msg.Features, lnwire.GlobalFeatures,
+		),
+		Color: msg.RGBColor,
+	}
+	if err := d.cfg.Router.AddNode(node); err != nil {
+		if routing.IsError(err, routing.ErrOutdated, routing.ErrIgnored) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,179 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// snapshotMsgs returns a valid set of announcements of a single channel, in
+// the order in which they'd be exported.
+func snapshotMsgs(t *testing.T) []lnwire.Message {
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	return []lnwire.Message{
+		batch.remoteChanAnn, batch.chanUpdAnn1, batch.chanUpdAnn2,
+		batch.nodeAnn1, batch.nodeAnn2,
+	}
+}
+
+// TestGraphSnapshotEncoding tests that a set of announcements written to a
+// graph snapshot can be read back, and that malformed snapshots are rejected.
+func TestGraphSnapshotEncoding(t *testing.T) {
+	t.Parallel()
+
+	chain := chainhash.Hash{1, 2, 3}
+	msgs := snapshotMsgs(t)
+
+	var b bytes.Buffer
+	if err := WriteGraphSnapshot(&b, chain, msgs); err != nil {
+		t.Fatalf("unable to write graph snapshot: %v", err)
+	}
+
+	// The buffer is reused below, so we'll copy the snapshot out of it.
+	snapshot := append([]byte(nil), b.Bytes()...)
+
+	readChain, readMsgs, err := ReadGraphSnapshot(bytes.NewReader(snapshot))
+	if err != nil {
+		t.Fatalf("unable to read graph snapshot: %v", err)
+	}
+	if readChain != chain {
+		t.Fatalf("expected chain %v, instead got %v", chain, readChain)
+	}
+	if len(readMsgs) != len(msgs) {
+		t.Fatalf("expected %v messages, instead got %v", len(msgs),
+			len(readMsgs))
+	}
+
+	// We'll compare the messages by their encoding, as decoding may not
+	// reproduce the exact same in-memory representation.
+	for i, msg := range msgs {
+		var expected, actual bytes.Buffer
+		if _, err := lnwire.WriteMessage(&expected, msg, 0); err != nil {
+			t.Fatalf("unable to encode message: %v", err)
+		}
+		_, err := lnwire.WriteMessage(&actual, readMsgs[i], 0)
+		if err != nil {
+			t.Fatalf("unable to encode message: %v", err)
+		}
+
+		if !bytes.Equal(expected.Bytes(), actual.Bytes()) {
+			t.Fatalf("message #%v doesn't match: expected %v, "+
+				"instead got %v", i, msg.MsgType(),
+				readMsgs[i].MsgType())
+		}
+	}
+
+	// An empty snapshot should also be read back properly.
+	b.Reset()
+	if err := WriteGraphSnapshot(&b, chain, nil); err != nil {
+		t.Fatalf("unable to write graph snapshot: %v", err)
+	}
+	_, readMsgs, err = ReadGraphSnapshot(&b)
+	if err != nil {
+		t.Fatalf("unable to read graph snapshot: %v", err)
+	}
+	if len(readMsgs) != 0 {
+		t.Fatalf("expected no messages, instead got %v", len(readMsgs))
+	}
+
+	// Only announcements may be written to a snapshot.
+	err = WriteGraphSnapshot(&b, chain, []lnwire.Message{&lnwire.Ping{}})
+	if err == nil {
+		t.Fatalf("expected writing a ping to fail")
+	}
+
+	// A snapshot with the wrong magic bytes, or an unknown version should
+	// be rejected.
+	corrupted := append([]byte(nil), snapshot...)
+	corrupted[0] ^= 0xff
+	_, _, err = ReadGraphSnapshot(bytes.NewReader(corrupted))
+	if err != ErrInvalidGraphSnapshot {
+		t.Fatalf("expected ErrInvalidGraphSnapshot, instead got %v",
+			err)
+	}
+
+	corrupted = append([]byte(nil), snapshot...)
+	corrupted[4] = GraphSnapshotVersion + 1
+	_, _, err = ReadGraphSnapshot(bytes.NewReader(corrupted))
+	if err == nil {
+		t.Fatalf("expected unknown version to be rejected")
+	}
+
+	// Finally, a truncated snapshot should fail to be read.
+	truncated := snapshot[:len(snapshot)-10]
+	if _, _, err := ReadGraphSnapshot(bytes.NewReader(truncated)); err == nil {
+		t.Fatalf("expected truncated snapshot to be rejected")
+	}
+}
+
+// TestImportGraph tests that the announcements of a graph snapshot are
+// validated and applied to the graph without being relayed, and that known
+// announcements are skipped.
+func TestImportGraph(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup, err := createTestCtx(0)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+	defer cleanup()
+
+	msgs := snapshotMsgs(t)
+
+	// We'll also add an update whose signature doesn't match its content,
+	// which should be rejected.
+	invalidUpdate := *msgs[1].(*lnwire.ChannelUpdate)
+	invalidUpdate.Timestamp++
+	invalidUpdate.BaseFee++
+	msgs = append(msgs, &invalidUpdate)
+
+	stats, err := ctx.gossiper.ImportGraph(msgs)
+	if err != nil {
+		t.Fatalf("unable to import graph: %v", err)
+	}
+	expectedStats := GraphImportStats{
+		NumChannels: 1,
+		NumUpdates:  2,
+		NumNodes:    2,
+		NumInvalid:  1,
+	}
+	if *stats != expectedStats {
+		t.Fatalf("expected stats %v, instead got %v", expectedStats,
+			*stats)
+	}
+
+	if len(ctx.router.infos) != 1 {
+		t.Fatalf("channel wasn't added to router")
+	}
+	if len(ctx.router.nodes) != 2 {
+		t.Fatalf("nodes weren't added to router")
+	}
+
+	// None of the imported announcements should be relayed.
+	select {
+	case <-ctx.broadcastedMessage:
+		t.Fatal("imported announcement was broadcast")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Importing the same set of valid announcements again should skip all
+	// of them.
+	stats, err = ctx.gossiper.ImportGraph(msgs[:5])
+	if err != nil {
+		t.Fatalf("unable to import graph: %v", err)
+	}
+	expectedStats = GraphImportStats{
+		NumSkipped: 5,
+	}
+	if *stats != expectedStats {
+		t.Fatalf("expected stats %v, instead got %v", expectedStats,
+			*stats)
+	}
+}
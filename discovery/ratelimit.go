@@ -0,0 +1,233 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+)
+
+const (
+	// DefaultMaxChannelUpdateBurst is the default number of channel
+	// updates for a single direction of a channel that we'll relay in
+	// quick succession, before the update interval kicks in.
+	DefaultMaxChannelUpdateBurst = 10
+
+	// DefaultChannelUpdateInterval is the default interval at which a
+	// single direction of a channel regains the allowance to have one more
+	// of its updates relayed.
+	DefaultChannelUpdateInterval = time.Minute
+
+	// DefaultMaxPeerUpdateBurst is the default number of channel updates
+	// that we'll relay from a single peer in quick succession, before the
+	// update interval kicks in.
+	DefaultMaxPeerUpdateBurst = 1000
+
+	// DefaultPeerUpdateInterval is the default interval at which a peer
+	// regains the allowance to have one more of the channel updates it
+	// sent us relayed.
+	DefaultPeerUpdateInterval = 100 * time.Millisecond
+)
+
+// tokenBucket is a simple token bucket rate limiter. The bucket holds up to
+// burst tokens, and regains a single token every interval. Each allowed event
+// consumes a single token.
+type tokenBucket struct {
+	burst    float64
+	interval time.Duration
+
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newTokenBucket creates a new full token bucket with the given burst size
+// and refill interval.
+func newTokenBucket(burst int, interval time.Duration,
+	now time.Time) *tokenBucket {
+
+	return &tokenBucket{
+		burst:      float64(burst),
+		interval:   interval,
+		tokens:     float64(burst),
+		lastRefill: now,
+	}
+}
+
+// refill adds the tokens that have been regained since the last refill to
+// the bucket, without exceeding its burst size.
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens += float64(elapsed) / float64(b.interval)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.lastRefill = now
+}
+
+// hasToken returns true if the bucket currently holds at least one token.
+func (b *tokenBucket) hasToken(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= 1
+}
+
+// take consumes a single token from the bucket.
+//
+// NOTE: This method MUST only be called after hasToken returned true.
+func (b *tokenBucket) take() {
+	b.tokens--
+}
+
+// isFull returns true if the bucket has regained all of its tokens, in which
+// case it's indistinguishable from a freshly created bucket.
+func (b *tokenBucket) isFull(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// UpdateRateLimits houses the parameters of the token buckets used to limit
+// the rate at which we relay channel updates. A zero burst size disables the
+// corresponding limit.
+type UpdateRateLimits struct {
+	// MaxChannelUpdateBurst is the number of updates for a single
+	// direction of a channel that we'll relay in quick succession.
+	MaxChannelUpdateBurst int
+
+	// ChannelUpdateInterval is the interval at which a single direction
+	// of a channel regains the allowance to have one more update relayed.
+	ChannelUpdateInterval time.Duration
+
+	// MaxPeerUpdateBurst is the number of updates sent by a single peer
+	// that we'll relay in quick succession.
+	MaxPeerUpdateBurst int
+
+	// PeerUpdateInterval is the interval at which a peer regains the
+	// allowance to have one more of its updates relayed.
+	PeerUpdateInterval time.Duration
+}
+
+// updateRateLimiter limits the rate at which channel updates are relayed to
+// the rest of the network, both for each direction of a channel and for each
+// peer that sends us updates. This protects us, and the network, against
+// peers that flap the policies of their channels.
+type updateRateLimiter struct {
+	limits UpdateRateLimits
+
+	// now returns the current time. It's a variable in order to allow
+	// tests to control the passing of time.
+	now func() time.Time
+
+	mu          sync.Mutex
+	chanBuckets map[channelUpdateID]*tokenBucket
+	peerBuckets map[routing.Vertex]*tokenBucket
+}
+
+// newUpdateRateLimiter creates a new updateRateLimiter enforcing the passed
+// limits.
+func newUpdateRateLimiter(limits UpdateRateLimits) *updateRateLimiter {
+	return &updateRateLimiter{
+		limits:      limits,
+		now:         time.Now,
+		chanBuckets: make(map[channelUpdateID]*tokenBucket),
+		peerBuckets: make(map[routing.Vertex]*tokenBucket),
+	}
+}
+
+// rateLimitResult describes the outcome of checking a channel update against
+// the rate limits.
+type rateLimitResult uint8
+
+const (
+	// rateLimitAllowed indicates that the update may be relayed.
+	rateLimitAllowed rateLimitResult = iota
+
+	// rateLimitChannel indicates that the direction of the channel the
+	// update belongs to has exceeded its limit.
+	rateLimitChannel
+
+	// rateLimitPeer indicates that the peer that sent us the update has
+	// exceeded its limit.
+	rateLimitPeer
+)
+
+// allow checks whether the passed channel update, sent to us by the given
+// peer, may be relayed. A token is only consumed from the buckets of the
+// channel and the peer if both of them allow the update.
+func (l *updateRateLimiter) allow(peer routing.Vertex,
+	upd *lnwire.ChannelUpdate) rateLimitResult {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	var chanBucket, peerBucket *tokenBucket
+	if l.limits.MaxChannelUpdateBurst > 0 {
+		chanID := channelUpdateID{
+			channelID: upd.ShortChannelID,
+			flags:     upd.Flags & lnwire.ChanUpdateDirection,
+		}
+
+		chanBucket = l.chanBuckets[chanID]
+		if chanBucket == nil {
+			chanBucket = newTokenBucket(
+				l.limits.MaxChannelUpdateBurst,
+				l.limits.ChannelUpdateInterval, now,
+			)
+			l.chanBuckets[chanID] = chanBucket
+		}
+
+		if !chanBucket.hasToken(now) {
+			return rateLimitChannel
+		}
+	}
+
+	if l.limits.MaxPeerUpdateBurst > 0 {
+		peerBucket = l.peerBuckets[peer]
+		if peerBucket == nil {
+			peerBucket = newTokenBucket(
+				l.limits.MaxPeerUpdateBurst,
+				l.limits.PeerUpdateInterval, now,
+			)
+			l.peerBuckets[peer] = peerBucket
+		}
+
+		if !peerBucket.hasToken(now) {
+			return rateLimitPeer
+		}
+	}
+
+	if chanBucket != nil {
+		chanBucket.take()
+	}
+	if peerBucket != nil {
+		peerBucket.take()
+	}
+
+	return rateLimitAllowed
+}
+
+// prune removes all buckets that have regained all of their tokens, as
+// they're indistinguishable from freshly created ones. This keeps the memory
+// used by the limiter proportional to the set of recently active channels
+// and peers.
+func (l *updateRateLimiter) prune() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for chanID, bucket := range l.chanBuckets {
+		if bucket.isFull(now) {
+			delete(l.chanBuckets, chanID)
+		}
+	}
+	for peer, bucket := range l.peerBuckets {
+		if bucket.isFull(now) {
+			delete(l.peerBuckets, peer)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+)
+
+// TestUpdateRateLimiter tests that the update rate limiter enforces the
+// limits of both the channels and the peers, and that the limits are lifted
+// once enough time has passed.
+func TestUpdateRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chanBurst    = 2
+		chanInterval = time.Minute
+		peerBurst    = 3
+		peerInterval = time.Second
+	)
+
+	limiter := newUpdateRateLimiter(UpdateRateLimits{
+		MaxChannelUpdateBurst: chanBurst,
+		ChannelUpdateInterval: chanInterval,
+		MaxPeerUpdateBurst:    peerBurst,
+		PeerUpdateInterval:    peerInterval,
+	})
+
+	now := time.Unix(1000000, 0)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	peer1 := routing.Vertex{1}
+	peer2 := routing.Vertex{2}
+
+	newUpdate := func(chanID uint64,
+		flags lnwire.ChanUpdateFlag) *lnwire.ChannelUpdate {
+
+		return &lnwire.ChannelUpdate{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(chanID),
+			Flags:          flags,
+		}
+	}
+
+	assertResult := func(peer routing.Vertex, upd *lnwire.ChannelUpdate,
+		expected rateLimitResult) {
+
+		t.Helper()
+
+		if result := limiter.allow(peer, upd); result != expected {
+			t.Fatalf("expected result %v for update of chan_id=%v, "+
+				"instead got %v", expected,
+				upd.ShortChannelID.ToUint64(), result)
+		}
+	}
+
+	// The first direction of the channel should be able to relay a burst
+	// of updates, after which it's limited.
+	for i := 0; i < chanBurst; i++ {
+		assertResult(peer1, newUpdate(1, 0), rateLimitAllowed)
+	}
+	assertResult(peer1, newUpdate(1, 0), rateLimitChannel)
+
+	// The other direction of the channel has a limit of its own. As the
+	// first peer has only a single token left, relaying an update for it
+	// will exhaust the limit of the peer.
+	assertResult(peer1, newUpdate(1, 1), rateLimitAllowed)
+	assertResult(peer1, newUpdate(2, 0), rateLimitPeer)
+
+	// Another peer should still be able to have updates of other channels
+	// relayed, but not of the limited channel.
+	assertResult(peer2, newUpdate(2, 0), rateLimitAllowed)
+	assertResult(peer2, newUpdate(1, 0), rateLimitChannel)
+
+	// Once the peer interval has passed, the first peer should regain a
+	// single token.
+	now = now.Add(peerInterval)
+	assertResult(peer1, newUpdate(3, 0), rateLimitAllowed)
+	assertResult(peer1, newUpdate(4, 0), rateLimitPeer)
+
+	// Once the channel interval has passed, the first direction of the
+	// channel should regain a single token.
+	now = now.Add(chanInterval)
+	assertResult(peer1, newUpdate(1, 0), rateLimitAllowed)
+	assertResult(peer2, newUpdate(1, 0), rateLimitChannel)
+
+	// After a long period of time, all buckets are full again, so they
+	// should all be pruned.
+	now = now.Add(chanInterval * chanBurst)
+	limiter.prune()
+	if len(limiter.chanBuckets) != 0 || len(limiter.peerBuckets) != 0 {
+		t.Fatalf("expected all buckets to be pruned, instead have "+
+			"%v channel and %v peer buckets",
+			len(limiter.chanBuckets), len(limiter.peerBuckets))
+	}
+
+	// A limiter without any limits should allow all updates.
+	limiter = newUpdateRateLimiter(UpdateRateLimits{})
+	for i := 0; i < 100; i++ {
+		assertResult(peer1, newUpdate(1, 0), rateLimitAllowed)
+	}
+}
@@ -0,0 +1,768 @@
+package discovery
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// mockChannelGraphTimeSeries is a mock implementation of the
+// ChannelGraphTimeSeries interface, backed by a static set of channels.
+type mockChannelGraphTimeSeries struct {
+	// chanIDs is the sorted set of channels known to the time series.
+	chanIDs []lnwire.ShortChannelID
+
+	// horizonMsgs is the set of messages returned for any update horizon.
+	horizonMsgs []lnwire.Message
+
+	// chanUpdates are the known channel updates of each channel.
+	chanUpdates map[lnwire.ShortChannelID][]*lnwire.ChannelUpdate
+}
+
+// newMockChannelGraphTimeSeries creates a new mock time series that knows of
+// the passed channels.
+func newMockChannelGraphTimeSeries(
+	chanIDs ...lnwire.ShortChannelID) *mockChannelGraphTimeSeries {
+
+	sort.Slice(chanIDs, func(i, j int) bool {
+		return chanIDs[i].ToUint64() < chanIDs[j].ToUint64()
+	})
+
+	return &mockChannelGraphTimeSeries{
+		chanIDs: chanIDs,
+		chanUpdates: make(
+			map[lnwire.ShortChannelID][]*lnwire.ChannelUpdate,
+		),
+	}
+}
+
+func (m *mockChannelGraphTimeSeries) HighestChanID(
+	chain chainhash.Hash) (*lnwire.ShortChannelID, error) {
+
+	var highestChanID lnwire.ShortChannelID
+	if len(m.chanIDs) != 0 {
+		highestChanID = m.chanIDs[len(m.chanIDs)-1]
+	}
+
+	return &highestChanID, nil
+}
+
+func (m *mockChannelGraphTimeSeries) UpdatesInHorizon(chain chainhash.Hash,
+	startTime time.Time, endTime time.Time) ([]lnwire.Message, error) {
+
+	return m.horizonMsgs, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterKnownChanIDs(chain chainhash.Hash,
+	superSet []lnwire.ShortChannelID) ([]lnwire.ShortChannelID, error) {
+
+	known := make(map[lnwire.ShortChannelID]struct{})
+	for _, chanID := range m.chanIDs {
+		known[chanID] = struct{}{}
+	}
+
+	var newChanIDs []lnwire.ShortChannelID
+	for _, chanID := range superSet {
+		if _, ok := known[chanID]; !ok {
+			newChanIDs = append(newChanIDs, chanID)
+		}
+	}
+
+	return newChanIDs, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FilterChannelRange(chain chainhash.Hash,
+	startHeight, endHeight uint32) ([]lnwire.ShortChannelID, error) {
+
+	var chansInRange []lnwire.ShortChannelID
+	for _, chanID := range m.chanIDs {
+		if chanID.BlockHeight < startHeight ||
+			chanID.BlockHeight > endHeight {
+
+			continue
+		}
+
+		chansInRange = append(chansInRange, chanID)
+	}
+
+	return chansInRange, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanAnns(chain chainhash.Hash,
+	shortChanIDs []lnwire.ShortChannelID) ([]lnwire.Message, error) {
+
+	var chanAnns []lnwire.Message
+	for _, chanID := range shortChanIDs {
+		chanAnns = append(chanAnns, &lnwire.ChannelAnnouncement{
+			ShortChannelID: chanID,
+		})
+	}
+
+	return chanAnns, nil
+}
+
+func (m *mockChannelGraphTimeSeries) FetchChanUpdates(chain chainhash.Hash,
+	shortChanID lnwire.ShortChannelID) ([]*lnwire.ChannelUpdate, error) {
+
+	return m.chanUpdates[shortChanID], nil
+}
+
+var _ ChannelGraphTimeSeries = (*mockChannelGraphTimeSeries)(nil)
+
+// newTestSyncer creates a new gossipSyncer backed by the passed time series.
+// All messages the syncer sends to the peer are delivered over the returned
+// channel.
+func newTestSyncer(chanSeries ChannelGraphTimeSeries,
+	chunkSize int32) (chan []lnwire.Message, *gossipSyncer) {
+
+	msgChan := make(chan []lnwire.Message, 20)
+	syncer := newGossiperSyncer(gossipSyncerCfg{
+		chainHash:       *chaincfg.MainNetParams.GenesisHash,
+		syncChanUpdates: true,
+		channelSeries:   chanSeries,
+		encodingType:    lnwire.EncodingSortedPlain,
+		chunkSize:       chunkSize,
+		sendToPeer: func(msgs ...lnwire.Message) error {
+			msgChan <- msgs
+			return nil
+		},
+	})
+
+	return msgChan, syncer
+}
+
+// assertNoMsgSent asserts that the syncer doesn't send any messages to the
+// peer.
+func assertNoMsgSent(t *testing.T, msgChan chan []lnwire.Message) {
+	select {
+	case msgs := <-msgChan:
+		t.Fatalf("expected no msgs to be sent, instead got: %v",
+			spew.Sdump(msgs))
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+// receiveMsgs waits for the syncer to send the next set of messages to the
+// peer.
+func receiveMsgs(t *testing.T, msgChan chan []lnwire.Message) []lnwire.Message {
+	select {
+	case msgs := <-msgChan:
+		return msgs
+	case <-time.After(time.Second * 5):
+		t.Fatalf("no msgs received")
+	}
+
+	return nil
+}
+
+// TestGossipSyncerFilterGossipMsgsNoHorizon tests that if the remote peer
+// doesn't have a horizon set, then we won't send any incoming messages to it.
+func TestGossipSyncerFilterGossipMsgsNoHorizon(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer := newTestSyncer(
+		newMockChannelGraphTimeSeries(), 1000,
+	)
+
+	// With the syncer created, we'll create a set of messages to filter
+	// through the gossiper to the target peer.
+	msgs := []msgWithSenders{
+		{
+			msg: &lnwire.NodeAnnouncement{
+				Timestamp: uint32(time.Now().Unix()),
+			},
+		},
+		{
+			msg: &lnwire.NodeAnnouncement{
+				Timestamp: uint32(time.Now().Unix()),
+			},
+		},
+	}
+
+	// We'll then attempt to filter the set of messages through the target
+	// peer.
+	syncer.FilterGossipMsgs(msgs...)
+
+	// As the remote peer doesn't yet have a gossip timestamp set, we
+	// shouldn't receive any outbound messages.
+	assertNoMsgSent(t, msgChan)
+}
+
+// TestGossipSyncerFilterGossipMsgsAllInMemory tests that we only send the
+// messages to the remote peer that pass its update horizon. Channel
+// announcements are only sent if one of their channel updates, either within
+// the batch or within the time series, passes the horizon.
+func TestGossipSyncerFilterGossipMsgsAllInMemory(t *testing.T) {
+	t.Parallel()
+
+	chanSeries := newMockChannelGraphTimeSeries()
+	msgChan, syncer := newTestSyncer(chanSeries, 1000)
+
+	// We'll create then apply a remote horizon for the target peer with a
+	// set of manually selected timestamps.
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		ChainHash:      syncer.cfg.chainHash,
+		FirstTimestamp: unixStamp(25000),
+		TimestampRange: uint32(1000),
+	}
+	syncer.remoteUpdateHorizon = remoteHorizon
+
+	// The first channel is announced along with an update within the
+	// horizon, the second one with an update outside of it. The updates
+	// of the last two channels aren't within the batch, so they'll be
+	// looked up in the time series.
+	chanID1 := lnwire.NewShortChanIDFromInt(10)
+	chanID2 := lnwire.NewShortChanIDFromInt(15)
+	chanID3 := lnwire.NewShortChanIDFromInt(20)
+	chanID4 := lnwire.NewShortChanIDFromInt(25)
+	chanSeries.chanUpdates[chanID3] = []*lnwire.ChannelUpdate{
+		{
+			ShortChannelID: chanID3,
+			Timestamp:      unixStamp(25002),
+		},
+	}
+	chanSeries.chanUpdates[chanID4] = []*lnwire.ChannelUpdate{
+		{
+			ShortChannelID: chanID4,
+			Timestamp:      unixStamp(25001 + 1000),
+		},
+	}
+
+	msgs := []msgWithSenders{
+		{
+			// This announcement should pass through as its update
+			// below is within the horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: chanID1,
+			},
+		},
+		{
+			// This announcement shouldn't pass through, as its
+			// update is beyond the horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: chanID2,
+			},
+		},
+		{
+			// This announcement should pass through, as its
+			// update within the time series is within the
+			// horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: chanID3,
+			},
+		},
+		{
+			// This announcement shouldn't pass through, as its
+			// update within the time series is beyond the
+			// horizon.
+			msg: &lnwire.ChannelAnnouncement{
+				ShortChannelID: chanID4,
+			},
+		},
+		{
+			msg: &lnwire.ChannelUpdate{
+				ShortChannelID: chanID1,
+				Timestamp:      unixStamp(25002),
+			},
+		},
+		{
+			msg: &lnwire.ChannelUpdate{
+				ShortChannelID: chanID2,
+				Timestamp:      unixStamp(24999),
+			},
+		},
+		{
+			// This node announcement is within the horizon, but
+			// was sent to us by the remote peer itself, so it
+			// shouldn't be sent back.
+			msg: &lnwire.NodeAnnouncement{
+				Timestamp: unixStamp(25001),
+			},
+			senders: map[routing.Vertex]struct{}{
+				syncer.cfg.peerPub: {},
+			},
+		},
+		{
+			msg: &lnwire.NodeAnnouncement{
+				Timestamp: unixStamp(25003),
+			},
+		},
+		{
+			msg: &lnwire.NodeAnnouncement{
+				Timestamp: unixStamp(26002),
+			},
+		},
+	}
+
+	syncer.FilterGossipMsgs(msgs...)
+
+	// Only the first and third channel announcements, the first channel
+	// update and the second node announcement should have been sent.
+	expectedMsgs := []lnwire.Message{
+		msgs[0].msg, msgs[2].msg, msgs[4].msg, msgs[7].msg,
+	}
+	sentMsgs := receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, expectedMsgs) {
+		t.Fatalf("expected msgs %v, instead got %v",
+			spew.Sdump(expectedMsgs), spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerApplyGossipFilter tests that once a gossip filter is applied
+// for the remote peer, then we send the peer all known updates that fall
+// within the horizon.
+func TestGossipSyncerApplyGossipFilter(t *testing.T) {
+	t.Parallel()
+
+	chanSeries := newMockChannelGraphTimeSeries()
+	msgChan, syncer := newTestSyncer(chanSeries, 1000)
+
+	remoteHorizon := &lnwire.GossipTimestampRange{
+		ChainHash:      syncer.cfg.chainHash,
+		FirstTimestamp: unixStamp(25000),
+		TimestampRange: uint32(1000),
+	}
+
+	// If the time series doesn't have any updates within the horizon, then
+	// we shouldn't send any messages to the peer.
+	if err := syncer.ApplyGossipFilter(remoteHorizon); err != nil {
+		t.Fatalf("unable to apply filter: %v", err)
+	}
+	assertNoMsgSent(t, msgChan)
+
+	if syncer.remoteUpdateHorizon != remoteHorizon {
+		t.Fatalf("expected remote horizon: %v, got: %v",
+			remoteHorizon, syncer.remoteUpdateHorizon)
+	}
+
+	// Otherwise, the backlog of updates should be sent once the filter is
+	// applied.
+	chanSeries.horizonMsgs = []lnwire.Message{
+		&lnwire.ChannelUpdate{
+			ShortChannelID: lnwire.NewShortChanIDFromInt(25),
+			Timestamp:      unixStamp(25002),
+		},
+	}
+	if err := syncer.ApplyGossipFilter(remoteHorizon); err != nil {
+		t.Fatalf("unable to apply filter: %v", err)
+	}
+
+	sentMsgs := receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, chanSeries.horizonMsgs) {
+		t.Fatalf("expected msgs %v, instead got %v",
+			spew.Sdump(chanSeries.horizonMsgs),
+			spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerReplyChanRangeQuery tests that our replies to a channel
+// range query are properly chunked, and that only the final chunk is marked
+// as complete.
+func TestGossipSyncerReplyChanRangeQuery(t *testing.T) {
+	t.Parallel()
+
+	// We'll use a chunk size of 2, and a set of five channels, one of
+	// which lies outside of the queried range.
+	const chunkSize = 2
+	chanSeries := newMockChannelGraphTimeSeries(
+		lnwire.ShortChannelID{BlockHeight: 1},
+		lnwire.ShortChannelID{BlockHeight: 2},
+		lnwire.ShortChannelID{BlockHeight: 3},
+		lnwire.ShortChannelID{BlockHeight: 4},
+		lnwire.ShortChannelID{BlockHeight: 50},
+	)
+	msgChan, syncer := newTestSyncer(chanSeries, chunkSize)
+
+	query := &lnwire.QueryChannelRange{
+		ChainHash:        syncer.cfg.chainHash,
+		FirstBlockHeight: 0,
+		NumBlocks:        10,
+	}
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to reply to range query: %v", err)
+	}
+
+	// We should receive two chunks, each containing two channels, with
+	// only the last one being marked as complete.
+	var replyChanIDs []lnwire.ShortChannelID
+	for i := 0; i < 2; i++ {
+		msgs := receiveMsgs(t, msgChan)
+		if len(msgs) != 1 {
+			t.Fatalf("expected a single reply, got %v", len(msgs))
+		}
+
+		reply, ok := msgs[0].(*lnwire.ReplyChannelRange)
+		if !ok {
+			t.Fatalf("expected ReplyChannelRange, got %T", msgs[0])
+		}
+		if reply.QueryChannelRange != *query {
+			t.Fatalf("reply doesn't echo query: %v",
+				spew.Sdump(reply))
+		}
+		if len(reply.ShortChanIDs) != chunkSize {
+			t.Fatalf("expected chunk of size %v, got %v",
+				chunkSize, len(reply.ShortChanIDs))
+		}
+
+		expectedComplete := uint8(0)
+		if i == 1 {
+			expectedComplete = 1
+		}
+		if reply.Complete != expectedComplete {
+			t.Fatalf("expected complete=%v for chunk #%v, got %v",
+				expectedComplete, i, reply.Complete)
+		}
+
+		replyChanIDs = append(replyChanIDs, reply.ShortChanIDs...)
+	}
+	assertNoMsgSent(t, msgChan)
+
+	if !reflect.DeepEqual(replyChanIDs, chanSeries.chanIDs[:4]) {
+		t.Fatalf("expected chan IDs %v, got %v",
+			chanSeries.chanIDs[:4], replyChanIDs)
+	}
+
+	// A query for a chain that we don't know of should be answered with a
+	// single empty reply that isn't marked as complete.
+	query.ChainHash = *chaincfg.TestNet3Params.GenesisHash
+	if err := syncer.replyChanRangeQuery(query); err != nil {
+		t.Fatalf("unable to reply to range query: %v", err)
+	}
+
+	msgs := receiveMsgs(t, msgChan)
+	reply, ok := msgs[0].(*lnwire.ReplyChannelRange)
+	if !ok {
+		t.Fatalf("expected ReplyChannelRange, got %T", msgs[0])
+	}
+	if reply.Complete != 0 || len(reply.ShortChanIDs) != 0 {
+		t.Fatalf("expected empty incomplete reply, got %v",
+			spew.Sdump(reply))
+	}
+}
+
+// TestGossipSyncerReplyShortChanIDs tests that our reply to a short channel ID
+// query contains the announcements of the queried channels, followed by a
+// final ReplyShortChanIDsEnd message.
+func TestGossipSyncerReplyShortChanIDs(t *testing.T) {
+	t.Parallel()
+
+	msgChan, syncer := newTestSyncer(newMockChannelGraphTimeSeries(), 1000)
+
+	queryChanIDs := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(2),
+	}
+	err := syncer.replyShortChanIDs(&lnwire.QueryShortChanIDs{
+		ChainHash:    syncer.cfg.chainHash,
+		EncodingType: lnwire.EncodingSortedPlain,
+		ShortChanIDs: queryChanIDs,
+	})
+	if err != nil {
+		t.Fatalf("unable to reply to query: %v", err)
+	}
+
+	expectedMsgs := []lnwire.Message{
+		&lnwire.ChannelAnnouncement{ShortChannelID: queryChanIDs[0]},
+		&lnwire.ChannelAnnouncement{ShortChannelID: queryChanIDs[1]},
+		&lnwire.ReplyShortChanIDsEnd{
+			ChainHash: syncer.cfg.chainHash,
+			Complete:  1,
+		},
+	}
+	sentMsgs := receiveMsgs(t, msgChan)
+	if !reflect.DeepEqual(sentMsgs, expectedMsgs) {
+		t.Fatalf("expected msgs %v, instead got %v",
+			spew.Sdump(expectedMsgs), spew.Sdump(sentMsgs))
+	}
+}
+
+// TestGossipSyncerSynchronizeChanIDs tests that the set of new channels to
+// query for is chunked properly, and that we signal once all of them have
+// been queried for.
+func TestGossipSyncerSynchronizeChanIDs(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 2
+	msgChan, syncer := newTestSyncer(
+		newMockChannelGraphTimeSeries(), chunkSize,
+	)
+
+	newChans := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(2),
+		lnwire.NewShortChanIDFromInt(3),
+	}
+	syncer.newChansToQuery = newChans
+
+	for i := 0; i < 2; i++ {
+		done, err := syncer.synchronizeChanIDs()
+		if err != nil {
+			t.Fatalf("unable to sync chan IDs: %v", err)
+		}
+		if done {
+			t.Fatalf("syncer shouldn't be done yet")
+		}
+
+		// Each query should contain the next chunk of channels.
+		chunkStart := i * chunkSize
+		chunkEnd := chunkStart + chunkSize
+		if chunkEnd > len(newChans) {
+			chunkEnd = len(newChans)
+		}
+
+		msgs := receiveMsgs(t, msgChan)
+		query, ok := msgs[0].(*lnwire.QueryShortChanIDs)
+		if !ok {
+			t.Fatalf("expected QueryShortChanIDs, got %T", msgs[0])
+		}
+		if !reflect.DeepEqual(
+			query.ShortChanIDs, newChans[chunkStart:chunkEnd],
+		) {
+			t.Fatalf("expected chan IDs %v, got %v",
+				newChans[chunkStart:chunkEnd],
+				query.ShortChanIDs)
+		}
+	}
+
+	// With all channels queried for, the syncer should now be done.
+	done, err := syncer.synchronizeChanIDs()
+	if err != nil {
+		t.Fatalf("unable to sync chan IDs: %v", err)
+	}
+	if !done {
+		t.Fatalf("syncer should be done")
+	}
+	assertNoMsgSent(t, msgChan)
+}
+
+// TestGossipSyncerProcessChanRangeReply tests that the chunked replies to our
+// range query are buffered until the final one arrives, after which we only
+// query for the channels we don't yet know of.
+func TestGossipSyncerProcessChanRangeReply(t *testing.T) {
+	t.Parallel()
+
+	chanSeries := newMockChannelGraphTimeSeries(
+		lnwire.NewShortChanIDFromInt(1),
+		lnwire.NewShortChanIDFromInt(3),
+	)
+	_, syncer := newTestSyncer(chanSeries, 1000)
+	syncer.setSyncState(waitingQueryRangeReply)
+
+	replies := []*lnwire.ReplyChannelRange{
+		{
+			ShortChanIDs: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(1),
+				lnwire.NewShortChanIDFromInt(2),
+			},
+		},
+		{
+			Complete: 1,
+			ShortChanIDs: []lnwire.ShortChannelID{
+				lnwire.NewShortChanIDFromInt(3),
+				lnwire.NewShortChanIDFromInt(4),
+			},
+		},
+	}
+
+	// The first reply isn't complete, so we should remain in the same
+	// state.
+	if err := syncer.processChanRangeReply(replies[0]); err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.syncState() != waitingQueryRangeReply {
+		t.Fatalf("expected state %v, got %v", waitingQueryRangeReply,
+			syncer.syncState())
+	}
+
+	// Once the final reply arrives, we should transition to querying for
+	// the two channels we don't know of.
+	if err := syncer.processChanRangeReply(replies[1]); err != nil {
+		t.Fatalf("unable to process reply: %v", err)
+	}
+	if syncer.syncState() != queryNewChannels {
+		t.Fatalf("expected state %v, got %v", queryNewChannels,
+			syncer.syncState())
+	}
+
+	expectedChans := []lnwire.ShortChannelID{
+		lnwire.NewShortChanIDFromInt(2),
+		lnwire.NewShortChanIDFromInt(4),
+	}
+	if !reflect.DeepEqual(syncer.newChansToQuery, expectedChans) {
+		t.Fatalf("expected chans to query %v, got %v", expectedChans,
+			syncer.newChansToQuery)
+	}
+	if len(syncer.bufferedChanRangeReplies) != 0 {
+		t.Fatalf("expected buffered replies to be cleared")
+	}
+}
+
+// TestGossipSyncerSynchronization tests that two gossip syncers connected to
+// each other reconcile their channel graphs, such that each side only
+// receives the announcements of the channels it doesn't yet know of.
+func TestGossipSyncerSynchronization(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 2
+	chanID := func(height uint32) lnwire.ShortChannelID {
+		return lnwire.ShortChannelID{BlockHeight: height}
+	}
+
+	// Alice knows of the first three channels, while Bob knows of all
+	// five of them.
+	aliceSeries := newMockChannelGraphTimeSeries(
+		chanID(1), chanID(2), chanID(3),
+	)
+	bobSeries := newMockChannelGraphTimeSeries(
+		chanID(1), chanID(2), chanID(3), chanID(4), chanID(5),
+	)
+	aliceMsgs, alice := newTestSyncer(aliceSeries, chunkSize)
+	bobMsgs, bob := newTestSyncer(bobSeries, chunkSize)
+
+	// The announcements received by either side are delivered over these
+	// channels.
+	aliceAnns := make(chan lnwire.Message, 100)
+	bobAnns := make(chan lnwire.Message, 100)
+
+	// deliver forwards the messages sent by one syncer to the other one.
+	// Gossip queries are handled by the syncer itself, while all other
+	// messages are handed to the gossiper in the real system.
+	deliver := func(msgChan chan []lnwire.Message, target *gossipSyncer,
+		anns chan lnwire.Message) {
+
+		for {
+			select {
+			case msgs := <-msgChan:
+				for _, msg := range msgs {
+					switch msg.(type) {
+					case *lnwire.QueryShortChanIDs,
+						*lnwire.ReplyShortChanIDsEnd,
+						*lnwire.QueryChannelRange,
+						*lnwire.ReplyChannelRange,
+						*lnwire.GossipTimestampRange:
+
+						target.ProcessQueryMsg(msg)
+
+					default:
+						anns <- msg
+					}
+				}
+
+			case <-target.quit:
+				return
+			}
+		}
+	}
+	go deliver(aliceMsgs, bob, bobAnns)
+	go deliver(bobMsgs, alice, aliceAnns)
+
+	if err := alice.Start(); err != nil {
+		t.Fatalf("unable to start syncer: %v", err)
+	}
+	defer alice.Stop()
+	if err := bob.Start(); err != nil {
+		t.Fatalf("unable to start syncer: %v", err)
+	}
+	defer bob.Stop()
+
+	// Both syncers should eventually reach their terminal state.
+	err := waitFor(func() error {
+		for _, syncer := range []*gossipSyncer{alice, bob} {
+			if syncer.syncState() != chansSynced {
+				return fmt.Errorf("expected state %v, got %v",
+					chansSynced, syncer.syncState())
+			}
+		}
+		return nil
+	}, time.Second*5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Alice should have only received the announcements of the two
+	// channels she didn't know of.
+	for _, expectedID := range []lnwire.ShortChannelID{chanID(4), chanID(5)} {
+		select {
+		case msg := <-aliceAnns:
+			chanAnn, ok := msg.(*lnwire.ChannelAnnouncement)
+			if !ok {
+				t.Fatalf("expected ChannelAnnouncement, got %T",
+					msg)
+			}
+			if chanAnn.ShortChannelID != expectedID {
+				t.Fatalf("expected chan ID %v, got %v",
+					expectedID, chanAnn.ShortChannelID)
+			}
+
+		case <-time.After(time.Second * 5):
+			t.Fatalf("announcement for %v not received", expectedID)
+		}
+	}
+
+	// As Bob already knows of all of Alice's channels, he shouldn't
+	// receive any announcements at all.
+	select {
+	case msg := <-bobAnns:
+		t.Fatalf("unexpected message received by bob: %v",
+			spew.Sdump(msg))
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// Finally, both sides should have sent their update horizons, which
+	// span to the end of time.
+	err = waitFor(func() error {
+		for _, syncer := range []*gossipSyncer{alice, bob} {
+			syncer.Lock()
+			horizon := syncer.remoteUpdateHorizon
+			syncer.Unlock()
+
+			if horizon == nil {
+				return fmt.Errorf("remote update horizon " +
+					"not set")
+			}
+			if horizon.TimestampRange != math.MaxUint32 {
+				return fmt.Errorf("expected unbounded "+
+					"horizon, got %v",
+					horizon.TimestampRange)
+			}
+		}
+		return nil
+	}, time.Second*5)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls the passed predicate until it returns nil, or the timeout
+// expires, in which case the last error of the predicate is returned.
+func waitFor(pred func() error, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		err := pred()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-deadline:
+			return err
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+}
+
+// unixStamp returns the passed unix timestamp as a uint32, as used within
+// the gossip messages.
+func unixStamp(a int64) uint32 {
+	t := time.Unix(a, 0)
+	return uint32(t.Unix())
+}
@@ -102,6 +102,26 @@ func createChanAnnouncement(chanProof *channeldb.ChannelAuthProof,
 	return chanAnn, edge1Ann, edge2Ann, nil
 }
 
+// makeNodeAnn re-creates the authenticated node announcement of the passed
+// node from its database representation.
+func makeNodeAnn(n *channeldb.LightningNode) (*lnwire.NodeAnnouncement, error) {
+	alias, _ := lnwire.NewNodeAlias(n.Alias)
+
+	wireSig, err := lnwire.NewSigFromRawSignature(n.AuthSigBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &lnwire.NodeAnnouncement{
+		Signature: wireSig,
+		Timestamp: uint32(n.LastUpdate.Unix()),
+		Addresses: n.Addresses,
+		NodeID:    n.PubKeyBytes,
+		Features:  n.Features.RawFeatureVector,
+		RGBColor:  n.Color,
+		Alias:     alias,
+	}, nil
+}
+
 // copyPubKey performs a copy of the target public key, setting a fresh curve
 // parameter during the process.
 func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
@@ -17,6 +17,57 @@ type InvoiceDatabase interface {
 	// SettleInvoice attempts to mark an invoice corresponding to the
 	// passed payment hash as fully settled.
 	SettleInvoice(chainhash.Hash) error
+
+	// AddKeysendInvoice adds an invoice paying the passed amount to the
+	// hash of the passed preimage, which the sender of a keysend payment
+	// carried to us within the onion. An error is returned if keysend
+	// payments aren't accepted.
+	AddKeysendInvoice([32]byte, lnwire.MilliSatoshi) error
+
+	// AcceptInvoice marks the hold invoice corresponding to the passed
+	// payment hash as accepted, as an HTLC paying to it is now being held.
+	// Once the invoice is either settled or canceled, a HodlEvent is
+	// delivered over the passed subscriber channel.
+	AcceptInvoice(chainhash.Hash, chan<- HodlEvent) error
+
+	// AddPartialHTLC adds an HTLC that pays only part of the invoice
+	// corresponding to the passed payment hash to the set of partial HTLCs
+	// that is held for it. The HTLC is identified by its circuit key. Once
+	// the set covers the invoice, the invoice is paid as if the set were a
+	// single HTLC. If the set isn't complete in time, then a HodlEvent
+	// signaling the timeout is delivered over the passed subscriber
+	// channel.
+	AddPartialHTLC(chainhash.Hash, channeldb.CircuitKey,
+		lnwire.MilliSatoshi, chan<- HodlEvent) error
+
+	// FailPartialHTLCs fails back the set of partial HTLCs that is held
+	// for the invoice corresponding to the passed payment hash, leaving
+	// the invoice itself open.
+	FailPartialHTLCs(chainhash.Hash) error
+
+	// CancelInvoice attempts to cancel the invoice corresponding to the
+	// passed payment hash, failing back any HTLCs that are held for it.
+	CancelInvoice(chainhash.Hash) error
+
+	// HodlUnsubscribeAll removes all HodlEvent subscriptions of the passed
+	// subscriber channel.
+	HodlUnsubscribeAll(chan<- HodlEvent)
+}
+
+// HodlEvent describes how an HTLC paying to a hold invoice, or paying only
+// part of an invoice, that is being held by the link should be resolved.
+type HodlEvent struct {
+	// Hash is the payment hash of the invoice.
+	Hash chainhash.Hash
+
+	// Preimage is the preimage that settles the HTLC. If nil, the invoice
+	// has been canceled and the HTLC is to be failed back.
+	Preimage *[32]byte
+
+	// MPPTimeout is set if the HTLC is to be failed back because the set
+	// of partial HTLCs it belongs to didn't cover the invoice in time. The
+	// invoice itself remains open in this case.
+	MPPTimeout bool
 }
 
 // ChannelLink is an interface which represents the subsystem for managing the
@@ -1,11 +1,15 @@
 package htlcswitch
 
 import (
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
 
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/lnwire/tlv"
 	"github.com/roasbeef/btcd/btcec"
 )
 
@@ -45,8 +49,77 @@ var (
 	// sourceHop is a sentinel value denoting that an incoming HTLC is
 	// initiated by our own switch.
 	sourceHop lnwire.ShortChannelID
+
+	// KeysendNextHop is a sentinel value that the sender of a keysend
+	// payment places within the hop payload of the final hop, in place of
+	// the next channel. It signals that the onion carries additional
+	// frames, addressed to the final hop itself, which hold the preimage
+	// chosen by the sender.
+	KeysendNextHop = lnwire.NewShortChanIDFromInt(math.MaxUint64)
+
+	// TLVPayloadNextHop is a sentinel value that the sender places within
+	// the next address of the first frame of a TLV encoded hop payload.
+	// As the sphinx package doesn't preserve the realm of the frames it
+	// processes, it's used to tell TLV payloads apart from legacy ones.
+	// The remainder of the first frame holds the start of the payload.
+	TLVPayloadNextHop = lnwire.NewShortChanIDFromInt(math.MaxUint64 - 1)
+)
+
+const (
+	// TLVPayloadRealm is the realm of the onion frames carrying a TLV
+	// encoded hop payload. Legacy hop payloads use realm 0.
+	TLVPayloadRealm = 0x01
+
+	// FrameDataSize is the number of bytes of a hop payload that a single
+	// onion frame carries. The sphinx package discards the padding of the
+	// frames it processes, so only the space of the next address, amount
+	// and CLTV fields can be used.
+	FrameDataSize = 8 + 8 + 4
+
+	// AmtOnionType is the TLV type of the record holding the amount to
+	// forward to the next hop, or to receive if we're the final hop.
+	AmtOnionType tlv.Type = 2
+
+	// LockTimeOnionType is the TLV type of the record holding the CLTV
+	// expiry of the outgoing HTLC.
+	LockTimeOnionType tlv.Type = 4
+
+	// NextHopOnionType is the TLV type of the record holding the short
+	// channel ID of the next hop. It's omitted for the final hop.
+	NextHopOnionType tlv.Type = 6
+
+	// MPPOnionType is the TLV type of the record holding the total amount
+	// of a multi-path payment, which is only included for the final hop.
+	// Its presence signals that the HTLC is one of the partial HTLCs of
+	// such a payment.
+	MPPOnionType tlv.Type = 8
+
+	// KeysendOnionType is the TLV type of the record holding the preimage
+	// of a keysend payment, which is only included for the final hop.
+	KeysendOnionType tlv.Type = 5482373484
 )
 
+// PayloadError is returned when a TLV encoded hop payload can't be parsed, or
+// is missing a record we require. Type and Offset identify the offending
+// record, and are reported back to the sender.
+type PayloadError struct {
+	// Type is the TLV type of the offending record.
+	Type tlv.Type
+
+	// Offset is the byte offset of the offending record within the
+	// payload.
+	Offset uint16
+
+	// Reason describes why the payload was rejected.
+	Reason string
+}
+
+// Error returns a human readable description of the payload error.
+func (e *PayloadError) Error() string {
+	return fmt.Sprintf("invalid hop payload: %v (type=%v, offset=%v)",
+		e.Reason, e.Type, e.Offset)
+}
+
 // ForwardingInfo contains all the information that is necessary to forward and
 // incoming HTLC to the next hop encoded within a valid HopIterator instance.
 // Forwarding links are to use this information to authenticate the information
@@ -70,6 +143,17 @@ type ForwardingInfo struct {
 	// in the outgoing HTLC.
 	OutgoingCTLV uint32
 
+	// KeysendPreimage is the preimage of a keysend payment, which the
+	// sender carried to us within the onion. It's only set if we're the
+	// final hop of such a payment, and allows us to settle the HTLC
+	// without having created an invoice for it beforehand.
+	KeysendPreimage *[32]byte
+
+	// MPPTotalAmount is the total amount of the multi-path payment that
+	// the HTLC is one of the partial HTLCs of. It's only set if we're the
+	// final hop of such a payment, and is zero otherwise.
+	MPPTotalAmount lnwire.MilliSatoshi
+
 	// TODO(roasbeef): modify sphinx logic to not just discard the
 	// remaining bytes, instead should include the rest as excess
 }
@@ -84,8 +168,9 @@ type HopIterator interface {
 	// _how_ this hop should forward the HTLC to the next hop.
 	// Additionally, the information encoded within the returned
 	// ForwardingInfo is to be used by each hop to authenticate the
-	// information given to it by the prior hop.
-	ForwardingInstructions() ForwardingInfo
+	// information given to it by the prior hop. An error is returned if
+	// the hop payload is invalid, in which case the HTLC must be failed.
+	ForwardingInstructions() (ForwardingInfo, error)
 
 	// EncodeNextHop encodes the onion packet destined for the next hop
 	// into the passed io.Writer.
@@ -107,8 +192,21 @@ type sphinxHopIterator struct {
 
 	// processedPacket is the outcome of processing an onion packet. It
 	// includes the information required to properly forward the packet to
-	// the next hop.
+	// the next hop. If the hop payload spans multiple frames, then this is
+	// the outcome of processing the last of them.
 	processedPacket *sphinx.ProcessedPacket
+
+	// tlvPayload is the TLV stream of the hop payload, if the sender
+	// encoded it as such. It's nil for legacy hop payloads.
+	tlvPayload []byte
+
+	// payloadErr is set if the frames of the hop payload couldn't be
+	// processed.
+	payloadErr error
+
+	// keysendPreimage is the preimage carried within the additional onion
+	// frames of a legacy keysend payment, if we're its final hop.
+	keysendPreimage *[32]byte
 }
 
 // makeSphinxHopIterator converts a processed packet returned from a sphinx
@@ -139,7 +237,16 @@ func (r *sphinxHopIterator) EncodeNextHop(w io.Writer) error {
 // hop to authenticate the information given to it by the prior hop.
 //
 // NOTE: Part of the HopIterator interface.
-func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
+func (r *sphinxHopIterator) ForwardingInstructions() (ForwardingInfo, error) {
+	if r.payloadErr != nil {
+		return ForwardingInfo{}, r.payloadErr
+	}
+
+	isExit := r.processedPacket.Action == sphinx.ExitNode
+	if r.tlvPayload != nil {
+		return decodeTLVPayload(r.tlvPayload, isExit)
+	}
+
 	fwdInst := r.processedPacket.ForwardingInstructions
 
 	var nextHop lnwire.ShortChannelID
@@ -151,12 +258,192 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 		nextHop = lnwire.NewShortChanIDFromInt(s)
 	}
 
+	// The additional frames of a keysend payment are addressed to us, so
+	// we're the final hop of the payment.
+	if r.keysendPreimage != nil {
+		nextHop = exitHop
+	}
+
 	return ForwardingInfo{
 		Network:         BitcoinHop,
 		NextHop:         nextHop,
 		AmountToForward: lnwire.MilliSatoshi(fwdInst.ForwardAmount),
 		OutgoingCTLV:    fwdInst.OutgoingCltv,
+		KeysendPreimage: r.keysendPreimage,
+	}, nil
+}
+
+// decodeTLVPayload parses the forwarding instructions from a TLV encoded hop
+// payload. The amount and CLTV expiry records are always required, while the
+// next hop is only required if we aren't the final hop.
+func decodeTLVPayload(payload []byte, isExit bool) (ForwardingInfo, error) {
+	records, err := tlv.Decode(bytes.NewReader(payload))
+	if err != nil {
+		return ForwardingInfo{}, &PayloadError{Reason: err.Error()}
+	}
+
+	fwdInfo := ForwardingInfo{
+		Network: BitcoinHop,
+		NextHop: exitHop,
+	}
+
+	var (
+		offset                     int
+		haveAmt, haveLock, haveHop bool
+	)
+	for i := range records {
+		record := &records[i]
+
+		payloadErr := func(reason string) error {
+			return &PayloadError{
+				Type:   record.Type,
+				Offset: uint16(offset),
+				Reason: reason,
+			}
+		}
+
+		switch record.Type {
+		case AmtOnionType:
+			amt, err := record.TUint64()
+			if err != nil {
+				return ForwardingInfo{}, payloadErr(err.Error())
+			}
+			fwdInfo.AmountToForward = lnwire.MilliSatoshi(amt)
+			haveAmt = true
+
+		case LockTimeOnionType:
+			lockTime, err := record.TUint32()
+			if err != nil {
+				return ForwardingInfo{}, payloadErr(err.Error())
+			}
+			fwdInfo.OutgoingCTLV = lockTime
+			haveLock = true
+
+		case NextHopOnionType:
+			scid, err := record.Uint64()
+			if err != nil {
+				return ForwardingInfo{}, payloadErr(err.Error())
+			}
+			if isExit {
+				return ForwardingInfo{}, payloadErr(
+					"next hop set for final hop",
+				)
+			}
+			fwdInfo.NextHop = lnwire.NewShortChanIDFromInt(scid)
+			haveHop = true
+
+		case KeysendOnionType:
+			preimage, err := record.Bytes32()
+			if err != nil {
+				return ForwardingInfo{}, payloadErr(err.Error())
+			}
+			if !isExit {
+				return ForwardingInfo{}, payloadErr(
+					"keysend preimage set for " +
+						"intermediate hop",
+				)
+			}
+			fwdInfo.KeysendPreimage = &preimage
+
+		case MPPOnionType:
+			total, err := record.TUint64()
+			if err != nil {
+				return ForwardingInfo{}, payloadErr(err.Error())
+			}
+			if !isExit {
+				return ForwardingInfo{}, payloadErr(
+					"mpp total set for intermediate hop",
+				)
+			}
+			if total == 0 {
+				return ForwardingInfo{}, payloadErr(
+					"zero mpp total",
+				)
+			}
+			fwdInfo.MPPTotalAmount = lnwire.MilliSatoshi(total)
+
+		// Following the "it's OK to be odd" rule, we'll ignore any
+		// unknown record of an odd type, but must reject those of an
+		// even type.
+		default:
+			if record.Type.IsRequired() {
+				return ForwardingInfo{}, payloadErr(
+					"unknown required type",
+				)
+			}
+		}
+
+		offset += tlv.BigSizeLen(uint64(record.Type)) +
+			tlv.BigSizeLen(uint64(len(record.Value))) +
+			len(record.Value)
+	}
+
+	missing := func(t tlv.Type) error {
+		return &PayloadError{
+			Type:   t,
+			Reason: "missing required record",
+		}
+	}
+	switch {
+	case !haveAmt:
+		return ForwardingInfo{}, missing(AmtOnionType)
+	case !haveLock:
+		return ForwardingInfo{}, missing(LockTimeOnionType)
+	case !haveHop && !isExit:
+		return ForwardingInfo{}, missing(NextHopOnionType)
+	}
+
+	return fwdInfo, nil
+}
+
+// NewFrameHopData returns an onion frame with the passed realm, carrying the
+// passed data within its next address, amount and CLTV fields. The data must
+// not exceed FrameDataSize bytes, and is padded with zeroes if shorter.
+func NewFrameHopData(realm byte, data []byte) (sphinx.HopData, error) {
+	if len(data) > FrameDataSize {
+		return sphinx.HopData{}, fmt.Errorf("frame data of %d bytes "+
+			"exceeds %d bytes", len(data), FrameDataSize)
+	}
+
+	var frame [FrameDataSize]byte
+	copy(frame[:], data)
+
+	hopData := sphinx.HopData{
+		Realm:         realm,
+		ForwardAmount: binary.BigEndian.Uint64(frame[8:16]),
+		OutgoingCltv:  binary.BigEndian.Uint32(frame[16:]),
 	}
+	copy(hopData.NextAddress[:], frame[:8])
+
+	return hopData, nil
+}
+
+// frameData returns the data carried within the next address, amount and
+// CLTV fields of the passed onion frame.
+func frameData(hopData *sphinx.HopData) [FrameDataSize]byte {
+	var data [FrameDataSize]byte
+	copy(data[:8], hopData.NextAddress[:])
+	binary.BigEndian.PutUint64(data[8:16], hopData.ForwardAmount)
+	binary.BigEndian.PutUint32(data[16:], hopData.OutgoingCltv)
+
+	return data
+}
+
+// NewKeysendHopData returns the additional onion frames of a legacy keysend
+// payment, which carry the passed preimage to the final hop. As the preimage
+// doesn't fit within a single frame, it's split across two frames, both of
+// which are addressed to the final hop itself.
+func NewKeysendHopData(preimage [32]byte) ([]sphinx.HopData, error) {
+	first, err := NewFrameHopData(0, preimage[:FrameDataSize])
+	if err != nil {
+		return nil, err
+	}
+	second, err := NewFrameHopData(0, preimage[FrameDataSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return []sphinx.HopData{first, second}, nil
 }
 
 // ExtractErrorEncrypter decodes and returns the ErrorEncrypter for this hop,
@@ -200,6 +487,143 @@ func (p *OnionProcessor) Stop() error {
 	return nil
 }
 
+// extractKeysendPreimage checks whether the passed processed packet is meant
+// for the final hop of a legacy keysend payment, and if so, processes the
+// additional onion frames addressed to us in order to extract the preimage
+// chosen by the sender. If the packet doesn't carry a preimage, nil is
+// returned and the HTLC is handled like any other HTLC.
+func (p *OnionProcessor) extractKeysendPreimage(packet *sphinx.ProcessedPacket,
+	rHash []byte) *[32]byte {
+
+	nextHop := binary.BigEndian.Uint64(
+		packet.ForwardingInstructions.NextAddress[:],
+	)
+	if packet.Action != sphinx.MoreHops ||
+		nextHop != KeysendNextHop.ToUint64() {
+
+		return nil
+	}
+
+	// The frame wrapping the additional frames has already been checked
+	// for replays, so we'll process the additional frames without adding
+	// them to the replay log.
+	var preimage [32]byte
+	for offset := 0; offset < len(preimage); offset += FrameDataSize {
+		if packet.Action != sphinx.MoreHops {
+			log.Errorf("keysend onion frames end prematurely")
+			return nil
+		}
+
+		var err error
+		packet, err = p.router.ReconstructOnionPacket(
+			packet.NextPacket, rHash,
+		)
+		if err != nil {
+			log.Errorf("unable to process keysend onion frame: %v",
+				err)
+			return nil
+		}
+
+		data := frameData(&packet.ForwardingInstructions)
+		copy(preimage[offset:], data[:])
+	}
+	if packet.Action != sphinx.ExitNode {
+		log.Errorf("keysend onion frames aren't the final frames")
+		return nil
+	}
+
+	return &preimage
+}
+
+// isTLVPayload returns true if the passed processed packet starts a TLV
+// encoded hop payload, as signalled by the TLVPayloadNextHop sentinel.
+func isTLVPayload(packet *sphinx.ProcessedPacket) bool {
+	nextHop := binary.BigEndian.Uint64(
+		packet.ForwardingInstructions.NextAddress[:],
+	)
+	return nextHop == TLVPayloadNextHop.ToUint64()
+}
+
+// readTLVPayload reads the TLV encoded hop payload that starts within the
+// passed processed packet, right after the TLVPayloadNextHop sentinel. The
+// payload is prefixed with its length, and if it doesn't fit within a single
+// frame, the remaining frames are addressed to us as well. The TLV stream is
+// returned along with the outcome of processing the last frame of the
+// payload.
+func (p *OnionProcessor) readTLVPayload(packet *sphinx.ProcessedPacket,
+	rHash []byte) ([]byte, *sphinx.ProcessedPacket, error) {
+
+	// The payload starts after the sentinel within the next address.
+	data := frameData(&packet.ForwardingInstructions)
+	first := data[len(packet.ForwardingInstructions.NextAddress):]
+
+	r := bytes.NewReader(first)
+	payloadLen, err := tlv.ReadBigSize(r)
+	if err != nil {
+		return nil, nil, &PayloadError{Reason: err.Error()}
+	}
+	prefixLen := uint64(len(first)) - uint64(r.Len())
+
+	// The payload can't span more frames than fit within the onion.
+	maxLen := uint64(sphinx.NumMaxHops*FrameDataSize-len(data)+
+		len(first)) - prefixLen
+	if payloadLen > maxLen {
+		return nil, nil, &PayloadError{
+			Reason: fmt.Sprintf("payload length %d exceeds %d",
+				payloadLen, maxLen),
+		}
+	}
+
+	payload := make([]byte, 0, prefixLen+payloadLen)
+	payload = append(payload, first...)
+	for uint64(len(payload)) < prefixLen+payloadLen {
+		// The frames following the first one have been protected from
+		// replays by it, so we'll process them without adding them
+		// to the replay log.
+		if packet.Action != sphinx.MoreHops {
+			return nil, nil, &PayloadError{
+				Reason: "payload exceeds final frame",
+			}
+		}
+		packet, err = p.router.ReconstructOnionPacket(
+			packet.NextPacket, rHash,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data := frameData(&packet.ForwardingInstructions)
+		payload = append(payload, data[:]...)
+	}
+
+	return payload[prefixLen : prefixLen+payloadLen], packet, nil
+}
+
+// processHopPayload completes the passed hop iterator by processing the
+// remainder of its hop payload, which either is TLV encoded or a legacy
+// payload, possibly followed by the frames of a keysend payment.
+func (p *OnionProcessor) processHopPayload(iterator *sphinxHopIterator,
+	rHash []byte) {
+
+	packet := iterator.processedPacket
+	if !isTLVPayload(packet) {
+		iterator.keysendPreimage = p.extractKeysendPreimage(
+			packet, rHash,
+		)
+		return
+	}
+
+	payload, lastPacket, err := p.readTLVPayload(packet, rHash)
+	if err != nil {
+		log.Errorf("unable to read tlv hop payload: %v", err)
+		iterator.payloadErr = err
+		return
+	}
+
+	iterator.tlvPayload = payload
+	iterator.processedPacket = lastPacket
+}
+
 // DecodeHopIterator attempts to decode a valid sphinx packet from the passed io.Reader
 // instance using the rHash as the associated data when checking the relevant
 // MACs during the decoding process.
@@ -241,7 +665,10 @@ func (p *OnionProcessor) DecodeHopIterator(r io.Reader, rHash []byte,
 		}
 	}
 
-	return makeSphinxHopIterator(onionPkt, sphinxPacket), lnwire.CodeNone
+	iterator := makeSphinxHopIterator(onionPkt, sphinxPacket)
+	p.processHopPayload(iterator, rHash)
+
+	return iterator, lnwire.CodeNone
 }
 
 // DecodeHopIteratorRequest encapsulates all date necessary to process an onion
@@ -391,7 +818,9 @@ func (p *OnionProcessor) DecodeHopIterators(id []byte,
 
 		// Finally, construct a hop iterator from our processed sphinx
 		// packet, simultaneously caching the original onion packet.
-		resp.HopIterator = makeSphinxHopIterator(&onionPkts[i], &packets[i])
+		iterator := makeSphinxHopIterator(&onionPkts[i], &packets[i])
+		p.processHopPayload(iterator, reqs[i].RHash)
+		resp.HopIterator = iterator
 	}
 
 	return resps, nil
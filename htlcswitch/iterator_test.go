@@ -0,0 +1,355 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lightning-onion"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/lnwire/tlv"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
+)
+
+// encodeTLVPayload encodes the passed records as a TLV stream.
+func encodeTLVPayload(t *testing.T, records ...tlv.Record) []byte {
+	var b bytes.Buffer
+	if err := tlv.Encode(&b, records); err != nil {
+		t.Fatalf("unable to encode records: %v", err)
+	}
+
+	return b.Bytes()
+}
+
+// TestDecodeTLVPayload tests that the forwarding instructions are parsed from
+// TLV encoded hop payloads, and that invalid payloads are rejected with an
+// error pointing to the offending record.
+func TestDecodeTLVPayload(t *testing.T) {
+	t.Parallel()
+
+	var preimage [32]byte
+	preimage[0] = 1
+
+	amt := tlv.NewTUint64Record(AmtOnionType, 1000)
+	lockTime := tlv.NewTUint32Record(LockTimeOnionType, 100)
+	nextHop := tlv.NewUint64Record(NextHopOnionType, 1234)
+	keysend := tlv.NewBytes32Record(KeysendOnionType, preimage)
+	mpp := tlv.NewTUint64Record(MPPOnionType, 5000)
+
+	testCases := []struct {
+		name    string
+		payload []byte
+		isExit  bool
+
+		fwdInfo ForwardingInfo
+
+		// errType and errOffset are only checked if an error is
+		// expected.
+		expectErr bool
+		errType   tlv.Type
+		errOffset uint16
+	}{
+		{
+			name:    "intermediate hop",
+			payload: encodeTLVPayload(t, amt, lockTime, nextHop),
+			fwdInfo: ForwardingInfo{
+				Network:         BitcoinHop,
+				NextHop:         lnwire.NewShortChanIDFromInt(1234),
+				AmountToForward: 1000,
+				OutgoingCTLV:    100,
+			},
+		},
+		{
+			name: "final hop with unknown odd type",
+			payload: encodeTLVPayload(t, amt, lockTime, tlv.Record{
+				Type: 7, Value: []byte{0x01},
+			}, keysend),
+			isExit: true,
+			fwdInfo: ForwardingInfo{
+				Network:         BitcoinHop,
+				NextHop:         exitHop,
+				AmountToForward: 1000,
+				OutgoingCTLV:    100,
+				KeysendPreimage: &preimage,
+			},
+		},
+		{
+			name:    "final hop with mpp total",
+			payload: encodeTLVPayload(t, amt, lockTime, mpp),
+			isExit:  true,
+			fwdInfo: ForwardingInfo{
+				Network:         BitcoinHop,
+				NextHop:         exitHop,
+				AmountToForward: 1000,
+				OutgoingCTLV:    100,
+				MPPTotalAmount:  5000,
+			},
+		},
+		{
+			name:      "missing amount",
+			payload:   encodeTLVPayload(t, lockTime, nextHop),
+			expectErr: true,
+			errType:   AmtOnionType,
+		},
+		{
+			name:      "missing next hop",
+			payload:   encodeTLVPayload(t, amt, lockTime),
+			expectErr: true,
+			errType:   NextHopOnionType,
+		},
+		{
+			name:      "next hop for final hop",
+			payload:   encodeTLVPayload(t, amt, lockTime, nextHop),
+			isExit:    true,
+			expectErr: true,
+			errType:   NextHopOnionType,
+			errOffset: 7,
+		},
+		{
+			name: "unknown even type",
+			payload: encodeTLVPayload(t, amt, lockTime, tlv.Record{
+				Type: 10, Value: []byte{0x01},
+			}),
+			isExit:    true,
+			expectErr: true,
+			errType:   10,
+			errOffset: 7,
+		},
+		{
+			name: "keysend for intermediate hop",
+			payload: encodeTLVPayload(
+				t, amt, lockTime, nextHop, keysend,
+			),
+			expectErr: true,
+			errType:   KeysendOnionType,
+			errOffset: 17,
+		},
+		{
+			name: "mpp total for intermediate hop",
+			payload: encodeTLVPayload(
+				t, amt, lockTime, nextHop, mpp,
+			),
+			expectErr: true,
+			errType:   MPPOnionType,
+			errOffset: 17,
+		},
+	}
+
+	for _, test := range testCases {
+		fwdInfo, err := decodeTLVPayload(test.payload, test.isExit)
+		if !test.expectErr {
+			if err != nil {
+				t.Fatalf("%v: unable to decode payload: %v",
+					test.name, err)
+			}
+			if !reflect.DeepEqual(fwdInfo, test.fwdInfo) {
+				t.Fatalf("%v: expected %v, got %v", test.name,
+					spew.Sdump(test.fwdInfo),
+					spew.Sdump(fwdInfo))
+			}
+			continue
+		}
+
+		payloadErr, ok := err.(*PayloadError)
+		if !ok {
+			t.Fatalf("%v: expected PayloadError, got %v",
+				test.name, err)
+		}
+		if payloadErr.Type != test.errType ||
+			payloadErr.Offset != test.errOffset {
+
+			t.Fatalf("%v: expected type %v at offset %v, got "+
+				"type %v at offset %v", test.name, test.errType,
+				test.errOffset, payloadErr.Type,
+				payloadErr.Offset)
+		}
+	}
+}
+
+// newTestOnionProcessor creates an onion processor backed by a real sphinx
+// router, along with a function that creates onion packets addressed to it
+// which carry the passed frames.
+func newTestOnionProcessor(t *testing.T) (*OnionProcessor,
+	func([]sphinx.HopData, []byte) []byte, func()) {
+
+	tempDir, err := ioutil.TempDir("", "onionprocessor")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	nodeKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate node key: %v", err)
+	}
+	router := sphinx.NewRouter(
+		filepath.Join(tempDir, "sphinxreplay.db"), nodeKey,
+		&chaincfg.RegressionNetParams, nil,
+	)
+	processor := NewOnionProcessor(router)
+	if err := processor.Start(); err != nil {
+		t.Fatalf("unable to start onion processor: %v", err)
+	}
+
+	newOnion := func(frames []sphinx.HopData, rHash []byte) []byte {
+		sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate session key: %v", err)
+		}
+
+		path := make([]*btcec.PublicKey, len(frames))
+		for i := range path {
+			path[i] = nodeKey.PubKey()
+		}
+		packet, err := sphinx.NewOnionPacket(
+			path, sessionKey, frames, rHash,
+		)
+		if err != nil {
+			t.Fatalf("unable to create onion packet: %v", err)
+		}
+
+		var b bytes.Buffer
+		if err := packet.Encode(&b); err != nil {
+			t.Fatalf("unable to encode onion packet: %v", err)
+		}
+		return b.Bytes()
+	}
+
+	cleanUp := func() {
+		processor.Stop()
+		os.RemoveAll(tempDir)
+	}
+
+	return processor, newOnion, cleanUp
+}
+
+// TestSphinxKeysendPreimage tests that the preimage of a legacy keysend
+// payment survives being carried through a real sphinx onion to the final
+// hop.
+func TestSphinxKeysendPreimage(t *testing.T) {
+	t.Parallel()
+
+	processor, newOnion, cleanUp := newTestOnionProcessor(t)
+	defer cleanUp()
+
+	var preimage [32]byte
+	for i := range preimage {
+		preimage[i] = byte(i + 1)
+	}
+	paymentHash := sha256.Sum256(preimage[:])
+	rHash := paymentHash[:]
+
+	hopData := sphinx.HopData{
+		ForwardAmount: 1000,
+		OutgoingCltv:  100,
+	}
+	binary.BigEndian.PutUint64(
+		hopData.NextAddress[:], KeysendNextHop.ToUint64(),
+	)
+	keysendFrames, err := NewKeysendHopData(preimage)
+	if err != nil {
+		t.Fatalf("unable to create keysend frames: %v", err)
+	}
+	frames := append([]sphinx.HopData{hopData}, keysendFrames...)
+
+	iterator, failCode := processor.DecodeHopIterator(
+		bytes.NewReader(newOnion(frames, rHash)), rHash, 100,
+	)
+	if failCode != lnwire.CodeNone {
+		t.Fatalf("unable to decode onion: %v", failCode)
+	}
+	fwdInfo, err := iterator.ForwardingInstructions()
+	if err != nil {
+		t.Fatalf("unable to get forwarding instructions: %v", err)
+	}
+
+	if fwdInfo.NextHop != exitHop {
+		t.Fatalf("expected exit hop, got %v", fwdInfo.NextHop)
+	}
+	if fwdInfo.AmountToForward != 1000 || fwdInfo.OutgoingCTLV != 100 {
+		t.Fatalf("invalid forwarding instructions: %v",
+			spew.Sdump(fwdInfo))
+	}
+	if fwdInfo.KeysendPreimage == nil ||
+		*fwdInfo.KeysendPreimage != preimage {
+
+		t.Fatalf("expected preimage %x, got %v", preimage,
+			spew.Sdump(fwdInfo.KeysendPreimage))
+	}
+}
+
+// TestSphinxTLVPayload tests that a TLV encoded hop payload spanning several
+// frames survives being carried through a real sphinx onion to the final hop.
+func TestSphinxTLVPayload(t *testing.T) {
+	t.Parallel()
+
+	processor, newOnion, cleanUp := newTestOnionProcessor(t)
+	defer cleanUp()
+
+	var preimage [32]byte
+	for i := range preimage {
+		preimage[i] = byte(i + 1)
+	}
+	rHash := bytes.Repeat([]byte{1}, 32)
+
+	stream := encodeTLVPayload(t,
+		tlv.NewTUint64Record(AmtOnionType, 1000),
+		tlv.NewTUint32Record(LockTimeOnionType, 100),
+		tlv.NewBytes32Record(KeysendOnionType, preimage),
+	)
+
+	var payload bytes.Buffer
+	err := binary.Write(
+		&payload, binary.BigEndian, TLVPayloadNextHop.ToUint64(),
+	)
+	if err != nil {
+		t.Fatalf("unable to write sentinel: %v", err)
+	}
+	if err := tlv.WriteBigSize(&payload, uint64(len(stream))); err != nil {
+		t.Fatalf("unable to write payload length: %v", err)
+	}
+	payload.Write(stream)
+
+	var frames []sphinx.HopData
+	for payload.Len() > 0 {
+		frame, err := NewFrameHopData(
+			TLVPayloadRealm, payload.Next(FrameDataSize),
+		)
+		if err != nil {
+			t.Fatalf("unable to create frame: %v", err)
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) < 2 {
+		t.Fatalf("expected payload to span several frames")
+	}
+
+	iterator, failCode := processor.DecodeHopIterator(
+		bytes.NewReader(newOnion(frames, rHash)), rHash, 100,
+	)
+	if failCode != lnwire.CodeNone {
+		t.Fatalf("unable to decode onion: %v", failCode)
+	}
+	fwdInfo, err := iterator.ForwardingInstructions()
+	if err != nil {
+		t.Fatalf("unable to get forwarding instructions: %v", err)
+	}
+
+	expectedFwdInfo := ForwardingInfo{
+		Network:         BitcoinHop,
+		NextHop:         exitHop,
+		AmountToForward: 1000,
+		OutgoingCTLV:    100,
+		KeysendPreimage: &preimage,
+	}
+	if !reflect.DeepEqual(fwdInfo, expectedFwdInfo) {
+		t.Fatalf("expected forwarding info %v, got %v",
+			spew.Sdump(expectedFwdInfo), spew.Sdump(fwdInfo))
+	}
+}
@@ -35,6 +35,25 @@ const (
 // to obfuscate the true failure.
 var ErrInternalLinkFailure = errors.New("internal link failure")
 
+// errKeysendHashMismatch is returned when the preimage carried within the onion
+// of a keysend payment doesn't match the payment hash of its HTLC.
+var errKeysendHashMismatch = errors.New("keysend preimage doesn't match " +
+	"payment hash")
+
+// hodlHtlc contains the details of an incoming HTLC paying to an accepted hold
+// invoice, or paying only part of an invoice, which are required to either
+// settle or fail it once the invoice has been resolved.
+type hodlHtlc struct {
+	htlcIndex  uint64
+	sourceRef  *channeldb.AddRef
+	obfuscator ErrorEncrypter
+	timeout    uint32
+
+	// partial is set if the HTLC is one of the partial HTLCs of a
+	// multi-path payment.
+	partial bool
+}
+
 // ForwardingPolicy describes the set of constraints that a given ChannelLink
 // is to adhere to when forwarding HTLC's. For each incoming HTLC, this set of
 // constraints will be consulted in order to ensure that adequate fees are
@@ -327,6 +346,15 @@ type channelLink struct {
 	logCommitTimer *time.Timer
 	logCommitTick  <-chan time.Time
 
+	// hodlMap stores the HTLCs paying to accepted hold invoices, keyed by
+	// payment hash. These HTLCs are held until the invoice registry
+	// notifies us that the invoice has either been settled or canceled.
+	hodlMap map[chainhash.Hash][]hodlHtlc
+
+	// hodlQueue is the channel over which the invoice registry delivers
+	// the resolutions of the hold invoices we're holding HTLCs for.
+	hodlQueue chan HodlEvent
+
 	sync.RWMutex
 
 	wg   sync.WaitGroup
@@ -348,6 +376,8 @@ func NewChannelLink(cfg ChannelLinkConfig, channel *lnwallet.LightningChannel,
 		overflowQueue:  newPacketQueue(lnwallet.MaxHTLCNumber / 2),
 		bestHeight:     currentHeight,
 		htlcUpdates:    make(chan []channeldb.HTLC),
+		hodlMap:        make(map[chainhash.Hash][]hodlHtlc),
+		hodlQueue:      make(chan HodlEvent, lnwallet.MaxHTLCNumber),
 		quit:           make(chan struct{}),
 	}
 }
@@ -413,6 +443,11 @@ func (l *channelLink) Stop() {
 
 	l.overflowQueue.Stop()
 
+	// We'll no longer be able to resolve any of the HTLCs we're holding,
+	// so remove our hodl subscriptions. The HTLCs will be reinstated from
+	// our forwarding packages once the link is started again.
+	l.cfg.Registry.HodlUnsubscribeAll(l.hodlQueue)
+
 	close(l.quit)
 	l.wg.Wait()
 }
@@ -800,6 +835,11 @@ out:
 
 			l.bestHeight = uint32(blockEpoch.Height)
 
+			// Cancel the hold invoices of any HTLCs we're holding
+			// that are about to expire, so they're failed back
+			// before the remote party has to go to chain.
+			l.cancelExpiringHodlHtlcs()
+
 			// If we're not the initiator of the channel, don't we
 			// don't control the fees, so we can ignore this.
 			if !l.channel.IsInitiator() {
@@ -920,6 +960,18 @@ out:
 		case msg := <-l.upstream:
 			l.handleUpstreamMsg(msg)
 
+		// A hold invoice we're holding HTLCs for has been resolved, so
+		// we'll either settle or fail the HTLCs paying to it.
+		case event := <-l.hodlQueue:
+			if !l.processHodlEvent(event) {
+				continue
+			}
+
+			if err := l.updateCommitTx(); err != nil {
+				l.fail("unable to update commitment: %v", err)
+				break out
+			}
+
 		// TODO(roasbeef): make distinct goroutine to handle?
 		case cmd := <-l.linkControl:
 
@@ -1860,7 +1912,27 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 
 		heightNow := l.bestHeight
 
-		fwdInfo := chanIterator.ForwardingInstructions()
+		fwdInfo, err := chanIterator.ForwardingInstructions()
+		if err != nil {
+			log.Errorf("unable to decode forwarding instructions "+
+				"of htlc(%x): %v", pd.RHash[:], err)
+
+			// If the sender's hop payload is invalid, then we'll
+			// point them to the offending record, if known.
+			failure := &lnwire.FailInvalidOnionPayload{}
+			if payloadErr, ok := err.(*PayloadError); ok {
+				failure = lnwire.NewInvalidOnionPayload(
+					payloadErr.Type, payloadErr.Offset,
+				)
+			}
+			l.sendHTLCError(
+				pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+			)
+
+			needUpdate = true
+			continue
+		}
+
 		switch fwdInfo.NextHop {
 		case exitHop:
 			if l.cfg.DebugHTLC && l.cfg.HodlHTLC {
@@ -1885,10 +1957,41 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				continue
 			}
 
+			// If the sender carried the preimage of a keysend
+			// payment to us, then there's no invoice for the
+			// payment yet. After making sure the preimage matches
+			// the payment hash, we'll create one on the fly,
+			// paying the amount the sender intended us to receive.
+			invoiceHash := chainhash.Hash(pd.RHash)
+			if fwdInfo.KeysendPreimage != nil {
+				var err error = errKeysendHashMismatch
+				keysendHash := sha256.Sum256(
+					fwdInfo.KeysendPreimage[:],
+				)
+				if keysendHash == invoiceHash {
+					err = l.cfg.Registry.AddKeysendInvoice(
+						*fwdInfo.KeysendPreimage,
+						fwdInfo.AmountToForward,
+					)
+				}
+				if err != nil {
+					log.Errorf("rejecting keysend htlc(%x): "+
+						"%v", pd.RHash[:], err)
+
+					failure := lnwire.FailUnknownPaymentHash{}
+					l.sendHTLCError(
+						pd.HtlcIndex, failure, obfuscator,
+						pd.SourceRef,
+					)
+
+					needUpdate = true
+					continue
+				}
+			}
+
 			// We're the designated payment destination.  Therefore
 			// we attempt to see if we have an invoice locally
 			// which'll allow us to settle this htlc.
-			invoiceHash := chainhash.Hash(pd.RHash)
 			invoice, err := l.cfg.Registry.LookupInvoice(invoiceHash)
 			if err != nil {
 				log.Errorf("unable to query invoice registry: "+
@@ -1902,6 +2005,25 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				continue
 			}
 
+			// If the invoice has been canceled or has expired,
+			// then we'll reject any HTLCs paying to it as if we
+			// didn't know about it at all.
+			if invoice.Terms.State == channeldb.ContractCanceled ||
+				invoice.Terms.State == channeldb.ContractExpired {
+
+				log.Errorf("rejecting htlc(%x) paying to "+
+					"%v invoice", pd.RHash[:],
+					invoice.Terms.State)
+
+				failure := lnwire.FailUnknownPaymentHash{}
+				l.sendHTLCError(
+					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				)
+
+				needUpdate = true
+				continue
+			}
+
 			// If the invoice is already settled, we choose to
 			// accept the payment to simplify failure recovery.
 			//
@@ -1919,11 +2041,24 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// TODO(conner): track ownership of settlements to
 			// properly recover from failures? or add batch invoice
 			// settlement
-			if invoice.Terms.Settled {
+			if invoice.Terms.State == channeldb.ContractSettled {
 				log.Warnf("Accepting duplicate payment for "+
 					"hash=%x", pd.RHash[:])
 			}
 
+			// If the sender marked the htlc as one of the partial
+			// HTLCs of a multi-path payment covering the value of
+			// the invoice, and the invoice is still open, then
+			// we'll hold on to the htlc until the partial HTLCs
+			// paying to the invoice cover its value. The sender
+			// only includes the MPP record if we advertise support
+			// for multi-path payments. An htlc without it that
+			// doesn't meet the value requested is failed below.
+			partial := !l.cfg.DebugHTLC && invoice.Terms.Value > 0 &&
+				invoice.Terms.State == channeldb.ContractOpen &&
+				fwdInfo.MPPTotalAmount >= invoice.Terms.Value &&
+				pd.Amount < invoice.Terms.Value
+
 			// If we're not currently in debug mode, and the
 			// extended htlc doesn't meet the value requested, then
 			// we'll fail the htlc.  Otherwise, we settle this htlc
@@ -1935,7 +2070,8 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// allows the payee to specify the amount of satoshis
 			// they wish to send.  So since we expect the htlc to
 			// have a different amount, we should not fail.
-			if !l.cfg.DebugHTLC && invoice.Terms.Value > 0 &&
+			if !partial && !l.cfg.DebugHTLC &&
+				invoice.Terms.Value > 0 &&
 				pd.Amount < invoice.Terms.Value {
 
 				log.Errorf("rejecting htlc due to incorrect "+
@@ -1961,7 +2097,8 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// allows the payee to specify the amount of satoshis
 			// they wish to send.  So since we expect the htlc to
 			// have a different amount, we should not fail.
-			if !l.cfg.DebugHTLC && invoice.Terms.Value > 0 &&
+			if !partial && !l.cfg.DebugHTLC &&
+				invoice.Terms.Value > 0 &&
 				fwdInfo.AmountToForward < invoice.Terms.Value {
 
 				log.Errorf("Onion payload of incoming htlc(%x) "+
@@ -1978,6 +2115,27 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				continue
 			}
 
+			// The hop-payload of a partial HTLC carries the amount
+			// of the HTLC itself rather than the value of the
+			// invoice, so we'll make sure we were extended at
+			// least that much.
+			if partial && pd.Amount < fwdInfo.AmountToForward {
+				log.Errorf("Onion payload of incoming partial "+
+					"htlc(%x) has incorrect value: "+
+					"expected at most %v, got %v", pd.RHash,
+					pd.Amount, fwdInfo.AmountToForward)
+
+				failure := lnwire.NewFinalIncorrectHtlcAmount(
+					pd.Amount,
+				)
+				l.sendHTLCError(
+					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				)
+
+				needUpdate = true
+				continue
+			}
+
 			// We'll also ensure that our time-lock value has been
 			// computed correctly.
 			//
@@ -2019,22 +2177,99 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				continue
 			}
 
+			heldHtlc := hodlHtlc{
+				htlcIndex:  pd.HtlcIndex,
+				sourceRef:  pd.SourceRef,
+				obfuscator: obfuscator,
+				timeout:    pd.Timeout,
+				partial:    partial,
+			}
+
+			// If this is a partial HTLC, then we'll add it to the
+			// set of partial HTLCs paying to the invoice, and hold
+			// on to it until the set either covers the invoice or
+			// times out.
+			if partial {
+				circuitKey := channeldb.CircuitKey{
+					ChanID: l.ShortChanID(),
+					HtlcID: pd.HtlcIndex,
+				}
+				err := l.cfg.Registry.AddPartialHTLC(
+					invoiceHash, circuitKey, pd.Amount,
+					l.hodlQueue,
+				)
+				if err != nil {
+					l.fail("unable to add partial htlc: %v",
+						err)
+					return false
+				}
+
+				l.infof("holding partial htlc %x of %v as "+
+					"exit hop", pd.RHash, pd.Amount)
+
+				l.hodlMap[invoiceHash] = append(
+					l.hodlMap[invoiceHash], heldHtlc,
+				)
+				continue
+			}
+
+			// If we don't know the preimage of the invoice yet,
+			// then this is a hold invoice. We'll mark it as
+			// accepted and hold on to the HTLC until the invoice
+			// is either settled or canceled.
 			preimage := invoice.Terms.PaymentPreimage
-			err = l.channel.SettleHTLC(preimage,
-				pd.HtlcIndex, pd.SourceRef, nil, nil)
-			if err != nil {
-				l.fail("unable to settle htlc: %v", err)
-				return false
+			if preimage == channeldb.UnknownPreimage {
+				err := l.cfg.Registry.AcceptInvoice(
+					invoiceHash, l.hodlQueue,
+				)
+				if err != nil {
+					l.fail("unable to accept invoice: %v", err)
+					return false
+				}
+
+				l.infof("holding %x as exit hop", pd.RHash)
+
+				l.hodlMap[invoiceHash] = append(
+					l.hodlMap[invoiceHash], heldHtlc,
+				)
+				continue
 			}
 
-			// Notify the invoiceRegistry of the invoices we just
-			// settled with this latest commitment update.
+			// Before settling the htlc, we'll settle the invoice
+			// within the invoiceRegistry. The invoice may have
+			// been canceled or have expired since we looked it
+			// up, in which case we'll only fail this htlc back.
 			err = l.cfg.Registry.SettleInvoice(invoiceHash)
-			if err != nil {
+			switch err {
+			case nil:
+
+			case channeldb.ErrInvoiceAlreadyCanceled,
+				channeldb.ErrInvoiceExpired:
+
+				log.Errorf("rejecting htlc(%x) paying to "+
+					"invoice that is no longer open: %v",
+					pd.RHash[:], err)
+
+				failure := lnwire.FailUnknownPaymentHash{}
+				l.sendHTLCError(
+					pd.HtlcIndex, failure, obfuscator, pd.SourceRef,
+				)
+
+				needUpdate = true
+				continue
+
+			default:
 				l.fail("unable to settle invoice: %v", err)
 				return false
 			}
 
+			err = l.channel.SettleHTLC(preimage,
+				pd.HtlcIndex, pd.SourceRef, nil, nil)
+			if err != nil {
+				l.fail("unable to settle htlc: %v", err)
+				return false
+			}
+
 			l.infof("settling %x as exit hop", pd.RHash)
 
 			// HTLC was successfully settled locally send
@@ -2343,6 +2578,97 @@ func (l *channelLink) handleBatchFwdErrs(errChan chan error) {
 	}
 }
 
+// processHodlEvent settles or fails all HTLCs we're holding for the hold
+// invoice that has been resolved. A bool is returned indicating whether the
+// commitment needs to be updated.
+func (l *channelLink) processHodlEvent(event HodlEvent) bool {
+	htlcs, ok := l.hodlMap[event.Hash]
+	if !ok {
+		return false
+	}
+	delete(l.hodlMap, event.Hash)
+
+	for _, htlc := range htlcs {
+		// If the invoice has been canceled, then we'll fail the HTLC
+		// back as if we didn't know about the invoice at all. If
+		// instead the set of partial HTLCs the HTLC belongs to timed
+		// out, then we'll let the sender know, such that it may retry.
+		if event.Preimage == nil {
+			l.infof("failing held htlc %x as exit hop", event.Hash)
+
+			var failure lnwire.FailureMessage
+			if event.MPPTimeout {
+				failure = lnwire.FailMPPTimeout{}
+			} else {
+				failure = lnwire.FailUnknownPaymentHash{}
+			}
+			l.sendHTLCError(
+				htlc.htlcIndex, failure, htlc.obfuscator,
+				htlc.sourceRef,
+			)
+			continue
+		}
+
+		err := l.channel.SettleHTLC(*event.Preimage, htlc.htlcIndex,
+			htlc.sourceRef, nil, nil)
+		if err != nil {
+			l.fail("unable to settle htlc: %v", err)
+			return false
+		}
+
+		l.infof("settling held htlc %x as exit hop", event.Hash)
+
+		l.cfg.Peer.SendMessage(&lnwire.UpdateFulfillHTLC{
+			ChanID:          l.ChanID(),
+			ID:              htlc.htlcIndex,
+			PaymentPreimage: *event.Preimage,
+		})
+	}
+
+	return true
+}
+
+// cancelExpiringHodlHtlcs cancels the hold invoices of the HTLCs we're
+// holding whose timeout is within the expiry grace delta of the current
+// height. For partial HTLCs, only the set of partial HTLCs paying to the
+// invoice is failed instead. The HTLCs themselves are failed once the
+// cancellation is delivered back to us over the hodl queue.
+func (l *channelLink) cancelExpiringHodlHtlcs() {
+	for hash, htlcs := range l.hodlMap {
+		for _, htlc := range htlcs {
+			// The grace delta is added to the best height rather
+			// than subtracted from the timeout, which could
+			// otherwise underflow.
+			if htlc.timeout > l.bestHeight+expiryGraceDelta {
+				continue
+			}
+
+			if htlc.partial {
+				l.warnf("failing partial htlcs of invoice %x, "+
+					"held htlc expires at height %v",
+					hash[:], htlc.timeout)
+
+				err := l.cfg.Registry.FailPartialHTLCs(hash)
+				if err != nil {
+					l.errorf("unable to fail partial htlcs "+
+						"of invoice %x: %v", hash[:], err)
+				}
+				break
+			}
+
+			l.warnf("canceling hold invoice %x, held htlc expires "+
+				"at height %v", hash[:], htlc.timeout)
+
+			err := l.cfg.Registry.CancelInvoice(hash)
+			if err != nil {
+				l.errorf("unable to cancel invoice %x: %v",
+					hash[:], err)
+			}
+			break
+		}
+	}
+}
+
 // sendHTLCError functions cancels HTLC and send cancel message back to the
 // peer from which HTLC was received.
 func (l *channelLink) sendHTLCError(htlcIndex uint64, failure lnwire.FailureMessage,
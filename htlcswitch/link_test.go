@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -229,7 +230,7 @@ func TestChannelLinkSingleHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("alice invoice wasn't settled")
 	}
 
@@ -454,7 +455,7 @@ func TestChannelLinkMultiHopPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -794,7 +795,7 @@ func TestUpdateForwardingPolicy(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -910,7 +911,7 @@ func TestChannelLinkMultiHopInsufficientPayment(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -986,8 +987,8 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 	}
 
 	// Send payment and expose err channel.
-	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
-		newMockDeobfuscator())
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), 1,
+		htlc, newMockDeobfuscator())
 	if err.Error() != lnwire.CodeUnknownPaymentHash.String() {
 		t.Fatal("error haven't been received")
 	}
@@ -997,7 +998,7 @@ func TestChannelLinkMultiHopUnknownPaymentHash(t *testing.T) {
 
 	// Check that alice invoice wasn't settled and bandwidth of htlc
 	// links hasn't been changed.
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("alice invoice was settled")
 	}
 
@@ -1078,7 +1079,7 @@ func TestChannelLinkMultiHopUnknownNextHop(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -1166,7 +1167,7 @@ func TestChannelLinkMultiHopDecodeError(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if invoice.Terms.Settled {
+	if invoice.Terms.State == channeldb.ContractSettled {
 		t.Fatal("carol invoice have been settled")
 	}
 
@@ -2680,7 +2681,7 @@ func TestChannelRetransmission(t *testing.T) {
 				err = errors.Errorf("unable to get invoice: %v", err)
 				continue
 			}
-			if !invoice.Terms.Settled {
+			if invoice.Terms.State != channeldb.ContractSettled {
 				err = errors.Errorf("alice invoice haven't been settled")
 				continue
 			}
@@ -3011,16 +3012,16 @@ func TestChannelLinkAcceptDuplicatePayment(t *testing.T) {
 	// With the invoice now added to Carol's registry, we'll send the
 	// payment. It should succeed w/o any issues as it has been crafted
 	// properly.
-	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
-		newMockDeobfuscator())
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), 1,
+		htlc, newMockDeobfuscator())
 	if err != nil {
 		t.Fatalf("unable to send payment to carol: %v", err)
 	}
 
 	// Now, if we attempt to send the payment *again* it should be rejected
 	// as it's a duplicate request.
-	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), htlc,
-		newMockDeobfuscator())
+	_, err = n.aliceServer.htlcSwitch.SendHTLC(n.bobServer.PubKey(), 2,
+		htlc, newMockDeobfuscator())
 	if err != nil {
 		t.Fatalf("error shouldn't have been received got: %v", err)
 	}
@@ -3082,7 +3083,7 @@ func TestChannelLinkAcceptOverpay(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to get invoice: %v", err)
 	}
-	if !invoice.Terms.Settled {
+	if invoice.Terms.State != channeldb.ContractSettled {
 		t.Fatal("carol invoice haven't been settled")
 	}
 
@@ -3110,3 +3111,434 @@ func TestChannelLinkAcceptOverpay(t *testing.T) {
 			expectedCarolBandwidth, n.carolChannelLink.Bandwidth())
 	}
 }
+
+// TestChannelLinkHoldInvoice tests that an HTLC paying to a hold invoice is
+// held by the exit hop until the invoice is either settled, in which case the
+// HTLC is settled with the supplied preimage, or canceled, in which case the
+// HTLC is failed back to the sender.
+func TestChannelLinkHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	for _, settle := range []bool{true, false} {
+		testChannelLinkHoldInvoice(t, settle)
+	}
+}
+
+func testChannelLinkHoldInvoice(t *testing.T, settle bool) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatalf("unable to start three hop network: %v", err)
+	}
+	defer n.stop()
+
+	// We'll make a payment from Alice to Carol, for which Carol only
+	// registers the payment hash within her invoice registry.
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount, testStartingHeight,
+		n.firstBobChannelLink, n.carolChannelLink)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preimage := invoice.Terms.PaymentPreimage
+	rhash := chainhash.Hash(htlc.PaymentHash)
+
+	registry := n.carolServer.registry
+	if err := registry.AddHoldInvoice(*invoice, rhash); err != nil {
+		t.Fatalf("unable to add invoice in carol registry: %v", err)
+	}
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), 1, htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	// Wait for Carol to accept the HTLC, the payment itself shouldn't
+	// complete yet as Carol doesn't know the preimage.
+	timeout := time.After(5 * time.Second)
+	for {
+		invoice, err := registry.LookupInvoice(rhash)
+		if err != nil {
+			t.Fatalf("unable to get invoice: %v", err)
+		}
+		if invoice.Terms.State == channeldb.ContractAccepted {
+			break
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("invoice wasn't accepted")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	select {
+	case err := <-paymentErr:
+		t.Fatalf("payment shouldn't have completed, got: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Now resolve the invoice, which should in turn resolve the payment.
+	if settle {
+		err = registry.SettleHoldInvoice(preimage)
+	} else {
+		err = registry.CancelInvoice(rhash)
+	}
+	if err != nil {
+		t.Fatalf("unable to resolve invoice: %v", err)
+	}
+
+	select {
+	case err = <-paymentErr:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't resolved")
+	}
+
+	if settle {
+		if err != nil {
+			t.Fatalf("unable to send payment: %v", err)
+		}
+		return
+	}
+
+	ferr, ok := err.(*ForwardingError)
+	if !ok {
+		t.Fatalf("expected a ForwardingError, instead got: %T", err)
+	}
+	if _, ok := ferr.FailureMessage.(*lnwire.FailUnknownPaymentHash); !ok {
+		t.Fatalf("expected unknown payment hash, instead have: %v",
+			err)
+	}
+}
+
+// TestChannelLinkMultiPathPayment tests that an HTLC paying only part of an
+// invoice is held by the exit hop until the partial HTLCs paying to the
+// invoice cover its value, in which case they're all settled, or until the
+// set of partial HTLCs is failed, in which case the HTLC is failed back to the
+// sender with an MPP timeout.
+func TestChannelLinkMultiPathPayment(t *testing.T) {
+	t.Parallel()
+
+	for _, complete := range []bool{true, false} {
+		testChannelLinkMultiPathPayment(t, complete)
+	}
+}
+
+func testChannelLinkMultiPathPayment(t *testing.T, complete bool) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatalf("unable to start three hop network: %v", err)
+	}
+	defer n.stop()
+
+	// We'll pay Carol's invoice with two HTLCs from Alice, each carrying
+	// half of the invoice amount, and marked as partial HTLCs through the
+	// total amount within their hop payload.
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount/2,
+		testStartingHeight, n.firstBobChannelLink, n.carolChannelLink)
+	hops[len(hops)-1].MPPTotalAmount = amount
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rhash := chainhash.Hash(htlc.PaymentHash)
+
+	registry := n.carolServer.registry
+	if err := registry.AddInvoice(*invoice); err != nil {
+		t.Fatalf("unable to add invoice in carol registry: %v", err)
+	}
+
+	sendShard := func(paymentID uint64) chan error {
+		paymentErr := make(chan error, 1)
+		go func() {
+			shard := *htlc
+			_, err := n.aliceServer.htlcSwitch.SendHTLC(
+				n.bobServer.PubKey(), paymentID, &shard,
+				newMockDeobfuscator(),
+			)
+			paymentErr <- err
+		}()
+
+		return paymentErr
+	}
+
+	// The first shard doesn't cover the invoice, so Carol should hold on
+	// to it.
+	firstErr := sendShard(1)
+	select {
+	case err := <-firstErr:
+		t.Fatalf("payment shouldn't have completed, got: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	invoice2, err := registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if invoice2.Terms.State != channeldb.ContractOpen {
+		t.Fatalf("expected invoice to remain open, got %v",
+			invoice2.Terms.State)
+	}
+
+	// If the payment isn't completed, then we'll fail the partial HTLCs,
+	// which should fail back the first shard with an MPP timeout.
+	if !complete {
+		if err := registry.FailPartialHTLCs(rhash); err != nil {
+			t.Fatalf("unable to fail partial htlcs: %v", err)
+		}
+
+		select {
+		case err = <-firstErr:
+		case <-time.After(30 * time.Second):
+			t.Fatalf("payment wasn't resolved")
+		}
+
+		ferr, ok := err.(*ForwardingError)
+		if !ok {
+			t.Fatalf("expected a ForwardingError, instead got: %T",
+				err)
+		}
+		if _, ok := ferr.FailureMessage.(*lnwire.FailMPPTimeout); !ok {
+			t.Fatalf("expected mpp timeout, instead have: %v", err)
+		}
+		return
+	}
+
+	// Otherwise, the second shard covers the invoice, so both of them
+	// should be settled.
+	secondErr := sendShard(2)
+	for _, paymentErr := range []chan error{firstErr, secondErr} {
+		select {
+		case err := <-paymentErr:
+			if err != nil {
+				t.Fatalf("unable to send payment: %v", err)
+			}
+		case <-time.After(30 * time.Second):
+			t.Fatalf("payment wasn't resolved")
+		}
+	}
+
+	invoice2, err = registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if invoice2.Terms.State != channeldb.ContractSettled {
+		t.Fatalf("expected invoice to be settled, got %v",
+			invoice2.Terms.State)
+	}
+}
+
+// TestChannelLinkKeysend tests that an HTLC carrying the preimage of a keysend
+// payment is settled by the exit hop without a prior invoice if keysend
+// payments are accepted, and rejected with an unknown payment hash otherwise.
+func TestChannelLinkKeysend(t *testing.T) {
+	t.Parallel()
+
+	for _, accept := range []bool{true, false} {
+		testChannelLinkKeysend(t, accept)
+	}
+}
+
+func testChannelLinkKeysend(t *testing.T, accept bool) {
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatalf("unable to start three hop network: %v", err)
+	}
+	defer n.stop()
+
+	registry := n.carolServer.registry
+	registry.Lock()
+	registry.acceptKeysend = accept
+	registry.Unlock()
+
+	// Alice will pay Carol without Carol having created an invoice, by
+	// carrying the preimage to Carol within the onion.
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := chainhash.Hash(sha256.Sum256(preimage[:]))
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount,
+		testStartingHeight, n.firstBobChannelLink, n.carolChannelLink)
+	hops[len(hops)-1].KeysendPreimage = &preimage
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	htlc := &lnwire.UpdateAddHTLC{
+		PaymentHash: rhash,
+		Amount:      htlcAmt,
+		Expiry:      totalTimelock,
+		OnionBlob:   blob,
+	}
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), 1, htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	select {
+	case err = <-paymentErr:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't resolved")
+	}
+
+	// If Carol doesn't accept keysend payments, then the payment should
+	// be failed as if Carol didn't know the payment hash.
+	if !accept {
+		ferr, ok := err.(*ForwardingError)
+		if !ok {
+			t.Fatalf("expected a ForwardingError, instead got: %T",
+				err)
+		}
+		_, ok = ferr.FailureMessage.(*lnwire.FailUnknownPaymentHash)
+		if !ok {
+			t.Fatalf("expected unknown payment hash, instead "+
+				"have: %v", err)
+		}
+
+		_, err := registry.LookupInvoice(rhash)
+		if err == nil {
+			t.Fatalf("invoice created for rejected keysend payment")
+		}
+		return
+	}
+
+	// Otherwise, the payment should succeed, with Carol having created a
+	// settled invoice for it on the fly.
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	invoice, err := registry.LookupInvoice(rhash)
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if invoice.Terms.State != channeldb.ContractSettled {
+		t.Fatalf("expected invoice to be settled, got %v",
+			invoice.Terms.State)
+	}
+	if invoice.Terms.Value != amount {
+		t.Fatalf("expected invoice value %v, got %v", amount,
+			invoice.Terms.Value)
+	}
+}
+
+// TestChannelLinkPartialPaymentWithoutMPP tests that an HTLC paying only part
+// of an invoice is failed right away with an incorrect payment amount if its
+// hop payload doesn't mark it as one of the partial HTLCs of a multi-path
+// payment.
+func TestChannelLinkPartialPaymentWithoutMPP(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*3,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatalf("unable to start three hop network: %v", err)
+	}
+	defer n.stop()
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	htlcAmt, totalTimelock, hops := generateHops(amount/2,
+		testStartingHeight, n.firstBobChannelLink, n.carolChannelLink)
+	blob, err := generateRoute(hops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoice, htlc, err := generatePayment(amount, htlcAmt, totalTimelock,
+		blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := n.carolServer.registry
+	if err := registry.AddInvoice(*invoice); err != nil {
+		t.Fatalf("unable to add invoice in carol registry: %v", err)
+	}
+
+	paymentErr := make(chan error, 1)
+	go func() {
+		_, err := n.aliceServer.htlcSwitch.SendHTLC(
+			n.bobServer.PubKey(), 1, htlc, newMockDeobfuscator(),
+		)
+		paymentErr <- err
+	}()
+
+	select {
+	case err = <-paymentErr:
+	case <-time.After(30 * time.Second):
+		t.Fatalf("payment wasn't resolved")
+	}
+
+	ferr, ok := err.(*ForwardingError)
+	if !ok {
+		t.Fatalf("expected a ForwardingError, instead got: %T", err)
+	}
+	if _, ok := ferr.FailureMessage.(*lnwire.FailIncorrectPaymentAmount); !ok {
+		t.Fatalf("expected incorrect payment amount, instead have: %v",
+			err)
+	}
+
+	invoice2, err := registry.LookupInvoice(chainhash.Hash(htlc.PaymentHash))
+	if err != nil {
+		t.Fatalf("unable to get invoice: %v", err)
+	}
+	if invoice2.Terms.State != channeldb.ContractOpen {
+		t.Fatalf("expected invoice to remain open, got %v",
+			invoice2.Terms.State)
+	}
+}
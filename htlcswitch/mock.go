@@ -224,10 +224,10 @@ func newMockHopIterator(hops ...ForwardingInfo) HopIterator {
 	return &mockHopIterator{hops: hops}
 }
 
-func (r *mockHopIterator) ForwardingInstructions() ForwardingInfo {
+func (r *mockHopIterator) ForwardingInstructions() (ForwardingInfo, error) {
 	h := r.hops[0]
 	r.hops = r.hops[1:]
-	return h
+	return h, nil
 }
 
 func (r *mockHopIterator) ExtractErrorEncrypter(
@@ -270,6 +270,19 @@ func (f *ForwardingInfo) encode(w io.Writer) error {
 		return err
 	}
 
+	var preimage [33]byte
+	if f.KeysendPreimage != nil {
+		preimage[0] = 1
+		copy(preimage[1:], f.KeysendPreimage[:])
+	}
+	if _, err := w.Write(preimage[:]); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.BigEndian, f.MPPTotalAmount); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -434,6 +447,19 @@ func (f *ForwardingInfo) decode(r io.Reader) error {
 		return err
 	}
 
+	var preimage [33]byte
+	if _, err := io.ReadFull(r, preimage[:]); err != nil {
+		return err
+	}
+	if preimage[0] == 1 {
+		f.KeysendPreimage = &[32]byte{}
+		copy(f.KeysendPreimage[:], preimage[1:])
+	}
+
+	if err := binary.Read(r, binary.BigEndian, &f.MPPTotalAmount); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -632,12 +658,19 @@ var _ ChannelLink = (*mockChannelLink)(nil)
 
 type mockInvoiceRegistry struct {
 	sync.Mutex
-	invoices map[chainhash.Hash]channeldb.Invoice
+	invoices        map[chainhash.Hash]channeldb.Invoice
+	hodlSubscribers map[chainhash.Hash][]chan<- HodlEvent
+	partialHtlcs    map[chainhash.Hash]map[CircuitKey]lnwire.MilliSatoshi
+	acceptKeysend   bool
 }
 
 func newMockRegistry() *mockInvoiceRegistry {
 	return &mockInvoiceRegistry{
-		invoices: make(map[chainhash.Hash]channeldb.Invoice),
+		invoices:        make(map[chainhash.Hash]channeldb.Invoice),
+		hodlSubscribers: make(map[chainhash.Hash][]chan<- HodlEvent),
+		partialHtlcs: make(
+			map[chainhash.Hash]map[CircuitKey]lnwire.MilliSatoshi,
+		),
 	}
 }
 
@@ -662,16 +695,175 @@ func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash) error {
 		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
 	}
 
-	if invoice.Terms.Settled {
+	switch invoice.Terms.State {
+	case channeldb.ContractSettled:
+		return nil
+	case channeldb.ContractCanceled:
+		return channeldb.ErrInvoiceAlreadyCanceled
+	case channeldb.ContractExpired:
+		return channeldb.ErrInvoiceExpired
+	}
+
+	invoice.Terms.State = channeldb.ContractSettled
+	i.invoices[rhash] = invoice
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AddKeysendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	if !i.acceptKeysend {
+		return fmt.Errorf("keysend payments not accepted")
+	}
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	if _, ok := i.invoices[rhash]; ok {
+		return nil
+	}
+
+	i.invoices[rhash] = channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           amt,
+			PaymentPreimage: preimage,
+		},
+	}
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AcceptInvoice(rhash chainhash.Hash,
+	subscriber chan<- HodlEvent) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	invoice.Terms.State = channeldb.ContractAccepted
+	i.invoices[rhash] = invoice
+
+	i.hodlSubscribers[rhash] = append(i.hodlSubscribers[rhash], subscriber)
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AddPartialHTLC(rhash chainhash.Hash,
+	circuitKey CircuitKey, amt lnwire.MilliSatoshi,
+	subscriber chan<- HodlEvent) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	htlcs, ok := i.partialHtlcs[rhash]
+	if !ok {
+		htlcs = make(map[CircuitKey]lnwire.MilliSatoshi)
+		i.partialHtlcs[rhash] = htlcs
+	}
+	htlcs[circuitKey] = amt
+
+	i.hodlSubscribers[rhash] = append(i.hodlSubscribers[rhash], subscriber)
+
+	var total lnwire.MilliSatoshi
+	for _, amt := range htlcs {
+		total += amt
+	}
+	if total < invoice.Terms.Value {
 		return nil
 	}
+	delete(i.partialHtlcs, rhash)
 
-	invoice.Terms.Settled = true
+	preimage := invoice.Terms.PaymentPreimage
+	invoice.Terms.State = channeldb.ContractSettled
 	i.invoices[rhash] = invoice
 
+	i.notifyHodlSubscribers(HodlEvent{Hash: rhash, Preimage: &preimage})
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) FailPartialHTLCs(rhash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	delete(i.partialHtlcs, rhash)
+	i.notifyHodlSubscribers(HodlEvent{Hash: rhash, MPPTimeout: true})
+
 	return nil
 }
 
+func (i *mockInvoiceRegistry) CancelInvoice(rhash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	invoice.Terms.State = channeldb.ContractCanceled
+	i.invoices[rhash] = invoice
+
+	i.notifyHodlSubscribers(HodlEvent{Hash: rhash})
+
+	return nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice paying to the hash of
+// the passed preimage, resolving the HTLCs that are being held for it.
+func (i *mockInvoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	i.Lock()
+	defer i.Unlock()
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	invoice.Terms.PaymentPreimage = preimage
+	invoice.Terms.State = channeldb.ContractSettled
+	i.invoices[rhash] = invoice
+
+	i.notifyHodlSubscribers(HodlEvent{Hash: rhash, Preimage: &preimage})
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) notifyHodlSubscribers(event HodlEvent) {
+	for _, subscriber := range i.hodlSubscribers[event.Hash] {
+		subscriber <- event
+	}
+	delete(i.hodlSubscribers, event.Hash)
+}
+
+func (i *mockInvoiceRegistry) HodlUnsubscribeAll(subscriber chan<- HodlEvent) {
+	i.Lock()
+	defer i.Unlock()
+
+	for hash, subscribers := range i.hodlSubscribers {
+		var remaining []chan<- HodlEvent
+		for _, s := range subscribers {
+			if s != subscriber {
+				remaining = append(remaining, s)
+			}
+		}
+		i.hodlSubscribers[hash] = remaining
+	}
+}
+
 func (i *mockInvoiceRegistry) AddInvoice(invoice channeldb.Invoice) error {
 	i.Lock()
 	defer i.Unlock()
@@ -682,6 +874,20 @@ func (i *mockInvoiceRegistry) AddInvoice(invoice channeldb.Invoice) error {
 	return nil
 }
 
+// AddHoldInvoice adds the passed invoice as a hold invoice, for which only the
+// payment hash is known.
+func (i *mockInvoiceRegistry) AddHoldInvoice(invoice channeldb.Invoice,
+	rhash chainhash.Hash) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice.Terms.PaymentPreimage = channeldb.UnknownPreimage
+	i.invoices[rhash] = invoice
+
+	return nil
+}
+
 var _ InvoiceDatabase = (*mockInvoiceRegistry)(nil)
 
 type mockSigner struct {
@@ -0,0 +1,336 @@
+package htlcswitch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/coreos/bbolt"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/multimutex"
+)
+
+var (
+	// networkResultStoreBucketKey is used for the root level bucket that
+	// stores the network result for each payment ID.
+	networkResultStoreBucketKey = []byte("network-result-store-bucket")
+
+	// ErrPaymentIDNotFound is an error returned if the given paymentID is
+	// not found, neither as an in-flight HTLC within the switch, nor as a
+	// result stored after the HTLC was resolved.
+	ErrPaymentIDNotFound = errors.New("paymentID not found")
+)
+
+// networkResult is the raw result received from the network after a payment
+// attempt has been made. Since the switch doesn't always have the necessary
+// data to decode the raw message, we store it together with some meta data,
+// and decode it when the router query for the final result.
+type networkResult struct {
+	// msg is the received result. This should be of type UpdateFulfillHTLC
+	// or UpdateFailHTLC.
+	msg lnwire.Message
+
+	// unencrypted indicates whether the failure encoded in the message is
+	// unencrypted, and hence doesn't need to be decrypted.
+	unencrypted bool
+
+	// isResolution indicates whether this is a resolution message, in
+	// which the failure reason might not be included.
+	isResolution bool
+}
+
+// serializeNetworkResult serializes the networkResult.
+func serializeNetworkResult(w io.Writer, n *networkResult) error {
+	var flags [2]byte
+	if n.unencrypted {
+		flags[0] = 1
+	}
+	if n.isResolution {
+		flags[1] = 1
+	}
+	if _, err := w.Write(flags[:]); err != nil {
+		return err
+	}
+
+	_, err := lnwire.WriteMessage(w, n.msg, 0)
+	return err
+}
+
+// deserializeNetworkResult deserializes the networkResult.
+func deserializeNetworkResult(r io.Reader) (*networkResult, error) {
+	var flags [2]byte
+	if _, err := io.ReadFull(r, flags[:]); err != nil {
+		return nil, err
+	}
+
+	msg, err := lnwire.ReadMessage(r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkResult{
+		msg:          msg,
+		unencrypted:  flags[0] == 1,
+		isResolution: flags[1] == 1,
+	}, nil
+}
+
+// networkResultStore is a persistent store that stores any results of HTLCs
+// in flight on the network. Since payment results are inherently
+// asynchronous, it is used as a common access point for senders of HTLCs, to
+// know when a result is back. The Switch will checkpoint any received result
+// to the store, and the store will keep results and notify the callers about
+// them, even across restarts.
+type networkResultStore struct {
+	db *channeldb.DB
+
+	// results is a map from paymentIDs to channels where subscribers to
+	// payment results will be notified.
+	results    map[uint64][]chan *networkResult
+	resultsMtx sync.Mutex
+
+	// paymentIDMtx is a multimutex used to make sure the database and
+	// result subscribers map is consistent for each payment ID in case of
+	// concurrent callers.
+	paymentIDMtx *multimutex.Mutex
+}
+
+// newNetworkResultStore creates a new networkResultStore backed by the given
+// database.
+func newNetworkResultStore(db *channeldb.DB) *networkResultStore {
+	return &networkResultStore{
+		db:           db,
+		results:      make(map[uint64][]chan *networkResult),
+		paymentIDMtx: multimutex.NewMutex(),
+	}
+}
+
+// storeResult stores the networkResult for the given paymentID, and notifies
+// any subscribers.
+func (store *networkResultStore) storeResult(paymentID uint64,
+	result *networkResult) error {
+
+	// We get a mutex for this payment ID. This is needed to ensure
+	// consistency between the database state and the subscribers in case
+	// of concurrent calls.
+	store.paymentIDMtx.Lock(paymentID)
+	defer store.paymentIDMtx.Unlock(paymentID)
+
+	// Serialize the payment result.
+	var b bytes.Buffer
+	if err := serializeNetworkResult(&b, result); err != nil {
+		return err
+	}
+
+	var paymentIDBytes [8]byte
+	binary.BigEndian.PutUint64(paymentIDBytes[:], paymentID)
+
+	err := store.db.Batch(func(tx *bolt.Tx) error {
+		networkResults, err := tx.CreateBucketIfNotExists(
+			networkResultStoreBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		return networkResults.Put(paymentIDBytes[:], b.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	// Now that the result is stored in the database, we can notify any
+	// active subscribers.
+	store.resultsMtx.Lock()
+	for _, res := range store.results[paymentID] {
+		res <- result
+	}
+	delete(store.results, paymentID)
+	store.resultsMtx.Unlock()
+
+	return nil
+}
+
+// subscribeResult is used to get the payment result for the given payment
+// ID. It returns a channel on which the result will be delivered when ready.
+// If the result is already stored, it will be delivered on the channel
+// immediately.
+func (store *networkResultStore) subscribeResult(paymentID uint64) (
+	<-chan *networkResult, error) {
+
+	// We get a mutex for this payment ID. This is needed to ensure
+	// consistency between the database state and the subscribers in case
+	// of concurrent calls.
+	store.paymentIDMtx.Lock(paymentID)
+	defer store.paymentIDMtx.Unlock(paymentID)
+
+	var (
+		result     *networkResult
+		resultChan = make(chan *networkResult, 1)
+	)
+
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		result, err = fetchResult(tx, paymentID)
+		switch {
+
+		// Result not yet available, we will notify once a result is
+		// available.
+		case err == ErrPaymentIDNotFound:
+			return nil
+
+		case err != nil:
+			return err
+
+		// The result was found, and will be returned immediately.
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// If the result was found, we can send it on the result channel
+	// immediately.
+	if result != nil {
+		resultChan <- result
+		return resultChan, nil
+	}
+
+	// Otherwise we store the result channel for when the result is
+	// available.
+	store.resultsMtx.Lock()
+	store.results[paymentID] = append(
+		store.results[paymentID], resultChan,
+	)
+	store.resultsMtx.Unlock()
+
+	return resultChan, nil
+}
+
+// unsubscribeResult removes the passed result channel, as returned by
+// subscribeResult, from the subscribers to the result of the given payment
+// ID. It's used when the caller no longer waits for the result.
+func (store *networkResultStore) unsubscribeResult(paymentID uint64,
+	resultChan <-chan *networkResult) {
+
+	store.resultsMtx.Lock()
+	defer store.resultsMtx.Unlock()
+
+	subscribers := store.results[paymentID]
+	for i, res := range subscribers {
+		if res != resultChan {
+			continue
+		}
+
+		subscribers = append(subscribers[:i], subscribers[i+1:]...)
+		break
+	}
+
+	if len(subscribers) == 0 {
+		delete(store.results, paymentID)
+		return
+	}
+	store.results[paymentID] = subscribers
+}
+
+// getResult attempts to immediately fetch the result for the given payment
+// ID from the store. If no result is available, ErrPaymentIDNotFound is
+// returned.
+func (store *networkResultStore) getResult(paymentID uint64) (
+	*networkResult, error) {
+
+	var result *networkResult
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		result, err = fetchResult(tx, paymentID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// numSubscribers returns the total number of subscribers currently waiting
+// for a result.
+func (store *networkResultStore) numSubscribers() int {
+	store.resultsMtx.Lock()
+	defer store.resultsMtx.Unlock()
+
+	var num int
+	for _, subscribers := range store.results {
+		num += len(subscribers)
+	}
+
+	return num
+}
+
+func fetchResult(tx *bolt.Tx, pid uint64) (*networkResult, error) {
+	var paymentIDBytes [8]byte
+	binary.BigEndian.PutUint64(paymentIDBytes[:], pid)
+
+	networkResults := tx.Bucket(networkResultStoreBucketKey)
+	if networkResults == nil {
+		return nil, ErrPaymentIDNotFound
+	}
+
+	// Check whether a result is already available.
+	resultBytes := networkResults.Get(paymentIDBytes[:])
+	if resultBytes == nil {
+		return nil, ErrPaymentIDNotFound
+	}
+
+	// Decode the result we found.
+	r := bytes.NewReader(resultBytes)
+
+	return deserializeNetworkResult(r)
+}
+
+// cleanStore removes all entries from the store, except the payment IDs
+// given. NOTE: Since every result not listed in the keep map will be deleted,
+// care should be taken to ensure no new payment attempts are being made
+// concurrently while this process is ongoing, as its result might end up
+// being deleted.
+func (store *networkResultStore) cleanStore(keep map[uint64]struct{}) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		networkResults, err := tx.CreateBucketIfNotExists(
+			networkResultStoreBucketKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		var toClean [][]byte
+		if err := networkResults.ForEach(func(k, _ []byte) error {
+			pid := binary.BigEndian.Uint64(k)
+			if _, ok := keep[pid]; ok {
+				return nil
+			}
+
+			toClean = append(toClean, k)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, k := range toClean {
+			err := networkResults.Delete(k)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(toClean) > 0 {
+			log.Infof("Removed %d stale entries from network "+
+				"result store", len(toClean))
+		}
+
+		return nil
+	})
+}
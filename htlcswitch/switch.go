@@ -36,28 +36,17 @@ var (
 	// txn.
 	ErrIncompleteForward = errors.Errorf("incomplete forward detected")
 
+	// ErrSwitchExiting signaled when the switch has received a shutdown
+	// request while waiting for the result of a payment. The payment may
+	// still be in flight, and its result can be retrieved after the
+	// switch has been restarted.
+	ErrSwitchExiting = errors.New("htlc switch exiting")
+
 	// zeroPreimage is the empty preimage which is returned when we have
 	// some errors.
 	zeroPreimage [sha256.Size]byte
 )
 
-// pendingPayment represents the payment which made by user and waits for
-// updates to be received whether the payment has been rejected or proceed
-// successfully.
-type pendingPayment struct {
-	paymentHash lnwallet.PaymentHash
-	amount      lnwire.MilliSatoshi
-
-	preimage chan [sha256.Size]byte
-	response chan *htlcPacket
-	err      chan error
-
-	// deobfuscator is an serializable entity which is used if we received
-	// an error, it deobfuscates the onion failure blob, and extracts the
-	// exact error from it.
-	deobfuscator ErrorDecrypter
-}
-
 // plexPacket encapsulates switch packet and adds error channel to receive
 // error from request handler.
 type plexPacket struct {
@@ -155,12 +144,12 @@ type Switch struct {
 	// service was initialized with.
 	cfg *Config
 
-	// pendingPayments stores payments initiated by the user that are not yet
-	// settled. The map is used to later look up the payments and notify the
-	// user of the result when they are complete. Each payment is given a unique
-	// integer ID when it is created.
-	pendingPayments map[uint64]*pendingPayment
-	pendingMutex    sync.RWMutex
+	// networkResults stores the results of payments initiated by the user.
+	// The store is used to later look up the payments and notify the
+	// user of the result when they are complete. Each payment attempt
+	// should be given a unique integer ID when it is created, otherwise
+	// results might be overwritten.
+	networkResults *networkResultStore
 
 	paymentSequencer Sequencer
 
@@ -240,7 +229,7 @@ func New(cfg Config) (*Switch, error) {
 		mailboxes:         make(map[lnwire.ShortChannelID]MailBox),
 		forwardingIndex:   make(map[lnwire.ShortChannelID]ChannelLink),
 		interfaceIndex:    make(map[[33]byte]map[ChannelLink]struct{}),
-		pendingPayments:   make(map[uint64]*pendingPayment),
+		networkResults:    newNetworkResultStore(cfg.DB),
 		htlcPlex:          make(chan *plexPacket),
 		chanCloseRequests: make(chan *ChanClose),
 		resolutionMsgs:    make(chan *resolutionMsg),
@@ -285,94 +274,110 @@ func (s *Switch) ProcessContractResolution(msg contractcourt.ResolutionMsg) erro
 	return nil
 }
 
+// NextPaymentID returns a unique ID that can be used to identify a locally
+// initiated payment attempt within the switch. IDs are persisted, such that
+// they remain unique across restarts.
+func (s *Switch) NextPaymentID() (uint64, error) {
+	return s.paymentSequencer.NextID()
+}
+
 // SendHTLC is used by other subsystems which aren't belong to htlc switch
-// package in order to send the htlc update.
-func (s *Switch) SendHTLC(nextNode [33]byte, htlc *lnwire.UpdateAddHTLC,
+// package in order to send the htlc update. The paymentID used MUST be unique
+// for this HTLC, and MUST be used only once, otherwise the switch might reject
+// it. The call blocks until the result of the HTLC is known, or the switch is
+// shutting down. If the caller goes away before the result is known, the
+// result can later be retrieved using GetPaymentResult.
+func (s *Switch) SendHTLC(firstHop [33]byte, paymentID uint64,
+	htlc *lnwire.UpdateAddHTLC,
 	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
 
-	// Create payment and add to the map of payment in order later to be
-	// able to retrieve it and return response to the user.
-	payment := &pendingPayment{
-		err:          make(chan error, 1),
-		response:     make(chan *htlcPacket, 1),
-		preimage:     make(chan [sha256.Size]byte, 1),
-		paymentHash:  htlc.PaymentHash,
-		amount:       htlc.Amount,
-		deobfuscator: deobfuscator,
-	}
-
-	paymentID, err := s.paymentSequencer.NextID()
+	// Before sending, we'll subscribe to the result of this payment ID,
+	// such that the result can't slip by us once the HTLC is on its way.
+	resultChan, err := s.networkResults.subscribeResult(paymentID)
 	if err != nil {
 		return zeroPreimage, err
 	}
 
-	s.pendingMutex.Lock()
-	s.pendingPayments[paymentID] = payment
-	s.pendingMutex.Unlock()
-
 	// Generate and send new update packet, if error will be received on
 	// this stage it means that packet haven't left boundaries of our
 	// system and something wrong happened.
 	packet := &htlcPacket{
 		incomingChanID: sourceHop,
 		incomingHTLCID: paymentID,
-		destNode:       nextNode,
+		destNode:       firstHop,
 		htlc:           htlc,
 	}
 
+	// If the HTLC couldn't be forwarded, then no result will ever be
+	// stored for it, so we'll no longer wait for one.
 	if err := s.forward(packet); err != nil {
-		s.removePendingPayment(paymentID)
+		s.networkResults.unsubscribeResult(paymentID, resultChan)
 		return zeroPreimage, err
 	}
 
-	// Returns channels so that other subsystem might wait/skip the
-	// waiting of handling of payment.
-	var preimage [sha256.Size]byte
-	var response *htlcPacket
+	return s.waitForResult(
+		paymentID, htlc.PaymentHash, deobfuscator, resultChan,
+	)
+}
 
-	select {
-	case e := <-payment.err:
-		err = e
-	case <-s.quit:
-		return zeroPreimage, errors.New("htlc switch have been stopped " +
-			"while waiting for payment result")
-	}
+// GetPaymentResult returns the result of a payment attempt previously handed
+// to the switch with the given paymentID. The call blocks until the result is
+// known, or the switch is shutting down. If the paymentID is unknown to the
+// switch, meaning the HTLC isn't in flight and no result for it has been
+// stored, ErrPaymentIDNotFound is returned.
+func (s *Switch) GetPaymentResult(paymentID uint64,
+	paymentHash lnwallet.PaymentHash,
+	deobfuscator ErrorDecrypter) ([sha256.Size]byte, error) {
 
-	select {
-	case pkt := <-payment.response:
-		response = pkt
-	case <-s.quit:
-		return zeroPreimage, errors.New("htlc switch have been stopped " +
-			"while waiting for payment result")
+	// If the HTLC is no longer in the circuit map, then the result must
+	// already have been stored, as the circuit is only torn down after
+	// the result has been written to the store.
+	inKey := CircuitKey{
+		ChanID: sourceHop,
+		HtlcID: paymentID,
 	}
+	if s.circuits.LookupCircuit(inKey) == nil {
+		result, err := s.networkResults.getResult(paymentID)
+		if err != nil {
+			return zeroPreimage, err
+		}
 
-	select {
-	case p := <-payment.preimage:
-		preimage = p
-	case <-s.quit:
-		return zeroPreimage, errors.New("htlc switch have been stopped " +
-			"while waiting for payment result")
+		return s.extractResult(deobfuscator, result, paymentID,
+			paymentHash)
 	}
 
-	// Remove circuit since we are about to complete an add/fail of this
-	// HTLC.
-	if teardownErr := s.teardownCircuit(response); teardownErr != nil {
-		log.Warnf("unable to teardown circuit %s: %v",
-			response.inKey(), teardownErr)
-		return preimage, err
+	// Otherwise the HTLC is still in flight, so we'll wait for its result
+	// to be received.
+	resultChan, err := s.networkResults.subscribeResult(paymentID)
+	if err != nil {
+		return zeroPreimage, err
 	}
 
-	// Finally, if this response is contained in a forwarding package, ack
-	// the settle/fail so that we don't continue to retransmit the HTLC
-	// internally.
-	if response.destRef != nil {
-		if ackErr := s.ackSettleFail(*response.destRef); ackErr != nil {
-			log.Warnf("unable to ack settle/fail reference: %s: %v",
-				*response.destRef, ackErr)
-		}
+	return s.waitForResult(paymentID, paymentHash, deobfuscator, resultChan)
+}
+
+// waitForResult waits for the network result of the given payment to be
+// delivered on the passed channel, and decodes it.
+func (s *Switch) waitForResult(paymentID uint64,
+	paymentHash lnwallet.PaymentHash, deobfuscator ErrorDecrypter,
+	resultChan <-chan *networkResult) ([sha256.Size]byte, error) {
+
+	select {
+	case result := <-resultChan:
+		return s.extractResult(deobfuscator, result, paymentID,
+			paymentHash)
+
+	case <-s.quit:
+		return zeroPreimage, ErrSwitchExiting
 	}
+}
 
-	return preimage, err
+// CleanStore calls the underlying result store, telling it is safe to delete
+// all entries except the ones in the keepPids map. This should be called
+// periodically to let the switch clean up payment results that we have
+// handled.
+func (s *Switch) CleanStore(keepPids map[uint64]struct{}) error {
+	return s.networkResults.cleanStore(keepPids)
 }
 
 // UpdateForwardingPolicies sends a message to the switch to update the
@@ -704,11 +709,6 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 	// incomingHTLCID fields on packet where the channel ID is blank and the
 	// HTLC ID is the payment ID. The switch basically views the users of the
 	// node as a special channel that also offers a sequence of HTLCs.
-	payment, err := s.findPayment(pkt.incomingHTLCID)
-	if err != nil {
-		return err
-	}
-
 	switch htlc := pkt.htlc.(type) {
 
 	// User have created the htlc update therefore we should find the
@@ -773,28 +773,109 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 		pkt.outgoingChanID = destination.ShortChanID()
 		return destination.HandleSwitchPacket(pkt)
 
-	// We've just received a settle update which means we can finalize the
-	// user payment and return successful response.
+	// We've just received a settle or fail update which means we can
+	// finalize the user payment and return the response.
+	case *lnwire.UpdateFulfillHTLC, *lnwire.UpdateFailHTLC:
+		s.wg.Add(1)
+		go s.handleLocalResponse(pkt)
+
+	default:
+		return errors.New("wrong update type")
+	}
+
+	return nil
+}
+
+// handleLocalResponse processes a Settle or Fail responding to a
+// locally-initiated payment. This is handled asynchronously to avoid blocking
+// the main event loop within the switch, as these operations can require
+// multiple db transactions. The guarantees of the circuit map are stringent
+// enough such that we are able to tolerate reordering of these operations
+// without side effects. The primary operations handled are:
+//  1. Save the payment result to the pending payment store.
+//  2. Notify subscribers about the payment result.
+//  3. Ack settle/fail references, to avoid resending this response internally
+//  4. Teardown the closing circuit in the circuit map
+//
+// NOTE: This method MUST be spawned as a goroutine.
+func (s *Switch) handleLocalResponse(pkt *htlcPacket) {
+	defer s.wg.Done()
+
+	paymentID := pkt.incomingHTLCID
+
+	// The error reason will be unencrypted in case this a local
+	// failure or a converted error.
+	unencrypted := pkt.localFailure
+	n := &networkResult{
+		msg:          pkt.htlc,
+		unencrypted:  unencrypted,
+		isResolution: pkt.isResolution,
+	}
+
+	// Store the result to the db. This will also notify subscribers about
+	// the result.
+	if err := s.networkResults.storeResult(paymentID, n); err != nil {
+		log.Errorf("Unable to complete payment for pid=%v: %v",
+			paymentID, err)
+		return
+	}
+
+	// First, we'll clean up any fwdpkg references, circuit entries, and
+	// mark in our db that the payment for this payment hash has either
+	// succeeded or failed.
+	//
+	// If this response is contained in a forwarding package, we'll start
+	// by acking the settle/fail so that we don't continue to retransmit
+	// the HTLC internally.
+	if pkt.destRef != nil {
+		if err := s.ackSettleFail(*pkt.destRef); err != nil {
+			log.Warnf("Unable to ack settle/fail reference: %s: %v",
+				*pkt.destRef, err)
+			return
+		}
+	}
+
+	// Next, we'll remove the circuit since we are about to complete an
+	// fulfill/fail of this HTLC. Since we've already removed the
+	// settle/fail fwdpkg reference, the response from the peer cannot be
+	// replayed internally if this step fails. If this happens, this logic
+	// will be executed when a provided resolution message comes through.
+	// This can only happen if the circuit is still open, which is why this
+	// ordering is chosen.
+	if err := s.teardownCircuit(pkt); err != nil {
+		log.Warnf("Unable to teardown circuit %s: %v",
+			pkt.inKey(), err)
+		return
+	}
+}
+
+// extractResult uses the given deobfuscator to extract the payment result
+// from the given network message.
+func (s *Switch) extractResult(deobfuscator ErrorDecrypter, n *networkResult,
+	paymentID uint64, paymentHash lnwallet.PaymentHash) ([sha256.Size]byte,
+	error) {
+
+	switch htlc := n.msg.(type) {
+
+	// We've received a settle update which means we can finalize the user
+	// payment and return successful response.
 	case *lnwire.UpdateFulfillHTLC:
-		// Notify the user that his payment was successfully proceed.
-		payment.err <- nil
-		payment.response <- pkt
-		payment.preimage <- htlc.PaymentPreimage
-		s.removePendingPayment(pkt.incomingHTLCID)
+		return htlc.PaymentPreimage, nil
 
-	// We've just received a fail update which means we can finalize the
+	// We've received a fail update which means we can finalize the
 	// user payment and return fail response.
 	case *lnwire.UpdateFailHTLC:
-		payment.err <- s.parseFailedPayment(payment, pkt, htlc)
-		payment.response <- pkt
-		payment.preimage <- zeroPreimage
-		s.removePendingPayment(pkt.incomingHTLCID)
+		paymentErr := s.parseFailedPayment(
+			deobfuscator, paymentID, paymentHash, n.unencrypted,
+			n.isResolution, htlc,
+		)
+
+		return zeroPreimage, paymentErr
 
 	default:
-		return errors.New("wrong update type")
+		return zeroPreimage, fmt.Errorf("received unknown response "+
+			"type: %T", htlc)
 	}
-
-	return nil
 }
 
 // parseFailedPayment determines the appropriate failure message to return to
@@ -803,8 +884,9 @@ func (s *Switch) handleLocalDispatch(pkt *htlcPacket) error {
 // 2) A resolution from the chain arbitrator,
 // 3) A failure from the remote party, which will need to be decrypted using the
 //      payment deobfuscator.
-func (s *Switch) parseFailedPayment(payment *pendingPayment, pkt *htlcPacket,
-	htlc *lnwire.UpdateFailHTLC) *ForwardingError {
+func (s *Switch) parseFailedPayment(deobfuscator ErrorDecrypter,
+	paymentID uint64, paymentHash lnwallet.PaymentHash, unencrypted,
+	isResolution bool, htlc *lnwire.UpdateFailHTLC) *ForwardingError {
 
 	var failure *ForwardingError
 
@@ -813,13 +895,14 @@ func (s *Switch) parseFailedPayment(payment *pendingPayment, pkt *htlcPacket,
 	// The payment never cleared the link, so we don't need to
 	// decrypt the error, simply decode it them report back to the
 	// user.
-	case pkt.localFailure:
+	case unencrypted:
 		var userErr string
 		r := bytes.NewReader(htlc.Reason)
 		failureMsg, err := lnwire.DecodeFailure(r, 0)
 		if err != nil {
-			userErr = fmt.Sprintf("unable to decode onion failure, "+
-				"htlc with hash(%x): %v", payment.paymentHash[:], err)
+			userErr = fmt.Sprintf("unable to decode onion "+
+				"failure (hash=%x, pid=%d): %v",
+				paymentHash, paymentID, err)
 			log.Error(userErr)
 			failureMsg = lnwire.NewTemporaryChannelFailure(nil)
 		}
@@ -833,7 +916,7 @@ func (s *Switch) parseFailedPayment(payment *pendingPayment, pkt *htlcPacket,
 	// the first hop. In this case, we'll report a permanent
 	// channel failure as this means us, or the remote party had to
 	// go on chain.
-	case pkt.isResolution && htlc.Reason == nil:
+	case isResolution && htlc.Reason == nil:
 		userErr := fmt.Sprintf("payment was resolved " +
 			"on-chain, then cancelled back")
 		failure = &ForwardingError{
@@ -848,10 +931,11 @@ func (s *Switch) parseFailedPayment(payment *pendingPayment, pkt *htlcPacket,
 		var err error
 		// We'll attempt to fully decrypt the onion encrypted
 		// error. If we're unable to then we'll bail early.
-		failure, err = payment.deobfuscator.DecryptError(htlc.Reason)
+		failure, err = deobfuscator.DecryptError(htlc.Reason)
 		if err != nil {
-			userErr := fmt.Sprintf("unable to de-obfuscate onion failure, "+
-				"htlc with hash(%x): %v", payment.paymentHash[:], err)
+			userErr := fmt.Sprintf("unable to de-obfuscate onion "+
+				"failure (hash=%x, pid=%d): %v",
+				paymentHash, paymentID, err)
 			log.Error(userErr)
 			failure = &ForwardingError{
 				ErrorSource:    s.cfg.SelfKey,
@@ -1942,34 +2026,6 @@ func (s *Switch) getLinks(destination [33]byte) ([]ChannelLink, error) {
 	return channelLinks, nil
 }
 
-// removePendingPayment is the helper function which removes the pending user
-// payment.
-func (s *Switch) removePendingPayment(paymentID uint64) error {
-	s.pendingMutex.Lock()
-	defer s.pendingMutex.Unlock()
-
-	if _, ok := s.pendingPayments[paymentID]; !ok {
-		return errors.Errorf("Cannot find pending payment with ID %d",
-			paymentID)
-	}
-
-	delete(s.pendingPayments, paymentID)
-	return nil
-}
-
-// findPayment is the helper function which find the payment.
-func (s *Switch) findPayment(paymentID uint64) (*pendingPayment, error) {
-	s.pendingMutex.RLock()
-	defer s.pendingMutex.RUnlock()
-
-	payment, ok := s.pendingPayments[paymentID]
-	if !ok {
-		return nil, errors.Errorf("Cannot find pending payment with ID %d",
-			paymentID)
-	}
-	return payment, nil
-}
-
 // CircuitModifier returns a reference to subset of the interfaces provided by
 // the circuit map, to allow links to open and close circuits.
 func (s *Switch) CircuitModifier() CircuitModifier {
@@ -1979,7 +2035,7 @@ func (s *Switch) CircuitModifier() CircuitModifier {
 // numPendingPayments is helper function which returns the overall number of
 // pending user payments.
 func (s *Switch) numPendingPayments() int {
-	return len(s.pendingPayments)
+	return s.networkResults.numSubscribers()
 }
 
 // commitCircuits persistently adds a circuit to the switch's circuit map.
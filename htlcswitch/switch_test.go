@@ -1178,7 +1178,7 @@ func TestSkipIneligibleLinksLocalForward(t *testing.T) {
 	// outgoing link. This should fail as Alice isn't yet able to forward
 	// any active HTLC's.
 	alicePub := aliceChannelLink.Peer().PubKey()
-	_, err = s.SendHTLC(alicePub, addMsg, nil)
+	_, err = s.SendHTLC(alicePub, 0, addMsg, nil)
 	if err == nil {
 		t.Fatalf("local forward should fail due to inactive link")
 	}
@@ -1188,6 +1188,49 @@ func TestSkipIneligibleLinksLocalForward(t *testing.T) {
 	}
 }
 
+// TestSwitchSendHTLCForwardFailure checks that a payment whose HTLC can't be
+// forwarded doesn't leave a subscriber to its result behind.
+func TestSwitchSendHTLCForwardFailure(t *testing.T) {
+	t.Parallel()
+
+	s, err := initSwitchWithDB(nil)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	preimage, err := genPreimage()
+	if err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rhash := fastsha256.Sum256(preimage[:])
+	addMsg := &lnwire.UpdateAddHTLC{
+		PaymentHash: rhash,
+		Amount:      1,
+	}
+
+	// The switch has no links, so forwarding the HTLC to any first hop
+	// should fail.
+	var firstHop [33]byte
+	for i := uint64(0); i < 3; i++ {
+		_, err = s.SendHTLC(firstHop, i, addMsg, nil)
+		if err == nil {
+			t.Fatalf("forward without links should fail")
+		}
+	}
+
+	if s.numPendingPayments() != 0 {
+		t.Fatalf("expected no pending payments, got %v",
+			s.numPendingPayments())
+	}
+	if s.circuits.NumOpen() != 0 {
+		t.Fatal("wrong amount of circuits")
+	}
+}
+
 // TestSwitchCancel checks that if htlc was rejected we remove unused
 // circuits.
 func TestSwitchCancel(t *testing.T) {
@@ -1503,16 +1546,16 @@ func TestSwitchSendPayment(t *testing.T) {
 	// Handle the request and checks that bob channel link received it.
 	errChan := make(chan error)
 	go func() {
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), 0,
+			update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
 	go func() {
 		// Send the payment with the same payment hash and same
 		// amount and check that it will be propagated successfully
-		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), update,
-			newMockDeobfuscator())
+		_, err := s.SendHTLC(aliceChannelLink.Peer().PubKey(), 1,
+			update, newMockDeobfuscator())
 		errChan <- err
 	}()
 
@@ -717,7 +717,13 @@ func (n *threeHopNetwork) makePayment(sendingPeer, receivingPeer Peer,
 
 	// Send payment and expose err channel.
 	go func() {
-		_, err := sender.htlcSwitch.SendHTLC(firstHopPub, htlc,
+		pid, err := sender.htlcSwitch.NextPaymentID()
+		if err != nil {
+			paymentErr <- err
+			return
+		}
+
+		_, err = sender.htlcSwitch.SendHTLC(firstHopPub, pid, htlc,
 			newMockDeobfuscator())
 		paymentErr <- err
 	}()
@@ -3,16 +3,32 @@ package main
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 )
 
+const (
+	// invoiceExpiryInterval is the interval at which the invoice registry
+	// checks whether the payment requests of any of the open invoices
+	// have expired.
+	invoiceExpiryInterval = time.Minute
+
+	// mppTimeout is the time we'll hold on to the partial HTLCs of a
+	// multi-path payment for the rest of the payment to arrive. If the
+	// partial HTLCs don't cover the invoice by then, they're failed back.
+	mppTimeout = time.Minute
+)
+
 var (
 	// debugPre is the default debug preimage which is inserted into the
 	// invoice registry if the --debughtlc flag is activated on start up.
@@ -22,16 +38,28 @@ var (
 	debugPre, _ = chainhash.NewHash(bytes.Repeat([]byte{1}, 32))
 
 	debugHash = chainhash.Hash(sha256.Sum256(debugPre[:]))
+
+	// errKeysendDisabled is returned when a keysend payment is received,
+	// but the daemon isn't configured to accept keysend payments.
+	errKeysendDisabled = errors.New("keysend payments not accepted")
 )
 
 // invoiceRegistry is a central registry of all the outstanding invoices
 // created by the daemon. The registry is a thin wrapper around a map in order
 // to ensure that all updates/reads are thread safe.
 type invoiceRegistry struct {
+	started uint32
+	stopped uint32
+
 	sync.RWMutex
 
 	cdb *channeldb.DB
 
+	// acceptKeysend signals whether keysend payments, which are made
+	// without an invoice, are accepted by creating an invoice for each of
+	// them on the fly.
+	acceptKeysend bool
+
 	clientMtx           sync.Mutex
 	nextClientID        uint32
 	notificationClients map[uint32]*invoiceSubscription
@@ -40,17 +68,205 @@ type invoiceRegistry struct {
 	// should be only created/used when manual tests require an invoice
 	// that *all* nodes are able to fully settle.
 	debugInvoices map[chainhash.Hash]*channeldb.Invoice
+
+	// hodlSubscriptions maps the payment hash of each accepted hold
+	// invoice to the set of subscribers that are holding HTLCs paying to
+	// it, and hodlReverseSubscriptions tracks the hashes each subscriber
+	// is subscribed to. Both are guarded by the hodlMtx.
+	hodlMtx                  sync.Mutex
+	hodlSubscriptions        map[chainhash.Hash]map[chan<- htlcswitch.HodlEvent]struct{}
+	hodlReverseSubscriptions map[chan<- htlcswitch.HodlEvent]map[chainhash.Hash]struct{}
+
+	// mppSets maps the payment hash of each open invoice to the set of
+	// partial HTLCs paying to it that is currently being held. It's
+	// guarded by the hodlMtx, as the HTLCs are resolved through the hodl
+	// subscriptions.
+	mppSets map[chainhash.Hash]*mppSet
+
+	// expiries maps the payment hash of each open invoice to the time at
+	// which its payment request expires. Once that time has passed, the
+	// invoice expiry watcher marks the invoice as expired, causing any
+	// HTLCs paying to it to be rejected.
+	expiryMtx sync.Mutex
+	expiries  map[chainhash.Hash]time.Time
+
+	wg   sync.WaitGroup
+	quit chan struct{}
 }
 
 // newInvoiceRegistry creates a new invoice registry. The invoice registry
 // wraps the persistent on-disk invoice storage with an additional in-memory
 // layer. The in-memory layer is in place such that debug invoices can be added
-// which are volatile yet available system wide within the daemon.
-func newInvoiceRegistry(cdb *channeldb.DB) *invoiceRegistry {
+// which are volatile yet available system wide within the daemon. If
+// acceptKeysend is set, then keysend payments are accepted.
+func newInvoiceRegistry(cdb *channeldb.DB, acceptKeysend bool) *invoiceRegistry {
 	return &invoiceRegistry{
 		cdb:                 cdb,
+		acceptKeysend:       acceptKeysend,
 		debugInvoices:       make(map[chainhash.Hash]*channeldb.Invoice),
 		notificationClients: make(map[uint32]*invoiceSubscription),
+		hodlSubscriptions: make(
+			map[chainhash.Hash]map[chan<- htlcswitch.HodlEvent]struct{},
+		),
+		hodlReverseSubscriptions: make(
+			map[chan<- htlcswitch.HodlEvent]map[chainhash.Hash]struct{},
+		),
+		mppSets:  make(map[chainhash.Hash]*mppSet),
+		expiries: make(map[chainhash.Hash]time.Time),
+		quit:     make(chan struct{}),
+	}
+}
+
+// mppSet is the set of partial HTLCs of a multi-path payment that is being
+// held for an invoice, until either the HTLCs cover the invoice or the set
+// times out.
+type mppSet struct {
+	// htlcs maps the circuit key of each of the partial HTLCs to its
+	// amount. As the HTLCs are identified by their circuit key, an HTLC
+	// that's added again after the link holding it restarts is only
+	// counted once.
+	htlcs map[channeldb.CircuitKey]lnwire.MilliSatoshi
+
+	// timer fails back the HTLCs of the set once it fires.
+	timer *time.Timer
+}
+
+// total returns the sum of the amounts of the HTLCs within the set.
+func (m *mppSet) total() lnwire.MilliSatoshi {
+	var total lnwire.MilliSatoshi
+	for _, amt := range m.htlcs {
+		total += amt
+	}
+
+	return total
+}
+
+// Start loads the expiry times of all pending invoices, and launches the
+// invoice expiry watcher.
+func (i *invoiceRegistry) Start() error {
+	if !atomic.CompareAndSwapUint32(&i.started, 0, 1) {
+		return nil
+	}
+
+	pendingInvoices, err := i.cdb.FetchAllInvoices(true)
+	if err != nil && err != channeldb.ErrNoInvoicesCreated {
+		return err
+	}
+
+	for _, invoice := range pendingInvoices {
+		i.addExpiry(invoice)
+	}
+
+	i.wg.Add(1)
+	go i.invoiceExpiryWatcher()
+
+	return nil
+}
+
+// Stop signals the invoice expiry watcher to exit, and waits for it to do so.
+func (i *invoiceRegistry) Stop() {
+	if !atomic.CompareAndSwapUint32(&i.stopped, 0, 1) {
+		return
+	}
+
+	close(i.quit)
+	i.wg.Wait()
+
+	i.hodlMtx.Lock()
+	for _, set := range i.mppSets {
+		set.timer.Stop()
+	}
+	i.hodlMtx.Unlock()
+}
+
+// addExpiry starts tracking the expiry of the passed open invoice, based on
+// the timestamp and expiry of its payment request. Invoices without a payment
+// request never expire.
+func (i *invoiceRegistry) addExpiry(invoice *channeldb.Invoice) {
+	if len(invoice.PaymentRequest) == 0 ||
+		invoice.Terms.State != channeldb.ContractOpen {
+
+		return
+	}
+
+	payReq, err := zpay32.Decode(
+		string(invoice.PaymentRequest), activeNetParams.Params,
+	)
+	if err != nil {
+		ltndLog.Errorf("Unable to decode payment request: %v", err)
+		return
+	}
+
+	expiry := payReq.Timestamp.Add(payReq.Expiry())
+
+	i.expiryMtx.Lock()
+	i.expiries[*payReq.PaymentHash] = expiry
+	i.expiryMtx.Unlock()
+}
+
+// invoiceExpiryWatcher periodically marks the open invoices whose payment
+// request has expired as expired.
+//
+// NOTE: This MUST be run as a goroutine.
+func (i *invoiceRegistry) invoiceExpiryWatcher() {
+	defer i.wg.Done()
+
+	ticker := time.NewTicker(invoiceExpiryInterval)
+	defer ticker.Stop()
+
+	// Expire any invoices that expired while we were offline before
+	// waiting for the first tick.
+	i.expireInvoices(time.Now())
+
+	for {
+		select {
+		case now := <-ticker.C:
+			i.expireInvoices(now)
+
+		case <-i.quit:
+			return
+		}
+	}
+}
+
+// expireInvoices marks all tracked invoices that have expired by the passed
+// time as expired, and notifies the invoice clients of them.
+func (i *invoiceRegistry) expireInvoices(now time.Time) {
+	var expired []chainhash.Hash
+
+	i.expiryMtx.Lock()
+	for rHash, expiry := range i.expiries {
+		if now.Before(expiry) {
+			continue
+		}
+
+		expired = append(expired, rHash)
+		delete(i.expiries, rHash)
+	}
+	i.expiryMtx.Unlock()
+
+	for _, rHash := range expired {
+		invoice, err := i.cdb.ExpireInvoice(rHash)
+		switch err {
+		case nil:
+
+		// The invoice is no longer open, so it's resolved by other
+		// means than its expiry.
+		case channeldb.ErrInvoiceAlreadyAccepted,
+			channeldb.ErrInvoiceAlreadySettled,
+			channeldb.ErrInvoiceAlreadyCanceled:
+
+			continue
+
+		default:
+			ltndLog.Errorf("Unable to expire invoice %x: %v",
+				rHash[:], err)
+			continue
+		}
+
+		ltndLog.Infof("Invoice %x expired", rHash[:])
+
+		i.notifyClients(invoice, channeldb.ContractExpired)
 	}
 }
 
@@ -79,20 +295,63 @@ func (i *invoiceRegistry) AddDebugInvoice(amt btcutil.Amount, preimage chainhash
 }
 
 // AddInvoice adds a regular invoice for the specified amount, identified by
-// the passed preimage. Additionally, any memo or receipt data provided will
-// also be stored on-disk. Once this invoice is added, subsystems within the
-// daemon add/forward HTLCs are able to obtain the proper preimage required
-// for redemption in the case that we're the final destination.
-func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
+// the passed payment hash. Additionally, any memo or receipt data provided
+// will also be stored on-disk. Once this invoice is added, subsystems within
+// the daemon add/forward HTLCs are able to obtain the proper preimage required
+// for redemption in the case that we're the final destination. If the
+// invoice's preimage is channeldb.UnknownPreimage, then it is added as a hold
+// invoice, and HTLCs paying to it will be held until it's either settled or
+// canceled.
+func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice,
+	paymentHash chainhash.Hash) error {
+
 	ltndLog.Debugf("Adding invoice %v", newLogClosure(func() string {
 		return spew.Sdump(invoice)
 	}))
 
 	// TODO(roasbeef): also check in memory for quick lookups/settles?
-	return i.cdb.AddInvoice(invoice)
+	if err := i.cdb.AddInvoice(invoice, paymentHash); err != nil {
+		return err
+	}
+
+	// Now that the invoice has been added, we'll start watching for its
+	// expiry.
+	i.addExpiry(invoice)
 
-	// TODO(roasbeef): re-enable?
-	//go i.notifyClients(invoice, false)
+	// The invoice has been assigned its add index, so we'll notify all
+	// clients of the newly added invoice.
+	go i.notifyClients(invoice, channeldb.ContractOpen)
+
+	return nil
+}
+
+// AddKeysendInvoice adds an invoice paying the passed amount to the hash of
+// the passed preimage, which the sender of a keysend payment carried to us
+// within the onion. If an invoice paying to the hash already exists, as the
+// HTLC of the payment is processed again after a restart, then the existing
+// invoice is left untouched.
+func (i *invoiceRegistry) AddKeysendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi) error {
+
+	if !i.acceptKeysend {
+		return errKeysendDisabled
+	}
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Terms: channeldb.ContractTerm{
+			Value:           amt,
+			PaymentPreimage: preimage,
+		},
+	}
+	paymentHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+
+	err := i.AddInvoice(invoice, paymentHash)
+	if err == channeldb.ErrDuplicateInvoice {
+		return nil
+	}
+
+	return err
 }
 
 // lookupInvoice looks up an invoice by its payment hash (R-Hash), if found
@@ -155,24 +414,372 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 
 		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
 
-		i.notifyClients(invoice, true)
+		i.notifyClients(invoice, channeldb.ContractSettled)
 	}()
 
 	return nil
 }
 
+// AcceptInvoice marks the hold invoice corresponding to the passed payment
+// hash as accepted, and subscribes the caller to its resolution. Once the
+// invoice is settled or canceled, a HodlEvent is sent over the subscriber
+// channel. If the invoice has already been resolved by the time it's
+// accepted, the event is delivered immediately.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) AcceptInvoice(rHash chainhash.Hash,
+	subscriber chan<- htlcswitch.HodlEvent) error {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	invoice, err := i.cdb.AcceptInvoice(rHash)
+	switch err {
+
+	// If the invoice was accepted, we'll register the subscriber such
+	// that it's notified once the invoice is resolved. We only notify
+	// the invoice clients the first time the invoice is accepted.
+	case nil:
+		if len(i.hodlSubscriptions[rHash]) == 0 {
+			ltndLog.Infof("Hold invoice %x accepted", rHash[:])
+
+			go i.notifyClients(invoice, channeldb.ContractAccepted)
+		}
+		i.hodlSubscribe(subscriber, rHash)
+
+		return nil
+
+	// The invoice was resolved in the meantime, so we'll let the
+	// subscriber know right away.
+	case channeldb.ErrInvoiceAlreadySettled,
+		channeldb.ErrInvoiceAlreadyCanceled,
+		channeldb.ErrInvoiceExpired:
+
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			return err
+		}
+
+		event := htlcswitch.HodlEvent{Hash: rHash}
+		if invoice.Terms.State == channeldb.ContractSettled {
+			preimage := invoice.Terms.PaymentPreimage
+			event.Preimage = &preimage
+		}
+		go func() {
+			subscriber <- event
+		}()
+
+		return nil
+
+	default:
+		return err
+	}
+}
+
+// AddPartialHTLC adds an HTLC that pays only part of the invoice corresponding
+// to the passed payment hash to the set of partial HTLCs that is held for it,
+// and subscribes the caller to the resolution of the HTLC. If the set covers
+// the invoice with the addition of this HTLC, then the invoice is paid by the
+// set: it's settled right away, or if it's a hold invoice, marked as
+// accepted. If instead the set doesn't cover the invoice within mppTimeout,
+// then its HTLCs are failed back while the invoice remains open.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) AddPartialHTLC(rHash chainhash.Hash,
+	circuitKey channeldb.CircuitKey, amt lnwire.MilliSatoshi,
+	subscriber chan<- htlcswitch.HodlEvent) error {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	switch invoice.Terms.State {
+
+	// The invoice is still open, so we'll add the HTLC to its set below.
+	case channeldb.ContractOpen:
+
+	// A set of partial HTLCs already covered the hold invoice, so we'll
+	// hold on to this HTLC until the invoice is resolved as well.
+	case channeldb.ContractAccepted:
+		i.hodlSubscribe(subscriber, rHash)
+		return nil
+
+	// The invoice was resolved in the meantime, so we'll let the
+	// subscriber know right away.
+	default:
+		event := htlcswitch.HodlEvent{Hash: rHash}
+		if invoice.Terms.State == channeldb.ContractSettled {
+			preimage := invoice.Terms.PaymentPreimage
+			event.Preimage = &preimage
+		}
+		go func() {
+			subscriber <- event
+		}()
+
+		return nil
+	}
+
+	set, ok := i.mppSets[rHash]
+	if !ok {
+		set = &mppSet{
+			htlcs: make(map[channeldb.CircuitKey]lnwire.MilliSatoshi),
+		}
+		set.timer = time.AfterFunc(mppTimeout, func() {
+			i.hodlMtx.Lock()
+			defer i.hodlMtx.Unlock()
+
+			// The set may have been resolved before the timer
+			// fired, in which case there's nothing left to fail.
+			if i.mppSets[rHash] != set {
+				return
+			}
+
+			ltndLog.Infof("Partial htlcs paying %v to invoice %x "+
+				"timed out", set.total(), rHash[:])
+
+			i.failMPPSet(rHash)
+		})
+		i.mppSets[rHash] = set
+	}
+	set.htlcs[circuitKey] = amt
+
+	i.hodlSubscribe(subscriber, rHash)
+
+	total := set.total()
+	if total < invoice.Terms.Value {
+		ltndLog.Debugf("Holding partial htlc %v paying %v to invoice "+
+			"%x, received %v of %v", circuitKey, amt, rHash[:],
+			total, invoice.Terms.Value)
+
+		return nil
+	}
+
+	// With this HTLC, the set covers the invoice, so we'll no longer have
+	// to time it out.
+	set.timer.Stop()
+	delete(i.mppSets, rHash)
+
+	ltndLog.Infof("Partial htlcs paying %v to invoice %x complete", total,
+		rHash[:])
+
+	// If we don't know the preimage, then this is a hold invoice, which
+	// we'll mark as accepted. The HTLCs of the set remain subscribed, and
+	// are resolved once the invoice is either settled or canceled.
+	if invoice.Terms.PaymentPreimage == channeldb.UnknownPreimage {
+		accepted, err := i.cdb.AcceptInvoice(rHash)
+		if err != nil {
+			return err
+		}
+
+		ltndLog.Infof("Hold invoice %x accepted", rHash[:])
+
+		go i.notifyClients(accepted, channeldb.ContractAccepted)
+
+		return nil
+	}
+
+	// Otherwise, we'll settle the invoice, along with all the HTLCs of the
+	// set.
+	if err := i.cdb.SettleInvoice(rHash); err != nil {
+		return err
+	}
+
+	preimage := invoice.Terms.PaymentPreimage
+	i.notifyHodlSubscribers(htlcswitch.HodlEvent{
+		Hash:     rHash,
+		Preimage: &preimage,
+	})
+
+	go func() {
+		invoice, err := i.cdb.LookupInvoice(rHash)
+		if err != nil {
+			ltndLog.Errorf("unable to find invoice: %v", err)
+			return
+		}
+
+		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+
+		i.notifyClients(invoice, channeldb.ContractSettled)
+	}()
+
+	return nil
+}
+
+// FailPartialHTLCs fails back the set of partial HTLCs that is held for the
+// invoice corresponding to the passed payment hash, leaving the invoice itself
+// open. If no such set is being held, then the partial HTLCs already covered
+// the invoice and are held for an accepted hold invoice instead, which is
+// canceled.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) FailPartialHTLCs(rHash chainhash.Hash) error {
+	i.hodlMtx.Lock()
+	if _, ok := i.mppSets[rHash]; ok {
+		ltndLog.Infof("Failing partial htlcs of invoice %x", rHash[:])
+
+		i.failMPPSet(rHash)
+		i.hodlMtx.Unlock()
+
+		return nil
+	}
+	i.hodlMtx.Unlock()
+
+	return i.CancelInvoice(rHash)
+}
+
+// failMPPSet removes the set of partial HTLCs held for the passed payment hash,
+// and fails back its HTLCs.
+//
+// NOTE: This method MUST be called with the hodlMtx held.
+func (i *invoiceRegistry) failMPPSet(rHash chainhash.Hash) {
+	if set, ok := i.mppSets[rHash]; ok {
+		set.timer.Stop()
+		delete(i.mppSets, rHash)
+	}
+
+	i.notifyHodlSubscribers(htlcswitch.HodlEvent{
+		Hash:       rHash,
+		MPPTimeout: true,
+	})
+}
+
+// SettleHoldInvoice settles the accepted hold invoice paying to the hash of
+// the passed preimage. All HTLCs that are being held for the invoice will be
+// settled using the preimage.
+func (i *invoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	invoice, err := i.cdb.SettleHoldInvoice(preimage)
+	if err != nil {
+		return err
+	}
+
+	rHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+	ltndLog.Infof("Hold invoice %x settled", rHash[:])
+
+	i.notifyHodlSubscribers(htlcswitch.HodlEvent{
+		Hash:     rHash,
+		Preimage: &preimage,
+	})
+
+	go i.notifyClients(invoice, channeldb.ContractSettled)
+
+	return nil
+}
+
+// CancelInvoice attempts to cancel the invoice corresponding to the passed
+// payment hash. All HTLCs that are being held for the invoice will be failed
+// back to their senders.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) CancelInvoice(rHash chainhash.Hash) error {
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	invoice, err := i.cdb.CancelInvoice(rHash)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Invoice %x canceled", rHash[:])
+
+	// Any partial HTLCs that are held for the invoice are failed back
+	// below, so we'll no longer have to time them out.
+	if set, ok := i.mppSets[rHash]; ok {
+		set.timer.Stop()
+		delete(i.mppSets, rHash)
+	}
+
+	i.notifyHodlSubscribers(htlcswitch.HodlEvent{Hash: rHash})
+
+	go i.notifyClients(invoice, channeldb.ContractCanceled)
+
+	return nil
+}
+
+// notifyHodlSubscribers sends the passed hodl event to all subscribers of the
+// invoice, after which the subscriptions are removed as the invoice has been
+// resolved.
+//
+// NOTE: This method MUST be called with the hodlMtx held.
+func (i *invoiceRegistry) notifyHodlSubscribers(event htlcswitch.HodlEvent) {
+	for subscriber := range i.hodlSubscriptions[event.Hash] {
+		go func(subscriber chan<- htlcswitch.HodlEvent) {
+			subscriber <- event
+		}(subscriber)
+
+		delete(i.hodlReverseSubscriptions[subscriber], event.Hash)
+	}
+
+	delete(i.hodlSubscriptions, event.Hash)
+}
+
+// hodlSubscribe adds a new hodl event subscription for the given payment
+// hash.
+//
+// NOTE: This method MUST be called with the hodlMtx held.
+func (i *invoiceRegistry) hodlSubscribe(subscriber chan<- htlcswitch.HodlEvent,
+	rHash chainhash.Hash) {
+
+	subscriptions, ok := i.hodlSubscriptions[rHash]
+	if !ok {
+		subscriptions = make(map[chan<- htlcswitch.HodlEvent]struct{})
+		i.hodlSubscriptions[rHash] = subscriptions
+	}
+	subscriptions[subscriber] = struct{}{}
+
+	reverseSubscriptions, ok := i.hodlReverseSubscriptions[subscriber]
+	if !ok {
+		reverseSubscriptions = make(map[chainhash.Hash]struct{})
+		i.hodlReverseSubscriptions[subscriber] = reverseSubscriptions
+	}
+	reverseSubscriptions[rHash] = struct{}{}
+}
+
+// HodlUnsubscribeAll cancels all of the hodl event subscriptions of the passed
+// subscriber.
+//
+// NOTE: Part of the htlcswitch.InvoiceDatabase interface.
+func (i *invoiceRegistry) HodlUnsubscribeAll(
+	subscriber chan<- htlcswitch.HodlEvent) {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	for rHash := range i.hodlReverseSubscriptions[subscriber] {
+		delete(i.hodlSubscriptions[rHash], subscriber)
+	}
+	delete(i.hodlReverseSubscriptions, subscriber)
+}
+
 // notifyClients notifies all currently registered invoice notification clients
-// of a newly added/settled invoice.
-func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool) {
+// of an invoice that has been added, or whose state has changed.
+func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice,
+	state channeldb.ContractState) {
+
 	i.clientMtx.Lock()
 	defer i.clientMtx.Unlock()
 
 	for _, client := range i.notificationClients {
 		var eventChan chan *channeldb.Invoice
-		if settle {
-			eventChan = client.SettledInvoices
-		} else {
+		switch state {
+		case channeldb.ContractOpen:
 			eventChan = client.NewInvoices
+		case channeldb.ContractAccepted:
+			eventChan = client.AcceptedInvoices
+		case channeldb.ContractSettled:
+			eventChan = client.SettledInvoices
+		case channeldb.ContractCanceled:
+			eventChan = client.CanceledInvoices
+		case channeldb.ContractExpired:
+			eventChan = client.ExpiredInvoices
+		default:
+			continue
 		}
 
 		go func() {
@@ -185,10 +792,15 @@ func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool)
 // or settled invoices. For each newly added invoice, a copy of the invoice
 // will be sent over the NewInvoices channel. Similarly, for each newly settled
 // invoice, a copy of the invoice will be sent over the SettledInvoices
-// channel.
+// channel. Hold invoices for which an HTLC has been accepted are sent over the
+// AcceptedInvoices channel, canceled invoices over the CanceledInvoices
+// channel, and expired invoices over the ExpiredInvoices channel.
 type invoiceSubscription struct {
-	NewInvoices     chan *channeldb.Invoice
-	SettledInvoices chan *channeldb.Invoice
+	NewInvoices      chan *channeldb.Invoice
+	AcceptedInvoices chan *channeldb.Invoice
+	SettledInvoices  chan *channeldb.Invoice
+	CanceledInvoices chan *channeldb.Invoice
+	ExpiredInvoices  chan *channeldb.Invoice
 
 	inv *invoiceRegistry
 	id  uint32
@@ -207,9 +819,12 @@ func (i *invoiceSubscription) Cancel() {
 // added.
 func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
 	client := &invoiceSubscription{
-		NewInvoices:     make(chan *channeldb.Invoice),
-		SettledInvoices: make(chan *channeldb.Invoice),
-		inv:             i,
+		NewInvoices:      make(chan *channeldb.Invoice),
+		AcceptedInvoices: make(chan *channeldb.Invoice),
+		SettledInvoices:  make(chan *channeldb.Invoice),
+		CanceledInvoices: make(chan *channeldb.Invoice),
+		ExpiredInvoices:  make(chan *channeldb.Invoice),
+		inv:              i,
 	}
 
 	i.clientMtx.Lock()
@@ -54,6 +54,11 @@ import (
 const (
 	// Make certificate valid for 14 months.
 	autogenCertValidity = 14 /*months*/ * 30 /*days*/ * 24 * time.Hour
+
+	// maxGrpcRecvMsgSize is the largest gRPC message we'll accept. It's
+	// raised well above gRPC's default of 4MB in order to allow graph
+	// snapshots to be imported.
+	maxGrpcRecvMsgSize = 200 * 1024 * 1024
 )
 
 var (
@@ -189,7 +194,10 @@ func lndMain() error {
 		MinVersion:   tls.VersionTLS12,
 	}
 	sCreds := credentials.NewTLS(tlsConf)
-	serverOpts := []grpc.ServerOption{grpc.Creds(sCreds)}
+	serverOpts := []grpc.ServerOption{
+		grpc.Creds(sCreds),
+		grpc.MaxRecvMsgSize(maxGrpcRecvMsgSize),
+	}
 	cCreds, err := credentials.NewClientTLSFromFile(cfg.TLSCertPath, "")
 	if err != nil {
 		return err
@@ -3107,6 +3107,17 @@ func testInvoiceSubscriptions(net *lntest.NetworkHarness, t *harnessTest) {
 				invoice.RPreimage, invoiceUpdate.RPreimage)
 		}
 
+		// The update should also carry the add index assigned when the
+		// invoice was created, along with a settle index.
+		if invoiceUpdate.AddIndex != invoiceResp.AddIndex {
+			t.Fatalf("invoice has wrong add index: expected %v, "+
+				"got %v", invoiceResp.AddIndex,
+				invoiceUpdate.AddIndex)
+		}
+		if invoiceUpdate.SettleIndex == 0 {
+			t.Fatalf("invoice should have non zero settle index")
+		}
+
 		close(updateSent)
 	}()
 
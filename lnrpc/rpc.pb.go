@@ -19,6 +19,7 @@ It has these top-level messages:
 	TransactionDetails
 	SendRequest
 	SendResponse
+	SendToRouteRequest
 	ChannelPoint
 	LightningAddress
 	SendManyRequest
@@ -61,9 +62,21 @@ It has these top-level messages:
 	ChannelBalanceRequest
 	ChannelBalanceResponse
 	QueryRoutesRequest
+	FeeLimit
+	CostModel
 	QueryRoutesResponse
 	Hop
 	Route
+	ProbeRequest
+	ProbeResponse
+	ProbeRouteResult
+	ProbeHop
+	QueryMissionControlRequest
+	QueryMissionControlResponse
+	NodeHistory
+	ChannelHistory
+	ResetMissionControlRequest
+	ResetMissionControlResponse
 	NodeInfoRequest
 	NodeInfo
 	LightningNode
@@ -75,6 +88,10 @@ It has these top-level messages:
 	ChanInfoRequest
 	NetworkInfoRequest
 	NetworkInfo
+	ExportGraphRequest
+	ExportGraphResponse
+	ImportGraphRequest
+	ImportGraphResponse
 	StopRequest
 	StopResponse
 	GraphTopologySubscription
@@ -82,13 +99,24 @@ It has these top-level messages:
 	NodeUpdate
 	ChannelEdgeUpdate
 	ClosedChannelUpdate
+	HopHint
+	RouteHint
 	Invoice
 	AddInvoiceResponse
 	PaymentHash
 	ListInvoiceRequest
 	ListInvoiceResponse
 	InvoiceSubscription
+	SettleInvoiceRequest
+	SettleInvoiceResponse
+	CancelInvoiceRequest
+	CancelInvoiceResponse
 	Payment
+	HTLCAttempt
+	HTLCFailure
+	RebalanceRequest
+	RebalanceResponse
+	TrackPaymentRequest
 	ListPaymentsRequest
 	ListPaymentsResponse
 	DeleteAllPaymentsRequest
@@ -102,6 +130,10 @@ It has these top-level messages:
 	FeeReportResponse
 	PolicyUpdateRequest
 	PolicyUpdateResponse
+	UpdateChanStatusRequest
+	UpdateChanStatusResponse
+	UpdateNodeAnnouncementRequest
+	UpdateNodeAnnouncementResponse
 	ForwardingHistoryRequest
 	ForwardingEvent
 	ForwardingHistoryResponse
@@ -149,7 +181,183 @@ func (x NewAddressRequest_AddressType) String() string {
 	return proto.EnumName(NewAddressRequest_AddressType_name, int32(x))
 }
 func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{17, 0}
+	return fileDescriptor0, []int{18, 0}
+}
+
+type Invoice_InvoiceState int32
+
+const (
+	Invoice_OPEN     Invoice_InvoiceState = 0
+	Invoice_SETTLED  Invoice_InvoiceState = 1
+	Invoice_CANCELED Invoice_InvoiceState = 2
+	Invoice_ACCEPTED Invoice_InvoiceState = 3
+	Invoice_EXPIRED  Invoice_InvoiceState = 4
+)
+
+var Invoice_InvoiceState_name = map[int32]string{
+	0: "OPEN",
+	1: "SETTLED",
+	2: "CANCELED",
+	3: "ACCEPTED",
+	4: "EXPIRED",
+}
+var Invoice_InvoiceState_value = map[string]int32{
+	"OPEN":     0,
+	"SETTLED":  1,
+	"CANCELED": 2,
+	"ACCEPTED": 3,
+	"EXPIRED":  4,
+}
+
+func (x Invoice_InvoiceState) String() string {
+	return proto.EnumName(Invoice_InvoiceState_name, int32(x))
+}
+func (Invoice_InvoiceState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{93, 0} }
+
+type Payment_PaymentStatus int32
+
+const (
+	Payment_UNKNOWN   Payment_PaymentStatus = 0
+	Payment_IN_FLIGHT Payment_PaymentStatus = 1
+	Payment_SUCCEEDED Payment_PaymentStatus = 2
+	Payment_FAILED    Payment_PaymentStatus = 3
+)
+
+var Payment_PaymentStatus_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "IN_FLIGHT",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var Payment_PaymentStatus_value = map[string]int32{
+	"UNKNOWN":   0,
+	"IN_FLIGHT": 1,
+	"SUCCEEDED": 2,
+	"FAILED":    3,
+}
+
+func (x Payment_PaymentStatus) String() string {
+	return proto.EnumName(Payment_PaymentStatus_name, int32(x))
+}
+func (Payment_PaymentStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{103, 0} }
+
+type Payment_FailureReason int32
+
+const (
+	// / The payment hasn't failed.
+	Payment_FAILURE_REASON_NONE Payment_FailureReason = 0
+	// / The payment timed out before a successful attempt was made.
+	Payment_FAILURE_REASON_TIMEOUT Payment_FailureReason = 1
+	// / No route to the destination could be found.
+	Payment_FAILURE_REASON_NO_ROUTE Payment_FailureReason = 2
+	// / An unexpected error occurred, or an attempt failed with an
+	// / error that can't be recovered from.
+	Payment_FAILURE_REASON_ERROR Payment_FailureReason = 3
+	// / The payment hash is unknown to the destination, or the final
+	// / cltv delta or amount is incorrect.
+	Payment_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS Payment_FailureReason = 4
+)
+
+var Payment_FailureReason_name = map[int32]string{
+	0: "FAILURE_REASON_NONE",
+	1: "FAILURE_REASON_TIMEOUT",
+	2: "FAILURE_REASON_NO_ROUTE",
+	3: "FAILURE_REASON_ERROR",
+	4: "FAILURE_REASON_INCORRECT_PAYMENT_DETAILS",
+}
+var Payment_FailureReason_value = map[string]int32{
+	"FAILURE_REASON_NONE":                      0,
+	"FAILURE_REASON_TIMEOUT":                   1,
+	"FAILURE_REASON_NO_ROUTE":                  2,
+	"FAILURE_REASON_ERROR":                     3,
+	"FAILURE_REASON_INCORRECT_PAYMENT_DETAILS": 4,
+}
+
+func (x Payment_FailureReason) String() string {
+	return proto.EnumName(Payment_FailureReason_name, int32(x))
+}
+func (Payment_FailureReason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{103, 1} }
+
+type HTLCAttempt_HTLCStatus int32
+
+const (
+	HTLCAttempt_IN_FLIGHT HTLCAttempt_HTLCStatus = 0
+	HTLCAttempt_SUCCEEDED HTLCAttempt_HTLCStatus = 1
+	HTLCAttempt_FAILED    HTLCAttempt_HTLCStatus = 2
+)
+
+var HTLCAttempt_HTLCStatus_name = map[int32]string{
+	0: "IN_FLIGHT",
+	1: "SUCCEEDED",
+	2: "FAILED",
+}
+var HTLCAttempt_HTLCStatus_value = map[string]int32{
+	"IN_FLIGHT": 0,
+	"SUCCEEDED": 1,
+	"FAILED":    2,
+}
+
+func (x HTLCAttempt_HTLCStatus) String() string {
+	return proto.EnumName(HTLCAttempt_HTLCStatus_name, int32(x))
+}
+func (HTLCAttempt_HTLCStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{104, 0} }
+
+type HTLCFailure_FailureReason int32
+
+const (
+	// / The failure was returned by a node that isn't part of the route.
+	HTLCFailure_UNKNOWN HTLCFailure_FailureReason = 0
+	// / The HTLC failed with an internal error before it was sent out.
+	HTLCFailure_INTERNAL HTLCFailure_FailureReason = 1
+	// / The HTLC failed with a failure message from the network.
+	HTLCFailure_MESSAGE HTLCFailure_FailureReason = 2
+)
+
+var HTLCFailure_FailureReason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INTERNAL",
+	2: "MESSAGE",
+}
+var HTLCFailure_FailureReason_value = map[string]int32{
+	"UNKNOWN":  0,
+	"INTERNAL": 1,
+	"MESSAGE":  2,
+}
+
+func (x HTLCFailure_FailureReason) String() string {
+	return proto.EnumName(HTLCFailure_FailureReason_name, int32(x))
+}
+func (HTLCFailure_FailureReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{105, 0}
+}
+
+type UpdateChanStatusRequest_Action int32
+
+const (
+	// / Advertise the channel as enabled.
+	UpdateChanStatusRequest_ENABLE UpdateChanStatusRequest_Action = 0
+	// / Advertise the channel as disabled.
+	UpdateChanStatusRequest_DISABLE UpdateChanStatusRequest_Action = 1
+	// / Derive the status of the channel from the liveness of its peer.
+	UpdateChanStatusRequest_AUTO UpdateChanStatusRequest_Action = 2
+)
+
+var UpdateChanStatusRequest_Action_name = map[int32]string{
+	0: "ENABLE",
+	1: "DISABLE",
+	2: "AUTO",
+}
+var UpdateChanStatusRequest_Action_value = map[string]int32{
+	"ENABLE":  0,
+	"DISABLE": 1,
+	"AUTO":    2,
+}
+
+func (x UpdateChanStatusRequest_Action) String() string {
+	return proto.EnumName(UpdateChanStatusRequest_Action_name, int32(x))
+}
+func (UpdateChanStatusRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{122, 0}
 }
 
 type GenSeedRequest struct {
@@ -417,6 +625,35 @@ type SendRequest struct {
 	PaymentRequest string `protobuf:"bytes,6,opt,name=payment_request,json=paymentRequest" json:"payment_request,omitempty"`
 	// / The CLTV delta from the current height that should be used to set the timelock for the final hop.
 	FinalCltvDelta int32 `protobuf:"varint,7,opt,name=final_cltv_delta,json=finalCltvDelta" json:"final_cltv_delta,omitempty"`
+	// *
+	// The maximum number of satoshis that will be paid as a fee of the payment.
+	// This value can be represented either as a percentage of the amount being
+	// sent, or as a fixed amount of the maximum fee the user is willing the pay to
+	// send the payment. If unset, the fee isn't limited.
+	FeeLimit *FeeLimit `protobuf:"bytes,8,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	// *
+	// An optional maximum total time lock for the route, expressed as a number of
+	// blocks from the current height. This includes the final CLTV delta. If
+	// zero, the time lock isn't limited.
+	CltvLimit uint32 `protobuf:"varint,9,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// *
+	// The channel id of the channel that must be taken to the first hop. If zero,
+	// any channel may be used.
+	OutgoingChanId uint64 `protobuf:"varint,10,opt,name=outgoing_chan_id,json=outgoingChanId" json:"outgoing_chan_id,omitempty"`
+	// *
+	// The pubkey of the last hop of the route. If empty, any last hop may be
+	// used.
+	LastHopPubkey []byte `protobuf:"bytes,11,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
+	// *
+	// An optional preimage chosen by the sender, which is carried to the
+	// destination within the onion. This allows paying a destination that accepts
+	// keysend payments without an invoice. The payment pays to the hash of the
+	// preimage, so any payment hash that is set must match it.
+	KeysendPreimage []byte `protobuf:"bytes,12,opt,name=keysend_preimage,json=keysendPreimage,proto3" json:"keysend_preimage,omitempty"`
+	// *
+	// An optional cost model used to weigh the channels of the candidate routes
+	// of the payment. If unset, the cost model configured for the node is used.
+	CostModel *CostModel `protobuf:"bytes,13,opt,name=cost_model,json=costModel" json:"cost_model,omitempty"`
 }
 
 func (m *SendRequest) Reset()                    { *m = SendRequest{} }
@@ -473,6 +710,48 @@ func (m *SendRequest) GetFinalCltvDelta() int32 {
 	return 0
 }
 
+func (m *SendRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *SendRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *SendRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *SendRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
+func (m *SendRequest) GetKeysendPreimage() []byte {
+	if m != nil {
+		return m.KeysendPreimage
+	}
+	return nil
+}
+
+func (m *SendRequest) GetCostModel() *CostModel {
+	if m != nil {
+		return m.CostModel
+	}
+	return nil
+}
+
 type SendResponse struct {
 	PaymentError    string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
 	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
@@ -505,6 +784,44 @@ func (m *SendResponse) GetPaymentRoute() *Route {
 	return nil
 }
 
+type SendToRouteRequest struct {
+	// / The payment hash to use for the HTLC.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,json=paymentHash,proto3" json:"payment_hash,omitempty"`
+	// / An optional hex-encoded payment hash to be used for the HTLC.
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string,json=paymentHashString" json:"payment_hash_string,omitempty"`
+	// *
+	// The set of routes that should be used to attempt to complete the payment.
+	// The routes are attempted in order, until the payment either succeeds, or
+	// all routes have failed.
+	Routes []*Route `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendToRouteRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
 type ChannelPoint struct {
 	// Types that are valid to be assigned to FundingTxid:
 	//	*ChannelPoint_FundingTxidBytes
@@ -517,7 +834,7 @@ type ChannelPoint struct {
 func (m *ChannelPoint) Reset()                    { *m = ChannelPoint{} }
 func (m *ChannelPoint) String() string            { return proto.CompactTextString(m) }
 func (*ChannelPoint) ProtoMessage()               {}
-func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ChannelPoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 type isChannelPoint_FundingTxid interface {
 	isChannelPoint_FundingTxid()
@@ -637,7 +954,7 @@ type LightningAddress struct {
 func (m *LightningAddress) Reset()                    { *m = LightningAddress{} }
 func (m *LightningAddress) String() string            { return proto.CompactTextString(m) }
 func (*LightningAddress) ProtoMessage()               {}
-func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*LightningAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *LightningAddress) GetPubkey() string {
 	if m != nil {
@@ -665,7 +982,7 @@ type SendManyRequest struct {
 func (m *SendManyRequest) Reset()                    { *m = SendManyRequest{} }
 func (m *SendManyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendManyRequest) ProtoMessage()               {}
-func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*SendManyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *SendManyRequest) GetAddrToAmount() map[string]int64 {
 	if m != nil {
@@ -696,7 +1013,7 @@ type SendManyResponse struct {
 func (m *SendManyResponse) Reset()                    { *m = SendManyResponse{} }
 func (m *SendManyResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendManyResponse) ProtoMessage()               {}
-func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*SendManyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *SendManyResponse) GetTxid() string {
 	if m != nil {
@@ -719,7 +1036,7 @@ type SendCoinsRequest struct {
 func (m *SendCoinsRequest) Reset()                    { *m = SendCoinsRequest{} }
 func (m *SendCoinsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsRequest) ProtoMessage()               {}
-func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*SendCoinsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *SendCoinsRequest) GetAddr() string {
 	if m != nil {
@@ -757,7 +1074,7 @@ type SendCoinsResponse struct {
 func (m *SendCoinsResponse) Reset()                    { *m = SendCoinsResponse{} }
 func (m *SendCoinsResponse) String() string            { return proto.CompactTextString(m) }
 func (*SendCoinsResponse) ProtoMessage()               {}
-func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*SendCoinsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *SendCoinsResponse) GetTxid() string {
 	if m != nil {
@@ -780,7 +1097,7 @@ type NewAddressRequest struct {
 func (m *NewAddressRequest) Reset()                    { *m = NewAddressRequest{} }
 func (m *NewAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressRequest) ProtoMessage()               {}
-func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*NewAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *NewAddressRequest) GetType() NewAddressRequest_AddressType {
 	if m != nil {
@@ -795,7 +1112,7 @@ type NewWitnessAddressRequest struct {
 func (m *NewWitnessAddressRequest) Reset()                    { *m = NewWitnessAddressRequest{} }
 func (m *NewWitnessAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NewWitnessAddressRequest) ProtoMessage()               {}
-func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*NewWitnessAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 type NewAddressResponse struct {
 	// / The newly generated wallet address
@@ -805,7 +1122,7 @@ type NewAddressResponse struct {
 func (m *NewAddressResponse) Reset()                    { *m = NewAddressResponse{} }
 func (m *NewAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NewAddressResponse) ProtoMessage()               {}
-func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*NewAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *NewAddressResponse) GetAddress() string {
 	if m != nil {
@@ -822,7 +1139,7 @@ type SignMessageRequest struct {
 func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
 func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageRequest) ProtoMessage()               {}
-func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *SignMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -839,7 +1156,7 @@ type SignMessageResponse struct {
 func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
 func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignMessageResponse) ProtoMessage()               {}
-func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *SignMessageResponse) GetSignature() string {
 	if m != nil {
@@ -858,7 +1175,7 @@ type VerifyMessageRequest struct {
 func (m *VerifyMessageRequest) Reset()                    { *m = VerifyMessageRequest{} }
 func (m *VerifyMessageRequest) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageRequest) ProtoMessage()               {}
-func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*VerifyMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *VerifyMessageRequest) GetMsg() []byte {
 	if m != nil {
@@ -884,7 +1201,7 @@ type VerifyMessageResponse struct {
 func (m *VerifyMessageResponse) Reset()                    { *m = VerifyMessageResponse{} }
 func (m *VerifyMessageResponse) String() string            { return proto.CompactTextString(m) }
 func (*VerifyMessageResponse) ProtoMessage()               {}
-func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*VerifyMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *VerifyMessageResponse) GetValid() bool {
 	if m != nil {
@@ -911,7 +1228,7 @@ type ConnectPeerRequest struct {
 func (m *ConnectPeerRequest) Reset()                    { *m = ConnectPeerRequest{} }
 func (m *ConnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerRequest) ProtoMessage()               {}
-func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*ConnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *ConnectPeerRequest) GetAddr() *LightningAddress {
 	if m != nil {
@@ -933,7 +1250,7 @@ type ConnectPeerResponse struct {
 func (m *ConnectPeerResponse) Reset()                    { *m = ConnectPeerResponse{} }
 func (m *ConnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*ConnectPeerResponse) ProtoMessage()               {}
-func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*ConnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 type DisconnectPeerRequest struct {
 	// / The pubkey of the node to disconnect from
@@ -943,7 +1260,7 @@ type DisconnectPeerRequest struct {
 func (m *DisconnectPeerRequest) Reset()                    { *m = DisconnectPeerRequest{} }
 func (m *DisconnectPeerRequest) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerRequest) ProtoMessage()               {}
-func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *DisconnectPeerRequest) GetPubKey() string {
 	if m != nil {
@@ -958,7 +1275,7 @@ type DisconnectPeerResponse struct {
 func (m *DisconnectPeerResponse) Reset()                    { *m = DisconnectPeerResponse{} }
 func (m *DisconnectPeerResponse) String() string            { return proto.CompactTextString(m) }
 func (*DisconnectPeerResponse) ProtoMessage()               {}
-func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 type HTLC struct {
 	Incoming         bool   `protobuf:"varint,1,opt,name=incoming" json:"incoming,omitempty"`
@@ -970,7 +1287,7 @@ type HTLC struct {
 func (m *HTLC) Reset()                    { *m = HTLC{} }
 func (m *HTLC) String() string            { return proto.CompactTextString(m) }
 func (*HTLC) ProtoMessage()               {}
-func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*HTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *HTLC) GetIncoming() bool {
 	if m != nil {
@@ -1060,7 +1377,7 @@ type Channel struct {
 func (m *Channel) Reset()                    { *m = Channel{} }
 func (m *Channel) String() string            { return proto.CompactTextString(m) }
 func (*Channel) ProtoMessage()               {}
-func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*Channel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *Channel) GetActive() bool {
 	if m != nil {
@@ -1191,7 +1508,7 @@ type ListChannelsRequest struct {
 func (m *ListChannelsRequest) Reset()                    { *m = ListChannelsRequest{} }
 func (m *ListChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsRequest) ProtoMessage()               {}
-func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*ListChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *ListChannelsRequest) GetActiveOnly() bool {
 	if m != nil {
@@ -1229,7 +1546,7 @@ type ListChannelsResponse struct {
 func (m *ListChannelsResponse) Reset()                    { *m = ListChannelsResponse{} }
 func (m *ListChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListChannelsResponse) ProtoMessage()               {}
-func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*ListChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *ListChannelsResponse) GetChannels() []*Channel {
 	if m != nil {
@@ -1260,7 +1577,7 @@ type Peer struct {
 func (m *Peer) Reset()                    { *m = Peer{} }
 func (m *Peer) String() string            { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()               {}
-func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*Peer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *Peer) GetPubKey() string {
 	if m != nil {
@@ -1324,7 +1641,7 @@ type ListPeersRequest struct {
 func (m *ListPeersRequest) Reset()                    { *m = ListPeersRequest{} }
 func (m *ListPeersRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersRequest) ProtoMessage()               {}
-func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*ListPeersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 type ListPeersResponse struct {
 	// / The list of currently connected peers
@@ -1334,7 +1651,7 @@ type ListPeersResponse struct {
 func (m *ListPeersResponse) Reset()                    { *m = ListPeersResponse{} }
 func (m *ListPeersResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPeersResponse) ProtoMessage()               {}
-func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*ListPeersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 func (m *ListPeersResponse) GetPeers() []*Peer {
 	if m != nil {
@@ -1349,7 +1666,7 @@ type GetInfoRequest struct {
 func (m *GetInfoRequest) Reset()                    { *m = GetInfoRequest{} }
 func (m *GetInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoRequest) ProtoMessage()               {}
-func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*GetInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 type GetInfoResponse struct {
 	// / The identity pubkey of the current node.
@@ -1381,7 +1698,7 @@ type GetInfoResponse struct {
 func (m *GetInfoResponse) Reset()                    { *m = GetInfoResponse{} }
 func (m *GetInfoResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetInfoResponse) ProtoMessage()               {}
-func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*GetInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 func (m *GetInfoResponse) GetIdentityPubkey() string {
 	if m != nil {
@@ -1476,7 +1793,7 @@ type ConfirmationUpdate struct {
 func (m *ConfirmationUpdate) Reset()                    { *m = ConfirmationUpdate{} }
 func (m *ConfirmationUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ConfirmationUpdate) ProtoMessage()               {}
-func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*ConfirmationUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *ConfirmationUpdate) GetBlockSha() []byte {
 	if m != nil {
@@ -1506,7 +1823,7 @@ type ChannelOpenUpdate struct {
 func (m *ChannelOpenUpdate) Reset()                    { *m = ChannelOpenUpdate{} }
 func (m *ChannelOpenUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelOpenUpdate) ProtoMessage()               {}
-func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*ChannelOpenUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *ChannelOpenUpdate) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1523,7 +1840,7 @@ type ChannelCloseUpdate struct {
 func (m *ChannelCloseUpdate) Reset()                    { *m = ChannelCloseUpdate{} }
 func (m *ChannelCloseUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelCloseUpdate) ProtoMessage()               {}
-func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*ChannelCloseUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *ChannelCloseUpdate) GetClosingTxid() []byte {
 	if m != nil {
@@ -1556,7 +1873,7 @@ type CloseChannelRequest struct {
 func (m *CloseChannelRequest) Reset()                    { *m = CloseChannelRequest{} }
 func (m *CloseChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseChannelRequest) ProtoMessage()               {}
-func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*CloseChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *CloseChannelRequest) GetChannelPoint() *ChannelPoint {
 	if m != nil {
@@ -1597,7 +1914,7 @@ type CloseStatusUpdate struct {
 func (m *CloseStatusUpdate) Reset()                    { *m = CloseStatusUpdate{} }
 func (m *CloseStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*CloseStatusUpdate) ProtoMessage()               {}
-func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*CloseStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 type isCloseStatusUpdate_Update interface {
 	isCloseStatusUpdate_Update()
@@ -1746,7 +2063,7 @@ type PendingUpdate struct {
 func (m *PendingUpdate) Reset()                    { *m = PendingUpdate{} }
 func (m *PendingUpdate) String() string            { return proto.CompactTextString(m) }
 func (*PendingUpdate) ProtoMessage()               {}
-func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*PendingUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
 
 func (m *PendingUpdate) GetTxid() []byte {
 	if m != nil {
@@ -1786,7 +2103,7 @@ type OpenChannelRequest struct {
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
 func (m *OpenChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenChannelRequest) ProtoMessage()               {}
-func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*OpenChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 func (m *OpenChannelRequest) GetNodePubkey() []byte {
 	if m != nil {
@@ -1862,7 +2179,7 @@ type OpenStatusUpdate struct {
 func (m *OpenStatusUpdate) Reset()                    { *m = OpenStatusUpdate{} }
 func (m *OpenStatusUpdate) String() string            { return proto.CompactTextString(m) }
 func (*OpenStatusUpdate) ProtoMessage()               {}
-func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*OpenStatusUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 type isOpenStatusUpdate_Update interface {
 	isOpenStatusUpdate_Update()
@@ -2024,7 +2341,7 @@ type PendingHTLC struct {
 func (m *PendingHTLC) Reset()                    { *m = PendingHTLC{} }
 func (m *PendingHTLC) String() string            { return proto.CompactTextString(m) }
 func (*PendingHTLC) ProtoMessage()               {}
-func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+func (*PendingHTLC) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 func (m *PendingHTLC) GetIncoming() bool {
 	if m != nil {
@@ -2074,7 +2391,7 @@ type PendingChannelsRequest struct {
 func (m *PendingChannelsRequest) Reset()                    { *m = PendingChannelsRequest{} }
 func (m *PendingChannelsRequest) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsRequest) ProtoMessage()               {}
-func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (*PendingChannelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 type PendingChannelsResponse struct {
 	// / The balance in satoshis encumbered in pending channels
@@ -2090,7 +2407,7 @@ type PendingChannelsResponse struct {
 func (m *PendingChannelsResponse) Reset()                    { *m = PendingChannelsResponse{} }
 func (m *PendingChannelsResponse) String() string            { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse) ProtoMessage()               {}
-func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (*PendingChannelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *PendingChannelsResponse) GetTotalLimboBalance() int64 {
 	if m != nil {
@@ -2134,7 +2451,7 @@ func (m *PendingChannelsResponse_PendingChannel) Reset() {
 func (m *PendingChannelsResponse_PendingChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_PendingChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_PendingChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 0}
+	return fileDescriptor0, []int{48, 0}
 }
 
 func (m *PendingChannelsResponse_PendingChannel) GetRemoteNodePub() string {
@@ -2201,7 +2518,7 @@ func (m *PendingChannelsResponse_PendingOpenChannel) String() string {
 }
 func (*PendingChannelsResponse_PendingOpenChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_PendingOpenChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 1}
+	return fileDescriptor0, []int{48, 1}
 }
 
 func (m *PendingChannelsResponse_PendingOpenChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2250,7 +2567,7 @@ func (m *PendingChannelsResponse_ClosedChannel) Reset()         { *m = PendingCh
 func (m *PendingChannelsResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
 func (*PendingChannelsResponse_ClosedChannel) ProtoMessage()    {}
 func (*PendingChannelsResponse_ClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 2}
+	return fileDescriptor0, []int{48, 2}
 }
 
 func (m *PendingChannelsResponse_ClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2294,7 +2611,7 @@ func (m *PendingChannelsResponse_ForceClosedChannel) String() string {
 }
 func (*PendingChannelsResponse_ForceClosedChannel) ProtoMessage() {}
 func (*PendingChannelsResponse_ForceClosedChannel) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{47, 3}
+	return fileDescriptor0, []int{48, 3}
 }
 
 func (m *PendingChannelsResponse_ForceClosedChannel) GetChannel() *PendingChannelsResponse_PendingChannel {
@@ -2352,7 +2669,7 @@ type WalletBalanceRequest struct {
 func (m *WalletBalanceRequest) Reset()                    { *m = WalletBalanceRequest{} }
 func (m *WalletBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceRequest) ProtoMessage()               {}
-func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (*WalletBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 type WalletBalanceResponse struct {
 	// / The balance of the wallet
@@ -2366,7 +2683,7 @@ type WalletBalanceResponse struct {
 func (m *WalletBalanceResponse) Reset()                    { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()               {}
-func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+func (*WalletBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *WalletBalanceResponse) GetTotalBalance() int64 {
 	if m != nil {
@@ -2395,7 +2712,7 @@ type ChannelBalanceRequest struct {
 func (m *ChannelBalanceRequest) Reset()                    { *m = ChannelBalanceRequest{} }
 func (m *ChannelBalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceRequest) ProtoMessage()               {}
-func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+func (*ChannelBalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 type ChannelBalanceResponse struct {
 	// / Sum of channels balances denominated in satoshis
@@ -2405,7 +2722,7 @@ type ChannelBalanceResponse struct {
 func (m *ChannelBalanceResponse) Reset()                    { *m = ChannelBalanceResponse{} }
 func (m *ChannelBalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ChannelBalanceResponse) ProtoMessage()               {}
-func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+func (*ChannelBalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 func (m *ChannelBalanceResponse) GetBalance() int64 {
 	if m != nil {
@@ -2421,12 +2738,35 @@ type QueryRoutesRequest struct {
 	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
 	// / The max number of routes to return.
 	NumRoutes int32 `protobuf:"varint,3,opt,name=num_routes,json=numRoutes" json:"num_routes,omitempty"`
+	// *
+	// The maximum number of satoshis that will be paid as a fee of the payment.
+	// This value can be represented either as a percentage of the amount being
+	// sent, or as a fixed amount of the maximum fee the user is willing the pay to
+	// send the payment. If unset, the fee isn't limited.
+	FeeLimit *FeeLimit `protobuf:"bytes,4,opt,name=fee_limit,json=feeLimit" json:"fee_limit,omitempty"`
+	// *
+	// An optional maximum total time lock for the route, expressed as a number of
+	// blocks from the current height. This includes the final CLTV delta. If
+	// zero, the time lock isn't limited.
+	CltvLimit uint32 `protobuf:"varint,5,opt,name=cltv_limit,json=cltvLimit" json:"cltv_limit,omitempty"`
+	// *
+	// The channel id of the channel that must be taken to the first hop. If zero,
+	// any channel may be used.
+	OutgoingChanId uint64 `protobuf:"varint,6,opt,name=outgoing_chan_id,json=outgoingChanId" json:"outgoing_chan_id,omitempty"`
+	// *
+	// The pubkey of the last hop of the route. If empty, any last hop may be
+	// used.
+	LastHopPubkey []byte `protobuf:"bytes,7,opt,name=last_hop_pubkey,json=lastHopPubkey,proto3" json:"last_hop_pubkey,omitempty"`
+	// *
+	// An optional cost model used to weigh the channels of the routes. If unset,
+	// the cost model configured for the node is used.
+	CostModel *CostModel `protobuf:"bytes,8,opt,name=cost_model,json=costModel" json:"cost_model,omitempty"`
 }
 
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
 func (m *QueryRoutesRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesRequest) ProtoMessage()               {}
-func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+func (*QueryRoutesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *QueryRoutesRequest) GetPubKey() string {
 	if m != nil {
@@ -2449,6 +2789,194 @@ func (m *QueryRoutesRequest) GetNumRoutes() int32 {
 	return 0
 }
 
+func (m *QueryRoutesRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *QueryRoutesRequest) GetLastHopPubkey() []byte {
+	if m != nil {
+		return m.LastHopPubkey
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetCostModel() *CostModel {
+	if m != nil {
+		return m.CostModel
+	}
+	return nil
+}
+
+type FeeLimit struct {
+	// Types that are valid to be assigned to Limit:
+	//	*FeeLimit_FixedMsat
+	//	*FeeLimit_Percent
+	Limit isFeeLimit_Limit `protobuf_oneof:"limit"`
+}
+
+func (m *FeeLimit) Reset()                    { *m = FeeLimit{} }
+func (m *FeeLimit) String() string            { return proto.CompactTextString(m) }
+func (*FeeLimit) ProtoMessage()               {}
+func (*FeeLimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+type isFeeLimit_Limit interface {
+	isFeeLimit_Limit()
+}
+
+type FeeLimit_FixedMsat struct {
+	FixedMsat int64 `protobuf:"varint,1,opt,name=fixed_msat,json=fixedMsat,oneof"`
+}
+type FeeLimit_Percent struct {
+	Percent int64 `protobuf:"varint,2,opt,name=percent,oneof"`
+}
+
+func (*FeeLimit_FixedMsat) isFeeLimit_Limit() {}
+func (*FeeLimit_Percent) isFeeLimit_Limit()   {}
+
+func (m *FeeLimit) GetLimit() isFeeLimit_Limit {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *FeeLimit) GetFixedMsat() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_FixedMsat); ok {
+		return x.FixedMsat
+	}
+	return 0
+}
+
+func (m *FeeLimit) GetPercent() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_Percent); ok {
+		return x.Percent
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*FeeLimit) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _FeeLimit_OneofMarshaler, _FeeLimit_OneofUnmarshaler, _FeeLimit_OneofSizer, []interface{}{
+		(*FeeLimit_FixedMsat)(nil),
+		(*FeeLimit_Percent)(nil),
+	}
+}
+
+func _FeeLimit_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_FixedMsat:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.FixedMsat))
+	case *FeeLimit_Percent:
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		return fmt.Errorf("FeeLimit.Limit has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _FeeLimit_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*FeeLimit)
+	switch tag {
+	case 1: // limit.fixed_msat
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_FixedMsat{int64(x)}
+		return true, err
+	case 2: // limit.percent
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_Percent{int64(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _FeeLimit_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_FixedMsat:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.FixedMsat))
+	case *FeeLimit_Percent:
+		n += proto.SizeVarint(2<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type CostModel struct {
+	// *
+	// The factor that the square of the fee paid for a channel is multiplied
+	// with. Higher values favor cheaper routes.
+	FeeWeight float64 `protobuf:"fixed64,1,opt,name=fee_weight,json=feeWeight" json:"fee_weight,omitempty"`
+	// *
+	// The weight of each block of time-lock delta of a channel. Higher values
+	// favor routes that lock up funds for a shorter time.
+	TimeLockRiskFactor float64 `protobuf:"fixed64,2,opt,name=time_lock_risk_factor,json=timeLockRiskFactor" json:"time_lock_risk_factor,omitempty"`
+	// *
+	// The cost of a failed payment attempt in satoshis. Each failure to be
+	// expected through a channel is weighed as if this fee were paid for it, so
+	// higher values favor more reliable routes.
+	AttemptCost int64 `protobuf:"varint,3,opt,name=attempt_cost,json=attemptCost" json:"attempt_cost,omitempty"`
+}
+
+func (m *CostModel) Reset()                    { *m = CostModel{} }
+func (m *CostModel) String() string            { return proto.CompactTextString(m) }
+func (*CostModel) ProtoMessage()               {}
+func (*CostModel) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *CostModel) GetFeeWeight() float64 {
+	if m != nil {
+		return m.FeeWeight
+	}
+	return 0
+}
+
+func (m *CostModel) GetTimeLockRiskFactor() float64 {
+	if m != nil {
+		return m.TimeLockRiskFactor
+	}
+	return 0
+}
+
+func (m *CostModel) GetAttemptCost() int64 {
+	if m != nil {
+		return m.AttemptCost
+	}
+	return 0
+}
+
 type QueryRoutesResponse struct {
 	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
 }
@@ -2456,7 +2984,7 @@ type QueryRoutesResponse struct {
 func (m *QueryRoutesResponse) Reset()                    { *m = QueryRoutesResponse{} }
 func (m *QueryRoutesResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryRoutesResponse) ProtoMessage()               {}
-func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+func (*QueryRoutesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 func (m *QueryRoutesResponse) GetRoutes() []*Route {
 	if m != nil {
@@ -2475,12 +3003,18 @@ type Hop struct {
 	AmtToForward int64  `protobuf:"varint,3,opt,name=amt_to_forward" json:"amt_to_forward,omitempty"`
 	Fee          int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
 	Expiry       uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// / The hex-encoded public key of the node at the end of this hop.
+	PubKey string `protobuf:"bytes,6,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / The amount forwarded by this hop in milli-satoshis.
+	AmtToForwardMsat int64 `protobuf:"varint,7,opt,name=amt_to_forward_msat" json:"amt_to_forward_msat,omitempty"`
+	// / The fee charged by the node at the start of this hop in milli-satoshis.
+	FeeMsat int64 `protobuf:"varint,8,opt,name=fee_msat" json:"fee_msat,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
 func (m *Hop) String() string            { return proto.CompactTextString(m) }
 func (*Hop) ProtoMessage()               {}
-func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+func (*Hop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
 
 func (m *Hop) GetChanId() uint64 {
 	if m != nil {
@@ -2517,6 +3051,27 @@ func (m *Hop) GetExpiry() uint32 {
 	return 0
 }
 
+func (m *Hop) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *Hop) GetAmtToForwardMsat() int64 {
+	if m != nil {
+		return m.AmtToForwardMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
 // *
 // A path through the channel graph which runs over one or more channels in
 // succession. This struct carries all the information required to craft the
@@ -2545,12 +3100,16 @@ type Route struct {
 	// *
 	// Contains details concerning the specific forwarding details at each hop.
 	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
+	// / The sum of the fees paid at each hop in milli-satoshis.
+	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+	// / The total amount sent along the route in milli-satoshis.
+	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
 }
 
 func (m *Route) Reset()                    { *m = Route{} }
 func (m *Route) String() string            { return proto.CompactTextString(m) }
 func (*Route) ProtoMessage()               {}
-func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
 
 func (m *Route) GetTotalTimeLock() uint32 {
 	if m != nil {
@@ -2580,24 +3139,363 @@ func (m *Route) GetHops() []*Hop {
 	return nil
 }
 
-type NodeInfoRequest struct {
-	// / The 33-byte hex-encoded compressed public of the target node
-	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
+func (m *Route) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
 }
 
-func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
-func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
-func (*NodeInfoRequest) ProtoMessage()               {}
-func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
-
-func (m *NodeInfoRequest) GetPubKey() string {
+func (m *Route) GetTotalAmtMsat() int64 {
 	if m != nil {
-		return m.PubKey
+		return m.TotalAmtMsat
 	}
-	return ""
+	return 0
 }
 
-type NodeInfo struct {
+type ProbeRequest struct {
+	// / The 33-byte hex-encoded public key for the payment destination
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / The amount to probe expressed in satoshis
+	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
+	// / The max number of routes to probe.
+	NumRoutes int32 `protobuf:"varint,3,opt,name=num_routes" json:"num_routes,omitempty"`
+	// *
+	// The maximum number of satoshis that may be paid as a fee along a probed
+	// route. If unset, the fee isn't limited.
+	FeeLimit *FeeLimit `protobuf:"bytes,4,opt,name=fee_limit" json:"fee_limit,omitempty"`
+	// *
+	// An optional maximum total time lock for the probed routes, expressed as a
+	// number of blocks from the current height. If zero, the time lock isn't
+	// limited.
+	CltvLimit uint32 `protobuf:"varint,5,opt,name=cltv_limit" json:"cltv_limit,omitempty"`
+	// *
+	// The CLTV delta to use for the final hop of the probed routes. If zero, the
+	// default delta is used.
+	FinalCltvDelta int32 `protobuf:"varint,6,opt,name=final_cltv_delta" json:"final_cltv_delta,omitempty"`
+}
+
+func (m *ProbeRequest) Reset()                    { *m = ProbeRequest{} }
+func (m *ProbeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProbeRequest) ProtoMessage()               {}
+func (*ProbeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *ProbeRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *ProbeRequest) GetAmt() int64 {
+	if m != nil {
+		return m.Amt
+	}
+	return 0
+}
+
+func (m *ProbeRequest) GetNumRoutes() int32 {
+	if m != nil {
+		return m.NumRoutes
+	}
+	return 0
+}
+
+func (m *ProbeRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *ProbeRequest) GetCltvLimit() uint32 {
+	if m != nil {
+		return m.CltvLimit
+	}
+	return 0
+}
+
+func (m *ProbeRequest) GetFinalCltvDelta() int32 {
+	if m != nil {
+		return m.FinalCltvDelta
+	}
+	return 0
+}
+
+type ProbeResponse struct {
+	// / The outcome of probing each of the candidate routes.
+	Results []*ProbeRouteResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *ProbeResponse) Reset()                    { *m = ProbeResponse{} }
+func (m *ProbeResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProbeResponse) ProtoMessage()               {}
+func (*ProbeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *ProbeResponse) GetResults() []*ProbeRouteResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ProbeRouteResult struct {
+	// / The probed route, carrying the full amount.
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+	// / Whether the full amount was able to reach the destination.
+	Success bool `protobuf:"varint,2,opt,name=success" json:"success,omitempty"`
+	// / The failure returned for the probe of the full amount, if any.
+	Failure string `protobuf:"bytes,3,opt,name=failure" json:"failure,omitempty"`
+	// *
+	// The channel id of the channel that lacked the liquidity to forward the
+	// full amount. Zero if no such channel was identified.
+	BottleneckChanId uint64 `protobuf:"varint,4,opt,name=bottleneck_chan_id" json:"bottleneck_chan_id,omitempty"`
+	// / The outcome for each hop of the route.
+	Hops []*ProbeHop `protobuf:"bytes,5,rep,name=hops" json:"hops,omitempty"`
+}
+
+func (m *ProbeRouteResult) Reset()                    { *m = ProbeRouteResult{} }
+func (m *ProbeRouteResult) String() string            { return proto.CompactTextString(m) }
+func (*ProbeRouteResult) ProtoMessage()               {}
+func (*ProbeRouteResult) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *ProbeRouteResult) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *ProbeRouteResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *ProbeRouteResult) GetFailure() string {
+	if m != nil {
+		return m.Failure
+	}
+	return ""
+}
+
+func (m *ProbeRouteResult) GetBottleneckChanId() uint64 {
+	if m != nil {
+		return m.BottleneckChanId
+	}
+	return 0
+}
+
+func (m *ProbeRouteResult) GetHops() []*ProbeHop {
+	if m != nil {
+		return m.Hops
+	}
+	return nil
+}
+
+type ProbeHop struct {
+	// / The channel id of the channel leading into the hop's node.
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The hex-encoded public key of the hop's node.
+	PubKey string `protobuf:"bytes,2,opt,name=pub_key" json:"pub_key,omitempty"`
+	// *
+	// The largest amount in milli-satoshis that was observed to reach the hop's
+	// node. Zero if none of the probes reached the node.
+	MaxAmtMsat int64 `protobuf:"varint,3,opt,name=max_amt_msat" json:"max_amt_msat,omitempty"`
+}
+
+func (m *ProbeHop) Reset()                    { *m = ProbeHop{} }
+func (m *ProbeHop) String() string            { return proto.CompactTextString(m) }
+func (*ProbeHop) ProtoMessage()               {}
+func (*ProbeHop) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *ProbeHop) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ProbeHop) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *ProbeHop) GetMaxAmtMsat() int64 {
+	if m != nil {
+		return m.MaxAmtMsat
+	}
+	return 0
+}
+
+type QueryMissionControlRequest struct {
+}
+
+func (m *QueryMissionControlRequest) Reset()                    { *m = QueryMissionControlRequest{} }
+func (m *QueryMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlRequest) ProtoMessage()               {}
+func (*QueryMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+// / QueryMissionControlResponse contains the mission control state.
+type QueryMissionControlResponse struct {
+	// / Node-level mission control state.
+	Nodes []*NodeHistory `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	// / Channel-level mission control state.
+	Channels []*ChannelHistory `protobuf:"bytes,2,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *QueryMissionControlResponse) Reset()                    { *m = QueryMissionControlResponse{} }
+func (m *QueryMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*QueryMissionControlResponse) ProtoMessage()               {}
+func (*QueryMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *QueryMissionControlResponse) GetNodes() []*NodeHistory {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *QueryMissionControlResponse) GetChannels() []*ChannelHistory {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+// / NodeHistory contains the mission control state for a node.
+type NodeHistory struct {
+	// / Node pubkey
+	Pubkey []byte `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	// / Time stamp of the last failure of the node.
+	LastFailTime int64 `protobuf:"varint,2,opt,name=last_fail_time" json:"last_fail_time,omitempty"`
+}
+
+func (m *NodeHistory) Reset()                    { *m = NodeHistory{} }
+func (m *NodeHistory) String() string            { return proto.CompactTextString(m) }
+func (*NodeHistory) ProtoMessage()               {}
+func (*NodeHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *NodeHistory) GetPubkey() []byte {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+func (m *NodeHistory) GetLastFailTime() int64 {
+	if m != nil {
+		return m.LastFailTime
+	}
+	return 0
+}
+
+// / ChannelHistory contains the mission control state for a channel.
+type ChannelHistory struct {
+	// / Short channel id
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// *
+	// Time stamp of the last failure of the channel. Zero if the channel didn't
+	// fail since its last success.
+	LastFailTime int64 `protobuf:"varint,2,opt,name=last_fail_time" json:"last_fail_time,omitempty"`
+	// / Minimum amount in milli-satoshis that the channel failed to forward.
+	MinPenalizeAmtMsat int64 `protobuf:"varint,3,opt,name=min_penalize_amt_msat" json:"min_penalize_amt_msat,omitempty"`
+	// *
+	// Time stamp of the last success of the channel. Zero if the channel didn't
+	// succeed since its last failure.
+	LastSuccessTime int64 `protobuf:"varint,4,opt,name=last_success_time" json:"last_success_time,omitempty"`
+	// / Maximum amount in milli-satoshis that the channel forwarded.
+	MaxSuccessAmtMsat int64 `protobuf:"varint,5,opt,name=max_success_amt_msat" json:"max_success_amt_msat,omitempty"`
+	// *
+	// Estimated success probability of the channel for the minimum penalized
+	// amount if it failed, or for the maximum succeeded amount otherwise.
+	SuccessProb float32 `protobuf:"fixed32,6,opt,name=success_prob" json:"success_prob,omitempty"`
+}
+
+func (m *ChannelHistory) Reset()                    { *m = ChannelHistory{} }
+func (m *ChannelHistory) String() string            { return proto.CompactTextString(m) }
+func (*ChannelHistory) ProtoMessage()               {}
+func (*ChannelHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *ChannelHistory) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *ChannelHistory) GetLastFailTime() int64 {
+	if m != nil {
+		return m.LastFailTime
+	}
+	return 0
+}
+
+func (m *ChannelHistory) GetMinPenalizeAmtMsat() int64 {
+	if m != nil {
+		return m.MinPenalizeAmtMsat
+	}
+	return 0
+}
+
+func (m *ChannelHistory) GetLastSuccessTime() int64 {
+	if m != nil {
+		return m.LastSuccessTime
+	}
+	return 0
+}
+
+func (m *ChannelHistory) GetMaxSuccessAmtMsat() int64 {
+	if m != nil {
+		return m.MaxSuccessAmtMsat
+	}
+	return 0
+}
+
+func (m *ChannelHistory) GetSuccessProb() float32 {
+	if m != nil {
+		return m.SuccessProb
+	}
+	return 0
+}
+
+type ResetMissionControlRequest struct {
+}
+
+func (m *ResetMissionControlRequest) Reset()                    { *m = ResetMissionControlRequest{} }
+func (m *ResetMissionControlRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlRequest) ProtoMessage()               {}
+func (*ResetMissionControlRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+type ResetMissionControlResponse struct {
+}
+
+func (m *ResetMissionControlResponse) Reset()                    { *m = ResetMissionControlResponse{} }
+func (m *ResetMissionControlResponse) String() string            { return proto.CompactTextString(m) }
+func (*ResetMissionControlResponse) ProtoMessage()               {}
+func (*ResetMissionControlResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+type NodeInfoRequest struct {
+	// / The 33-byte hex-encoded compressed public of the target node
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
+}
+
+func (m *NodeInfoRequest) Reset()                    { *m = NodeInfoRequest{} }
+func (m *NodeInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeInfoRequest) ProtoMessage()               {}
+func (*NodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *NodeInfoRequest) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+type NodeInfo struct {
 	// *
 	// An individual vertex/node within the channel graph. A node is
 	// connected to other nodes by one or more channel edges emanating from it. As
@@ -2611,7 +3509,7 @@ type NodeInfo struct {
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
 func (m *NodeInfo) String() string            { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()               {}
-func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+func (*NodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
 
 func (m *NodeInfo) GetNode() *LightningNode {
 	if m != nil {
@@ -2650,7 +3548,7 @@ type LightningNode struct {
 func (m *LightningNode) Reset()                    { *m = LightningNode{} }
 func (m *LightningNode) String() string            { return proto.CompactTextString(m) }
 func (*LightningNode) ProtoMessage()               {}
-func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+func (*LightningNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
 
 func (m *LightningNode) GetLastUpdate() uint32 {
 	if m != nil {
@@ -2695,7 +3593,7 @@ type NodeAddress struct {
 func (m *NodeAddress) Reset()                    { *m = NodeAddress{} }
 func (m *NodeAddress) String() string            { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()               {}
-func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+func (*NodeAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
 
 func (m *NodeAddress) GetNetwork() string {
 	if m != nil {
@@ -2721,7 +3619,7 @@ type RoutingPolicy struct {
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
 func (m *RoutingPolicy) String() string            { return proto.CompactTextString(m) }
 func (*RoutingPolicy) ProtoMessage()               {}
-func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+func (*RoutingPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
 
 func (m *RoutingPolicy) GetTimeLockDelta() uint32 {
 	if m != nil {
@@ -2775,7 +3673,7 @@ type ChannelEdge struct {
 func (m *ChannelEdge) Reset()                    { *m = ChannelEdge{} }
 func (m *ChannelEdge) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdge) ProtoMessage()               {}
-func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+func (*ChannelEdge) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
 
 func (m *ChannelEdge) GetChannelId() uint64 {
 	if m != nil {
@@ -2839,7 +3737,7 @@ type ChannelGraphRequest struct {
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
 func (m *ChannelGraphRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraphRequest) ProtoMessage()               {}
-func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
 
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
@@ -2852,7 +3750,7 @@ type ChannelGraph struct {
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
 func (m *ChannelGraph) String() string            { return proto.CompactTextString(m) }
 func (*ChannelGraph) ProtoMessage()               {}
-func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+func (*ChannelGraph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *ChannelGraph) GetNodes() []*LightningNode {
 	if m != nil {
@@ -2879,7 +3777,7 @@ type ChanInfoRequest struct {
 func (m *ChanInfoRequest) Reset()                    { *m = ChanInfoRequest{} }
 func (m *ChanInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*ChanInfoRequest) ProtoMessage()               {}
-func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+func (*ChanInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
 
 func (m *ChanInfoRequest) GetChanId() uint64 {
 	if m != nil {
@@ -2894,7 +3792,7 @@ type NetworkInfoRequest struct {
 func (m *NetworkInfoRequest) Reset()                    { *m = NetworkInfoRequest{} }
 func (m *NetworkInfoRequest) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfoRequest) ProtoMessage()               {}
-func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+func (*NetworkInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
 
 type NetworkInfo struct {
 	GraphDiameter        uint32  `protobuf:"varint,1,opt,name=graph_diameter" json:"graph_diameter,omitempty"`
@@ -2906,12 +3804,22 @@ type NetworkInfo struct {
 	AvgChannelSize       float64 `protobuf:"fixed64,7,opt,name=avg_channel_size" json:"avg_channel_size,omitempty"`
 	MinChannelSize       int64   `protobuf:"varint,8,opt,name=min_channel_size" json:"min_channel_size,omitempty"`
 	MaxChannelSize       int64   `protobuf:"varint,9,opt,name=max_channel_size" json:"max_channel_size,omitempty"`
+	// / The number of channel updates rejected as stale or duplicate.
+	NumStaleUpdates uint64 `protobuf:"varint,10,opt,name=num_stale_updates" json:"num_stale_updates,omitempty"`
+	// *
+	// The number of channel updates that were applied to the graph, but not
+	// relayed, as their channel exceeded its rate limit.
+	NumChanRateLimitedUpdates uint64 `protobuf:"varint,11,opt,name=num_chan_rate_limited_updates" json:"num_chan_rate_limited_updates,omitempty"`
+	// *
+	// The number of channel updates that were applied to the graph, but not
+	// relayed, as the peer that sent them exceeded its rate limit.
+	NumPeerRateLimitedUpdates uint64 `protobuf:"varint,12,opt,name=num_peer_rate_limited_updates" json:"num_peer_rate_limited_updates,omitempty"`
 }
 
 func (m *NetworkInfo) Reset()                    { *m = NetworkInfo{} }
 func (m *NetworkInfo) String() string            { return proto.CompactTextString(m) }
 func (*NetworkInfo) ProtoMessage()               {}
-func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+func (*NetworkInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
 func (m *NetworkInfo) GetGraphDiameter() uint32 {
 	if m != nil {
@@ -2976,93 +3884,209 @@ func (m *NetworkInfo) GetMaxChannelSize() int64 {
 	return 0
 }
 
-type StopRequest struct {
+func (m *NetworkInfo) GetNumStaleUpdates() uint64 {
+	if m != nil {
+		return m.NumStaleUpdates
+	}
+	return 0
 }
 
-func (m *StopRequest) Reset()                    { *m = StopRequest{} }
-func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
-func (*StopRequest) ProtoMessage()               {}
-func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
-
-type StopResponse struct {
+func (m *NetworkInfo) GetNumChanRateLimitedUpdates() uint64 {
+	if m != nil {
+		return m.NumChanRateLimitedUpdates
+	}
+	return 0
 }
 
-func (m *StopResponse) Reset()                    { *m = StopResponse{} }
-func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
-func (*StopResponse) ProtoMessage()               {}
-func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+func (m *NetworkInfo) GetNumPeerRateLimitedUpdates() uint64 {
+	if m != nil {
+		return m.NumPeerRateLimitedUpdates
+	}
+	return 0
+}
 
-type GraphTopologySubscription struct {
+type ExportGraphRequest struct {
 }
 
-func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
-func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
-func (*GraphTopologySubscription) ProtoMessage()               {}
-func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+func (m *ExportGraphRequest) Reset()                    { *m = ExportGraphRequest{} }
+func (m *ExportGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphRequest) ProtoMessage()               {}
+func (*ExportGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
 
-type GraphTopologyUpdate struct {
-	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
-	ChannelUpdates []*ChannelEdgeUpdate   `protobuf:"bytes,2,rep,name=channel_updates,json=channelUpdates" json:"channel_updates,omitempty"`
-	ClosedChans    []*ClosedChannelUpdate `protobuf:"bytes,3,rep,name=closed_chans,json=closedChans" json:"closed_chans,omitempty"`
+type ExportGraphResponse struct {
+	// / The serialized graph snapshot.
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 }
 
-func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
-func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
-func (*GraphTopologyUpdate) ProtoMessage()               {}
-func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+func (m *ExportGraphResponse) Reset()                    { *m = ExportGraphResponse{} }
+func (m *ExportGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportGraphResponse) ProtoMessage()               {}
+func (*ExportGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
 
-func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
+func (m *ExportGraphResponse) GetSnapshot() []byte {
 	if m != nil {
-		return m.NodeUpdates
+		return m.Snapshot
 	}
 	return nil
 }
 
-func (m *GraphTopologyUpdate) GetChannelUpdates() []*ChannelEdgeUpdate {
-	if m != nil {
-		return m.ChannelUpdates
-	}
-	return nil
+type ImportGraphRequest struct {
+	// / The serialized graph snapshot, as returned by ExportGraph.
+	Snapshot []byte `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 }
 
-func (m *GraphTopologyUpdate) GetClosedChans() []*ClosedChannelUpdate {
+func (m *ImportGraphRequest) Reset()                    { *m = ImportGraphRequest{} }
+func (m *ImportGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphRequest) ProtoMessage()               {}
+func (*ImportGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *ImportGraphRequest) GetSnapshot() []byte {
 	if m != nil {
-		return m.ClosedChans
+		return m.Snapshot
 	}
 	return nil
 }
 
-type NodeUpdate struct {
-	Addresses      []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
-	IdentityKey    string   `protobuf:"bytes,2,opt,name=identity_key,json=identityKey" json:"identity_key,omitempty"`
-	GlobalFeatures []byte   `protobuf:"bytes,3,opt,name=global_features,json=globalFeatures,proto3" json:"global_features,omitempty"`
-	Alias          string   `protobuf:"bytes,4,opt,name=alias" json:"alias,omitempty"`
+type ImportGraphResponse struct {
+	// / The number of channels that were added to the graph.
+	NumChannels uint32 `protobuf:"varint,1,opt,name=num_channels" json:"num_channels,omitempty"`
+	// / The number of channel updates that were applied to the graph.
+	NumUpdates uint32 `protobuf:"varint,2,opt,name=num_updates" json:"num_updates,omitempty"`
+	// / The number of node announcements that were applied to the graph.
+	NumNodes uint32 `protobuf:"varint,3,opt,name=num_nodes" json:"num_nodes,omitempty"`
+	// / The number of announcements that were skipped as they were already known.
+	NumSkipped uint32 `protobuf:"varint,4,opt,name=num_skipped" json:"num_skipped,omitempty"`
+	// / The number of announcements that were rejected as they failed validation.
+	NumInvalid uint32 `protobuf:"varint,5,opt,name=num_invalid" json:"num_invalid,omitempty"`
 }
 
-func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
-func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
-func (*NodeUpdate) ProtoMessage()               {}
-func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+func (m *ImportGraphResponse) Reset()                    { *m = ImportGraphResponse{} }
+func (m *ImportGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportGraphResponse) ProtoMessage()               {}
+func (*ImportGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
-func (m *NodeUpdate) GetAddresses() []string {
+func (m *ImportGraphResponse) GetNumChannels() uint32 {
 	if m != nil {
-		return m.Addresses
+		return m.NumChannels
 	}
-	return nil
+	return 0
 }
 
-func (m *NodeUpdate) GetIdentityKey() string {
+func (m *ImportGraphResponse) GetNumUpdates() uint32 {
 	if m != nil {
-		return m.IdentityKey
+		return m.NumUpdates
 	}
-	return ""
+	return 0
 }
 
-func (m *NodeUpdate) GetGlobalFeatures() []byte {
+func (m *ImportGraphResponse) GetNumNodes() uint32 {
 	if m != nil {
-		return m.GlobalFeatures
+		return m.NumNodes
 	}
-	return nil
+	return 0
+}
+
+func (m *ImportGraphResponse) GetNumSkipped() uint32 {
+	if m != nil {
+		return m.NumSkipped
+	}
+	return 0
+}
+
+func (m *ImportGraphResponse) GetNumInvalid() uint32 {
+	if m != nil {
+		return m.NumInvalid
+	}
+	return 0
+}
+
+type StopRequest struct {
+}
+
+func (m *StopRequest) Reset()                    { *m = StopRequest{} }
+func (m *StopRequest) String() string            { return proto.CompactTextString(m) }
+func (*StopRequest) ProtoMessage()               {}
+func (*StopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+type StopResponse struct {
+}
+
+func (m *StopResponse) Reset()                    { *m = StopResponse{} }
+func (m *StopResponse) String() string            { return proto.CompactTextString(m) }
+func (*StopResponse) ProtoMessage()               {}
+func (*StopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+type GraphTopologySubscription struct {
+}
+
+func (m *GraphTopologySubscription) Reset()                    { *m = GraphTopologySubscription{} }
+func (m *GraphTopologySubscription) String() string            { return proto.CompactTextString(m) }
+func (*GraphTopologySubscription) ProtoMessage()               {}
+func (*GraphTopologySubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+type GraphTopologyUpdate struct {
+	NodeUpdates    []*NodeUpdate          `protobuf:"bytes,1,rep,name=node_updates,json=nodeUpdates" json:"node_updates,omitempty"`
+	ChannelUpdates []*ChannelEdgeUpdate   `protobuf:"bytes,2,rep,name=channel_updates,json=channelUpdates" json:"channel_updates,omitempty"`
+	ClosedChans    []*ClosedChannelUpdate `protobuf:"bytes,3,rep,name=closed_chans,json=closedChans" json:"closed_chans,omitempty"`
+}
+
+func (m *GraphTopologyUpdate) Reset()                    { *m = GraphTopologyUpdate{} }
+func (m *GraphTopologyUpdate) String() string            { return proto.CompactTextString(m) }
+func (*GraphTopologyUpdate) ProtoMessage()               {}
+func (*GraphTopologyUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+func (m *GraphTopologyUpdate) GetNodeUpdates() []*NodeUpdate {
+	if m != nil {
+		return m.NodeUpdates
+	}
+	return nil
+}
+
+func (m *GraphTopologyUpdate) GetChannelUpdates() []*ChannelEdgeUpdate {
+	if m != nil {
+		return m.ChannelUpdates
+	}
+	return nil
+}
+
+func (m *GraphTopologyUpdate) GetClosedChans() []*ClosedChannelUpdate {
+	if m != nil {
+		return m.ClosedChans
+	}
+	return nil
+}
+
+type NodeUpdate struct {
+	Addresses      []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+	IdentityKey    string   `protobuf:"bytes,2,opt,name=identity_key,json=identityKey" json:"identity_key,omitempty"`
+	GlobalFeatures []byte   `protobuf:"bytes,3,opt,name=global_features,json=globalFeatures,proto3" json:"global_features,omitempty"`
+	Alias          string   `protobuf:"bytes,4,opt,name=alias" json:"alias,omitempty"`
+}
+
+func (m *NodeUpdate) Reset()                    { *m = NodeUpdate{} }
+func (m *NodeUpdate) String() string            { return proto.CompactTextString(m) }
+func (*NodeUpdate) ProtoMessage()               {}
+func (*NodeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *NodeUpdate) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+func (m *NodeUpdate) GetIdentityKey() string {
+	if m != nil {
+		return m.IdentityKey
+	}
+	return ""
+}
+
+func (m *NodeUpdate) GetGlobalFeatures() []byte {
+	if m != nil {
+		return m.GlobalFeatures
+	}
+	return nil
 }
 
 func (m *NodeUpdate) GetAlias() string {
@@ -3088,7 +4112,7 @@ type ChannelEdgeUpdate struct {
 func (m *ChannelEdgeUpdate) Reset()                    { *m = ChannelEdgeUpdate{} }
 func (m *ChannelEdgeUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ChannelEdgeUpdate) ProtoMessage()               {}
-func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+func (*ChannelEdgeUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *ChannelEdgeUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3146,7 +4170,7 @@ type ClosedChannelUpdate struct {
 func (m *ClosedChannelUpdate) Reset()                    { *m = ClosedChannelUpdate{} }
 func (m *ClosedChannelUpdate) String() string            { return proto.CompactTextString(m) }
 func (*ClosedChannelUpdate) ProtoMessage()               {}
-func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+func (*ClosedChannelUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 func (m *ClosedChannelUpdate) GetChanId() uint64 {
 	if m != nil {
@@ -3176,6 +4200,80 @@ func (m *ClosedChannelUpdate) GetChanPoint() *ChannelPoint {
 	return nil
 }
 
+type HopHint struct {
+	// / The public key of the node at the start of the channel.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id" json:"node_id,omitempty"`
+	// / The unique identifier of the channel.
+	ChanId uint64 `protobuf:"varint,2,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The base fee of the channel denominated in millisatoshis.
+	FeeBaseMsat uint32 `protobuf:"varint,3,opt,name=fee_base_msat" json:"fee_base_msat,omitempty"`
+	// *
+	// The fee rate of the channel for sending one satoshi across it denominated in
+	// millionths of a satoshi.
+	FeeProportionalMillionths uint32 `protobuf:"varint,4,opt,name=fee_proportional_millionths" json:"fee_proportional_millionths,omitempty"`
+	// / The time-lock delta of the channel.
+	CltvExpiryDelta uint32 `protobuf:"varint,5,opt,name=cltv_expiry_delta" json:"cltv_expiry_delta,omitempty"`
+}
+
+func (m *HopHint) Reset()                    { *m = HopHint{} }
+func (m *HopHint) String() string            { return proto.CompactTextString(m) }
+func (*HopHint) ProtoMessage()               {}
+func (*HopHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+func (m *HopHint) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *HopHint) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
+	}
+	return 0
+}
+
+func (m *HopHint) GetFeeBaseMsat() uint32 {
+	if m != nil {
+		return m.FeeBaseMsat
+	}
+	return 0
+}
+
+func (m *HopHint) GetFeeProportionalMillionths() uint32 {
+	if m != nil {
+		return m.FeeProportionalMillionths
+	}
+	return 0
+}
+
+func (m *HopHint) GetCltvExpiryDelta() uint32 {
+	if m != nil {
+		return m.CltvExpiryDelta
+	}
+	return 0
+}
+
+type RouteHint struct {
+	// *
+	// A list of hop hints that when chained together can assist in reaching a
+	// specific destination.
+	HopHints []*HopHint `protobuf:"bytes,1,rep,name=hop_hints" json:"hop_hints,omitempty"`
+}
+
+func (m *RouteHint) Reset()                    { *m = RouteHint{} }
+func (m *RouteHint) String() string            { return proto.CompactTextString(m) }
+func (*RouteHint) ProtoMessage()               {}
+func (*RouteHint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+
+func (m *RouteHint) GetHopHints() []*HopHint {
+	if m != nil {
+		return m.HopHints
+	}
+	return nil
+}
+
 type Invoice struct {
 	// *
 	// An optional memo to attach along with the invoice. Used for record keeping
@@ -3189,7 +4287,11 @@ type Invoice struct {
 	// The hex-encoded preimage (32 byte) which will allow settling an incoming
 	// HTLC payable to this preimage
 	RPreimage []byte `protobuf:"bytes,3,opt,name=r_preimage,proto3" json:"r_preimage,omitempty"`
-	// / The hash of the preimage
+	// *
+	// The hash of the preimage. If set while r_preimage is left empty, a hold
+	// invoice is created: HTLCs paying to it are accepted and held until the
+	// invoice is either settled with SettleInvoice or canceled with
+	// CancelInvoice.
 	RHash []byte `protobuf:"bytes,4,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
 	// / The value of this invoice in satoshis
 	Value int64 `protobuf:"varint,5,opt,name=value" json:"value,omitempty"`
@@ -3215,12 +4317,32 @@ type Invoice struct {
 	FallbackAddr string `protobuf:"bytes,12,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
 	// / Delta to use for the time-lock of the CLTV extended to the final hop.
 	CltvExpiry uint64 `protobuf:"varint,13,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	// *
+	// Route hints that can each be individually used to assist in reaching the
+	// invoice's destination.
+	RouteHints []*RouteHint `protobuf:"bytes,14,rep,name=route_hints" json:"route_hints,omitempty"`
+	// / Whether this invoice should include routing hints for private channels.
+	Private bool `protobuf:"varint,15,opt,name=private" json:"private,omitempty"`
+	// / The state the invoice is in.
+	State Invoice_InvoiceState `protobuf:"varint,16,opt,name=state,enum=lnrpc.Invoice_InvoiceState" json:"state,omitempty"`
+	// *
+	// The "add" index of this invoice. Each newly created invoice will increment
+	// this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all added
+	// invoices with an add_index greater than this one.
+	AddIndex uint64 `protobuf:"varint,17,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// The "settle" index of this invoice. Each newly settled invoice will
+	// increment this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all
+	// settled invoices with an settle_index greater than this one.
+	SettleIndex uint64 `protobuf:"varint,18,opt,name=settle_index" json:"settle_index,omitempty"`
 }
 
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -3313,6 +4435,41 @@ func (m *Invoice) GetCltvExpiry() uint64 {
 	return 0
 }
 
+func (m *Invoice) GetRouteHints() []*RouteHint {
+	if m != nil {
+		return m.RouteHints
+	}
+	return nil
+}
+
+func (m *Invoice) GetPrivate() bool {
+	if m != nil {
+		return m.Private
+	}
+	return false
+}
+
+func (m *Invoice) GetState() Invoice_InvoiceState {
+	if m != nil {
+		return m.State
+	}
+	return Invoice_OPEN
+}
+
+func (m *Invoice) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
 type AddInvoiceResponse struct {
 	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
 	// *
@@ -3320,12 +4477,18 @@ type AddInvoiceResponse struct {
 	// details of the invoice, the sender has all the data necessary to send a
 	// payment to the recipient.
 	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+	// *
+	// The "add" index of this invoice. Each newly created invoice will increment
+	// this index making it monotonically increasing. Callers to the
+	// SubscribeInvoices call can use this to instantly get notified of all added
+	// invoices with an add_index greater than this one.
+	AddIndex uint64 `protobuf:"varint,16,opt,name=add_index" json:"add_index,omitempty"`
 }
 
 func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
 func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *AddInvoiceResponse) GetRHash() []byte {
 	if m != nil {
@@ -3341,6 +4504,13 @@ func (m *AddInvoiceResponse) GetPaymentRequest() string {
 	return ""
 }
 
+func (m *AddInvoiceResponse) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
 type PaymentHash struct {
 	// *
 	// The hex-encoded payment hash of the invoice to be looked up. The passed
@@ -3350,145 +4520,663 @@ type PaymentHash struct {
 	RHash []byte `protobuf:"bytes,2,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
 }
 
-func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
-func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
-func (*PaymentHash) ProtoMessage()               {}
-func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
-
-func (m *PaymentHash) GetRHashStr() string {
+func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
+func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
+func (*PaymentHash) ProtoMessage()               {}
+func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+
+func (m *PaymentHash) GetRHashStr() string {
+	if m != nil {
+		return m.RHashStr
+	}
+	return ""
+}
+
+func (m *PaymentHash) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+type ListInvoiceRequest struct {
+	// / Toggles if all invoices should be returned, or only those that are currently open or accepted.
+	PendingOnly bool `protobuf:"varint,1,opt,name=pending_only,json=pendingOnly" json:"pending_only,omitempty"`
+	// *
+	// The index of an invoice that will be used as either the start or end of a
+	// query to determine which invoices should be returned in the response.
+	IndexOffset uint64 `protobuf:"varint,4,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The max number of invoices to return in the response to this query. If zero, all matching invoices are returned.
+	NumMaxInvoices uint64 `protobuf:"varint,5,opt,name=num_max_invoices" json:"num_max_invoices,omitempty"`
+	// *
+	// If set, the invoices returned will result from seeking backwards from the
+	// specified index offset. This can be used to paginate backwards.
+	Reversed bool `protobuf:"varint,6,opt,name=reversed" json:"reversed,omitempty"`
+	// / If set, only invoices created at or after this unix timestamp are returned.
+	CreationDateStart uint64 `protobuf:"varint,7,opt,name=creation_date_start" json:"creation_date_start,omitempty"`
+	// / If set, only invoices created at or before this unix timestamp are returned.
+	CreationDateEnd uint64 `protobuf:"varint,8,opt,name=creation_date_end" json:"creation_date_end,omitempty"`
+}
+
+func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
+func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListInvoiceRequest) ProtoMessage()               {}
+func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+func (m *ListInvoiceRequest) GetPendingOnly() bool {
+	if m != nil {
+		return m.PendingOnly
+	}
+	return false
+}
+
+func (m *ListInvoiceRequest) GetIndexOffset() uint64 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetNumMaxInvoices() uint64 {
+	if m != nil {
+		return m.NumMaxInvoices
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetReversed() bool {
+	if m != nil {
+		return m.Reversed
+	}
+	return false
+}
+
+func (m *ListInvoiceRequest) GetCreationDateStart() uint64 {
+	if m != nil {
+		return m.CreationDateStart
+	}
+	return 0
+}
+
+func (m *ListInvoiceRequest) GetCreationDateEnd() uint64 {
+	if m != nil {
+		return m.CreationDateEnd
+	}
+	return 0
+}
+
+type ListInvoiceResponse struct {
+	// *
+	// A list of invoices from the time slice of the time series specified in the
+	// request.
+	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
+	// *
+	// The index of the last item in the set of returned invoices. This can be used
+	// to seek further, pagination style.
+	LastIndexOffset uint64 `protobuf:"varint,2,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
+	// *
+	// The index of the first item in the set of returned invoices. This can be
+	// used to seek backwards, pagination style.
+	FirstIndexOffset uint64 `protobuf:"varint,3,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+}
+
+func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
+func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListInvoiceResponse) ProtoMessage()               {}
+func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
+	if m != nil {
+		return m.Invoices
+	}
+	return nil
+}
+
+func (m *ListInvoiceResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
+func (m *ListInvoiceResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
+	}
+	return 0
+}
+
+type InvoiceSubscription struct {
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all added indexes with an add_index greater than this
+	// value. This allows callers to catch up on any events they missed while they
+	// weren't connected to the streaming RPC.
+	AddIndex uint64 `protobuf:"varint,1,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// If specified (non-zero), then we'll first start by sending out
+	// notifications for all settled indexes with an settle_index greater than
+	// this value. This allows callers to catch up on any events they missed while
+	// they weren't connected to the streaming RPC.
+	SettleIndex uint64 `protobuf:"varint,2,opt,name=settle_index" json:"settle_index,omitempty"`
+}
+
+func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
+func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
+func (*InvoiceSubscription) ProtoMessage()               {}
+func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+func (m *InvoiceSubscription) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *InvoiceSubscription) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
+type SettleInvoiceRequest struct {
+	// / The preimage of the hold invoice to be settled.
+	Preimage []byte `protobuf:"bytes,1,opt,name=preimage,proto3" json:"preimage,omitempty"`
+}
+
+func (m *SettleInvoiceRequest) Reset()                    { *m = SettleInvoiceRequest{} }
+func (m *SettleInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceRequest) ProtoMessage()               {}
+func (*SettleInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+func (m *SettleInvoiceRequest) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
+}
+
+type SettleInvoiceResponse struct {
+}
+
+func (m *SettleInvoiceResponse) Reset()                    { *m = SettleInvoiceResponse{} }
+func (m *SettleInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*SettleInvoiceResponse) ProtoMessage()               {}
+func (*SettleInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+type CancelInvoiceRequest struct {
+	// / The payment hash of the invoice to be canceled.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+}
+
+func (m *CancelInvoiceRequest) Reset()                    { *m = CancelInvoiceRequest{} }
+func (m *CancelInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceRequest) ProtoMessage()               {}
+func (*CancelInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+func (m *CancelInvoiceRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+type CancelInvoiceResponse struct {
+}
+
+func (m *CancelInvoiceResponse) Reset()                    { *m = CancelInvoiceResponse{} }
+func (m *CancelInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*CancelInvoiceResponse) ProtoMessage()               {}
+func (*CancelInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+type Payment struct {
+	// / The payment hash
+	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// / The value of the payment in satoshis
+	Value int64 `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
+	// / The date of this payment
+	CreationDate int64 `protobuf:"varint,3,opt,name=creation_date" json:"creation_date,omitempty"`
+	// / The path this payment took
+	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
+	// / The fee paid for this payment in satoshis
+	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
+	// / The payment preimage
+	PaymentPreimage string `protobuf:"bytes,6,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
+	// / The optional payment request being fulfilled.
+	PaymentRequest string `protobuf:"bytes,7,opt,name=payment_request" json:"payment_request,omitempty"`
+	// / The status of the payment.
+	Status Payment_PaymentStatus `protobuf:"varint,8,opt,name=status,enum=lnrpc.Payment_PaymentStatus" json:"status,omitempty"`
+	// / The value of the payment in milli-satoshis.
+	ValueMsat int64 `protobuf:"varint,9,opt,name=value_msat" json:"value_msat,omitempty"`
+	// / The fee paid for this payment in milli-satoshis.
+	FeeMsat int64 `protobuf:"varint,10,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The time in UNIX nanoseconds at which the payment was created.
+	CreationTimeNs int64 `protobuf:"varint,11,opt,name=creation_time_ns" json:"creation_time_ns,omitempty"`
+	// / The HTLCs made in attempt to settle the payment, in attempt order.
+	Htlcs []*HTLCAttempt `protobuf:"bytes,12,rep,name=htlcs" json:"htlcs,omitempty"`
+	// *
+	// The index of the payment, which can be used as the index offset of a
+	// ListPayments query.
+	PaymentIndex uint64 `protobuf:"varint,13,opt,name=payment_index" json:"payment_index,omitempty"`
+	// / The reason the payment failed, if it did.
+	FailureReason Payment_FailureReason `protobuf:"varint,14,opt,name=failure_reason,enum=lnrpc.Payment_FailureReason" json:"failure_reason,omitempty"`
+}
+
+func (m *Payment) Reset()                    { *m = Payment{} }
+func (m *Payment) String() string            { return proto.CompactTextString(m) }
+func (*Payment) ProtoMessage()               {}
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+func (m *Payment) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *Payment) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Payment) GetCreationDate() int64 {
+	if m != nil {
+		return m.CreationDate
+	}
+	return 0
+}
+
+func (m *Payment) GetPath() []string {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *Payment) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *Payment) GetPaymentPreimage() string {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return ""
+}
+
+func (m *Payment) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+func (m *Payment) GetStatus() Payment_PaymentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return Payment_UNKNOWN
+}
+
+func (m *Payment) GetValueMsat() int64 {
+	if m != nil {
+		return m.ValueMsat
+	}
+	return 0
+}
+
+func (m *Payment) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *Payment) GetCreationTimeNs() int64 {
+	if m != nil {
+		return m.CreationTimeNs
+	}
+	return 0
+}
+
+func (m *Payment) GetHtlcs() []*HTLCAttempt {
+	if m != nil {
+		return m.Htlcs
+	}
+	return nil
+}
+
+func (m *Payment) GetPaymentIndex() uint64 {
+	if m != nil {
+		return m.PaymentIndex
+	}
+	return 0
+}
+
+func (m *Payment) GetFailureReason() Payment_FailureReason {
+	if m != nil {
+		return m.FailureReason
+	}
+	return Payment_FAILURE_REASON_NONE
+}
+
+type HTLCAttempt struct {
+	// / The unique ID of the attempt.
+	AttemptId uint64 `protobuf:"varint,1,opt,name=attempt_id" json:"attempt_id,omitempty"`
+	// / The status of the HTLC.
+	Status HTLCAttempt_HTLCStatus `protobuf:"varint,2,opt,name=status,enum=lnrpc.HTLCAttempt_HTLCStatus" json:"status,omitempty"`
+	// / The route taken by this HTLC.
+	Route *Route `protobuf:"bytes,3,opt,name=route" json:"route,omitempty"`
+	// / The time in UNIX nanoseconds at which this HTLC was sent.
+	AttemptTimeNs int64 `protobuf:"varint,4,opt,name=attempt_time_ns" json:"attempt_time_ns,omitempty"`
+	// *
+	// The time in UNIX nanoseconds at which this HTLC was settled or failed.
+	// This value will not be set if the HTLC is still in flight.
+	ResolveTimeNs int64 `protobuf:"varint,5,opt,name=resolve_time_ns" json:"resolve_time_ns,omitempty"`
+	// / Detailed htlc failure info, if the HTLC failed.
+	Failure *HTLCFailure `protobuf:"bytes,6,opt,name=failure" json:"failure,omitempty"`
+}
+
+func (m *HTLCAttempt) Reset()                    { *m = HTLCAttempt{} }
+func (m *HTLCAttempt) String() string            { return proto.CompactTextString(m) }
+func (*HTLCAttempt) ProtoMessage()               {}
+func (*HTLCAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *HTLCAttempt) GetAttemptId() uint64 {
+	if m != nil {
+		return m.AttemptId
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetStatus() HTLCAttempt_HTLCStatus {
+	if m != nil {
+		return m.Status
+	}
+	return HTLCAttempt_IN_FLIGHT
+}
+
+func (m *HTLCAttempt) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *HTLCAttempt) GetAttemptTimeNs() int64 {
+	if m != nil {
+		return m.AttemptTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetResolveTimeNs() int64 {
+	if m != nil {
+		return m.ResolveTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetFailure() *HTLCFailure {
+	if m != nil {
+		return m.Failure
+	}
+	return nil
+}
+
+type HTLCFailure struct {
+	// / The reason the HTLC failed.
+	Reason HTLCFailure_FailureReason `protobuf:"varint,1,opt,name=reason,enum=lnrpc.HTLCFailure_FailureReason" json:"reason,omitempty"`
+	// / The BOLT #4 failure code of the failure message, if any.
+	Code uint32 `protobuf:"varint,2,opt,name=code" json:"code,omitempty"`
+	// / A human readable description of the failure message, if any.
+	Message string `protobuf:"bytes,3,opt,name=message" json:"message,omitempty"`
+	// *
+	// The position in the route of the node that generated the failure. Position
+	// zero is the sender node, and position i the node at the end of hop i.
+	FailureSourceIndex uint32 `protobuf:"varint,4,opt,name=failure_source_index" json:"failure_source_index,omitempty"`
+}
+
+func (m *HTLCFailure) Reset()                    { *m = HTLCFailure{} }
+func (m *HTLCFailure) String() string            { return proto.CompactTextString(m) }
+func (*HTLCFailure) ProtoMessage()               {}
+func (*HTLCFailure) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+func (m *HTLCFailure) GetReason() HTLCFailure_FailureReason {
+	if m != nil {
+		return m.Reason
+	}
+	return HTLCFailure_UNKNOWN
+}
+
+func (m *HTLCFailure) GetCode() uint32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *HTLCFailure) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *HTLCFailure) GetFailureSourceIndex() uint32 {
+	if m != nil {
+		return m.FailureSourceIndex
+	}
+	return 0
+}
+
+type RebalanceRequest struct {
+	// / The channel that the funds should leave through.
+	OutgoingChanId uint64 `protobuf:"varint,1,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The channel that the funds should arrive through.
+	IncomingChanId uint64 `protobuf:"varint,2,opt,name=incoming_chan_id" json:"incoming_chan_id,omitempty"`
+	// / The amount to shift between the channels in satoshis.
+	Amt int64 `protobuf:"varint,3,opt,name=amt" json:"amt,omitempty"`
+	// *
+	// The maximum fee in satoshis that may be paid for the rebalance. If zero,
+	// then the fee isn't limited.
+	MaxFee int64 `protobuf:"varint,4,opt,name=max_fee" json:"max_fee,omitempty"`
+}
+
+func (m *RebalanceRequest) Reset()                    { *m = RebalanceRequest{} }
+func (m *RebalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*RebalanceRequest) ProtoMessage()               {}
+func (*RebalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+func (m *RebalanceRequest) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetIncomingChanId() uint64 {
+	if m != nil {
+		return m.IncomingChanId
+	}
+	return 0
+}
+
+func (m *RebalanceRequest) GetAmt() int64 {
 	if m != nil {
-		return m.RHashStr
+		return m.Amt
 	}
-	return ""
+	return 0
 }
 
-func (m *PaymentHash) GetRHash() []byte {
+func (m *RebalanceRequest) GetMaxFee() int64 {
 	if m != nil {
-		return m.RHash
+		return m.MaxFee
 	}
-	return nil
+	return 0
 }
 
-type ListInvoiceRequest struct {
-	// / Toggles if all invoices should be returned, or only those that are currently unsettled.
-	PendingOnly bool `protobuf:"varint,1,opt,name=pending_only,json=pendingOnly" json:"pending_only,omitempty"`
+type RebalanceResponse struct {
+	// / The error that caused the rebalance to fail, if any.
+	PaymentError string `protobuf:"bytes,1,opt,name=payment_error" json:"payment_error,omitempty"`
+	// / The preimage of the internal invoice paid by the rebalance.
+	PaymentPreimage []byte `protobuf:"bytes,2,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
+	// / The circular route the rebalance took.
+	PaymentRoute *Route `protobuf:"bytes,3,opt,name=payment_route" json:"payment_route,omitempty"`
+	// / The total fee paid for the rebalance in satoshis.
+	Fee int64 `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
+	// / The total fee paid for the rebalance in milli-satoshis.
+	FeeMsat int64 `protobuf:"varint,5,opt,name=fee_msat" json:"fee_msat,omitempty"`
 }
 
-func (m *ListInvoiceRequest) Reset()                    { *m = ListInvoiceRequest{} }
-func (m *ListInvoiceRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListInvoiceRequest) ProtoMessage()               {}
-func (*ListInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+func (m *RebalanceResponse) Reset()                    { *m = RebalanceResponse{} }
+func (m *RebalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*RebalanceResponse) ProtoMessage()               {}
+func (*RebalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
 
-func (m *ListInvoiceRequest) GetPendingOnly() bool {
+func (m *RebalanceResponse) GetPaymentError() string {
 	if m != nil {
-		return m.PendingOnly
+		return m.PaymentError
 	}
-	return false
+	return ""
 }
 
-type ListInvoiceResponse struct {
-	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices" json:"invoices,omitempty"`
+func (m *RebalanceResponse) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
 }
 
-func (m *ListInvoiceResponse) Reset()                    { *m = ListInvoiceResponse{} }
-func (m *ListInvoiceResponse) String() string            { return proto.CompactTextString(m) }
-func (*ListInvoiceResponse) ProtoMessage()               {}
-func (*ListInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
-
-func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
+func (m *RebalanceResponse) GetPaymentRoute() *Route {
 	if m != nil {
-		return m.Invoices
+		return m.PaymentRoute
 	}
 	return nil
 }
 
-type InvoiceSubscription struct {
+func (m *RebalanceResponse) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
 }
 
-func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
-func (m *InvoiceSubscription) String() string            { return proto.CompactTextString(m) }
-func (*InvoiceSubscription) ProtoMessage()               {}
-func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+func (m *RebalanceResponse) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
 
-type Payment struct {
-	// / The payment hash
-	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
-	// / The value of the payment in satoshis
-	Value int64 `protobuf:"varint,2,opt,name=value" json:"value,omitempty"`
-	// / The date of this payment
-	CreationDate int64 `protobuf:"varint,3,opt,name=creation_date" json:"creation_date,omitempty"`
-	// / The path this payment took
-	Path []string `protobuf:"bytes,4,rep,name=path" json:"path,omitempty"`
-	// / The fee paid for this payment in satoshis
-	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
-	// / The payment preimage
-	PaymentPreimage string `protobuf:"bytes,6,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
+type TrackPaymentRequest struct {
+	// / The hash of the payment to track.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
 }
 
-func (m *Payment) Reset()                    { *m = Payment{} }
-func (m *Payment) String() string            { return proto.CompactTextString(m) }
-func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
 
-func (m *Payment) GetPaymentHash() string {
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
 	if m != nil {
 		return m.PaymentHash
 	}
-	return ""
+	return nil
 }
 
-func (m *Payment) GetValue() int64 {
+type ListPaymentsRequest struct {
+	// *
+	// The index of a payment that will be used as either the start or end of a
+	// query to determine which payments should be returned in the response.
+	IndexOffset uint64 `protobuf:"varint,1,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The max number of payments to return in the response to this query. If zero, all matching payments are returned.
+	MaxPayments uint64 `protobuf:"varint,2,opt,name=max_payments" json:"max_payments,omitempty"`
+	// *
+	// If set, the payments returned will result from seeking backwards from the
+	// specified index offset. This can be used to paginate backwards.
+	Reversed bool `protobuf:"varint,3,opt,name=reversed" json:"reversed,omitempty"`
+	// / If set, only payments created at or after this unix timestamp are returned.
+	CreationDateStart uint64 `protobuf:"varint,4,opt,name=creation_date_start" json:"creation_date_start,omitempty"`
+	// / If set, only payments created at or before this unix timestamp are returned.
+	CreationDateEnd uint64 `protobuf:"varint,5,opt,name=creation_date_end" json:"creation_date_end,omitempty"`
+	// *
+	// If set, payments that are still in flight or have failed are returned as
+	// well. Otherwise only succeeded payments are returned.
+	IncludeIncomplete bool `protobuf:"varint,6,opt,name=include_incomplete" json:"include_incomplete,omitempty"`
+}
+
+func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
+func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPaymentsRequest) ProtoMessage()               {}
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+func (m *ListPaymentsRequest) GetIndexOffset() uint64 {
 	if m != nil {
-		return m.Value
+		return m.IndexOffset
 	}
 	return 0
 }
 
-func (m *Payment) GetCreationDate() int64 {
+func (m *ListPaymentsRequest) GetMaxPayments() uint64 {
 	if m != nil {
-		return m.CreationDate
+		return m.MaxPayments
 	}
 	return 0
 }
 
-func (m *Payment) GetPath() []string {
+func (m *ListPaymentsRequest) GetReversed() bool {
 	if m != nil {
-		return m.Path
+		return m.Reversed
 	}
-	return nil
+	return false
 }
 
-func (m *Payment) GetFee() int64 {
+func (m *ListPaymentsRequest) GetCreationDateStart() uint64 {
 	if m != nil {
-		return m.Fee
+		return m.CreationDateStart
 	}
 	return 0
 }
 
-func (m *Payment) GetPaymentPreimage() string {
+func (m *ListPaymentsRequest) GetCreationDateEnd() uint64 {
 	if m != nil {
-		return m.PaymentPreimage
+		return m.CreationDateEnd
 	}
-	return ""
+	return 0
 }
 
-type ListPaymentsRequest struct {
+func (m *ListPaymentsRequest) GetIncludeIncomplete() bool {
+	if m != nil {
+		return m.IncludeIncomplete
+	}
+	return false
 }
 
-func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
-func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
-func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
-
 type ListPaymentsResponse struct {
 	// / The list of payments
 	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+	// *
+	// The index of the first item in the set of returned payments. This can be
+	// used to seek backwards, pagination style.
+	FirstIndexOffset uint64 `protobuf:"varint,2,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+	// *
+	// The index of the last item in the set of returned payments. This can be
+	// used to seek further, pagination style.
+	LastIndexOffset uint64 `protobuf:"varint,3,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
 }
 
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -3497,13 +5185,27 @@ func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	return nil
 }
 
+func (m *ListPaymentsResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
+	}
+	return 0
+}
+
+func (m *ListPaymentsResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
 type DeleteAllPaymentsRequest struct {
 }
 
 func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
 func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
 
 type DeleteAllPaymentsResponse struct {
 }
@@ -3511,7 +5213,7 @@ type DeleteAllPaymentsResponse struct {
 func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
 func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
 
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
@@ -3521,7 +5223,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -3544,7 +5246,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -3561,7 +5263,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -3571,21 +5273,22 @@ func (m *PayReqString) GetPayReq() string {
 }
 
 type PayReq struct {
-	Destination     string `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
-	PaymentHash     string `protobuf:"bytes,2,opt,name=payment_hash" json:"payment_hash,omitempty"`
-	NumSatoshis     int64  `protobuf:"varint,3,opt,name=num_satoshis" json:"num_satoshis,omitempty"`
-	Timestamp       int64  `protobuf:"varint,4,opt,name=timestamp" json:"timestamp,omitempty"`
-	Expiry          int64  `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
-	Description     string `protobuf:"bytes,6,opt,name=description" json:"description,omitempty"`
-	DescriptionHash string `protobuf:"bytes,7,opt,name=description_hash" json:"description_hash,omitempty"`
-	FallbackAddr    string `protobuf:"bytes,8,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
-	CltvExpiry      int64  `protobuf:"varint,9,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	Destination     string       `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
+	PaymentHash     string       `protobuf:"bytes,2,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	NumSatoshis     int64        `protobuf:"varint,3,opt,name=num_satoshis" json:"num_satoshis,omitempty"`
+	Timestamp       int64        `protobuf:"varint,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	Expiry          int64        `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	Description     string       `protobuf:"bytes,6,opt,name=description" json:"description,omitempty"`
+	DescriptionHash string       `protobuf:"bytes,7,opt,name=description_hash" json:"description_hash,omitempty"`
+	FallbackAddr    string       `protobuf:"bytes,8,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
+	CltvExpiry      int64        `protobuf:"varint,9,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	RouteHints      []*RouteHint `protobuf:"bytes,10,rep,name=route_hints" json:"route_hints,omitempty"`
 }
 
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -3650,13 +5353,20 @@ func (m *PayReq) GetCltvExpiry() int64 {
 	return 0
 }
 
+func (m *PayReq) GetRouteHints() []*RouteHint {
+	if m != nil {
+		return m.RouteHints
+	}
+	return nil
+}
+
 type FeeReportRequest struct {
 }
 
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -3672,7 +5382,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -3716,7 +5426,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -3762,7 +5472,7 @@ type PolicyUpdateRequest struct {
 func (m *PolicyUpdateRequest) Reset()                    { *m = PolicyUpdateRequest{} }
 func (m *PolicyUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateRequest) ProtoMessage()               {}
-func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
 
 type isPolicyUpdateRequest_Scope interface {
 	isPolicyUpdateRequest_Scope()
@@ -3899,7 +5609,124 @@ type PolicyUpdateResponse struct {
 func (m *PolicyUpdateResponse) Reset()                    { *m = PolicyUpdateResponse{} }
 func (m *PolicyUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateResponse) ProtoMessage()               {}
-func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+type UpdateChanStatusRequest struct {
+	// / The channel point of the channel to update the status of.
+	ChanPoint *ChannelPoint `protobuf:"bytes,1,opt,name=chan_point" json:"chan_point,omitempty"`
+	// / The action to apply to the channel.
+	Action UpdateChanStatusRequest_Action `protobuf:"varint,2,opt,name=action,enum=lnrpc.UpdateChanStatusRequest_Action" json:"action,omitempty"`
+}
+
+func (m *UpdateChanStatusRequest) Reset()                    { *m = UpdateChanStatusRequest{} }
+func (m *UpdateChanStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusRequest) ProtoMessage()               {}
+func (*UpdateChanStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
+
+func (m *UpdateChanStatusRequest) GetChanPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChanPoint
+	}
+	return nil
+}
+
+func (m *UpdateChanStatusRequest) GetAction() UpdateChanStatusRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return UpdateChanStatusRequest_ENABLE
+}
+
+type UpdateChanStatusResponse struct {
+}
+
+func (m *UpdateChanStatusResponse) Reset()                    { *m = UpdateChanStatusResponse{} }
+func (m *UpdateChanStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateChanStatusResponse) ProtoMessage()               {}
+func (*UpdateChanStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
+
+type UpdateNodeAnnouncementRequest struct {
+	// / The new alias of the node. If empty, the alias is left unchanged.
+	Alias string `protobuf:"bytes,1,opt,name=alias" json:"alias,omitempty"`
+	// / The new color of the node, in hex format (#rrggbb). If empty, the color is left unchanged.
+	Color string `protobuf:"bytes,2,opt,name=color" json:"color,omitempty"`
+	// / The set of addresses to start advertising.
+	AddAddresses []string `protobuf:"bytes,3,rep,name=add_addresses" json:"add_addresses,omitempty"`
+	// / The set of currently advertised addresses to stop advertising.
+	RemoveAddresses []string `protobuf:"bytes,4,rep,name=remove_addresses" json:"remove_addresses,omitempty"`
+	// / The set of global feature bits to set.
+	SetFeatureBits []uint32 `protobuf:"varint,5,rep,packed,name=set_feature_bits" json:"set_feature_bits,omitempty"`
+	// / The set of global feature bits to unset.
+	UnsetFeatureBits []uint32 `protobuf:"varint,6,rep,packed,name=unset_feature_bits" json:"unset_feature_bits,omitempty"`
+}
+
+func (m *UpdateNodeAnnouncementRequest) Reset()         { *m = UpdateNodeAnnouncementRequest{} }
+func (m *UpdateNodeAnnouncementRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeAnnouncementRequest) ProtoMessage()    {}
+func (*UpdateNodeAnnouncementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{124}
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetAddAddresses() []string {
+	if m != nil {
+		return m.AddAddresses
+	}
+	return nil
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetRemoveAddresses() []string {
+	if m != nil {
+		return m.RemoveAddresses
+	}
+	return nil
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetSetFeatureBits() []uint32 {
+	if m != nil {
+		return m.SetFeatureBits
+	}
+	return nil
+}
+
+func (m *UpdateNodeAnnouncementRequest) GetUnsetFeatureBits() []uint32 {
+	if m != nil {
+		return m.UnsetFeatureBits
+	}
+	return nil
+}
+
+type UpdateNodeAnnouncementResponse struct {
+	// / The node as advertised by the updated node announcement.
+	Node *LightningNode `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+}
+
+func (m *UpdateNodeAnnouncementResponse) Reset()         { *m = UpdateNodeAnnouncementResponse{} }
+func (m *UpdateNodeAnnouncementResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeAnnouncementResponse) ProtoMessage()    {}
+func (*UpdateNodeAnnouncementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{125}
+}
+
+func (m *UpdateNodeAnnouncementResponse) GetNode() *LightningNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
 
 type ForwardingHistoryRequest struct {
 	// / Start time is the starting point of the forwarding history request. All records beyond this point will be included, respecting the end time, and the index offset.
@@ -3915,7 +5742,7 @@ type ForwardingHistoryRequest struct {
 func (m *ForwardingHistoryRequest) Reset()                    { *m = ForwardingHistoryRequest{} }
 func (m *ForwardingHistoryRequest) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryRequest) ProtoMessage()               {}
-func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
 
 func (m *ForwardingHistoryRequest) GetStartTime() uint64 {
 	if m != nil {
@@ -3963,7 +5790,7 @@ type ForwardingEvent struct {
 func (m *ForwardingEvent) Reset()                    { *m = ForwardingEvent{} }
 func (m *ForwardingEvent) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingEvent) ProtoMessage()               {}
-func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
 
 func (m *ForwardingEvent) GetTimestamp() uint64 {
 	if m != nil {
@@ -4017,7 +5844,7 @@ type ForwardingHistoryResponse struct {
 func (m *ForwardingHistoryResponse) Reset()                    { *m = ForwardingHistoryResponse{} }
 func (m *ForwardingHistoryResponse) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryResponse) ProtoMessage()               {}
-func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
 
 func (m *ForwardingHistoryResponse) GetForwardingEvents() []*ForwardingEvent {
 	if m != nil {
@@ -4045,6 +5872,7 @@ func init() {
 	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
 	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
 	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
 	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
 	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
 	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
@@ -4091,9 +5919,21 @@ func init() {
 	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
 	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
 	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
+	proto.RegisterType((*FeeLimit)(nil), "lnrpc.FeeLimit")
+	proto.RegisterType((*CostModel)(nil), "lnrpc.CostModel")
 	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
 	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
 	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*ProbeRequest)(nil), "lnrpc.ProbeRequest")
+	proto.RegisterType((*ProbeResponse)(nil), "lnrpc.ProbeResponse")
+	proto.RegisterType((*ProbeRouteResult)(nil), "lnrpc.ProbeRouteResult")
+	proto.RegisterType((*ProbeHop)(nil), "lnrpc.ProbeHop")
+	proto.RegisterType((*QueryMissionControlRequest)(nil), "lnrpc.QueryMissionControlRequest")
+	proto.RegisterType((*QueryMissionControlResponse)(nil), "lnrpc.QueryMissionControlResponse")
+	proto.RegisterType((*NodeHistory)(nil), "lnrpc.NodeHistory")
+	proto.RegisterType((*ChannelHistory)(nil), "lnrpc.ChannelHistory")
+	proto.RegisterType((*ResetMissionControlRequest)(nil), "lnrpc.ResetMissionControlRequest")
+	proto.RegisterType((*ResetMissionControlResponse)(nil), "lnrpc.ResetMissionControlResponse")
 	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
 	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
 	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
@@ -4105,6 +5945,10 @@ func init() {
 	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
 	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
 	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
+	proto.RegisterType((*ExportGraphRequest)(nil), "lnrpc.ExportGraphRequest")
+	proto.RegisterType((*ExportGraphResponse)(nil), "lnrpc.ExportGraphResponse")
+	proto.RegisterType((*ImportGraphRequest)(nil), "lnrpc.ImportGraphRequest")
+	proto.RegisterType((*ImportGraphResponse)(nil), "lnrpc.ImportGraphResponse")
 	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
 	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
@@ -4112,13 +5956,24 @@ func init() {
 	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
 	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
 	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
+	proto.RegisterType((*HopHint)(nil), "lnrpc.HopHint")
+	proto.RegisterType((*RouteHint)(nil), "lnrpc.RouteHint")
 	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
 	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
 	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
 	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
 	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
 	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
+	proto.RegisterType((*SettleInvoiceRequest)(nil), "lnrpc.SettleInvoiceRequest")
+	proto.RegisterType((*SettleInvoiceResponse)(nil), "lnrpc.SettleInvoiceResponse")
+	proto.RegisterType((*CancelInvoiceRequest)(nil), "lnrpc.CancelInvoiceRequest")
+	proto.RegisterType((*CancelInvoiceResponse)(nil), "lnrpc.CancelInvoiceResponse")
 	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*HTLCAttempt)(nil), "lnrpc.HTLCAttempt")
+	proto.RegisterType((*HTLCFailure)(nil), "lnrpc.HTLCFailure")
+	proto.RegisterType((*RebalanceRequest)(nil), "lnrpc.RebalanceRequest")
+	proto.RegisterType((*RebalanceResponse)(nil), "lnrpc.RebalanceResponse")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
 	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
 	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
 	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
@@ -4132,10 +5987,20 @@ func init() {
 	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
 	proto.RegisterType((*PolicyUpdateRequest)(nil), "lnrpc.PolicyUpdateRequest")
 	proto.RegisterType((*PolicyUpdateResponse)(nil), "lnrpc.PolicyUpdateResponse")
+	proto.RegisterType((*UpdateChanStatusRequest)(nil), "lnrpc.UpdateChanStatusRequest")
+	proto.RegisterType((*UpdateChanStatusResponse)(nil), "lnrpc.UpdateChanStatusResponse")
+	proto.RegisterType((*UpdateNodeAnnouncementRequest)(nil), "lnrpc.UpdateNodeAnnouncementRequest")
+	proto.RegisterType((*UpdateNodeAnnouncementResponse)(nil), "lnrpc.UpdateNodeAnnouncementResponse")
 	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
 	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
 	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Invoice_InvoiceState", Invoice_InvoiceState_name, Invoice_InvoiceState_value)
+	proto.RegisterEnum("lnrpc.Payment_PaymentStatus", Payment_PaymentStatus_name, Payment_PaymentStatus_value)
+	proto.RegisterEnum("lnrpc.Payment_FailureReason", Payment_FailureReason_name, Payment_FailureReason_value)
+	proto.RegisterEnum("lnrpc.HTLCAttempt_HTLCStatus", HTLCAttempt_HTLCStatus_name, HTLCAttempt_HTLCStatus_value)
+	proto.RegisterEnum("lnrpc.HTLCFailure_FailureReason", HTLCFailure_FailureReason_name, HTLCFailure_FailureReason_value)
+	proto.RegisterEnum("lnrpc.UpdateChanStatusRequest_Action", UpdateChanStatusRequest_Action_name, UpdateChanStatusRequest_Action_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -4439,6 +6304,22 @@ type LightningClient interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute is a bi-directional streaming RPC for sending payment through
+	// the Lightning Network. This method differs from SendPayment in that it
+	// allows users to specify a full route manually. This can be used for things
+	// like rebalancing, and atomic swaps.
+	SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error)
+	// *
+	// SendToRouteSync is a synchronous version of SendToRoute. It Will block
+	// until the payment either fails or succeeds.
+	SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance shifts funds from one of our channels to another one of our
+	// channels, by paying an internal invoice over a circular route that leaves
+	// through the outgoing channel and returns through the incoming channel. The
+	// fee paid to the nodes along the route is reported in the response.
+	Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error)
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -4446,16 +6327,38 @@ type LightningClient interface {
 	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
 	// * lncli: `listinvoices`
 	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
+	// database. Any active debug invoices are ignored. It has full support for
+	// paginated responses, allowing users to query for specific invoices through
+	// their add_index. This can be done by using either the first_index_offset or
+	// last_index_offset fields included in the response as the index_offset of the
+	// next request. The reversed flag can be set in order to paginate backwards.
+	// If none of the parameters are specified, then all invoices are returned.
 	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
 	// * lncli: `lookupinvoice`
 	// LookupInvoice attempts to look up an invoice according to its payment hash.
 	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
 	// returned.
 	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the supplied preimage.
+	// All HTLCs that are being held for the invoice are settled with it.
+	SettleInvoice(ctx context.Context, in *SettleInvoiceRequest, opts ...grpc.CallOption) (*SettleInvoiceResponse, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels an open or accepted invoice. All HTLCs that are being
+	// held for the invoice are failed back to their senders.
+	CancelInvoice(ctx context.Context, in *CancelInvoiceRequest, opts ...grpc.CallOption) (*CancelInvoiceResponse, error)
 	// *
 	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
+	// notifying the client of newly added/settled invoices. Hold invoices are
+	// also sent once an HTLC paying to them has been accepted, and invoices are
+	// sent once they've been canceled or have expired. The caller can
+	// optionally specify the add_index and/or the settle_index. If specified,
+	// then we'll first start by sending add invoice events for all invoices with
+	// an add_index greater than the specified value. If the settle_index is
+	// specified, then next, we'll send out all settle events for invoices with a
+	// settle_index greater than the specified value. One or both of these
+	// fields can be set. If no fields are set, then we'll only send out the
+	// latest add/settle events.
 	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
 	// * lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
@@ -4465,6 +6368,13 @@ type LightningClient interface {
 	// * lncli: `listpayments`
 	// ListPayments returns a list of all outgoing payments.
 	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of updates
+	// for the outgoing payment identified by the given payment hash. The current
+	// state of the payment is sent first, followed by the updated payment each
+	// time an HTLC attempt is made or resolved, or the payment is failed. The
+	// stream is closed once the payment has succeeded or failed.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
@@ -4493,10 +6403,41 @@ type LightningClient interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsulated within the HTLC.
 	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	// * lncli: `probe`
+	// Probe estimates whether a payment of a specific amount is able to reach a
+	// target destination, without actually paying it. An HTLC with a random
+	// payment hash is sent along each candidate route found just like
+	// QueryRoutes does. If a channel along a route lacks the liquidity to forward
+	// the full amount, then smaller amounts are probed to estimate the amount
+	// each hop of the route is able to receive. The outcome of each probe is
+	// also fed into mission control.
+	Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error)
+	// * lncli: `querymc`
+	// QueryMissionControl exposes the internal mission control state to callers.
+	// It is a development feature, useful to inspect which channels and nodes
+	// the router learned to avoid from past payment attempts.
+	QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all mission control state, both in memory and
+	// on disk, so that path finding starts over from the a priori probabilities.
+	ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
 	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
+	// * lncli: `exportgraph`
+	// ExportGraph returns a compact snapshot of the public channel graph as known
+	// by the responding node. The snapshot contains the signed announcements of
+	// all channels, their latest updates, and the announcements of their nodes,
+	// which allows another node to quickly bootstrap its view of the graph by
+	// importing it.
+	ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*ExportGraphResponse, error)
+	// * lncli: `importgraph`
+	// ImportGraph imports a graph snapshot as returned by ExportGraph. Each of
+	// the announcements within the snapshot is fully validated before being
+	// applied to the channel graph, so the snapshot doesn't need to come from a
+	// trusted source. Imported announcements aren't relayed to our peers.
+	ImportGraph(ctx context.Context, in *ImportGraphRequest, opts ...grpc.CallOption) (*ImportGraphResponse, error)
 	// * lncli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -4523,6 +6464,20 @@ type LightningClient interface {
 	// UpdateChannelPolicy allows the caller to update the fee schedule and
 	// channel policies for all channels globally, or a particular channel.
 	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
+	// * lncli: `updatechanstatus`
+	// UpdateChanStatus allows the caller to manually disable or enable one of
+	// our public channels, by broadcasting a channel update with the disabled
+	// flag set accordingly. A manually set status overrides the status derived
+	// from the liveness of the channel's peer until the AUTO action is
+	// requested.
+	UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error)
+	// * lncli: `updatenodeannouncement`
+	// UpdateNodeAnnouncement allows the caller to update the alias, color,
+	// advertised addresses and global features of our node announcement at
+	// runtime. The updated announcement is re-signed and broadcast to the
+	// network. Note that these changes aren't persisted to the configuration,
+	// so they'll be overridden by it once the daemon restarts.
+	UpdateNodeAnnouncement(ctx context.Context, in *UpdateNodeAnnouncementRequest, opts ...grpc.CallOption) (*UpdateNodeAnnouncementResponse, error)
 	// * lncli: `fwdinghistory`
 	// ForwardingHistory allows the caller to query the htlcswitch for a record of
 	// all HTLC's forwarded within the target time range, and integer offset
@@ -4825,6 +6780,55 @@ func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest,
 	return out, nil
 }
 
+func (c *lightningClient) SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SendToRoute", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendToRouteClient{stream}
+	return x, nil
+}
+
+type Lightning_SendToRouteClient interface {
+	Send(*SendToRouteRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendToRouteClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendToRouteClient) Send(m *SendToRouteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendToRouteClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRouteSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) Rebalance(ctx context.Context, in *RebalanceRequest, opts ...grpc.CallOption) (*RebalanceResponse, error) {
+	out := new(RebalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/Rebalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
 	out := new(AddInvoiceResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
@@ -4852,8 +6856,26 @@ func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, op
 	return out, nil
 }
 
+func (c *lightningClient) SettleInvoice(ctx context.Context, in *SettleInvoiceRequest, opts ...grpc.CallOption) (*SettleInvoiceResponse, error) {
+	out := new(SettleInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SettleInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CancelInvoice(ctx context.Context, in *CancelInvoiceRequest, opts ...grpc.CallOption) (*CancelInvoiceResponse, error) {
+	out := new(CancelInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CancelInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -4893,13 +6915,45 @@ func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, op
 	return out, nil
 }
 
-func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
-	out := new(ListPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
-	if err != nil {
+func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*Payment, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*Payment, error) {
+	m := new(Payment)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
@@ -4947,6 +7001,33 @@ func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesReques
 	return out, nil
 }
 
+func (c *lightningClient) Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error) {
+	out := new(ProbeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/Probe", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryMissionControl(ctx context.Context, in *QueryMissionControlRequest, opts ...grpc.CallOption) (*QueryMissionControlResponse, error) {
+	out := new(QueryMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ResetMissionControl(ctx context.Context, in *ResetMissionControlRequest, opts ...grpc.CallOption) (*ResetMissionControlResponse, error) {
+	out := new(ResetMissionControlResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ResetMissionControl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
 	out := new(NetworkInfo)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
@@ -4956,6 +7037,24 @@ func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoReq
 	return out, nil
 }
 
+func (c *lightningClient) ExportGraph(ctx context.Context, in *ExportGraphRequest, opts ...grpc.CallOption) (*ExportGraphResponse, error) {
+	out := new(ExportGraphResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportGraph(ctx context.Context, in *ImportGraphRequest, opts ...grpc.CallOption) (*ImportGraphResponse, error) {
+	out := new(ImportGraphResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
 	out := new(StopResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
@@ -4966,7 +7065,7 @@ func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts
 }
 
 func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -5024,6 +7123,24 @@ func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpd
 	return out, nil
 }
 
+func (c *lightningClient) UpdateChanStatus(ctx context.Context, in *UpdateChanStatusRequest, opts ...grpc.CallOption) (*UpdateChanStatusResponse, error) {
+	out := new(UpdateChanStatusResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChanStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateNodeAnnouncement(ctx context.Context, in *UpdateNodeAnnouncementRequest, opts ...grpc.CallOption) (*UpdateNodeAnnouncementResponse, error) {
+	out := new(UpdateNodeAnnouncementResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateNodeAnnouncement", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
 	out := new(ForwardingHistoryResponse)
 	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
@@ -5146,6 +7263,22 @@ type LightningServer interface {
 	// Additionally, this RPC expects the destination's public key and the payment
 	// hash (if any) to be encoded as hex strings.
 	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// * lncli: `sendtoroute`
+	// SendToRoute is a bi-directional streaming RPC for sending payment through
+	// the Lightning Network. This method differs from SendPayment in that it
+	// allows users to specify a full route manually. This can be used for things
+	// like rebalancing, and atomic swaps.
+	SendToRoute(Lightning_SendToRouteServer) error
+	// *
+	// SendToRouteSync is a synchronous version of SendToRoute. It Will block
+	// until the payment either fails or succeeds.
+	SendToRouteSync(context.Context, *SendToRouteRequest) (*SendResponse, error)
+	// * lncli: `rebalance`
+	// Rebalance shifts funds from one of our channels to another one of our
+	// channels, by paying an internal invoice over a circular route that leaves
+	// through the outgoing channel and returns through the incoming channel. The
+	// fee paid to the nodes along the route is reported in the response.
+	Rebalance(context.Context, *RebalanceRequest) (*RebalanceResponse, error)
 	// * lncli: `addinvoice`
 	// AddInvoice attempts to add a new invoice to the invoice database. Any
 	// duplicated invoices are rejected, therefore all invoices *must* have a
@@ -5153,16 +7286,38 @@ type LightningServer interface {
 	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
 	// * lncli: `listinvoices`
 	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
+	// database. Any active debug invoices are ignored. It has full support for
+	// paginated responses, allowing users to query for specific invoices through
+	// their add_index. This can be done by using either the first_index_offset or
+	// last_index_offset fields included in the response as the index_offset of the
+	// next request. The reversed flag can be set in order to paginate backwards.
+	// If none of the parameters are specified, then all invoices are returned.
 	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
 	// * lncli: `lookupinvoice`
 	// LookupInvoice attempts to look up an invoice according to its payment hash.
 	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
 	// returned.
 	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
+	// * lncli: `settleinvoice`
+	// SettleInvoice settles an accepted hold invoice using the supplied preimage.
+	// All HTLCs that are being held for the invoice are settled with it.
+	SettleInvoice(context.Context, *SettleInvoiceRequest) (*SettleInvoiceResponse, error)
+	// * lncli: `cancelinvoice`
+	// CancelInvoice cancels an open or accepted invoice. All HTLCs that are being
+	// held for the invoice are failed back to their senders.
+	CancelInvoice(context.Context, *CancelInvoiceRequest) (*CancelInvoiceResponse, error)
 	// *
 	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
+	// notifying the client of newly added/settled invoices. Hold invoices are
+	// also sent once an HTLC paying to them has been accepted, and invoices are
+	// sent once they've been canceled or have expired. The caller can
+	// optionally specify the add_index and/or the settle_index. If specified,
+	// then we'll first start by sending add invoice events for all invoices with
+	// an add_index greater than the specified value. If the settle_index is
+	// specified, then next, we'll send out all settle events for invoices with a
+	// settle_index greater than the specified value. One or both of these
+	// fields can be set. If no fields are set, then we'll only send out the
+	// latest add/settle events.
 	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
 	// * lncli: `decodepayreq`
 	// DecodePayReq takes an encoded payment request string and attempts to decode
@@ -5172,6 +7327,13 @@ type LightningServer interface {
 	// * lncli: `listpayments`
 	// ListPayments returns a list of all outgoing payments.
 	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// * lncli: `trackpayment`
+	// TrackPayment returns a uni-directional stream (server -> client) of updates
+	// for the outgoing payment identified by the given payment hash. The current
+	// state of the payment is sent first, followed by the updated payment each
+	// time an HTLC attempt is made or resolved, or the payment is failed. The
+	// stream is closed once the payment has succeeded or failed.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
 	// *
 	// DeleteAllPayments deletes all outgoing payments from DB.
 	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
@@ -5200,10 +7362,41 @@ type LightningServer interface {
 	// send an HTLC, also including the necessary information that should be
 	// present within the Sphinx packet encapsulated within the HTLC.
 	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	// * lncli: `probe`
+	// Probe estimates whether a payment of a specific amount is able to reach a
+	// target destination, without actually paying it. An HTLC with a random
+	// payment hash is sent along each candidate route found just like
+	// QueryRoutes does. If a channel along a route lacks the liquidity to forward
+	// the full amount, then smaller amounts are probed to estimate the amount
+	// each hop of the route is able to receive. The outcome of each probe is
+	// also fed into mission control.
+	Probe(context.Context, *ProbeRequest) (*ProbeResponse, error)
+	// * lncli: `querymc`
+	// QueryMissionControl exposes the internal mission control state to callers.
+	// It is a development feature, useful to inspect which channels and nodes
+	// the router learned to avoid from past payment attempts.
+	QueryMissionControl(context.Context, *QueryMissionControlRequest) (*QueryMissionControlResponse, error)
+	// * lncli: `resetmc`
+	// ResetMissionControl clears all mission control state, both in memory and
+	// on disk, so that path finding starts over from the a priori probabilities.
+	ResetMissionControl(context.Context, *ResetMissionControlRequest) (*ResetMissionControlResponse, error)
 	// * lncli: `getnetworkinfo`
 	// GetNetworkInfo returns some basic stats about the known channel graph from
 	// the point of view of the node.
 	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
+	// * lncli: `exportgraph`
+	// ExportGraph returns a compact snapshot of the public channel graph as known
+	// by the responding node. The snapshot contains the signed announcements of
+	// all channels, their latest updates, and the announcements of their nodes,
+	// which allows another node to quickly bootstrap its view of the graph by
+	// importing it.
+	ExportGraph(context.Context, *ExportGraphRequest) (*ExportGraphResponse, error)
+	// * lncli: `importgraph`
+	// ImportGraph imports a graph snapshot as returned by ExportGraph. Each of
+	// the announcements within the snapshot is fully validated before being
+	// applied to the channel graph, so the snapshot doesn't need to come from a
+	// trusted source. Imported announcements aren't relayed to our peers.
+	ImportGraph(context.Context, *ImportGraphRequest) (*ImportGraphResponse, error)
 	// * lncli: `stop`
 	// StopDaemon will send a shutdown request to the interrupt handler, triggering
 	// a graceful shutdown of the daemon.
@@ -5230,6 +7423,20 @@ type LightningServer interface {
 	// UpdateChannelPolicy allows the caller to update the fee schedule and
 	// channel policies for all channels globally, or a particular channel.
 	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
+	// * lncli: `updatechanstatus`
+	// UpdateChanStatus allows the caller to manually disable or enable one of
+	// our public channels, by broadcasting a channel update with the disabled
+	// flag set accordingly. A manually set status overrides the status derived
+	// from the liveness of the channel's peer until the AUTO action is
+	// requested.
+	UpdateChanStatus(context.Context, *UpdateChanStatusRequest) (*UpdateChanStatusResponse, error)
+	// * lncli: `updatenodeannouncement`
+	// UpdateNodeAnnouncement allows the caller to update the alias, color,
+	// advertised addresses and global features of our node announcement at
+	// runtime. The updated announcement is re-signed and broadcast to the
+	// network. Note that these changes aren't persisted to the configuration,
+	// so they'll be overridden by it once the daemon restarts.
+	UpdateNodeAnnouncement(context.Context, *UpdateNodeAnnouncementRequest) (*UpdateNodeAnnouncementResponse, error)
 	// * lncli: `fwdinghistory`
 	// ForwardingHistory allows the caller to query the htlcswitch for a record of
 	// all HTLC's forwarded within the target time range, and integer offset
@@ -5643,6 +7850,68 @@ func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SendToRoute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendToRoute(&lightningSendToRouteServer{stream})
+}
+
+type Lightning_SendToRouteServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendToRouteRequest, error)
+	grpc.ServerStream
+}
+
+type lightningSendToRouteServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendToRouteServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningSendToRouteServer) Recv() (*SendToRouteRequest, error) {
+	m := new(SendToRouteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_SendToRouteSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendToRouteSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendToRouteSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendToRouteSync(ctx, req.(*SendToRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_Rebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).Rebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/Rebalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).Rebalance(ctx, req.(*RebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Invoice)
 	if err := dec(in); err != nil {
@@ -5697,6 +7966,42 @@ func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_SettleInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettleInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SettleInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SettleInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SettleInvoice(ctx, req.(*SettleInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_CancelInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).CancelInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/CancelInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).CancelInvoice(ctx, req.(*CancelInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(InvoiceSubscription)
 	if err := stream.RecvMsg(m); err != nil {
@@ -5754,6 +8059,27 @@ func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
+}
+
+type Lightning_TrackPaymentServer interface {
+	Send(*Payment) error
+	grpc.ServerStream
+}
+
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningTrackPaymentServer) Send(m *Payment) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAllPaymentsRequest)
 	if err := dec(in); err != nil {
@@ -5844,6 +8170,60 @@ func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_Probe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).Probe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/Probe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).Probe(ctx, req.(*ProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_QueryMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).QueryMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryMissionControl(ctx, req.(*QueryMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ResetMissionControl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetMissionControlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ResetMissionControl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ResetMissionControl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ResetMissionControl(ctx, req.(*ResetMissionControlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NetworkInfoRequest)
 	if err := dec(in); err != nil {
@@ -5862,6 +8242,42 @@ func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_ExportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ExportGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ExportGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ExportGraph(ctx, req.(*ExportGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ImportGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ImportGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ImportGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ImportGraph(ctx, req.(*ImportGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StopRequest)
 	if err := dec(in); err != nil {
@@ -5955,6 +8371,42 @@ func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_UpdateChanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChanStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChanStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChanStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChanStatus(ctx, req.(*UpdateChanStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_UpdateNodeAnnouncement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeAnnouncementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateNodeAnnouncement",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateNodeAnnouncement(ctx, req.(*UpdateNodeAnnouncementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ForwardingHistoryRequest)
 	if err := dec(in); err != nil {
@@ -6045,6 +8497,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendPaymentSync",
 			Handler:    _Lightning_SendPaymentSync_Handler,
 		},
+		{
+			MethodName: "SendToRouteSync",
+			Handler:    _Lightning_SendToRouteSync_Handler,
+		},
+		{
+			MethodName: "Rebalance",
+			Handler:    _Lightning_Rebalance_Handler,
+		},
 		{
 			MethodName: "AddInvoice",
 			Handler:    _Lightning_AddInvoice_Handler,
@@ -6057,6 +8517,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LookupInvoice",
 			Handler:    _Lightning_LookupInvoice_Handler,
 		},
+		{
+			MethodName: "SettleInvoice",
+			Handler:    _Lightning_SettleInvoice_Handler,
+		},
+		{
+			MethodName: "CancelInvoice",
+			Handler:    _Lightning_CancelInvoice_Handler,
+		},
 		{
 			MethodName: "DecodePayReq",
 			Handler:    _Lightning_DecodePayReq_Handler,
@@ -6085,10 +8553,30 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRoutes",
 			Handler:    _Lightning_QueryRoutes_Handler,
 		},
+		{
+			MethodName: "Probe",
+			Handler:    _Lightning_Probe_Handler,
+		},
+		{
+			MethodName: "QueryMissionControl",
+			Handler:    _Lightning_QueryMissionControl_Handler,
+		},
+		{
+			MethodName: "ResetMissionControl",
+			Handler:    _Lightning_ResetMissionControl_Handler,
+		},
 		{
 			MethodName: "GetNetworkInfo",
 			Handler:    _Lightning_GetNetworkInfo_Handler,
 		},
+		{
+			MethodName: "ExportGraph",
+			Handler:    _Lightning_ExportGraph_Handler,
+		},
+		{
+			MethodName: "ImportGraph",
+			Handler:    _Lightning_ImportGraph_Handler,
+		},
 		{
 			MethodName: "StopDaemon",
 			Handler:    _Lightning_StopDaemon_Handler,
@@ -6105,6 +8593,14 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChannelPolicy",
 			Handler:    _Lightning_UpdateChannelPolicy_Handler,
 		},
+		{
+			MethodName: "UpdateChanStatus",
+			Handler:    _Lightning_UpdateChanStatus_Handler,
+		},
+		{
+			MethodName: "UpdateNodeAnnouncement",
+			Handler:    _Lightning_UpdateNodeAnnouncement_Handler,
+		},
 		{
 			MethodName: "ForwardingHistory",
 			Handler:    _Lightning_ForwardingHistory_Handler,
@@ -6132,11 +8628,22 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SendToRoute",
+			Handler:       _Lightning_SendToRoute_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SubscribeInvoices",
 			Handler:       _Lightning_SubscribeInvoices_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeChannelGraph",
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
@@ -6149,341 +8656,473 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 5373 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5c, 0x4d, 0x90, 0x1c, 0xd9,
-	0x51, 0x56, 0xf5, 0xf4, 0xfc, 0x74, 0x76, 0xcf, 0xdf, 0x9b, 0xd1, 0xa8, 0xd5, 0xab, 0xd5, 0x6a,
-	0xcb, 0x1b, 0x2b, 0x21, 0x16, 0x8d, 0x76, 0x6c, 0x2f, 0xcb, 0x0a, 0xd6, 0xa1, 0xff, 0x59, 0x5b,
-	0x2b, 0x8f, 0x6b, 0x24, 0x2f, 0x78, 0x81, 0x76, 0x4d, 0xf7, 0x9b, 0x9e, 0xb2, 0xba, 0xab, 0x6a,
-	0xab, 0x5e, 0xcf, 0xa8, 0x77, 0x51, 0x04, 0x3f, 0x11, 0x9c, 0x70, 0xf8, 0x00, 0x11, 0x84, 0x21,
-	0x1c, 0x44, 0xd8, 0x17, 0x38, 0x70, 0xe4, 0x64, 0x02, 0xee, 0x8e, 0x20, 0x38, 0xf8, 0x44, 0x70,
-	0xe3, 0xe7, 0x02, 0x67, 0x2e, 0x1c, 0x08, 0x22, 0xf3, 0xfd, 0xd4, 0x7b, 0x55, 0x35, 0x92, 0x6c,
-	0x83, 0x6f, 0xf3, 0xbe, 0x97, 0x95, 0xef, 0x2f, 0x33, 0x5f, 0x66, 0xbe, 0xec, 0x81, 0x56, 0x96,
-	0x0e, 0xae, 0xa5, 0x59, 0x22, 0x12, 0x36, 0x3f, 0x8e, 0xb3, 0x74, 0xd0, 0xbb, 0x30, 0x4a, 0x92,
-	0xd1, 0x98, 0x6f, 0x87, 0x69, 0xb4, 0x1d, 0xc6, 0x71, 0x22, 0x42, 0x11, 0x25, 0x71, 0x2e, 0x89,
-	0xfc, 0x6f, 0xc2, 0xca, 0x7d, 0x1e, 0xef, 0x73, 0x3e, 0x0c, 0xf8, 0x27, 0x53, 0x9e, 0x0b, 0xf6,
-	0x8b, 0xb0, 0x1e, 0xf2, 0x4f, 0x39, 0x1f, 0xf6, 0xd3, 0x30, 0xcf, 0xd3, 0xa3, 0x2c, 0xcc, 0x79,
-	0xd7, 0xbb, 0xe4, 0x5d, 0xe9, 0x04, 0x6b, 0xb2, 0x63, 0xcf, 0xe0, 0xec, 0x75, 0xe8, 0xe4, 0x48,
-	0xca, 0x63, 0x91, 0x25, 0xe9, 0xac, 0xdb, 0x20, 0xba, 0x36, 0x62, 0x77, 0x25, 0xe4, 0x8f, 0x61,
-	0xd5, 0x8c, 0x90, 0xa7, 0x49, 0x9c, 0x73, 0x76, 0x1d, 0x36, 0x07, 0x51, 0x7a, 0xc4, 0xb3, 0x3e,
-	0x7d, 0x3c, 0x89, 0xf9, 0x24, 0x89, 0xa3, 0x41, 0xd7, 0xbb, 0x34, 0x77, 0xa5, 0x15, 0x30, 0xd9,
-	0x87, 0x5f, 0x7c, 0xa8, 0x7a, 0xd8, 0x65, 0x58, 0xe5, 0xb1, 0xc4, 0xf9, 0x90, 0xbe, 0x52, 0x43,
-	0xad, 0x14, 0x30, 0x7e, 0xe0, 0xff, 0xb9, 0x07, 0xeb, 0x1f, 0xc4, 0x91, 0xf8, 0x28, 0x1c, 0x8f,
-	0xb9, 0xd0, 0x6b, 0xba, 0x0c, 0xab, 0x27, 0x04, 0xd0, 0x9a, 0x4e, 0x92, 0x6c, 0xa8, 0x56, 0xb4,
-	0x22, 0xe1, 0x3d, 0x85, 0x9e, 0x3a, 0xb3, 0xc6, 0xa9, 0x33, 0xab, 0xdd, 0xae, 0xb9, 0xfa, 0xed,
-	0xf2, 0x37, 0x81, 0xd9, 0x93, 0x93, 0xdb, 0xe1, 0xbf, 0x0f, 0x1b, 0x8f, 0xe3, 0x71, 0x32, 0x78,
-	0xf2, 0xd3, 0x4d, 0xda, 0xdf, 0x82, 0x4d, 0xf7, 0x7b, 0xc5, 0xf7, 0xbb, 0x0d, 0x68, 0x3f, 0xca,
-	0xc2, 0x38, 0x0f, 0x07, 0x78, 0xe4, 0xac, 0x0b, 0x8b, 0xe2, 0x69, 0xff, 0x28, 0xcc, 0x8f, 0x88,
-	0x51, 0x2b, 0xd0, 0x4d, 0xb6, 0x05, 0x0b, 0xe1, 0x24, 0x99, 0xc6, 0x82, 0x76, 0x75, 0x2e, 0x50,
-	0x2d, 0xf6, 0x16, 0xac, 0xc7, 0xd3, 0x49, 0x7f, 0x90, 0xc4, 0x87, 0x51, 0x36, 0x91, 0x82, 0x43,
-	0x8b, 0x9b, 0x0f, 0xaa, 0x1d, 0xec, 0x22, 0xc0, 0x01, 0x4e, 0x43, 0x0e, 0xd1, 0xa4, 0x21, 0x2c,
-	0x84, 0xf9, 0xd0, 0x51, 0x2d, 0x1e, 0x8d, 0x8e, 0x44, 0x77, 0x9e, 0x18, 0x39, 0x18, 0xf2, 0x10,
-	0xd1, 0x84, 0xf7, 0x73, 0x11, 0x4e, 0xd2, 0xee, 0x02, 0xcd, 0xc6, 0x42, 0xa8, 0x3f, 0x11, 0xe1,
-	0xb8, 0x7f, 0xc8, 0x79, 0xde, 0x5d, 0x54, 0xfd, 0x06, 0x61, 0x6f, 0xc2, 0xca, 0x90, 0xe7, 0xa2,
-	0x1f, 0x0e, 0x87, 0x19, 0xcf, 0x73, 0x9e, 0x77, 0x97, 0xe8, 0xe8, 0x4a, 0xa8, 0xdf, 0x85, 0xad,
-	0xfb, 0x5c, 0x58, 0xbb, 0x93, 0xab, 0x6d, 0xf7, 0x1f, 0x00, 0xb3, 0xe0, 0x3b, 0x5c, 0x84, 0xd1,
-	0x38, 0x67, 0xef, 0x40, 0x47, 0x58, 0xc4, 0x24, 0xaa, 0xed, 0x1d, 0x76, 0x8d, 0x74, 0xec, 0x9a,
-	0xf5, 0x41, 0xe0, 0xd0, 0xf9, 0xff, 0xed, 0x41, 0x7b, 0x9f, 0xc7, 0x46, 0xbb, 0x18, 0x34, 0x71,
-	0x26, 0xea, 0x24, 0xe9, 0x6f, 0xf6, 0x1a, 0xb4, 0x69, 0x76, 0xb9, 0xc8, 0xa2, 0x78, 0x44, 0x47,
-	0xd0, 0x0a, 0x00, 0xa1, 0x7d, 0x42, 0xd8, 0x1a, 0xcc, 0x85, 0x13, 0x41, 0x1b, 0x3f, 0x17, 0xe0,
-	0x9f, 0xa8, 0x77, 0x69, 0x38, 0x9b, 0xf0, 0x58, 0x14, 0x9b, 0xdd, 0x09, 0xda, 0x0a, 0xdb, 0xc5,
-	0xdd, 0xbe, 0x06, 0x1b, 0x36, 0x89, 0xe6, 0x3e, 0x4f, 0xdc, 0xd7, 0x2d, 0x4a, 0x35, 0xc8, 0x65,
-	0x58, 0xd5, 0xf4, 0x99, 0x9c, 0x2c, 0x6d, 0x7f, 0x2b, 0x58, 0x51, 0xb0, 0x5e, 0xc2, 0x15, 0x58,
-	0x3b, 0x8c, 0xe2, 0x70, 0xdc, 0x1f, 0x8c, 0xc5, 0x71, 0x7f, 0xc8, 0xc7, 0x22, 0xa4, 0x83, 0x98,
-	0x0f, 0x56, 0x08, 0xbf, 0x3d, 0x16, 0xc7, 0x77, 0x10, 0xf5, 0xff, 0xc4, 0x83, 0x8e, 0x5c, 0xbc,
-	0x52, 0xfc, 0x37, 0x60, 0x59, 0x8f, 0xc1, 0xb3, 0x2c, 0xc9, 0x94, 0x1c, 0xba, 0x20, 0xbb, 0x0a,
-	0x6b, 0x1a, 0x48, 0x33, 0x1e, 0x4d, 0xc2, 0x11, 0x57, 0xda, 0x5e, 0xc1, 0xd9, 0x4e, 0xc1, 0x31,
-	0x4b, 0xa6, 0x42, 0xaa, 0x5e, 0x7b, 0xa7, 0xa3, 0x0e, 0x26, 0x40, 0x2c, 0x70, 0x49, 0xfc, 0xef,
-	0x7b, 0xd0, 0xb9, 0x7d, 0x14, 0xc6, 0x31, 0x1f, 0xef, 0x25, 0x51, 0x2c, 0xd8, 0x75, 0x60, 0x87,
-	0xd3, 0x78, 0x18, 0xc5, 0xa3, 0xbe, 0x78, 0x1a, 0x0d, 0xfb, 0x07, 0x33, 0xc1, 0x73, 0x79, 0x44,
-	0xbb, 0x67, 0x82, 0x9a, 0x3e, 0xf6, 0x16, 0xac, 0x39, 0x68, 0x2e, 0x32, 0x79, 0x6e, 0xbb, 0x67,
-	0x82, 0x4a, 0x0f, 0x0a, 0x7e, 0x32, 0x15, 0xe9, 0x54, 0xf4, 0xa3, 0x78, 0xc8, 0x9f, 0xd2, 0x1c,
-	0x97, 0x03, 0x07, 0xbb, 0xb5, 0x02, 0x1d, 0xfb, 0x3b, 0xff, 0x7d, 0x58, 0x7b, 0x80, 0x1a, 0x11,
-	0x47, 0xf1, 0xe8, 0xa6, 0x14, 0x5b, 0x54, 0xd3, 0x74, 0x7a, 0xf0, 0x84, 0xcf, 0xd4, 0xbe, 0xa9,
-	0x16, 0x0a, 0xd5, 0x51, 0x92, 0x0b, 0x25, 0x39, 0xf4, 0xb7, 0xff, 0xaf, 0x1e, 0xac, 0xe2, 0xde,
-	0x7f, 0x18, 0xc6, 0x33, 0x7d, 0x72, 0x0f, 0xa0, 0x83, 0xac, 0x1e, 0x25, 0x37, 0xa5, 0xb2, 0x4b,
-	0x21, 0xbe, 0xa2, 0xf6, 0xaa, 0x44, 0x7d, 0xcd, 0x26, 0x45, 0x63, 0x3e, 0x0b, 0x9c, 0xaf, 0x51,
-	0x6c, 0x45, 0x98, 0x8d, 0xb8, 0x20, 0x33, 0xa0, 0xcc, 0x02, 0x48, 0xe8, 0x76, 0x12, 0x1f, 0xb2,
-	0x4b, 0xd0, 0xc9, 0x43, 0xd1, 0x4f, 0x79, 0x46, 0xbb, 0x46, 0xa2, 0x37, 0x17, 0x40, 0x1e, 0x8a,
-	0x3d, 0x9e, 0xdd, 0x9a, 0x09, 0xde, 0xfb, 0x12, 0xac, 0x57, 0x46, 0x41, 0x69, 0x2f, 0x96, 0x88,
-	0x7f, 0xb2, 0x4d, 0x98, 0x3f, 0x0e, 0xc7, 0x53, 0xae, 0xac, 0x93, 0x6c, 0xbc, 0xd7, 0x78, 0xd7,
-	0xf3, 0xdf, 0x84, 0xb5, 0x62, 0xda, 0x4a, 0xc8, 0x18, 0x34, 0x71, 0x07, 0x15, 0x03, 0xfa, 0xdb,
-	0xff, 0x3d, 0x4f, 0x12, 0xde, 0x4e, 0x22, 0xa3, 0xe9, 0x48, 0x88, 0x06, 0x41, 0x13, 0xe2, 0xdf,
-	0xa7, 0x5a, 0xc2, 0x9f, 0x7d, 0xb1, 0xfe, 0x65, 0x58, 0xb7, 0xa6, 0xf0, 0x9c, 0xc9, 0x7e, 0xdb,
-	0x83, 0xf5, 0x87, 0xfc, 0x44, 0x9d, 0xba, 0x9e, 0xed, 0xbb, 0xd0, 0x14, 0xb3, 0x54, 0x5e, 0xc5,
-	0x2b, 0x3b, 0x6f, 0xa8, 0x43, 0xab, 0xd0, 0x5d, 0x53, 0xcd, 0x47, 0xb3, 0x94, 0x07, 0xf4, 0x85,
-	0xff, 0x3e, 0xb4, 0x2d, 0x90, 0x9d, 0x83, 0x8d, 0x8f, 0x3e, 0x78, 0xf4, 0xf0, 0xee, 0xfe, 0x7e,
-	0x7f, 0xef, 0xf1, 0xad, 0xaf, 0xdc, 0xfd, 0x8d, 0xfe, 0xee, 0xcd, 0xfd, 0xdd, 0xb5, 0x33, 0x6c,
-	0x0b, 0xd8, 0xc3, 0xbb, 0xfb, 0x8f, 0xee, 0xde, 0x71, 0x70, 0xcf, 0xef, 0x41, 0xf7, 0x21, 0x3f,
-	0xf9, 0x28, 0x12, 0x31, 0xcf, 0x73, 0x77, 0x34, 0xff, 0x1a, 0x30, 0x7b, 0x0a, 0x6a, 0x55, 0x5d,
-	0x58, 0x54, 0xa6, 0x56, 0xdf, 0x34, 0xaa, 0xe9, 0xbf, 0x09, 0x6c, 0x3f, 0x1a, 0xc5, 0x1f, 0xf2,
-	0x3c, 0x0f, 0x47, 0x5c, 0xaf, 0x6d, 0x0d, 0xe6, 0x26, 0xf9, 0x48, 0x19, 0x45, 0xfc, 0xd3, 0xff,
-	0x3c, 0x6c, 0x38, 0x74, 0x8a, 0xf1, 0x05, 0x68, 0xe5, 0xd1, 0x28, 0x0e, 0xc5, 0x34, 0xe3, 0x8a,
-	0x75, 0x01, 0xf8, 0xf7, 0x60, 0xf3, 0xeb, 0x3c, 0x8b, 0x0e, 0x67, 0x2f, 0x62, 0xef, 0xf2, 0x69,
-	0x94, 0xf9, 0xdc, 0x85, 0xb3, 0x25, 0x3e, 0x6a, 0x78, 0x29, 0x88, 0xea, 0xb8, 0x96, 0x02, 0xd9,
-	0xb0, 0xd4, 0xb2, 0x61, 0xab, 0xa5, 0xff, 0x18, 0xd8, 0xed, 0x24, 0x8e, 0xf9, 0x40, 0xec, 0x71,
-	0x9e, 0x15, 0xfe, 0x55, 0x21, 0x75, 0xed, 0x9d, 0x73, 0xea, 0x1c, 0xcb, 0xba, 0xae, 0xc4, 0x91,
-	0x41, 0x33, 0xe5, 0xd9, 0x84, 0x18, 0x2f, 0x05, 0xf4, 0xb7, 0x7f, 0x16, 0x36, 0x1c, 0xb6, 0xea,
-	0xb6, 0x7f, 0x1b, 0xce, 0xde, 0x89, 0xf2, 0x41, 0x75, 0xc0, 0x2e, 0x2c, 0xa6, 0xd3, 0x83, 0x7e,
-	0xa1, 0x53, 0xba, 0x89, 0x97, 0x60, 0xf9, 0x13, 0xc5, 0xec, 0x0f, 0x3d, 0x68, 0xee, 0x3e, 0x7a,
-	0x70, 0x9b, 0xf5, 0x60, 0x29, 0x8a, 0x07, 0xc9, 0x04, 0xaf, 0x0e, 0xb9, 0x68, 0xd3, 0x3e, 0x55,
-	0x57, 0x2e, 0x40, 0x8b, 0x6e, 0x1c, 0xbc, 0xd7, 0x95, 0x2b, 0x54, 0x00, 0xe8, 0x53, 0xf0, 0xa7,
-	0x69, 0x94, 0x91, 0xd3, 0xa0, 0x5d, 0x81, 0x26, 0x59, 0xc4, 0x6a, 0x87, 0xff, 0x3f, 0x4d, 0x58,
-	0x54, 0xb6, 0x9a, 0xc6, 0x1b, 0x88, 0xe8, 0x98, 0xab, 0x99, 0xa8, 0x16, 0xde, 0x2a, 0x19, 0x9f,
-	0x24, 0x82, 0xf7, 0x9d, 0x63, 0x70, 0x41, 0xa4, 0x1a, 0x48, 0x46, 0xfd, 0x14, 0xad, 0x3e, 0xcd,
-	0xac, 0x15, 0xb8, 0x20, 0x6e, 0x16, 0x02, 0xfd, 0x68, 0x48, 0x73, 0x6a, 0x06, 0xba, 0x89, 0x3b,
-	0x31, 0x08, 0xd3, 0x70, 0x10, 0x89, 0x99, 0x52, 0x6e, 0xd3, 0x46, 0xde, 0xe3, 0x64, 0x10, 0x8e,
-	0xfb, 0x07, 0xe1, 0x38, 0x8c, 0x07, 0x5c, 0x39, 0x2e, 0x2e, 0x88, 0xbe, 0x89, 0x9a, 0x92, 0x26,
-	0x93, 0xfe, 0x4b, 0x09, 0x45, 0x1f, 0x67, 0x90, 0x4c, 0x26, 0x91, 0x40, 0x97, 0xa6, 0xbb, 0x24,
-	0x0d, 0x49, 0x81, 0xd0, 0x4a, 0x64, 0xeb, 0x44, 0xee, 0x5e, 0x4b, 0x8e, 0xe6, 0x80, 0xc8, 0xe5,
-	0x90, 0x73, 0x32, 0x48, 0x4f, 0x4e, 0xba, 0x20, 0xb9, 0x14, 0x08, 0x9e, 0xc3, 0x34, 0xce, 0xb9,
-	0x10, 0x63, 0x3e, 0x34, 0x13, 0x6a, 0x13, 0x59, 0xb5, 0x83, 0x5d, 0x87, 0x0d, 0xe9, 0x65, 0xe5,
-	0xa1, 0x48, 0xf2, 0xa3, 0x28, 0xef, 0xe7, 0x3c, 0x16, 0xdd, 0x0e, 0xd1, 0xd7, 0x75, 0xb1, 0x77,
-	0xe1, 0x5c, 0x09, 0xce, 0xf8, 0x80, 0x47, 0xc7, 0x7c, 0xd8, 0x5d, 0xa6, 0xaf, 0x4e, 0xeb, 0x66,
-	0x97, 0xa0, 0x8d, 0xce, 0xe5, 0x34, 0x1d, 0x86, 0x78, 0x0f, 0xaf, 0xd0, 0x39, 0xd8, 0x10, 0x7b,
-	0x1b, 0x96, 0x53, 0x2e, 0x2f, 0xcb, 0x23, 0x31, 0x1e, 0xe4, 0xdd, 0x55, 0xba, 0xc9, 0xda, 0x4a,
-	0x99, 0x50, 0x72, 0x03, 0x97, 0x02, 0x85, 0x72, 0x90, 0x93, 0xbb, 0x12, 0xce, 0xba, 0x6b, 0x24,
-	0x6e, 0x05, 0x40, 0x3a, 0x92, 0x45, 0xc7, 0xa1, 0xe0, 0xdd, 0x75, 0x92, 0x2d, 0xdd, 0xf4, 0xff,
-	0xc2, 0x83, 0x8d, 0x07, 0x51, 0x2e, 0x94, 0x10, 0x1a, 0x73, 0xfc, 0x1a, 0xb4, 0xa5, 0xf8, 0xf5,
-	0x93, 0x78, 0x3c, 0x53, 0x12, 0x09, 0x12, 0xfa, 0x6a, 0x3c, 0x9e, 0xb1, 0xcf, 0xc1, 0x72, 0x14,
-	0xdb, 0x24, 0x52, 0x87, 0x3b, 0x1a, 0x24, 0xa2, 0xd7, 0xa0, 0x9d, 0x4e, 0x0f, 0xc6, 0xd1, 0x40,
-	0x92, 0xcc, 0x49, 0x2e, 0x12, 0x22, 0x02, 0x74, 0xf4, 0xe4, 0x4c, 0x24, 0x45, 0x93, 0x28, 0xda,
-	0x0a, 0x43, 0x12, 0xff, 0x16, 0x6c, 0xba, 0x13, 0x54, 0xc6, 0xea, 0x2a, 0x2c, 0x29, 0xd9, 0xce,
-	0xbb, 0x6d, 0xda, 0x9f, 0x15, 0xb5, 0x3f, 0x8a, 0x34, 0x30, 0xfd, 0xfe, 0x7f, 0x78, 0xd0, 0x44,
-	0x03, 0x70, 0xba, 0xb1, 0xb0, 0x6d, 0xfa, 0x9c, 0x63, 0xd3, 0xc9, 0xef, 0x47, 0xaf, 0x48, 0x8a,
-	0x84, 0x54, 0x1b, 0x0b, 0x29, 0xfa, 0x33, 0x3e, 0x38, 0x26, 0xdd, 0x31, 0xfd, 0x88, 0xa0, 0x66,
-	0xe1, 0xd5, 0x49, 0x5f, 0x4b, 0xc5, 0x31, 0x6d, 0xdd, 0x47, 0x5f, 0x2e, 0x16, 0x7d, 0xf4, 0x5d,
-	0x17, 0x16, 0xa3, 0xf8, 0x20, 0x99, 0xc6, 0x43, 0x52, 0x92, 0xa5, 0x40, 0x37, 0xf1, 0xb0, 0x53,
-	0xf2, 0xa4, 0xa2, 0x09, 0x57, 0xda, 0x51, 0x00, 0x3e, 0x43, 0xd7, 0x2a, 0x27, 0x83, 0x67, 0xee,
-	0xb1, 0x77, 0x60, 0xdd, 0xc2, 0xd4, 0x0e, 0xbe, 0x0e, 0xf3, 0x29, 0x02, 0xca, 0x51, 0xd2, 0xe2,
-	0x45, 0x96, 0x52, 0xf6, 0xf8, 0x6b, 0x18, 0x3f, 0x8b, 0x0f, 0xe2, 0xc3, 0x44, 0x73, 0xfa, 0xfb,
-	0x39, 0x0c, 0x78, 0x15, 0xa4, 0x18, 0x5d, 0x81, 0xd5, 0x68, 0xc8, 0x63, 0x11, 0x89, 0x59, 0xdf,
-	0xf1, 0xe0, 0xca, 0x30, 0xde, 0x30, 0xe1, 0x38, 0x0a, 0x73, 0x65, 0xc3, 0x64, 0x83, 0xed, 0xc0,
-	0x26, 0x8a, 0xbf, 0x96, 0x68, 0x73, 0xac, 0xd2, 0x91, 0xac, 0xed, 0x43, 0x8d, 0x45, 0x5c, 0x49,
-	0xa0, 0xf9, 0x44, 0x5a, 0xda, 0xba, 0x2e, 0xdc, 0x35, 0xc9, 0x09, 0x97, 0x3c, 0x2f, 0x55, 0xc4,
-	0x00, 0x95, 0xe8, 0x6d, 0x41, 0x3a, 0xb1, 0xe5, 0xe8, 0xcd, 0x8a, 0x00, 0x97, 0x2a, 0x11, 0xe0,
-	0x15, 0x58, 0xcd, 0x67, 0xf1, 0x80, 0x0f, 0xfb, 0x22, 0xc1, 0x71, 0xa3, 0x98, 0x4e, 0x67, 0x29,
-	0x28, 0xc3, 0x14, 0xab, 0xf2, 0x5c, 0xc4, 0x5c, 0x90, 0xe9, 0x5a, 0x0a, 0x74, 0x13, 0x6f, 0x01,
-	0x22, 0x91, 0x42, 0xdd, 0x0a, 0x54, 0x0b, 0xaf, 0xca, 0x69, 0x16, 0xe5, 0xdd, 0x0e, 0xa1, 0xf4,
-	0x37, 0xfb, 0x02, 0x9c, 0x3d, 0xc0, 0xc8, 0xea, 0x88, 0x87, 0x43, 0x9e, 0xd1, 0xe9, 0xcb, 0xc0,
-	0x52, 0x5a, 0xa0, 0xfa, 0x4e, 0xff, 0x53, 0xba, 0xb7, 0x4d, 0x60, 0xfb, 0x98, 0x8c, 0x0e, 0x7b,
-	0x05, 0x5a, 0x72, 0x25, 0xf9, 0x51, 0xa8, 0x5c, 0x89, 0x25, 0x02, 0xf6, 0x8f, 0x42, 0x54, 0x53,
-	0x67, 0x73, 0x1a, 0xe4, 0x1f, 0xb6, 0x09, 0xdb, 0x95, 0x7b, 0xf3, 0x06, 0xac, 0xe8, 0x90, 0x39,
-	0xef, 0x8f, 0xf9, 0xa1, 0xd0, 0x61, 0x40, 0x3c, 0x9d, 0xe0, 0x70, 0xf9, 0x03, 0x7e, 0x28, 0xfc,
-	0x87, 0xb0, 0xae, 0xb4, 0xf3, 0xab, 0x29, 0xd7, 0x43, 0xff, 0x4a, 0xf9, 0xea, 0x92, 0xbe, 0xc3,
-	0x86, 0xab, 0xce, 0x14, 0xcb, 0x94, 0xee, 0x33, 0x3f, 0x00, 0xa6, 0xba, 0x6f, 0x8f, 0x93, 0x9c,
-	0x2b, 0x86, 0x3e, 0x74, 0x06, 0xe3, 0x24, 0xd7, 0xc1, 0x86, 0x5a, 0x8e, 0x83, 0xe1, 0x09, 0xe4,
-	0xd3, 0xc1, 0x00, 0xf5, 0x5d, 0x5a, 0x2e, 0xdd, 0xf4, 0xff, 0xd2, 0x83, 0x0d, 0xe2, 0xa6, 0xed,
-	0x88, 0xf1, 0x50, 0x5f, 0x7e, 0x9a, 0x9d, 0x81, 0x1d, 0x80, 0x6d, 0xc2, 0xfc, 0x61, 0x92, 0x0d,
-	0xb8, 0x1a, 0x49, 0x36, 0x7e, 0x72, 0x9f, 0xbb, 0x59, 0xf1, 0xb9, 0xff, 0xc9, 0x83, 0x75, 0x9a,
-	0xea, 0xbe, 0x08, 0xc5, 0x34, 0x57, 0xcb, 0xff, 0x55, 0x58, 0xc6, 0xa5, 0x72, 0xad, 0x34, 0x6a,
-	0xa2, 0x9b, 0x46, 0xbf, 0x09, 0x95, 0xc4, 0xbb, 0x67, 0x02, 0x97, 0x98, 0x7d, 0x09, 0x3a, 0x76,
-	0xde, 0x83, 0xe6, 0xdc, 0xde, 0x39, 0xaf, 0x57, 0x59, 0x91, 0x9c, 0xdd, 0x33, 0x81, 0xf3, 0x01,
-	0xbb, 0x01, 0x40, 0x4e, 0x05, 0xb1, 0x55, 0x01, 0xeb, 0x79, 0x77, 0x93, 0xac, 0xc3, 0xda, 0x3d,
-	0x13, 0x58, 0xe4, 0xb7, 0x96, 0x60, 0x41, 0xde, 0x82, 0xfe, 0x7d, 0x58, 0x76, 0x66, 0xea, 0xc4,
-	0x12, 0x1d, 0x19, 0x4b, 0x54, 0x42, 0xcf, 0x46, 0x35, 0xf4, 0xf4, 0xff, 0xbd, 0x01, 0x0c, 0xa5,
-	0xad, 0x74, 0x9c, 0x78, 0x0d, 0x27, 0x43, 0xc7, 0xa9, 0xea, 0x04, 0x36, 0xc4, 0xae, 0x01, 0xb3,
-	0x9a, 0x3a, 0xc3, 0x20, 0x6f, 0x87, 0x9a, 0x1e, 0x34, 0x63, 0xd2, 0x23, 0xd2, 0x91, 0xae, 0x72,
-	0x1f, 0xe5, 0xb9, 0xd5, 0xf6, 0xe1, 0x05, 0x90, 0x4e, 0xf3, 0x23, 0x74, 0x13, 0xb4, 0xdb, 0xa5,
-	0xdb, 0x65, 0x01, 0x59, 0x78, 0xa1, 0x80, 0x2c, 0x96, 0x05, 0xc4, 0xbe, 0xf8, 0x97, 0x9c, 0x8b,
-	0x1f, 0xbd, 0xac, 0x49, 0x14, 0x93, 0xf7, 0xd0, 0x9f, 0xe0, 0xe8, 0xca, 0xcb, 0x72, 0x40, 0x76,
-	0x15, 0xd6, 0x94, 0xf7, 0x56, 0x78, 0x17, 0x40, 0x7b, 0x5c, 0xc1, 0xfd, 0x1f, 0x7b, 0xb0, 0x86,
-	0xfb, 0xec, 0xc8, 0xe2, 0x7b, 0x40, 0xaa, 0xf0, 0x92, 0xa2, 0xe8, 0xd0, 0xfe, 0xec, 0x92, 0xf8,
-	0x2e, 0xb4, 0x88, 0x61, 0x92, 0xf2, 0x58, 0x09, 0x62, 0xd7, 0x15, 0xc4, 0xc2, 0x0a, 0xed, 0x9e,
-	0x09, 0x0a, 0x62, 0x4b, 0x0c, 0xff, 0xd1, 0x83, 0xb6, 0x9a, 0xe6, 0x4f, 0x1d, 0x31, 0xf4, 0x60,
-	0x09, 0x25, 0xd2, 0x72, 0xcb, 0x4d, 0x1b, 0xef, 0x8c, 0x09, 0x86, 0x65, 0x78, 0x49, 0x3a, 0xd1,
-	0x42, 0x19, 0xc6, 0x1b, 0x8f, 0x0c, 0x6e, 0xde, 0x17, 0xd1, 0xb8, 0xaf, 0x7b, 0x55, 0x9a, 0xb1,
-	0xae, 0x0b, 0xed, 0x4e, 0x2e, 0xc2, 0x11, 0x57, 0x97, 0x99, 0x6c, 0x60, 0x58, 0xa4, 0x16, 0x54,
-	0x72, 0xfa, 0xfc, 0x1f, 0x01, 0x9c, 0xab, 0x74, 0x99, 0xa4, 0xb6, 0x72, 0x83, 0xc7, 0xd1, 0xe4,
-	0x20, 0x31, 0x1e, 0xb5, 0x67, 0x7b, 0xc8, 0x4e, 0x17, 0x1b, 0xc1, 0x59, 0x7d, 0x6b, 0xe3, 0x9e,
-	0x16, 0x77, 0x74, 0x83, 0xdc, 0x8d, 0xb7, 0x5d, 0x19, 0x28, 0x0f, 0xa8, 0x71, 0x5b, 0x73, 0xeb,
-	0xf9, 0xb1, 0x23, 0xe8, 0x1a, 0xf7, 0x40, 0x99, 0x78, 0xcb, 0x85, 0xc0, 0xb1, 0xde, 0x7a, 0xc1,
-	0x58, 0x64, 0x8f, 0x86, 0x7a, 0x98, 0x53, 0xb9, 0xb1, 0x19, 0x5c, 0xd4, 0x7d, 0x64, 0xc3, 0xab,
-	0xe3, 0x35, 0x5f, 0x6a, 0x6d, 0xf7, 0xf0, 0x63, 0x77, 0xd0, 0x17, 0x30, 0xee, 0xfd, 0xc8, 0x83,
-	0x15, 0x97, 0x1d, 0x8a, 0x8e, 0x52, 0x42, 0x6d, 0x8c, 0xb4, 0xdb, 0x55, 0x82, 0xab, 0xc1, 0x61,
-	0xa3, 0x2e, 0x38, 0xb4, 0x43, 0xc0, 0xb9, 0x17, 0x85, 0x80, 0xcd, 0x97, 0x0b, 0x01, 0xe7, 0xeb,
-	0x42, 0xc0, 0xde, 0x7f, 0x79, 0xc0, 0xaa, 0xe7, 0xcb, 0xee, 0xcb, 0xe8, 0x34, 0xe6, 0x63, 0x65,
-	0x27, 0x7e, 0xe9, 0xe5, 0x64, 0x44, 0xef, 0xa1, 0xfe, 0x1a, 0x85, 0xd5, 0x36, 0x04, 0xb6, 0xdb,
-	0xb2, 0x1c, 0xd4, 0x75, 0x95, 0x82, 0xd2, 0xe6, 0x8b, 0x83, 0xd2, 0xf9, 0x17, 0x07, 0xa5, 0x0b,
-	0xe5, 0xa0, 0xb4, 0xf7, 0x3b, 0xb0, 0xec, 0x9c, 0xfa, 0xff, 0xdd, 0x8a, 0xcb, 0x2e, 0x8f, 0x3c,
-	0x60, 0x07, 0xeb, 0xfd, 0x67, 0x03, 0x58, 0x55, 0xf2, 0x7e, 0xae, 0x73, 0x20, 0x39, 0x72, 0x0c,
-	0xc8, 0x9c, 0x92, 0x23, 0xc7, 0x74, 0xfc, 0x7f, 0x1a, 0xc5, 0xb7, 0x60, 0x3d, 0xe3, 0x83, 0xe4,
-	0x98, 0x9e, 0xda, 0xdc, 0x84, 0x46, 0xb5, 0x03, 0x9d, 0x3e, 0x37, 0x14, 0x5f, 0x72, 0x5e, 0x46,
-	0xac, 0x9b, 0xa1, 0x14, 0x91, 0xfb, 0x5b, 0xb0, 0x29, 0x1f, 0xac, 0x6e, 0x49, 0x56, 0xda, 0xc8,
-	0x7e, 0xcf, 0x83, 0xb3, 0xa5, 0x8e, 0xe2, 0xf9, 0x40, 0xda, 0x51, 0xd7, 0xb8, 0xba, 0x20, 0xce,
-	0x5f, 0x09, 0xb0, 0x35, 0x7f, 0x79, 0xdf, 0x54, 0x3b, 0x70, 0x7f, 0xa6, 0x71, 0x95, 0x5e, 0xee,
-	0x7a, 0x5d, 0x97, 0x7f, 0x0e, 0xce, 0xaa, 0x93, 0x2d, 0x4d, 0x7c, 0x07, 0xb6, 0xca, 0x1d, 0x45,
-	0x3e, 0xd4, 0x9d, 0xb2, 0x6e, 0xfa, 0xbf, 0x0d, 0xec, 0x6b, 0x53, 0x9e, 0xcd, 0xe8, 0xa1, 0xc2,
-	0x24, 0x17, 0xce, 0x95, 0xa3, 0xf0, 0x85, 0x74, 0x7a, 0xf0, 0x15, 0x3e, 0xd3, 0x2f, 0x41, 0x8d,
-	0xe2, 0x25, 0xe8, 0x55, 0x00, 0x0c, 0x2b, 0xe8, 0x65, 0x43, 0xbf, 0xcd, 0x61, 0xd4, 0x26, 0x19,
-	0xfa, 0x37, 0x60, 0xc3, 0xe1, 0x6f, 0x76, 0x72, 0x41, 0x7d, 0x21, 0x43, 0x5b, 0xf7, 0xbd, 0x44,
-	0xf5, 0xf9, 0x7f, 0xea, 0xc1, 0xdc, 0x6e, 0x92, 0xda, 0x49, 0x31, 0xcf, 0x4d, 0x8a, 0x29, 0xbb,
-	0xd9, 0x37, 0x66, 0xb1, 0xa1, 0xb4, 0xde, 0x06, 0xd1, 0xea, 0x85, 0x13, 0x81, 0xc1, 0xdd, 0x61,
-	0x92, 0x9d, 0x84, 0xd9, 0x50, 0x6d, 0x6f, 0x09, 0xc5, 0xd5, 0x15, 0xc6, 0x05, 0xff, 0x44, 0x87,
-	0x81, 0x72, 0x82, 0x33, 0x15, 0x8f, 0xaa, 0x96, 0xff, 0x1d, 0x0f, 0xe6, 0x69, 0xae, 0xa8, 0x09,
-	0xf2, 0xf8, 0xe9, 0x91, 0x90, 0x52, 0x8e, 0x9e, 0xd4, 0x84, 0x12, 0x5c, 0x7a, 0x3a, 0x6c, 0x54,
-	0x9e, 0x0e, 0x2f, 0x40, 0x4b, 0xb6, 0x8a, 0xb7, 0xb6, 0x02, 0x60, 0x17, 0xa1, 0x79, 0x94, 0xa4,
-	0xfa, 0xfe, 0x02, 0x9d, 0x69, 0x4a, 0xd2, 0x80, 0x70, 0xff, 0x2a, 0xac, 0x3e, 0x4c, 0x86, 0xdc,
-	0xca, 0x04, 0x9c, 0x7a, 0x8a, 0xfe, 0xef, 0x7a, 0xb0, 0xa4, 0x89, 0xd9, 0x15, 0x68, 0xe2, 0x35,
-	0x54, 0x72, 0xfc, 0x4c, 0x3e, 0x18, 0xe9, 0x02, 0xa2, 0x40, 0xf3, 0x41, 0x11, 0x64, 0xe1, 0x26,
-	0xe8, 0xf8, 0xb1, 0xb8, 0x80, 0xdf, 0x84, 0x15, 0x39, 0xe7, 0xd2, 0x45, 0x55, 0x42, 0xfd, 0xbf,
-	0xf2, 0x60, 0xd9, 0x19, 0x03, 0xdd, 0xfd, 0x71, 0x98, 0x0b, 0x95, 0x63, 0x53, 0x9b, 0x68, 0x43,
-	0x76, 0x6e, 0xa8, 0xe1, 0xe6, 0x86, 0x4c, 0xd6, 0x62, 0xce, 0xce, 0x5a, 0x5c, 0x87, 0x56, 0xf1,
-	0x0c, 0xdb, 0x74, 0xcc, 0x02, 0x8e, 0xa8, 0x33, 0xdd, 0x05, 0x11, 0xf2, 0x19, 0x24, 0xe3, 0x24,
-	0x53, 0xaf, 0x94, 0xb2, 0xe1, 0xdf, 0x80, 0xb6, 0x45, 0x8f, 0xd3, 0x88, 0xb9, 0x38, 0x49, 0xb2,
-	0x27, 0x3a, 0x45, 0xa5, 0x9a, 0xe6, 0x41, 0xa7, 0x51, 0x3c, 0xe8, 0xf8, 0x7f, 0xed, 0xc1, 0x32,
-	0x4a, 0x4a, 0x14, 0x8f, 0xf6, 0x92, 0x71, 0x34, 0x98, 0x91, 0xc4, 0x68, 0xa1, 0x50, 0xcf, 0x97,
-	0x5a, 0x62, 0x5c, 0x18, 0xef, 0x7b, 0xed, 0xed, 0x2b, 0x79, 0x31, 0x6d, 0x94, 0x7c, 0xbc, 0xb7,
-	0x0e, 0xc2, 0x9c, 0xcb, 0xf0, 0x40, 0xd9, 0x69, 0x07, 0x44, 0xeb, 0x82, 0x40, 0x16, 0x0a, 0xde,
-	0x9f, 0x44, 0xe3, 0x71, 0x24, 0x69, 0xa5, 0x84, 0xd7, 0x75, 0xf9, 0x3f, 0x6c, 0x40, 0x5b, 0x59,
-	0x91, 0xbb, 0xc3, 0x91, 0x4c, 0x06, 0x2b, 0x27, 0xc4, 0xa8, 0x9f, 0x85, 0xe8, 0x7e, 0xc7, 0x6d,
-	0xb1, 0x90, 0xf2, 0xb1, 0xce, 0x55, 0x8f, 0xf5, 0x02, 0xb4, 0x50, 0xbc, 0xde, 0x26, 0xff, 0x48,
-	0xbe, 0xda, 0x17, 0x80, 0xee, 0xdd, 0xa1, 0xde, 0xf9, 0xa2, 0x97, 0x00, 0xc7, 0x23, 0x5a, 0x28,
-	0x79, 0x44, 0xef, 0x42, 0x47, 0xb1, 0xa1, 0x7d, 0xa7, 0xe0, 0xab, 0x10, 0x70, 0xe7, 0x4c, 0x02,
-	0x87, 0x52, 0x7f, 0xb9, 0xa3, 0xbf, 0x5c, 0x7a, 0xd1, 0x97, 0x9a, 0x92, 0xde, 0x46, 0xe4, 0xde,
-	0xdc, 0xcf, 0xc2, 0xf4, 0x48, 0x5b, 0xe6, 0xa1, 0x79, 0xf0, 0x25, 0x98, 0x5d, 0x85, 0x79, 0xfc,
-	0x4c, 0x5b, 0xbf, 0x7a, 0xa5, 0x93, 0x24, 0xec, 0x0a, 0xcc, 0xf3, 0xe1, 0x88, 0x6b, 0xaf, 0x9c,
-	0xb9, 0xf1, 0x11, 0x9e, 0x51, 0x20, 0x09, 0xd0, 0x04, 0x20, 0x5a, 0x32, 0x01, 0xae, 0xe5, 0x5c,
-	0xc0, 0xe6, 0x07, 0x43, 0x7f, 0x13, 0xd8, 0x43, 0x29, 0xb5, 0x76, 0xee, 0xf0, 0x0f, 0xe6, 0xa0,
-	0x6d, 0xc1, 0xa8, 0xcd, 0x23, 0x9c, 0x70, 0x7f, 0x18, 0x85, 0x13, 0x2e, 0x78, 0xa6, 0x24, 0xb5,
-	0x84, 0x92, 0x81, 0x3d, 0x1e, 0xf5, 0x93, 0xa9, 0xe8, 0x0f, 0xf9, 0x28, 0xe3, 0xf2, 0xbe, 0xf3,
-	0x82, 0x12, 0x8a, 0x74, 0x93, 0xf0, 0xa9, 0x4d, 0x27, 0xe5, 0xa1, 0x84, 0xea, 0x4c, 0xa0, 0xdc,
-	0xa3, 0x66, 0x91, 0x09, 0x94, 0x3b, 0x52, 0xb6, 0x43, 0xf3, 0x35, 0x76, 0xe8, 0x1d, 0xd8, 0x92,
-	0x16, 0x47, 0xe9, 0x66, 0xbf, 0x24, 0x26, 0xa7, 0xf4, 0x62, 0x3c, 0x8d, 0x73, 0xd6, 0x02, 0x9e,
-	0x47, 0x9f, 0xca, 0xa8, 0xdd, 0x0b, 0x2a, 0x38, 0xd2, 0xa2, 0x3a, 0x3a, 0xb4, 0xf2, 0xb5, 0xa4,
-	0x82, 0x13, 0x6d, 0xf8, 0xd4, 0xa5, 0x6d, 0x29, 0xda, 0x12, 0xee, 0x2f, 0x43, 0x7b, 0x5f, 0x24,
-	0xa9, 0x3e, 0x94, 0x15, 0xe8, 0xc8, 0xa6, 0x7a, 0x1b, 0x7b, 0x05, 0xce, 0x93, 0x14, 0x3d, 0x4a,
-	0xd2, 0x64, 0x9c, 0x8c, 0x66, 0xfb, 0xd3, 0x83, 0x7c, 0x90, 0x45, 0x29, 0x7a, 0xcb, 0xfe, 0x3f,
-	0x78, 0xb0, 0xe1, 0xf4, 0xaa, 0x30, 0xff, 0x0b, 0x52, 0xa4, 0xcd, 0xa3, 0x86, 0x14, 0xbc, 0x75,
-	0xcb, 0x1c, 0x4a, 0x42, 0x99, 0x60, 0x79, 0xac, 0xde, 0x39, 0x6e, 0xc2, 0xaa, 0x9e, 0x99, 0xfe,
-	0x50, 0x4a, 0x61, 0xb7, 0x2a, 0x85, 0xea, 0xfb, 0x15, 0xf5, 0x81, 0x66, 0xf1, 0x6b, 0xd2, 0xe7,
-	0xe4, 0x43, 0x5a, 0xa3, 0x8e, 0xf7, 0x7a, 0xfa, 0x7b, 0xdb, 0xd1, 0xd5, 0x33, 0x18, 0x18, 0x30,
-	0xf7, 0xff, 0xc8, 0x03, 0x28, 0x66, 0x87, 0x82, 0x51, 0x98, 0x74, 0x59, 0xae, 0x65, 0x99, 0xef,
-	0xd7, 0xa1, 0x63, 0xf2, 0xd9, 0xc5, 0x2d, 0xd1, 0xd6, 0x18, 0x3a, 0x30, 0x97, 0x61, 0x75, 0x34,
-	0x4e, 0x0e, 0xe8, 0xce, 0xa5, 0xc7, 0xd6, 0x5c, 0xbd, 0x10, 0xae, 0x48, 0xf8, 0x9e, 0x42, 0x8b,
-	0x2b, 0xa5, 0x69, 0x5d, 0x29, 0xfe, 0xb7, 0x1b, 0x26, 0x3f, 0x5a, 0xac, 0xf9, 0x54, 0x2d, 0x63,
-	0x3b, 0x15, 0xe3, 0x78, 0x4a, 0x3a, 0x92, 0x32, 0x1b, 0x7b, 0x2f, 0x0c, 0xf2, 0x6e, 0xc0, 0x4a,
-	0x26, 0xad, 0x8f, 0x36, 0x4d, 0xcd, 0xe7, 0x98, 0xa6, 0xe5, 0xcc, 0xb9, 0x77, 0x7e, 0x01, 0xd6,
-	0xc2, 0xe1, 0x31, 0xcf, 0x44, 0x44, 0xde, 0x3e, 0x5d, 0xfa, 0xd2, 0xa0, 0xae, 0x5a, 0x38, 0xdd,
-	0xc5, 0x97, 0x61, 0x55, 0xbd, 0xca, 0x1a, 0x4a, 0x55, 0x8b, 0x53, 0xc0, 0x48, 0xe8, 0xff, 0x40,
-	0xa7, 0x62, 0xdd, 0x33, 0x3c, 0x7d, 0x47, 0xec, 0xd5, 0x35, 0x4a, 0xab, 0xfb, 0x9c, 0x4a, 0x8b,
-	0x0e, 0x75, 0x48, 0xa1, 0x12, 0xd4, 0x12, 0x54, 0x69, 0x6c, 0x77, 0x4b, 0x9b, 0x2f, 0xb3, 0xa5,
-	0xfe, 0xf7, 0xe6, 0x60, 0xf1, 0x83, 0xf8, 0x38, 0x89, 0x06, 0x94, 0xa4, 0x9c, 0xf0, 0x49, 0xa2,
-	0x0b, 0x1e, 0xf0, 0x6f, 0xbc, 0xd1, 0xe9, 0xf1, 0x2f, 0x15, 0x2a, 0xcb, 0xa8, 0x9b, 0x78, 0xbb,
-	0x65, 0x45, 0x11, 0x90, 0x94, 0x14, 0x0b, 0x41, 0xff, 0x30, 0xb3, 0x2b, 0xa0, 0x54, 0xab, 0xa8,
-	0x18, 0x99, 0xb7, 0x2a, 0x46, 0x28, 0xa5, 0x2d, 0xdf, 0x35, 0x69, 0x3b, 0x97, 0x02, 0xdd, 0x24,
-	0x3f, 0x36, 0xe3, 0x32, 0xe0, 0xa5, 0x7b, 0x72, 0x51, 0xf9, 0xb1, 0x36, 0x88, 0x77, 0xa9, 0xfc,
-	0x40, 0xd2, 0x48, 0x5b, 0x63, 0x43, 0xe8, 0x5b, 0x94, 0x8b, 0xa8, 0x5a, 0xf2, 0x88, 0x4b, 0x30,
-	0x1a, 0xa4, 0x21, 0x37, 0x76, 0x43, 0xae, 0x01, 0x64, 0x91, 0x53, 0x19, 0xb7, 0xbc, 0x60, 0xf9,
-	0x3e, 0xab, 0x5a, 0xe4, 0x83, 0x84, 0xe3, 0xf1, 0x41, 0x38, 0x78, 0x42, 0xa5, 0x6d, 0xf4, 0x1c,
-	0xdb, 0x0a, 0x5c, 0x10, 0x67, 0x4d, 0x95, 0x5a, 0x8a, 0xc5, 0xb2, 0x7c, 0x4e, 0xb5, 0x20, 0xff,
-	0xeb, 0xc0, 0x6e, 0x0e, 0x87, 0xea, 0x84, 0x4c, 0x8c, 0x50, 0xec, 0xad, 0xe7, 0xec, 0x6d, 0xcd,
-	0x1a, 0x1b, 0xb5, 0x6b, 0xf4, 0xef, 0x42, 0x7b, 0xcf, 0xaa, 0x48, 0xa3, 0xc3, 0xd4, 0xb5, 0x68,
-	0x4a, 0x00, 0x2c, 0xc4, 0x1a, 0xb0, 0x61, 0x0f, 0xe8, 0xff, 0x32, 0xb0, 0x07, 0x51, 0x2e, 0xcc,
-	0xfc, 0xe4, 0x06, 0xbe, 0x0e, 0x1d, 0x93, 0xed, 0x2a, 0x5e, 0x60, 0xdb, 0x0a, 0xa3, 0x97, 0xd1,
-	0x9b, 0xf2, 0xe9, 0xb6, 0xbc, 0xb0, 0xab, 0xb0, 0x14, 0x49, 0x48, 0xdb, 0x61, 0xfd, 0x30, 0xaa,
-	0x29, 0x4d, 0x3f, 0x3a, 0x14, 0x0a, 0x74, 0xcc, 0xfc, 0x0f, 0x3d, 0x58, 0x54, 0x4b, 0xc3, 0xeb,
-	0xd0, 0xa9, 0xc5, 0x93, 0x0b, 0x73, 0xb0, 0xfa, 0x0a, 0xa6, 0xaa, 0xd4, 0xcd, 0xd5, 0x49, 0x1d,
-	0x83, 0x66, 0x1a, 0x8a, 0x23, 0xf2, 0xa0, 0x5b, 0x01, 0xfd, 0xad, 0x23, 0xa5, 0xf9, 0x22, 0x52,
-	0xaa, 0x2b, 0x9a, 0x93, 0x36, 0xa3, 0x82, 0xe3, 0xa2, 0xe8, 0xb1, 0x53, 0xe2, 0x26, 0xbb, 0xa9,
-	0x1e, 0x92, 0x0b, 0xb8, 0xd8, 0x2f, 0xc5, 0xa2, 0xbc, 0x5f, 0x8a, 0x34, 0x30, 0xfd, 0x7e, 0x0f,
-	0xba, 0x77, 0xf8, 0x98, 0x0b, 0x7e, 0x73, 0x3c, 0x2e, 0xf3, 0x7f, 0x05, 0xce, 0xd7, 0xf4, 0xa9,
-	0x5b, 0xf5, 0x1e, 0xac, 0xdf, 0xe1, 0x07, 0xd3, 0xd1, 0x03, 0x7e, 0x5c, 0x3c, 0x41, 0x30, 0x68,
-	0xe6, 0x47, 0xc9, 0x89, 0x3a, 0x5b, 0xfa, 0x1b, 0x03, 0xde, 0x31, 0xd2, 0xf4, 0xf3, 0x94, 0x0f,
-	0x74, 0xed, 0x0e, 0x21, 0xfb, 0x29, 0x1f, 0xf8, 0xef, 0x00, 0xb3, 0xf9, 0xa8, 0x25, 0xa0, 0xe6,
-	0x4e, 0x0f, 0xfa, 0xf9, 0x2c, 0x17, 0x7c, 0xa2, 0x8b, 0x92, 0x6c, 0xc8, 0xbf, 0x0c, 0x9d, 0xbd,
-	0x70, 0x16, 0xf0, 0x4f, 0x54, 0x39, 0x24, 0x06, 0x6f, 0xe1, 0x0c, 0x45, 0xd9, 0x04, 0x6f, 0xd4,
-	0xed, 0xff, 0x5d, 0x03, 0x16, 0x24, 0x25, 0x72, 0x1d, 0xf2, 0x5c, 0x44, 0xb1, 0x4c, 0xbf, 0x2b,
-	0xae, 0x16, 0x54, 0x91, 0x8d, 0x46, 0x8d, 0x6c, 0x28, 0x77, 0x4a, 0xd7, 0x41, 0x28, 0x21, 0x70,
-	0x30, 0x8a, 0x4d, 0xcd, 0xe3, 0x65, 0x53, 0xc5, 0xa6, 0x1a, 0x28, 0x45, 0xc9, 0x85, 0x7d, 0x90,
-	0xf3, 0xd3, 0x42, 0xab, 0xc4, 0xc1, 0x86, 0x6a, 0xad, 0xd0, 0xa2, 0x94, 0x9a, 0x8a, 0x15, 0xaa,
-	0x58, 0x9b, 0xa5, 0x97, 0xb0, 0x36, 0xd2, 0xc7, 0x72, 0xac, 0x0d, 0x83, 0xb5, 0x7b, 0x9c, 0x07,
-	0x3c, 0x4d, 0x32, 0x5d, 0x53, 0xea, 0x7f, 0xd7, 0x83, 0x35, 0x75, 0x7b, 0x98, 0x3e, 0xf6, 0xba,
-	0x73, 0xd5, 0x78, 0x75, 0x19, 0xd9, 0x37, 0x60, 0x99, 0x82, 0x2d, 0x8c, 0xa4, 0x28, 0xb2, 0x52,
-	0xf9, 0x07, 0x07, 0xc4, 0x39, 0xe9, 0x1c, 0xe3, 0x24, 0x1a, 0xab, 0x0d, 0xb6, 0x21, 0xbc, 0x16,
-	0x75, 0x30, 0x46, 0xdb, 0xeb, 0x05, 0xa6, 0xed, 0xff, 0xad, 0x07, 0xeb, 0xd6, 0x84, 0x95, 0x44,
-	0xdd, 0x00, 0xfd, 0x84, 0x29, 0xf3, 0x09, 0x52, 0x31, 0xce, 0xb9, 0x37, 0x61, 0xf1, 0x99, 0x43,
-	0x4c, 0x07, 0x13, 0xce, 0x68, 0x82, 0xf9, 0x54, 0x56, 0x77, 0x35, 0x03, 0x1b, 0x42, 0xa1, 0x38,
-	0xe1, 0xfc, 0x89, 0x21, 0x99, 0x23, 0x12, 0x07, 0xa3, 0x17, 0xaa, 0x24, 0x16, 0x47, 0x86, 0x48,
-	0x96, 0x5e, 0xb8, 0xa0, 0xff, 0xcf, 0x1e, 0x6c, 0x48, 0x0f, 0x44, 0xf9, 0x77, 0xa6, 0x2c, 0x6c,
-	0x41, 0xba, 0x5c, 0x52, 0xbb, 0x76, 0xcf, 0x04, 0xaa, 0xcd, 0xbe, 0xf8, 0x92, 0x5e, 0x93, 0x79,
-	0x99, 0x3c, 0xe5, 0x2c, 0xe6, 0xea, 0xce, 0xe2, 0x39, 0x3b, 0x5d, 0x17, 0x99, 0xcf, 0xd7, 0x46,
-	0xe6, 0xb7, 0x16, 0x61, 0x3e, 0x1f, 0x24, 0x29, 0xf7, 0xb7, 0x60, 0xd3, 0x5d, 0x9c, 0x32, 0x27,
-	0xdf, 0xf7, 0xa0, 0x7b, 0x4f, 0xa6, 0x95, 0xa2, 0x78, 0xb4, 0x1b, 0xe5, 0x22, 0xc9, 0x4c, 0x1d,
-	0xec, 0x45, 0x80, 0x5c, 0x84, 0x99, 0x90, 0xf5, 0x21, 0x2a, 0xa6, 0x2e, 0x10, 0x9c, 0x23, 0x8f,
-	0x87, 0xb2, 0x57, 0x9e, 0x8d, 0x69, 0xe3, 0xc1, 0xd0, 0xab, 0x69, 0x3f, 0x39, 0x3c, 0xcc, 0xb9,
-	0xf1, 0x91, 0x6c, 0x0c, 0xc3, 0x2c, 0xd4, 0x5e, 0x0c, 0x2c, 0xf8, 0x31, 0x99, 0x4d, 0x19, 0x43,
-	0x95, 0x50, 0xff, 0x6f, 0x3c, 0x58, 0x2d, 0x26, 0x79, 0x17, 0x41, 0x57, 0xd3, 0xe5, 0xd4, 0x2c,
-	0x4d, 0xd7, 0xd1, 0x7e, 0x34, 0xec, 0x47, 0xb1, 0x9a, 0x9b, 0x85, 0x90, 0xf6, 0xa9, 0x56, 0x32,
-	0xd5, 0xb5, 0x38, 0x36, 0x24, 0x9f, 0xe0, 0x04, 0x7e, 0x2d, 0x0b, 0x71, 0x54, 0x8b, 0xca, 0x7b,
-	0x26, 0x82, 0xbe, 0x5a, 0x90, 0x39, 0x3e, 0xd5, 0xd4, 0x77, 0xcd, 0x22, 0xa1, 0xf8, 0xa7, 0xff,
-	0x1d, 0x0f, 0xce, 0xd7, 0x6c, 0xae, 0xd2, 0x8c, 0x3b, 0xb0, 0x7e, 0x68, 0x3a, 0xf5, 0x06, 0x48,
-	0xf5, 0xd8, 0x52, 0x52, 0x54, 0x5a, 0x74, 0x50, 0xfd, 0x80, 0xbd, 0x05, 0xeb, 0x94, 0xa4, 0x90,
-	0x5b, 0xea, 0xbc, 0x5e, 0x57, 0x3b, 0x76, 0x7e, 0xd0, 0x80, 0x15, 0x99, 0x33, 0x96, 0xbf, 0x84,
-	0xe0, 0x19, 0xfb, 0x10, 0x16, 0xd5, 0xef, 0x4e, 0xd8, 0x59, 0x35, 0xac, 0xfb, 0x4b, 0x97, 0xde,
-	0x56, 0x19, 0x56, 0xb2, 0xb3, 0xf1, 0xfb, 0x3f, 0xfe, 0xb7, 0x3f, 0x6e, 0x2c, 0xb3, 0xf6, 0xf6,
-	0xf1, 0xdb, 0xdb, 0x23, 0x1e, 0xe7, 0xc8, 0xe3, 0x37, 0x01, 0x8a, 0x9f, 0x6e, 0xb0, 0xae, 0x71,
-	0x18, 0x4a, 0x3f, 0x35, 0xe9, 0x9d, 0xaf, 0xe9, 0x51, 0x7c, 0xcf, 0x13, 0xdf, 0x0d, 0x7f, 0x05,
-	0xf9, 0x46, 0x71, 0x24, 0xe4, 0xef, 0x38, 0xde, 0xf3, 0xae, 0xb2, 0x21, 0x74, 0xec, 0x9f, 0x70,
-	0x30, 0x1d, 0x9f, 0xd5, 0xfc, 0x2e, 0xa4, 0xf7, 0x4a, 0x6d, 0x9f, 0x0e, 0x4e, 0x69, 0x8c, 0xb3,
-	0xfe, 0x1a, 0x8e, 0x31, 0x25, 0x0a, 0x33, 0xca, 0xce, 0xbf, 0xbc, 0x02, 0x2d, 0x93, 0xe3, 0x60,
-	0xdf, 0x82, 0x65, 0x27, 0xcd, 0xce, 0x34, 0xe3, 0xba, 0xac, 0x7c, 0xef, 0x42, 0x7d, 0xa7, 0x1a,
-	0xf6, 0x22, 0x0d, 0xdb, 0x65, 0x5b, 0x38, 0xac, 0xca, 0x6d, 0x6f, 0xd3, 0xe3, 0x82, 0x2c, 0xe7,
-	0x79, 0x02, 0x2b, 0x6e, 0x6a, 0x9c, 0x5d, 0x70, 0x0d, 0x4a, 0x69, 0xb4, 0x57, 0x4f, 0xe9, 0x55,
-	0xc3, 0x5d, 0xa0, 0xe1, 0xb6, 0xd8, 0xa6, 0x3d, 0x9c, 0xc9, 0x3d, 0x70, 0x2a, 0xc0, 0xb2, 0x7f,
-	0xdb, 0xc1, 0x5e, 0x35, 0x47, 0x5d, 0xf7, 0x9b, 0x0f, 0x73, 0x68, 0xd5, 0x1f, 0x7e, 0xf8, 0x5d,
-	0x1a, 0x8a, 0x31, 0xda, 0x50, 0xfb, 0xa7, 0x1d, 0xec, 0x63, 0x68, 0x99, 0x7a, 0x6e, 0x76, 0xce,
-	0x2a, 0xa2, 0xb7, 0x8b, 0xcc, 0x7b, 0xdd, 0x6a, 0x47, 0xdd, 0x51, 0xd9, 0x9c, 0x51, 0x20, 0x1e,
-	0xc0, 0x59, 0xe5, 0x70, 0x1e, 0xf0, 0x9f, 0x64, 0x25, 0x35, 0xbf, 0x48, 0xb9, 0xee, 0xb1, 0x1b,
-	0xb0, 0xa4, 0xcb, 0xe4, 0xd9, 0x56, 0x7d, 0xb9, 0x7f, 0xef, 0x5c, 0x05, 0x57, 0xfa, 0x7c, 0x13,
-	0xa0, 0x28, 0xf1, 0x36, 0x92, 0x5f, 0x29, 0x3c, 0x37, 0x9b, 0x58, 0x53, 0x0f, 0x3e, 0xa2, 0x82,
-	0x76, 0xb7, 0x82, 0x9c, 0xbd, 0x56, 0xd0, 0xd7, 0xd6, 0x96, 0x3f, 0x87, 0xa1, 0xbf, 0x45, 0x7b,
-	0xb7, 0xc6, 0x48, 0x95, 0x62, 0x7e, 0xa2, 0x4b, 0x11, 0xef, 0x40, 0xdb, 0x2a, 0x1b, 0x67, 0x9a,
-	0x43, 0xb5, 0xe4, 0xbc, 0xd7, 0xab, 0xeb, 0x52, 0xd3, 0xfd, 0x32, 0x2c, 0x3b, 0xf5, 0xdf, 0x46,
-	0x33, 0xea, 0xaa, 0xcb, 0x8d, 0x66, 0xd4, 0x97, 0x8c, 0x7f, 0x03, 0xda, 0x56, 0xb5, 0x36, 0xb3,
-	0x8a, 0x33, 0x4a, 0x75, 0xda, 0x66, 0x46, 0x75, 0xc5, 0xdd, 0x9b, 0xb4, 0xde, 0x15, 0xbf, 0x85,
-	0xeb, 0xa5, 0x7a, 0x3c, 0x14, 0x92, 0x6f, 0xc1, 0x8a, 0x5b, 0xbf, 0x6d, 0xb4, 0xaa, 0xb6, 0x12,
-	0xdc, 0x68, 0xd5, 0x29, 0x45, 0xdf, 0x4a, 0x20, 0xaf, 0x6e, 0x98, 0x41, 0xb6, 0x3f, 0x53, 0x19,
-	0xfe, 0x67, 0xec, 0x6b, 0x68, 0x3a, 0x54, 0x81, 0x24, 0x2b, 0xaa, 0xd6, 0xdd, 0x32, 0x4a, 0x23,
-	0xed, 0x95, 0x5a, 0x4a, 0x7f, 0x9d, 0x98, 0xb7, 0x59, 0xb1, 0x02, 0x69, 0xa1, 0xa9, 0x50, 0xd2,
-	0xb2, 0xd0, 0x76, 0x2d, 0xa5, 0x65, 0xa1, 0x9d, 0x7a, 0xca, 0xb2, 0x85, 0x16, 0x11, 0xf2, 0x88,
-	0x61, 0xb5, 0xf4, 0x20, 0x6b, 0x94, 0xa5, 0xbe, 0x9c, 0xa3, 0x77, 0xf1, 0xf9, 0xef, 0xb8, 0xae,
-	0x99, 0xd1, 0xe6, 0x65, 0x5b, 0x57, 0xdf, 0xfc, 0x16, 0x74, 0xec, 0xba, 0x5b, 0x63, 0xb3, 0x6b,
-	0xaa, 0x85, 0x8d, 0xcd, 0xae, 0x2b, 0xd4, 0xd5, 0x87, 0xcb, 0x3a, 0xf6, 0x30, 0xec, 0x1b, 0xb0,
-	0x6a, 0x3d, 0xfd, 0xef, 0xcf, 0xe2, 0x81, 0x11, 0x9e, 0x6a, 0xb1, 0x56, 0xaf, 0xce, 0x3f, 0xf3,
-	0xcf, 0x11, 0xe3, 0x75, 0xdf, 0x61, 0x8c, 0x82, 0x73, 0x1b, 0xda, 0x76, 0x59, 0xc1, 0x73, 0xf8,
-	0x9e, 0xb3, 0xba, 0xec, 0xba, 0xa5, 0xeb, 0x1e, 0xfb, 0x33, 0x0f, 0x3a, 0x76, 0x19, 0x20, 0x73,
-	0x92, 0x8a, 0x25, 0x3e, 0x5d, 0xbb, 0xcf, 0x66, 0xe4, 0x07, 0x34, 0xc9, 0x07, 0x57, 0xbf, 0xec,
-	0x6c, 0xf2, 0x67, 0x8e, 0x9f, 0x7f, 0xad, 0xfc, 0x93, 0xaa, 0x67, 0x65, 0x02, 0xbb, 0xa0, 0xed,
-	0xd9, 0x75, 0x8f, 0xbd, 0x27, 0x7f, 0x76, 0xa7, 0x63, 0x74, 0x66, 0x19, 0xb7, 0xf2, 0x96, 0xd9,
-	0xbf, 0x50, 0xbb, 0xe2, 0x5d, 0xf7, 0xd8, 0x37, 0xe5, 0x2f, 0xa7, 0xd4, 0xb7, 0xb4, 0xf3, 0x2f,
-	0xfb, 0xbd, 0xff, 0x06, 0xad, 0xe6, 0xa2, 0x7f, 0xde, 0x59, 0x4d, 0xd9, 0xba, 0xef, 0x01, 0x14,
-	0x09, 0x17, 0x56, 0xca, 0x3e, 0x18, 0xbb, 0x57, 0xcd, 0xc9, 0xb8, 0x27, 0xaa, 0x93, 0x14, 0xc8,
-	0xf1, 0x63, 0x29, 0x8c, 0x8a, 0x3e, 0x37, 0x47, 0x5a, 0x4d, 0x9c, 0xf4, 0x7a, 0x75, 0x5d, 0x75,
-	0xa2, 0xa8, 0xf9, 0xb3, 0xc7, 0xb0, 0xfc, 0x20, 0x49, 0x9e, 0x4c, 0x53, 0x93, 0xc4, 0x73, 0xe3,
-	0xff, 0xdd, 0x30, 0x3f, 0xea, 0x95, 0x56, 0xe1, 0x5f, 0x22, 0x56, 0x3d, 0xd6, 0xb5, 0x58, 0x6d,
-	0x7f, 0x56, 0xa4, 0x7b, 0x9e, 0xb1, 0x10, 0xd6, 0xcd, 0x1d, 0x67, 0x26, 0xde, 0x73, 0xd9, 0xd8,
-	0x59, 0x97, 0xca, 0x10, 0x8e, 0xd7, 0xa1, 0x67, 0xbb, 0x9d, 0x6b, 0x9e, 0xd7, 0x3d, 0xb6, 0x07,
-	0x9d, 0x3b, 0x7c, 0x90, 0x0c, 0xb9, 0x8a, 0xd8, 0x37, 0x8a, 0x89, 0x9b, 0x50, 0xbf, 0xb7, 0xec,
-	0x80, 0xae, 0xd6, 0xa7, 0xe1, 0x2c, 0xe3, 0x9f, 0x6c, 0x7f, 0xa6, 0x72, 0x01, 0xcf, 0xb4, 0xd6,
-	0xeb, 0xfc, 0x85, 0xa3, 0xf5, 0xa5, 0x84, 0x87, 0xa3, 0xf5, 0x95, 0x84, 0x87, 0xb3, 0xd5, 0x3a,
-	0x7f, 0xc2, 0xc6, 0xb0, 0x5e, 0xc9, 0x91, 0x98, 0x9b, 0xf2, 0xb4, 0xcc, 0x4a, 0xef, 0xd2, 0xe9,
-	0x04, 0xee, 0x68, 0x57, 0xdd, 0xd1, 0xf6, 0x61, 0xf9, 0x0e, 0x97, 0x9b, 0x25, 0x1f, 0xc6, 0x7a,
-	0xae, 0x19, 0xb1, 0x1f, 0xd1, 0xca, 0x26, 0x86, 0xfa, 0x5c, 0xb3, 0x4e, 0xaf, 0x52, 0xec, 0x63,
-	0x68, 0xdf, 0xe7, 0x42, 0xbf, 0x84, 0x19, 0x7f, 0xa3, 0xf4, 0x34, 0xd6, 0xab, 0x79, 0x48, 0x73,
-	0x65, 0x86, 0xb8, 0x6d, 0xf3, 0xe1, 0x88, 0x4b, 0x65, 0xef, 0x47, 0xc3, 0x67, 0xec, 0xd7, 0x89,
-	0xb9, 0x79, 0x3c, 0xdf, 0xb2, 0x1e, 0x50, 0x6c, 0xe6, 0xab, 0x25, 0xbc, 0x8e, 0x73, 0x9c, 0x0c,
-	0xb9, 0x75, 0xc1, 0xc5, 0xd0, 0xb6, 0x2a, 0x25, 0x8c, 0x02, 0x55, 0xab, 0x33, 0x8c, 0x02, 0xd5,
-	0x14, 0x56, 0xf8, 0x57, 0x68, 0x1c, 0x9f, 0x5d, 0x2a, 0xc6, 0x91, 0xc5, 0x14, 0xc5, 0x48, 0xdb,
-	0x9f, 0x85, 0x13, 0xf1, 0x8c, 0x7d, 0x44, 0xbf, 0x1c, 0xb0, 0x5f, 0xfb, 0x0a, 0x7f, 0xa7, 0xfc,
-	0x30, 0x68, 0x36, 0xcb, 0xea, 0x72, 0x7d, 0x20, 0x39, 0x14, 0xdd, 0x83, 0x5f, 0x04, 0xd8, 0x17,
-	0x49, 0x7a, 0x27, 0xe4, 0x93, 0x24, 0x2e, 0x2c, 0x57, 0xf1, 0xa2, 0x55, 0x58, 0x2e, 0xeb, 0x59,
-	0x8b, 0x7d, 0x64, 0x79, 0x9c, 0xce, 0x63, 0xa9, 0x16, 0xae, 0x53, 0x1f, 0xbd, 0xcc, 0x86, 0xd4,
-	0x3c, 0x7c, 0x5d, 0xf7, 0xd0, 0x7f, 0x2c, 0x32, 0x72, 0xc6, 0x7f, 0xac, 0x24, 0xfb, 0x8c, 0xd9,
-	0xab, 0x49, 0xdf, 0xed, 0x41, 0xab, 0x48, 0x0b, 0xe9, 0x2b, 0xa9, 0x9c, 0x44, 0x32, 0x77, 0x4c,
-	0x25, 0x59, 0xe3, 0xaf, 0xd1, 0x56, 0x01, 0x5b, 0xc2, 0xad, 0xa2, 0x0c, 0x4c, 0x04, 0x1b, 0x72,
-	0x82, 0xe6, 0xc2, 0xa4, 0x37, 0x1a, 0xbd, 0x92, 0x9a, 0x84, 0x89, 0xd1, 0xe6, 0xda, 0x7c, 0x83,
-	0x13, 0xdb, 0xa1, 0xb4, 0xca, 0xf7, 0x21, 0x34, 0xcd, 0x13, 0x58, 0xaf, 0x04, 0xcb, 0x46, 0xa5,
-	0x4f, 0xcb, 0x51, 0x18, 0x95, 0x3e, 0x35, 0xce, 0xf6, 0xcf, 0xd2, 0x90, 0xab, 0x3e, 0xe0, 0x90,
-	0xf9, 0x49, 0x24, 0x06, 0x47, 0xef, 0x79, 0x57, 0x0f, 0x16, 0xe8, 0x1f, 0x3b, 0x7c, 0xfe, 0x7f,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x05, 0xff, 0x3b, 0xf8, 0x0a, 0x42, 0x00, 0x00,
+	// 7480 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7c, 0x4b, 0x6c, 0x24, 0xc9,
+	0x71, 0xe8, 0x54, 0x77, 0xf3, 0xd3, 0xd1, 0xcd, 0x66, 0x33, 0xc9, 0xe1, 0xf4, 0xd4, 0xfc, 0xb8,
+	0xa5, 0xd1, 0x2e, 0xdf, 0x68, 0x34, 0x1f, 0x4a, 0xda, 0xb7, 0xda, 0xd5, 0xe7, 0x71, 0xc8, 0x9e,
+	0x21, 0x25, 0x0e, 0x87, 0x2a, 0x72, 0xde, 0xea, 0xf7, 0xd0, 0x2a, 0x76, 0x27, 0xc9, 0x12, 0xbb,
+	0xab, 0x5a, 0x55, 0xd5, 0x9c, 0xe9, 0xdd, 0xb7, 0xc0, 0xfb, 0x00, 0x02, 0x1e, 0xf0, 0xd6, 0x3e,
+	0xd8, 0x30, 0x20, 0x1b, 0x86, 0x0d, 0xeb, 0x62, 0x1f, 0x6c, 0xf8, 0xe2, 0x8b, 0x6d, 0x41, 0x80,
+	0x2f, 0x06, 0x04, 0x18, 0x3e, 0x08, 0x3e, 0x18, 0x06, 0x0c, 0xf8, 0x77, 0xb1, 0x6f, 0x06, 0x74,
+	0xf0, 0xc5, 0x36, 0x22, 0x7f, 0x95, 0x59, 0x55, 0xcd, 0xe1, 0x4a, 0xb6, 0xe1, 0x53, 0x77, 0x46,
+	0x44, 0x45, 0xfe, 0x22, 0x23, 0x23, 0x23, 0x22, 0x13, 0xaa, 0xd1, 0xb0, 0x7b, 0x6f, 0x18, 0x85,
+	0x49, 0x48, 0xa6, 0xfa, 0x41, 0x34, 0xec, 0xda, 0xd7, 0x8f, 0xc3, 0xf0, 0xb8, 0x4f, 0xef, 0x7b,
+	0x43, 0xff, 0xbe, 0x17, 0x04, 0x61, 0xe2, 0x25, 0x7e, 0x18, 0xc4, 0x9c, 0xc8, 0xf9, 0x16, 0x34,
+	0x9e, 0xd0, 0x60, 0x9f, 0xd2, 0x9e, 0x4b, 0xbf, 0x33, 0xa2, 0x71, 0x42, 0x3e, 0x01, 0x0b, 0x1e,
+	0x7d, 0x8f, 0xd2, 0x5e, 0x67, 0xe8, 0xc5, 0xf1, 0xf0, 0x24, 0xf2, 0x62, 0xda, 0xb2, 0x56, 0xac,
+	0xd5, 0xba, 0xdb, 0xe4, 0x88, 0x3d, 0x05, 0x27, 0xaf, 0x41, 0x3d, 0x46, 0x52, 0x1a, 0x24, 0x51,
+	0x38, 0x1c, 0xb7, 0x4a, 0x8c, 0xae, 0x86, 0xb0, 0x36, 0x07, 0x39, 0x7d, 0x98, 0x57, 0x35, 0xc4,
+	0xc3, 0x30, 0x88, 0x29, 0x79, 0x00, 0x4b, 0x5d, 0x7f, 0x78, 0x42, 0xa3, 0x0e, 0xfb, 0x78, 0x10,
+	0xd0, 0x41, 0x18, 0xf8, 0xdd, 0x96, 0xb5, 0x52, 0x5e, 0xad, 0xba, 0x84, 0xe3, 0xf0, 0x8b, 0xa7,
+	0x02, 0x43, 0xde, 0x80, 0x79, 0x1a, 0x70, 0x38, 0xed, 0xb1, 0xaf, 0x44, 0x55, 0x8d, 0x14, 0x8c,
+	0x1f, 0x38, 0xbf, 0x62, 0xc1, 0xc2, 0x76, 0xe0, 0x27, 0xef, 0x7a, 0xfd, 0x3e, 0x4d, 0x64, 0x9f,
+	0xde, 0x80, 0xf9, 0x17, 0x0c, 0xc0, 0xfa, 0xf4, 0x22, 0x8c, 0x7a, 0xa2, 0x47, 0x0d, 0x0e, 0xde,
+	0x13, 0xd0, 0x89, 0x2d, 0x2b, 0x4d, 0x6c, 0x59, 0xe1, 0x70, 0x95, 0x8b, 0x87, 0xcb, 0x59, 0x02,
+	0xa2, 0x37, 0x8e, 0x0f, 0x87, 0xf3, 0x05, 0x58, 0x7c, 0x1e, 0xf4, 0xc3, 0xee, 0xe9, 0x4f, 0xd7,
+	0x68, 0x67, 0x19, 0x96, 0xcc, 0xef, 0x05, 0xdf, 0xef, 0x95, 0xa0, 0x76, 0x10, 0x79, 0x41, 0xec,
+	0x75, 0x71, 0xca, 0x49, 0x0b, 0x66, 0x92, 0x97, 0x9d, 0x13, 0x2f, 0x3e, 0x61, 0x8c, 0xaa, 0xae,
+	0x2c, 0x92, 0x65, 0x98, 0xf6, 0x06, 0xe1, 0x28, 0x48, 0xd8, 0xa8, 0x96, 0x5d, 0x51, 0x22, 0x77,
+	0x61, 0x21, 0x18, 0x0d, 0x3a, 0xdd, 0x30, 0x38, 0xf2, 0xa3, 0x01, 0x17, 0x1c, 0xd6, 0xb9, 0x29,
+	0x37, 0x8f, 0x20, 0x37, 0x01, 0x0e, 0xb1, 0x19, 0xbc, 0x8a, 0x0a, 0xab, 0x42, 0x83, 0x10, 0x07,
+	0xea, 0xa2, 0x44, 0xfd, 0xe3, 0x93, 0xa4, 0x35, 0xc5, 0x18, 0x19, 0x30, 0xe4, 0x91, 0xf8, 0x03,
+	0xda, 0x89, 0x13, 0x6f, 0x30, 0x6c, 0x4d, 0xb3, 0xd6, 0x68, 0x10, 0x86, 0x0f, 0x13, 0xaf, 0xdf,
+	0x39, 0xa2, 0x34, 0x6e, 0xcd, 0x08, 0xbc, 0x82, 0x90, 0xd7, 0xa1, 0xd1, 0xa3, 0x71, 0xd2, 0xf1,
+	0x7a, 0xbd, 0x88, 0xc6, 0x31, 0x8d, 0x5b, 0xb3, 0x6c, 0xea, 0x32, 0x50, 0xa7, 0x05, 0xcb, 0x4f,
+	0x68, 0xa2, 0x8d, 0x4e, 0x2c, 0x86, 0xdd, 0xd9, 0x01, 0xa2, 0x81, 0x37, 0x69, 0xe2, 0xf9, 0xfd,
+	0x98, 0xbc, 0x09, 0xf5, 0x44, 0x23, 0x66, 0xa2, 0x5a, 0x5b, 0x23, 0xf7, 0xd8, 0x1a, 0xbb, 0xa7,
+	0x7d, 0xe0, 0x1a, 0x74, 0xce, 0x4f, 0xca, 0x50, 0xdb, 0xa7, 0x81, 0x5a, 0x5d, 0x04, 0x2a, 0xd8,
+	0x12, 0x31, 0x93, 0xec, 0x3f, 0xb9, 0x05, 0x35, 0xd6, 0xba, 0x38, 0x89, 0xfc, 0xe0, 0x98, 0x4d,
+	0x41, 0xd5, 0x05, 0x04, 0xed, 0x33, 0x08, 0x69, 0x42, 0xd9, 0x1b, 0x24, 0x6c, 0xe0, 0xcb, 0x2e,
+	0xfe, 0xc5, 0x75, 0x37, 0xf4, 0xc6, 0x03, 0x1a, 0x24, 0xe9, 0x60, 0xd7, 0xdd, 0x9a, 0x80, 0x6d,
+	0xe1, 0x68, 0xdf, 0x83, 0x45, 0x9d, 0x44, 0x72, 0x9f, 0x62, 0xdc, 0x17, 0x34, 0x4a, 0x51, 0xc9,
+	0x1b, 0x30, 0x2f, 0xe9, 0x23, 0xde, 0x58, 0x36, 0xfc, 0x55, 0xb7, 0x21, 0xc0, 0xb2, 0x0b, 0xab,
+	0xd0, 0x3c, 0xf2, 0x03, 0xaf, 0xdf, 0xe9, 0xf6, 0x93, 0xb3, 0x4e, 0x8f, 0xf6, 0x13, 0x8f, 0x4d,
+	0xc4, 0x94, 0xdb, 0x60, 0xf0, 0x8d, 0x7e, 0x72, 0xb6, 0x89, 0x50, 0x72, 0x17, 0xaa, 0x47, 0x94,
+	0x76, 0xfa, 0xfe, 0xc0, 0x4f, 0x5a, 0xb3, 0x2b, 0xd6, 0x6a, 0x6d, 0x6d, 0x5e, 0x8c, 0xd8, 0x63,
+	0x4a, 0x77, 0x10, 0xec, 0xce, 0x1e, 0x89, 0x7f, 0xe4, 0x06, 0x00, 0xe3, 0xc8, 0xc9, 0xab, 0x2b,
+	0xd6, 0xea, 0x9c, 0x5b, 0x45, 0x08, 0x47, 0xaf, 0x42, 0x33, 0x1c, 0x25, 0xc7, 0xa1, 0x1f, 0x1c,
+	0x77, 0xba, 0x27, 0x5e, 0xd0, 0xf1, 0x7b, 0x2d, 0x58, 0xb1, 0x56, 0x2b, 0x6e, 0x43, 0xc2, 0x37,
+	0x4e, 0xbc, 0x60, 0xbb, 0x47, 0x5e, 0x87, 0xf9, 0xbe, 0x17, 0x27, 0x9d, 0x93, 0x70, 0xd8, 0x19,
+	0x8e, 0x0e, 0x4f, 0xe9, 0xb8, 0x55, 0x63, 0xe3, 0x33, 0x87, 0xe0, 0xad, 0x70, 0xb8, 0xc7, 0x80,
+	0xe4, 0xbf, 0x40, 0xf3, 0x94, 0x8e, 0x63, 0x1a, 0xf4, 0x3a, 0xc3, 0x88, 0xfa, 0x03, 0xef, 0x98,
+	0xb6, 0xea, 0x8c, 0x70, 0x5e, 0xc0, 0xf7, 0x04, 0x98, 0xdc, 0x07, 0xe8, 0x86, 0x71, 0xd2, 0x19,
+	0x84, 0x3d, 0xda, 0x6f, 0xcd, 0xb1, 0xae, 0x34, 0x45, 0x57, 0x36, 0xc2, 0x38, 0x79, 0x8a, 0x70,
+	0xb7, 0xda, 0x95, 0x7f, 0x9d, 0x5f, 0xb4, 0xa0, 0xce, 0xe7, 0x5d, 0xe8, 0xbc, 0xdb, 0x30, 0x27,
+	0x87, 0x97, 0x46, 0x51, 0x18, 0x89, 0x25, 0x68, 0x02, 0xc9, 0x1d, 0x68, 0x4a, 0x80, 0x6a, 0x12,
+	0x57, 0x74, 0x39, 0x38, 0x59, 0x4b, 0x39, 0x46, 0xe1, 0x28, 0xe1, 0x5a, 0xa7, 0xb6, 0x56, 0x17,
+	0xcd, 0x72, 0x11, 0xe6, 0x9a, 0x24, 0xce, 0x87, 0x16, 0x10, 0x6c, 0xd6, 0x41, 0xc8, 0xd1, 0x62,
+	0x4a, 0xb3, 0xe2, 0x64, 0x5d, 0x58, 0x9c, 0x4a, 0x93, 0xc4, 0xe9, 0x36, 0x4c, 0xb3, 0x2a, 0x51,
+	0x5f, 0x94, 0x73, 0xcd, 0x12, 0x38, 0xe7, 0x37, 0x2c, 0xa8, 0xe3, 0xac, 0x05, 0xb4, 0xbf, 0x17,
+	0xfa, 0x41, 0x42, 0x1e, 0x00, 0x39, 0x1a, 0x05, 0x3d, 0x9c, 0xe4, 0xe4, 0xa5, 0xdf, 0xeb, 0x1c,
+	0x8e, 0x91, 0x05, 0x6b, 0xcf, 0xd6, 0x25, 0xb7, 0x00, 0x47, 0xee, 0x42, 0xd3, 0x80, 0xc6, 0x49,
+	0xc4, 0x5b, 0xb5, 0x75, 0xc9, 0xcd, 0x61, 0x50, 0x07, 0x85, 0xa3, 0x64, 0x38, 0x4a, 0x3a, 0x7e,
+	0xd0, 0xa3, 0x2f, 0xd9, 0x98, 0xcd, 0xb9, 0x06, 0xec, 0x51, 0x03, 0xea, 0xfa, 0x77, 0xce, 0x17,
+	0xa0, 0xb9, 0x83, 0xca, 0x29, 0xf0, 0x83, 0xe3, 0x75, 0xae, 0x41, 0x50, 0x63, 0x0a, 0xd1, 0xe2,
+	0xf3, 0x28, 0x4a, 0xb8, 0xbe, 0x4f, 0xc2, 0x38, 0x11, 0xe3, 0xc2, 0xfe, 0x3b, 0x7f, 0x63, 0xc1,
+	0x3c, 0x0e, 0xfa, 0x53, 0x2f, 0x18, 0xcb, 0x11, 0xdf, 0x81, 0x3a, 0xb2, 0x3a, 0x08, 0xd7, 0xb9,
+	0xde, 0xe5, 0xfa, 0x64, 0x55, 0x0c, 0x52, 0x86, 0xfa, 0x9e, 0x4e, 0x8a, 0xfb, 0xea, 0xd8, 0x35,
+	0xbe, 0x46, 0x0d, 0x92, 0x78, 0xd1, 0x31, 0x4d, 0x98, 0x46, 0x16, 0x1a, 0x1a, 0x38, 0x68, 0x23,
+	0x0c, 0x8e, 0xc8, 0x0a, 0xd4, 0x63, 0x2f, 0xe9, 0x0c, 0x69, 0xc4, 0x46, 0x8d, 0x69, 0x81, 0xb2,
+	0x0b, 0xb1, 0x97, 0xec, 0xd1, 0xe8, 0xd1, 0x38, 0xa1, 0xf6, 0x17, 0x61, 0x21, 0x57, 0x0b, 0x2a,
+	0x9e, 0xb4, 0x8b, 0xf8, 0x97, 0x2c, 0xc1, 0xd4, 0x99, 0xd7, 0x1f, 0x51, 0xb1, 0x51, 0xf0, 0xc2,
+	0xdb, 0xa5, 0xb7, 0x2c, 0xe7, 0x75, 0x68, 0xa6, 0xcd, 0x16, 0x42, 0x4f, 0xa0, 0x82, 0x23, 0x28,
+	0x18, 0xb0, 0xff, 0xce, 0xff, 0xb6, 0x38, 0xe1, 0x46, 0xe8, 0x2b, 0xa5, 0x8b, 0x84, 0xa8, 0x9b,
+	0x25, 0x21, 0xfe, 0x9f, 0xb8, 0x29, 0xfd, 0xec, 0x9d, 0x75, 0xde, 0x80, 0x05, 0xad, 0x09, 0xe7,
+	0x34, 0xf6, 0x43, 0x0b, 0x16, 0x76, 0xe9, 0x0b, 0x31, 0xeb, 0xb2, 0xb5, 0x6f, 0x41, 0x25, 0x19,
+	0x0f, 0xb9, 0x55, 0xd4, 0x58, 0xbb, 0x2d, 0x26, 0x2d, 0x47, 0x77, 0x4f, 0x14, 0x0f, 0xc6, 0x43,
+	0xea, 0xb2, 0x2f, 0x9c, 0x2f, 0x40, 0x4d, 0x03, 0x92, 0x2b, 0xb0, 0xf8, 0xee, 0xf6, 0xc1, 0x6e,
+	0x7b, 0x7f, 0xbf, 0xb3, 0xf7, 0xfc, 0xd1, 0x97, 0xdb, 0x5f, 0xeb, 0x6c, 0xad, 0xef, 0x6f, 0x35,
+	0x2f, 0x91, 0x65, 0x20, 0xbb, 0xed, 0xfd, 0x83, 0xf6, 0xa6, 0x01, 0xb7, 0x1c, 0x1b, 0x5a, 0xbb,
+	0xf4, 0xc5, 0xbb, 0x7e, 0x12, 0xd0, 0x38, 0x36, 0x6b, 0x73, 0xee, 0x01, 0xd1, 0x9b, 0x20, 0x7a,
+	0xd5, 0x82, 0x19, 0xb1, 0xeb, 0xc9, 0x4d, 0x5f, 0x14, 0x9d, 0xd7, 0x81, 0xec, 0xfb, 0xc7, 0xc1,
+	0x53, 0x1a, 0xc7, 0xde, 0xb1, 0x52, 0x05, 0x4d, 0x28, 0x0f, 0xe2, 0x63, 0xa1, 0x01, 0xf0, 0xaf,
+	0xf3, 0x29, 0x58, 0x34, 0xe8, 0x04, 0xe3, 0xeb, 0x50, 0x8d, 0xfd, 0xe3, 0xc0, 0x4b, 0x46, 0x11,
+	0x15, 0xac, 0x53, 0x80, 0xf3, 0x18, 0x96, 0xfe, 0x3b, 0x8d, 0xfc, 0xa3, 0xf1, 0xab, 0xd8, 0x9b,
+	0x7c, 0x4a, 0x59, 0x3e, 0x6d, 0xb8, 0x9c, 0xe1, 0x23, 0xaa, 0xe7, 0x82, 0x28, 0xa6, 0x6b, 0xd6,
+	0xe5, 0x05, 0x6d, 0x59, 0x96, 0xf4, 0x65, 0xe9, 0x3c, 0x07, 0xb2, 0x11, 0x06, 0x01, 0xed, 0x26,
+	0x7b, 0x94, 0x46, 0xa9, 0xa9, 0x9b, 0x4a, 0x5d, 0x6d, 0xed, 0x8a, 0x98, 0xc7, 0xec, 0x5a, 0x17,
+	0xe2, 0x48, 0xa0, 0x32, 0xa4, 0xd1, 0x80, 0x31, 0x9e, 0x75, 0xd9, 0x7f, 0xe7, 0x32, 0x2c, 0x1a,
+	0x6c, 0x85, 0xe1, 0xf5, 0x10, 0x2e, 0x6f, 0xfa, 0x71, 0x37, 0x5f, 0x61, 0x0b, 0x66, 0x86, 0xa3,
+	0xc3, 0x4e, 0xba, 0xa6, 0x64, 0x11, 0xed, 0x91, 0xec, 0x27, 0x82, 0xd9, 0x77, 0x2d, 0xa8, 0x6c,
+	0x1d, 0xec, 0x6c, 0x10, 0x1b, 0x66, 0xfd, 0xa0, 0x1b, 0x0e, 0x50, 0xed, 0xf2, 0x4e, 0xab, 0xf2,
+	0xc4, 0xb5, 0x72, 0x1d, 0xaa, 0x4c, 0x5b, 0xa3, 0x89, 0x25, 0xac, 0xd2, 0x14, 0x80, 0xe6, 0x1d,
+	0x7d, 0x39, 0xf4, 0x23, 0x66, 0xbf, 0x49, 0xab, 0xac, 0xc2, 0x34, 0x62, 0x1e, 0xe1, 0xfc, 0x73,
+	0x05, 0x66, 0x84, 0xae, 0x66, 0xf5, 0x75, 0x13, 0xff, 0x8c, 0x8a, 0x96, 0x88, 0x12, 0xee, 0x72,
+	0x11, 0x1d, 0x84, 0x09, 0xed, 0x18, 0xd3, 0x60, 0x02, 0x91, 0xaa, 0xcb, 0x19, 0x75, 0x86, 0xa8,
+	0xf5, 0x59, 0xcb, 0xaa, 0xae, 0x09, 0xc4, 0xc1, 0x92, 0xfb, 0x7c, 0x85, 0xed, 0xf3, 0xb2, 0x88,
+	0x23, 0xd1, 0xf5, 0x86, 0x5e, 0xd7, 0x4f, 0xc6, 0x62, 0x71, 0xab, 0x32, 0xf2, 0xee, 0x87, 0x5d,
+	0xaf, 0xdf, 0x39, 0xf4, 0xfa, 0x5e, 0xd0, 0xa5, 0xc2, 0x86, 0x34, 0x81, 0x68, 0x26, 0x8a, 0x26,
+	0x49, 0x32, 0x6e, 0x4a, 0x66, 0xa0, 0x68, 0x6e, 0x76, 0xc3, 0xc1, 0xc0, 0x4f, 0xd0, 0xba, 0x64,
+	0x26, 0x4c, 0xd9, 0xd5, 0x20, 0xac, 0x27, 0xbc, 0xf4, 0x82, 0x8f, 0x5e, 0x95, 0xd7, 0x66, 0x00,
+	0x91, 0x0b, 0xda, 0x41, 0xa8, 0x90, 0x4e, 0x5f, 0x30, 0xa3, 0xa5, 0xec, 0x6a, 0x10, 0x9c, 0x87,
+	0x51, 0x10, 0xd3, 0x24, 0xe9, 0xd3, 0x9e, 0x6a, 0x50, 0x8d, 0x91, 0xe5, 0x11, 0xe4, 0x01, 0x2c,
+	0x72, 0x83, 0x37, 0xf6, 0x92, 0x30, 0x3e, 0xf1, 0xe3, 0x4e, 0x4c, 0x83, 0x84, 0x59, 0x2e, 0x65,
+	0xb7, 0x08, 0x45, 0xde, 0x82, 0x2b, 0x19, 0x70, 0x44, 0xbb, 0xd4, 0x3f, 0xa3, 0x3d, 0x66, 0xca,
+	0x94, 0xdd, 0x49, 0x68, 0xb2, 0x02, 0x35, 0xb4, 0xf3, 0x47, 0xc3, 0x9e, 0x87, 0xfb, 0x70, 0x83,
+	0xcd, 0x83, 0x0e, 0x22, 0x0f, 0x61, 0x6e, 0x48, 0xf9, 0x66, 0x79, 0x92, 0xf4, 0xbb, 0x71, 0x6b,
+	0x9e, 0xed, 0x64, 0x35, 0xb1, 0x98, 0x50, 0x72, 0x5d, 0x93, 0x02, 0x85, 0xb2, 0x1b, 0x33, 0xcb,
+	0xd1, 0x1b, 0xb7, 0x9a, 0xc2, 0xce, 0x93, 0x00, 0xb6, 0x46, 0x22, 0xff, 0xcc, 0x4b, 0x68, 0x6b,
+	0x81, 0xc9, 0x96, 0x2c, 0x3a, 0xbf, 0x66, 0xc1, 0xe2, 0x8e, 0x1f, 0x27, 0x42, 0x08, 0x95, 0x3a,
+	0xbe, 0x05, 0x35, 0x2e, 0x7e, 0x9d, 0x30, 0xe8, 0x8f, 0x85, 0x44, 0x02, 0x07, 0x3d, 0x0b, 0xfa,
+	0x63, 0xf2, 0x31, 0x98, 0xf3, 0x03, 0x9d, 0x84, 0xaf, 0xe1, 0xba, 0x1f, 0x68, 0x44, 0xb7, 0xa0,
+	0x36, 0x1c, 0x1d, 0xf6, 0xfd, 0x2e, 0x27, 0x29, 0x73, 0x2e, 0x1c, 0xc4, 0x08, 0xd0, 0x48, 0xe2,
+	0x2d, 0xe1, 0x14, 0x15, 0x46, 0x51, 0x13, 0x30, 0x24, 0x71, 0x1e, 0xc1, 0x92, 0xd9, 0x40, 0xa1,
+	0xac, 0xee, 0xc0, 0xac, 0x90, 0xed, 0xb8, 0x55, 0x63, 0xe3, 0xd3, 0x90, 0xc6, 0x23, 0x07, 0xbb,
+	0x0a, 0xef, 0xfc, 0xbd, 0x05, 0x15, 0x54, 0x00, 0x93, 0x95, 0x85, 0xae, 0xd3, 0xcb, 0x86, 0x4e,
+	0x67, 0x47, 0x30, 0xb4, 0x8a, 0xb8, 0x48, 0xf0, 0x65, 0xa3, 0x41, 0x52, 0x7c, 0x44, 0xbb, 0x67,
+	0xad, 0x29, 0x1d, 0x8f, 0x10, 0x5c, 0x59, 0xb8, 0x75, 0xb2, 0xaf, 0xf9, 0xc2, 0x51, 0x65, 0x89,
+	0x63, 0x5f, 0xce, 0xa4, 0x38, 0xf6, 0x5d, 0x0b, 0x66, 0xfc, 0xe0, 0x30, 0x1c, 0x05, 0x3d, 0xb6,
+	0x48, 0x66, 0x5d, 0x59, 0xc4, 0xc9, 0x1e, 0x32, 0x4b, 0xca, 0x1f, 0x50, 0xb1, 0x3a, 0x52, 0x80,
+	0x43, 0xd0, 0xb4, 0x8a, 0x99, 0xc2, 0x53, 0xfb, 0xd8, 0x9b, 0xb0, 0xa0, 0xc1, 0xc4, 0x08, 0xbe,
+	0x06, 0x53, 0x43, 0x04, 0xb4, 0x2c, 0x43, 0xbc, 0x90, 0xc8, 0xe5, 0x18, 0xa7, 0x89, 0xae, 0x8c,
+	0x64, 0x3b, 0x38, 0x0a, 0x25, 0xa7, 0x1f, 0x96, 0x61, 0x5e, 0x81, 0x04, 0xa3, 0x55, 0x98, 0xf7,
+	0x7b, 0x34, 0x48, 0xfc, 0x64, 0xdc, 0x31, 0x2c, 0xb8, 0x2c, 0x18, 0x77, 0x18, 0xaf, 0xef, 0x7b,
+	0xb1, 0xd0, 0x61, 0xbc, 0x40, 0xd6, 0x60, 0x09, 0xc5, 0x5f, 0x4a, 0xb4, 0x9a, 0x56, 0x6e, 0x48,
+	0x16, 0xe2, 0x70, 0xc5, 0x22, 0x5c, 0x48, 0xa0, 0xfa, 0x84, 0x6b, 0xda, 0x22, 0x14, 0x8e, 0x1a,
+	0xe7, 0x84, 0x5d, 0x9e, 0xe2, 0x4b, 0x44, 0x01, 0x72, 0x07, 0xe9, 0x69, 0x6e, 0xc4, 0x66, 0x0f,
+	0xd2, 0xda, 0x61, 0x7c, 0x36, 0x77, 0x18, 0x5f, 0x85, 0xf9, 0x78, 0x1c, 0x74, 0x69, 0xaf, 0x93,
+	0x84, 0x58, 0xaf, 0x1f, 0xb0, 0xd9, 0x99, 0x75, 0xb3, 0x60, 0x9c, 0xdb, 0x84, 0xc6, 0x49, 0x40,
+	0x13, 0xa6, 0xba, 0x66, 0x5d, 0x59, 0xc4, 0x5d, 0x80, 0x91, 0x70, 0xa1, 0xae, 0xba, 0xa2, 0x84,
+	0x5b, 0xe5, 0x28, 0xf2, 0xe3, 0x56, 0x9d, 0x41, 0xd9, 0x7f, 0xf2, 0x69, 0xb8, 0x7c, 0x88, 0x87,
+	0xdc, 0x13, 0xea, 0xf5, 0x68, 0xc4, 0x66, 0x9f, 0x9f, 0xf1, 0xb9, 0x06, 0x2a, 0x46, 0x3a, 0xef,
+	0xb1, 0x7d, 0x5b, 0xf9, 0x18, 0x9e, 0x33, 0xa5, 0x43, 0xae, 0x41, 0x95, 0xf7, 0x24, 0x3e, 0xf1,
+	0x84, 0x29, 0x31, 0xcb, 0x00, 0xfb, 0x27, 0x1e, 0x2e, 0x53, 0x63, 0x70, 0x4a, 0xcc, 0x3e, 0xac,
+	0x31, 0xd8, 0x16, 0x1f, 0x9b, 0xdb, 0xd0, 0x90, 0xde, 0x8b, 0xb8, 0xd3, 0xa7, 0x47, 0x89, 0x3c,
+	0x06, 0x04, 0xa3, 0x01, 0x56, 0x17, 0xef, 0xd0, 0xa3, 0xc4, 0xd9, 0x85, 0x05, 0xb1, 0x3a, 0x9f,
+	0x0d, 0xa9, 0xac, 0xfa, 0xb3, 0xd9, 0xad, 0x8b, 0xdb, 0x0e, 0x8b, 0xe6, 0x72, 0x66, 0x67, 0x99,
+	0xcc, 0x7e, 0xe6, 0xb8, 0x40, 0x04, 0x7a, 0xa3, 0x1f, 0xc6, 0x54, 0x30, 0x74, 0xa0, 0xde, 0xed,
+	0x87, 0xb1, 0x3c, 0x6c, 0x88, 0xee, 0x18, 0x30, 0x9c, 0x81, 0x78, 0xd4, 0xed, 0xe2, 0x7a, 0xe7,
+	0x9a, 0x4b, 0x16, 0x9d, 0xdf, 0xb4, 0x60, 0x91, 0x71, 0x93, 0x7a, 0x44, 0x59, 0xa8, 0x17, 0x6f,
+	0x66, 0xbd, 0xab, 0x95, 0x50, 0xea, 0x8f, 0xc2, 0xa8, 0x4b, 0x45, 0x4d, 0xbc, 0xf0, 0xd1, 0x6d,
+	0xee, 0x4a, 0xce, 0xe6, 0xfe, 0x73, 0x0b, 0x16, 0x58, 0x53, 0xf7, 0x13, 0x2f, 0x19, 0xc5, 0xa2,
+	0xfb, 0x9f, 0x83, 0x39, 0xec, 0x2a, 0x95, 0x8b, 0x46, 0x34, 0x74, 0x49, 0xad, 0x6f, 0x06, 0xe5,
+	0xc4, 0x5b, 0x97, 0x5c, 0x93, 0x98, 0x7c, 0x11, 0xea, 0xba, 0x0b, 0x8a, 0xb5, 0xb9, 0xb6, 0x76,
+	0x55, 0x1d, 0xcc, 0xb3, 0x92, 0xb3, 0x75, 0xc9, 0x35, 0x3e, 0x20, 0xef, 0x00, 0x30, 0xa3, 0x82,
+	0xb1, 0x6d, 0x95, 0xcd, 0xcf, 0x73, 0x93, 0xb5, 0x75, 0xc9, 0xd5, 0xc8, 0x1f, 0xcd, 0xc2, 0x34,
+	0xdf, 0x05, 0x9d, 0x27, 0x30, 0x67, 0xb4, 0xd4, 0x38, 0x4b, 0xd4, 0xf9, 0x59, 0x22, 0x77, 0xf4,
+	0x2c, 0xe5, 0x8f, 0x9e, 0xce, 0xdf, 0x95, 0x80, 0xa0, 0xb4, 0x65, 0xa6, 0x13, 0xb7, 0xe1, 0xb0,
+	0x67, 0x18, 0x55, 0x75, 0x57, 0x07, 0x91, 0x7b, 0x40, 0xb4, 0xa2, 0x3c, 0x9d, 0xf3, 0xdd, 0xa1,
+	0x00, 0x83, 0x6a, 0x8c, 0x5b, 0x44, 0xf2, 0xa4, 0x2b, 0xcc, 0x47, 0x3e, 0x6f, 0x85, 0x38, 0xdc,
+	0x00, 0x86, 0x23, 0x3c, 0xfa, 0x7b, 0x89, 0x34, 0xbb, 0x64, 0x39, 0x2b, 0x20, 0xd3, 0xaf, 0x14,
+	0x90, 0x99, 0xac, 0x80, 0xe8, 0x1b, 0xff, 0xac, 0xb1, 0xf1, 0xa3, 0x95, 0x35, 0xf0, 0x03, 0x66,
+	0x3d, 0x74, 0x06, 0x58, 0xbb, 0xb0, 0xb2, 0x0c, 0x20, 0xfa, 0x4e, 0x84, 0xf5, 0x96, 0x5a, 0x17,
+	0xc0, 0xc6, 0x38, 0x07, 0x77, 0x7e, 0x6c, 0x41, 0x13, 0xc7, 0xd9, 0x90, 0xc5, 0xb7, 0x81, 0x2d,
+	0x85, 0x0b, 0x8a, 0xa2, 0x41, 0xfb, 0xb3, 0x4b, 0xe2, 0x5b, 0x50, 0x65, 0x0c, 0xc3, 0x21, 0x0d,
+	0x84, 0x20, 0xb6, 0x4c, 0x41, 0x4c, 0xb5, 0xd0, 0xd6, 0x25, 0x37, 0x25, 0xd6, 0xc4, 0xf0, 0x4f,
+	0x2d, 0xa8, 0x89, 0x66, 0xfe, 0xd4, 0x27, 0x06, 0x1b, 0x66, 0x51, 0x22, 0x35, 0xb3, 0x5c, 0x95,
+	0x71, 0xcf, 0x18, 0xe0, 0xb1, 0x0c, 0x37, 0x49, 0xe3, 0xb4, 0x90, 0x05, 0xe3, 0x8e, 0xc7, 0x14,
+	0x6e, 0xdc, 0x49, 0xfc, 0x7e, 0x47, 0x62, 0x85, 0xc7, 0xb7, 0x08, 0x85, 0x7a, 0x27, 0x4e, 0xd0,
+	0xdd, 0xc5, 0x37, 0x33, 0x5e, 0xc0, 0x63, 0x91, 0xe8, 0x50, 0xc6, 0xe8, 0x73, 0x7e, 0x04, 0x70,
+	0x25, 0x87, 0x52, 0xf1, 0x05, 0x61, 0x06, 0xf7, 0xfd, 0xc1, 0x61, 0xa8, 0x2c, 0x6a, 0x4b, 0xb7,
+	0x90, 0x0d, 0x14, 0x39, 0x86, 0xcb, 0x72, 0xd7, 0xc6, 0x31, 0x4d, 0xf7, 0xe8, 0x12, 0x33, 0x37,
+	0x1e, 0x9a, 0x32, 0x90, 0xad, 0x50, 0xc2, 0xf5, 0x95, 0x5b, 0xcc, 0x8f, 0x9c, 0x40, 0x4b, 0x22,
+	0xa4, 0x8a, 0xd7, 0x4c, 0x08, 0xac, 0xeb, 0xee, 0x2b, 0xea, 0x62, 0xfa, 0xa8, 0x27, 0xab, 0x99,
+	0xc8, 0x8d, 0x8c, 0xe1, 0xa6, 0xc4, 0x31, 0x1d, 0x9e, 0xaf, 0xaf, 0x72, 0xa1, 0xbe, 0x3d, 0xc6,
+	0x8f, 0xcd, 0x4a, 0x5f, 0xc1, 0xd8, 0xfe, 0x91, 0x05, 0x0d, 0x93, 0x1d, 0x8a, 0x8e, 0x58, 0x84,
+	0x52, 0x19, 0x49, 0xb3, 0x2b, 0x03, 0xce, 0x1f, 0x0e, 0x4b, 0x45, 0x87, 0x43, 0xfd, 0x08, 0x58,
+	0x7e, 0xd5, 0x11, 0xb0, 0x72, 0xb1, 0x23, 0xe0, 0x54, 0xd1, 0x11, 0xd0, 0xfe, 0x89, 0x05, 0x24,
+	0x3f, 0xbf, 0xe4, 0x09, 0x3f, 0x9d, 0x06, 0xb4, 0x2f, 0xf4, 0xc4, 0x27, 0x2f, 0x26, 0x23, 0x72,
+	0x0c, 0xe5, 0xd7, 0x28, 0xac, 0xba, 0x22, 0xd0, 0xcd, 0x96, 0x39, 0xb7, 0x08, 0x95, 0x39, 0x94,
+	0x56, 0x5e, 0x7d, 0x28, 0x9d, 0x7a, 0xf5, 0xa1, 0x74, 0x3a, 0x7b, 0x28, 0xb5, 0xff, 0x27, 0xcc,
+	0x19, 0xb3, 0xfe, 0x6f, 0xd7, 0xe3, 0xac, 0xc9, 0xc3, 0x27, 0xd8, 0x80, 0xd9, 0xff, 0x50, 0x02,
+	0x92, 0x97, 0xbc, 0xff, 0xd0, 0x36, 0x30, 0x39, 0x32, 0x14, 0x48, 0x59, 0xc8, 0x91, 0x0e, 0xfc,
+	0x77, 0x55, 0x8a, 0x77, 0x61, 0x21, 0xa2, 0xdd, 0xf0, 0x8c, 0x45, 0x3d, 0x4d, 0x87, 0x46, 0x1e,
+	0x81, 0x46, 0x9f, 0x79, 0x14, 0x9f, 0x35, 0x82, 0x54, 0xda, 0xce, 0x90, 0x39, 0x91, 0x63, 0x04,
+	0x91, 0xc7, 0x0e, 0x1f, 0x71, 0x56, 0x52, 0xc9, 0xfe, 0xaa, 0x05, 0x97, 0x33, 0x88, 0x34, 0x9c,
+	0xc1, 0xf5, 0xa8, 0xa9, 0x5c, 0x4d, 0x20, 0xb6, 0x5f, 0x08, 0xb0, 0xd6, 0x7e, 0xbe, 0xdf, 0xe4,
+	0x11, 0x38, 0x3e, 0xa3, 0x20, 0x4f, 0xcf, 0x47, 0xbd, 0x08, 0xe5, 0x5c, 0x81, 0xcb, 0x62, 0x66,
+	0x33, 0x0d, 0x5f, 0x83, 0xe5, 0x2c, 0x22, 0xf5, 0x87, 0x9a, 0x4d, 0x96, 0x45, 0xe7, 0x77, 0x4a,
+	0x40, 0xbe, 0x32, 0xa2, 0xd1, 0x98, 0x85, 0x28, 0x94, 0x77, 0xe1, 0x4a, 0xf6, 0x18, 0x8e, 0x3e,
+	0xc5, 0x2f, 0xd3, 0xb1, 0x8c, 0xca, 0x95, 0xd2, 0xa8, 0xdc, 0x0d, 0x00, 0x3c, 0x57, 0xa8, 0xb8,
+	0x07, 0xce, 0x2b, 0x1e, 0xdb, 0x38, 0x43, 0x33, 0x1c, 0x56, 0xf9, 0x68, 0xe1, 0xb0, 0xa9, 0x8b,
+	0x84, 0xc3, 0xa6, 0x2f, 0x1a, 0x0e, 0x9b, 0x29, 0x0a, 0x87, 0x99, 0x31, 0xae, 0xd9, 0x57, 0xc7,
+	0xb8, 0xf6, 0x60, 0x56, 0xb6, 0x9b, 0xdc, 0x02, 0x38, 0xf2, 0x5f, 0xd2, 0x1e, 0xb7, 0xcf, 0xd8,
+	0xc8, 0xa2, 0x95, 0xc2, 0x60, 0x4f, 0xd1, 0x3a, 0xb3, 0x61, 0x66, 0x48, 0xa3, 0x2e, 0x95, 0x06,
+	0xc7, 0xd6, 0x25, 0x57, 0x02, 0x1e, 0xcd, 0xc0, 0x14, 0xeb, 0xa5, 0xf3, 0xbf, 0x2c, 0xa8, 0xaa,
+	0xaa, 0x70, 0x04, 0x70, 0xbc, 0x84, 0x12, 0x43, 0x9e, 0x96, 0x8b, 0x23, 0xf8, 0x2e, 0x03, 0x90,
+	0x87, 0x70, 0x99, 0x05, 0x86, 0xd9, 0x61, 0x2f, 0xf2, 0xe3, 0xd3, 0xce, 0x91, 0xd7, 0x4d, 0x42,
+	0x1e, 0xfd, 0xb1, 0x5c, 0x82, 0xc8, 0x9d, 0xb0, 0x7b, 0xea, 0xfa, 0xf1, 0xe9, 0x63, 0x86, 0xc1,
+	0xb3, 0xa1, 0x97, 0x24, 0x74, 0x30, 0x44, 0x33, 0x35, 0x96, 0x11, 0xd5, 0x9a, 0x80, 0x61, 0xcd,
+	0xce, 0x3b, 0xb0, 0x68, 0x08, 0x81, 0x92, 0x77, 0x19, 0xce, 0xb2, 0xce, 0x09, 0x67, 0xfd, 0x8b,
+	0x05, 0xe5, 0xad, 0x70, 0xa8, 0xbb, 0x2e, 0x2d, 0xd3, 0x75, 0x29, 0x76, 0xb7, 0x8e, 0xda, 0xbc,
+	0x4a, 0x42, 0x37, 0xeb, 0x40, 0xdc, 0x9b, 0xbc, 0x41, 0x82, 0x47, 0xf0, 0xa3, 0x30, 0x7a, 0xe1,
+	0x45, 0x3d, 0xd1, 0xd2, 0x0c, 0x14, 0x45, 0x30, 0xdd, 0x02, 0xf0, 0x2f, 0x9a, 0x75, 0xcc, 0x73,
+	0x3b, 0x16, 0x12, 0x23, 0x4a, 0xba, 0x33, 0x69, 0xda, 0x74, 0x26, 0x3d, 0x80, 0x45, 0x93, 0x2b,
+	0x9f, 0x42, 0x6e, 0x9f, 0x17, 0xa1, 0x70, 0xef, 0xc5, 0x79, 0x61, 0x64, 0xdc, 0x25, 0xaa, 0xca,
+	0xce, 0x5f, 0x59, 0x30, 0xc5, 0xc6, 0x04, 0xf5, 0x22, 0x57, 0x06, 0x6a, 0x92, 0xd8, 0x58, 0xcc,
+	0xb9, 0x59, 0x70, 0x26, 0xa6, 0x5f, 0xca, 0xc5, 0xf4, 0xaf, 0x43, 0x95, 0x97, 0xd2, 0x20, 0x78,
+	0x0a, 0x20, 0x37, 0x31, 0xe2, 0x36, 0x94, 0xd6, 0x0c, 0x48, 0xbf, 0x63, 0x38, 0x74, 0x19, 0x3c,
+	0x6d, 0x07, 0xf2, 0xe2, 0x8d, 0xe6, 0xfb, 0x61, 0x16, 0x8c, 0xa3, 0xae, 0xd8, 0x72, 0x42, 0xae,
+	0x6a, 0x33, 0x50, 0xe7, 0xcf, 0x2c, 0xa8, 0xef, 0x45, 0xe1, 0x21, 0x7d, 0xa5, 0x5b, 0xbf, 0x40,
+	0x47, 0xdc, 0x2c, 0xd0, 0x11, 0x1a, 0x84, 0x7c, 0xf2, 0x02, 0x4a, 0x22, 0xa5, 0x40, 0x76, 0x39,
+	0x2d, 0xa1, 0x41, 0xf0, 0x50, 0x94, 0x0b, 0xd6, 0xf3, 0xc3, 0x59, 0x0e, 0xee, 0x3c, 0x82, 0x39,
+	0xd1, 0x2d, 0x21, 0xf4, 0x0f, 0x61, 0x26, 0xa2, 0xf1, 0xa8, 0x9f, 0x48, 0xa9, 0x97, 0x21, 0x12,
+	0x4e, 0xc6, 0x23, 0xc8, 0x88, 0x77, 0x25, 0x9d, 0xf3, 0x43, 0x0b, 0x9a, 0x59, 0x2c, 0x71, 0x60,
+	0x8a, 0x47, 0xa8, 0xad, 0x82, 0x08, 0x35, 0x47, 0x4d, 0xf6, 0x71, 0x20, 0xe6, 0xc8, 0xf3, 0xfb,
+	0x18, 0x1e, 0x12, 0xde, 0x4e, 0x51, 0xc4, 0x43, 0xef, 0x61, 0x98, 0x24, 0x7d, 0x1a, 0xd0, 0xee,
+	0x69, 0xc7, 0x0c, 0x16, 0x14, 0x60, 0xc8, 0xc7, 0x84, 0xa8, 0x4c, 0xad, 0x94, 0xb5, 0x61, 0x65,
+	0xcd, 0x55, 0xf2, 0xe2, 0x1c, 0xc2, 0xac, 0x84, 0x9c, 0xb3, 0x8e, 0xb5, 0x29, 0x2f, 0x99, 0x53,
+	0xee, 0x40, 0x7d, 0xe0, 0xbd, 0x4c, 0x65, 0x88, 0x0b, 0xac, 0x01, 0x73, 0xae, 0x83, 0xcd, 0x94,
+	0xcc, 0x53, 0x3f, 0x8e, 0xfd, 0x30, 0xd8, 0x08, 0x83, 0x24, 0x0a, 0xe5, 0x69, 0xdf, 0x79, 0x0f,
+	0xae, 0x15, 0x62, 0x95, 0x07, 0x73, 0x0a, 0x8d, 0xe5, 0x6c, 0x0e, 0xca, 0x6e, 0xd8, 0xa3, 0x5b,
+	0x7e, 0x9c, 0x84, 0xd1, 0xd8, 0xe5, 0x04, 0xe4, 0x21, 0xcc, 0x66, 0x0e, 0x32, 0x97, 0xcd, 0x23,
+	0xa5, 0xa4, 0x57, 0x64, 0xce, 0x53, 0xa8, 0x69, 0x8c, 0x32, 0x61, 0xee, 0xba, 0x0a, 0x73, 0xbf,
+	0x0e, 0x0d, 0xb6, 0xa7, 0xe0, 0x4c, 0x70, 0xd7, 0x2e, 0x17, 0xf1, 0x0c, 0xd4, 0xf9, 0xb9, 0x12,
+	0x34, 0xcc, 0xba, 0xce, 0x19, 0xd3, 0x0b, 0x32, 0x45, 0x57, 0x22, 0x9e, 0xfc, 0x87, 0x34, 0xf0,
+	0xfa, 0xfe, 0x7b, 0x34, 0x3b, 0xd4, 0xc5, 0x48, 0xb4, 0x45, 0x18, 0x1f, 0x21, 0x56, 0xbc, 0x02,
+	0xae, 0x39, 0xf3, 0x08, 0xf4, 0x8f, 0xe0, 0x8c, 0x49, 0x98, 0xaa, 0x82, 0xab, 0x8e, 0x42, 0x1c,
+	0xce, 0xbc, 0x84, 0x0d, 0xa3, 0xf0, 0x90, 0xad, 0xb3, 0x92, 0x6b, 0xc0, 0x70, 0xe6, 0x5d, 0x1a,
+	0xd3, 0xa4, 0x78, 0xe6, 0x6f, 0xc0, 0xb5, 0x42, 0xac, 0x08, 0x05, 0xde, 0x81, 0x79, 0x9c, 0x1c,
+	0xcd, 0xc5, 0x3d, 0xd1, 0x3a, 0xc1, 0xad, 0x74, 0x56, 0x12, 0x93, 0x55, 0xa8, 0xa0, 0x44, 0x64,
+	0x3c, 0x1a, 0x2a, 0xd0, 0x89, 0x74, 0x2e, 0xa3, 0xc0, 0x3e, 0x30, 0xd7, 0x68, 0x2a, 0x36, 0xd2,
+	0x31, 0xaa, 0x60, 0xa9, 0x9e, 0xcc, 0x9c, 0xc0, 0x32, 0x50, 0xe7, 0xb7, 0x2c, 0x98, 0x33, 0xea,
+	0x40, 0x3f, 0x16, 0x1b, 0x6a, 0xee, 0xaf, 0x10, 0xfb, 0x81, 0x0e, 0x3a, 0x67, 0x5d, 0x29, 0x77,
+	0x7c, 0x59, 0x77, 0xc7, 0x3f, 0x80, 0x6a, 0x9a, 0xea, 0x55, 0xc9, 0x2d, 0x08, 0x19, 0xc2, 0x4d,
+	0x89, 0x90, 0x4f, 0x37, 0xec, 0x87, 0x91, 0xc8, 0x84, 0xe2, 0x05, 0xe7, 0x1d, 0xa8, 0x69, 0xf4,
+	0xd8, 0x8c, 0x80, 0x26, 0x2f, 0xc2, 0xe8, 0x54, 0x6a, 0x74, 0x51, 0x54, 0x99, 0x0a, 0xa5, 0x34,
+	0x53, 0xc1, 0xf9, 0x6d, 0x0b, 0xe6, 0x50, 0x99, 0xf9, 0xc1, 0xf1, 0x5e, 0xd8, 0xf7, 0xbb, 0x63,
+	0xb6, 0xe9, 0x28, 0xdb, 0x84, 0x6b, 0x5d, 0xb9, 0xf9, 0x99, 0x60, 0xdc, 0x4c, 0xa5, 0x1b, 0x4b,
+	0x88, 0xbb, 0x2a, 0xa3, 0xb1, 0x80, 0x9a, 0xfe, 0xd0, 0x8b, 0xa9, 0x2e, 0xe0, 0x26, 0x10, 0x37,
+	0x70, 0x04, 0x44, 0x5e, 0x42, 0x3b, 0x03, 0xbf, 0xdf, 0xf7, 0x39, 0x2d, 0x17, 0xed, 0x22, 0x94,
+	0xf3, 0x07, 0x25, 0xa8, 0x89, 0x55, 0xd9, 0xee, 0x1d, 0xf3, 0x28, 0x27, 0x2f, 0xa6, 0xab, 0x52,
+	0x83, 0x48, 0xbc, 0x71, 0x1e, 0xd7, 0x20, 0xd9, 0x69, 0x2d, 0xe7, 0xa7, 0x15, 0xe3, 0x19, 0x61,
+	0x8f, 0x3e, 0x64, 0x07, 0x7f, 0x9e, 0x19, 0x98, 0x02, 0x24, 0x76, 0x8d, 0x61, 0xa7, 0x52, 0x2c,
+	0x03, 0x18, 0x47, 0xfd, 0xe9, 0xcc, 0x51, 0xff, 0x2d, 0xa8, 0x0b, 0x36, 0x6c, 0xdc, 0x5b, 0x33,
+	0x86, 0x80, 0x1b, 0x73, 0xe2, 0x1a, 0x94, 0xf2, 0xcb, 0x35, 0xf9, 0xe5, 0xec, 0xab, 0xbe, 0x94,
+	0x94, 0x2c, 0xe8, 0xcf, 0xc7, 0xe6, 0x49, 0xe4, 0x0d, 0x4f, 0xe4, 0xda, 0xed, 0x41, 0x5d, 0x07,
+	0x93, 0x3b, 0xa6, 0x9a, 0x2e, 0x5e, 0x74, 0x9c, 0x04, 0x55, 0x3a, 0xed, 0x1d, 0x53, 0xa9, 0xa5,
+	0x89, 0xa9, 0xa5, 0x71, 0x8e, 0x5c, 0x4e, 0x80, 0x2a, 0x80, 0x99, 0xf5, 0xa6, 0x0a, 0x30, 0x15,
+	0x2a, 0x86, 0x61, 0x82, 0xed, 0x1e, 0x66, 0x9b, 0xee, 0x72, 0xa9, 0xd5, 0xc8, 0x9d, 0x1f, 0x54,
+	0xa0, 0xa6, 0x81, 0x71, 0x35, 0x1f, 0x63, 0x83, 0x3b, 0x3d, 0xdf, 0x1b, 0xd0, 0x84, 0x46, 0x42,
+	0x52, 0x33, 0x50, 0xa4, 0xf3, 0xce, 0x8e, 0x3b, 0xe1, 0x28, 0xe9, 0xf4, 0xe8, 0x71, 0x44, 0xa9,
+	0xb0, 0xb3, 0x33, 0x50, 0xa4, 0x43, 0xed, 0xa8, 0xd1, 0x71, 0x79, 0xc8, 0x40, 0x65, 0x88, 0x8b,
+	0x8f, 0x51, 0x25, 0x0d, 0x71, 0xf1, 0x11, 0xc9, 0xea, 0xa1, 0xa9, 0x02, 0x3d, 0xf4, 0x26, 0x2c,
+	0x73, 0x8d, 0x23, 0xd6, 0x66, 0x27, 0x23, 0x26, 0x13, 0xb0, 0x68, 0x13, 0x61, 0x9b, 0xa5, 0x80,
+	0xc7, 0xfe, 0x7b, 0xdc, 0x1d, 0x6d, 0xb9, 0x39, 0x38, 0xd2, 0xe2, 0x72, 0x34, 0x68, 0xb9, 0xcd,
+	0x9b, 0x83, 0x33, 0x5a, 0xef, 0xa5, 0x49, 0x5b, 0x15, 0xb4, 0x19, 0xb8, 0xcc, 0xac, 0x8d, 0x13,
+	0xaf, 0x4f, 0x55, 0x78, 0x9d, 0xa7, 0x33, 0xe6, 0x11, 0x64, 0x13, 0x6e, 0xc8, 0x9e, 0xf3, 0xc5,
+	0xcc, 0x8c, 0x3b, 0xda, 0x53, 0x5f, 0xd6, 0xd8, 0x97, 0xe7, 0x13, 0x49, 0x2e, 0x43, 0x4a, 0xa3,
+	0x62, 0x2e, 0xf5, 0x94, 0xcb, 0x44, 0x22, 0x94, 0xaa, 0xf6, 0xcb, 0x61, 0x18, 0x25, 0x86, 0xf4,
+	0x3f, 0x84, 0x45, 0x03, 0x2a, 0x6c, 0x15, 0x8c, 0x19, 0x07, 0xde, 0x30, 0x3e, 0x09, 0x65, 0xca,
+	0xab, 0x2a, 0x3b, 0x0f, 0x80, 0x6c, 0x0f, 0xb2, 0x8c, 0xce, 0xfd, 0xe2, 0x07, 0x16, 0x2c, 0x6e,
+	0x0f, 0xf2, 0xb5, 0x64, 0x85, 0xc5, 0x2a, 0x10, 0x96, 0x4c, 0x26, 0x03, 0xdf, 0xd7, 0x74, 0x90,
+	0x29, 0x90, 0xe5, 0xac, 0x40, 0x8a, 0xef, 0xe3, 0x53, 0x7f, 0x38, 0xa4, 0x3d, 0x21, 0xb0, 0x3a,
+	0x48, 0x52, 0xf8, 0x01, 0xcf, 0x4b, 0x9a, 0x4a, 0x29, 0x04, 0xc8, 0x99, 0x83, 0xda, 0x7e, 0x12,
+	0x0e, 0xe5, 0x98, 0x35, 0xa0, 0xce, 0x8b, 0x62, 0x7b, 0xbf, 0x06, 0x57, 0x59, 0xbf, 0x0e, 0xc2,
+	0x61, 0xd8, 0x0f, 0x8f, 0xc7, 0xfb, 0xa3, 0xc3, 0xb8, 0x1b, 0xf9, 0xc3, 0xc4, 0x0f, 0x03, 0xe7,
+	0x4f, 0x2c, 0x58, 0x34, 0xb0, 0x22, 0x68, 0xf1, 0x69, 0xae, 0xc7, 0x54, 0xc7, 0xb8, 0xb6, 0x59,
+	0xd0, 0xf6, 0x40, 0x4e, 0xc8, 0xc3, 0x45, 0xcf, 0x45, 0x5f, 0xd7, 0x61, 0x5e, 0x8a, 0x63, 0x3a,
+	0x22, 0xe5, 0x7c, 0xcc, 0x01, 0x55, 0x8f, 0xf8, 0xbe, 0x21, 0x3e, 0x90, 0x2c, 0x3e, 0xcf, 0x3d,
+	0x68, 0xb4, 0xc7, 0xc6, 0x58, 0x7a, 0xaf, 0x6d, 0xf9, 0xbd, 0xee, 0xb6, 0x93, 0x2d, 0xe8, 0x2a,
+	0x60, 0xec, 0xfc, 0x7f, 0x0b, 0x20, 0x6d, 0x1d, 0x0e, 0x7e, 0xba, 0x8f, 0xf3, 0x7b, 0x00, 0x29,
+	0x00, 0xcf, 0xed, 0x2a, 0x3a, 0x9f, 0x9a, 0x06, 0x35, 0x09, 0x43, 0x6f, 0xcc, 0x1b, 0x30, 0x7f,
+	0xdc, 0x0f, 0x0f, 0xd9, 0x81, 0x8e, 0xa5, 0x8e, 0xc5, 0x22, 0xdf, 0xa9, 0xc1, 0xc1, 0x8f, 0x05,
+	0x34, 0xb5, 0x23, 0x2a, 0x9a, 0x1d, 0xe1, 0x7c, 0x58, 0x82, 0x85, 0x5c, 0x9f, 0x27, 0xaa, 0x56,
+	0xb2, 0x96, 0xdb, 0x11, 0x27, 0x04, 0x57, 0x59, 0x9c, 0x66, 0xef, 0x95, 0x2e, 0xeb, 0x77, 0xa0,
+	0x11, 0xf1, 0x2d, 0x47, 0xee, 0x47, 0x95, 0x73, 0xf6, 0xa3, 0xb9, 0x48, 0x2f, 0x62, 0x1e, 0xb3,
+	0xd7, 0x3b, 0xa3, 0x51, 0xe2, 0x33, 0xdf, 0x25, 0xb3, 0xf4, 0xf8, 0x2e, 0x3a, 0xaf, 0xc1, 0x99,
+	0x01, 0xf6, 0x06, 0xcc, 0x8b, 0x1c, 0x33, 0x45, 0x29, 0x92, 0xbc, 0x53, 0x30, 0x12, 0x3a, 0xdf,
+	0x97, 0x81, 0x65, 0x73, 0x0e, 0x27, 0x8f, 0x88, 0xde, 0xbb, 0x52, 0xa6, 0x77, 0x1f, 0x13, 0x41,
+	0xde, 0x9e, 0x74, 0x90, 0x8a, 0x70, 0x3b, 0x07, 0x8a, 0xa0, 0xbc, 0x39, 0xa4, 0x95, 0x8b, 0x0c,
+	0x29, 0x86, 0xf1, 0x66, 0xb6, 0xc2, 0xe1, 0x96, 0x48, 0x17, 0x63, 0x0b, 0x41, 0x65, 0x70, 0xca,
+	0xa2, 0x7e, 0xe2, 0x28, 0xe5, 0xbc, 0x31, 0x79, 0x03, 0x6b, 0x2e, 0x6b, 0x60, 0xfd, 0x37, 0xb8,
+	0x86, 0x80, 0x61, 0x14, 0xa2, 0xea, 0xf1, 0x43, 0x3c, 0x36, 0x33, 0x6b, 0x2a, 0x0c, 0x92, 0x13,
+	0xb9, 0x77, 0x9d, 0x47, 0xc2, 0xfc, 0xa0, 0x78, 0xce, 0xe6, 0xce, 0x18, 0x61, 0x10, 0x72, 0x05,
+	0x91, 0x47, 0x38, 0x9f, 0x85, 0x2a, 0x3b, 0x1a, 0xb3, 0x6e, 0xdd, 0x85, 0x2a, 0x3a, 0xee, 0x4e,
+	0xfc, 0x40, 0x9d, 0xc2, 0x1b, 0xa9, 0x8f, 0x63, 0x8b, 0x0d, 0x88, 0x22, 0x70, 0x7e, 0x77, 0x0a,
+	0x66, 0xb6, 0x83, 0xb3, 0xd0, 0xef, 0xb2, 0x18, 0xf4, 0x80, 0x0e, 0x42, 0x99, 0xcf, 0x8a, 0xff,
+	0x71, 0x28, 0x58, 0x6e, 0xd7, 0x30, 0x11, 0x41, 0x64, 0x59, 0x44, 0x1b, 0x2f, 0x4a, 0x73, 0xce,
+	0xf9, 0xd2, 0xd1, 0x20, 0x78, 0x12, 0x8c, 0xf4, 0xbb, 0x06, 0xa2, 0x94, 0x26, 0x04, 0x4f, 0x69,
+	0x09, 0xc1, 0x58, 0x8f, 0x48, 0x5b, 0x6b, 0x4d, 0x8b, 0xd3, 0x3c, 0x2f, 0x32, 0x07, 0x58, 0x44,
+	0x79, 0x3c, 0x83, 0x59, 0x8b, 0x33, 0xc2, 0x01, 0xa6, 0x03, 0x51, 0x97, 0xf2, 0x0f, 0x38, 0x0d,
+	0xdf, 0x71, 0x75, 0x10, 0x5a, 0xd8, 0xd9, 0xeb, 0x0a, 0x55, 0x2e, 0xf3, 0x19, 0x30, 0x6e, 0xcb,
+	0x3d, 0xaa, 0x14, 0x29, 0xef, 0x03, 0xf0, 0x9c, 0xfa, 0x2c, 0x5c, 0x73, 0x9f, 0xf1, 0xf4, 0x3b,
+	0x51, 0x62, 0x82, 0xe2, 0xf5, 0xfb, 0x87, 0x5e, 0xf7, 0x94, 0x5d, 0x22, 0x61, 0x5b, 0x65, 0xd5,
+	0x35, 0x81, 0xd8, 0x6a, 0x6d, 0x36, 0x59, 0x66, 0x4b, 0xc5, 0xd5, 0x41, 0x64, 0x0d, 0x6a, 0xcc,
+	0xdd, 0x21, 0xe6, 0xb3, 0xb1, 0x52, 0xd6, 0x9c, 0xac, 0x6a, 0xd2, 0x5d, 0x9d, 0x48, 0x8f, 0x8b,
+	0xcf, 0x9b, 0x71, 0xf1, 0x87, 0x2c, 0x66, 0x9a, 0x50, 0x96, 0x44, 0xd7, 0x58, 0xbb, 0x26, 0xf8,
+	0x08, 0x01, 0x90, 0xbf, 0x18, 0xe3, 0xa6, 0x2e, 0xa7, 0x14, 0x7a, 0x56, 0x64, 0x20, 0x2c, 0xb0,
+	0x06, 0xa6, 0x00, 0x76, 0x82, 0xe5, 0x63, 0xcc, 0x09, 0x08, 0x23, 0x30, 0x60, 0xce, 0x2e, 0xd4,
+	0x75, 0xc6, 0x64, 0x16, 0x2a, 0xcf, 0xf6, 0xda, 0xbb, 0xcd, 0x4b, 0xa4, 0x06, 0x33, 0xfb, 0xed,
+	0x83, 0x83, 0x9d, 0xf6, 0x66, 0xd3, 0x22, 0x75, 0x98, 0xdd, 0x58, 0xdf, 0xdd, 0x68, 0x63, 0xa9,
+	0x84, 0xa5, 0xf5, 0x8d, 0x8d, 0xf6, 0xde, 0x41, 0x7b, 0xb3, 0x59, 0x46, 0xc2, 0xf6, 0x57, 0xf7,
+	0xb6, 0xdd, 0xf6, 0x66, 0xb3, 0xe2, 0x24, 0x40, 0xd6, 0x7b, 0x3d, 0xc1, 0x52, 0x6d, 0xe9, 0xa9,
+	0xb8, 0x59, 0x86, 0xb8, 0x15, 0x4c, 0x7b, 0xa9, 0x78, 0xda, 0x8d, 0x9e, 0x36, 0x33, 0x3d, 0x75,
+	0xda, 0x50, 0xdb, 0xd3, 0x6e, 0x37, 0x30, 0xe9, 0x97, 0xf7, 0x1a, 0xc4, 0x8a, 0xd1, 0x20, 0x5a,
+	0x73, 0x4a, 0x7a, 0x73, 0x9c, 0xff, 0x5b, 0x02, 0x82, 0xc9, 0x6a, 0xaa, 0xf9, 0xe9, 0x7d, 0x0a,
+	0x19, 0xfe, 0x4d, 0x53, 0x12, 0x6b, 0x02, 0xc6, 0xb2, 0x09, 0x1d, 0xa8, 0xb3, 0x96, 0x74, 0xc2,
+	0xa3, 0xa3, 0x98, 0xca, 0x5c, 0x3d, 0x03, 0x86, 0x92, 0x8b, 0xe6, 0x03, 0x1a, 0x8f, 0x3e, 0xaf,
+	0x20, 0x16, 0x39, 0x7b, 0x39, 0x38, 0xea, 0xdf, 0x88, 0x9e, 0xd1, 0x28, 0x56, 0x4b, 0x4e, 0x95,
+	0x59, 0x88, 0x51, 0x5f, 0x5e, 0x68, 0x5d, 0x46, 0xdc, 0xc5, 0x5b, 0x71, 0x8b, 0x50, 0x4c, 0x61,
+	0x19, 0x60, 0x2a, 0x32, 0xfb, 0x2a, 0x6e, 0x1e, 0xa1, 0x12, 0x33, 0xb3, 0x93, 0x78, 0x07, 0xf3,
+	0x0f, 0x44, 0xbb, 0x4d, 0xd5, 0x25, 0x29, 0x15, 0x5e, 0xb9, 0x67, 0x8c, 0x41, 0xe1, 0xea, 0x3a,
+	0x8f, 0x40, 0xcf, 0xdf, 0x91, 0x1f, 0x65, 0xc9, 0xcb, 0x8c, 0xbc, 0x00, 0xe3, 0xbc, 0x0b, 0x8b,
+	0x52, 0x68, 0x35, 0xa3, 0xca, 0x94, 0x11, 0xeb, 0x55, 0xab, 0xa1, 0x54, 0xb0, 0x1a, 0xd6, 0x60,
+	0x69, 0x9f, 0x95, 0x33, 0x12, 0x80, 0xb9, 0x32, 0x52, 0x99, 0x0a, 0x33, 0x56, 0x96, 0x31, 0x6a,
+	0x95, 0xf9, 0x46, 0x18, 0x80, 0x6f, 0xc3, 0xd2, 0x86, 0x17, 0x74, 0x69, 0x3f, 0xc3, 0xcc, 0x29,
+	0xbc, 0x9e, 0x63, 0xc0, 0x58, 0x28, 0xcc, 0xfc, 0x56, 0x30, 0xfd, 0x70, 0x1a, 0x66, 0x84, 0xa8,
+	0x17, 0x32, 0xaa, 0x9a, 0x8c, 0x8a, 0x6f, 0x78, 0xe4, 0xd5, 0x76, 0xb9, 0x48, 0x6d, 0x63, 0x8e,
+	0xbc, 0x97, 0x9c, 0x30, 0x47, 0x4c, 0xd5, 0x65, 0xff, 0x65, 0x8c, 0x62, 0x2a, 0x8d, 0x51, 0x14,
+	0x5d, 0x72, 0xe2, 0x56, 0x48, 0x0e, 0x5e, 0xb4, 0xde, 0x67, 0x8a, 0xd7, 0xfb, 0xa7, 0x61, 0x3a,
+	0x66, 0xd9, 0x3c, 0x4c, 0x4e, 0x1b, 0x6b, 0xd7, 0xa5, 0x7b, 0x97, 0xd3, 0xc9, 0x5f, 0x9e, 0xf1,
+	0xe3, 0x0a, 0x5a, 0x5c, 0xf8, 0xac, 0x83, 0x7a, 0x5e, 0x91, 0x06, 0x31, 0x62, 0x1d, 0x60, 0xc6,
+	0x3a, 0xb0, 0x1f, 0xaa, 0xfb, 0xcc, 0xad, 0x13, 0xc4, 0x62, 0xdb, 0xc8, 0xc1, 0xf1, 0x84, 0xcf,
+	0x63, 0xb2, 0x75, 0xe3, 0x84, 0x8f, 0xc1, 0xd8, 0x75, 0x1e, 0x7d, 0x72, 0x39, 0x81, 0x7e, 0x51,
+	0x8c, 0x8b, 0x1d, 0xdf, 0x46, 0x4c, 0x20, 0xd9, 0x84, 0x86, 0xf0, 0x82, 0x77, 0x22, 0xea, 0xc5,
+	0x61, 0xd0, 0x6a, 0x14, 0xf6, 0xfa, 0x31, 0x27, 0x72, 0x19, 0x8d, 0x9b, 0xf9, 0xc6, 0x79, 0x0c,
+	0x73, 0xc6, 0xb0, 0xa0, 0x66, 0x7e, 0xbe, 0xfb, 0xe5, 0xdd, 0x67, 0xef, 0xa2, 0x3e, 0x9f, 0x83,
+	0xea, 0xf6, 0x6e, 0xe7, 0xf1, 0xce, 0xf6, 0x93, 0xad, 0x83, 0xa6, 0x85, 0xc5, 0xfd, 0xe7, 0x1b,
+	0x1b, 0xed, 0xf6, 0x26, 0x53, 0xe9, 0x00, 0xd3, 0x8f, 0xd7, 0xb7, 0x51, 0xbd, 0x97, 0x99, 0xa7,
+	0xcf, 0xa8, 0x09, 0x6f, 0xb6, 0x20, 0xf6, 0xb9, 0xdb, 0xee, 0xb8, 0xed, 0xf5, 0xfd, 0x67, 0xbb,
+	0x9d, 0xdd, 0x67, 0xbb, 0xed, 0xe6, 0x25, 0x62, 0xc3, 0x72, 0x06, 0x71, 0xb0, 0xfd, 0xb4, 0xfd,
+	0xec, 0x39, 0xd6, 0x70, 0x0d, 0xae, 0xe4, 0x3e, 0xea, 0xb8, 0xcf, 0x9e, 0x1f, 0xb4, 0x9b, 0x25,
+	0xd2, 0x82, 0xa5, 0x0c, 0xb2, 0xed, 0xba, 0xcf, 0xdc, 0x66, 0x99, 0xdc, 0x85, 0xd5, 0x0c, 0x66,
+	0x7b, 0x77, 0xe3, 0x99, 0xeb, 0xb6, 0x37, 0x0e, 0x3a, 0x7b, 0xeb, 0x5f, 0x7b, 0xda, 0xde, 0x3d,
+	0xe8, 0x6c, 0xb6, 0x0f, 0xd6, 0xb7, 0x77, 0xf6, 0x9b, 0x15, 0xe7, 0x8f, 0x4a, 0x50, 0xd3, 0x86,
+	0x1d, 0x25, 0x40, 0xc6, 0x04, 0x53, 0xe7, 0x57, 0x0a, 0x21, 0x9f, 0x51, 0x72, 0x55, 0x62, 0x23,
+	0x7c, 0x23, 0x3f, 0x75, 0xec, 0x7f, 0x46, 0xb0, 0x54, 0xcc, 0xa3, 0x3c, 0x39, 0xe6, 0xb1, 0x0a,
+	0xf3, 0xb2, 0x22, 0x29, 0x3f, 0xdc, 0x6b, 0x97, 0x05, 0xf3, 0xf4, 0x99, 0x38, 0xec, 0x9f, 0x51,
+	0x45, 0x29, 0x82, 0x58, 0x19, 0x30, 0xb9, 0x9b, 0x46, 0x4b, 0xa6, 0x57, 0xac, 0x8c, 0xa8, 0xc9,
+	0x39, 0x92, 0x24, 0xce, 0x9b, 0x00, 0x69, 0xdb, 0xcd, 0x09, 0xbf, 0x64, 0x4e, 0xb8, 0xa5, 0x4d,
+	0x78, 0xc9, 0xf9, 0x6b, 0x0b, 0x6a, 0x1a, 0x43, 0xf2, 0x16, 0x4c, 0x0b, 0x31, 0xe4, 0x77, 0xa2,
+	0x56, 0xf2, 0x95, 0x66, 0x44, 0x51, 0xd0, 0xa3, 0xca, 0xe8, 0xe2, 0x31, 0x84, 0x1f, 0xc8, 0xd9,
+	0x7f, 0xb4, 0x78, 0x06, 0xfc, 0xba, 0x8f, 0x8c, 0xf8, 0x88, 0x22, 0xba, 0xe5, 0xa5, 0x08, 0xc7,
+	0xe1, 0x28, 0xea, 0x4a, 0xd5, 0xcc, 0x6d, 0xf0, 0x42, 0x9c, 0xf3, 0x5f, 0xb3, 0xb2, 0x69, 0x08,
+	0x79, 0x1d, 0x66, 0xb7, 0x77, 0x0f, 0xda, 0xee, 0xee, 0xfa, 0x4e, 0xd3, 0x42, 0xd4, 0xd3, 0xf6,
+	0xfe, 0xfe, 0xfa, 0x93, 0x76, 0xb3, 0xe4, 0xfc, 0x92, 0x05, 0x4d, 0x97, 0x1e, 0x1a, 0x99, 0x05,
+	0xb8, 0xe8, 0x73, 0x71, 0x77, 0x2e, 0x34, 0x39, 0x38, 0xd2, 0xca, 0x7c, 0xbb, 0x8e, 0x79, 0x02,
+	0xc9, 0xc1, 0x0b, 0xee, 0xf8, 0xe2, 0x28, 0x78, 0x2f, 0xb5, 0x1c, 0x1f, 0x59, 0x74, 0xfe, 0xd8,
+	0x82, 0x05, 0xad, 0x61, 0xff, 0x99, 0x6e, 0x98, 0x16, 0x84, 0xa4, 0x75, 0x15, 0x3a, 0x95, 0x09,
+	0x17, 0x7f, 0x16, 0x16, 0x0f, 0x22, 0xaf, 0x7b, 0xba, 0x67, 0x5e, 0x31, 0xbe, 0xc8, 0x86, 0xf7,
+	0xff, 0x4a, 0xdc, 0xe8, 0x10, 0x9f, 0xc6, 0xda, 0xb7, 0x86, 0x51, 0x60, 0x15, 0x18, 0x56, 0x22,
+	0x46, 0x27, 0xf8, 0xc5, 0x72, 0x67, 0xd7, 0x61, 0x86, 0x41, 0x55, 0xbe, 0x98, 0x41, 0x55, 0xf9,
+	0x88, 0x06, 0xd5, 0xd4, 0x04, 0x83, 0x0a, 0xcd, 0x1b, 0x3f, 0xe8, 0xf6, 0x47, 0x78, 0x7e, 0x45,
+	0x41, 0x19, 0xf6, 0x69, 0x42, 0x85, 0x59, 0x57, 0x80, 0x71, 0x7e, 0xdd, 0x82, 0x25, 0x73, 0x2c,
+	0x52, 0x0b, 0x4c, 0x75, 0xd2, 0xb4, 0xc0, 0xe4, 0x88, 0x2b, 0xfc, 0x04, 0x9b, 0xaa, 0x34, 0xc9,
+	0xa6, 0x2a, 0xb6, 0xd8, 0xca, 0x13, 0x2c, 0x36, 0xbc, 0xb9, 0xb8, 0x49, 0xb1, 0xb1, 0xeb, 0xfd,
+	0x7e, 0x66, 0xca, 0xd0, 0xf1, 0x55, 0x80, 0x13, 0xf6, 0xcb, 0x63, 0x58, 0xd8, 0xa4, 0x87, 0xa3,
+	0xe3, 0x1d, 0x7a, 0x96, 0x26, 0x44, 0x13, 0xa8, 0xc4, 0x27, 0xe1, 0x0b, 0x61, 0x58, 0xb3, 0xff,
+	0x98, 0x2e, 0xd2, 0x47, 0x9a, 0x4e, 0x3c, 0xa4, 0x5d, 0x79, 0x93, 0x90, 0x41, 0xf6, 0x87, 0xb4,
+	0xeb, 0xbc, 0x09, 0x44, 0xe7, 0x23, 0x06, 0x08, 0x0f, 0x9a, 0xa3, 0xc3, 0x4e, 0x3c, 0x8e, 0x13,
+	0x3a, 0x90, 0x57, 0x24, 0x75, 0x90, 0xf3, 0x06, 0xd4, 0xf7, 0x3c, 0xbc, 0x89, 0x2b, 0x2e, 0x36,
+	0x63, 0xc4, 0xcd, 0x1b, 0xa3, 0xd9, 0xa1, 0x22, 0x6e, 0x0c, 0xed, 0xfc, 0x63, 0x09, 0xa6, 0x39,
+	0x25, 0x72, 0xed, 0xd1, 0x38, 0xf1, 0x03, 0x9e, 0x0c, 0x2c, 0xb8, 0x6a, 0xa0, 0x9c, 0x84, 0x97,
+	0x0a, 0x2c, 0x31, 0xe1, 0xd6, 0x94, 0xb7, 0xb2, 0x64, 0x24, 0x59, 0x87, 0xb1, 0xdc, 0x08, 0x75,
+	0x95, 0xa2, 0x22, 0x72, 0x23, 0x24, 0x20, 0x93, 0x0d, 0x92, 0x1e, 0x67, 0x79, 0xfb, 0xa4, 0x19,
+	0x2c, 0x8c, 0x2f, 0x1d, 0x54, 0x78, 0x68, 0xe6, 0x86, 0x57, 0x0e, 0x9e, 0x3f, 0x1c, 0xcf, 0x5e,
+	0xe0, 0x70, 0xcc, 0x4d, 0xad, 0xf3, 0x0e, 0xc7, 0x70, 0x81, 0xc3, 0x31, 0x5e, 0x20, 0x7a, 0x4c,
+	0xa9, 0x4b, 0xd1, 0xed, 0x22, 0xc5, 0xe9, 0x7b, 0x16, 0x34, 0x85, 0xc7, 0x48, 0xe1, 0xc8, 0x6b,
+	0x86, 0x7b, 0xc9, 0x2a, 0xca, 0x29, 0xbd, 0x0d, 0x73, 0xcc, 0xe9, 0xa3, 0xb4, 0x95, 0xc8, 0xcd,
+	0x31, 0x80, 0xd8, 0x0f, 0x99, 0x25, 0x39, 0xf0, 0xfb, 0x32, 0x85, 0x48, 0x03, 0x49, 0x85, 0x17,
+	0x79, 0xe2, 0x1e, 0x84, 0xe5, 0xaa, 0xb2, 0xf3, 0x87, 0x16, 0x2c, 0x68, 0x0d, 0x16, 0x52, 0xf8,
+	0x0e, 0xc8, 0x4b, 0x18, 0x3c, 0x07, 0xc6, 0xcc, 0xb6, 0xc8, 0xf6, 0xc5, 0x35, 0x88, 0xd9, 0x64,
+	0x7a, 0x63, 0xd6, 0xc0, 0x78, 0x34, 0x10, 0x0b, 0x56, 0x07, 0xa1, 0x20, 0xbd, 0xa0, 0xf4, 0x54,
+	0x91, 0xf0, 0x45, 0x6a, 0xc0, 0xb0, 0xf3, 0x03, 0x74, 0x56, 0x29, 0x22, 0xae, 0xcc, 0x4c, 0xa0,
+	0xf3, 0x17, 0x16, 0x2c, 0x72, 0xaf, 0xa3, 0xf0, 0xe9, 0xaa, 0x0c, 0x98, 0x69, 0xee, 0x66, 0xe5,
+	0x2b, 0x72, 0xeb, 0x92, 0x2b, 0xca, 0xe4, 0x33, 0x17, 0xf4, 0x94, 0xaa, 0xbb, 0x15, 0x13, 0xe6,
+	0xa2, 0x5c, 0x34, 0x17, 0xe7, 0x8c, 0x74, 0x51, 0x08, 0x76, 0xaa, 0x30, 0x04, 0x8b, 0xe9, 0x67,
+	0x71, 0x37, 0x1c, 0x52, 0x4c, 0x83, 0x34, 0x3b, 0x27, 0x54, 0xd0, 0xef, 0x5b, 0x70, 0x85, 0x83,
+	0xb0, 0xc5, 0xc2, 0xd0, 0x13, 0x3d, 0xff, 0x54, 0x4e, 0xae, 0x8a, 0xfb, 0x67, 0xf4, 0xee, 0xf3,
+	0xfc, 0xfa, 0xac, 0xb8, 0x27, 0xd0, 0x58, 0xfb, 0xb8, 0xf8, 0x60, 0x42, 0x25, 0xf7, 0xd6, 0x19,
+	0xb1, 0x2b, 0x3e, 0x72, 0x3e, 0x01, 0xd3, 0x1c, 0x82, 0x36, 0x59, 0x7b, 0x77, 0xfd, 0xd1, 0x4e,
+	0x9b, 0xbb, 0x5f, 0x36, 0xb7, 0xf7, 0x59, 0xc1, 0x42, 0xaf, 0xcc, 0xfa, 0xf3, 0x83, 0x67, 0xcd,
+	0x12, 0x2a, 0xde, 0x3c, 0x5b, 0xd1, 0xb1, 0x7f, 0xb2, 0xe0, 0x06, 0x47, 0xb2, 0xe0, 0x77, 0x10,
+	0x84, 0xa3, 0xa0, 0x4b, 0xf5, 0x9d, 0x58, 0x79, 0xcb, 0x2d, 0x3d, 0xea, 0xae, 0x62, 0xe8, 0x25,
+	0x2d, 0x86, 0x8e, 0x73, 0x86, 0x87, 0xe7, 0xd4, 0x8f, 0x5f, 0x66, 0xc7, 0x40, 0x13, 0x28, 0xaf,
+	0x69, 0x9c, 0xd1, 0x8e, 0x19, 0xb8, 0xaf, 0xba, 0x39, 0x38, 0xd2, 0xc6, 0x34, 0x91, 0x1e, 0xfd,
+	0xce, 0xa1, 0x9f, 0xf0, 0xe4, 0x9d, 0x39, 0x37, 0x07, 0xc7, 0xed, 0x6b, 0x14, 0x64, 0xa1, 0xad,
+	0x69, 0x46, 0x5d, 0x80, 0x71, 0xbe, 0x04, 0x37, 0x27, 0x75, 0x5d, 0x25, 0xda, 0x5c, 0x30, 0x6b,
+	0x02, 0x9f, 0xb1, 0x68, 0x3d, 0xe6, 0x29, 0x72, 0x98, 0x61, 0x2b, 0xb2, 0x6a, 0xc4, 0x10, 0xde,
+	0x04, 0x60, 0x36, 0x00, 0xcf, 0x38, 0x11, 0x07, 0x8c, 0x14, 0x82, 0x42, 0x4c, 0x83, 0x5e, 0x9a,
+	0xf0, 0x52, 0x71, 0x55, 0x39, 0x67, 0xcc, 0x08, 0xc7, 0xb9, 0x0e, 0xc3, 0x80, 0xab, 0xf4, 0x06,
+	0xd1, 0x33, 0xb6, 0xd3, 0x73, 0x6b, 0x38, 0x03, 0x75, 0x7e, 0xcf, 0x82, 0xf9, 0xb4, 0x91, 0x6d,
+	0x04, 0x9a, 0xdb, 0x87, 0x70, 0x80, 0x28, 0x80, 0x8a, 0xfb, 0xfb, 0xe8, 0x11, 0x11, 0x6d, 0xd3,
+	0x20, 0x4c, 0xa5, 0x8b, 0x52, 0x38, 0x92, 0xe6, 0x8f, 0x0e, 0xe2, 0xb7, 0x4c, 0xd0, 0x12, 0x10,
+	0xb6, 0x8e, 0x28, 0xb1, 0x1b, 0xac, 0x83, 0x84, 0x7d, 0xc5, 0x93, 0x56, 0x65, 0x51, 0xda, 0x8f,
+	0xdc, 0x37, 0x85, 0x7f, 0x9d, 0x9f, 0xb7, 0xe0, 0x6a, 0xc1, 0xe0, 0x8a, 0x49, 0xda, 0x84, 0x85,
+	0x23, 0x85, 0x94, 0x03, 0xc0, 0xf5, 0xe7, 0xb2, 0xcc, 0x9b, 0x33, 0x3b, 0xed, 0xe6, 0x3f, 0x50,
+	0xb6, 0x0c, 0x1f, 0x52, 0xe3, 0x82, 0x56, 0x1e, 0xb1, 0xf6, 0xfd, 0x12, 0x34, 0x78, 0x5a, 0x34,
+	0x7f, 0x77, 0x89, 0x46, 0xe4, 0x29, 0xcc, 0x88, 0x57, 0xae, 0x88, 0xcc, 0xb1, 0x32, 0xdf, 0xd5,
+	0xb2, 0x97, 0xb3, 0x60, 0xb1, 0x06, 0x17, 0xff, 0xcf, 0x8f, 0xff, 0xf6, 0x17, 0x4a, 0x73, 0xa4,
+	0x76, 0xff, 0xec, 0xe1, 0xfd, 0x63, 0x1a, 0xc4, 0xc8, 0xe3, 0x9b, 0x00, 0xe9, 0x43, 0x51, 0xa4,
+	0xa5, 0xbc, 0x66, 0x99, 0x87, 0xad, 0xec, 0xab, 0x05, 0x18, 0xc1, 0xf7, 0x2a, 0xe3, 0xbb, 0xe8,
+	0x34, 0x90, 0xaf, 0x1f, 0xf8, 0x09, 0x7f, 0x35, 0xea, 0x6d, 0xeb, 0x0e, 0xe9, 0x41, 0x5d, 0x7f,
+	0x30, 0x8a, 0xc8, 0xa0, 0x5d, 0xc1, 0x2b, 0x54, 0xf6, 0xb5, 0x42, 0x9c, 0x8c, 0x58, 0xb2, 0x3a,
+	0x2e, 0x3b, 0x4d, 0xac, 0x63, 0xc4, 0x28, 0x54, 0x2d, 0x6b, 0x7f, 0x79, 0x1b, 0xaa, 0x6a, 0xb1,
+	0x90, 0x6f, 0xc3, 0x9c, 0x91, 0x49, 0x4e, 0x24, 0xe3, 0xa2, 0xc4, 0x73, 0xfb, 0x7a, 0x31, 0x52,
+	0x54, 0x7b, 0x93, 0x55, 0xdb, 0x22, 0xcb, 0x58, 0xad, 0x38, 0x06, 0xdd, 0x67, 0xf9, 0xf3, 0xfc,
+	0xc6, 0xea, 0xa9, 0x4a, 0x3a, 0x93, 0x95, 0x5d, 0x37, 0x35, 0x72, 0xa6, 0xb6, 0x1b, 0x13, 0xb0,
+	0xa2, 0xba, 0xeb, 0xac, 0xba, 0x65, 0xb2, 0xa4, 0x57, 0xa7, 0x02, 0xcb, 0x94, 0xdd, 0x31, 0xd6,
+	0x5f, 0x92, 0x22, 0x37, 0xd4, 0x54, 0x17, 0xbd, 0x30, 0xa5, 0x26, 0x2d, 0xff, 0xcc, 0x94, 0xd3,
+	0x62, 0x55, 0x11, 0xc2, 0x06, 0x54, 0x7f, 0x48, 0x8a, 0x7c, 0x03, 0xaa, 0xea, 0xc9, 0x12, 0x72,
+	0x45, 0x7b, 0x27, 0x46, 0x7f, 0x47, 0xc5, 0x6e, 0xe5, 0x11, 0x45, 0x53, 0xa5, 0x73, 0x46, 0x81,
+	0xd8, 0x81, 0xcb, 0xc2, 0x2f, 0x7a, 0x48, 0x3f, 0x4a, 0x4f, 0x0a, 0xde, 0xbf, 0x7a, 0x60, 0x91,
+	0x77, 0x60, 0x56, 0xbe, 0x04, 0x43, 0x96, 0x8b, 0x5f, 0xb4, 0xb1, 0xaf, 0xe4, 0xe0, 0x62, 0x3d,
+	0xaf, 0x03, 0xa4, 0xaf, 0x98, 0x28, 0xc9, 0xcf, 0xbd, 0xad, 0x62, 0x5f, 0x2d, 0xc0, 0x08, 0x16,
+	0xc7, 0xb0, 0x90, 0x7b, 0x24, 0x85, 0xdc, 0x4a, 0xe9, 0x0b, 0x9f, 0x4f, 0x39, 0x87, 0xa1, 0xb3,
+	0xcc, 0xc6, 0xae, 0x49, 0xd8, 0x52, 0x0a, 0xe8, 0x0b, 0x79, 0xdb, 0x7e, 0x13, 0x6a, 0xda, 0xcb,
+	0x28, 0x44, 0x72, 0xc8, 0xbf, 0xaa, 0x62, 0xdb, 0x45, 0x28, 0xd1, 0xdc, 0x2f, 0xc1, 0x9c, 0xf1,
+	0xc4, 0x89, 0x5a, 0x19, 0x45, 0x0f, 0xa8, 0xd8, 0xd7, 0x8b, 0x91, 0x82, 0xd7, 0xd7, 0xa1, 0xa6,
+	0x3d, 0x48, 0x42, 0xb4, 0xfb, 0x87, 0x99, 0xa7, 0x48, 0x6c, 0xbb, 0x08, 0x25, 0xfa, 0xbb, 0xc4,
+	0xfa, 0xdb, 0x70, 0xaa, 0xd8, 0x5f, 0x76, 0xe5, 0x1c, 0x85, 0xe4, 0xdb, 0xd0, 0x30, 0x9f, 0x28,
+	0x51, 0xab, 0xaa, 0xf0, 0xb1, 0x13, 0xfb, 0xc6, 0x04, 0xac, 0x29, 0x90, 0x77, 0x16, 0x55, 0x25,
+	0xf7, 0xdf, 0x17, 0xb9, 0x7e, 0x1f, 0x90, 0xaf, 0x40, 0x55, 0xbd, 0x01, 0x40, 0xd2, 0x87, 0x59,
+	0xcc, 0x97, 0x02, 0xec, 0x56, 0x1e, 0x21, 0x98, 0x2f, 0x30, 0xe6, 0x35, 0x92, 0xf6, 0x80, 0x6b,
+	0x68, 0xf6, 0x16, 0x80, 0xa6, 0xa1, 0xf5, 0xe7, 0x02, 0xec, 0xe5, 0x2c, 0xb8, 0x58, 0x43, 0x27,
+	0x3e, 0xf2, 0x08, 0x60, 0x3e, 0x73, 0xe7, 0x48, 0x2d, 0x96, 0xe2, 0x1b, 0x8b, 0xf6, 0xcd, 0xf3,
+	0xaf, 0x2a, 0x99, 0x6a, 0x46, 0xaa, 0x97, 0xfb, 0xf2, 0x82, 0xe9, 0xff, 0x80, 0xba, 0xfe, 0xb4,
+	0x84, 0xd2, 0xd9, 0x05, 0x0f, 0x62, 0xd8, 0xd7, 0x0a, 0x71, 0xe6, 0xe4, 0x92, 0xba, 0x5e, 0x0d,
+	0xf9, 0x3a, 0xcc, 0x6b, 0xb7, 0xdb, 0xf6, 0xc7, 0x41, 0x57, 0x09, 0x4f, 0xfe, 0x3e, 0xb2, 0x5d,
+	0x64, 0xe0, 0x3a, 0x57, 0x18, 0xe3, 0x05, 0xc7, 0x60, 0x8c, 0x82, 0xb3, 0x01, 0x35, 0x8d, 0xc7,
+	0x79, 0x7c, 0xaf, 0x68, 0x28, 0xfd, 0x6a, 0xee, 0x03, 0x8b, 0xfc, 0x32, 0xbe, 0x14, 0xa6, 0xdd,
+	0x74, 0x27, 0x46, 0xa6, 0x49, 0x86, 0x4f, 0x4b, 0xc7, 0xe9, 0x8c, 0x1c, 0x97, 0x35, 0x72, 0xe7,
+	0xce, 0x97, 0x8c, 0x41, 0x7e, 0xdf, 0x38, 0x08, 0xde, 0xcb, 0xbe, 0x1a, 0xf6, 0x41, 0x96, 0x40,
+	0xbf, 0xb3, 0xfd, 0xc1, 0x03, 0x8b, 0xbc, 0xcd, 0x1f, 0xf9, 0x93, 0x61, 0x16, 0xa2, 0x29, 0xb7,
+	0xec, 0x90, 0xe9, 0x8f, 0xc2, 0xad, 0x5a, 0x0f, 0x2c, 0xf2, 0x2d, 0x98, 0xd7, 0xbe, 0x65, 0x23,
+	0x7f, 0xd1, 0xef, 0x9d, 0xdb, 0xac, 0x37, 0x37, 0x9d, 0xab, 0x46, 0x6f, 0xb2, 0xda, 0x7d, 0x1d,
+	0x6a, 0xda, 0x9b, 0x6f, 0xa9, 0x9a, 0xca, 0xbd, 0x03, 0x37, 0xb9, 0x91, 0x03, 0x98, 0xd7, 0xc8,
+	0x0d, 0xf1, 0xb8, 0x20, 0x1b, 0xe7, 0x0e, 0x6b, 0xeb, 0x6d, 0xe7, 0xd6, 0xc4, 0xb6, 0xde, 0x67,
+	0x07, 0x7b, 0x6c, 0xb1, 0x07, 0x55, 0xe5, 0xdf, 0x54, 0xcb, 0x3f, 0xeb, 0x8a, 0xb5, 0x5b, 0x79,
+	0x84, 0xa8, 0xeb, 0x35, 0x56, 0xd7, 0x35, 0x67, 0xd9, 0xa8, 0x2b, 0x92, 0x74, 0x58, 0xc5, 0x1e,
+	0x40, 0x1a, 0x76, 0x26, 0x99, 0xb8, 0xa4, 0xda, 0x0c, 0xf2, 0x91, 0x69, 0x53, 0xcc, 0x65, 0xf8,
+	0x12, 0x39, 0x7e, 0x83, 0xaf, 0x50, 0x41, 0x1f, 0xab, 0x01, 0xca, 0xc7, 0x87, 0x6d, 0xbb, 0x08,
+	0x55, 0xb4, 0x3e, 0x25, 0x7f, 0xf2, 0x1c, 0xe6, 0x76, 0xc2, 0xf0, 0x74, 0x34, 0x94, 0x2d, 0x26,
+	0xa6, 0x1f, 0x0f, 0xa3, 0xd8, 0x76, 0xa6, 0x17, 0xce, 0x0a, 0x63, 0x65, 0x93, 0x96, 0xc6, 0xea,
+	0xfe, 0xfb, 0x69, 0x58, 0xfb, 0x03, 0xdc, 0x7b, 0x8c, 0x50, 0xa4, 0xda, 0x7b, 0x8a, 0x82, 0x9a,
+	0xf6, 0xf5, 0x62, 0x64, 0xba, 0x8f, 0x19, 0x11, 0x48, 0xc5, 0xab, 0x28, 0xa6, 0x69, 0x5f, 0x2f,
+	0x46, 0x0a, 0x5e, 0x1e, 0x2c, 0x28, 0x83, 0x44, 0x0d, 0xa8, 0x6d, 0x76, 0x4f, 0x8f, 0xe4, 0xe6,
+	0xba, 0x6e, 0x98, 0x88, 0x72, 0x14, 0xef, 0xc7, 0x92, 0xe7, 0x03, 0x8b, 0xec, 0x41, 0x7d, 0x93,
+	0x62, 0xb8, 0x41, 0xf8, 0xec, 0x16, 0xd3, 0x01, 0x55, 0xce, 0x3e, 0x7b, 0xce, 0x00, 0x9a, 0x2a,
+	0x7a, 0xe8, 0x8d, 0x23, 0xfa, 0x9d, 0xfb, 0xef, 0x0b, 0x6f, 0xe0, 0x07, 0x52, 0x45, 0xef, 0x29,
+	0x0f, 0xb2, 0xbe, 0x3d, 0x99, 0x2e, 0x4f, 0xfb, 0x5a, 0x21, 0xae, 0x48, 0x04, 0x94, 0x7f, 0xf6,
+	0x73, 0x50, 0xd7, 0x7d, 0xe5, 0x8a, 0x7d, 0x81, 0x03, 0xdd, 0xce, 0x78, 0x79, 0x1f, 0x58, 0xa4,
+	0x0f, 0x0b, 0x39, 0x1f, 0xab, 0x32, 0x8a, 0x26, 0x79, 0x66, 0xed, 0x95, 0xc9, 0x04, 0x66, 0x5b,
+	0xef, 0x98, 0x6d, 0xdd, 0x87, 0xb9, 0x4d, 0xca, 0x87, 0x9a, 0x67, 0x43, 0xdb, 0xe6, 0x8e, 0xa1,
+	0xa7, 0x7c, 0xda, 0x8b, 0x05, 0x38, 0x73, 0x07, 0x67, 0xa9, 0xc8, 0xe4, 0x1b, 0x50, 0x7b, 0x42,
+	0x13, 0x99, 0xfe, 0xac, 0x4c, 0xcb, 0x4c, 0x3e, 0xb4, 0x5d, 0x90, 0x3d, 0x6d, 0xae, 0x04, 0xc6,
+	0xed, 0x3e, 0xe6, 0x53, 0x73, 0xbd, 0xde, 0xf1, 0x7b, 0x1f, 0x90, 0xaf, 0x32, 0xe6, 0xea, 0xc6,
+	0xc4, 0xb2, 0x96, 0x40, 0xa9, 0x33, 0x9f, 0xcf, 0xc0, 0x8b, 0x38, 0x07, 0x61, 0x8f, 0x6a, 0xb6,
+	0x4c, 0x00, 0x35, 0xed, 0x46, 0xa1, 0x52, 0x0b, 0xf9, 0xab, 0xa6, 0xb6, 0x5d, 0x84, 0x12, 0xe3,
+	0xbc, 0xca, 0xea, 0x71, 0xc8, 0x4a, 0x5a, 0x0f, 0xbf, 0x31, 0x96, 0xd6, 0x74, 0xff, 0x7d, 0x6f,
+	0x90, 0x7c, 0x40, 0x76, 0x61, 0x8a, 0x5d, 0x60, 0x4a, 0x25, 0x5a, 0xbb, 0xab, 0x66, 0x2f, 0x99,
+	0x40, 0xc1, 0xdd, 0x66, 0xdc, 0x97, 0x9c, 0xf9, 0x94, 0x3b, 0x5e, 0x57, 0x61, 0x9a, 0xf2, 0x9b,
+	0xe2, 0x46, 0xa4, 0x79, 0x29, 0x85, 0xbc, 0xa6, 0x37, 0xb6, 0xf0, 0x3a, 0x8b, 0xed, 0x9c, 0x47,
+	0x22, 0x56, 0xfa, 0x37, 0x61, 0xb1, 0xe0, 0xca, 0x8b, 0xe2, 0x3e, 0xf9, 0xb2, 0x8c, 0xed, 0x9c,
+	0x47, 0x22, 0xb8, 0xbf, 0xcb, 0xde, 0x84, 0xd2, 0xd3, 0xdd, 0x53, 0x33, 0x3f, 0x9b, 0x19, 0x6f,
+	0x93, 0x3c, 0xca, 0x34, 0xfd, 0xf9, 0xc0, 0x30, 0xf3, 0x6f, 0x13, 0x6a, 0x5a, 0xbe, 0xb3, 0xe2,
+	0x9a, 0xcf, 0x8c, 0xb6, 0xed, 0x22, 0x94, 0x72, 0x5e, 0xd4, 0xb6, 0x07, 0x79, 0x2e, 0xdb, 0x83,
+	0x89, 0x5c, 0x8a, 0xd2, 0x9f, 0x3f, 0x03, 0x80, 0x79, 0xc4, 0x9b, 0x1e, 0x1d, 0x60, 0x40, 0x55,
+	0x2a, 0xe9, 0x34, 0xd3, 0xd8, 0x5e, 0x34, 0x60, 0x6a, 0x6c, 0xd2, 0x43, 0x9f, 0x71, 0x73, 0x41,
+	0x2e, 0xfa, 0x89, 0xc9, 0xc8, 0xb6, 0x5d, 0x44, 0xa1, 0x4c, 0xb5, 0x75, 0x80, 0x34, 0xd2, 0xa2,
+	0x8e, 0x70, 0xb9, 0x20, 0x8e, 0x7d, 0xb5, 0x00, 0x23, 0xda, 0xb6, 0x07, 0xd5, 0xd4, 0x75, 0x7f,
+	0x25, 0xbd, 0xff, 0x68, 0x38, 0xfa, 0xed, 0x56, 0x1e, 0x21, 0xe4, 0xb9, 0xc9, 0xa6, 0x0d, 0xc8,
+	0x2c, 0x4e, 0x1b, 0xf3, 0x92, 0xfb, 0xb0, 0x98, 0xba, 0x41, 0x99, 0xcd, 0xca, 0x72, 0x67, 0x65,
+	0x4f, 0x0a, 0x9c, 0xda, 0xf6, 0xb5, 0x42, 0x5c, 0x91, 0x7b, 0x05, 0xb5, 0x08, 0xcf, 0xdb, 0xc5,
+	0x05, 0x13, 0x40, 0x33, 0xeb, 0x71, 0x25, 0x37, 0xcf, 0xf7, 0xf0, 0xda, 0xb7, 0x26, 0xe2, 0x27,
+	0xd5, 0xc7, 0xf3, 0x0c, 0xb0, 0xbe, 0xef, 0x5a, 0xb0, 0x5c, 0xec, 0xca, 0x24, 0xb7, 0x0d, 0xb6,
+	0x13, 0x9c, 0xbc, 0xf6, 0xc7, 0x5f, 0x41, 0x25, 0x9a, 0x70, 0x8b, 0x35, 0xe1, 0xaa, 0xc3, 0x76,
+	0x3f, 0x54, 0x72, 0x9e, 0x46, 0x85, 0x0d, 0x19, 0xc0, 0x42, 0xce, 0x51, 0xa7, 0xf6, 0x98, 0x49,
+	0xfe, 0x51, 0x7b, 0x65, 0x32, 0x81, 0xa8, 0xf8, 0x32, 0xab, 0x78, 0xde, 0x01, 0xac, 0x38, 0x7e,
+	0xe1, 0x27, 0xdd, 0x93, 0xb7, 0xad, 0x3b, 0x87, 0xd3, 0xec, 0x09, 0xfb, 0x4f, 0xfd, 0xeb, 0x00,
+	0x7f, 0xb8, 0x46, 0xee, 0xf4, 0x5e, 0x00, 0x00,
 }
@@ -21,6 +21,38 @@ const (
 	// connection is established.
 	InitialRoutingSync FeatureBit = 3
 
+	// GossipQueriesRequired is a local feature bit meaning that the node
+	// requires the peer to support the gossip query messages, which allow
+	// both sides to reconcile their views of the channel graph rather
+	// than exchanging a complete dump of routing information.
+	GossipQueriesRequired FeatureBit = 6
+
+	// GossipQueriesOptional is an optional local feature bit that
+	// signals that the node understands the gossip query messages, and
+	// will use them to synchronize the channel graph with peers that do
+	// too.
+	GossipQueriesOptional FeatureBit = 7
+
+	// TLVOnionPayloadRequired is a global feature bit meaning that the
+	// node requires the hop payloads addressed to it to be TLV encoded.
+	TLVOnionPayloadRequired FeatureBit = 8
+
+	// TLVOnionPayloadOptional is a global feature bit meaning that the
+	// node understands TLV encoded hop payloads, which may span multiple
+	// frames of the onion. Nodes that don't advertise it receive legacy,
+	// fixed size hop payloads.
+	TLVOnionPayloadOptional FeatureBit = 9
+
+	// MultiPathPaymentsRequired is a global feature bit meaning that the
+	// node requires payments to it to be split across multiple HTLCs.
+	MultiPathPaymentsRequired FeatureBit = 16
+
+	// MultiPathPaymentsOptional is a global feature bit meaning that the
+	// node accepts payments that are split across multiple HTLCs sharing
+	// the same payment hash. The partial HTLCs are held until their total
+	// covers the invoice.
+	MultiPathPaymentsOptional FeatureBit = 17
+
 	// maxAllowedSize is a maximum allowed size of feature vector.
 	//
 	// NOTE: Within the protocol, the maximum allowed message size is 65535
@@ -42,14 +74,21 @@ const (
 // not advertised to the entire network. A full description of these feature
 // bits is provided in the BOLT-09 specification.
 var LocalFeatures = map[FeatureBit]string{
-	InitialRoutingSync: "initial-routing-sync",
+	InitialRoutingSync:    "initial-routing-sync",
+	GossipQueriesRequired: "gossip-queries",
+	GossipQueriesOptional: "gossip-queries",
 }
 
 // GlobalFeatures is a mapping of known global feature bits to a descriptive
 // name. All known global feature bits must be assigned a name in this mapping.
 // Global features are those which are advertised to the entire network. A full
 // description of these feature bits is provided in the BOLT-09 specification.
-var GlobalFeatures map[FeatureBit]string
+var GlobalFeatures = map[FeatureBit]string{
+	TLVOnionPayloadRequired:   "tlv-onion",
+	TLVOnionPayloadOptional:   "tlv-onion",
+	MultiPathPaymentsRequired: "multi-path-payments",
+	MultiPathPaymentsOptional: "multi-path-payments",
+}
 
 // RawFeatureVector represents a set of feature bits as defined in BOLT-09.  A
 // RawFeatureVector itself just stores a set of bit flags but can be used to
@@ -85,6 +124,16 @@ func (fv *RawFeatureVector) Unset(feature FeatureBit) {
 	delete(fv.features, feature)
 }
 
+// Clone makes a copy of the feature vector, which can be modified without
+// affecting the original.
+func (fv *RawFeatureVector) Clone() *RawFeatureVector {
+	newFeatures := NewRawFeatureVector()
+	for bit := range fv.features {
+		newFeatures.Set(bit)
+	}
+	return newFeatures
+}
+
 // SerializeSize returns the number of bytes needed to represent feature vector
 // in byte format.
 func (fv *RawFeatureVector) SerializeSize() int {
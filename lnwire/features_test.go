@@ -95,6 +95,24 @@ func TestFeatureVectorSetUnset(t *testing.T) {
 	}
 }
 
+// TestFeatureVectorClone tests that a cloned feature vector has the same bits
+// set as the original, and that modifying it doesn't affect the original.
+func TestFeatureVectorClone(t *testing.T) {
+	t.Parallel()
+
+	fv := NewRawFeatureVector(0, 3)
+	clone := fv.Clone()
+	if !clone.IsSet(0) || !clone.IsSet(3) || clone.IsSet(1) {
+		t.Fatalf("clone doesn't match original")
+	}
+
+	clone.Set(5)
+	clone.Unset(0)
+	if fv.IsSet(5) || !fv.IsSet(0) {
+		t.Fatalf("modifying clone affected original")
+	}
+}
+
 func TestFeatureVectorEncodeDecode(t *testing.T) {
 	t.Parallel()
 
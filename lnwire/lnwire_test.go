@@ -75,6 +75,36 @@ func randRawFeatureVector(r *rand.Rand) *RawFeatureVector {
 	return featureVec
 }
 
+// randShortChanIDs returns a sorted, randomly sized set of short channel ID's,
+// or nil if the generated set is empty.
+func randShortChanIDs(r *rand.Rand) []ShortChannelID {
+	numChanIDs := r.Int31n(5000)
+	if numChanIDs == 0 {
+		return nil
+	}
+
+	shortChanIDs := make([]ShortChannelID, numChanIDs)
+	for i := range shortChanIDs {
+		shortChanIDs[i] = ShortChannelID{
+			BlockHeight: uint32(r.Int31n(1 << 24)),
+			TxIndex:     uint32(r.Int31n(1 << 24)),
+			TxPosition:  uint16(r.Int31()),
+		}
+	}
+
+	return shortChanIDs
+}
+
+// randShortChanIDEncoding returns one of the known short channel ID
+// encodings at random.
+func randShortChanIDEncoding(r *rand.Rand) ShortChanIDEncoding {
+	if r.Int31n(2) == 0 {
+		return EncodingSortedPlain
+	}
+
+	return EncodingSortedZlib
+}
+
 func TestMaxOutPointIndex(t *testing.T) {
 	t.Parallel()
 
@@ -553,6 +583,37 @@ func TestLightningWireProtocol(t *testing.T) {
 				}
 			}
 
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgQueryShortChanIDs: func(v []reflect.Value, r *rand.Rand) {
+			req := QueryShortChanIDs{
+				EncodingType: randShortChanIDEncoding(r),
+				ShortChanIDs: randShortChanIDs(r),
+			}
+
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to read chain hash: %v", err)
+				return
+			}
+
+			v[0] = reflect.ValueOf(req)
+		},
+		MsgReplyChannelRange: func(v []reflect.Value, r *rand.Rand) {
+			req := ReplyChannelRange{
+				QueryChannelRange: QueryChannelRange{
+					FirstBlockHeight: uint32(r.Int31()),
+					NumBlocks:        uint32(r.Int31()),
+				},
+				Complete:     uint8(r.Int31n(2)),
+				EncodingType: randShortChanIDEncoding(r),
+				ShortChanIDs: randShortChanIDs(r),
+			}
+
+			if _, err := r.Read(req.ChainHash[:]); err != nil {
+				t.Fatalf("unable to read chain hash: %v", err)
+				return
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 	}
@@ -705,6 +766,36 @@ func TestLightningWireProtocol(t *testing.T) {
 				return mainScenario(&m)
 			},
 		},
+		{
+			msgType: MsgQueryShortChanIDs,
+			scenario: func(m QueryShortChanIDs) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyShortChanIDsEnd,
+			scenario: func(m ReplyShortChanIDsEnd) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgQueryChannelRange,
+			scenario: func(m QueryChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgReplyChannelRange,
+			scenario: func(m ReplyChannelRange) bool {
+				return mainScenario(&m)
+			},
+		},
+		{
+			msgType: MsgGossipTimestampRange,
+			scenario: func(m GossipTimestampRange) bool {
+				return mainScenario(&m)
+			},
+		},
 	}
 	for _, test := range tests {
 		var config *quick.Config
@@ -10,6 +10,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnwire/tlv"
 )
 
 // FailureMessage represents the onion failure object identified by its unique
@@ -76,6 +77,8 @@ const (
 	CodeFinalExpiryTooSoon            FailCode = 17
 	CodeFinalIncorrectCltvExpiry      FailCode = 18
 	CodeFinalIncorrectHtlcAmount      FailCode = 19
+	CodeMPPTimeout                    FailCode = 23
+	CodeInvalidOnionPayload                    = FlagPerm | 22
 )
 
 // String returns the string representation of the failure code.
@@ -144,6 +147,12 @@ func (c FailCode) String() string {
 	case CodeFinalIncorrectHtlcAmount:
 		return "FinalIncorrectHtlcAmount"
 
+	case CodeMPPTimeout:
+		return "MPPTimeout"
+
+	case CodeInvalidOnionPayload:
+		return "InvalidOnionPayload"
+
 	default:
 		return "<unknown>"
 	}
@@ -354,6 +363,26 @@ func (f FailFinalExpiryTooSoon) Error() string {
 	return f.Code().String()
 }
 
+// FailMPPTimeout is returned if the HTLC is part of a multi-path payment, and
+// the complete set of HTLCs paying to the invoice didn't arrive in time.
+//
+// NOTE: May only be returned by the final node in the path.
+type FailMPPTimeout struct{}
+
+// Code returns the failure unique code.
+//
+// NOTE: Part of the FailureMessage interface.
+func (f FailMPPTimeout) Code() FailCode {
+	return CodeMPPTimeout
+}
+
+// Returns a human readable string describing the target FailureMessage.
+//
+// NOTE: Implements the error interface.
+func (f FailMPPTimeout) Error() string {
+	return f.Code().String()
+}
+
 // FailInvalidOnionVersion is returned if the onion version byte is unknown.
 //
 // NOTE: May be returned only by intermediate nodes.
@@ -990,6 +1019,70 @@ func (f *FailFinalIncorrectHtlcAmount) Encode(w io.Writer, pver uint32) error {
 	return writeElement(w, f.IncomingHTLCAmount)
 }
 
+// FailInvalidOnionPayload is returned if the hop payload within the onion
+// couldn't be parsed, or is missing a required record. The type of the
+// offending record and the offset into the payload at which the error was
+// encountered are included.
+//
+// NOTE: May be returned by any node in the payment route.
+type FailInvalidOnionPayload struct {
+	// Type is the TLV type of the record that caused the failure.
+	Type tlv.Type
+
+	// Offset is the byte offset within the payload at which the failure
+	// occurred.
+	Offset uint16
+}
+
+// NewInvalidOnionPayload creates new instance of the FailInvalidOnionPayload.
+func NewInvalidOnionPayload(t tlv.Type,
+	offset uint16) *FailInvalidOnionPayload {
+
+	return &FailInvalidOnionPayload{
+		Type:   t,
+		Offset: offset,
+	}
+}
+
+// Code returns the failure unique code.
+//
+// NOTE: Part of the FailureMessage interface.
+func (f *FailInvalidOnionPayload) Code() FailCode {
+	return CodeInvalidOnionPayload
+}
+
+// Returns a human readable string describing the target FailureMessage.
+//
+// NOTE: Implements the error interface.
+func (f FailInvalidOnionPayload) Error() string {
+	return fmt.Sprintf("InvalidOnionPayload(type=%v, offset=%v)",
+		f.Type, f.Offset)
+}
+
+// Decode decodes the failure from bytes stream.
+//
+// NOTE: Part of the Serializable interface.
+func (f *FailInvalidOnionPayload) Decode(r io.Reader, pver uint32) error {
+	recordType, err := tlv.ReadBigSize(r)
+	if err != nil {
+		return err
+	}
+	f.Type = tlv.Type(recordType)
+
+	return readElement(r, &f.Offset)
+}
+
+// Encode writes the failure in bytes stream.
+//
+// NOTE: Part of the Serializable interface.
+func (f *FailInvalidOnionPayload) Encode(w io.Writer, pver uint32) error {
+	if err := tlv.WriteBigSize(w, uint64(f.Type)); err != nil {
+		return err
+	}
+
+	return writeElement(w, f.Offset)
+}
+
 // DecodeFailure decodes, validates, and parses the lnwire onion failure, for
 // the provided protocol version.
 func DecodeFailure(r io.Reader, pver uint32) (FailureMessage, error) {
@@ -1151,6 +1244,13 @@ func makeEmptyOnionError(code FailCode) (FailureMessage, error) {
 
 	case CodeFinalIncorrectHtlcAmount:
 		return &FailFinalIncorrectHtlcAmount{}, nil
+
+	case CodeMPPTimeout:
+		return &FailMPPTimeout{}, nil
+
+	case CodeInvalidOnionPayload:
+		return &FailInvalidOnionPayload{}, nil
+
 	default:
 		return nil, errors.Errorf("unknown error code: %v", code)
 	}
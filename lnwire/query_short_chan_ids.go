@@ -0,0 +1,312 @@
+package lnwire
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// ShortChanIDEncoding is an enum-like type that represents exactly how a set
+// of short channel ID's is encoded on the wire. The set of encodings allows
+// us to take advantage of the structure of a list of short channel ID's to
+// achieve a high degree of compression.
+type ShortChanIDEncoding uint8
+
+const (
+	// EncodingSortedPlain signals that the set of short channel ID's is
+	// encoded using the regular encoding, in a sorted order.
+	EncodingSortedPlain ShortChanIDEncoding = 0
+
+	// EncodingSortedZlib signals that the set of short channel ID's is
+	// encoded by first sorting the set of channel ID's, as then
+	// compressing them using zlib.
+	EncodingSortedZlib ShortChanIDEncoding = 1
+)
+
+const (
+	// maxZlibBufSize is the max number of bytes that we'll accept from a
+	// zlib decoding instance. We do this in order to limit the total
+	// amount of memory allocated during a decoding instance.
+	maxZlibBufSize = 67413630
+)
+
+// ErrUnknownShortChanIDEncoding is a parametrized error that indicates that
+// we came across an unknown short channel ID encoding, and therefore were
+// unable to continue parsing.
+func ErrUnknownShortChanIDEncoding(encoding ShortChanIDEncoding) error {
+	return fmt.Errorf("unknown short chan id encoding: %v", encoding)
+}
+
+// QueryShortChanIDs is a message that allows the sender to query a set of
+// channel announcement and channel update messages that correspond to the set
+// of encoded short channel ID's. The encoding of the short channel ID's is
+// detailed in the query message ensuring that the receiver knows how to
+// properly decode each encode short channel ID which may be encoded using a
+// compression format. The receiver should respond with a series of channel
+// announcement and channel updates, finally sending a ReplyShortChanIDsEnd
+// message.
+type QueryShortChanIDs struct {
+	// ChainHash denotes the target chain that we're querying for the
+	// channel ID's of.
+	ChainHash chainhash.Hash
+
+	// EncodingType is a signal to the receiver of the message that
+	// indicates exactly how the set of short channel ID's that follow
+	// have been encoded.
+	EncodingType ShortChanIDEncoding
+
+	// ShortChanIDs is a slice of decoded short channel ID's.
+	ShortChanIDs []ShortChannelID
+}
+
+// NewQueryShortChanIDs creates a new QueryShortChanIDs message.
+func NewQueryShortChanIDs(h chainhash.Hash, e ShortChanIDEncoding,
+	s []ShortChannelID) *QueryShortChanIDs {
+
+	return &QueryShortChanIDs{
+		ChainHash:    h,
+		EncodingType: e,
+		ShortChanIDs: s,
+	}
+}
+
+// A compile time check to ensure QueryShortChanIDs implements the
+// lnwire.Message interface.
+var _ Message = (*QueryShortChanIDs)(nil)
+
+// Decode deserializes a serialized QueryShortChanIDs message stored in the
+// passed io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Decode(r io.Reader, pver uint32) error {
+	err := readElements(r, q.ChainHash[:])
+	if err != nil {
+		return err
+	}
+
+	q.EncodingType, q.ShortChanIDs, err = decodeShortChanIDs(r)
+
+	return err
+}
+
+// decodeShortChanIDs decodes a set of short channel ID's that have been
+// encoded. The first byte of the body details how the short chan ID's were
+// encoded. We'll use this type to govern exactly how we go about encoding the
+// set of short channel ID's.
+func decodeShortChanIDs(r io.Reader) (ShortChanIDEncoding, []ShortChannelID,
+	error) {
+
+	// First, we'll attempt to read the number of bytes in the body of the
+	// set of encoded short channel ID's.
+	var numBytesResp uint16
+	err := readElements(r, &numBytesResp)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if numBytesResp == 0 {
+		return 0, nil, fmt.Errorf("no encoding type specified")
+	}
+
+	queryBody := make([]byte, numBytesResp)
+	if _, err := io.ReadFull(r, queryBody); err != nil {
+		return 0, nil, err
+	}
+
+	// The first byte is the encoding type, so we'll extract that so we can
+	// continue our parsing.
+	encodingType := ShortChanIDEncoding(queryBody[0])
+
+	// Before continuing, we'll snip off the first byte of the query body
+	// as that was just the encoding type.
+	queryBody = queryBody[1:]
+
+	// If after extracting the encoding type, then number of remaining
+	// bytes is zero, then the set of short channel ID's is empty.
+	if len(queryBody) == 0 {
+		switch encodingType {
+		case EncodingSortedPlain, EncodingSortedZlib:
+			return encodingType, nil, nil
+		default:
+			return 0, nil, ErrUnknownShortChanIDEncoding(encodingType)
+		}
+	}
+
+	var bodyReader io.Reader
+	switch encodingType {
+
+	// In this encoding, we'll simply read a sort array of encoded short
+	// channel ID's from the buffer.
+	case EncodingSortedPlain:
+		// If after extracting the encoding type, the number of
+		// remaining bytes instead a multiple of the size of an encoded
+		// short channel ID (8 bytes), then we'll return a parsing
+		// error.
+		if len(queryBody)%8 != 0 {
+			return 0, nil, fmt.Errorf("whole number of short chan "+
+				"ID's cannot be encoded in len=%v",
+				len(queryBody))
+		}
+
+		bodyReader = bytes.NewReader(queryBody)
+
+	// In this encoding, we'll use zlib to decode the compressed payload.
+	// However, we'll pay attention to ensure that we don't open our selves
+	// up to a memory exhaustion attack.
+	case EncodingSortedZlib:
+		zlibReader, err := zlib.NewReader(bytes.NewReader(queryBody))
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to create zlib "+
+				"reader: %v", err)
+		}
+		defer zlibReader.Close()
+
+		// We'll limit the number of bytes we're willing to inflate in
+		// order to not allocate an unbounded amount of memory for a
+		// single query.
+		bodyReader = io.LimitReader(zlibReader, maxZlibBufSize)
+
+	default:
+		// If we've been sent an encoding type that we don't know of,
+		// then we'll return a parsing error as we can't continue if
+		// we're unable to encode them.
+		return 0, nil, ErrUnknownShortChanIDEncoding(encodingType)
+	}
+
+	// We'll now read out each short channel ID until we reach the end of
+	// the body, ensuring that each one is larger than the last, as the
+	// set must be sorted.
+	var shortChanIDs []ShortChannelID
+	for {
+		var cid ShortChannelID
+		err := readElements(bodyReader, &cid)
+		switch {
+		case err == io.EOF:
+			return encodingType, shortChanIDs, nil
+
+		case err != nil:
+			return 0, nil, fmt.Errorf("unable to parse short chan "+
+				"ID: %v", err)
+		}
+
+		numIDs := len(shortChanIDs)
+		if numIDs > 0 {
+			prevID := shortChanIDs[numIDs-1]
+			if cid.ToUint64() < prevID.ToUint64() {
+				return 0, nil, fmt.Errorf("short chan ID's "+
+					"aren't sorted: %v is before %v",
+					prevID, cid)
+			}
+		}
+
+		shortChanIDs = append(shortChanIDs, cid)
+	}
+}
+
+// Encode serializes the target QueryShortChanIDs into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) Encode(w io.Writer, pver uint32) error {
+	// First, we'll write out the chain hash.
+	err := writeElements(w, q.ChainHash[:])
+	if err != nil {
+		return err
+	}
+
+	// Base on our encoding type, we'll write out the set of short channel
+	// ID's.
+	return encodeShortChanIDs(w, q.EncodingType, q.ShortChanIDs)
+}
+
+// encodeShortChanIDs encodes the passed short channel ID's into the passed
+// io.Writer, respecting the specified encoding type. The passed set of short
+// channel ID's are sorted in place before being encoded.
+func encodeShortChanIDs(w io.Writer, encodingType ShortChanIDEncoding,
+	shortChanIDs []ShortChannelID) error {
+
+	// Both encodings require the set of short channel ID's to be sorted in
+	// ascending order, so we'll ensure that's the case first.
+	sort.Slice(shortChanIDs, func(i, j int) bool {
+		return shortChanIDs[i].ToUint64() < shortChanIDs[j].ToUint64()
+	})
+
+	var body bytes.Buffer
+	switch encodingType {
+
+	// In this encoding, we'll simply write a sorted array of encoded short
+	// channel ID's.
+	case EncodingSortedPlain:
+		for _, chanID := range shortChanIDs {
+			if err := writeElements(&body, chanID); err != nil {
+				return err
+			}
+		}
+
+	// For this encoding we'll first write out a serialized version of all
+	// the channel ID's into a buffer, then zlib encode that. The final
+	// body is the compressed payload.
+	case EncodingSortedZlib:
+		// An empty set of short channel ID's is signalled by the
+		// encoding type alone.
+		if len(shortChanIDs) == 0 {
+			break
+		}
+
+		zlibWriter := zlib.NewWriter(&body)
+		for _, chanID := range shortChanIDs {
+			err := writeElements(zlibWriter, chanID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Now that we've written all the elements, we'll ensure the
+		// compressed stream is written to the underlying buffer.
+		if err := zlibWriter.Close(); err != nil {
+			return err
+		}
+
+	default:
+		// If we're trying to encode with an encoding type that we
+		// don't know of, then we'll exit early with an error.
+		return ErrUnknownShortChanIDEncoding(encodingType)
+	}
+
+	// The body is prefixed by its length, which also accounts for the
+	// encoding type byte.
+	numBytesBody := 1 + body.Len()
+	if numBytesBody > MaxMessagePayload {
+		return fmt.Errorf("encoded short chan ID's exceed max "+
+			"payload size: %v", numBytesBody)
+	}
+
+	err := writeElements(w, uint16(numBytesBody), uint8(encodingType))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(body.Bytes())
+	return err
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MsgType() MessageType {
+	return MsgQueryShortChanIDs
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// QueryShortChanIDs complete message observing the specified protocol
+// version.
+//
+// This is part of the lnwire.Message interface.
+func (q *QueryShortChanIDs) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
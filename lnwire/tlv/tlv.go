@@ -0,0 +1,328 @@
+// Package tlv implements the type-length-value (TLV) encoding used within the
+// Lightning Network for extensible message and onion payloads. A TLV stream is
+// a sequence of records, each consisting of a type, the length of its value,
+// and the value itself. Both the type and the length are encoded as BigSize
+// integers, and the records of a stream are sorted by strictly increasing
+// type.
+package tlv
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	// ErrNonCanonicalBigSize is returned when a BigSize integer is decoded
+	// that wasn't encoded using the smallest possible number of bytes.
+	ErrNonCanonicalBigSize = errors.New("decoded BigSize is not canonical")
+
+	// ErrRecordsNotSorted is returned when the records of a stream aren't
+	// sorted by strictly increasing type. This also covers streams that
+	// hold more than one record of the same type.
+	ErrRecordsNotSorted = errors.New("tlv records not sorted by " +
+		"strictly increasing type")
+
+	// ErrRecordTooLarge is returned when the length of a record exceeds
+	// the maximum allowed record length.
+	ErrRecordTooLarge = errors.New("tlv record too large")
+)
+
+// MaxRecordSize is the maximum length of the value of a single record.
+// Records are only used within messages and onion payloads, neither of which
+// can exceed this size.
+const MaxRecordSize = math.MaxUint16
+
+// Type is the type of a TLV record. Following the "it's OK to be odd" rule,
+// records of an even type must be understood by the reader of a stream, while
+// records of an odd type may be ignored if unknown.
+type Type uint64
+
+// IsRequired returns whether the reader of a stream must understand records
+// of this type.
+func (t Type) IsRequired() bool {
+	return t%2 == 0
+}
+
+// Record is a single record of a TLV stream.
+type Record struct {
+	// Type is the type of the record, which determines how its value is
+	// to be interpreted.
+	Type Type
+
+	// Value is the raw value of the record.
+	Value []byte
+}
+
+// WriteBigSize writes the passed integer as a BigSize, which is encoded like
+// the varint of the Bitcoin protocol, but in big-endian byte order.
+func WriteBigSize(w io.Writer, val uint64) error {
+	var buf [9]byte
+
+	var b []byte
+	switch {
+	case val < 0xfd:
+		buf[0] = uint8(val)
+		b = buf[:1]
+
+	case val <= math.MaxUint16:
+		buf[0] = 0xfd
+		binary.BigEndian.PutUint16(buf[1:3], uint16(val))
+		b = buf[:3]
+
+	case val <= math.MaxUint32:
+		buf[0] = 0xfe
+		binary.BigEndian.PutUint32(buf[1:5], uint32(val))
+		b = buf[:5]
+
+	default:
+		buf[0] = 0xff
+		binary.BigEndian.PutUint64(buf[1:], val)
+		b = buf[:]
+	}
+
+	_, err := w.Write(b)
+	return err
+}
+
+// ReadBigSize reads a BigSize integer from the passed reader. An error is
+// returned if the integer wasn't encoded canonically.
+func ReadBigSize(r io.Reader) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:1]); err != nil {
+		return 0, err
+	}
+
+	switch buf[0] {
+	case 0xfd:
+		if _, err := io.ReadFull(r, buf[:2]); err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		val := uint64(binary.BigEndian.Uint16(buf[:2]))
+		if val < 0xfd {
+			return 0, ErrNonCanonicalBigSize
+		}
+		return val, nil
+
+	case 0xfe:
+		if _, err := io.ReadFull(r, buf[:4]); err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		val := uint64(binary.BigEndian.Uint32(buf[:4]))
+		if val <= math.MaxUint16 {
+			return 0, ErrNonCanonicalBigSize
+		}
+		return val, nil
+
+	case 0xff:
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		val := binary.BigEndian.Uint64(buf[:])
+		if val <= math.MaxUint32 {
+			return 0, ErrNonCanonicalBigSize
+		}
+		return val, nil
+
+	default:
+		return uint64(buf[0]), nil
+	}
+}
+
+// BigSizeLen returns the number of bytes needed to encode the passed integer
+// as a BigSize.
+func BigSizeLen(val uint64) int {
+	switch {
+	case val < 0xfd:
+		return 1
+	case val <= math.MaxUint16:
+		return 3
+	case val <= math.MaxUint32:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// unexpectedEOF converts an io.EOF encountered in the middle of a value into
+// an io.ErrUnexpectedEOF, as the value was cut short.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Encode writes the passed records to the passed writer as a TLV stream. The
+// records MUST be sorted by strictly increasing type.
+func Encode(w io.Writer, records []Record) error {
+	for i, record := range records {
+		if i > 0 && record.Type <= records[i-1].Type {
+			return ErrRecordsNotSorted
+		}
+		if len(record.Value) > MaxRecordSize {
+			return ErrRecordTooLarge
+		}
+
+		if err := WriteBigSize(w, uint64(record.Type)); err != nil {
+			return err
+		}
+		err := WriteBigSize(w, uint64(len(record.Value)))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(record.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode reads a TLV stream from the passed reader until it's exhausted. An
+// error is returned if the records of the stream aren't sorted by strictly
+// increasing type, or the stream ends in the middle of a record.
+func Decode(r io.Reader) ([]Record, error) {
+	var records []Record
+	for {
+		recordType, err := ReadBigSize(r)
+		switch {
+		// The stream may only end in between records.
+		case err == io.EOF:
+			return records, nil
+
+		case err != nil:
+			return nil, err
+		}
+
+		length, err := ReadBigSize(r)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if length > MaxRecordSize {
+			return nil, ErrRecordTooLarge
+		}
+
+		value := make([]byte, length)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+
+		record := Record{
+			Type:  Type(recordType),
+			Value: value,
+		}
+		if len(records) > 0 &&
+			record.Type <= records[len(records)-1].Type {
+
+			return nil, ErrRecordsNotSorted
+		}
+
+		records = append(records, record)
+	}
+}
+
+// NewTUint64Record creates a record holding the passed integer as a truncated
+// uint64. A truncated integer is encoded in big-endian byte order, with its
+// leading zero bytes omitted.
+func NewTUint64Record(t Type, val uint64) Record {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], val)
+
+	i := 0
+	for i < len(b) && b[i] == 0 {
+		i++
+	}
+
+	return Record{
+		Type:  t,
+		Value: b[i:],
+	}
+}
+
+// TUint64 interprets the value of the record as a truncated uint64. An error
+// is returned if the value is too long or not minimally encoded.
+func (r *Record) TUint64() (uint64, error) {
+	return r.truncatedUint(8)
+}
+
+// NewTUint32Record creates a record holding the passed integer as a truncated
+// uint32.
+func NewTUint32Record(t Type, val uint32) Record {
+	return NewTUint64Record(t, uint64(val))
+}
+
+// TUint32 interprets the value of the record as a truncated uint32. An error
+// is returned if the value is too long or not minimally encoded.
+func (r *Record) TUint32() (uint32, error) {
+	val, err := r.truncatedUint(4)
+	return uint32(val), err
+}
+
+// truncatedUint interprets the value of the record as a truncated integer of
+// at most maxLen bytes.
+func (r *Record) truncatedUint(maxLen int) (uint64, error) {
+	if len(r.Value) > maxLen {
+		return 0, fmt.Errorf("truncated integer of type %d has "+
+			"length %d, exceeding %d", r.Type, len(r.Value), maxLen)
+	}
+	if len(r.Value) > 0 && r.Value[0] == 0 {
+		return 0, fmt.Errorf("truncated integer of type %d is not "+
+			"minimally encoded", r.Type)
+	}
+
+	var val uint64
+	for _, b := range r.Value {
+		val = val<<8 | uint64(b)
+	}
+
+	return val, nil
+}
+
+// NewUint64Record creates a record holding the passed integer as a fixed
+// size, big-endian uint64.
+func NewUint64Record(t Type, val uint64) Record {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, val)
+
+	return Record{
+		Type:  t,
+		Value: value,
+	}
+}
+
+// Uint64 interprets the value of the record as a fixed size, big-endian
+// uint64.
+func (r *Record) Uint64() (uint64, error) {
+	if len(r.Value) != 8 {
+		return 0, fmt.Errorf("uint64 of type %d has length %d",
+			r.Type, len(r.Value))
+	}
+
+	return binary.BigEndian.Uint64(r.Value), nil
+}
+
+// NewBytes32Record creates a record holding the passed 32 bytes.
+func NewBytes32Record(t Type, val [32]byte) Record {
+	value := make([]byte, 32)
+	copy(value, val[:])
+
+	return Record{
+		Type:  t,
+		Value: value,
+	}
+}
+
+// Bytes32 interprets the value of the record as 32 bytes.
+func (r *Record) Bytes32() ([32]byte, error) {
+	var val [32]byte
+	if len(r.Value) != len(val) {
+		return val, fmt.Errorf("32 byte value of type %d has length "+
+			"%d", r.Type, len(r.Value))
+	}
+	copy(val[:], r.Value)
+
+	return val, nil
+}
@@ -0,0 +1,323 @@
+package tlv
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestBigSizeEncoding tests that integers are encoded as BigSize using the
+// expected number of bytes, and decode to the same integer.
+func TestBigSizeEncoding(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val     uint64
+		encoded []byte
+	}{
+		{
+			val:     0,
+			encoded: []byte{0x00},
+		},
+		{
+			val:     0xfc,
+			encoded: []byte{0xfc},
+		},
+		{
+			val:     0xfd,
+			encoded: []byte{0xfd, 0x00, 0xfd},
+		},
+		{
+			val:     math.MaxUint16,
+			encoded: []byte{0xfd, 0xff, 0xff},
+		},
+		{
+			val:     math.MaxUint16 + 1,
+			encoded: []byte{0xfe, 0x00, 0x01, 0x00, 0x00},
+		},
+		{
+			val:     math.MaxUint32,
+			encoded: []byte{0xfe, 0xff, 0xff, 0xff, 0xff},
+		},
+		{
+			val: math.MaxUint32 + 1,
+			encoded: []byte{
+				0xff, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+				0x00,
+			},
+		},
+		{
+			val: math.MaxUint64,
+			encoded: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0xff,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		var b bytes.Buffer
+		if err := WriteBigSize(&b, test.val); err != nil {
+			t.Fatalf("unable to write %v: %v", test.val, err)
+		}
+		if !bytes.Equal(b.Bytes(), test.encoded) {
+			t.Fatalf("expected %v to be encoded as %x, got %x",
+				test.val, test.encoded, b.Bytes())
+		}
+		if BigSizeLen(test.val) != len(test.encoded) {
+			t.Fatalf("expected length %v for %v, got %v",
+				len(test.encoded), test.val,
+				BigSizeLen(test.val))
+		}
+
+		val, err := ReadBigSize(&b)
+		if err != nil {
+			t.Fatalf("unable to read %x: %v", test.encoded, err)
+		}
+		if val != test.val {
+			t.Fatalf("expected %v, got %v", test.val, val)
+		}
+	}
+}
+
+// TestBigSizeDecodingErrors tests that non-canonical and truncated BigSize
+// integers are rejected.
+func TestBigSizeDecodingErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		encoded []byte
+		err     error
+	}{
+		{
+			encoded: []byte{},
+			err:     io.EOF,
+		},
+		{
+			encoded: []byte{0xfd, 0x00, 0xfc},
+			err:     ErrNonCanonicalBigSize,
+		},
+		{
+			encoded: []byte{0xfe, 0x00, 0x00, 0xff, 0xff},
+			err:     ErrNonCanonicalBigSize,
+		},
+		{
+			encoded: []byte{
+				0xff, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff,
+				0xff,
+			},
+			err: ErrNonCanonicalBigSize,
+		},
+		{
+			encoded: []byte{0xfd, 0x00},
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			encoded: []byte{0xfe, 0xff, 0xff},
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			encoded: []byte{0xff, 0xff, 0xff, 0xff, 0xff},
+			err:     io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, test := range testCases {
+		_, err := ReadBigSize(bytes.NewReader(test.encoded))
+		if err != test.err {
+			t.Fatalf("expected error %v for %x, got %v", test.err,
+				test.encoded, err)
+		}
+	}
+}
+
+// TestStreamEncoding tests that a TLV stream is encoded as expected, and
+// decodes to the same records.
+func TestStreamEncoding(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		NewTUint64Record(2, 1000),
+		NewTUint32Record(4, 0),
+		NewUint64Record(6, 0x0102030405060708),
+		{
+			Type:  0xfd,
+			Value: []byte{0xaa},
+		},
+	}
+	encoded := []byte{
+		0x02, 0x02, 0x03, 0xe8,
+		0x04, 0x00,
+		0x06, 0x08, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0xfd, 0x00, 0xfd, 0x01, 0xaa,
+	}
+
+	var b bytes.Buffer
+	if err := Encode(&b, records); err != nil {
+		t.Fatalf("unable to encode records: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), encoded) {
+		t.Fatalf("expected stream %x, got %x", encoded, b.Bytes())
+	}
+
+	decoded, err := Decode(&b)
+	if err != nil {
+		t.Fatalf("unable to decode stream: %v", err)
+	}
+
+	// The empty value is decoded as an empty, rather than a nil slice.
+	records[1].Value = []byte{}
+	if !reflect.DeepEqual(records, decoded) {
+		t.Fatalf("record mismatch: expected %v, got %v",
+			spew.Sdump(records), spew.Sdump(decoded))
+	}
+
+	// An empty stream should decode without any records.
+	decoded, err = Decode(bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("unable to decode empty stream: %v", err)
+	}
+	if len(decoded) != 0 {
+		t.Fatalf("expected no records, got %v", len(decoded))
+	}
+}
+
+// TestStreamErrors tests that invalid TLV streams are rejected, both when
+// encoding and decoding.
+func TestStreamErrors(t *testing.T) {
+	t.Parallel()
+
+	unsorted := []Record{
+		NewTUint64Record(4, 1),
+		NewTUint64Record(2, 1),
+	}
+	if err := Encode(&bytes.Buffer{}, unsorted); err != ErrRecordsNotSorted {
+		t.Fatalf("expected ErrRecordsNotSorted, got %v", err)
+	}
+
+	duplicate := []Record{
+		NewTUint64Record(2, 1),
+		NewTUint64Record(2, 1),
+	}
+	if err := Encode(&bytes.Buffer{}, duplicate); err != ErrRecordsNotSorted {
+		t.Fatalf("expected ErrRecordsNotSorted, got %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		encoded []byte
+		err     error
+	}{
+		{
+			name:    "unsorted",
+			encoded: []byte{0x04, 0x01, 0x01, 0x02, 0x01, 0x01},
+			err:     ErrRecordsNotSorted,
+		},
+		{
+			name:    "duplicate",
+			encoded: []byte{0x02, 0x01, 0x01, 0x02, 0x01, 0x01},
+			err:     ErrRecordsNotSorted,
+		},
+		{
+			name:    "missing length",
+			encoded: []byte{0x02},
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "truncated value",
+			encoded: []byte{0x02, 0x02, 0x01},
+			err:     io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "non-canonical type",
+			encoded: []byte{0xfd, 0x00, 0x02, 0x00},
+			err:     ErrNonCanonicalBigSize,
+		},
+		{
+			name: "record too large",
+			encoded: []byte{
+				0x02, 0xfe, 0x00, 0x01, 0x00, 0x00,
+			},
+			err: ErrRecordTooLarge,
+		},
+	}
+
+	for _, test := range testCases {
+		_, err := Decode(bytes.NewReader(test.encoded))
+		if err != test.err {
+			t.Fatalf("%v: expected error %v, got %v", test.name,
+				test.err, err)
+		}
+	}
+}
+
+// TestTruncatedIntegers tests that truncated integers are encoded minimally,
+// and that invalid encodings are rejected.
+func TestTruncatedIntegers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		val     uint64
+		encoded []byte
+	}{
+		{
+			val:     0,
+			encoded: []byte{},
+		},
+		{
+			val:     1,
+			encoded: []byte{0x01},
+		},
+		{
+			val:     0x100,
+			encoded: []byte{0x01, 0x00},
+		},
+		{
+			val: math.MaxUint64,
+			encoded: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		record := NewTUint64Record(1, test.val)
+		if !bytes.Equal(record.Value, test.encoded) {
+			t.Fatalf("expected %v to be encoded as %x, got %x",
+				test.val, test.encoded, record.Value)
+		}
+
+		val, err := record.TUint64()
+		if err != nil {
+			t.Fatalf("unable to decode %x: %v", test.encoded, err)
+		}
+		if val != test.val {
+			t.Fatalf("expected %v, got %v", test.val, val)
+		}
+	}
+
+	record := NewTUint32Record(1, math.MaxUint32)
+	val, err := record.TUint32()
+	if err != nil {
+		t.Fatalf("unable to decode %x: %v", record.Value, err)
+	}
+	if val != math.MaxUint32 {
+		t.Fatalf("expected %v, got %v", uint32(math.MaxUint32), val)
+	}
+
+	// A truncated uint32 can't be longer than four bytes.
+	record = NewTUint64Record(1, math.MaxUint32+1)
+	if _, err := record.TUint32(); err == nil {
+		t.Fatalf("expected oversized uint32 to be rejected")
+	}
+
+	// Leading zero bytes aren't allowed.
+	record = Record{Type: 1, Value: []byte{0x00, 0x01}}
+	if _, err := record.TUint64(); err == nil {
+		t.Fatalf("expected non-minimal encoding to be rejected")
+	}
+}
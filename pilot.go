@@ -158,7 +158,7 @@ func initAutoPilot(svr *server, cfg *autoPilotConfig) (*autopilot.Agent, error)
 		WalletBalance: func() (btcutil.Amount, error) {
 			return svr.cc.wallet.ConfirmedBalance(1)
 		},
-		Graph:           autopilot.ChannelGraphFromDatabase(svr.chanDB.ChannelGraph()),
+		Graph:           autopilot.ChannelGraphFromSource(svr.chanRouter),
 		MaxPendingOpens: 10,
 	}
 
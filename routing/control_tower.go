@@ -0,0 +1,370 @@
+package routing
+
+import (
+	"sync"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/multimutex"
+)
+
+// ControlTower tracks all outgoing payments made, whose primary purpose is to
+// prevent duplicate payments to the same payment hash. In production, a
+// persistent implementation is preferred so that tracking can survive across
+// restarts. Payments are transitioned through various payment states, and the
+// ControlTower interface provides access to driving the state transitions.
+type ControlTower interface {
+	// InitPayment atomically moves the payment into the InFlight state.
+	// This method checks that no succeeded or in-flight payment exists for
+	// this payment hash.
+	InitPayment([32]byte, *channeldb.PaymentCreationInfo) error
+
+	// RegisterAttempt atomically records the provided HTLCAttemptInfo.
+	// This MUST be called before the HTLC is handed to the switch.
+	RegisterAttempt([32]byte, *channeldb.HTLCAttemptInfo) error
+
+	// SettleAttempt marks the given attempt settled with the preimage,
+	// which implicitly means the payment as a whole succeeded.
+	SettleAttempt([32]byte, uint64, *channeldb.HTLCSettleInfo) (
+		*channeldb.Payment, error)
+
+	// FailAttempt marks the given payment attempt failed.
+	FailAttempt([32]byte, uint64, *channeldb.HTLCFailInfo) (
+		*channeldb.Payment, error)
+
+	// Fail records the ultimate reason the payment failed. No new
+	// attempts can be registered for the payment afterwards, and the
+	// payment transitions into the Failed state once all of its attempts
+	// have been failed. At that point InitPayment will return nil on its
+	// next call for this payment hash, allowing the user to make a
+	// subsequent payment.
+	Fail([32]byte, channeldb.FailureReason) (*channeldb.Payment, error)
+
+	// FetchPayment returns the current record of the payment to the
+	// given payment hash.
+	FetchPayment([32]byte) (*channeldb.Payment, error)
+
+	// FetchInFlightPayments returns all payments with status InFlight.
+	FetchInFlightPayments() ([]*channeldb.Payment, error)
+
+	// SubscribePayment subscribes to updates of the payment to the given
+	// payment hash. The current record of the payment is always delivered
+	// as the first update.
+	SubscribePayment([32]byte) (*PaymentSubscription, error)
+}
+
+// PaymentSubscription is a subscription to the updates of a single payment
+// tracked by the control tower.
+type PaymentSubscription struct {
+	// Updates is the channel over which the record of the payment is
+	// delivered each time it changes, in the order the changes were made.
+	// The channel is closed once the payment has reached a final state,
+	// or the subscription is canceled.
+	Updates <-chan *channeldb.Payment
+
+	updates chan *channeldb.Payment
+
+	// queue holds the updates that haven't yet been delivered, such that
+	// the control tower never blocks on a slow subscriber. final is set
+	// once the last update has been queued.
+	queue    []*channeldb.Payment
+	final    bool
+	queueMtx sync.Mutex
+
+	// queueSignal is signaled each time the queue is modified.
+	queueSignal chan struct{}
+
+	paymentHash [32]byte
+	id          uint64
+	tower       *controlTower
+
+	cancelOnce sync.Once
+	quit       chan struct{}
+}
+
+// enqueue queues the passed update for delivery to the subscriber. If final
+// is set, the subscription is closed after the update has been delivered.
+func (s *PaymentSubscription) enqueue(payment *channeldb.Payment, final bool) {
+	s.queueMtx.Lock()
+	s.queue = append(s.queue, payment)
+	s.final = final
+	s.queueMtx.Unlock()
+
+	select {
+	case s.queueSignal <- struct{}{}:
+	default:
+	}
+}
+
+// deliverUpdates delivers the queued updates to the subscriber, in the order
+// they were queued.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *PaymentSubscription) deliverUpdates() {
+	defer close(s.updates)
+
+	for {
+		s.queueMtx.Lock()
+		if len(s.queue) == 0 {
+			final := s.final
+			s.queueMtx.Unlock()
+
+			if final {
+				return
+			}
+
+			select {
+			case <-s.queueSignal:
+				continue
+			case <-s.quit:
+				return
+			}
+		}
+
+		next := s.queue[0]
+		s.queue[0] = nil
+		s.queue = s.queue[1:]
+		s.queueMtx.Unlock()
+
+		select {
+		case s.updates <- next:
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Cancel cancels the subscription, after which no more updates are
+// delivered.
+func (s *PaymentSubscription) Cancel() {
+	s.cancelOnce.Do(func() {
+		s.tower.unsubscribe(s)
+		close(s.quit)
+	})
+}
+
+// controlTower is persistent implementation of ControlTower to restrict
+// double payment sending.
+type controlTower struct {
+	db *channeldb.PaymentControl
+
+	// subscribers holds the active subscriptions for each payment hash,
+	// indexed by their ID.
+	subscribers      map[[32]byte]map[uint64]*PaymentSubscription
+	nextSubscriberID uint64
+	subscribersMtx   sync.Mutex
+
+	// paymentsMtx ensures that the updates of a single payment are written
+	// to the database and delivered to its subscribers in the same order.
+	paymentsMtx *multimutex.HashMutex
+}
+
+// A compile time check to ensure controlTower implements the ControlTower
+// interface.
+var _ ControlTower = (*controlTower)(nil)
+
+// NewControlTower creates a new instance of the controlTower.
+func NewControlTower(db *channeldb.PaymentControl) ControlTower {
+	return &controlTower{
+		db: db,
+		subscribers: make(
+			map[[32]byte]map[uint64]*PaymentSubscription,
+		),
+		paymentsMtx: multimutex.NewHashMutex(),
+	}
+}
+
+// InitPayment checks or records the given PaymentCreationInfo with the DB,
+// making sure it does not already exist as an in-flight payment. When this
+// method returns successfully, the payment is guaranteed to be in the InFlight
+// state.
+func (p *controlTower) InitPayment(paymentHash [32]byte,
+	info *channeldb.PaymentCreationInfo) error {
+
+	return p.db.InitPayment(paymentHash, info)
+}
+
+// RegisterAttempt atomically records the provided HTLCAttemptInfo to the DB.
+func (p *controlTower) RegisterAttempt(paymentHash [32]byte,
+	attempt *channeldb.HTLCAttemptInfo) error {
+
+	p.paymentsMtx.Lock(paymentHash)
+	defer p.paymentsMtx.Unlock(paymentHash)
+
+	payment, err := p.db.RegisterAttempt(paymentHash, attempt)
+	if err != nil {
+		return err
+	}
+
+	p.notifySubscribers(paymentHash, payment)
+
+	return nil
+}
+
+// SettleAttempt marks the given attempt settled with the preimage.
+func (p *controlTower) SettleAttempt(paymentHash [32]byte,
+	attemptID uint64, settleInfo *channeldb.HTLCSettleInfo) (
+	*channeldb.Payment, error) {
+
+	p.paymentsMtx.Lock(paymentHash)
+	defer p.paymentsMtx.Unlock(paymentHash)
+
+	payment, err := p.db.SettleAttempt(paymentHash, attemptID, settleInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	p.notifySubscribers(paymentHash, payment)
+
+	return payment, nil
+}
+
+// FailAttempt marks the given payment attempt failed.
+func (p *controlTower) FailAttempt(paymentHash [32]byte,
+	attemptID uint64, failInfo *channeldb.HTLCFailInfo) (
+	*channeldb.Payment, error) {
+
+	p.paymentsMtx.Lock(paymentHash)
+	defer p.paymentsMtx.Unlock(paymentHash)
+
+	payment, err := p.db.FailAttempt(paymentHash, attemptID, failInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	p.notifySubscribers(paymentHash, payment)
+
+	return payment, nil
+}
+
+// Fail transitions a payment into the Failed state, and records the reason
+// the payment failed.
+func (p *controlTower) Fail(paymentHash [32]byte,
+	reason channeldb.FailureReason) (*channeldb.Payment, error) {
+
+	p.paymentsMtx.Lock(paymentHash)
+	defer p.paymentsMtx.Unlock(paymentHash)
+
+	payment, err := p.db.Fail(paymentHash, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	p.notifySubscribers(paymentHash, payment)
+
+	return payment, nil
+}
+
+// FetchPayment returns the current record of the payment to the given
+// payment hash.
+func (p *controlTower) FetchPayment(paymentHash [32]byte) (
+	*channeldb.Payment, error) {
+
+	return p.db.FetchPayment(paymentHash)
+}
+
+// FetchInFlightPayments returns all payments with status InFlight.
+func (p *controlTower) FetchInFlightPayments() ([]*channeldb.Payment, error) {
+	return p.db.FetchInFlightPayments()
+}
+
+// SubscribePayment subscribes to updates of the payment to the given payment
+// hash. The current record of the payment is delivered as the first update,
+// and if the payment already reached a final state, the subscription is
+// closed right after.
+func (p *controlTower) SubscribePayment(paymentHash [32]byte) (
+	*PaymentSubscription, error) {
+
+	// We hold the payment's mutex while fetching its current record and
+	// registering the subscription, such that no update can be missed in
+	// between.
+	p.paymentsMtx.Lock(paymentHash)
+	defer p.paymentsMtx.Unlock(paymentHash)
+
+	payment, err := p.db.FetchPayment(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan *channeldb.Payment)
+	subscription := &PaymentSubscription{
+		Updates:     updates,
+		updates:     updates,
+		queueSignal: make(chan struct{}, 1),
+		paymentHash: paymentHash,
+		tower:       p,
+		quit:        make(chan struct{}),
+	}
+
+	final := isFinalPayment(payment)
+	subscription.enqueue(payment, final)
+
+	// Only payments that haven't yet reached a final state will receive
+	// further updates.
+	if !final {
+		p.subscribersMtx.Lock()
+		subscription.id = p.nextSubscriberID
+		p.nextSubscriberID++
+
+		subscribers, ok := p.subscribers[paymentHash]
+		if !ok {
+			subscribers = make(map[uint64]*PaymentSubscription)
+			p.subscribers[paymentHash] = subscribers
+		}
+		subscribers[subscription.id] = subscription
+		p.subscribersMtx.Unlock()
+	}
+
+	go subscription.deliverUpdates()
+
+	return subscription, nil
+}
+
+// unsubscribe removes the passed subscription from the set of subscribers of
+// its payment.
+func (p *controlTower) unsubscribe(subscription *PaymentSubscription) {
+	p.subscribersMtx.Lock()
+	defer p.subscribersMtx.Unlock()
+
+	subscribers, ok := p.subscribers[subscription.paymentHash]
+	if !ok {
+		return
+	}
+
+	delete(subscribers, subscription.id)
+	if len(subscribers) == 0 {
+		delete(p.subscribers, subscription.paymentHash)
+	}
+}
+
+// notifySubscribers delivers the updated record of the payment to all of its
+// subscribers. If the payment reached a final state, then all subscriptions
+// are closed.
+//
+// NOTE: This method MUST be called with the payment's mutex held.
+func (p *controlTower) notifySubscribers(paymentHash [32]byte,
+	payment *channeldb.Payment) {
+
+	p.subscribersMtx.Lock()
+	defer p.subscribersMtx.Unlock()
+
+	subscribers, ok := p.subscribers[paymentHash]
+	if !ok {
+		return
+	}
+
+	final := isFinalPayment(payment)
+	for _, subscriber := range subscribers {
+		subscriber.enqueue(payment, final)
+	}
+
+	if final {
+		delete(p.subscribers, paymentHash)
+	}
+}
+
+// isFinalPayment returns true if the payment reached a final state, after
+// which its record won't change anymore.
+func isFinalPayment(payment *channeldb.Payment) bool {
+	return payment.Status == channeldb.StatusSucceeded ||
+		payment.Status == channeldb.StatusFailed
+}
@@ -0,0 +1,234 @@
+package routing
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// makeTestControlTower creates a control tower backed by a fresh database,
+// along with a payment to track.
+func makeTestControlTower(t *testing.T) (ControlTower,
+	*channeldb.PaymentCreationInfo, *channeldb.HTLCAttemptInfo,
+	[32]byte, func()) {
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to create test db: %v", err)
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate session key: %v", err)
+	}
+
+	info := &channeldb.PaymentCreationInfo{
+		PaymentHash:  sha256.Sum256(preimage[:]),
+		Value:        1000,
+		CreationDate: time.Unix(time.Now().Unix(), 0),
+	}
+	attempt := &channeldb.HTLCAttemptInfo{
+		AttemptID:  1,
+		SessionKey: sessionKey,
+		Route: channeldb.PaymentRoute{
+			TotalAmount: 1000,
+			Hops: []*channeldb.PaymentHop{
+				{ChannelID: 1, AmtToForward: 1000},
+			},
+		},
+		AttemptTime: time.Unix(time.Now().Unix(), 0),
+	}
+
+	control := NewControlTower(
+		channeldb.NewPaymentControl(graph.Database()),
+	)
+
+	return control, info, attempt, preimage, cleanUp
+}
+
+// assertPaymentUpdate asserts that the next update received on the
+// subscription carries the expected status and number of htlcs.
+func assertPaymentUpdate(t *testing.T, subscription *PaymentSubscription,
+	status channeldb.PaymentStatus, numHtlcs int) *channeldb.Payment {
+
+	t.Helper()
+
+	select {
+	case payment, ok := <-subscription.Updates:
+		if !ok {
+			t.Fatalf("subscription closed unexpectedly")
+		}
+		if payment.Status != status {
+			t.Fatalf("expected status %v, got %v", status,
+				payment.Status)
+		}
+		if len(payment.HTLCs) != numHtlcs {
+			t.Fatalf("expected %v htlcs, got %v", numHtlcs,
+				len(payment.HTLCs))
+		}
+		return payment
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no payment update received")
+	}
+
+	return nil
+}
+
+// assertSubscriptionClosed asserts that the subscription is closed without
+// delivering any further updates.
+func assertSubscriptionClosed(t *testing.T, subscription *PaymentSubscription) {
+	t.Helper()
+
+	select {
+	case payment, ok := <-subscription.Updates:
+		if ok {
+			t.Fatalf("unexpected update: %v", payment.Status)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("subscription not closed")
+	}
+}
+
+// TestControlTowerSubscribeSuccess tests that a subscriber receives every
+// update of a payment until it succeeds, after which the subscription is
+// closed.
+func TestControlTowerSubscribeSuccess(t *testing.T) {
+	t.Parallel()
+
+	control, info, attempt, preimage, cleanUp := makeTestControlTower(t)
+	defer cleanUp()
+
+	// Subscribing to an unknown payment should fail.
+	_, err := control.SubscribePayment(info.PaymentHash)
+	if err != channeldb.ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	if err := control.InitPayment(info.PaymentHash, info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	subscription, err := control.SubscribePayment(info.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer subscription.Cancel()
+
+	// The current state of the payment should be delivered right away.
+	assertPaymentUpdate(t, subscription, channeldb.StatusInFlight, 0)
+
+	// We'll make a first attempt that fails, followed by a second attempt
+	// that succeeds. Each of these should be delivered in order.
+	if err := control.RegisterAttempt(info.PaymentHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	_, err = control.FailAttempt(
+		info.PaymentHash, attempt.AttemptID, &channeldb.HTLCFailInfo{
+			Reason:             channeldb.HTLCFailMessage,
+			Message:            &lnwire.FailUnknownPaymentHash{},
+			FailureSourceIndex: 1,
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to fail attempt: %v", err)
+	}
+
+	attempt.AttemptID++
+	if err := control.RegisterAttempt(info.PaymentHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+	_, err = control.SettleAttempt(
+		info.PaymentHash, attempt.AttemptID,
+		&channeldb.HTLCSettleInfo{Preimage: preimage},
+	)
+	if err != nil {
+		t.Fatalf("unable to settle attempt: %v", err)
+	}
+
+	assertPaymentUpdate(t, subscription, channeldb.StatusInFlight, 1)
+	payment := assertPaymentUpdate(
+		t, subscription, channeldb.StatusInFlight, 1,
+	)
+	failure := payment.HTLCs[0].Failure
+	if failure == nil || failure.FailureSourceIndex != 1 {
+		t.Fatalf("expected failed attempt, got %v", failure)
+	}
+	assertPaymentUpdate(t, subscription, channeldb.StatusInFlight, 2)
+	assertPaymentUpdate(t, subscription, channeldb.StatusSucceeded, 2)
+	assertSubscriptionClosed(t, subscription)
+
+	// A subscriber to the succeeded payment should only receive its
+	// final state.
+	subscription, err = control.SubscribePayment(info.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	assertPaymentUpdate(t, subscription, channeldb.StatusSucceeded, 2)
+	assertSubscriptionClosed(t, subscription)
+}
+
+// TestControlTowerSubscribeFail tests that a subscriber is notified once a
+// payment fails, and that a canceled subscription is closed.
+func TestControlTowerSubscribeFail(t *testing.T) {
+	t.Parallel()
+
+	control, info, attempt, _, cleanUp := makeTestControlTower(t)
+	defer cleanUp()
+
+	if err := control.InitPayment(info.PaymentHash, info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	if err := control.RegisterAttempt(info.PaymentHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+
+	subscription, err := control.SubscribePayment(info.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	canceled, err := control.SubscribePayment(info.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+
+	assertPaymentUpdate(t, subscription, channeldb.StatusInFlight, 1)
+	assertPaymentUpdate(t, canceled, channeldb.StatusInFlight, 1)
+
+	canceled.Cancel()
+	assertSubscriptionClosed(t, canceled)
+
+	// Failing the payment leaves it in flight, as its attempt is still
+	// outstanding. Once the attempt fails, so does the payment.
+	_, err = control.Fail(info.PaymentHash, channeldb.FailureReasonNoRoute)
+	if err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	_, err = control.FailAttempt(
+		info.PaymentHash, attempt.AttemptID, &channeldb.HTLCFailInfo{},
+	)
+	if err != nil {
+		t.Fatalf("unable to fail attempt: %v", err)
+	}
+
+	assertPaymentUpdate(t, subscription, channeldb.StatusInFlight, 1)
+	payment := assertPaymentUpdate(
+		t, subscription, channeldb.StatusFailed, 1,
+	)
+	if *payment.FailureReason != channeldb.FailureReasonNoRoute {
+		t.Fatalf("expected failure reason %v, got %v",
+			channeldb.FailureReasonNoRoute, *payment.FailureReason)
+	}
+	assertSubscriptionClosed(t, subscription)
+}
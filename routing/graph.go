@@ -1,4 +1,102 @@
 package routing
 
-// TODO(roasbeef): abstract out graph to interface
-//  * add in-memory version of graph for tests
+import (
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// routingGraph is an abstract interface that provides the information path
+// finding requires in order to traverse the channel graph. It allows path
+// finding to run against either the in-memory graph cache or the database.
+type routingGraph interface {
+	// forEachNode calls the passed callback for every node within the
+	// graph.
+	forEachNode(cb func(node *channeldb.LightningNode) error) error
+
+	// forEachNodeChannel calls the passed callback for every channel of
+	// the given node that the node has advertised a policy for. The first
+	// policy is the outgoing policy of the node, while the second is the
+	// incoming policy of the node at the other end of the channel, which
+	// is nil if unknown.
+	forEachNodeChannel(node Vertex, cb func(info *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error) error
+
+	// fetchChannel returns the channel with the given ID, along with the
+	// policies of both of its nodes.
+	fetchChannel(chanID uint64) (*channeldb.ChannelEdgeInfo,
+		*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy, error)
+}
+
+// dbRoutingGraph is a routingGraph that's backed by the channel graph within
+// the database. All node traversals share a single read transaction, which
+// gives them a consistent view of the graph.
+type dbRoutingGraph struct {
+	graph *channeldb.ChannelGraph
+	tx    *bolt.Tx
+}
+
+// A compile time check to ensure dbRoutingGraph implements the routingGraph
+// interface.
+var _ routingGraph = (*dbRoutingGraph)(nil)
+
+// newDBRoutingGraph opens a read transaction on the database of the passed
+// graph, and returns a routingGraph that traverses the graph within it. The
+// returned graph must be closed once it's no longer needed.
+func newDBRoutingGraph(graph *channeldb.ChannelGraph) (*dbRoutingGraph, error) {
+	tx, err := graph.Database().Begin(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dbRoutingGraph{
+		graph: graph,
+		tx:    tx,
+	}, nil
+}
+
+// close releases the read transaction of the graph.
+func (g *dbRoutingGraph) close() error {
+	return g.tx.Rollback()
+}
+
+// forEachNode calls the passed callback for every node within the graph.
+//
+// NOTE: Part of the routingGraph interface.
+func (g *dbRoutingGraph) forEachNode(
+	cb func(node *channeldb.LightningNode) error) error {
+
+	return g.graph.ForEachNode(g.tx, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		return cb(node)
+	})
+}
+
+// forEachNodeChannel calls the passed callback for every channel of the given
+// node that the node has advertised a policy for.
+//
+// NOTE: Part of the routingGraph interface.
+func (g *dbRoutingGraph) forEachNodeChannel(node Vertex,
+	cb func(info *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error) error {
+
+	// As we already have an open transaction, the node only needs its
+	// public key in order to traverse its channels.
+	dbNode := &channeldb.LightningNode{PubKeyBytes: node}
+	return dbNode.ForEachChannel(g.tx, func(_ *bolt.Tx,
+		info *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error {
+
+		return cb(info, outPolicy, inPolicy)
+	})
+}
+
+// fetchChannel returns the channel with the given ID, along with the policies
+// of both of its nodes.
+//
+// NOTE: Part of the routingGraph interface.
+func (g *dbRoutingGraph) fetchChannel(chanID uint64) (*channeldb.ChannelEdgeInfo,
+	*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy, error) {
+
+	return g.graph.FetchChannelEdgesByID(chanID)
+}
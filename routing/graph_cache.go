@@ -0,0 +1,395 @@
+package routing
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// cachedChannel is a channel within the graph cache, along with the policies
+// of both of its nodes. The first policy is the one of the first node, and
+// leads to the second node. Either policy is nil if it's unknown.
+type cachedChannel struct {
+	info    *channeldb.ChannelEdgeInfo
+	policy1 *channeldb.ChannelEdgePolicy
+	policy2 *channeldb.ChannelEdgePolicy
+}
+
+// nodes returns the public keys of both nodes of the channel.
+func (c *cachedChannel) nodes() []Vertex {
+	return []Vertex{c.info.NodeKey1Bytes, c.info.NodeKey2Bytes}
+}
+
+// graphCache is an in-memory copy of the channel graph, which allows it to be
+// traversed without the overhead of a database transaction, and without
+// deserializing each node and policy on every access. It's kept in sync with
+// the database by the ChannelRouter, which applies every change it makes to
+// the graph to the cache as well.
+//
+// The cache is safe for concurrent use. All of its contents are treated as
+// immutable: any change replaces the affected entries rather than modifying
+// them, which allows readers to traverse the cache without holding its lock.
+// As a result, the nodes, channels and policies handed out by the cache must
+// never be modified by the caller. As they lack a database reference, they
+// can't be used for database traversals either.
+type graphCache struct {
+	mtx sync.RWMutex
+
+	// nodes holds every node within the graph.
+	nodes map[Vertex]*channeldb.LightningNode
+
+	// channels holds every channel within the graph, keyed by its short
+	// channel ID.
+	channels map[uint64]*cachedChannel
+
+	// nodeChannels holds the channels of each node, sorted by their short
+	// channel ID. The slices are replaced on every change, so they can be
+	// traversed after releasing the lock.
+	nodeChannels map[Vertex][]*cachedChannel
+}
+
+// A compile time check to ensure graphCache implements the routingGraph
+// interface.
+var _ routingGraph = (*graphCache)(nil)
+
+// newGraphCache creates a new graph cache, populated with all nodes and
+// channels within the passed channel graph.
+func newGraphCache(graph *channeldb.ChannelGraph) (*graphCache, error) {
+	c := &graphCache{
+		nodes:        make(map[Vertex]*channeldb.LightningNode),
+		channels:     make(map[uint64]*cachedChannel),
+		nodeChannels: make(map[Vertex][]*cachedChannel),
+	}
+
+	err := graph.ForEachNode(nil, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		c.addNode(node)
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNotFound {
+		return nil, err
+	}
+
+	err = graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		policy1, policy2 *channeldb.ChannelEdgePolicy) error {
+
+		c.addChannel(info)
+		if policy1 != nil {
+			c.updatePolicy(policy1)
+		}
+		if policy2 != nil {
+			c.updatePolicy(policy2)
+		}
+		return nil
+	})
+	switch {
+	case err == channeldb.ErrGraphNotFound:
+	case err == channeldb.ErrGraphNoEdgesFound:
+	case err != nil:
+		return nil, err
+	}
+
+	log.Infof("Populated graph cache with %v nodes and %v channels",
+		len(c.nodes), len(c.channels))
+
+	return c, nil
+}
+
+// addNode adds the node to the cache, replacing any prior version of it.
+func (c *graphCache) addNode(node *channeldb.LightningNode) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	nodeCopy := cacheableNode(node)
+	vertex := Vertex(node.PubKeyBytes)
+	c.nodes[vertex] = nodeCopy
+
+	// The policies leading to the node point to the node itself, so
+	// they'll need to point to its latest version from now on.
+	for _, channel := range c.nodeChannels[vertex] {
+		newChannel := *channel
+		if vertex == channel.info.NodeKey2Bytes {
+			newChannel.policy1 = withNode(channel.policy1, nodeCopy)
+		} else {
+			newChannel.policy2 = withNode(channel.policy2, nodeCopy)
+		}
+		c.replaceChannel(&newChannel)
+	}
+}
+
+// addChannel adds the channel to the cache, without any policies. If any of
+// its nodes isn't known yet, then a node without any announced attributes is
+// added in its place.
+func (c *graphCache) addChannel(info *channeldb.ChannelEdgeInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if _, ok := c.channels[info.ChannelID]; ok {
+		return
+	}
+
+	infoCopy := *info
+	channel := &cachedChannel{
+		info: &infoCopy,
+	}
+	c.channels[info.ChannelID] = channel
+
+	for _, vertex := range []Vertex{info.NodeKey1Bytes, info.NodeKey2Bytes} {
+		if _, ok := c.nodes[vertex]; !ok {
+			c.nodes[vertex] = cacheableNode(
+				&channeldb.LightningNode{PubKeyBytes: vertex},
+			)
+		}
+
+		// We'll insert the channel such that the channels of the node
+		// remain sorted by their ID.
+		channels := c.nodeChannels[vertex]
+		i := sort.Search(len(channels), func(i int) bool {
+			return channels[i].info.ChannelID >= info.ChannelID
+		})
+		newChannels := make([]*cachedChannel, 0, len(channels)+1)
+		newChannels = append(newChannels, channels[:i]...)
+		newChannels = append(newChannels, channel)
+		newChannels = append(newChannels, channels[i:]...)
+		c.nodeChannels[vertex] = newChannels
+	}
+}
+
+// updateChannel replaces the static information of a channel that's already
+// within the cache, such as after its authentication proof became known.
+func (c *graphCache) updateChannel(info *channeldb.ChannelEdgeInfo) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	channel, ok := c.channels[info.ChannelID]
+	if !ok {
+		return
+	}
+
+	infoCopy := *info
+	newChannel := *channel
+	newChannel.info = &infoCopy
+	c.replaceChannel(&newChannel)
+}
+
+// updatePolicy sets the policy of one of the nodes of a channel within the
+// cache. The direction flag of the policy determines which node it belongs
+// to.
+func (c *graphCache) updatePolicy(policy *channeldb.ChannelEdgePolicy) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	channel, ok := c.channels[policy.ChannelID]
+	if !ok {
+		return
+	}
+
+	// The policy of the first node leads to the second node, and vice
+	// versa.
+	newChannel := *channel
+	if policy.Flags&lnwire.ChanUpdateDirection == 0 {
+		toNode := c.nodes[channel.info.NodeKey2Bytes]
+		newChannel.policy1 = withNode(policy, toNode)
+	} else {
+		toNode := c.nodes[channel.info.NodeKey1Bytes]
+		newChannel.policy2 = withNode(policy, toNode)
+	}
+	c.replaceChannel(&newChannel)
+}
+
+// removeChannel removes the channel with the given ID from the cache. The
+// nodes of the channel remain within the cache.
+func (c *graphCache) removeChannel(chanID uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	channel, ok := c.channels[chanID]
+	if !ok {
+		return
+	}
+	delete(c.channels, chanID)
+
+	for _, vertex := range channel.nodes() {
+		channels := c.nodeChannels[vertex]
+		newChannels := make([]*cachedChannel, 0, len(channels))
+		for _, nodeChannel := range channels {
+			if nodeChannel.info.ChannelID == chanID {
+				continue
+			}
+			newChannels = append(newChannels, nodeChannel)
+		}
+		c.nodeChannels[vertex] = newChannels
+	}
+}
+
+// replaceChannel replaces the cached version of a channel with the passed
+// one, both in the channel index and within the channels of its nodes.
+//
+// NOTE: This method MUST be called with the cache's lock held.
+func (c *graphCache) replaceChannel(channel *cachedChannel) {
+	chanID := channel.info.ChannelID
+	c.channels[chanID] = channel
+
+	for _, vertex := range channel.nodes() {
+		channels := c.nodeChannels[vertex]
+		newChannels := make([]*cachedChannel, len(channels))
+		copy(newChannels, channels)
+		for i, nodeChannel := range newChannels {
+			if nodeChannel.info.ChannelID == chanID {
+				newChannels[i] = channel
+				break
+			}
+		}
+		c.nodeChannels[vertex] = newChannels
+	}
+}
+
+// fetchNode returns the node with the given public key, or false if it isn't
+// within the cache.
+func (c *graphCache) fetchNode(vertex Vertex) (*channeldb.LightningNode, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	node, ok := c.nodes[vertex]
+	return node, ok
+}
+
+// forEachNode calls the passed callback for every node within the cache, in
+// the order of their public keys.
+//
+// NOTE: Part of the routingGraph interface.
+func (c *graphCache) forEachNode(
+	cb func(node *channeldb.LightningNode) error) error {
+
+	c.mtx.RLock()
+	nodes := make([]*channeldb.LightningNode, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		nodes = append(nodes, node)
+	}
+	c.mtx.RUnlock()
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(
+			nodes[i].PubKeyBytes[:], nodes[j].PubKeyBytes[:],
+		) < 0
+	})
+
+	for _, node := range nodes {
+		if err := cb(node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachChannel calls the passed callback for every channel within the
+// cache, in the order of their IDs, along with the policies of both of its
+// nodes. Each channel is passed as a copy, as its keys are only parsed once
+// they're first accessed, which would otherwise modify the shared channel.
+func (c *graphCache) forEachChannel(cb func(*channeldb.ChannelEdgeInfo,
+	*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy) error) error {
+
+	c.mtx.RLock()
+	channels := make([]*cachedChannel, 0, len(c.channels))
+	for _, channel := range c.channels {
+		channels = append(channels, channel)
+	}
+	c.mtx.RUnlock()
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].info.ChannelID < channels[j].info.ChannelID
+	})
+
+	for _, channel := range channels {
+		info := *channel.info
+		err := cb(&info, channel.policy1, channel.policy2)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// forEachNodeChannel calls the passed callback for every channel of the given
+// node that the node has advertised a policy for, in the order of their IDs.
+//
+// NOTE: Part of the routingGraph interface.
+func (c *graphCache) forEachNodeChannel(node Vertex,
+	cb func(info *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error) error {
+
+	// As the slice is never modified once it's within the cache, we can
+	// traverse it without holding the lock.
+	c.mtx.RLock()
+	channels := c.nodeChannels[node]
+	c.mtx.RUnlock()
+
+	for _, channel := range channels {
+		outPolicy, inPolicy := channel.policy1, channel.policy2
+		if node == channel.info.NodeKey2Bytes {
+			outPolicy, inPolicy = inPolicy, outPolicy
+		}
+		if outPolicy == nil {
+			continue
+		}
+
+		if err := cb(channel.info, outPolicy, inPolicy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchChannel returns the channel with the given ID, along with the policies
+// of both of its nodes.
+//
+// NOTE: Part of the routingGraph interface.
+func (c *graphCache) fetchChannel(chanID uint64) (*channeldb.ChannelEdgeInfo,
+	*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy, error) {
+
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	channel, ok := c.channels[chanID]
+	if !ok {
+		return nil, nil, nil, channeldb.ErrEdgeNotFound
+	}
+
+	return channel.info, channel.policy1, channel.policy2, nil
+}
+
+// cacheableNode returns a copy of the node that's safe to share between the
+// readers of the cache. As the public key of a node is only parsed once it's
+// first accessed, we'll parse it upfront, such that the readers never modify
+// the shared node.
+func cacheableNode(node *channeldb.LightningNode) *channeldb.LightningNode {
+	nodeCopy := *node
+	if _, err := nodeCopy.PubKey(); err != nil {
+		log.Debugf("Unable to parse public key of node %x: %v",
+			node.PubKeyBytes, err)
+	}
+
+	return &nodeCopy
+}
+
+// withNode returns a copy of the policy, pointing to the passed node as the
+// node the policy leads to. If the policy is nil, then nil is returned.
+func withNode(policy *channeldb.ChannelEdgePolicy,
+	node *channeldb.LightningNode) *channeldb.ChannelEdgePolicy {
+
+	if policy == nil {
+		return nil
+	}
+
+	policyCopy := *policy
+	policyCopy.Node = node
+	return &policyCopy
+}
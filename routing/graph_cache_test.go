@@ -0,0 +1,478 @@
+package routing
+
+import (
+	"bytes"
+	"encoding/binary"
+	prand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// newTestGraphCache returns a graph cache populated with the current contents
+// of the passed channel graph.
+func newTestGraphCache(t testing.TB,
+	graph *channeldb.ChannelGraph) *graphCache {
+
+	t.Helper()
+
+	cache, err := newGraphCache(graph)
+	if err != nil {
+		t.Fatalf("unable to create graph cache: %v", err)
+	}
+
+	return cache
+}
+
+// assertPolicyEqual asserts that the cached policy matches the one read from
+// the database, including the node it leads to.
+func assertPolicyEqual(t *testing.T, chanID uint64, cached,
+	db *channeldb.ChannelEdgePolicy) {
+
+	t.Helper()
+
+	switch {
+	case cached == nil && db == nil:
+		return
+	case cached == nil || db == nil:
+		t.Fatalf("policy mismatch for channel %v: cached %v, db %v",
+			chanID, cached, db)
+	}
+
+	if cached.ChannelID != db.ChannelID || cached.Flags != db.Flags ||
+		!cached.LastUpdate.Equal(db.LastUpdate) ||
+		cached.TimeLockDelta != db.TimeLockDelta ||
+		cached.FeeBaseMSat != db.FeeBaseMSat ||
+		cached.FeeProportionalMillionths != db.FeeProportionalMillionths {
+
+		t.Fatalf("policy mismatch for channel %v: cached %v, db %v",
+			chanID, cached, db)
+	}
+
+	if cached.Node.PubKeyBytes != db.Node.PubKeyBytes ||
+		cached.Node.Alias != db.Node.Alias {
+
+		t.Fatalf("node mismatch for policy of channel %v: cached "+
+			"%x (%v), db %x (%v)", chanID, cached.Node.PubKeyBytes,
+			cached.Node.Alias, db.Node.PubKeyBytes, db.Node.Alias)
+	}
+}
+
+// assertGraphCacheSynced asserts that the contents of the cache match those
+// of the channel graph within the database, both when traversing the entire
+// graph and when traversing the channels of each node.
+func assertGraphCacheSynced(t *testing.T, cache *graphCache,
+	graph *channeldb.ChannelGraph) {
+
+	t.Helper()
+
+	var dbNodes, cachedNodes []*channeldb.LightningNode
+	err := graph.ForEachNode(nil, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		dbNodes = append(dbNodes, node)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to traverse db nodes: %v", err)
+	}
+	err = cache.forEachNode(func(node *channeldb.LightningNode) error {
+		cachedNodes = append(cachedNodes, node)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to traverse cached nodes: %v", err)
+	}
+
+	if len(dbNodes) != len(cachedNodes) {
+		t.Fatalf("expected %v nodes, got %v", len(dbNodes),
+			len(cachedNodes))
+	}
+	for i, node := range dbNodes {
+		if node.PubKeyBytes != cachedNodes[i].PubKeyBytes ||
+			node.Alias != cachedNodes[i].Alias {
+
+			t.Fatalf("node mismatch: cached %x (%v), db %x (%v)",
+				cachedNodes[i].PubKeyBytes,
+				cachedNodes[i].Alias, node.PubKeyBytes,
+				node.Alias)
+		}
+	}
+
+	type channel struct {
+		info             *channeldb.ChannelEdgeInfo
+		policy1, policy2 *channeldb.ChannelEdgePolicy
+	}
+	var dbChannels, cachedChannels []channel
+	err = graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		policy1, policy2 *channeldb.ChannelEdgePolicy) error {
+
+		dbChannels = append(dbChannels, channel{info, policy1, policy2})
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		t.Fatalf("unable to traverse db channels: %v", err)
+	}
+	err = cache.forEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		policy1, policy2 *channeldb.ChannelEdgePolicy) error {
+
+		cachedChannels = append(
+			cachedChannels, channel{info, policy1, policy2},
+		)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to traverse cached channels: %v", err)
+	}
+
+	if len(dbChannels) != len(cachedChannels) {
+		t.Fatalf("expected %v channels, got %v", len(dbChannels),
+			len(cachedChannels))
+	}
+	for i, dbChannel := range dbChannels {
+		cachedChannel := cachedChannels[i]
+		chanID := dbChannel.info.ChannelID
+		if cachedChannel.info.ChannelID != chanID ||
+			cachedChannel.info.Capacity != dbChannel.info.Capacity {
+
+			t.Fatalf("channel mismatch: cached %v, db %v",
+				cachedChannel.info, dbChannel.info)
+		}
+
+		assertPolicyEqual(
+			t, chanID, cachedChannel.policy1, dbChannel.policy1,
+		)
+		assertPolicyEqual(
+			t, chanID, cachedChannel.policy2, dbChannel.policy2,
+		)
+	}
+
+	dbGraph, err := newDBRoutingGraph(graph)
+	if err != nil {
+		t.Fatalf("unable to open db graph: %v", err)
+	}
+	defer dbGraph.close()
+
+	for _, node := range dbNodes {
+		type nodeChannel struct {
+			chanID              uint64
+			outPolicy, inPolicy *channeldb.ChannelEdgePolicy
+		}
+		var dbNodeChannels, cachedNodeChannels []nodeChannel
+
+		vertex := Vertex(node.PubKeyBytes)
+		err := dbGraph.forEachNodeChannel(vertex, func(
+			info *channeldb.ChannelEdgeInfo,
+			outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error {
+
+			dbNodeChannels = append(dbNodeChannels, nodeChannel{
+				info.ChannelID, outPolicy, inPolicy,
+			})
+			return nil
+		})
+		if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+			t.Fatalf("unable to traverse db node channels: %v",
+				err)
+		}
+		err = cache.forEachNodeChannel(vertex, func(
+			info *channeldb.ChannelEdgeInfo,
+			outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error {
+
+			cachedNodeChannels = append(
+				cachedNodeChannels, nodeChannel{
+					info.ChannelID, outPolicy, inPolicy,
+				},
+			)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse cached node channels: %v",
+				err)
+		}
+
+		if len(dbNodeChannels) != len(cachedNodeChannels) {
+			t.Fatalf("expected %v channels for node %x, got %v",
+				len(dbNodeChannels), node.PubKeyBytes,
+				len(cachedNodeChannels))
+		}
+		for i, dbChannel := range dbNodeChannels {
+			cachedChannel := cachedNodeChannels[i]
+			if cachedChannel.chanID != dbChannel.chanID {
+				t.Fatalf("expected channel %v for node %x, "+
+					"got %v", dbChannel.chanID,
+					node.PubKeyBytes, cachedChannel.chanID)
+			}
+
+			assertPolicyEqual(
+				t, dbChannel.chanID, cachedChannel.outPolicy,
+				dbChannel.outPolicy,
+			)
+			assertPolicyEqual(
+				t, dbChannel.chanID, cachedChannel.inPolicy,
+				dbChannel.inPolicy,
+			)
+		}
+	}
+}
+
+// TestGraphCacheUpdates tests that the graph cache is populated with the
+// contents of the channel graph, and remains in sync with it as nodes,
+// channels and policies are added, updated and removed.
+func TestGraphCacheUpdates(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	cache := newTestGraphCache(t, graph)
+	assertGraphCacheSynced(t, cache, graph)
+
+	// We'll update the policy of one of the channels. The new policy
+	// should be returned from then on.
+	const songokuChan = 12345
+	info, policy1, _, err := graph.FetchChannelEdgesByID(songokuChan)
+	if err != nil {
+		t.Fatalf("unable to fetch channel: %v", err)
+	}
+	policy1.FeeBaseMSat++
+	policy1.LastUpdate = policy1.LastUpdate.Add(time.Second)
+	if err := graph.UpdateEdgePolicy(policy1); err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+	cache.updatePolicy(policy1)
+	assertGraphCacheSynced(t, cache, graph)
+
+	// Next, we'll update one of the nodes. All policies leading to the
+	// node should point to its new version.
+	songoku, err := graph.FetchLightningNode(aliases["songoku"])
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	songoku.Alias = "kakarot"
+	songoku.LastUpdate = songoku.LastUpdate.Add(time.Second)
+	if err := graph.AddLightningNode(songoku); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+	cache.addNode(songoku)
+	assertGraphCacheSynced(t, cache, graph)
+
+	// A channel to a node we don't know yet should add the node to the
+	// cache as well.
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create private key: %v", err)
+	}
+	newNode := &channeldb.LightningNode{}
+	copy(newNode.PubKeyBytes[:], priv.PubKey().SerializeCompressed())
+	if err := graph.AddLightningNode(newNode); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	newInfo := *info
+	newInfo.ChannelID = 1
+	newInfo.ChannelPoint = wire.OutPoint{Index: 1}
+	newInfo.NodeKey2Bytes = newNode.PubKeyBytes
+	if err := graph.AddChannelEdge(&newInfo); err != nil {
+		t.Fatalf("unable to add channel: %v", err)
+	}
+	cache.addChannel(&newInfo)
+	assertGraphCacheSynced(t, cache, graph)
+
+	newPolicy := *policy1
+	newPolicy.ChannelID = newInfo.ChannelID
+	newPolicy.Flags = lnwire.ChanUpdateDirection
+	if err := graph.UpdateEdgePolicy(&newPolicy); err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+	cache.updatePolicy(&newPolicy)
+	assertGraphCacheSynced(t, cache, graph)
+
+	// Finally, removing both channels should leave the cache in sync as
+	// well.
+	for _, chanInfo := range []*channeldb.ChannelEdgeInfo{info, &newInfo} {
+		err := graph.DeleteChannelEdge(&chanInfo.ChannelPoint)
+		if err != nil {
+			t.Fatalf("unable to delete channel: %v", err)
+		}
+		cache.removeChannel(chanInfo.ChannelID)
+	}
+	assertGraphCacheSynced(t, cache, graph)
+}
+
+// createRandomTestGraph creates a channel graph with the given number of
+// nodes, connected by the given number of channels with random policies. The
+// first of the returned nodes is the source node of the graph.
+func createRandomTestGraph(numNodes, numChannels int) (*channeldb.ChannelGraph,
+	func(), []*btcec.PublicKey, error) {
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// As the graph is thrown away afterwards, we don't need to sync it to
+	// disk after each write, which speeds up its creation considerably.
+	graph.Database().NoSync = true
+
+	r := prand.New(prand.NewSource(1))
+
+	nodes := make([]*btcec.PublicKey, numNodes)
+	for i := range nodes {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			cleanUp()
+			return nil, nil, nil, err
+		}
+		nodes[i] = priv.PubKey()
+
+		dbNode := &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			AuthSigBytes:         testSig.Serialize(),
+			LastUpdate:           time.Now(),
+			Addresses:            testAddrs,
+			Features:             testFeatures,
+		}
+		dbNode.AddPubKey(nodes[i])
+
+		if i == 0 {
+			err = graph.SetSourceNode(dbNode)
+		} else {
+			err = graph.AddLightningNode(dbNode)
+		}
+		if err != nil {
+			cleanUp()
+			return nil, nil, nil, err
+		}
+	}
+
+	for chanID := uint64(1); chanID <= uint64(numChannels); chanID++ {
+		node1 := nodes[r.Intn(numNodes)].SerializeCompressed()
+		node2 := nodes[r.Intn(numNodes)].SerializeCompressed()
+		switch bytes.Compare(node1, node2) {
+		case 0:
+			continue
+		case 1:
+			node1, node2 = node2, node1
+		}
+
+		var fundingPoint wire.OutPoint
+		binary.BigEndian.PutUint64(fundingPoint.Hash[:], chanID)
+
+		edgeInfo := &channeldb.ChannelEdgeInfo{
+			ChannelID:    chanID,
+			AuthProof:    &testAuthProof,
+			ChannelPoint: fundingPoint,
+			Capacity:     btcutil.Amount(1000000 + r.Intn(100000000)),
+		}
+		copy(edgeInfo.NodeKey1Bytes[:], node1)
+		copy(edgeInfo.NodeKey2Bytes[:], node2)
+		copy(edgeInfo.BitcoinKey1Bytes[:], node1)
+		copy(edgeInfo.BitcoinKey2Bytes[:], node2)
+
+		if err := graph.AddChannelEdge(edgeInfo); err != nil {
+			cleanUp()
+			return nil, nil, nil, err
+		}
+
+		for _, flags := range []lnwire.ChanUpdateFlag{
+			0, lnwire.ChanUpdateDirection,
+		} {
+			feeRate := lnwire.MilliSatoshi(1 + r.Intn(1000))
+			policy := &channeldb.ChannelEdgePolicy{
+				SigBytes:                  testSig.Serialize(),
+				Flags:                     flags,
+				ChannelID:                 chanID,
+				LastUpdate:                time.Now(),
+				TimeLockDelta:             uint16(10 + r.Intn(144)),
+				MinHTLC:                   1,
+				FeeBaseMSat:               lnwire.MilliSatoshi(r.Intn(2000)),
+				FeeProportionalMillionths: feeRate,
+			}
+			if err := graph.UpdateEdgePolicy(policy); err != nil {
+				cleanUp()
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	return graph, cleanUp, nodes, nil
+}
+
+// BenchmarkFindPath compares the performance of path finding within the graph
+// cache with that of path finding within the database.
+func BenchmarkFindPath(b *testing.B) {
+	const (
+		numNodes    = 1000
+		numChannels = 5000
+	)
+
+	graph, cleanUp, nodes, err := createRandomTestGraph(
+		numNodes, numChannels,
+	)
+	if err != nil {
+		b.Fatalf("unable to create graph: %v", err)
+	}
+	defer cleanUp()
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		b.Fatalf("unable to fetch source node: %v", err)
+	}
+	paymentAmt := lnwire.NewMSatFromSatoshis(10000)
+
+	benchmarkFindPath := func(b *testing.B, g routingGraph) {
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			target := nodes[1+i%(numNodes-1)]
+			_, err := findPath(
+				g, nil, sourceNode, target, nil, nil,
+				paymentAmt, nil,
+			)
+			if err != nil && !IsError(err, ErrNoPathFound) {
+				b.Fatalf("unable to find path: %v", err)
+			}
+		}
+	}
+
+	b.Run("graph cache", func(b *testing.B) {
+		benchmarkFindPath(b, newTestGraphCache(b, graph))
+	})
+
+	b.Run("database", func(b *testing.B) {
+		dbGraph, err := newDBRoutingGraph(graph)
+		if err != nil {
+			b.Fatalf("unable to open db graph: %v", err)
+		}
+		defer dbGraph.close()
+
+		benchmarkFindPath(b, dbGraph)
+	})
+}
+
+// BenchmarkGraphCacheCreation measures the time it takes to populate the
+// graph cache from the database.
+func BenchmarkGraphCacheCreation(b *testing.B) {
+	graph, cleanUp, _, err := createRandomTestGraph(1000, 5000)
+	if err != nil {
+		b.Fatalf("unable to create graph: %v", err)
+	}
+	defer cleanUp()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		newTestGraphCache(b, graph)
+	}
+}
@@ -1,49 +1,70 @@
 package routing
 
 import (
+	"bytes"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
 )
 
 const (
 	// vertexDecay is the decay period of colored vertexes added to
 	// missionControl. Once vertexDecay passes after an entry has been
-	// added to the prune view, it is garbage collected. This value is
-	// larger than edgeDecay as an edge failure typical indicates an
-	// unbalanced channel, while a vertex failure indicates a node is not
-	// online and active.
+	// added to the prune view, it is garbage collected. A vertex failure
+	// indicates a node is not online and active, so unlike channel
+	// failures, it isn't tied to the amount of the HTLC.
 	vertexDecay = time.Duration(time.Minute * 5)
 
-	// edgeDecay is the decay period of colored edges added to
-	// missionControl. Once edgeDecay passed after an entry has been added,
-	// it is garbage collected. This value is smaller than vertexDecay as
-	// an edge related failure during payment sending typically indicates
-	// that a channel was unbalanced, a condition which may quickly change.
-	//
-	// TODO(roasbeef): instead use random delay on each?
-	edgeDecay = time.Duration(time.Second * 5)
+	// aprioriHopProbability is the probability that a channel is able to
+	// forward an HTLC, assumed in the absence of any observations of it.
+	aprioriHopProbability = 0.6
+
+	// prevSuccessProbability is the probability that a channel is able to
+	// forward an HTLC, if it forwarded an HTLC of at least the same amount
+	// since its last failure.
+	prevSuccessProbability = 0.95
+
+	// penaltyHalfLife is the time after which the penalty of a failed
+	// channel is halved. Right after the failure, the channel is assumed
+	// to be unable to forward HTLCs of at least the failed amount, and its
+	// probability recovers towards aprioriHopProbability over time, as
+	// its balance is likely to have shifted.
+	penaltyHalfLife = time.Hour
+
+	// minProbability is the probability below which a channel isn't
+	// considered at all during path finding.
+	minProbability = 0.01
+
+	// maxMissionControlResults is the number of channel observations that
+	// are kept in the persisted history of mission control.
+	maxMissionControlResults = 1000
 )
 
 // missionControl contains state which summarizes the past attempts of HTLC
 // routing by external callers when sending payments throughout the network.
 // missionControl remembers the outcome of these past routing attempts (success
 // and failure), and is able to provide hints/guidance to future HTLC routing
-// attempts.  missionControl maintains a decaying network view of the
-// edges/vertexes that should be marked as "pruned" during path finding. This
-// graph view acts as a shared memory during HTLC payment routing attempts.
-// With each execution, if an error is encountered, based on the type of error
-// and the location of the error within the route, an edge or vertex is added
-// to the view. Later sending attempts will then query the view for all the
-// vertexes/edges that should be ignored. Items in the view decay after a set
-// period of time, allowing the view to be dynamic w.r.t network changes.
+// attempts.
+//
+// For each channel, missionControl keeps track of the amounts it was last
+// able and unable to forward, which are used to estimate the probability that
+// the channel is able to forward an HTLC of a given amount. This history is
+// persisted, so it survives restarts. Path finding factors the probability of
+// each channel into its weight, to bias towards channels that are likely to
+// succeed.
+//
+// Additionally, missionControl maintains a decaying network view of vertexes
+// that should be marked as "pruned" during path finding, as a vertex failure
+// typically indicates that the node isn't online.
 type missionControl struct {
-	// failedEdges maps a short channel ID to be pruned, to the time that
-	// it was added to the prune view. Edges are added to this map if a
-	// caller reports to missionControl a failure localized to that edge
-	// when sending a payment.
-	failedEdges map[uint64]time.Time
+	// history maps a short channel ID to the outcomes of the HTLCs
+	// forwarded over the channel.
+	history map[uint64]*channelHistory
 
 	// failedVertexes maps a node's public key that should be pruned, to
 	// the time that it was added to the prune view. Vertexes are added to
@@ -53,28 +74,184 @@ type missionControl struct {
 
 	graph *channeldb.ChannelGraph
 
+	// routingGraph is the graph path finding is carried out against,
+	// which is normally the in-memory graph cache of the router.
+	routingGraph routingGraph
+
 	selfNode *channeldb.LightningNode
 
+	// now returns the current time. It's overridden in tests.
+	now func() time.Time
+
 	sync.Mutex
 
 	// TODO(roasbeef): further counters, if vertex continually unavailable,
 	// add to another generation
+}
 
-	// TODO(roasbeef): also add favorable metrics for nodes
+// channelHistory summarizes the outcomes of the HTLCs forwarded over a single
+// channel.
+type channelHistory struct {
+	// lastFailTime is the time of the last failure of the channel. It's
+	// zero if the channel never failed.
+	lastFailTime time.Time
+
+	// minPenalizeAmt is the smallest amount the channel failed to forward
+	// since its last success of a larger amount. HTLCs of a smaller amount
+	// aren't penalized.
+	minPenalizeAmt lnwire.MilliSatoshi
+
+	// lastSuccessTime is the time of the last success of the channel. It's
+	// zero if the channel never succeeded.
+	lastSuccessTime time.Time
+
+	// maxSuccessAmt is the largest amount the channel forwarded since its
+	// last failure of a smaller amount.
+	maxSuccessAmt lnwire.MilliSatoshi
 }
 
-// newMissionControl returns a new instance of missionControl.
-//
-// TODO(roasbeef): persist memory
-func newMissionControl(g *channeldb.ChannelGraph,
-	selfNode *channeldb.LightningNode) *missionControl {
+// newMissionControl returns a new instance of missionControl, populated with
+// the history persisted within the graph's database. Paths are found within
+// the passed routing graph.
+func newMissionControl(g *channeldb.ChannelGraph, routingGraph routingGraph,
+	selfNode *channeldb.LightningNode) (*missionControl, error) {
 
-	return &missionControl{
-		failedEdges:    make(map[uint64]time.Time),
+	m := &missionControl{
+		history:        make(map[uint64]*channelHistory),
 		failedVertexes: make(map[Vertex]time.Time),
 		selfNode:       selfNode,
 		graph:          g,
+		routingGraph:   routingGraph,
+		now:            time.Now,
+	}
+
+	results, err := g.Database().FetchMissionControlResults()
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		m.applyResult(result)
+	}
+
+	log.Debugf("Mission Control loaded %v results for %v channels",
+		len(results), len(m.history))
+
+	return m, nil
+}
+
+// applyResult updates the history of the result's channel with the result.
+//
+// NOTE: This method MUST be called with the mutex held.
+func (m *missionControl) applyResult(result *channeldb.MissionControlResult) {
+	chanID := result.ChannelID.ToUint64()
+	history, ok := m.history[chanID]
+	if !ok {
+		history = &channelHistory{}
+		m.history[chanID] = history
+	}
+
+	if result.Success {
+		// A success of an amount at least as large as the smallest
+		// failed amount shows the channel recovered from the failure.
+		if !history.lastFailTime.IsZero() &&
+			result.Amount >= history.minPenalizeAmt {
+
+			history.lastFailTime = time.Time{}
+			history.minPenalizeAmt = 0
+		}
+
+		if history.lastSuccessTime.IsZero() ||
+			result.Amount > history.maxSuccessAmt {
+
+			history.maxSuccessAmt = result.Amount
+		}
+		history.lastSuccessTime = result.Timestamp
+		return
+	}
+
+	// A failure of an amount no larger than the largest succeeded amount
+	// shows the channel can no longer forward that amount.
+	if !history.lastSuccessTime.IsZero() &&
+		result.Amount <= history.maxSuccessAmt {
+
+		history.lastSuccessTime = time.Time{}
+		history.maxSuccessAmt = 0
 	}
+
+	if history.lastFailTime.IsZero() ||
+		result.Amount < history.minPenalizeAmt {
+
+		history.minPenalizeAmt = result.Amount
+	}
+	history.lastFailTime = result.Timestamp
+}
+
+// reportResult records the outcome of forwarding an HTLC of the given amount
+// over a channel, both within the in-memory history and the persisted one.
+func (m *missionControl) reportResult(chanID uint64, amt lnwire.MilliSatoshi,
+	success bool) {
+
+	result := &channeldb.MissionControlResult{
+		ChannelID: lnwire.NewShortChanIDFromInt(chanID),
+		Amount:    amt,
+		Success:   success,
+		Timestamp: m.now(),
+	}
+
+	m.Lock()
+	m.applyResult(result)
+	m.Unlock()
+
+	err := m.graph.Database().AddMissionControlResult(
+		result, maxMissionControlResults,
+	)
+	if err != nil {
+		log.Errorf("Unable to persist mission control result for "+
+			"channel %v: %v", chanID, err)
+	}
+}
+
+// getEdgeProbability returns the estimated probability that the channel is
+// able to forward an HTLC of the given amount.
+func (m *missionControl) getEdgeProbability(chanID uint64,
+	amt lnwire.MilliSatoshi) float64 {
+
+	m.Lock()
+	defer m.Unlock()
+
+	history, ok := m.history[chanID]
+	if !ok {
+		return aprioriHopProbability
+	}
+
+	return history.probability(amt, m.now())
+}
+
+// probability returns the estimated probability that the channel is able to
+// forward an HTLC of the given amount at the given time.
+func (h *channelHistory) probability(amt lnwire.MilliSatoshi,
+	now time.Time) float64 {
+
+	// If the channel failed to forward a smaller amount, then the
+	// probability starts at zero right after the failure, and recovers
+	// towards the a priori probability as time passes.
+	if !h.lastFailTime.IsZero() && amt >= h.minPenalizeAmt {
+		timeSinceFail := now.Sub(h.lastFailTime)
+		if timeSinceFail < 0 {
+			timeSinceFail = 0
+		}
+
+		halvings := float64(timeSinceFail) / float64(penaltyHalfLife)
+		return aprioriHopProbability * (1 - math.Pow(2, -halvings))
+	}
+
+	// If the channel forwarded at least this amount since, then it's
+	// likely to succeed again.
+	if !h.lastSuccessTime.IsZero() && amt <= h.maxSuccessAmt {
+		return prevSuccessProbability
+	}
+
+	return aprioriHopProbability
 }
 
 // graphPruneView is a filter of sorts that path finding routines should
@@ -89,14 +266,16 @@ type graphPruneView struct {
 }
 
 // GraphPruneView returns a new graphPruneView instance which is to be
-// consulted during path finding. If a vertex/edge is found within the returned
+// consulted during path finding. If a vertex is found within the returned
 // prune view, it is to be ignored as a goroutine has had issues routing
 // through it successfully. Within this method the main view of the
 // missionControl is garbage collected as entries are detected to be "stale".
+// Failed edges aren't part of the view, as they're accounted for by their
+// probability instead.
 func (m *missionControl) GraphPruneView() graphPruneView {
 	// First, we'll grab the current time, this value will be used to
 	// determine if an entry is stale or not.
-	now := time.Now()
+	now := m.now()
 
 	m.Lock()
 
@@ -116,28 +295,13 @@ func (m *missionControl) GraphPruneView() graphPruneView {
 		vertexes[vertex] = struct{}{}
 	}
 
-	// We'll also do the same for edges, but use the edgeDecay this time
-	// rather than the decay for vertexes.
-	edges := make(map[uint64]struct{})
-	for edge, pruneTime := range m.failedEdges {
-		if now.Sub(pruneTime) >= edgeDecay {
-			log.Tracef("Pruning decayed failure report for edge %v "+
-				"from Mission Control", edge)
-
-			delete(m.failedEdges, edge)
-			continue
-		}
-
-		edges[edge] = struct{}{}
-	}
-
 	m.Unlock()
 
-	log.Debugf("Mission Control returning prune view of %v edges, %v "+
-		"vertexes", len(edges), len(vertexes))
+	log.Debugf("Mission Control returning prune view of %v vertexes",
+		len(vertexes))
 
 	return graphPruneView{
-		edges:    edges,
+		edges:    make(map[uint64]struct{}),
 		vertexes: vertexes,
 	}
 }
@@ -152,20 +316,109 @@ func (m *missionControl) GraphPruneView() graphPruneView {
 type paymentSession struct {
 	pruneViewSnapshot graphPruneView
 
+	// additionalEdges is a set of ephemeral edges, derived from the route
+	// hints of the payment, that will be spliced into the channel graph
+	// during path finding. The map is keyed by the vertex at the start of
+	// each edge.
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy
+
+	// preBuiltRoutes is an optional set of routes supplied by the caller.
+	// If haveRoutes is true, then these routes are handed out in order,
+	// instead of running path finding.
+	preBuiltRoutes []*Route
+	haveRoutes     bool
+
+	// usedCapacity is the amount of each channel that is committed to the
+	// in-flight HTLCs of the payment, if it's split across multiple
+	// routes. Path finding deducts this amount from the capacity of the
+	// channel, such that the routes of the payment don't together exceed
+	// it.
+	usedCapacity map[uint64]lnwire.MilliSatoshi
+
 	mc *missionControl
 }
 
 // NewPaymentSession creates a new payment session backed by the latest prune
-// view from Mission Control.
-func (m *missionControl) NewPaymentSession() *paymentSession {
+// view from Mission Control. An optional set of routing hints can be provided
+// in order to populate additional edges to explore when finding a path to the
+// payment's destination.
+func (m *missionControl) NewPaymentSession(routeHints [][]HopHint,
+	target *btcec.PublicKey) *paymentSession {
+
 	viewSnapshot := m.GraphPruneView()
 
 	return &paymentSession{
 		pruneViewSnapshot: viewSnapshot,
+		additionalEdges:   hopHintsToEdges(routeHints, target),
 		mc:                m,
 	}
 }
 
+// NewPaymentSessionFromRoutes creates a new payment session which will only
+// attempt the passed set of pre-built routes, in the order given. The session
+// starts out with an empty prune view, as the caller explicitly selected the
+// routes. Failures are still reported back to mission control, and any
+// route that contains a vertex or edge that failed during this session will be
+// skipped.
+func (m *missionControl) NewPaymentSessionFromRoutes(
+	routes []*Route) *paymentSession {
+
+	return &paymentSession{
+		pruneViewSnapshot: graphPruneView{
+			edges:    make(map[uint64]struct{}),
+			vertexes: make(map[Vertex]struct{}),
+		},
+		preBuiltRoutes: routes,
+		haveRoutes:     true,
+		mc:             m,
+	}
+}
+
+// hopHintsToEdges converts the passed set of routing hints into a set of
+// channel edge policies indexed by the public key of each channel's starting
+// node. If multiple hop hints are provided within a single route hint, we
+// assume they're chained together and sorted in forward order in order to
+// reach the target.
+func hopHintsToEdges(routeHints [][]HopHint,
+	target *btcec.PublicKey) map[Vertex][]*channeldb.ChannelEdgePolicy {
+
+	edges := make(map[Vertex][]*channeldb.ChannelEdgePolicy)
+	for _, routeHint := range routeHints {
+		for i, hopHint := range routeHint {
+			// In order to determine the end node of this hint,
+			// we'll need to look at the next hint's start node. If
+			// we've reached the end of the hints list, we can
+			// assume we've reached the destination.
+			endNode := &channeldb.LightningNode{}
+			if i != len(routeHint)-1 {
+				endNode.AddPubKey(routeHint[i+1].NodeID)
+			} else {
+				endNode.AddPubKey(target)
+			}
+
+			// Finally, create the channel edge from the hop hint
+			// and add it to list of edges corresponding to the
+			// node at the start of the channel.
+			edge := &channeldb.ChannelEdgePolicy{
+				Node:      endNode,
+				ChannelID: hopHint.ChannelID,
+				FeeBaseMSat: lnwire.MilliSatoshi(
+					hopHint.FeeBaseMSat,
+				),
+				FeeProportionalMillionths: lnwire.MilliSatoshi(
+					hopHint.FeeProportionalMillionths,
+				),
+				TimeLockDelta: hopHint.CLTVExpiryDelta,
+			}
+
+			v := NewVertex(hopHint.NodeID)
+			edges[v] = append(edges[v], edge)
+		}
+	}
+
+	return edges
+}
+
 // ReportVertexFailure adds a vertex to the graph prune view after a client
 // reports a routing failure localized to the vertex. The time the vertex was
 // added is noted, as it'll be pruned from the shared view after a period of
@@ -181,29 +434,39 @@ func (p *paymentSession) ReportVertexFailure(v Vertex) {
 	// view, with this new piece of information so it can be utilized for
 	// new payment sessions.
 	p.mc.Lock()
-	p.mc.failedVertexes[v] = time.Now()
+	p.mc.failedVertexes[v] = p.mc.now()
 	p.mc.Unlock()
 }
 
-// ReportChannelFailure adds a channel to the graph prune view. The time the
-// channel was added is noted, as it'll be pruned from the global view after a
-// period of edgeDecay. However, the edge will remain pruned for the duration
-// of the *local* session. This ensures that we don't flap by continually
-// retrying an edge after its pruning has expired.
-//
-// TODO(roasbeef): also add value attempted to send and capacity of channel
-func (p *paymentSession) ReportChannelFailure(e uint64) {
-	log.Debugf("Reporting edge %v failure to Mission Control", e)
+// ReportChannelFailure adds a channel to the graph prune view of the session,
+// such that it isn't retried for the duration of the *local* session. The
+// failure to forward the given amount is also recorded within the history of
+// mission control, lowering the probability of the channel for future
+// payments.
+func (p *paymentSession) ReportChannelFailure(e uint64,
+	amt lnwire.MilliSatoshi) {
+
+	log.Debugf("Reporting edge %v failure of %v to Mission Control", e,
+		amt)
 
 	// First, we'll add the failed edge to our local prune view snapshot.
 	p.pruneViewSnapshot.edges[e] = struct{}{}
 
-	// With the edge added, we'll now report back to the global prune view,
-	// with this new piece of information so it can be utilized for new
-	// payment sessions.
-	p.mc.Lock()
-	p.mc.failedEdges[e] = time.Now()
-	p.mc.Unlock()
+	// With the edge added, we'll now report back to mission control, with
+	// this new piece of information so it can be utilized for new payment
+	// sessions.
+	p.mc.reportResult(e, amt, false)
+}
+
+// ReportChannelSuccess records within the history of mission control that
+// the channel was able to forward the given amount.
+func (p *paymentSession) ReportChannelSuccess(e uint64,
+	amt lnwire.MilliSatoshi) {
+
+	log.Tracef("Reporting edge %v success of %v to Mission Control", e,
+		amt)
+
+	p.mc.reportResult(e, amt, true)
 }
 
 // RequestRoute returns a route which is likely to be capable for successfully
@@ -228,11 +491,31 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 
 	// TODO(roasbeef): sync logic amongst dist sys
 
+	// If the session was created with a set of pre-built routes, then
+	// we'll hand out the next route that doesn't cross any of the
+	// vertexes or edges that failed so far.
+	if p.haveRoutes {
+		return p.nextPreBuiltRoute(pruneView)
+	}
+
 	// Taking into account this prune view, we'll attempt to locate a path
 	// to our destination, respecting the recommendations from
 	// missionControl.
-	path, err := findPath(nil, p.mc.graph, p.mc.selfNode, payment.Target,
-		pruneView.vertexes, pruneView.edges, payment.Amount)
+	restrictions := &RestrictParams{
+		FeeLimit:          payment.FeeLimit,
+		CltvLimit:         payment.CltvLimit,
+		OutgoingChannelID: payment.OutgoingChannelID,
+		LastHop:           payment.LastHop,
+		CostModel:         payment.CostModel,
+	}
+	limits := newPathLimits(restrictions, finalCltvDelta)
+	limits.usedCapacity = p.usedCapacity
+	limits.edgeProbability = p.mc.getEdgeProbability
+	path, err := findPath(
+		p.mc.routingGraph, p.additionalEdges, p.mc.selfNode,
+		payment.Target, pruneView.vertexes, pruneView.edges,
+		payment.Amount, limits,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -248,14 +531,196 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 		return nil, err
 	}
 
+	// As path finding only estimates the fees of the path, we'll make
+	// sure the fully computed route is still within the limits.
+	if err := limits.checkRoute(route, height); err != nil {
+		return nil, err
+	}
+
 	return route, err
 }
 
+// reserveCapacity marks the capacity of the channels along the passed route as
+// committed to an in-flight HTLC of the payment, such that routes requested
+// for the other HTLCs of the payment don't rely on it.
+func (p *paymentSession) reserveCapacity(route *Route) {
+	if p.usedCapacity == nil {
+		p.usedCapacity = make(map[uint64]lnwire.MilliSatoshi)
+	}
+
+	for _, hop := range route.Hops {
+		p.usedCapacity[hop.Channel.ChannelID] += hop.AmtToForward
+	}
+}
+
+// releaseCapacity releases the capacity of the channels along the passed
+// route, which was reserved for an HTLC of the payment that is no longer in
+// flight.
+func (p *paymentSession) releaseCapacity(route *Route) {
+	for _, hop := range route.Hops {
+		chanID := hop.Channel.ChannelID
+		if p.usedCapacity[chanID] <= hop.AmtToForward {
+			delete(p.usedCapacity, chanID)
+			continue
+		}
+		p.usedCapacity[chanID] -= hop.AmtToForward
+	}
+}
+
+// nextPreBuiltRoute pops the next pre-built route of the session that doesn't
+// contain any of the vertexes or edges within the passed prune view. If no
+// such route remains, then ErrNoRouteFound is returned.
+func (p *paymentSession) nextPreBuiltRoute(pruneView graphPruneView) (*Route,
+	error) {
+
+	for len(p.preBuiltRoutes) > 0 {
+		// The slice is shared with the caller, so we'll only advance
+		// past the route rather than clearing its entry.
+		route := p.preBuiltRoutes[0]
+		p.preBuiltRoutes = p.preBuiltRoutes[1:]
+
+		if isPrunedRoute(route, pruneView) {
+			log.Debugf("Skipping pre-built route with total "+
+				"amount %v, as it crosses a pruned vertex "+
+				"or edge", route.TotalAmount)
+			continue
+		}
+
+		return route, nil
+	}
+
+	return nil, newErr(ErrNoRouteFound, "all pre-built routes have "+
+		"been attempted")
+}
+
+// isPrunedRoute returns true if the route crosses any of the vertexes or
+// edges within the passed prune view.
+func isPrunedRoute(route *Route, pruneView graphPruneView) bool {
+	for vertex := range pruneView.vertexes {
+		if route.containsNode(vertex) {
+			return true
+		}
+	}
+
+	for edge := range pruneView.edges {
+		if route.containsChannel(edge) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ResetHistory resets the history of missionControl returning it to a state as
-// if no payment attempts have been made.
-func (m *missionControl) ResetHistory() {
+// if no payment attempts have been made. The persisted history is removed as
+// well.
+func (m *missionControl) ResetHistory() error {
 	m.Lock()
-	m.failedEdges = make(map[uint64]time.Time)
+	m.history = make(map[uint64]*channelHistory)
 	m.failedVertexes = make(map[Vertex]time.Time)
 	m.Unlock()
+
+	return m.graph.Database().ResetMissionControlResults()
+}
+
+// MissionControlChannel is a snapshot of the history of mission control for
+// a single channel.
+type MissionControlChannel struct {
+	// ChannelID is the short channel ID of the channel.
+	ChannelID uint64
+
+	// LastFailTime is the time of the last failure of the channel. It's
+	// zero if the channel didn't fail since its last success.
+	LastFailTime time.Time
+
+	// MinPenalizeAmt is the smallest amount the channel failed to
+	// forward. HTLCs of a smaller amount aren't penalized.
+	MinPenalizeAmt lnwire.MilliSatoshi
+
+	// LastSuccessTime is the time of the last success of the channel.
+	// It's zero if the channel didn't succeed since its last failure.
+	LastSuccessTime time.Time
+
+	// MaxSuccessAmt is the largest amount the channel forwarded.
+	MaxSuccessAmt lnwire.MilliSatoshi
+
+	// SuccessProb is the current estimated probability that the channel
+	// is able to forward an HTLC of MinPenalizeAmt if it failed, or of
+	// MaxSuccessAmt otherwise.
+	SuccessProb float64
+}
+
+// MissionControlNode is a snapshot of a node that mission control currently
+// prunes from path finding.
+type MissionControlNode struct {
+	// PubKey is the public key of the node.
+	PubKey Vertex
+
+	// LastFailTime is the time the node failed.
+	LastFailTime time.Time
+}
+
+// MissionControlSnapshot is a snapshot of the current state of mission
+// control.
+type MissionControlSnapshot struct {
+	// Channels is the history of each channel that mission control has
+	// observed, sorted by channel ID.
+	Channels []MissionControlChannel
+
+	// Nodes is the set of nodes that are currently pruned.
+	Nodes []MissionControlNode
+}
+
+// GetHistorySnapshot returns a snapshot of the current state of mission
+// control.
+func (m *missionControl) GetHistorySnapshot() *MissionControlSnapshot {
+	now := m.now()
+
+	m.Lock()
+	defer m.Unlock()
+
+	snapshot := &MissionControlSnapshot{
+		Channels: make([]MissionControlChannel, 0, len(m.history)),
+		Nodes:    make([]MissionControlNode, 0, len(m.failedVertexes)),
+	}
+
+	for chanID, history := range m.history {
+		probAmt := history.maxSuccessAmt
+		if !history.lastFailTime.IsZero() {
+			probAmt = history.minPenalizeAmt
+		}
+
+		snapshot.Channels = append(snapshot.Channels,
+			MissionControlChannel{
+				ChannelID:       chanID,
+				LastFailTime:    history.lastFailTime,
+				MinPenalizeAmt:  history.minPenalizeAmt,
+				LastSuccessTime: history.lastSuccessTime,
+				MaxSuccessAmt:   history.maxSuccessAmt,
+				SuccessProb:     history.probability(probAmt, now),
+			},
+		)
+	}
+	sort.Slice(snapshot.Channels, func(i, j int) bool {
+		return snapshot.Channels[i].ChannelID <
+			snapshot.Channels[j].ChannelID
+	})
+
+	for vertex, failTime := range m.failedVertexes {
+		if now.Sub(failTime) >= vertexDecay {
+			continue
+		}
+
+		snapshot.Nodes = append(snapshot.Nodes, MissionControlNode{
+			PubKey:       vertex,
+			LastFailTime: failTime,
+		})
+	}
+	sort.Slice(snapshot.Nodes, func(i, j int) bool {
+		return bytes.Compare(
+			snapshot.Nodes[i].PubKey[:], snapshot.Nodes[j].PubKey[:],
+		) < 0
+	})
+
+	return snapshot
 }
@@ -0,0 +1,100 @@
+package routing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// assertProbability asserts that mission control estimates the expected
+// probability for the channel and amount.
+func assertProbability(t *testing.T, mc *missionControl, chanID uint64,
+	amt lnwire.MilliSatoshi, expected float64) {
+
+	t.Helper()
+
+	probability := mc.getEdgeProbability(chanID, amt)
+	if math.Abs(probability-expected) > 1e-9 {
+		t.Fatalf("expected probability %v for channel %v and amount "+
+			"%v, got %v", expected, chanID, amt, probability)
+	}
+}
+
+// TestMissionControlProbability tests that mission control estimates the
+// probability of a channel based on its past successes and failures, and that
+// this history survives a restart until it's reset.
+func TestMissionControlProbability(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, err := makeTestGraph()
+	if err != nil {
+		t.Fatalf("unable to create test graph: %v", err)
+	}
+	defer cleanUp()
+
+	now := time.Unix(1000000, 0)
+	newTestMissionControl := func() *missionControl {
+		mc, err := newMissionControl(graph, nil, nil)
+		if err != nil {
+			t.Fatalf("unable to create mission control: %v", err)
+		}
+		mc.now = func() time.Time { return now }
+		return mc
+	}
+	mc := newTestMissionControl()
+	session := mc.NewPaymentSession(nil, nil)
+
+	// Without any observations, the a priori probability is assumed.
+	assertProbability(t, mc, 1, 1000, aprioriHopProbability)
+
+	// Right after a failure, the channel isn't expected to forward the
+	// failed amount or more, while smaller amounts aren't affected.
+	session.ReportChannelFailure(1, 1000)
+	assertProbability(t, mc, 1, 1000, 0)
+	assertProbability(t, mc, 1, 2000, 0)
+	assertProbability(t, mc, 1, 999, aprioriHopProbability)
+
+	// As time passes, the probability recovers.
+	now = now.Add(penaltyHalfLife)
+	assertProbability(t, mc, 1, 1000, aprioriHopProbability/2)
+
+	// After a success, the channel is likely to forward the same amount
+	// or less again.
+	session.ReportChannelSuccess(2, 2000)
+	assertProbability(t, mc, 2, 2000, prevSuccessProbability)
+	assertProbability(t, mc, 2, 2001, aprioriHopProbability)
+
+	// A subsequent failure of a smaller amount overrides the success.
+	session.ReportChannelFailure(2, 1500)
+	assertProbability(t, mc, 2, 1000, aprioriHopProbability)
+	assertProbability(t, mc, 2, 1500, 0)
+
+	// The history should be restored after a restart.
+	mc = newTestMissionControl()
+	assertProbability(t, mc, 1, 1000, aprioriHopProbability/2)
+	assertProbability(t, mc, 2, 1500, 0)
+
+	snapshot := mc.GetHistorySnapshot()
+	if len(snapshot.Channels) != 2 {
+		t.Fatalf("expected 2 channels in snapshot, got %v",
+			len(snapshot.Channels))
+	}
+	if snapshot.Channels[1].ChannelID != 2 ||
+		snapshot.Channels[1].MinPenalizeAmt != 1500 ||
+		snapshot.Channels[1].SuccessProb != 0 {
+
+		t.Fatalf("unexpected channel in snapshot: %v",
+			snapshot.Channels[1])
+	}
+
+	// Once reset, the history should be gone, also after a restart.
+	if err := mc.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
+	assertProbability(t, mc, 2, 1500, aprioriHopProbability)
+
+	mc = newTestMissionControl()
+	assertProbability(t, mc, 2, 1500, aprioriHopProbability)
+}
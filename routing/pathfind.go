@@ -8,10 +8,11 @@ import (
 
 	"container/heap"
 
-	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/lnwire/tlv"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
@@ -26,8 +27,218 @@ const (
 
 	// infinity is used as a starting distance in our shortest path search.
 	infinity = math.MaxInt64
+
+	// noFeeLimit is used to signal that the fees paid along a path aren't
+	// limited.
+	noFeeLimit = lnwire.MilliSatoshi(math.MaxUint64)
+
+	// noCltvLimit is used to signal that the total time-lock of a path
+	// isn't limited.
+	noCltvLimit = math.MaxUint32
+
+	// maxEdgeWeight is the maximum weight of a single edge, which ensures
+	// that the distance of a path within the HopLimit can't overflow.
+	maxEdgeWeight = infinity / (HopLimit + 1)
 )
 
+// CostModel defines how path finding weighs the fee, the time-lock and the
+// reliability of an edge against each other when searching for the cheapest
+// path. The weight of an edge is:
+//
+//	FeeWeight * fee^2 + 1 + TimeLockRiskFactor * timeLockDelta +
+//	  AttemptCost^2 * (1/p - 1)
+//
+// where p is the estimated probability that the edge is able to forward the
+// payment.
+type CostModel struct {
+	// FeeWeight is the factor that the square of the fee paid for an edge
+	// is multiplied with. Squaring the fee biases the search heavily
+	// towards cheap edges.
+	FeeWeight float64
+
+	// TimeLockRiskFactor is the weight of each block of time-lock delta
+	// of an edge. It expresses the risk of having the payment's funds
+	// locked up for that long should a node along the path go offline.
+	TimeLockRiskFactor float64
+
+	// AttemptCost is the cost of a failed payment attempt, expressed as
+	// the fee that's considered to be equivalent to the delay it causes.
+	// Each failed attempt to be expected through an edge is weighted as
+	// if this fee were paid for the edge.
+	AttemptCost lnwire.MilliSatoshi
+}
+
+// DefaultCostModel is the cost model used by path finding if none is
+// specified. A failed attempt is considered as costly as paying a fee of 100
+// satoshis on a single edge.
+var DefaultCostModel = CostModel{
+	FeeWeight:          1,
+	TimeLockRiskFactor: 1,
+	AttemptCost:        100000,
+}
+
+// RestrictParams wraps the optional restrictions that the route of a payment
+// must adhere to. A nil restriction isn't enforced.
+type RestrictParams struct {
+	// FeeLimit is the maximum total fee that may be paid to the nodes
+	// along the route.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// CltvLimit is the maximum total time-lock of the route, expressed as
+	// a number of blocks relative to the current height. This includes the
+	// final CLTV delta.
+	CltvLimit *uint32
+
+	// OutgoingChannelID is the ID of the channel of ours that the route
+	// must leave through.
+	OutgoingChannelID *uint64
+
+	// LastHop is the node that must precede the target in the route.
+	LastHop *Vertex
+
+	// CostModel is the cost model used to weigh the edges of the route.
+	// If nil, the DefaultCostModel is used.
+	CostModel *CostModel
+}
+
+// pathLimits wraps the restrictions of a payment that any path found for it
+// must adhere to. These restrictions are enforced while searching for a path,
+// such that candidate edges violating them are pruned right away.
+type pathLimits struct {
+	// feeLimit is the maximum total fee that may be paid to the nodes
+	// along the path.
+	feeLimit lnwire.MilliSatoshi
+
+	// cltvLimit is the maximum total time-lock of the path, relative to
+	// the current height. This includes the final CLTV delta of the
+	// target.
+	cltvLimit uint32
+
+	// finalCLTVDelta is the CLTV delta of the final hop of the path.
+	finalCLTVDelta uint16
+
+	// outgoingChannelID is the optional ID of the channel that the path
+	// must leave the source node through.
+	outgoingChannelID *uint64
+
+	// lastHop is the optional node that must precede the target in the
+	// path.
+	lastHop *Vertex
+
+	// spurSearch indicates that the search starts at a spur node other
+	// than ourselves, at the end of a root path. A fee is paid for the
+	// edges out of such a node, and the outgoing channel restriction
+	// doesn't apply to them, as the root path already satisfies it.
+	spurSearch bool
+
+	// usedCapacity is the optional amount of each channel that is already
+	// committed to the other in-flight HTLCs of a multi-path payment. This
+	// amount isn't available to the path, so it's deducted from the
+	// capacity of the channel.
+	usedCapacity map[uint64]lnwire.MilliSatoshi
+
+	// edgeProbability optionally returns the estimated probability that a
+	// channel is able to forward an HTLC of the given amount. Channels
+	// below minProbability are skipped, and the weight of the others is
+	// increased by the expected cost of their failure. If nil, all
+	// channels are assumed to succeed.
+	edgeProbability func(chanID uint64, amt lnwire.MilliSatoshi) float64
+
+	// costModel is the cost model used to weigh the edges of the path.
+	costModel CostModel
+}
+
+// newPathLimits returns the pathLimits for the passed optional restrictions.
+func newPathLimits(restrictions *RestrictParams,
+	finalCLTVDelta uint16) *pathLimits {
+
+	limits := &pathLimits{
+		feeLimit:       noFeeLimit,
+		cltvLimit:      noCltvLimit,
+		finalCLTVDelta: finalCLTVDelta,
+		costModel:      DefaultCostModel,
+	}
+	if restrictions == nil {
+		return limits
+	}
+
+	if restrictions.FeeLimit != nil {
+		limits.feeLimit = *restrictions.FeeLimit
+	}
+	if restrictions.CltvLimit != nil {
+		limits.cltvLimit = *restrictions.CltvLimit
+	}
+	limits.outgoingChannelID = restrictions.OutgoingChannelID
+	limits.lastHop = restrictions.LastHop
+	if restrictions.CostModel != nil {
+		limits.costModel = *restrictions.CostModel
+	}
+
+	return limits
+}
+
+// checkRoute returns an error if the passed route, with its fees and
+// time-locks fully computed, exceeds the limits. As path finding estimates the
+// fees of a path based on the amount sent to the target, a path that is within
+// the limits might still result in a route that isn't.
+func (l *pathLimits) checkRoute(route *Route, currentHeight uint32) error {
+	if route.TotalFees > l.feeLimit {
+		return newErrf(ErrNoRouteFound, "route fee of %v exceeds the "+
+			"fee limit of %v", route.TotalFees, l.feeLimit)
+	}
+
+	timeLock := route.TotalTimeLock - currentHeight
+	if timeLock > l.cltvLimit {
+		return newErrf(ErrNoRouteFound, "route time-lock of %v "+
+			"exceeds the cltv limit of %v", timeLock, l.cltvLimit)
+	}
+
+	return nil
+}
+
+// spurLimits returns the limits that a spur path branching off the passed root
+// path must adhere to, such that the combined path adheres to l. The root path
+// is expected to start with the self-edge of the source node, as inserted by
+// findPaths. False is returned if the root path already exceeds the limits.
+func (l *pathLimits) spurLimits(rootPath []*ChannelHop,
+	amt lnwire.MilliSatoshi) (*pathLimits, bool) {
+
+	var (
+		fee      lnwire.MilliSatoshi
+		timeLock uint32
+	)
+	for i, hop := range rootPath {
+		// The self-edge of the source node doesn't carry a policy, so
+		// it can be skipped.
+		if i == 0 {
+			continue
+		}
+
+		// As within findPath, no fee is paid for the first edge out of
+		// the source node.
+		if i > 1 {
+			fee += computeFee(amt, hop.ChannelEdgePolicy)
+		}
+		timeLock += uint32(hop.TimeLockDelta)
+	}
+
+	if fee > l.feeLimit || timeLock > l.cltvLimit {
+		return nil, false
+	}
+
+	return &pathLimits{
+		feeLimit:          l.feeLimit - fee,
+		cltvLimit:         l.cltvLimit - timeLock,
+		finalCLTVDelta:    l.finalCLTVDelta,
+		outgoingChannelID: l.outgoingChannelID,
+		lastHop:           l.lastHop,
+		spurSearch:        len(rootPath) > 1,
+		usedCapacity:      l.usedCapacity,
+		edgeProbability:   l.edgeProbability,
+		costModel:         l.costModel,
+	}, true
+}
+
 // ChannelHop is an intermediate hop within the network with a greater
 // multi-hop payment route. This struct contains the relevant routing policy of
 // the particular edge, as well as the total capacity, and origin chain of the
@@ -47,6 +258,28 @@ type ChannelHop struct {
 	*channeldb.ChannelEdgePolicy
 }
 
+// HopHint is a routing hint that contains the minimum information of a channel
+// required for an intermediate hop in a route to forward the payment to the
+// next. This should be ideally used for private channels, since they are not
+// publicly advertised to the network for routing.
+type HopHint struct {
+	// NodeID is the public key of the node at the start of the channel.
+	NodeID *btcec.PublicKey
+
+	// ChannelID is the unique identifier of the channel.
+	ChannelID uint64
+
+	// FeeBaseMSat is the base fee of the channel in millisatoshis.
+	FeeBaseMSat uint32
+
+	// FeeProportionalMillionths is the fee rate, in millionths of a
+	// satoshi, for every satoshi sent through the channel.
+	FeeProportionalMillionths uint32
+
+	// CLTVExpiryDelta is the time-lock delta of the channel.
+	CLTVExpiryDelta uint16
+}
+
 // Hop represents the forwarding details at a particular position within the
 // final route. This struct houses the values necessary to create the HTLC
 // which will travel along this hop, and also encode the per-hop payload
@@ -69,6 +302,16 @@ type Hop struct {
 	// payment, this difference nets the hop fees for forwarding the
 	// payment.
 	Fee lnwire.MilliSatoshi
+
+	// TLVPayload is true if the node of this hop advertises support for
+	// TLV encoded hop payloads. Only the payload of the final hop is then
+	// TLV encoded, all other hops use the legacy, fixed size format.
+	TLVPayload bool
+
+	// MPPTotalAmount is the total amount of the multi-path payment that
+	// the route carries a shard of. It's only set for the final hop of
+	// such a route, which must use a TLV payload to receive it.
+	MPPTotalAmount lnwire.MilliSatoshi
 }
 
 // computeFee computes the fee to forward an HTLC of `amt` milli-satoshis over
@@ -177,6 +420,18 @@ func (r *Route) containsNode(v Vertex) bool {
 	return ok
 }
 
+// channelAmount returns the amount that the route forwards over the channel
+// with the given ID, or zero if the channel isn't part of the route.
+func (r *Route) channelAmount(chanID uint64) lnwire.MilliSatoshi {
+	for _, hop := range r.Hops {
+		if hop.Channel.ChannelID == chanID {
+			return hop.AmtToForward
+		}
+	}
+
+	return 0
+}
+
 // containsChannel returns true if a channel is present in the target route,
 // and false otherwise. The passed chanID should be the converted uint64 form
 // of lnwire.ShortChannelID.
@@ -186,37 +441,165 @@ func (r *Route) containsChannel(chanID uint64) bool {
 }
 
 // ToHopPayloads converts a complete route into the series of per-hop payloads
-// that is to be encoded within each HTLC using an opaque Sphinx packet.
-func (r *Route) ToHopPayloads() []sphinx.HopData {
-	hopPayloads := make([]sphinx.HopData, len(r.Hops))
+// that is to be encoded within each HTLC using an opaque Sphinx packet. The
+// payload of each hop consists of one or more onion frames, all of which are
+// addressed to the hop. If a keysend preimage is passed, it's carried to the
+// final hop.
+//
+// NOTE: Only the final hop receives a TLV encoded payload, and only if it
+// advertises support for them. The sphinx package preserves just the next
+// address, amount and CLTV fields of each frame, which the legacy payload of
+// an intermediate hop fills entirely, leaving no room for the
+// TLVPayloadNextHop sentinel. Intermediate hops therefore always receive
+// legacy payloads, even if they advertise support for TLV payloads.
+func (r *Route) ToHopPayloads(keysendPreimage *[32]byte) ([][]sphinx.HopData,
+	error) {
+
+	hopPayloads := make([][]sphinx.HopData, len(r.Hops))
 
 	// For each hop encoded within the route, we'll convert the hop struct
-	// to the matching per-hop payload struct as used by the sphinx
+	// to the matching per-hop payload frames as used by the sphinx
 	// package.
 	for i, hop := range r.Hops {
-		hopPayloads[i] = sphinx.HopData{
-			// TODO(roasbeef): properly set realm, make sphinx type
-			// an enum actually?
-			Realm:         0,
-			ForwardAmount: uint64(hop.AmtToForward),
-			OutgoingCltv:  hop.OutgoingTimeLock,
-		}
+		isFinal := i == len(r.Hops)-1
 
 		// As a base case, the next hop is set to all zeroes in order
 		// to indicate that the "last hop" as no further hops after it.
-		nextHop := uint64(0)
-
 		// If we aren't on the last hop, then we set the "next address"
 		// field to be the channel that directly follows it.
-		if i != len(r.Hops)-1 {
+		nextHop := uint64(0)
+		var preimage *[32]byte
+		if isFinal {
+			preimage = keysendPreimage
+		} else {
 			nextHop = r.Hops[i+1].Channel.ChannelID
 		}
 
-		binary.BigEndian.PutUint64(hopPayloads[i].NextAddress[:],
-			nextHop)
+		var (
+			frames []sphinx.HopData
+			err    error
+		)
+		switch {
+		case hop.TLVPayload && isFinal:
+			frames, err = hop.tlvPayload(preimage)
+
+		case hop.MPPTotalAmount != 0:
+			return nil, fmt.Errorf("hop %v can't receive the mpp "+
+				"total without a tlv payload", i)
+
+		default:
+			frames, err = hop.legacyPayload(nextHop, preimage)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		hopPayloads[i] = frames
 	}
 
-	return hopPayloads
+	return hopPayloads, nil
+}
+
+// legacyPayload returns the fixed size payload of the hop. If a keysend
+// preimage is passed, then the payload points to the additional frames
+// carrying the preimage, which are addressed to the hop itself.
+func (h *Hop) legacyPayload(nextHop uint64,
+	keysendPreimage *[32]byte) ([]sphinx.HopData, error) {
+
+	hopData := sphinx.HopData{
+		Realm:         0,
+		ForwardAmount: uint64(h.AmtToForward),
+		OutgoingCltv:  h.OutgoingTimeLock,
+	}
+	if keysendPreimage != nil {
+		nextHop = htlcswitch.KeysendNextHop.ToUint64()
+	}
+	binary.BigEndian.PutUint64(hopData.NextAddress[:], nextHop)
+
+	if keysendPreimage == nil {
+		return []sphinx.HopData{hopData}, nil
+	}
+
+	keysendHopData, err := htlcswitch.NewKeysendHopData(*keysendPreimage)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]sphinx.HopData{hopData}, keysendHopData...), nil
+}
+
+// tlvPayload returns the TLV encoded payload of the final hop. The TLV stream
+// is prefixed with its length, and split across as many frames as needed. The
+// first frame carries the TLVPayloadNextHop sentinel in its next address,
+// followed by the start of the payload.
+func (h *Hop) tlvPayload(keysendPreimage *[32]byte) ([]sphinx.HopData,
+	error) {
+
+	records := []tlv.Record{
+		tlv.NewTUint64Record(
+			htlcswitch.AmtOnionType, uint64(h.AmtToForward),
+		),
+		tlv.NewTUint32Record(
+			htlcswitch.LockTimeOnionType, h.OutgoingTimeLock,
+		),
+	}
+	if h.MPPTotalAmount != 0 {
+		records = append(records, tlv.NewTUint64Record(
+			htlcswitch.MPPOnionType, uint64(h.MPPTotalAmount),
+		))
+	}
+	if keysendPreimage != nil {
+		records = append(records, tlv.NewBytes32Record(
+			htlcswitch.KeysendOnionType, *keysendPreimage,
+		))
+	}
+
+	var stream bytes.Buffer
+	if err := tlv.Encode(&stream, records); err != nil {
+		return nil, err
+	}
+
+	var payload bytes.Buffer
+	err := binary.Write(
+		&payload, binary.BigEndian, htlcswitch.TLVPayloadNextHop.ToUint64(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := tlv.WriteBigSize(&payload, uint64(stream.Len())); err != nil {
+		return nil, err
+	}
+	if _, err := stream.WriteTo(&payload); err != nil {
+		return nil, err
+	}
+
+	var frames []sphinx.HopData
+	data := payload.Bytes()
+	for len(data) > 0 {
+		frameLen := htlcswitch.FrameDataSize
+		if len(data) < frameLen {
+			frameLen = len(data)
+		}
+
+		frame, err := htlcswitch.NewFrameHopData(
+			htlcswitch.TLVPayloadRealm, data[:frameLen],
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+		data = data[frameLen:]
+	}
+
+	return frames, nil
+}
+
+// supportsTLVPayload returns true if the passed node advertises support for
+// TLV encoded hop payloads within its node announcement.
+func supportsTLVPayload(node *channeldb.LightningNode) bool {
+	return node != nil && node.Features != nil &&
+		node.Features.HasFeature(lnwire.TLVOnionPayloadOptional)
 }
 
 // newRoute returns a fully valid route between the source and target that's
@@ -316,6 +699,7 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 			Channel:      edge,
 			AmtToForward: amtToForward,
 			Fee:          fee,
+			TLVPayload:   supportsTLVPayload(edge.Node),
 		}
 
 		route.TotalFees += nextHop.Fee
@@ -374,6 +758,66 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 	return route, nil
 }
 
+// NewRouteFromHops creates a new Route from the passed set of hops, which were
+// constructed by the caller rather than through path finding. The total fees
+// of the route are derived from the passed total amount and the amount that
+// arrives at the final hop. An error is returned if the hops don't form a
+// consistent route.
+//
+// NOTE: The passed slice of hops MUST be sorted in forward order: from the
+// source to the target node of the route.
+func NewRouteFromHops(amtToSend lnwire.MilliSatoshi, timeLock uint32,
+	sourceVertex Vertex, hops []*Hop) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("route must have at least one hop")
+	}
+
+	route := &Route{
+		Hops:          hops,
+		TotalTimeLock: timeLock,
+		TotalAmount:   amtToSend,
+		nodeIndex:     make(map[Vertex]struct{}),
+		chanIndex:     make(map[uint64]struct{}),
+		nextHopMap:    make(map[Vertex]*ChannelHop),
+		prevHopMap:    make(map[Vertex]*ChannelHop),
+	}
+
+	// With the route created, we'll populate the indexes used to look up
+	// the nodes and channels of the route, exactly as newRoute does.
+	for i, hop := range hops {
+		if hop == nil || hop.Channel == nil || hop.Channel.ChannelEdgePolicy == nil ||
+			hop.Channel.Node == nil {
+
+			return nil, fmt.Errorf("hop %v has no channel edge", i)
+		}
+
+		// The payload format of each hop is negotiated from the
+		// features of its node, exactly as newRoute does.
+		hop.TLVPayload = supportsTLVPayload(hop.Channel.Node)
+
+		v := Vertex(hop.Channel.Node.PubKeyBytes)
+		route.nodeIndex[v] = struct{}{}
+		route.chanIndex[hop.Channel.ChannelID] = struct{}{}
+		route.prevHopMap[v] = hop.Channel
+
+		if i != len(hops)-1 {
+			route.nextHopMap[v] = hops[i+1].Channel
+		}
+	}
+	route.nextHopMap[sourceVertex] = hops[0].Channel
+
+	finalHop := hops[len(hops)-1]
+	if amtToSend < finalHop.AmtToForward {
+		return nil, fmt.Errorf("total amount %v is below the amount "+
+			"%v that arrives at the final hop", amtToSend,
+			finalHop.AmtToForward)
+	}
+	route.TotalFees = amtToSend - finalHop.AmtToForward
+
+	return route, nil
+}
+
 // Vertex is a simple alias for the serialization of a compressed Bitcoin
 // public key.
 type Vertex [33]byte
@@ -399,29 +843,45 @@ type edgeWithPrev struct {
 }
 
 // edgeWeight computes the weight of an edge. This value is used when searching
-// for the shortest path within the channel graph between two nodes. Currently
-// a component is just 1 + the cltv delta value required at this hop, this
-// value should be tuned with experimental and empirical data. We'll also
-// factor in the "pure fee" through this hop, using the square of this fee as
-// part of the weighting. The goal here is to bias more heavily towards fee
-// ranking, and fallback to a time-lock based value in the case of a fee tie.
+// for the shortest path within the channel graph between two nodes. The weight
+// combines the fee, the time-lock and the probability of success of the edge
+// as defined by the passed cost model. By default, the square of the "pure"
+// fee through this hop dominates the weight, biasing heavily towards fee
+// ranking, and falling back to a time-lock based value in the case of a fee
+// tie.
 //
-// TODO(roasbeef): compute robust weight metric
-func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
+// The probability that the edge is able to forward the amount is factored in
+// as well. Each expected failed attempt is weighted as if the attempt cost of
+// the model were paid as a fee, which for a success probability p amounts to
+// (1/p - 1) times that weight. An edge that is certain to succeed therefore
+// isn't penalized at all.
+func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy,
+	probability float64, costModel *CostModel) int64 {
+
 	// First, we'll compute the "pure" fee through this hop. We say pure,
 	// as this may not be what's ultimately paid as fees are properly
 	// calculated backwards, while we're going in the reverse direction.
-	pureFee := computeFee(amt, e)
+	pureFee := float64(computeFee(amt, e))
 
 	// We'll then square the fee itself in order to more heavily weight our
 	// edge selection to bias towards lower fees.
-	feeWeight := int64(pureFee * pureFee)
+	feeWeight := costModel.FeeWeight * pureFee * pureFee
 
-	// The final component is then 1 plus the timelock delta.
-	timeWeight := int64(1 + e.TimeLockDelta)
+	// The time-lock component is 1 plus the weighted timelock delta.
+	timeWeight := 1 + costModel.TimeLockRiskFactor*float64(e.TimeLockDelta)
 
-	// The final weighting is: fee^2 + time_lock_delta.
-	return feeWeight + timeWeight
+	// The expected cost of the failed attempts through this edge.
+	attemptCost := float64(costModel.AttemptCost)
+	failureWeight := attemptCost * attemptCost * (1/probability - 1)
+
+	// The final weighting is the sum of all components. We'll cap it such
+	// that the distance of a path within the hop limit can't overflow.
+	weight := feeWeight + timeWeight + failureWeight
+	if weight > maxEdgeWeight {
+		return maxEdgeWeight
+	}
+
+	return int64(weight)
 }
 
 // findPath attempts to find a path from the source node within the
@@ -432,18 +892,22 @@ func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
 // from the target to the source.
-func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+//
+// The additionalEdges map allows the caller to splice in ephemeral edges that
+// aren't part of the channel graph, such as those derived from the routing
+// hints of an invoice. The map is keyed by the vertex at the start of each
+// edge.
+//
+// If limits is non-nil, any edge that would cause the path to violate them is
+// pruned during the search. The fee of each edge is estimated based on amt.
+func findPath(graph routingGraph,
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy,
 	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
 	ignoredNodes map[Vertex]struct{}, ignoredEdges map[uint64]struct{},
-	amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, limits *pathLimits) ([]*ChannelHop, error) {
 
-	var err error
-	if tx == nil {
-		tx, err = graph.Database().Begin(false)
-		if err != nil {
-			return nil, err
-		}
-		defer tx.Rollback()
+	if limits == nil {
+		limits = newPathLimits(nil, 0)
 	}
 
 	// First we'll initialize an empty heap which'll help us to quickly
@@ -455,9 +919,7 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// map for the node set with a distance of "infinity".  We also mark
 	// add the node to our set of unvisited nodes.
 	distance := make(map[Vertex]nodeWithDist)
-	if err := graph.ForEachNode(tx, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
-		// TODO(roasbeef): with larger graph can just use disk seeks
-		// with a visited map
+	if err := graph.forEachNode(func(node *channeldb.LightningNode) error {
 		distance[Vertex(node.PubKeyBytes)] = nodeWithDist{
 			dist: infinity,
 			node: node,
@@ -467,6 +929,31 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		return nil, err
 	}
 
+	// We'll also include all the nodes found within the additional edges
+	// that are not known to us yet in the distance map. The end node of
+	// each of these edges may not be part of the graph either, so we'll
+	// add those as well.
+	for vertex, edges := range additionalEdges {
+		if _, ok := distance[vertex]; !ok {
+			distance[vertex] = nodeWithDist{
+				dist: infinity,
+				node: &channeldb.LightningNode{PubKeyBytes: vertex},
+			}
+		}
+
+		for _, edge := range edges {
+			endVertex := Vertex(edge.Node.PubKeyBytes)
+			if _, ok := distance[endVertex]; ok {
+				continue
+			}
+
+			distance[endVertex] = nodeWithDist{
+				dist: infinity,
+				node: edge.Node,
+			}
+		}
+	}
+
 	// TODO(roasbeef): also add path caching
 	//  * similar to route caching, but doesn't factor in the amount
 
@@ -484,10 +971,148 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	heap.Push(&nodeHeap, distance[sourceVertex])
 
 	targetBytes := target.SerializeCompressed()
+	targetVertex := NewVertex(target)
 
 	// We'll use this map as a series of "previous" hop pointers. So to get
 	// to `Vertex` we'll take the edge that it's mapped to within `prev`.
 	prev := make(map[Vertex]edgeWithPrev)
+
+	// processEdge is a helper closure that will be used to make sure edges
+	// satisfy our specific requirements, and relax the distance to the end
+	// node of the edge if so.
+	processEdge := func(outEdge *channeldb.ChannelEdgePolicy,
+		capacity btcutil.Amount, pivot Vertex) {
+
+		v := Vertex(outEdge.Node.PubKeyBytes)
+
+		// If the outgoing edge is currently disabled, then we'll stop
+		// here, as we shouldn't attempt to route through it.
+		edgeFlags := lnwire.ChanUpdateFlag(outEdge.Flags)
+		if edgeFlags&lnwire.ChanUpdateDisabled == lnwire.ChanUpdateDisabled {
+			return
+		}
+
+		// If this Vertex or edge has been black listed, then we'll skip
+		// exploring this edge during this iteration.
+		if _, ok := ignoredNodes[v]; ok {
+			return
+		}
+		if _, ok := ignoredEdges[outEdge.ChannelID]; ok {
+			return
+		}
+
+		// If the payment must leave through a particular channel of
+		// ours, then we'll skip all other edges out of our own node.
+		if limits.outgoingChannelID != nil && pivot == sourceVertex &&
+			!limits.spurSearch &&
+			outEdge.ChannelID != *limits.outgoingChannelID {
+
+			return
+		}
+
+		// Likewise, if the payment must arrive through a particular
+		// node, then we'll skip all edges into the target that start
+		// at any other node.
+		if limits.lastHop != nil && v == targetVertex &&
+			pivot != *limits.lastHop {
+
+			return
+		}
+
+		// If the target were reached through this edge, the total
+		// time-lock of the path would be the final CLTV delta on top
+		// of the time-lock deltas of all prior edges. As the time-lock
+		// only grows as we move further, we can prune this edge if
+		// that already exceeds our limit.
+		pivotDist := distance[pivot]
+		timeLock := pivotDist.timeLockDelta +
+			uint32(limits.finalCLTVDelta)
+		if timeLock > limits.cltvLimit {
+			return
+		}
+
+		// Similarly, we'll prune this edge if the fee we'll pay for
+		// forwarding over it pushes us over the fee limit. Unless
+		// we're searching for a spur path, no fee is paid for the
+		// edges of the source node, as that's ourselves.
+		fee := pivotDist.fee
+		if pivot != sourceVertex || limits.spurSearch {
+			fee += computeFee(amt, outEdge)
+		}
+		if fee > limits.feeLimit {
+			return
+		}
+
+		// Any capacity of the channel that's already committed to the
+		// other in-flight HTLCs of the payment isn't available to us.
+		availCapacity := capacity
+		if used, ok := limits.usedCapacity[outEdge.ChannelID]; ok {
+			if used.ToSatoshis() >= availCapacity {
+				return
+			}
+			availCapacity -= used.ToSatoshis()
+		}
+
+		// Unless the edge is one of our own channels, whose balance we
+		// know, we'll estimate the probability that it's able to
+		// forward the amount, skipping it if it's very unlikely to.
+		probability := 1.0
+		if limits.edgeProbability != nil &&
+			(pivot != sourceVertex || limits.spurSearch) {
+
+			probability = limits.edgeProbability(
+				outEdge.ChannelID, amt,
+			)
+			if probability < minProbability {
+				return
+			}
+		}
+
+		// Compute the tentative distance to this new channel/edge which
+		// is the distance to our current pivot node plus the weight of
+		// this edge.
+		tempDist := distance[pivot].dist +
+			edgeWeight(
+				amt, outEdge, probability, &limits.costModel,
+			)
+
+		// If this new tentative distance is better than the current
+		// best known distance to this node, then we record the new
+		// better distance, and also populate our "next hop" map with
+		// this edge. We'll also shave off irrelevant edges by adding
+		// the sufficient capacity of an edge and clearing their
+		// min-htlc amount to our relaxation condition.
+		if tempDist < distance[v].dist &&
+			availCapacity >= amt.ToSatoshis() &&
+			amt >= outEdge.MinHTLC &&
+			outEdge.TimeLockDelta != 0 {
+
+			distance[v] = nodeWithDist{
+				dist: tempDist,
+				node: outEdge.Node,
+				fee:  fee,
+				timeLockDelta: pivotDist.timeLockDelta +
+					uint32(outEdge.TimeLockDelta),
+			}
+			prev[v] = edgeWithPrev{
+				// We'll use the *incoming* edge here as we
+				// need to use the routing policy specified by
+				// the node this channel connects to.
+				edge: &ChannelHop{
+					ChannelEdgePolicy: outEdge,
+					Capacity:          capacity,
+				},
+				prevNode: pivot,
+			}
+
+			// Add this new node to our heap as we'd like to
+			// further explore down this edge.
+			heap.Push(&nodeHeap, distance[v])
+		}
+
+		// TODO(roasbeef): return min HTLC as error in end?
+	}
+
 	for nodeHeap.Len() != 0 {
 		// Fetch the node within the smallest distance from our source
 		// from the heap.
@@ -505,75 +1130,25 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		// examine all the outgoing edge (channels) from this node to
 		// further our graph traversal.
 		pivot := Vertex(bestNode.PubKeyBytes)
-		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
+		err := graph.forEachNodeChannel(pivot, func(
 			edgeInfo *channeldb.ChannelEdgeInfo,
-			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
-
-			v := Vertex(outEdge.Node.PubKeyBytes)
-
-			// If the outgoing edge is currently disabled, then
-			// we'll stop here, as we shouldn't attempt to route
-			// through it.
-			edgeFlags := lnwire.ChanUpdateFlag(outEdge.Flags)
-			if edgeFlags&lnwire.ChanUpdateDisabled == lnwire.ChanUpdateDisabled {
-				return nil
-			}
-
-			// If this Vertex or edge has been black listed, then
-			// we'll skip exploring this edge during this
-			// iteration.
-			if _, ok := ignoredNodes[v]; ok {
-				return nil
-			}
-			if _, ok := ignoredEdges[outEdge.ChannelID]; ok {
-				return nil
-			}
-
-			// Compute the tentative distance to this new
-			// channel/edge which is the distance to our current
-			// pivot node plus the weight of this edge.
-			tempDist := distance[pivot].dist + edgeWeight(amt, outEdge)
-
-			// If this new tentative distance is better than the
-			// current best known distance to this node, then we
-			// record the new better distance, and also populate
-			// our "next hop" map with this edge. We'll also shave
-			// off irrelevant edges by adding the sufficient
-			// capacity of an edge and clearing their min-htlc
-			// amount to our relaxation condition.
-			if tempDist < distance[v].dist &&
-				edgeInfo.Capacity >= amt.ToSatoshis() &&
-				amt >= outEdge.MinHTLC &&
-				outEdge.TimeLockDelta != 0 {
-
-				distance[v] = nodeWithDist{
-					dist: tempDist,
-					node: outEdge.Node,
-				}
-				prev[v] = edgeWithPrev{
-					// We'll use the *incoming* edge here
-					// as we need to use the routing policy
-					// specified by the node this channel
-					// connects to.
-					edge: &ChannelHop{
-						ChannelEdgePolicy: outEdge,
-						Capacity:          edgeInfo.Capacity,
-					},
-					prevNode: bestNode.PubKeyBytes,
-				}
-
-				// Add this new node to our heap as we'd like
-				// to further explore down this edge.
-				heap.Push(&nodeHeap, distance[v])
-			}
-
-			// TODO(roasbeef): return min HTLC as error in end?
+			outEdge, _ *channeldb.ChannelEdgePolicy) error {
 
+			processEdge(outEdge, edgeInfo.Capacity, pivot)
 			return nil
 		})
 		if err != nil {
 			return nil, err
 		}
+
+		// Then, we'll examine all the additional edges from the node
+		// we're currently visiting. Since we don't know the capacity
+		// of these private channels, we'll assume they were selected
+		// as routing hints due to having enough capacity for the
+		// payment.
+		for _, edge := range additionalEdges[pivot] {
+			processEdge(edge, btcutil.MaxSatoshi, pivot)
+		}
 	}
 
 	// If the target node isn't found in the prev hop map, then a path
@@ -629,9 +1204,17 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 // make our inner path finding algorithm aware of our k-shortest paths
 // algorithm, rather than attempting to use an unmodified path finding
 // algorithm in a block box manner.
-func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+//
+// If limits is non-nil, only paths that adhere to them are returned.
+func findPaths(graph routingGraph,
+	additionalEdges map[Vertex][]*channeldb.ChannelEdgePolicy,
 	source *channeldb.LightningNode, target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, numPaths uint32) ([][]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, numPaths uint32,
+	limits *pathLimits) ([][]*ChannelHop, error) {
+
+	if limits == nil {
+		limits = newPathLimits(nil, 0)
+	}
 
 	ignoredEdges := make(map[uint64]struct{})
 	ignoredVertexes := make(map[Vertex]struct{})
@@ -647,7 +1230,8 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// selfNode) to the target destination that's capable of carrying amt
 	// satoshis along the path before fees are calculated.
 	startingPath, err := findPath(
-		tx, graph, source, target, ignoredVertexes, ignoredEdges, amt,
+		graph, additionalEdges, source, target, ignoredVertexes,
+		ignoredEdges, amt, limits,
 	)
 	if err != nil {
 		log.Errorf("Unable to find path: %v", err)
@@ -715,13 +1299,23 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 				ignoredVertexes[Vertex(node)] = struct{}{}
 			}
 
+			// The spur path must adhere to what's left of our
+			// limits after the fees and time-lock of the root
+			// path have been accounted for. If the root path
+			// already exceeds them, there's no need to search any
+			// further from this spur node.
+			spurLimits, ok := limits.spurLimits(rootPath, amt)
+			if !ok {
+				continue
+			}
+
 			// With the edges that are part of our root path, and
 			// the Vertexes (other than the spur path) within the
 			// root path removed, we'll attempt to find another
 			// shortest path from the spur node to the destination.
 			spurPath, err := findPath(
-				tx, graph, spurNode, target, ignoredVertexes,
-				ignoredEdges, amt,
+				graph, additionalEdges, spurNode, target,
+				ignoredVertexes, ignoredEdges, amt, spurLimits,
 			)
 
 			// If we weren't able to find a path, we'll continue to
@@ -764,3 +1358,140 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 
 	return shortestPaths, nil
 }
+
+// findCircularPath attempts to find a path from the source node back to
+// itself, capable of supporting a payment of `amt` value. The path must leave
+// the source node through the outgoing channel, and return to it through the
+// incoming channel, which allows the source node to shift funds between its
+// channels. The section of the path between the peers of the two channels is
+// found using findPath, and never crosses the source node itself.
+//
+// If limits is non-nil, any edge that would cause the path to violate them is
+// pruned during the search. Any outgoing channel or last hop restrictions
+// within the limits are ignored, as both ends of the path are fixed.
+func findCircularPath(graph routingGraph,
+	sourceNode *channeldb.LightningNode, outgoingChanID,
+	incomingChanID uint64, amt lnwire.MilliSatoshi,
+	limits *pathLimits) ([]*ChannelHop, error) {
+
+	if outgoingChanID == incomingChanID {
+		return nil, newErr(ErrNoPathFound, "outgoing and incoming "+
+			"channel must differ")
+	}
+
+	sourceVertex := Vertex(sourceNode.PubKeyBytes)
+	firstHop, firstPeer, err := fetchSourceChannelHop(
+		graph, sourceVertex, outgoingChanID, true,
+	)
+	if err != nil {
+		return nil, err
+	}
+	lastHop, lastPeer, err := fetchSourceChannelHop(
+		graph, sourceVertex, incomingChanID, false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The path between the peers of both channels is searched with the
+	// peer of the outgoing channel as the source. As that peer charges a
+	// fee for forwarding, the search is carried out as a spur search.
+	innerLimits := newPathLimits(nil, 0)
+	if limits != nil {
+		*innerLimits = *limits
+	}
+	innerLimits.outgoingChannelID = nil
+	innerLimits.lastHop = nil
+	innerLimits.spurSearch = true
+
+	// If both channels are with the same peer, then the path doesn't
+	// need any edges in between.
+	var innerPath []*ChannelHop
+	if firstPeer != lastPeer {
+		target, err := btcec.ParsePubKey(lastPeer[:], btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		ignoredNodes := map[Vertex]struct{}{
+			sourceVertex: {},
+		}
+		innerPath, err = findPath(
+			graph, nil, firstHop.Node, target, ignoredNodes,
+			nil, amt, innerLimits,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	path := make([]*ChannelHop, 0, len(innerPath)+2)
+	path = append(path, firstHop)
+	path = append(path, innerPath...)
+	path = append(path, lastHop)
+
+	if len(path) > HopLimit {
+		return nil, newErr(ErrMaxHopsExceeded, "potential path has "+
+			"too many hops")
+	}
+
+	return path, nil
+}
+
+// fetchSourceChannelHop returns the edge of the given channel of the source
+// node as a ChannelHop, along with the peer at the other end of the channel.
+// If outgoing is true, then the edge leading away from the source node is
+// returned, otherwise the edge leading towards it.
+func fetchSourceChannelHop(graph routingGraph, source Vertex,
+	chanID uint64, outgoing bool) (*ChannelHop, Vertex, error) {
+
+	edgeInfo, policy1, policy2, err := graph.fetchChannel(chanID)
+	if err != nil {
+		return nil, Vertex{}, fmt.Errorf("unable to fetch channel "+
+			"%v: %v", chanID, err)
+	}
+
+	// The first policy is the one of the first node of the channel, and
+	// leads to the second node.
+	var (
+		peer   Vertex
+		policy *channeldb.ChannelEdgePolicy
+	)
+	switch source {
+	case edgeInfo.NodeKey1Bytes:
+		peer = edgeInfo.NodeKey2Bytes
+		policy = policy1
+		if !outgoing {
+			policy = policy2
+		}
+
+	case edgeInfo.NodeKey2Bytes:
+		peer = edgeInfo.NodeKey1Bytes
+		policy = policy2
+		if !outgoing {
+			policy = policy1
+		}
+
+	default:
+		return nil, Vertex{}, newErrf(ErrNoPathFound, "channel %v is "+
+			"not a channel of the source node", chanID)
+	}
+	if policy == nil {
+		return nil, Vertex{}, newErrf(ErrNoPathFound, "no policy "+
+			"known for channel %v", chanID)
+	}
+
+	edgeFlags := lnwire.ChanUpdateFlag(policy.Flags)
+	if edgeFlags&lnwire.ChanUpdateDisabled == lnwire.ChanUpdateDisabled {
+		return nil, Vertex{}, newErrf(ErrNoPathFound, "channel %v is "+
+			"disabled", chanID)
+	}
+
+	hop := &ChannelHop{
+		ChannelEdgePolicy: policy,
+		Capacity:          edgeInfo.Capacity,
+		Chain:             edgeInfo.ChainHash,
+	}
+
+	return hop, peer, nil
+}
@@ -10,12 +10,17 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/lnwire/tlv"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
@@ -40,6 +45,16 @@ const (
 	// implementations will use in order to ensure that they're calculating
 	// the payload for each hop in path properly.
 	specExampleFilePath = "testdata/spec_example.json"
+
+	// multiPathGraphFilePath is a file path which stores a graph where
+	// the target can only be paid in full by splitting the payment across
+	// its two incoming channels.
+	multiPathGraphFilePath = "testdata/multi_path_graph.json"
+
+	// costModelGraphFilePath is a file path which stores a graph with a
+	// cheap but slow, and an expensive but fast path to the target, such
+	// that the cost model decides which of them is used.
+	costModelGraphFilePath = "testdata/cost_model_graph.json"
 )
 
 var (
@@ -311,8 +326,10 @@ func TestBasicGraphPathFinding(t *testing.T) {
 
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["sophon"]
-	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil,
+	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -355,10 +372,21 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// Next, we'll assert that the "next hop" field in each route payload
 	// properly points to the channel ID that the HTLC should be forwarded
 	// along.
-	hopPayloads := route.ToHopPayloads()
-	if len(hopPayloads) != 2 {
+	payloadFrames, err := route.ToHopPayloads(nil)
+	if err != nil {
+		t.Fatalf("unable to create hop payloads: %v", err)
+	}
+	if len(payloadFrames) != 2 {
 		t.Fatalf("incorrect number of hop payloads: expected %v, got %v",
-			2, len(hopPayloads))
+			2, len(payloadFrames))
+	}
+	hopPayloads := make([]sphinx.HopData, len(payloadFrames))
+	for i, frames := range payloadFrames {
+		if len(frames) != 1 {
+			t.Fatalf("expected single frame for hop %v, got %v",
+				i, len(frames))
+		}
+		hopPayloads[i] = frames[0]
 	}
 
 	// The first hop should point to the second hop.
@@ -451,8 +479,10 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	// exist two possible paths in the graph, but the shorter (1 hop) path
 	// should be selected.
 	target = aliases["luoji"]
-	path, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil,
+	)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -485,6 +515,284 @@ func TestBasicGraphPathFinding(t *testing.T) {
 	}
 }
 
+// readTLVPayloadFrames reassembles the TLV stream carried within the passed
+// onion frames, which must start with the TLV payload sentinel.
+func readTLVPayloadFrames(t *testing.T, frames []sphinx.HopData) []tlv.Record {
+	var data bytes.Buffer
+	for _, frame := range frames {
+		if frame.Realm != htlcswitch.TLVPayloadRealm {
+			t.Fatalf("expected realm %v, got %v",
+				htlcswitch.TLVPayloadRealm, frame.Realm)
+		}
+
+		var b bytes.Buffer
+		if err := frame.Encode(&b); err != nil {
+			t.Fatalf("unable to encode frame: %v", err)
+		}
+		data.Write(b.Bytes()[1 : 1+htlcswitch.FrameDataSize])
+	}
+
+	var nextHop uint64
+	if err := binary.Read(&data, binary.BigEndian, &nextHop); err != nil {
+		t.Fatalf("unable to read sentinel: %v", err)
+	}
+	if nextHop != htlcswitch.TLVPayloadNextHop.ToUint64() {
+		t.Fatalf("expected tlv payload sentinel, got %v", nextHop)
+	}
+
+	payloadLen, err := tlv.ReadBigSize(&data)
+	if err != nil {
+		t.Fatalf("unable to read payload length: %v", err)
+	}
+	if uint64(data.Len()) < payloadLen {
+		t.Fatalf("payload of %v bytes exceeds frames", payloadLen)
+	}
+
+	records, err := tlv.Decode(bytes.NewReader(data.Next(int(payloadLen))))
+	if err != nil {
+		t.Fatalf("unable to decode payload: %v", err)
+	}
+
+	return records
+}
+
+// TestToHopPayloadsTLV tests that a final hop advertising support for TLV
+// payloads receives a TLV encoded payload, while intermediate hops always
+// receive a legacy payload, and that the preimage of a keysend payment is
+// carried to the final hop in either format.
+func TestToHopPayloadsTLV(t *testing.T) {
+	t.Parallel()
+
+	newHop := func(chanID uint64, amt lnwire.MilliSatoshi, timeLock uint32,
+		tlvPayload bool) *Hop {
+
+		return &Hop{
+			Channel: &ChannelHop{
+				ChannelEdgePolicy: &channeldb.ChannelEdgePolicy{
+					ChannelID: chanID,
+				},
+			},
+			AmtToForward:     amt,
+			OutgoingTimeLock: timeLock,
+			TLVPayload:       tlvPayload,
+		}
+	}
+
+	var preimage [32]byte
+	if _, err := prand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+
+	// The first and final hop support TLV payloads, while the second hop
+	// doesn't.
+	route := &Route{
+		Hops: []*Hop{
+			newHop(1, 3000, 120, true),
+			newHop(2, 2000, 110, false),
+			newHop(3, 1000, 100, true),
+		},
+	}
+	payloads, err := route.ToHopPayloads(&preimage)
+	if err != nil {
+		t.Fatalf("unable to create hop payloads: %v", err)
+	}
+	if len(payloads) != 3 {
+		t.Fatalf("expected 3 hop payloads, got %v", len(payloads))
+	}
+
+	// Both intermediate hops should receive a single legacy frame pointing
+	// to the next channel, regardless of their support for TLV payloads.
+	for i, hop := range route.Hops[:2] {
+		if len(payloads[i]) != 1 || payloads[i][0].Realm != 0 {
+			t.Fatalf("expected single legacy frame, got %v",
+				spew.Sdump(payloads[i]))
+		}
+		legacy := payloads[i][0]
+		nextHop := binary.BigEndian.Uint64(legacy.NextAddress[:])
+		if nextHop != route.Hops[i+1].Channel.ChannelID ||
+			legacy.ForwardAmount != uint64(hop.AmtToForward) ||
+			legacy.OutgoingCltv != hop.OutgoingTimeLock {
+
+			t.Fatalf("invalid legacy payload: %v",
+				spew.Sdump(legacy))
+		}
+	}
+
+	// The final TLV payload carries the keysend preimage, which doesn't
+	// fit within a single frame.
+	if len(payloads[2]) != 3 {
+		t.Fatalf("expected three frames, got %v", len(payloads[2]))
+	}
+	records := readTLVPayloadFrames(t, payloads[2])
+	expectedRecords := []tlv.Record{
+		tlv.NewTUint64Record(htlcswitch.AmtOnionType, 1000),
+		tlv.NewTUint32Record(htlcswitch.LockTimeOnionType, 100),
+		tlv.NewBytes32Record(htlcswitch.KeysendOnionType, preimage),
+	}
+	if !reflect.DeepEqual(records, expectedRecords) {
+		t.Fatalf("expected records %v, got %v", expectedRecords,
+			records)
+	}
+
+	// Without a preimage, the final TLV payload fits within a single
+	// frame.
+	payloads, err = route.ToHopPayloads(nil)
+	if err != nil {
+		t.Fatalf("unable to create hop payloads: %v", err)
+	}
+	if len(payloads[2]) != 1 {
+		t.Fatalf("expected single frame, got %v", len(payloads[2]))
+	}
+	records = readTLVPayloadFrames(t, payloads[2])
+	if !reflect.DeepEqual(records, expectedRecords[:2]) {
+		t.Fatalf("expected records %v, got %v", expectedRecords[:2],
+			records)
+	}
+
+	// If the route carries a shard of a multi-path payment, then the
+	// final TLV payload also carries the total amount of the payment.
+	route.Hops[2].MPPTotalAmount = 5000
+	payloads, err = route.ToHopPayloads(nil)
+	if err != nil {
+		t.Fatalf("unable to create hop payloads: %v", err)
+	}
+	records = readTLVPayloadFrames(t, payloads[2])
+	expectedRecords = append(expectedRecords[:2:2], tlv.NewTUint64Record(
+		htlcswitch.MPPOnionType, 5000,
+	))
+	if !reflect.DeepEqual(records, expectedRecords) {
+		t.Fatalf("expected records %v, got %v", expectedRecords,
+			records)
+	}
+
+	// A legacy payload can't carry the total amount.
+	route.Hops[2].TLVPayload = false
+	if _, err := route.ToHopPayloads(nil); err == nil {
+		t.Fatalf("expected mpp total without tlv payload to fail")
+	}
+	route.Hops[2].MPPTotalAmount = 0
+
+	// If the final hop uses a legacy payload instead, the preimage is
+	// carried within the additional frames that the payload points to.
+	payloads, err = route.ToHopPayloads(&preimage)
+	if err != nil {
+		t.Fatalf("unable to create hop payloads: %v", err)
+	}
+	if len(payloads[2]) != 3 {
+		t.Fatalf("expected three frames, got %v", len(payloads[2]))
+	}
+	nextHop := binary.BigEndian.Uint64(payloads[2][0].NextAddress[:])
+	if nextHop != htlcswitch.KeysendNextHop.ToUint64() {
+		t.Fatalf("expected keysend next hop, got %v", nextHop)
+	}
+	keysendFrames, err := htlcswitch.NewKeysendHopData(preimage)
+	if err != nil {
+		t.Fatalf("unable to create keysend frames: %v", err)
+	}
+	if !reflect.DeepEqual(payloads[2][1:], keysendFrames) {
+		t.Fatalf("expected keysend frames %v, got %v",
+			spew.Sdump(keysendFrames), spew.Sdump(payloads[2][1:]))
+	}
+}
+
+// TestPathFindingWithAdditionalEdges asserts that we are able to find paths to
+// nodes that do not exist in the graph by way of hop hints. We also test that
+// the path can support custom channel policies for these edges.
+func TestPathFindingWithAdditionalEdges(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+
+	// In this test, we'll test that we're able to find paths through
+	// private channels when providing them as additional edges in our path
+	// finding algorithm. To do so, we'll create a new node, doge, and
+	// create a private channel between it and songoku. We'll then attempt
+	// to find a path from our source node, roasbeef, to doge.
+	dogePrivKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate doge private key: %v", err)
+	}
+	dogePubKey := dogePrivKey.PubKey()
+
+	doge := &channeldb.LightningNode{}
+	doge.AddPubKey(dogePubKey)
+	doge.Alias = "doge"
+
+	// Create the channel edge going from songoku to doge and include it in
+	// our map of additional edges.
+	songokuToDoge := &channeldb.ChannelEdgePolicy{
+		Node:                      doge,
+		ChannelID:                 1337,
+		FeeBaseMSat:               1,
+		FeeProportionalMillionths: 1000,
+		TimeLockDelta:             9,
+	}
+
+	additionalEdges := map[Vertex][]*channeldb.ChannelEdgePolicy{
+		NewVertex(aliases["songoku"]): {songokuToDoge},
+	}
+
+	// We should now be able to find a path from roasbeef to doge.
+	path, err := findPath(
+		newTestGraphCache(t, graph), additionalEdges, sourceNode,
+		dogePubKey, nil, nil, paymentAmt, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to find private path to doge: %v", err)
+	}
+
+	// The path should represent the following hops:
+	//	roasbeef -> songoku -> doge
+	if len(path) != 2 {
+		t.Fatalf("expected path length of 2, instead was: %v", len(path))
+	}
+	if path[0].Node.Alias != "songoku" {
+		t.Fatalf("expected first hop to be songoku, instead was: %v",
+			path[0].Node.Alias)
+	}
+	if path[1].Node.Alias != "doge" {
+		t.Fatalf("expected second hop to be doge, instead was: %v",
+			path[1].Node.Alias)
+	}
+
+	// The private edge should carry the policy specified within the hint
+	// so the route is able to pay the proper fee to songoku.
+	sourceVertex := Vertex(sourceNode.PubKeyBytes)
+	route, err := newRoute(paymentAmt, sourceVertex, path, 100, 1)
+	if err != nil {
+		t.Fatalf("unable to create route: %v", err)
+	}
+	expectedFee := computeFee(paymentAmt, songokuToDoge)
+	if route.TotalFees != expectedFee {
+		t.Fatalf("expected total fees of %v, instead have %v",
+			expectedFee, route.TotalFees)
+	}
+
+	// Finally, if we ignore the private channel, then we should no longer
+	// be able to find a path to doge.
+	ignoredEdges := map[uint64]struct{}{
+		songokuToDoge.ChannelID: {},
+	}
+	_, err = findPath(
+		newTestGraphCache(t, graph), additionalEdges, sourceNode,
+		dogePubKey, nil, ignoredEdges, paymentAmt, nil,
+	)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("expected ErrNoPathFound, instead got: %v", err)
+	}
+}
+
 func TestKShortestPathFinding(t *testing.T) {
 	t.Parallel()
 
@@ -510,7 +818,8 @@ func TestKShortestPathFinding(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["luoji"]
 	paths, err := findPaths(
-		nil, graph, sourceNode, target, paymentAmt, 100,
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		paymentAmt, 100, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find paths between roasbeef and "+
@@ -548,6 +857,373 @@ func TestKShortestPathFinding(t *testing.T) {
 	assertExpectedPath(paths[1], "roasbeef", "satoshi", "luoji")
 }
 
+// TestPathFindingLimits tests that paths exceeding the fee or time-lock limits
+// of a payment are pruned during path finding.
+func TestPathFindingLimits(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	sourceVertex := Vertex(sourceNode.PubKeyBytes)
+
+	const (
+		startingHeight = 100
+		finalHopCLTV   = 1
+	)
+
+	// In our basic_graph.json, the cheapest path from roasbeef to sophon
+	// goes through songoku, who charges a fee of 10 msat plus 1000 ppm.
+	// The path through phamnuwen is far more expensive. For a payment of
+	// 100 sat, the fee of the cheapest path is thus 110 msat, and its
+	// total time-lock is the final CLTV delta plus the delta of 1 of the
+	// first channel.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+
+	feeLimit := lnwire.MilliSatoshi(110)
+	cltvLimit := uint32(2)
+	limits := newPathLimits(&RestrictParams{
+		FeeLimit:  &feeLimit,
+		CltvLimit: &cltvLimit,
+	}, finalHopCLTV)
+
+	path, err := findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target, nil,
+		nil, paymentAmt, limits,
+	)
+	if err != nil {
+		t.Fatalf("unable to find path: %v", err)
+	}
+	route, err := newRoute(
+		paymentAmt, sourceVertex, path, startingHeight, finalHopCLTV,
+	)
+	if err != nil {
+		t.Fatalf("unable to create route: %v", err)
+	}
+	if err := limits.checkRoute(route, startingHeight); err != nil {
+		t.Fatalf("route exceeds limits: %v", err)
+	}
+	if route.Hops[0].Channel.Node.Alias != "songoku" {
+		t.Fatalf("expected route through songoku, got %v",
+			route.Hops[0].Channel.Node.Alias)
+	}
+
+	// Lowering either of the limits by one should leave us without a
+	// path to sophon.
+	feeLimit--
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target, nil,
+		nil, paymentAmt, newPathLimits(
+			&RestrictParams{FeeLimit: &feeLimit}, finalHopCLTV,
+		),
+	)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("expected ErrNoPathFound, got %v", err)
+	}
+
+	cltvLimit--
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target, nil,
+		nil, paymentAmt, newPathLimits(
+			&RestrictParams{CltvLimit: &cltvLimit}, finalHopCLTV,
+		),
+	)
+	if !IsError(err, ErrNoPathFound) {
+		t.Fatalf("expected ErrNoPathFound, got %v", err)
+	}
+
+	// There are two paths from roasbeef to luo ji: a direct one, and one
+	// through satoshi. With a fee limit of zero, only the direct path
+	// should be found, as we don't pay any fees to ourselves.
+	feeLimit = 0
+	paths, err := findPaths(
+		newTestGraphCache(t, graph), nil, sourceNode,
+		aliases["luoji"], paymentAmt, 100, newPathLimits(
+			&RestrictParams{FeeLimit: &feeLimit}, finalHopCLTV,
+		),
+	)
+	if err != nil {
+		t.Fatalf("unable to find paths: %v", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("expected a single path, instead %v were found",
+			len(paths))
+	}
+	if len(paths[0]) != 2 || paths[0][1].Node.Alias != "luoji" {
+		t.Fatalf("expected direct path to luo ji")
+	}
+}
+
+// TestPathFindingRestrictions tests that the outgoing channel and last hop
+// restrictions of a payment are respected during path finding.
+func TestPathFindingRestrictions(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	// In our basic_graph.json, roasbeef can reach sophon either through
+	// songoku, which is the cheapest path, or through phamnuwen.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+
+	const (
+		songokuChan   = 12345
+		phamnuwenChan = 999991
+	)
+	phamnuwen := NewVertex(aliases["phamnuwen"])
+
+	assertPath := func(restrictions *RestrictParams,
+		expectedFirstHop string) {
+
+		t.Helper()
+
+		path, err := findPath(
+			newTestGraphCache(t, graph), nil, sourceNode, target,
+			nil, nil, paymentAmt, newPathLimits(restrictions, 1),
+		)
+		if expectedFirstHop == "" {
+			if !IsError(err, ErrNoPathFound) {
+				t.Fatalf("expected ErrNoPathFound, got %v", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unable to find path: %v", err)
+		}
+
+		if path[0].Node.Alias != expectedFirstHop {
+			t.Fatalf("expected path through %v, got %v",
+				expectedFirstHop, path[0].Node.Alias)
+		}
+	}
+
+	// Without any restrictions, the path through songoku is used.
+	assertPath(nil, "songoku")
+
+	// Forcing the payment to leave through our channel with phamnuwen, or
+	// to arrive through phamnuwen, should result in the more expensive
+	// path.
+	outgoingChan := uint64(phamnuwenChan)
+	assertPath(
+		&RestrictParams{OutgoingChannelID: &outgoingChan}, "phamnuwen",
+	)
+	assertPath(&RestrictParams{LastHop: &phamnuwen}, "phamnuwen")
+
+	// Leaving through our channel with songoku while arriving through
+	// phamnuwen isn't possible.
+	outgoingChan = songokuChan
+	assertPath(&RestrictParams{
+		OutgoingChannelID: &outgoingChan,
+		LastHop:           &phamnuwen,
+	}, "")
+}
+
+// TestPathFindingCostModel tests that the path chosen by path finding changes
+// as the weights of the cost model change.
+func TestPathFindingCostModel(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, aliases, err := parseTestGraph(costModelGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	// In our cost_model_graph.json, roasbeef can reach sophon either
+	// through songoku, who charges a fee of 1 sat but requires a time-lock
+	// delta of 144 blocks, or through luoji, who charges a fee of 2 sat
+	// but only requires a time-lock delta of 9 blocks.
+	const songokuChan = 3495345
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := aliases["sophon"]
+
+	// unreliableSongoku estimates that the channel of songoku is only able
+	// to forward half of the payments.
+	unreliableSongoku := func(chanID uint64,
+		_ lnwire.MilliSatoshi) float64 {
+
+		if chanID == songokuChan {
+			return 0.5
+		}
+		return 1
+	}
+
+	testCases := []struct {
+		name            string
+		costModel       *CostModel
+		edgeProbability func(uint64, lnwire.MilliSatoshi) float64
+		expectedHop     string
+	}{
+		{
+			// By default, the fee dominates the weight.
+			name:        "default",
+			expectedHop: "songoku",
+		},
+		{
+			// Weighing the time-lock heavily favors the faster
+			// path.
+			name: "time-lock risk",
+			costModel: &CostModel{
+				FeeWeight:          1,
+				TimeLockRiskFactor: 100000,
+			},
+			expectedHop: "luoji",
+		},
+		{
+			// If fees aren't taken into account at all, then the
+			// time-lock decides.
+			name: "no fee weight",
+			costModel: &CostModel{
+				TimeLockRiskFactor: 1,
+			},
+			expectedHop: "luoji",
+		},
+		{
+			// Without an attempt cost, an unreliable channel isn't
+			// penalized.
+			name: "no attempt cost",
+			costModel: &CostModel{
+				FeeWeight:          1,
+				TimeLockRiskFactor: 1,
+			},
+			edgeProbability: unreliableSongoku,
+			expectedHop:     "songoku",
+		},
+		{
+			// With the default attempt cost, the expected failure
+			// of songoku's channel outweighs its lower fee.
+			name:            "attempt cost",
+			edgeProbability: unreliableSongoku,
+			expectedHop:     "luoji",
+		},
+	}
+
+	for _, testCase := range testCases {
+		limits := newPathLimits(
+			&RestrictParams{CostModel: testCase.costModel}, 1,
+		)
+		limits.edgeProbability = testCase.edgeProbability
+
+		path, err := findPath(
+			newTestGraphCache(t, graph), nil, sourceNode, target,
+			nil, nil, paymentAmt, limits,
+		)
+		if err != nil {
+			t.Fatalf("%v: unable to find path: %v", testCase.name,
+				err)
+		}
+
+		if path[0].Node.Alias != testCase.expectedHop {
+			t.Fatalf("%v: expected path through %v, got %v",
+				testCase.name, testCase.expectedHop,
+				path[0].Node.Alias)
+		}
+	}
+}
+
+// TestFindCircularPath tests that we're able to find a path from ourselves
+// back to ourselves, which leaves and arrives through the given channels.
+func TestFindCircularPath(t *testing.T) {
+	t.Parallel()
+
+	graph, cleanUp, _, err := parseTestGraph(basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create graph: %v", err)
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+
+	const (
+		songokuChan   = 12345
+		phamnuwenChan = 999991
+		luojiChan     = 689530843
+		satoshiChan   = 2340213491
+		sophonChan    = 3495345
+	)
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+
+	assertPath := func(outgoingChan, incomingChan uint64,
+		expectedHops ...string) {
+
+		t.Helper()
+
+		path, err := findCircularPath(
+			newTestGraphCache(t, graph), sourceNode, outgoingChan,
+			incomingChan, paymentAmt, nil,
+		)
+		if len(expectedHops) == 0 {
+			if !IsError(err, ErrNoPathFound) {
+				t.Fatalf("expected ErrNoPathFound, got %v", err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("unable to find path: %v", err)
+		}
+
+		if len(path) != len(expectedHops) {
+			t.Fatalf("expected path of length %v, got %v",
+				len(expectedHops), len(path))
+		}
+		for i, hop := range path {
+			if hop.Node.Alias != expectedHops[i] {
+				t.Fatalf("expected hop %v to be %v, got %v", i,
+					expectedHops[i], hop.Node.Alias)
+			}
+		}
+		if path[0].ChannelID != outgoingChan {
+			t.Fatalf("expected path to leave through channel "+
+				"%v, got %v", outgoingChan, path[0].ChannelID)
+		}
+		if path[len(path)-1].ChannelID != incomingChan {
+			t.Fatalf("expected path to arrive through channel "+
+				"%v, got %v", incomingChan,
+				path[len(path)-1].ChannelID)
+		}
+	}
+
+	// Leaving through songoku and arriving through phamnuwen requires a
+	// path through sophon, as the path may not cross ourselves.
+	assertPath(
+		songokuChan, phamnuwenChan,
+		"songoku", "sophon", "phamnuwen", "roasbeef",
+	)
+
+	// Luo ji and satoshi have a channel between them.
+	assertPath(luojiChan, satoshiChan, "luoji", "satoshi", "roasbeef")
+
+	// Using the same channel in both directions isn't possible, nor is
+	// using a channel that isn't ours.
+	assertPath(songokuChan, songokuChan)
+	assertPath(sophonChan, phamnuwenChan)
+}
+
 func TestNewRoutePathTooLong(t *testing.T) {
 	t.Skip()
 
@@ -572,8 +1248,10 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// We start by confirming that routing a payment 20 hops away is possible.
 	// Alice should be able to find a valid route to ursula.
 	target := aliases["ursula"]
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil,
+	)
 	if err != nil {
 		t.Fatalf("path should have been found")
 	}
@@ -581,8 +1259,10 @@ func TestNewRoutePathTooLong(t *testing.T) {
 	// Vincent is 21 hops away from Alice, and thus no valid route should be
 	// presented to Alice.
 	target = aliases["vincent"]
-	path, err := findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, paymentAmt)
+	path, err := findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, paymentAmt, nil,
+	)
 	if err == nil {
 		t.Fatalf("should not have been able to find path, supposed to be "+
 			"greater than 20 hops, found route with %v hops",
@@ -621,8 +1301,10 @@ func TestPathNotAvailable(t *testing.T) {
 		t.Fatalf("unable to parse pubkey: %v", err)
 	}
 
-	_, err = findPath(nil, graph, sourceNode, unknownNode, ignoredVertexes,
-		ignoredEdges, 100)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, unknownNode,
+		ignoredVertexes, ignoredEdges, 100, nil,
+	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("path shouldn't have been found: %v", err)
 	}
@@ -655,8 +1337,10 @@ func TestPathInsufficientCapacity(t *testing.T) {
 	target := aliases["sophon"]
 
 	payAmt := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil,
+	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -683,8 +1367,10 @@ func TestRouteFailMinHTLC(t *testing.T) {
 	// attempt should fail.
 	target := aliases["songoku"]
 	payAmt := lnwire.MilliSatoshi(10)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil,
+	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -711,8 +1397,10 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 	// succeed without issue, and return a single path.
 	target := aliases["songoku"]
 	payAmt := lnwire.NewMSatFromSatoshis(10000)
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil,
+	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
 	}
@@ -730,8 +1418,10 @@ func TestRouteFailDisabledEdge(t *testing.T) {
 
 	// Now, if we attempt to route through that edge, we should get a
 	// failure as it is no longer eligible.
-	_, err = findPath(nil, graph, sourceNode, target, ignoredVertexes,
-		ignoredEdges, payAmt)
+	_, err = findPath(
+		newTestGraphCache(t, graph), nil, sourceNode, target,
+		ignoredVertexes, ignoredEdges, payAmt, nil,
+	)
 	if !IsError(err, ErrNoPathFound) {
 		t.Fatalf("graph shouldn't be able to support payment: %v", err)
 	}
@@ -782,7 +1472,7 @@ func TestPathFindSpecExample(t *testing.T) {
 	// Query for a route of 4,999,999 mSAT to carol.
 	carol := ctx.aliases["C"]
 	const amt lnwire.MilliSatoshi = 4999999
-	routes, err := ctx.router.FindRoutes(carol, amt, 100)
+	routes, err := ctx.router.FindRoutes(carol, amt, nil, 100)
 	if err != nil {
 		t.Fatalf("unable to find route: %v", err)
 	}
@@ -842,7 +1532,7 @@ func TestPathFindSpecExample(t *testing.T) {
 
 	// We'll now request a route from A -> B -> C.
 	ctx.router.routeCache = make(map[routeTuple][]*Route)
-	routes, err = ctx.router.FindRoutes(carol, amt, 100)
+	routes, err = ctx.router.FindRoutes(carol, amt, nil, 100)
 	if err != nil {
 		t.Fatalf("unable to find routes: %v", err)
 	}
@@ -0,0 +1,297 @@
+package routing
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+const (
+	// maxLiquidityProbes is the maximum number of additional probes that
+	// are sent along a route that isn't able to carry the full probed
+	// amount, in order to narrow down the amount it's able to carry. Each
+	// of these probes halves the range the amount is known to be in.
+	maxLiquidityProbes = 10
+)
+
+// ProbeHop is the outcome of probing a single hop of a route.
+type ProbeHop struct {
+	// ChannelID is the short channel ID of the channel leading into the
+	// hop's node.
+	ChannelID uint64
+
+	// PubKey is the public key of the hop's node.
+	PubKey Vertex
+
+	// MaxAmt is the largest amount that was observed to be forwarded over
+	// the channel to the hop's node. It's zero if none of the probes
+	// reached the node.
+	MaxAmt lnwire.MilliSatoshi
+}
+
+// ProbeResult is the outcome of probing a single route.
+type ProbeResult struct {
+	// Route is the probed route, carrying the full probed amount.
+	Route *Route
+
+	// Success is true if a probe of the full amount reached the
+	// destination, which is signalled by the destination failing the HTLC
+	// as it doesn't know its payment hash.
+	Success bool
+
+	// FailureMessage is the failure returned for the probe of the full
+	// amount. It's nil if the probe succeeded.
+	FailureMessage lnwire.FailureMessage
+
+	// BottleneckChannel is the short channel ID of the channel that lacked
+	// the liquidity to forward the full amount, or zero if no such channel
+	// was identified.
+	BottleneckChannel uint64
+
+	// Hops holds the outcome for each hop of the route, in the same order
+	// as the hops of the route.
+	Hops []ProbeHop
+}
+
+// ProbeRoutes estimates whether a payment of the passed amount is able to
+// reach the target, without actually paying it. Up to numRoutes candidate
+// routes are found just like FindRoutes does, and an HTLC with a random
+// payment hash is sent along each of them. As nobody knows the preimage of
+// such an HTLC, it's always failed back: if the destination fails it as it
+// doesn't know the payment hash, the amount was able to reach the
+// destination. If a channel along the route fails it due to a lack of
+// liquidity instead, further probes of smaller amounts are sent to estimate
+// the amount the route is able to carry. The outcome of each probe is
+// reported to mission control, such that subsequent payments benefit from
+// it.
+func (r *ChannelRouter) ProbeRoutes(target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, restrictions *RestrictParams,
+	numRoutes uint32, finalCLTVDelta uint16) ([]*ProbeResult, error) {
+
+	routes, err := r.FindRoutes(
+		target, amt, restrictions, numRoutes, finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(len(routes)) > numRoutes {
+		routes = routes[:numRoutes]
+	}
+
+	// We'll fetch the current block height so we can rebuild the routes
+	// for smaller amounts if needed.
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// The session is only used to report the outcome of each probe to
+	// mission control, as the routes to probe are already known.
+	paySession := r.missionControl.NewPaymentSessionFromRoutes(routes)
+
+	results := make([]*ProbeResult, 0, len(routes))
+	for _, route := range routes {
+		result, err := r.probeRoute(
+			paySession, route, uint32(currentHeight),
+			finalCLTVDelta,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// probeRoute probes the passed route, carrying the full amount to probe. If
+// a channel along the route lacks the liquidity to forward the full amount,
+// then the amount the route is able to carry is narrowed down by probing it
+// with smaller amounts.
+func (r *ChannelRouter) probeRoute(paySession *paymentSession, route *Route,
+	currentHeight uint32, finalCLTVDelta uint16) (*ProbeResult, error) {
+
+	result := &ProbeResult{
+		Route: route,
+		Hops:  make([]ProbeHop, len(route.Hops)),
+	}
+	path := make([]*ChannelHop, len(route.Hops))
+	for i, hop := range route.Hops {
+		path[i] = hop.Channel
+		result.Hops[i] = ProbeHop{
+			ChannelID: hop.Channel.ChannelID,
+			PubKey:    Vertex(hop.Channel.Node.PubKeyBytes),
+		}
+	}
+
+	// recordProbe notes the amount forwarded to each of the nodes the
+	// probe along the passed route reached.
+	recordProbe := func(probedRoute *Route, numReached int) {
+		for i := 0; i < numReached; i++ {
+			amt := probedRoute.Hops[i].AmtToForward
+			if amt > result.Hops[i].MaxAmt {
+				result.Hops[i].MaxAmt = amt
+			}
+		}
+	}
+
+	numReached, failure, err := r.sendProbe(paySession, route)
+	if err != nil {
+		return nil, err
+	}
+	recordProbe(route, numReached)
+
+	if isProbeSuccess(route, numReached, failure) {
+		result.Success = true
+		return result, nil
+	}
+	result.FailureMessage = failure
+
+	// Only if a channel lacked the liquidity to forward the full amount,
+	// it makes sense to probe the route with smaller amounts. The
+	// channel leading away from the last node that was reached is the
+	// bottleneck.
+	_, ok := failure.(*lnwire.FailTemporaryChannelFailure)
+	if !ok || numReached >= len(route.Hops) {
+		return result, nil
+	}
+	result.BottleneckChannel = route.Hops[numReached].Channel.ChannelID
+
+	// We'll now binary search the amount the route is able to carry to
+	// the destination, which lies between zero and the full amount.
+	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	lowAmt := lnwire.MilliSatoshi(0)
+	highAmt := route.Hops[len(route.Hops)-1].AmtToForward
+	for i := 0; i < maxLiquidityProbes && highAmt-lowAmt > 1; i++ {
+		select {
+		case <-r.quit:
+			return nil, fmt.Errorf("router shutting down")
+		default:
+		}
+
+		probeAmt := lowAmt + (highAmt-lowAmt)/2
+		probedRoute, err := newRoute(
+			probeAmt, sourceVertex, path, currentHeight,
+			finalCLTVDelta,
+		)
+		if err != nil {
+			break
+		}
+
+		numReached, failure, err := r.sendProbe(
+			paySession, probedRoute,
+		)
+		if err != nil {
+			return nil, err
+		}
+		recordProbe(probedRoute, numReached)
+
+		_, ok := failure.(*lnwire.FailTemporaryChannelFailure)
+		if ok {
+			highAmt = probeAmt
+			continue
+		}
+
+		if !isProbeSuccess(probedRoute, numReached, failure) {
+			// The probe failed for a reason other than a lack of
+			// liquidity, so smaller amounts won't tell us more.
+			break
+		}
+		lowAmt = probeAmt
+	}
+
+	return result, nil
+}
+
+// sendProbe sends an HTLC with a random payment hash along the passed route,
+// and blocks until it's failed back. The number of hops whose node the HTLC
+// reached is returned, along with the failure message the HTLC was failed
+// with. The outcome is reported to mission control.
+func (r *ChannelRouter) sendProbe(paySession *paymentSession,
+	route *Route) (int, lnwire.FailureMessage, error) {
+
+	var paymentHash [32]byte
+	if _, err := rand.Read(paymentHash[:]); err != nil {
+		return 0, nil, err
+	}
+
+	log.Tracef("Probing route with payment hash %x: %v", paymentHash,
+		newLogClosure(func() string {
+			return spew.Sdump(route)
+		}),
+	)
+
+	onionBlob, circuit, err := generateSphinxPacket(
+		route, paymentHash[:], nil,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	htlcAdd := &lnwire.UpdateAddHTLC{
+		Amount:      route.TotalAmount,
+		Expiry:      route.TotalTimeLock,
+		PaymentHash: paymentHash,
+	}
+	copy(htlcAdd.OnionBlob[:], onionBlob)
+
+	attemptID, err := r.cfg.NextPaymentID()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Unlike a payment attempt, a probe isn't recorded with the control
+	// tower, as it never settles.
+	firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+	_, sendErr := r.cfg.SendToSwitch(firstHop, attemptID, htlcAdd, circuit)
+	if sendErr == nil {
+		return 0, nil, fmt.Errorf("probe with payment hash %x was "+
+			"unexpectedly settled", paymentHash)
+	}
+
+	fErr, ok := sendErr.(*htlcswitch.ForwardingError)
+	if !ok {
+		return 0, nil, sendErr
+	}
+
+	// With the failure known, we'll report it to mission control exactly
+	// as we would for a payment attempt.
+	r.processSendError(
+		paySession, route, sendErr,
+		make(map[lnwire.ShortChannelID]struct{}),
+	)
+
+	// Our own node is the source of the failure if the HTLC didn't make
+	// it past the first channel, otherwise it's the node at the end of
+	// one of the hops.
+	numReached := 0
+	if fErr.ErrorSource != nil {
+		errVertex := NewVertex(fErr.ErrorSource)
+		for i, hop := range route.Hops {
+			if Vertex(hop.Channel.Node.PubKeyBytes) == errVertex {
+				numReached = i + 1
+				break
+			}
+		}
+	}
+
+	return numReached, fErr.FailureMessage, nil
+}
+
+// isProbeSuccess returns true if the probe along the route reached the
+// destination, which failed it as it didn't know the payment hash.
+func isProbeSuccess(route *Route, numReached int,
+	failure lnwire.FailureMessage) bool {
+
+	if numReached != len(route.Hops) {
+		return false
+	}
+
+	_, ok := failure.(*lnwire.FailUnknownPaymentHash)
+	return ok
+}
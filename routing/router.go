@@ -3,6 +3,7 @@ package routing
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"runtime"
 	"sort"
 	"sync"
@@ -36,6 +37,14 @@ const (
 	// if we should give up on a payment attempt. This will be used if a
 	// value isn't specified in the LightningNode struct.
 	defaultPayAttemptTimeout = time.Duration(time.Second * 60)
+
+	// maxShards is the maximum number of HTLCs that a payment split across
+	// multiple routes may have in flight at once.
+	maxShards = 16
+
+	// minShardAmt is the smallest amount that a single HTLC of a payment
+	// split across multiple routes may carry.
+	minShardAmt = lnwire.MilliSatoshi(10000 * 1000)
 )
 
 // ChannelGraphSource represents the source of information about the topology
@@ -71,6 +80,10 @@ type ChannelGraphSource interface {
 	// passed channel ID.
 	IsKnownEdge(chanID lnwire.ShortChannelID) bool
 
+	// IsZombieEdge returns true if the graph source has marked the passed
+	// channel ID as a zombie.
+	IsZombieEdge(chanID lnwire.ShortChannelID) bool
+
 	// IsStaleEdgePolicy returns true if the graph source has a channel
 	// edge for the passed channel ID (and flags) that have a more recent
 	// timestamp.
@@ -149,11 +162,35 @@ type Config struct {
 
 	// SendToSwitch is a function that directs a link-layer switch to
 	// forward a fully encoded payment to the first hop in the route
-	// denoted by its public key. A non-nil error is to be returned if the
-	// payment was unsuccessful.
-	SendToSwitch func(firstHop [33]byte, htlcAdd *lnwire.UpdateAddHTLC,
+	// denoted by its public key. The paymentID uniquely identifies the
+	// payment attempt within the switch. A non-nil error is to be returned
+	// if the payment was unsuccessful.
+	SendToSwitch func(firstHop [33]byte, paymentID uint64,
+		htlcAdd *lnwire.UpdateAddHTLC,
+		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
+
+	// GetPaymentResult is a function that blocks until the result of the
+	// payment attempt previously handed to the switch with the given
+	// paymentID is known. The circuit of the attempt is used to decrypt
+	// any failure that is returned. If the switch doesn't know of the
+	// paymentID, then htlcswitch.ErrPaymentIDNotFound is to be returned.
+	GetPaymentResult func(paymentID uint64, paymentHash [32]byte,
 		circuit *sphinx.Circuit) ([sha256.Size]byte, error)
 
+	// NextPaymentID is a function that returns a unique ID to be used for
+	// the next payment attempt handed to the switch.
+	NextPaymentID func() (uint64, error)
+
+	// CleanPaymentResults is a function that signals the switch that it
+	// is safe to delete the stored results of all payment attempts, except
+	// the ones within the passed set.
+	CleanPaymentResults func(keep map[uint64]struct{}) error
+
+	// Control keeps track of the status of ongoing payments, ensuring we
+	// don't pay the same payment hash twice, and that payments in flight
+	// can be resumed across restarts.
+	Control ControlTower
+
 	// ChannelPruneExpiry is the duration used to determine if a channel
 	// should be pruned or not. If the delta between now and when the
 	// channel was last updated is greater than ChannelPruneExpiry, then
@@ -168,19 +205,38 @@ type Config struct {
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
 // prospective routes based on first the destination, and then the target
 // amount. We required the target amount as that will influence the available
-// set of paths for a payment.
+// set of paths for a payment. The restrictions of the query are included as
+// well, as they restrict the set of paths further, along with the cost model
+// that ranks them.
 type routeTuple struct {
-	amt  lnwire.MilliSatoshi
-	dest [33]byte
+	amt               lnwire.MilliSatoshi
+	dest              [33]byte
+	feeLimit          lnwire.MilliSatoshi
+	cltvLimit         uint32
+	outgoingChannelID uint64
+	lastHop           Vertex
+	costModel         CostModel
 }
 
-// newRouteTuple creates a new route tuple from the target and amount.
-func newRouteTuple(amt lnwire.MilliSatoshi, dest []byte) routeTuple {
+// newRouteTuple creates a new route tuple from the target, amount and limits.
+func newRouteTuple(amt lnwire.MilliSatoshi, dest []byte,
+	limits *pathLimits) routeTuple {
+
 	r := routeTuple{
-		amt: amt,
+		amt:       amt,
+		feeLimit:  limits.feeLimit,
+		cltvLimit: limits.cltvLimit,
+		costModel: limits.costModel,
 	}
 	copy(r.dest[:], dest)
 
+	if limits.outgoingChannelID != nil {
+		r.outgoingChannelID = *limits.outgoingChannelID
+	}
+	if limits.lastHop != nil {
+		r.lastHop = *limits.lastHop
+	}
+
 	return r
 }
 
@@ -245,14 +301,20 @@ type ChannelRouter struct {
 	ntfnClientUpdates chan *topologyClientUpdate
 
 	// missionControl is a shared memory of sorts that executions of
-	// payment path finding use in order to remember which vertexes/edges
-	// were pruned from prior attempts. During SendPayment execution,
-	// errors sent by nodes are mapped into a vertex or edge to be pruned.
-	// Each run will then take into account this set of pruned
-	// vertexes/edges to reduce route failure and pass on graph information
-	// gained to the next execution.
+	// payment path finding use in order to remember the outcomes of prior
+	// attempts. During SendPayment execution, errors sent by nodes are
+	// mapped into a failed vertex or edge, while successfully forwarded
+	// HTLCs are recorded for the edges they crossed. Each run will then
+	// take into account this history to reduce route failure and pass on
+	// graph information gained to the next execution.
 	missionControl *missionControl
 
+	// graphCache is an in-memory copy of the channel graph, which path
+	// finding and the traversals of the graph by other sub-systems are
+	// carried out against. Every change the router makes to the graph is
+	// applied to the cache as well.
+	graphCache *graphCache
+
 	// channelEdgeMtx is a mutex we use to make sure we process only one
 	// ChannelEdgePolicy at a time for a given channelID, to ensure
 	// consistency between the various database accesses.
@@ -283,12 +345,25 @@ func New(cfg Config) (*ChannelRouter, error) {
 		return nil, err
 	}
 
+	graphCache, err := newGraphCache(cfg.Graph)
+	if err != nil {
+		return nil, err
+	}
+
+	missionControl, err := newMissionControl(
+		cfg.Graph, graphCache, selfNode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ChannelRouter{
 		cfg:               &cfg,
 		networkUpdates:    make(chan *routingMsg),
 		topologyClients:   make(map[uint64]*topologyClient),
 		ntfnClientUpdates: make(chan *topologyClientUpdate),
-		missionControl:    newMissionControl(cfg.Graph, selfNode),
+		missionControl:    missionControl,
+		graphCache:        graphCache,
 		channelEdgeMtx:    multimutex.NewMutex(),
 		selfNode:          selfNode,
 		routeCache:        make(map[routeTuple][]*Route),
@@ -369,6 +444,13 @@ func (r *ChannelRouter) Start() error {
 		return err
 	}
 
+	// With the graph synchronized, we'll resume tracking any payments
+	// that were still in flight when we last shut down, such that their
+	// final outcome is recorded.
+	if err := r.resumePayments(); err != nil {
+		return err
+	}
+
 	r.wg.Add(1)
 	go r.networkHandler()
 
@@ -451,10 +533,13 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 			"(hash=%v)", pruneHeight, pruneHash)
 		// Prune the graph for every channel that was opened at height
 		// >= pruneHeight.
-		_, err := r.cfg.Graph.DisconnectBlockAtHeight(pruneHeight)
+		removedChans, err := r.cfg.Graph.DisconnectBlockAtHeight(
+			pruneHeight,
+		)
 		if err != nil {
 			return err
 		}
+		r.removeCachedChannels(removedChans)
 
 		pruneHash, pruneHeight, err = r.cfg.Graph.PruneTip()
 		if err != nil {
@@ -516,6 +601,7 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 		if err != nil {
 			return err
 		}
+		r.removeCachedChannels(closedChans)
 
 		numClosed := uint32(len(closedChans))
 		log.Infof("Block %v (height=%v) closed %v channels",
@@ -532,9 +618,11 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 // pruneZombieChans is a method that will be called periodically to prune out
 // any "zombie" channels. We consider channels zombies if *both* edges haven't
 // been updated since our zombie horizon. We do this periodically to keep a
-// health, lively routing table.
+// health, lively routing table. Zombie channels are marked as such within the
+// channel graph rather than deleted, so they can be resurrected if a fresh
+// update for them arrives.
 func (r *ChannelRouter) pruneZombieChans() error {
-	var chansToPrune []wire.OutPoint
+	var chansToPrune []*channeldb.ChannelEdgeInfo
 	chanExpiry := r.cfg.ChannelPruneExpiry
 
 	log.Infof("Examining Channel Graph for zombie channels")
@@ -579,20 +667,14 @@ func (r *ChannelRouter) pruneZombieChans() error {
 
 			// TODO(roasbeef): add ability to delete single
 			// directional edge
-			chansToPrune = append(chansToPrune, info.ChannelPoint)
-
-			// As we're detecting this as a zombie channel, we'll
-			// add this to the set of recently rejected items so we
-			// don't re-accept it shortly after.
-			r.rejectCache[info.ChannelID] = struct{}{}
+			chansToPrune = append(chansToPrune, info)
 		}
 
 		return nil
 	}
 
-	r.rejectMtx.Lock()
-	defer r.rejectMtx.Unlock()
-
+	// Channels which are already marked as zombies aren't visited by the
+	// traversal, so we'll only collect the newly detected zombies.
 	err := r.cfg.Graph.ForEachChannel(filterPruneChans)
 	if err != nil {
 		return fmt.Errorf("Unable to filter local zombie "+
@@ -602,20 +684,75 @@ func (r *ChannelRouter) pruneZombieChans() error {
 	log.Infof("Pruning %v Zombie Channels", len(chansToPrune))
 
 	// With the set zombie-like channels obtained, we'll do another pass to
-	// delete al zombie channels from the channel graph.
+	// mark all of them as zombies within the channel graph, which removes
+	// them from path finding.
 	for _, chanToPrune := range chansToPrune {
-		log.Tracef("Pruning zombie chan ChannelPoint(%v)", chanToPrune)
+		log.Tracef("Marking ChannelPoint(%v) as zombie",
+			chanToPrune.ChannelPoint)
 
-		err := r.cfg.Graph.DeleteChannelEdge(&chanToPrune)
-		if err != nil {
+		r.channelEdgeMtx.Lock(chanToPrune.ChannelID)
+		err := r.cfg.Graph.MarkEdgeZombie(chanToPrune.ChannelID)
+		if err == nil {
+			r.graphCache.removeChannel(chanToPrune.ChannelID)
+		}
+		r.channelEdgeMtx.Unlock(chanToPrune.ChannelID)
+
+		// The channel may have been closed in the meantime, in which
+		// case there's nothing left to mark.
+		if err != nil && err != channeldb.ErrEdgeNotFound {
 			return fmt.Errorf("Unable to prune zombie "+
 				"chans: %v", err)
 		}
 	}
 
+	if len(chansToPrune) > 0 {
+		r.routeCacheMtx.Lock()
+		r.routeCache = make(map[routeTuple][]*Route)
+		r.routeCacheMtx.Unlock()
+	}
+
 	return nil
 }
 
+// resurrectZombieChan marks the zombie channel with the passed ID as live
+// once again, and adds it back to the graph cache so it can be used for path
+// finding.
+//
+// NOTE: This method MUST be called with the channel's edge mutex held.
+func (r *ChannelRouter) resurrectZombieChan(chanID uint64) error {
+	if err := r.cfg.Graph.MarkEdgeLive(chanID); err != nil {
+		return errors.Errorf("unable to mark chan_id=%v as live: %v",
+			chanID, err)
+	}
+
+	info, policy1, policy2, err := r.cfg.Graph.FetchChannelEdgesByID(chanID)
+	if err != nil {
+		return errors.Errorf("unable to fetch chan_id=%v: %v", chanID,
+			err)
+	}
+
+	r.graphCache.addChannel(info)
+	if policy1 != nil {
+		r.graphCache.updatePolicy(policy1)
+	}
+	if policy2 != nil {
+		r.graphCache.updatePolicy(policy2)
+	}
+
+	log.Infof("Resurrected zombie ChannelPoint(%v): chan_id=%v",
+		info.ChannelPoint, chanID)
+
+	return nil
+}
+
+// removeCachedChannels removes the passed channels, which have been removed
+// from the channel graph, from the graph cache as well.
+func (r *ChannelRouter) removeCachedChannels(chans []*channeldb.ChannelEdgeInfo) {
+	for _, info := range chans {
+		r.graphCache.removeChannel(info.ChannelID)
+	}
+}
+
 // networkHandler is the primary goroutine for the ChannelRouter. The roles of
 // this goroutine include answering queries related to the state of the
 // network, pruning the graph on new block notification, applying network
@@ -700,12 +837,15 @@ func (r *ChannelRouter) networkHandler() {
 
 			// Update the channel graph to reflect that this block
 			// was disconnected.
-			_, err := r.cfg.Graph.DisconnectBlockAtHeight(blockHeight)
+			removedChans, err := r.cfg.Graph.DisconnectBlockAtHeight(
+				blockHeight,
+			)
 			if err != nil {
 				log.Errorf("unable to prune graph with stale "+
 					"block: %v", err)
 				continue
 			}
+			r.removeCachedChannels(removedChans)
 
 			// Invalidate the route cache, as some channels might
 			// not be confirmed anymore.
@@ -766,6 +906,7 @@ func (r *ChannelRouter) networkHandler() {
 				log.Errorf("unable to prune routing table: %v", err)
 				continue
 			}
+			r.removeCachedChannels(chansClosed)
 
 			log.Infof("Block %v (height=%v) closed %v channels",
 				chainUpdate.Hash, blockHeight, len(chansClosed))
@@ -898,6 +1039,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			return errors.Errorf("unable to add node %v to the "+
 				"graph: %v", msg.PubKeyBytes, err)
 		}
+		r.graphCache.addNode(msg)
 
 		log.Infof("Updated vertex data for node=%x", msg.PubKeyBytes)
 
@@ -937,6 +1079,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 				return errors.Errorf("unable to add node %v to"+
 					" the graph: %v", node1.PubKeyBytes, err)
 			}
+			r.graphCache.addNode(node1)
 		}
 		_, exists, _ = r.cfg.Graph.HasLightningNode(msg.NodeKey2Bytes)
 		if !exists {
@@ -949,6 +1092,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 				return errors.Errorf("unable to add node %v to"+
 					" the graph: %v", node2.PubKeyBytes, err)
 			}
+			r.graphCache.addNode(node2)
 		}
 
 		// Before we can add the channel to the channel graph, we need
@@ -1010,6 +1154,7 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		if err := r.cfg.Graph.AddChannelEdge(msg); err != nil {
 			return errors.Errorf("unable to add edge: %v", err)
 		}
+		r.graphCache.addChannel(msg)
 
 		invalidateCache = true
 		log.Infof("New channel discovered! Link "+
@@ -1089,6 +1234,22 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			}
 		}
 
+		// If the channel has been marked as a zombie, then the update
+		// needs to be recent enough to bring it back to life, as it
+		// would otherwise be marked as a zombie again right away.
+		isZombie, err := r.cfg.Graph.IsZombieEdge(msg.ChannelID)
+		if err != nil {
+			return errors.Errorf("unable to check zombie index: %v",
+				err)
+		}
+		if isZombie &&
+			time.Since(msg.LastUpdate) >= r.cfg.ChannelPruneExpiry {
+
+			return newErrf(ErrOutdated, "Ignoring outdated update "+
+				"(flags=%v) for zombie chan_id=%v", msg.Flags,
+				msg.ChannelID)
+		}
+
 		if !exists {
 			// Before we can update the channel information, we'll
 			// ensure that the target channel is still open by
@@ -1125,6 +1286,16 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			return err
 		}
 
+		// A fresh update for a zombie channel brings it back to life,
+		// so we'll remove it from the zombie index and add it back to
+		// the graph cache along with its policies.
+		if isZombie {
+			if err := r.resurrectZombieChan(msg.ChannelID); err != nil {
+				return err
+			}
+		}
+		r.graphCache.updatePolicy(msg)
+
 		invalidateCache = true
 		log.Debugf("New channel update applied: %v", spew.Sdump(msg))
 
@@ -1231,9 +1402,10 @@ func pruneChannelFromRoutes(routes []*Route, skipChan uint64) []*Route {
 // of of routes. A route differs from a path in that it has full time-lock and
 // fee information attached. The set of routes return ed may be less than the
 // initial set of paths as it's possible we drop a route if it can't handle the
-// total payment flow after fees are calculated.
-func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta uint16,
-	amt lnwire.MilliSatoshi, currentHeight uint32) ([]*Route, error) {
+// total payment flow after fees are calculated, or if it exceeds the limits.
+func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop,
+	limits *pathLimits, amt lnwire.MilliSatoshi,
+	currentHeight uint32) ([]*Route, error) {
 
 	validRoutes := make([]*Route, 0, len(paths))
 	for _, path := range paths {
@@ -1241,13 +1413,20 @@ func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta
 		// hop in the path as it contains a "self-hop" that is inserted
 		// by our KSP algorithm.
 		route, err := newRoute(
-			amt, source, path[1:], currentHeight, finalCLTVDelta,
+			amt, source, path[1:], currentHeight,
+			limits.finalCLTVDelta,
 		)
 		if err != nil {
 			// TODO(roasbeef): report straw breaking edge?
 			continue
 		}
 
+		// Now that the fees and time-locks of the route are fully
+		// computed, we'll make sure it's still within our limits.
+		if err := limits.checkRoute(route, currentHeight); err != nil {
+			continue
+		}
+
 		// If the path as enough total flow to support the computed
 		// route, then we'll add it to our set of valid routes.
 		validRoutes = append(validRoutes, route)
@@ -1289,8 +1468,13 @@ func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta
 // the required fee and time lock values running backwards along the route. The
 // route that will be ranked the highest is the one with the lowest cumulative
 // fee along the route.
+//
+// The optional restrictions limit the set of returned routes, for instance by
+// their total fee and time-lock. Paths violating these restrictions are pruned
+// while searching the graph.
 func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, numPaths uint32, finalExpiry ...uint16) ([]*Route, error) {
+	amt lnwire.MilliSatoshi, restrictions *RestrictParams,
+	numPaths uint32, finalExpiry ...uint16) ([]*Route, error) {
 
 	var finalCLTVDelta uint16
 	if len(finalExpiry) == 0 {
@@ -1298,6 +1482,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	} else {
 		finalCLTVDelta = finalExpiry[0]
 	}
+	limits := newPathLimits(restrictions, finalCLTVDelta)
 
 	dest := target.SerializeCompressed()
 	log.Debugf("Searching for path to %x, sending %v", dest, amt)
@@ -1305,7 +1490,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// Before attempting to perform a series of graph traversals to find
 	// the k-shortest paths to the destination, we'll first consult our
 	// path cache
-	rt := newRouteTuple(amt, dest)
+	rt := newRouteTuple(amt, dest, limits)
 	r.routeCacheMtx.RLock()
 	routes, ok := r.routeCache[rt]
 	r.routeCacheMtx.RUnlock()
@@ -1325,9 +1510,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// We can short circuit the routing by opportunistically checking to
 	// see if the target vertex event exists in the current graph.
 	targetVertex := NewVertex(target)
-	if _, exists, err := r.cfg.Graph.HasLightningNode(targetVertex); err != nil {
-		return nil, err
-	} else if !exists {
+	if _, exists := r.graphCache.fetchNode(targetVertex); !exists {
 		log.Debugf("Target %x is not in known graph", dest)
 		return nil, newErrf(ErrTargetNotInNetwork, "target not found")
 	}
@@ -1339,25 +1522,16 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 		return nil, err
 	}
 
-	tx, err := r.cfg.Graph.Database().Begin(false)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
-	}
-
 	// Now that we know the destination is reachable within the graph,
 	// we'll execute our KSP algorithm to find the k-shortest paths from
 	// our source to the destination.
 	shortestPaths, err := findPaths(
-		tx, r.cfg.Graph, r.selfNode, target, amt, numPaths,
+		r.graphCache, nil, r.selfNode, target, amt, numPaths, limits,
 	)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	tx.Rollback()
-
 	// Now that we have a set of paths, we'll need to turn them into
 	// *routes* by computing the required time-lock and fee information for
 	// each path. During this process, some paths may be discarded if they
@@ -1365,7 +1539,7 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	// factored in.
 	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
 	validRoutes, err := pathsToFeeSortedRoutes(
-		sourceVertex, shortestPaths, finalCLTVDelta, amt,
+		sourceVertex, shortestPaths, limits, amt,
 		uint32(currentHeight),
 	)
 	if err != nil {
@@ -1387,12 +1561,65 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	return validRoutes, nil
 }
 
+// FindCircularRoute attempts to find a route from ourselves back to ourselves
+// which is able to carry `amt`, leaving through the outgoing channel and
+// arriving through the incoming channel. Paying ourselves over such a route
+// shifts funds from the outgoing to the incoming channel, at the cost of the
+// fees of the nodes in between. Such a route can't be found by FindRoutes, as
+// its source and destination are the same node.
+//
+// Only the fee and time-lock restrictions are taken into account, as the
+// first and last hop of the route are fixed by the passed channels.
+func (r *ChannelRouter) FindCircularRoute(outgoingChanID, incomingChanID uint64,
+	amt lnwire.MilliSatoshi, restrictions *RestrictParams,
+	finalCLTVDelta uint16) (*Route, error) {
+
+	log.Debugf("Searching for circular route from channel %v to "+
+		"channel %v, sending %v", outgoingChanID, incomingChanID, amt)
+
+	// We'll fetch the current block height so we can properly calculate
+	// the required HTLC time locks within the route.
+	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	limits := newPathLimits(restrictions, finalCLTVDelta)
+	path, err := findCircularPath(
+		r.graphCache, r.selfNode, outgoingChanID, incomingChanID, amt,
+		limits,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	route, err := newRoute(
+		amt, sourceVertex, path, uint32(currentHeight), finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// As path finding only estimates the fees of the path, we'll make
+	// sure the fully computed route is still within the limits.
+	if err := limits.checkRoute(route, uint32(currentHeight)); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
 // generateSphinxPacket generates then encodes a sphinx packet which encodes
 // the onion route specified by the passed layer 3 route. The blob returned
 // from this function can immediately be included within an HTLC add packet to
 // be sent to the first hop within the route.
-func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
-	*sphinx.Circuit, error) {
+//
+// If a keysend preimage is passed, then it's carried to the final hop within
+// its payload.
+func generateSphinxPacket(route *Route, paymentHash []byte,
+	keysendPreimage *[32]byte) ([]byte, *sphinx.Circuit, error) {
+
 	// First obtain all the public keys along the route which are contained
 	// in each hop.
 	nodes := make([]*btcec.PublicKey, len(route.Hops))
@@ -1415,10 +1642,31 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 	// Next we generate the per-hop payload which gives each node within
 	// the route the necessary information (fees, CLTV value, etc) to
 	// properly forward the payment.
-	hopPayloads := route.ToHopPayloads()
+	hopPayloads, err := route.ToHopPayloads(keysendPreimage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The payload of a hop may span multiple onion frames, each of which
+	// is addressed to the node of the hop.
+	var (
+		frameNodes   []*btcec.PublicKey
+		frameHopData []sphinx.HopData
+	)
+	for i, frames := range hopPayloads {
+		for _, frame := range frames {
+			frameNodes = append(frameNodes, nodes[i])
+			frameHopData = append(frameHopData, frame)
+		}
+	}
+	if len(frameHopData) > sphinx.NumMaxHops {
+		return nil, nil, fmt.Errorf("route of %v hops requires %v "+
+			"onion frames, exceeding the maximum of %v",
+			len(nodes), len(frameHopData), sphinx.NumMaxHops)
+	}
 
 	log.Tracef("Constructed per-hop payloads for payment_hash=%x: %v",
-		paymentHash[:], spew.Sdump(hopPayloads))
+		paymentHash[:], spew.Sdump(frameHopData))
 
 	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
 	if err != nil {
@@ -1427,8 +1675,8 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 
 	// Next generate the onion routing packet which allows us to perform
 	// privacy preserving source routing across the network.
-	sphinxPacket, err := sphinx.NewOnionPacket(nodes, sessionKey,
-		hopPayloads, paymentHash)
+	sphinxPacket, err := sphinx.NewOnionPacket(frameNodes, sessionKey,
+		frameHopData, paymentHash)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1449,6 +1697,9 @@ func generateSphinxPacket(route *Route, paymentHash []byte) ([]byte,
 		}),
 	)
 
+	// Only the payload of the final hop may span multiple frames, and
+	// errors are always encrypted using its first frame, so the circuit
+	// only covers the hops of the route.
 	return onionBlob.Bytes(), &sphinx.Circuit{
 		SessionKey:  sessionKey,
 		PaymentPath: nodes,
@@ -1476,12 +1727,59 @@ type LightningPayment struct {
 	// used.
 	FinalCLTVDelta *uint16
 
+	// FeeLimit is the maximum total fee that may be paid to the nodes
+	// along the route of the payment. If this value is unspecified, then
+	// the fee isn't limited.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// CltvLimit is the maximum total time-lock of the route of the
+	// payment, expressed as a number of blocks relative to the current
+	// height. This includes the final CLTV delta. If this value is
+	// unspecified, then the time-lock isn't limited.
+	CltvLimit *uint32
+
+	// OutgoingChannelID is the ID of the channel of ours that the payment
+	// must leave through. If this value is unspecified, then any of our
+	// channels may be used.
+	OutgoingChannelID *uint64
+
+	// LastHop is the node that must precede the target in the route of
+	// the payment. If this value is unspecified, then the payment may
+	// arrive through any node.
+	LastHop *Vertex
+
+	// CostModel is the cost model used to weigh the edges of the routes
+	// of the payment. If this value is unspecified, then the
+	// DefaultCostModel is used.
+	CostModel *CostModel
+
 	// PayAttemptTimeout is a timeout value that we'll use to determine
 	// when we should should abandon the payment attempt after consecutive
 	// payment failure. This prevents us from attempting to send a payment
 	// indefinitely.
 	PayAttemptTimeout time.Duration
 
+	// RouteHints represents the different routing hints that can be used
+	// to assist a payment in reaching its destination successfully. These
+	// hints will act as intermediate hops along the route.
+	//
+	// NOTE: This is optional unless required by the payment. When providing
+	// multiple routes, ensure the hop hints within each route are chained
+	// together and sorted in forward order in order to reach the
+	// destination successfully.
+	RouteHints [][]HopHint
+
+	// PaymentRequest is an optional payment request that this payment is
+	// attempting to complete.
+	PaymentRequest []byte
+
+	// KeysendPreimage is an optional preimage chosen by the sender, which
+	// is carried to the destination within the onion. This allows paying
+	// a destination that accepts keysend payments without first obtaining
+	// an invoice from it. If set, the PaymentHash MUST be the hash of the
+	// preimage.
+	KeysendPreimage *[32]byte
+
 	// TODO(roasbeef): add e2e message?
 }
 
@@ -1492,7 +1790,84 @@ type LightningPayment struct {
 // will be returned which describes the path the successful payment traversed
 // within the network to reach the destination. Additionally, the payment
 // preimage will also be returned.
+//
+// Each payment is recorded with the control tower, which rejects the payment
+// if a payment to the same payment hash is already in flight or has succeeded.
+//
+// If no single route is able to carry the full amount, and the destination
+// accepts multi-path payments, then the payment is split into multiple HTLCs
+// sent along different routes. In that case, the returned route is the route
+// of the first HTLC that was settled.
 func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
+	// Before starting the HTLC routing attempt, we'll create a fresh
+	// payment session which will report our errors back to mission
+	// control.
+	paySession := r.missionControl.NewPaymentSession(
+		payment.RouteHints, payment.Target,
+	)
+
+	return r.sendPayment(payment, paySession)
+}
+
+// SendToRoute attempts to send a payment with the given payment hash over
+// the passed set of pre-built routes. The routes are attempted in the order
+// given, skipping any route that crosses a vertex or edge that failed during
+// an earlier attempt. All routes must lead to the same destination, and
+// deliver the same amount to it. Like SendPayment, this function is blocking
+// and returns the preimage along with the route that succeeded.
+func (r *ChannelRouter) SendToRoute(routes []*Route,
+	paymentHash [32]byte) ([32]byte, *Route, error) {
+
+	if len(routes) == 0 {
+		return [32]byte{}, nil, fmt.Errorf("no routes provided")
+	}
+	for i, route := range routes {
+		if len(route.Hops) == 0 {
+			return [32]byte{}, nil, fmt.Errorf("route %v has no "+
+				"hops", i)
+		}
+	}
+
+	// We'll derive the target and amount of the payment from the final
+	// hop of the first route, and ensure that all other routes agree.
+	finalHop := routes[0].Hops[len(routes[0].Hops)-1]
+	target := Vertex(finalHop.Channel.Node.PubKeyBytes)
+	amt := finalHop.AmtToForward
+	for i, route := range routes[1:] {
+		finalHop := route.Hops[len(route.Hops)-1]
+		if Vertex(finalHop.Channel.Node.PubKeyBytes) != target {
+			return [32]byte{}, nil, fmt.Errorf("route %v leads "+
+				"to a different destination", i+1)
+		}
+		if finalHop.AmtToForward != amt {
+			return [32]byte{}, nil, fmt.Errorf("route %v delivers "+
+				"%v instead of %v", i+1,
+				finalHop.AmtToForward, amt)
+		}
+	}
+
+	targetPub, err := btcec.ParsePubKey(target[:], btcec.S256())
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	payment := &LightningPayment{
+		Target:      targetPub,
+		Amount:      amt,
+		PaymentHash: paymentHash,
+	}
+
+	paySession := r.missionControl.NewPaymentSessionFromRoutes(routes)
+
+	return r.sendPayment(payment, paySession)
+}
+
+// sendPayment records the payment with the control tower, and then attempts
+// the routes handed out by the payment session until either the payment
+// succeeds, or a terminal error is encountered.
+func (r *ChannelRouter) sendPayment(payment *LightningPayment,
+	paySession *paymentSession) ([32]byte, *Route, error) {
+
 	log.Tracef("Dispatching route for lightning payment: %v",
 		newLogClosure(func() string {
 			payment.Target.Curve = nil
@@ -1500,6 +1875,46 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		}),
 	)
 
+	// Before any HTLC is sent, we'll record the payment with the control
+	// tower. This ensures we don't pay the same payment hash twice.
+	info := &channeldb.PaymentCreationInfo{
+		PaymentHash:    payment.PaymentHash,
+		Value:          payment.Amount,
+		CreationDate:   time.Now(),
+		PaymentRequest: payment.PaymentRequest,
+	}
+	err := r.cfg.Control.InitPayment(payment.PaymentHash, info)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	preImage, route, reason, err := r.sendPaymentAttempts(
+		payment, paySession,
+	)
+	if err != nil {
+		// The payment failed, so we'll record the reason with the
+		// control tower. If an attempt is still in flight, the payment
+		// is only marked failed once the attempt has been resolved.
+		_, failErr := r.cfg.Control.Fail(payment.PaymentHash, reason)
+		if failErr != nil {
+			log.Errorf("Unable to mark payment %x failed: %v",
+				payment.PaymentHash, failErr)
+		}
+
+		return [32]byte{}, nil, err
+	}
+
+	return preImage, route, nil
+}
+
+// sendPaymentAttempts is the payment loop of sendPayment, which attempts the
+// routes of the payment session until either the payment succeeds, or a
+// terminal error is encountered. In the latter case, the reason the payment
+// failed is returned along with the error.
+func (r *ChannelRouter) sendPaymentAttempts(payment *LightningPayment,
+	paySession *paymentSession) ([32]byte, *Route,
+	channeldb.FailureReason, error) {
+
 	var (
 		preImage  [32]byte
 		sendError error
@@ -1515,7 +1930,7 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 	// calculate the required HTLC time locks within the route.
 	_, currentHeight, err := r.cfg.Chain.GetBestBlock()
 	if err != nil {
-		return preImage, nil, err
+		return preImage, nil, channeldb.FailureReasonError, err
 	}
 
 	var finalCLTVDelta uint16
@@ -1534,11 +1949,6 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 
 	timeoutChan := time.After(payAttemptTimeout)
 
-	// Before starting the HTLC routing attempt, we'll create a fresh
-	// payment session which will report our errors back to mission
-	// control.
-	paySession := r.missionControl.NewPaymentSession()
-
 	// We'll continue until either our payment succeeds, or we encounter a
 	// critical error during path finding.
 	for {
@@ -1551,12 +1961,12 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			errStr := fmt.Sprintf("payment attempt not completed "+
 				"before timeout of %v", payAttemptTimeout)
 
-			return preImage, nil, newErr(
-				ErrPaymentAttemptTimeout, errStr,
-			)
+			return preImage, nil, channeldb.FailureReasonTimeout,
+				newErr(ErrPaymentAttemptTimeout, errStr)
 
 		case <-r.quit:
-			return preImage, nil, fmt.Errorf("router shutting down")
+			return preImage, nil, channeldb.FailureReasonError,
+				fmt.Errorf("router shutting down")
 
 		default:
 			// Fall through if we haven't hit our time limit, or
@@ -1571,15 +1981,36 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			payment, uint32(currentHeight), finalCLTVDelta,
 		)
 		if err != nil {
+			// If no single route is able to carry the full amount,
+			// then we'll attempt to split the payment across
+			// multiple routes instead, given that the destination
+			// accepts that.
+			noPath := IsError(
+				err, ErrNoPathFound, ErrInsufficientCapacity,
+			)
+			if noPath && r.canSplitPayment(payment, paySession) {
+				log.Debugf("No single route found for payment "+
+					"%x, splitting it across multiple "+
+					"routes", payment.PaymentHash)
+
+				return r.sendMultiPathAttempts(
+					payment, paySession,
+					uint32(currentHeight), finalCLTVDelta,
+					timeoutChan, errFailedFeeChans,
+				)
+			}
+
 			// If we're unable to successfully make a payment using
 			// any of the routes we've found, then return an error.
 			if sendError != nil {
-				return [32]byte{}, nil, fmt.Errorf("unable to "+
-					"route payment to destination: %v",
-					sendError)
+				return [32]byte{}, nil,
+					channeldb.FailureReasonNoRoute,
+					fmt.Errorf("unable to route payment "+
+						"to destination: %v", sendError)
 			}
 
-			return preImage, nil, err
+			return preImage, nil, channeldb.FailureReasonNoRoute,
+				err
 		}
 
 		log.Tracef("Attempting to send payment %x, using route: %v",
@@ -1592,10 +2023,10 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		// with the htlcAdd message that we send directly to the
 		// switch.
 		onionBlob, circuit, err := generateSphinxPacket(
-			route, payment.PaymentHash[:],
+			route, payment.PaymentHash[:], payment.KeysendPreimage,
 		)
 		if err != nil {
-			return preImage, nil, err
+			return preImage, nil, channeldb.FailureReasonError, err
 		}
 
 		// Craft an HTLC packet to send to the layer 2 switch. The
@@ -1611,9 +2042,8 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		// Attempt to send this payment through the network to complete
 		// the payment. If this attempt fails, then we'll continue on
 		// to the next available route.
-		firstHop := route.Hops[0].Channel.Node.PubKeyBytes
-		preImage, sendError = r.cfg.SendToSwitch(
-			firstHop, htlcAdd, circuit,
+		preImage, sendError = r.sendPaymentAttempt(
+			payment.PaymentHash, route, htlcAdd, circuit,
 		)
 		if sendError != nil {
 			// An error occurred when attempting to send the
@@ -1623,219 +2053,868 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 			log.Errorf("Attempt to send payment %x failed: %v",
 				payment.PaymentHash, sendError)
 
-			fErr, ok := sendError.(*htlcswitch.ForwardingError)
-			if !ok {
-				return preImage, nil, sendError
+			terminal := r.processSendError(
+				paySession, route, sendError, errFailedFeeChans,
+			)
+			if terminal {
+				return preImage, nil,
+					paymentFailureReason(sendError), sendError
 			}
+			continue
+		}
 
-			errSource := fErr.ErrorSource
+		reportRouteSuccess(paySession, route, nil)
 
-			log.Tracef("node=%x reported failure when sending "+
-				"htlc=%x", errSource.SerializeCompressed(),
-				payment.PaymentHash[:])
+		return preImage, route, 0, nil
+	}
+}
 
-			switch onionErr := fErr.FailureMessage.(type) {
-			// If the end destination didn't know they payment
-			// hash, then we'll terminate immediately.
-			case *lnwire.FailUnknownPaymentHash:
-				return preImage, nil, sendError
+// canSplitPayment returns true if the passed payment may be split across
+// multiple routes. This requires the destination to advertise that it accepts
+// multi-path payments, as well as TLV payloads to carry the total amount of
+// the payment to it, and the payment to be large enough to be split into
+// shards of at least minShardAmt.
+func (r *ChannelRouter) canSplitPayment(payment *LightningPayment,
+	paySession *paymentSession) bool {
 
-			// If we sent the wrong amount to the destination, then
-			// we'll exit early.
-			case *lnwire.FailIncorrectPaymentAmount:
-				return preImage, nil, sendError
+	// Payments over pre-built routes are never split, as the caller
+	// selected the routes to use. Neither are keysend payments, as the
+	// destination creates the invoice from the first HTLC it receives.
+	if paySession.haveRoutes || payment.KeysendPreimage != nil ||
+		payment.Amount < 2*minShardAmt {
 
-			// If the time-lock that was extended to the final node
-			// was incorrect, then we can't proceed.
-			case *lnwire.FailFinalIncorrectCltvExpiry:
-				return preImage, nil, sendError
+		return false
+	}
 
-			// If we crafted an invalid onion payload for the final
-			// node, then we'll exit early.
-			case *lnwire.FailFinalIncorrectHtlcAmount:
-				return preImage, nil, sendError
+	target, err := r.cfg.Graph.FetchLightningNode(payment.Target)
+	if err != nil {
+		return false
+	}
 
-			// Similarly, if the HTLC expiry that we extended to
-			// the final hop expires too soon, then will fail the
-			// payment.
-			//
-			// TODO(roasbeef): can happen to to race condition, try
-			// again with recent block height
-			case *lnwire.FailFinalExpiryTooSoon:
-				return preImage, nil, sendError
-
-			// If we erroneously attempted to cross a chain border,
-			// then we'll cancel the payment.
-			case *lnwire.FailInvalidRealm:
-				return preImage, nil, sendError
-
-			// If we get a notice that the expiry was too soon for
-			// an intermediate node, then we'll prune out the node
-			// that sent us this error, as it doesn't now what the
-			// correct block height is.
-			case *lnwire.FailExpiryTooSoon:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+	return target.Features != nil &&
+		target.Features.HasFeature(lnwire.MultiPathPaymentsOptional) &&
+		supportsTLVPayload(target)
+}
 
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
+// shardResult is the outcome of a single HTLC of a payment that is split
+// across multiple routes.
+type shardResult struct {
+	route    *Route
+	preImage [32]byte
+	err      error
+}
+
+// sendMultiPathAttempts is the payment loop for payments that are split across
+// multiple routes. The payment is divided into shards, each of which is sent
+// as a separate HTLC sharing the payment hash, along its own route. Whenever
+// no route is found for a shard, the shard is halved, and whenever a shard
+// fails, its amount is sent again along a new route. As the destination holds
+// on to the shards until all of them have arrived, the payment succeeds once
+// any of its shards is settled.
+func (r *ChannelRouter) sendMultiPathAttempts(payment *LightningPayment,
+	paySession *paymentSession, height uint32, finalCLTVDelta uint16,
+	timeoutChan <-chan time.Time,
+	errFailedFeeChans map[lnwire.ShortChannelID]struct{}) ([32]byte,
+	*Route, channeldb.FailureReason, error) {
+
+	// Each shard reports its outcome over the results channel. Once we
+	// return, the shards that are still in flight no longer report back,
+	// though their outcome is still recorded with the control tower.
+	results := make(chan *shardResult)
+	done := make(chan struct{})
+	defer close(done)
+
+	var (
+		remaining   = payment.Amount
+		shardAmt    = payment.Amount / 2
+		numInFlight int
+		sendError   error
+	)
+
+	for {
+		// We'll send out shards until the full amount of the payment
+		// is in flight.
+		for remaining > 0 && numInFlight < maxShards {
+			// A shard never leaves less than the minimum shard
+			// amount behind, so the last shard carries whatever
+			// remains.
+			amt := shardAmt
+			if remaining < amt+minShardAmt {
+				amt = remaining
+			}
+
+			route, err := requestShardRoute(
+				payment, paySession, amt, height,
+				finalCLTVDelta,
+			)
+			noPath := IsError(
+				err, ErrNoPathFound, ErrInsufficientCapacity,
+			)
+			switch {
+
+			// If no route can carry the shard, then we'll halve
+			// it, unless it'd drop below the minimum shard
+			// amount.
+			case noPath && amt/2 >= minShardAmt:
+				shardAmt = amt / 2
 				continue
 
-			// If we hit an instance of onion payload corruption or
-			// an invalid version, then we'll exit early as this
-			// shouldn't happen in the typical case.
-			case *lnwire.FailInvalidOnionVersion:
-				return preImage, nil, sendError
-			case *lnwire.FailInvalidOnionHmac:
-				return preImage, nil, sendError
-			case *lnwire.FailInvalidOnionKey:
-				return preImage, nil, sendError
-
-			// If the onion error includes a channel update, and
-			// isn't necessarily fatal, then we'll apply the update
-			// an continue with the rest of the routes.
-			case *lnwire.FailAmountBelowMinimum:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+			// Otherwise, the remaining amount can't be routed, so
+			// the payment fails. Any shards still in flight are
+			// failed back by the destination, as the payment
+			// never completes.
+			case err != nil && sendError != nil:
+				return [32]byte{}, nil,
+					channeldb.FailureReasonNoRoute,
+					fmt.Errorf("unable to route payment "+
+						"to destination: %v", sendError)
+
+			case err != nil:
+				return [32]byte{}, nil,
+					channeldb.FailureReasonNoRoute, err
+			}
 
-				return preImage, nil, sendError
+			log.Tracef("Attempting to send shard of %v of payment "+
+				"%x, using route: %v", amt, payment.PaymentHash,
+				newLogClosure(func() string {
+					return spew.Sdump(route)
+				}),
+			)
 
-			// If we get a failure due to a fee, so we'll apply the
-			// new fee update, and retry our attempt using the
-			// newly updated fees.
-			case *lnwire.FailFeeInsufficient:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
+			onionBlob, circuit, err := generateSphinxPacket(
+				route, payment.PaymentHash[:],
+				payment.KeysendPreimage,
+			)
+			if err != nil {
+				return [32]byte{}, nil,
+					channeldb.FailureReasonError, err
+			}
 
-					pruneEdgeFailure(
-						paySession, route, errSource,
-					)
-				}
+			htlcAdd := &lnwire.UpdateAddHTLC{
+				Amount:      route.TotalAmount,
+				Expiry:      route.TotalTimeLock,
+				PaymentHash: payment.PaymentHash,
+			}
+			copy(htlcAdd.OnionBlob[:], onionBlob)
 
-				// We'll now check to see if we've already
-				// reported a fee related failure for this
-				// node. If so, then we'll actually prune out
-				// the edge for now.
-				chanID := update.ShortChannelID
-				_, ok := errFailedFeeChans[chanID]
-				if ok {
-					pruneEdgeFailure(
-						paySession, route, errSource,
-					)
-					continue
-				}
+			// The capacity along the route is now committed to
+			// this shard, so the routes of the other shards can't
+			// rely on it.
+			paySession.reserveCapacity(route)
+			remaining -= amt
+			numInFlight++
 
-				// Finally, we'll record a fee failure from
-				// this node and move on.
-				errFailedFeeChans[chanID] = struct{}{}
-				continue
+			go func() {
+				preImage, err := r.sendPaymentAttempt(
+					payment.PaymentHash, route, htlcAdd,
+					circuit,
+				)
 
-			// If we get the failure for an intermediate node that
-			// disagrees with our time lock values, then we'll
-			// prune it out for now, and continue with path
-			// finding.
-			case *lnwire.FailIncorrectCltvExpiry:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
+				select {
+				case results <- &shardResult{
+					route:    route,
+					preImage: preImage,
+					err:      err,
+				}:
+				case <-done:
 				}
+			}()
+		}
+
+		select {
+		case result := <-results:
+			numInFlight--
+			paySession.releaseCapacity(result.route)
 
-				pruneVertexFailure(
-					paySession, route, errSource, false,
+			if result.err == nil {
+				reportRouteSuccess(
+					paySession, result.route, nil,
 				)
-				continue
+				return result.preImage, result.route, 0, nil
+			}
 
-			// The outgoing channel that this node was meant to
-			// forward one is currently disabled, so we'll apply
-			// the update and continue.
-			case *lnwire.FailChannelDisabled:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(&update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+			log.Errorf("Attempt to send shard of payment %x "+
+				"failed: %v", payment.PaymentHash, result.err)
 
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+			sendError = result.err
+			terminal := r.processSendError(
+				paySession, result.route, result.err,
+				errFailedFeeChans,
+			)
+			if terminal {
+				return [32]byte{}, nil,
+					paymentFailureReason(result.err),
+					result.err
+			}
 
-			// It's likely that the outgoing channel didn't have
-			// sufficient capacity, so we'll prune this edge for
-			// now, and continue onwards with our path finding.
-			case *lnwire.FailTemporaryChannelFailure:
-				update := onionErr.Update
-				if err := r.applyChannelUpdate(update); err != nil {
-					log.Errorf("unable to apply channel "+
-						"update for onion error: %v", err)
-				}
+			// The amount of the failed shard will be sent again
+			// along a new route.
+			finalHop := result.route.Hops[len(result.route.Hops)-1]
+			remaining += finalHop.AmtToForward
 
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+		case <-timeoutChan:
+			payAttemptTimeout := payment.PayAttemptTimeout
+			if payAttemptTimeout == time.Duration(0) {
+				payAttemptTimeout = defaultPayAttemptTimeout
+			}
 
-			// If the send fail due to a node not having the
-			// required features, then we'll note this error and
-			// continue.
-			case *lnwire.FailRequiredNodeFeatureMissing:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+			errStr := fmt.Sprintf("payment attempt not completed "+
+				"before timeout of %v", payAttemptTimeout)
 
-			// If the send fail due to a node not having the
-			// required features, then we'll note this error and
-			// continue.
-			case *lnwire.FailRequiredChannelFeatureMissing:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+			return [32]byte{}, nil, channeldb.FailureReasonTimeout,
+				newErr(ErrPaymentAttemptTimeout, errStr)
 
-			// If the next hop in the route wasn't known or
-			// offline, we'll prune the _next_ hop from the set of
-			// routes and retry.
-			case *lnwire.FailUnknownNextPeer:
-				pruneVertexFailure(
-					paySession, route, errSource, true,
-				)
-				continue
+		case <-r.quit:
+			return [32]byte{}, nil, channeldb.FailureReasonError,
+				fmt.Errorf("router shutting down")
+		}
+	}
+}
 
-			// If the node wasn't able to forward for which ever
-			// reason, then we'll note this and continue with the
-			// routes.
-			case *lnwire.FailTemporaryNodeFailure:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+// requestShardRoute requests a route from the payment session for a shard of
+// the passed payment that carries the given amount. The fee limit of the
+// payment is divided among its shards in proportion to their amount, and the
+// final hop of the route is handed the total amount of the payment.
+func requestShardRoute(payment *LightningPayment, paySession *paymentSession,
+	amt lnwire.MilliSatoshi, height uint32,
+	finalCLTVDelta uint16) (*Route, error) {
+
+	shard := *payment
+	shard.Amount = amt
+
+	if payment.FeeLimit != nil {
+		feeLimit := new(big.Int).Mul(
+			new(big.Int).SetUint64(uint64(*payment.FeeLimit)),
+			new(big.Int).SetUint64(uint64(amt)),
+		)
+		feeLimit.Div(
+			feeLimit, new(big.Int).SetUint64(uint64(payment.Amount)),
+		)
 
-			case *lnwire.FailPermanentNodeFailure:
-				pruneVertexFailure(
-					paySession, route, errSource, false,
-				)
-				continue
+		shardFeeLimit := lnwire.MilliSatoshi(feeLimit.Uint64())
+		shard.FeeLimit = &shardFeeLimit
+	}
 
-			// If we get a permanent channel or node failure, then
-			// we'll note this (exclude the vertex/edge), and
-			// continue with the rest of the routes.
-			case *lnwire.FailPermanentChannelFailure:
-				pruneEdgeFailure(paySession, route, errSource)
-				continue
+	route, err := paySession.RequestRoute(&shard, height, finalCLTVDelta)
+	if err != nil {
+		return nil, err
+	}
 
-			default:
-				return preImage, nil, sendError
+	// The final hop learns that the HTLC is a shard of a larger payment
+	// through the total amount of the payment.
+	route.Hops[len(route.Hops)-1].MPPTotalAmount = payment.Amount
+
+	return route, nil
+}
+
+// processSendError analyzes the error returned for a payment attempt along the
+// passed route. Any channel update carried by the error is applied, and
+// depending on the error, the failing vertex or edge is pruned from the
+// payment session. True is returned if the error is terminal, in which case
+// the payment as a whole should be failed.
+func (r *ChannelRouter) processSendError(paySession *paymentSession,
+	route *Route, sendErr error,
+	errFailedFeeChans map[lnwire.ShortChannelID]struct{}) bool {
+
+	fErr, ok := sendErr.(*htlcswitch.ForwardingError)
+	if !ok {
+		return true
+	}
+
+	errSource := fErr.ErrorSource
+
+	// Every channel leading up to the source of the error was able to
+	// forward the HTLC, which we'll report to mission control.
+	reportRouteSuccess(paySession, route, errSource)
+
+	log.Tracef("node=%x reported failure when sending htlc",
+		errSource.SerializeCompressed())
+
+	switch onionErr := fErr.FailureMessage.(type) {
+	// If the end destination didn't know they payment
+	// hash, then we'll terminate immediately.
+	case *lnwire.FailUnknownPaymentHash:
+		return true
+
+	// If we sent the wrong amount to the destination, then
+	// we'll exit early.
+	case *lnwire.FailIncorrectPaymentAmount:
+		return true
+
+	// If the time-lock that was extended to the final node
+	// was incorrect, then we can't proceed.
+	case *lnwire.FailFinalIncorrectCltvExpiry:
+		return true
+
+	// If we crafted an invalid onion payload for the final
+	// node, then we'll exit early.
+	case *lnwire.FailFinalIncorrectHtlcAmount:
+		return true
+
+	// Similarly, if the HTLC expiry that we extended to
+	// the final hop expires too soon, then will fail the
+	// payment.
+	//
+	// TODO(roasbeef): can happen to to race condition, try
+	// again with recent block height
+	case *lnwire.FailFinalExpiryTooSoon:
+		return true
+
+	// If we erroneously attempted to cross a chain border,
+	// then we'll cancel the payment.
+	case *lnwire.FailInvalidRealm:
+		return true
+
+	// If we get a notice that the expiry was too soon for
+	// an intermediate node, then we'll prune out the node
+	// that sent us this error, as it doesn't now what the
+	// correct block height is.
+	case *lnwire.FailExpiryTooSoon:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If a node rejected the hop payload we crafted for it,
+	// then it likely advertised a payload format it doesn't
+	// properly understand. If that node is the destination,
+	// there's nothing else to try, otherwise we'll route
+	// around it.
+	case *lnwire.FailInvalidOnionPayload:
+		finalHop := route.Hops[len(route.Hops)-1]
+		finalVertex := Vertex(finalHop.Channel.Node.PubKeyBytes)
+		if NewVertex(errSource) == finalVertex {
+			return true
+		}
+
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If we hit an instance of onion payload corruption or
+	// an invalid version, then we'll exit early as this
+	// shouldn't happen in the typical case.
+	case *lnwire.FailInvalidOnionVersion:
+		return true
+	case *lnwire.FailInvalidOnionHmac:
+		return true
+	case *lnwire.FailInvalidOnionKey:
+		return true
+
+	// If the onion error includes a channel update, and
+	// isn't necessarily fatal, then we'll apply the update
+	// an continue with the rest of the routes.
+	case *lnwire.FailAmountBelowMinimum:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		return true
+
+	// If we get a failure due to a fee, so we'll apply the
+	// new fee update, and retry our attempt using the
+	// newly updated fees.
+	case *lnwire.FailFeeInsufficient:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+
+			pruneEdgeFailure(
+				paySession, route, errSource,
+			)
+		}
+
+		// We'll now check to see if we've already
+		// reported a fee related failure for this
+		// node. If so, then we'll actually prune out
+		// the edge for now.
+		chanID := update.ShortChannelID
+		_, ok := errFailedFeeChans[chanID]
+		if ok {
+			pruneEdgeFailure(
+				paySession, route, errSource,
+			)
+			return false
+		}
+
+		// Finally, we'll record a fee failure from
+		// this node and move on.
+		errFailedFeeChans[chanID] = struct{}{}
+		return false
+
+	// If we get the failure for an intermediate node that
+	// disagrees with our time lock values, then we'll
+	// prune it out for now, and continue with path
+	// finding.
+	case *lnwire.FailIncorrectCltvExpiry:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// The outgoing channel that this node was meant to
+	// forward one is currently disabled, so we'll apply
+	// the update and continue.
+	case *lnwire.FailChannelDisabled:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(&update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// It's likely that the outgoing channel didn't have
+	// sufficient capacity, so we'll prune this edge for
+	// now, and continue onwards with our path finding.
+	case *lnwire.FailTemporaryChannelFailure:
+		update := onionErr.Update
+		if err := r.applyChannelUpdate(update); err != nil {
+			log.Errorf("unable to apply channel "+
+				"update for onion error: %v", err)
+		}
+
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// If the send fail due to a node not having the
+	// required features, then we'll note this error and
+	// continue.
+	case *lnwire.FailRequiredNodeFeatureMissing:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If the send fail due to a node not having the
+	// required features, then we'll note this error and
+	// continue.
+	case *lnwire.FailRequiredChannelFeatureMissing:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If the next hop in the route wasn't known or
+	// offline, we'll prune the _next_ hop from the set of
+	// routes and retry.
+	case *lnwire.FailUnknownNextPeer:
+		pruneVertexFailure(
+			paySession, route, errSource, true,
+		)
+		return false
+
+	// If the node wasn't able to forward for which ever
+	// reason, then we'll note this and continue with the
+	// routes.
+	case *lnwire.FailTemporaryNodeFailure:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	case *lnwire.FailPermanentNodeFailure:
+		pruneVertexFailure(
+			paySession, route, errSource, false,
+		)
+		return false
+
+	// If we get a permanent channel or node failure, then
+	// we'll note this (exclude the vertex/edge), and
+	// continue with the rest of the routes.
+	case *lnwire.FailPermanentChannelFailure:
+		pruneEdgeFailure(paySession, route, errSource)
+		return false
+
+	// If the other HTLCs of a multi-path payment didn't arrive
+	// at the destination in time, then the destination failed
+	// back this one as well. As nothing is wrong with the route
+	// itself, we'll simply retry.
+	case *lnwire.FailMPPTimeout:
+		return false
+
+	default:
+		return true
+	}
+}
+
+// sendPaymentAttempt hands the HTLC of a single payment attempt to the switch,
+// and blocks until the result of the attempt is known. The attempt is recorded
+// with the control tower before the HTLC is sent, such that it can be resumed
+// after a restart, and its outcome is recorded once known.
+func (r *ChannelRouter) sendPaymentAttempt(paymentHash [32]byte, route *Route,
+	htlcAdd *lnwire.UpdateAddHTLC, circuit *sphinx.Circuit) ([32]byte,
+	error) {
+
+	// We'll obtain a unique ID for this attempt, which identifies the HTLC
+	// within the switch.
+	attemptID, err := r.cfg.NextPaymentID()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	attempt := &channeldb.HTLCAttemptInfo{
+		AttemptID:   attemptID,
+		SessionKey:  circuit.SessionKey,
+		Route:       newPaymentRoute(route),
+		AttemptTime: time.Now(),
+	}
+	err = r.cfg.Control.RegisterAttempt(paymentHash, attempt)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+	preImage, sendErr := r.cfg.SendToSwitch(
+		firstHop, attemptID, htlcAdd, circuit,
+	)
+	switch {
+
+	// If the switch is shutting down, then the HTLC may still be in
+	// flight. We'll leave the attempt unresolved, such that it's resumed
+	// once we restart.
+	case sendErr == htlcswitch.ErrSwitchExiting:
+		return [32]byte{}, sendErr
+
+	case sendErr != nil:
+		_, err := r.cfg.Control.FailAttempt(
+			paymentHash, attemptID,
+			r.newHTLCFailInfo(&attempt.Route, sendErr),
+		)
+		if err != nil {
+			log.Errorf("Unable to record failure of attempt %v "+
+				"for payment %x (%v): %v", attemptID,
+				paymentHash, sendErr, err)
+			return [32]byte{}, err
+		}
+
+		return [32]byte{}, sendErr
+	}
+
+	_, err = r.cfg.Control.SettleAttempt(
+		paymentHash, attemptID, &channeldb.HTLCSettleInfo{
+			Preimage:   preImage,
+			SettleTime: time.Now(),
+		},
+	)
+	if err != nil {
+		// The payment did succeed, so we'll still return the preimage
+		// even though we're unable to record it.
+		log.Errorf("Unable to record settle of attempt %v for "+
+			"payment %x: %v", attemptID, paymentHash, err)
+	}
+
+	return preImage, nil
+}
+
+// newHTLCFailInfo creates the failure info to record for an attempt along the
+// passed route that failed with the given error. If the error carries a
+// failure message, then the message is recorded along with the index of the
+// node that generated it within the route.
+func (r *ChannelRouter) newHTLCFailInfo(route *channeldb.PaymentRoute,
+	sendErr error) *channeldb.HTLCFailInfo {
+
+	failInfo := &channeldb.HTLCFailInfo{
+		FailTime: time.Now(),
+		Reason:   channeldb.HTLCFailInternal,
+	}
+
+	fErr, ok := sendErr.(*htlcswitch.ForwardingError)
+	if !ok {
+		return failInfo
+	}
+
+	failInfo.Message = fErr.FailureMessage
+	failInfo.Reason = channeldb.HTLCFailUnknown
+	if fErr.ErrorSource == nil {
+		return failInfo
+	}
+
+	// Our own node is found at index zero, followed by the node at the
+	// end of each hop of the route.
+	errSource := fErr.ErrorSource.SerializeCompressed()
+	if bytes.Equal(errSource, r.selfNode.PubKeyBytes[:]) {
+		failInfo.Reason = channeldb.HTLCFailMessage
+		return failInfo
+	}
+
+	for i, hop := range route.Hops {
+		if bytes.Equal(errSource, hop.PubKeyBytes[:]) {
+			failInfo.Reason = channeldb.HTLCFailMessage
+			failInfo.FailureSourceIndex = uint32(i + 1)
+			break
+		}
+	}
+
+	return failInfo
+}
+
+// paymentFailureReason returns the reason to record for a payment that
+// terminated with the given error returned for one of its attempts.
+func paymentFailureReason(err error) channeldb.FailureReason {
+	fErr, ok := err.(*htlcswitch.ForwardingError)
+	if !ok {
+		return channeldb.FailureReasonError
+	}
+
+	switch fErr.FailureMessage.(type) {
+	case *lnwire.FailUnknownPaymentHash,
+		*lnwire.FailIncorrectPaymentAmount,
+		*lnwire.FailFinalIncorrectCltvExpiry,
+		*lnwire.FailFinalIncorrectHtlcAmount:
+
+		return channeldb.FailureReasonIncorrectPaymentDetails
+
+	default:
+		return channeldb.FailureReasonError
+	}
+}
+
+// newPaymentRoute converts the passed route into the form it's recorded in
+// with the control tower.
+func newPaymentRoute(route *Route) channeldb.PaymentRoute {
+	hops := make([]*channeldb.PaymentHop, len(route.Hops))
+	for i, hop := range route.Hops {
+		hops[i] = &channeldb.PaymentHop{
+			PubKeyBytes:      hop.Channel.Node.PubKeyBytes,
+			ChannelID:        hop.Channel.ChannelID,
+			OutgoingTimeLock: hop.OutgoingTimeLock,
+			AmtToForward:     hop.AmtToForward,
+		}
+	}
+
+	return channeldb.PaymentRoute{
+		TotalTimeLock: route.TotalTimeLock,
+		TotalAmount:   route.TotalAmount,
+		TotalFees:     route.TotalFees,
+		Hops:          hops,
+	}
+}
+
+// attemptCircuit reconstructs the sphinx circuit of a recorded payment
+// attempt, which allows any failure returned for the attempt to be decrypted.
+func attemptCircuit(attempt *channeldb.HTLCAttemptInfo) (*sphinx.Circuit,
+	error) {
+
+	nodes := make([]*btcec.PublicKey, len(attempt.Route.Hops))
+	for i, hop := range attempt.Route.Hops {
+		pub, err := btcec.ParsePubKey(hop.PubKeyBytes[:], btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = pub
+	}
+
+	return &sphinx.Circuit{
+		SessionKey:  attempt.SessionKey,
+		PaymentPath: nodes,
+	}, nil
+}
+
+// resumePayments fetches all payments that were in flight when the router was
+// last shut down, and launches a goroutine for each of their attempts still in
+// flight, which waits for the result of the attempt and records it. Payments
+// aren't retried after a restart, so a failed attempt fails the payment as a
+// whole. Before doing so, the switch is signaled that it may delete the
+// results of all attempts that are no longer in flight.
+func (r *ChannelRouter) resumePayments() error {
+	payments, err := r.cfg.Control.FetchInFlightPayments()
+	if err != nil {
+		return err
+	}
+
+	// The switch must keep the results of all attempts still in flight,
+	// as we haven't yet recorded their outcome.
+	keep := make(map[uint64]struct{})
+	for _, payment := range payments {
+		for _, attempt := range payment.InFlightHTLCs() {
+			keep[attempt.AttemptID] = struct{}{}
+		}
+	}
+	if err := r.cfg.CleanPaymentResults(keep); err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		paymentHash := payment.Info.PaymentHash
+		inFlights := payment.InFlightHTLCs()
+
+		// If the payment has no attempts in flight, then we went away
+		// before the payment was either retried or failed. As we don't
+		// retry payments after a restart, we'll fail it right away.
+		if len(inFlights) == 0 {
+			log.Infof("Failing payment %x without attempts in "+
+				"flight", paymentHash)
+
+			_, err := r.cfg.Control.Fail(
+				paymentHash, channeldb.FailureReasonError,
+			)
+			if err != nil {
+				return err
 			}
+
+			continue
 		}
 
-		return preImage, route, nil
+		for _, attempt := range inFlights {
+			log.Infof("Resuming attempt %v for payment %x",
+				attempt.AttemptID, paymentHash)
+
+			r.wg.Add(1)
+			go r.resumeAttempt(paymentHash, attempt.HTLCAttemptInfo)
+		}
+	}
+
+	return nil
+}
+
+// resumeAttempt waits for the result of a payment attempt that was in flight
+// when the router was last shut down, and records its outcome with the
+// control tower.
+//
+// NOTE: This MUST be run as a goroutine.
+func (r *ChannelRouter) resumeAttempt(paymentHash [32]byte,
+	attempt channeldb.HTLCAttemptInfo) {
+
+	defer r.wg.Done()
+
+	circuit, err := attemptCircuit(&attempt)
+	if err != nil {
+		log.Errorf("Unable to reconstruct circuit of attempt %v for "+
+			"payment %x: %v", attempt.AttemptID, paymentHash, err)
+		return
+	}
+
+	// The switch only returns once the result is known, or it is shutting
+	// down. To not block our own shutdown, we'll wait for the result
+	// within a separate goroutine.
+	type attemptResult struct {
+		preimage [32]byte
+		err      error
+	}
+	resultChan := make(chan *attemptResult, 1)
+	go func() {
+		preimage, err := r.cfg.GetPaymentResult(
+			attempt.AttemptID, paymentHash, circuit,
+		)
+		resultChan <- &attemptResult{
+			preimage: preimage,
+			err:      err,
+		}
+	}()
+
+	var result *attemptResult
+	select {
+	case result = <-resultChan:
+	case <-r.quit:
+		return
+	}
+
+	switch {
+
+	// The switch is shutting down, so the attempt will be resumed once we
+	// restart.
+	case result.err == htlcswitch.ErrSwitchExiting:
+		return
+
+	// The attempt succeeded, so we'll record the preimage.
+	case result.err == nil:
+		log.Infof("Resumed attempt %v for payment %x succeeded",
+			attempt.AttemptID, paymentHash)
+
+		_, err := r.cfg.Control.SettleAttempt(
+			paymentHash, attempt.AttemptID,
+			&channeldb.HTLCSettleInfo{
+				Preimage:   result.preimage,
+				SettleTime: time.Now(),
+			},
+		)
+		if err != nil {
+			log.Errorf("Unable to record settle of attempt %v "+
+				"for payment %x: %v", attempt.AttemptID,
+				paymentHash, err)
+		}
+
+		return
+	}
+
+	// Otherwise the attempt failed. This includes the case where the
+	// switch doesn't know of the attempt, meaning the HTLC never left our
+	// node.
+	log.Infof("Resumed attempt %v for payment %x failed: %v",
+		attempt.AttemptID, paymentHash, result.err)
+
+	payment, err := r.cfg.Control.FailAttempt(
+		paymentHash, attempt.AttemptID,
+		r.newHTLCFailInfo(&attempt.Route, result.err),
+	)
+	if err != nil {
+		log.Errorf("Unable to record failure of attempt %v for "+
+			"payment %x: %v", attempt.AttemptID, paymentHash, err)
+		return
+	}
+
+	// As the payment isn't retried, we'll fail the payment itself, unless
+	// it was already failed before we restarted.
+	if payment.FailureReason != nil {
+		return
+	}
+
+	_, err = r.cfg.Control.Fail(
+		paymentHash, paymentFailureReason(result.err),
+	)
+	if err != nil {
+		log.Errorf("Unable to mark payment %x failed: %v",
+			paymentHash, err)
+	}
+}
+
+// reportRouteSuccess reports to mission control that the channels along the
+// passed route were able to forward the HTLC sent along it. If errSource is
+// non-nil, then only the channels up to and including the one into the error
+// source are reported, as the HTLC failed there. Otherwise, the HTLC
+// succeeded, so all channels of the route are reported.
+func reportRouteSuccess(paySession *paymentSession, route *Route,
+	errSource *btcec.PublicKey) {
+
+	var errVertex Vertex
+	if errSource != nil {
+		errVertex = NewVertex(errSource)
+
+		// If the error originated at ourselves, then the HTLC didn't
+		// cross any channel.
+		if !route.containsNode(errVertex) {
+			return
+		}
+	}
+
+	for _, hop := range route.Hops {
+		paySession.ReportChannelSuccess(
+			hop.Channel.ChannelID, hop.AmtToForward,
+		)
+
+		if errSource != nil &&
+			Vertex(hop.Channel.Node.PubKeyBytes) == errVertex {
+
+			return
+		}
 	}
 }
 
@@ -1891,8 +2970,25 @@ func pruneEdgeFailure(paySession *paymentSession, route *Route,
 	}
 
 	// If the channel was found, then we'll inform mission control of this
-	// failure so future attempts avoid this link temporarily.
-	paySession.ReportChannelFailure(badChan.ChannelID)
+	// failure so future attempts are less likely to use this link for the
+	// same amount.
+	paySession.ReportChannelFailure(
+		badChan.ChannelID, route.channelAmount(badChan.ChannelID),
+	)
+}
+
+// QueryMissionControl returns a snapshot of the current state of mission
+// control, which contains the history of each channel it has observed, along
+// with the nodes that are currently pruned from path finding.
+func (r *ChannelRouter) QueryMissionControl() *MissionControlSnapshot {
+	return r.missionControl.GetHistorySnapshot()
+}
+
+// ResetMissionControl clears the history of mission control, both in memory
+// and on disk, returning it to a state as if no payment attempts have been
+// made.
+func (r *ChannelRouter) ResetMissionControl() error {
+	return r.missionControl.ResetHistory()
 }
 
 // applyChannelUpdate applies a channel update directly to the database,
@@ -2011,13 +3107,26 @@ func (r *ChannelRouter) GetChannelByID(chanID lnwire.ShortChannelID) (
 	return r.cfg.Graph.FetchChannelEdgesByID(chanID.ToUint64())
 }
 
-// ForEachNode is used to iterate over every node in router topology.
+// ForEachNode is used to iterate over every node in router topology. The
+// nodes are served from the router's in-memory copy of the graph, and must
+// not be modified by the caller.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) error {
-	return r.cfg.Graph.ForEachNode(nil, func(_ *bolt.Tx, n *channeldb.LightningNode) error {
-		return cb(n)
-	})
+	return r.graphCache.forEachNode(cb)
+}
+
+// ForEachNodeChannel is used to iterate over every channel of the target node
+// that the node has advertised a routing policy for. The first policy passed
+// to the callback is the outgoing policy of the node, while the second is the
+// incoming policy of the node at the other end of the channel, which may be
+// nil. The channels are served from the router's in-memory copy of the graph,
+// and must not be modified by the caller.
+func (r *ChannelRouter) ForEachNodeChannel(nodePub [33]byte,
+	cb func(*channeldb.ChannelEdgeInfo, *channeldb.ChannelEdgePolicy,
+		*channeldb.ChannelEdgePolicy) error) error {
+
+	return r.graphCache.forEachNodeChannel(Vertex(nodePub), cb)
 }
 
 // ForAllOutgoingChannels is used to iterate over all outgoing channels owned by
@@ -2035,13 +3144,14 @@ func (r *ChannelRouter) ForAllOutgoingChannels(cb func(*channeldb.ChannelEdgeInf
 }
 
 // ForEachChannel is used to iterate over every known edge (channel) within our
-// view of the channel graph.
+// view of the channel graph. The channels are served from the router's
+// in-memory copy of the graph, and must not be modified by the caller.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) ForEachChannel(cb func(chanInfo *channeldb.ChannelEdgeInfo,
 	e1, e2 *channeldb.ChannelEdgePolicy) error) error {
 
-	return r.cfg.Graph.ForEachChannel(cb)
+	return r.graphCache.forEachChannel(cb)
 }
 
 // AddProof updates the channel edge info with proof which is needed to
@@ -2057,7 +3167,12 @@ func (r *ChannelRouter) AddProof(chanID lnwire.ShortChannelID,
 	}
 
 	info.AuthProof = proof
-	return r.cfg.Graph.UpdateChannelEdge(info)
+	if err := r.cfg.Graph.UpdateChannelEdge(info); err != nil {
+		return err
+	}
+	r.graphCache.updateChannel(info)
+
+	return nil
 }
 
 // IsStaleNode returns true if the graph source has a node announcement for the
@@ -2079,6 +3194,15 @@ func (r *ChannelRouter) IsKnownEdge(chanID lnwire.ShortChannelID) bool {
 	return exists
 }
 
+// IsZombieEdge returns true if the graph source has marked the passed channel
+// ID as a zombie.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) IsZombieEdge(chanID lnwire.ShortChannelID) bool {
+	isZombie, _ := r.cfg.Graph.IsZombieEdge(chanID.ToUint64())
+	return isZombie
+}
+
 // IsStaleEdgePolicy returns true if the graph soruce has a channel edge for
 // the passed channel ID (and flags) that have a more recent timestamp.
 //
@@ -2100,6 +3224,14 @@ func (r *ChannelRouter) IsStaleEdgePolicy(chanID lnwire.ShortChannelID,
 		return false
 	}
 
+	// Updates for zombie channels are only fresh if they're recent enough
+	// to bring the channel back to life.
+	if r.IsZombieEdge(chanID) &&
+		time.Since(timestamp) >= r.cfg.ChannelPruneExpiry {
+
+		return true
+	}
+
 	// As edges are directional edge node has a unique policy for the
 	// direction of the edge they control. Therefore we first check if we
 	// already have the most up to date information for that edge. If so,
@@ -6,6 +6,8 @@ import (
 	"image/color"
 	"math/rand"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +25,30 @@ import (
 // be returned by FindRoutes
 const defaultNumRoutes = 10
 
+// testPaymentID is used to hand out unique payment IDs to the payment
+// attempts of the test routers.
+var testPaymentID uint64
+
+// nextTestPaymentID returns the next unique payment ID for a payment attempt.
+func nextTestPaymentID() (uint64, error) {
+	return atomic.AddUint64(&testPaymentID, 1), nil
+}
+
+// mockGetPaymentResult is used as the GetPaymentResult function of the test
+// routers. As payment attempts are never actually handed to a switch, their
+// IDs are always unknown.
+func mockGetPaymentResult(uint64, [32]byte, *sphinx.Circuit) ([32]byte,
+	error) {
+
+	return [32]byte{}, htlcswitch.ErrPaymentIDNotFound
+}
+
+// mockCleanPaymentResults is used as the CleanPaymentResults function of the
+// test routers.
+func mockCleanPaymentResults(map[uint64]struct{}) error {
+	return nil
+}
+
 type testCtx struct {
 	router *ChannelRouter
 
@@ -46,10 +72,18 @@ func (c *testCtx) RestartRouter() error {
 		Graph:     c.graph,
 		Chain:     c.chain,
 		ChainView: c.chainView,
-		SendToSwitch: func(_ [33]byte,
-			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+		SendToSwitch: func(_ [33]byte, _ uint64,
+			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte,
+			error) {
+
 			return [32]byte{}, nil
 		},
+		GetPaymentResult:    mockGetPaymentResult,
+		NextPaymentID:       nextTestPaymentID,
+		CleanPaymentResults: mockCleanPaymentResults,
+		Control: NewControlTower(
+			channeldb.NewPaymentControl(c.graph.Database()),
+		),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -121,11 +155,18 @@ func createTestCtx(startingHeight uint32, testGraph ...string) (*testCtx, func()
 		Graph:     graph,
 		Chain:     chain,
 		ChainView: chainView,
-		SendToSwitch: func(_ [33]byte, _ *lnwire.UpdateAddHTLC,
-			_ *sphinx.Circuit) ([32]byte, error) {
+		SendToSwitch: func(_ [33]byte, _ uint64,
+			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte,
+			error) {
 
 			return [32]byte{}, nil
 		},
+		GetPaymentResult:    mockGetPaymentResult,
+		NextPaymentID:       nextTestPaymentID,
+		CleanPaymentResults: mockCleanPaymentResults,
+		Control: NewControlTower(
+			channeldb.NewPaymentControl(graph.Database()),
+		),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -172,7 +213,7 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	// Execute a query for all possible routes between roasbeef and luo ji.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := ctx.aliases["luoji"]
-	routes, err := ctx.router.FindRoutes(target, paymentAmt,
+	routes, err := ctx.router.FindRoutes(target, paymentAmt, nil,
 		defaultNumRoutes, DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -235,7 +276,7 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	// router's configuration to ignore the path that has luo ji as the
 	// first hop. This should force the router to instead take the
 	// available two hop path (through satoshi).
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
@@ -280,6 +321,106 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	}
 }
 
+// TestSendToRoute tests that a payment sent over a set of pre-built routes
+// attempts the routes in order, skipping to the next route once one fails,
+// and that the payment fails once all routes have been attempted.
+func TestSendToRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// We'll start by finding the two routes from roasbeef to luo ji: the
+	// direct route, followed by the route through satoshi.
+	paymentAmt := lnwire.NewMSatFromSatoshis(1000)
+	routes, err := ctx.router.FindRoutes(
+		ctx.aliases["luoji"], paymentAmt, nil, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 2 || len(routes[0].Hops) != 1 {
+		t.Fatalf("expected direct route followed by a two hop "+
+			"route, instead got: %v", spew.Sdump(routes))
+	}
+
+	// The routes are re-created from their hops, as a caller with its own
+	// routing engine would do.
+	sourceVertex := Vertex(ctx.router.selfNode.PubKeyBytes)
+	for i, route := range routes {
+		routes[i], err = NewRouteFromHops(
+			route.TotalAmount, route.TotalTimeLock, sourceVertex,
+			route.Hops,
+		)
+		if err != nil {
+			t.Fatalf("unable to create route: %v", err)
+		}
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourceNode := ctx.router.selfNode
+
+	// We'll modify the SendToSwitch method to fail any HTLC that has luo
+	// ji as its first hop, such that the direct route fails.
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return preImage, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return preImage, nil
+	}
+
+	// Sending the payment over both routes should succeed using the route
+	// through satoshi.
+	var payHash [32]byte
+	paymentPreImage, route, err := ctx.router.SendToRoute(routes, payHash)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+	if route != routes[1] {
+		t.Fatalf("expected second route to be used, instead got: %v",
+			spew.Sdump(route))
+	}
+
+	// Sending a payment over only the direct route should fail, as there's
+	// no other route to fall back to.
+	payHash[0] = 1
+	_, _, err = ctx.router.SendToRoute(routes[:1], payHash)
+	if err == nil {
+		t.Fatalf("expected payment over failing route to fail")
+	}
+
+	// Finally, routes that lead to different destinations can't be used
+	// for the same payment.
+	routes[1].Hops = routes[1].Hops[:1]
+	payHash[0] = 2
+	_, _, err = ctx.router.SendToRoute(routes, payHash)
+	if err == nil {
+		t.Fatalf("expected routes to different destinations to be " +
+			"rejected")
+	}
+}
+
 // TestSendPaymentErrorRepeatedFeeInsufficient tests that if we receive
 // multiple fee related errors from a channel that we're attempting to route
 // through, then we'll prune the channel after the second attempt.
@@ -329,7 +470,7 @@ func TestSendPaymentErrorRepeatedFeeInsufficient(t *testing.T) {
 	// We'll now modify the SendToSwitch method to return an error for the
 	// outgoing channel to luo ji. This will be a fee related error, so it
 	// should only cause the edge to be pruned after the second attempt.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
@@ -433,7 +574,7 @@ func TestSendPaymentErrorNonFinalTimeLockErrors(t *testing.T) {
 	// outgoing channel to son goku. Since this is a time lock related
 	// error, we should fail the payment flow all together, as Goku is the
 	// only channel to Sophon.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(sourceNode.SerializeCompressed(), n[:]) {
@@ -485,7 +626,7 @@ func TestSendPaymentErrorNonFinalTimeLockErrors(t *testing.T) {
 	// We'll now modify the error return an IncorrectCltvExpiry error
 	// instead, this should result in the same behavior of roasbeef routing
 	// around the faulty Son Goku node.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(sourceNode.SerializeCompressed(), n[:]) {
@@ -501,7 +642,10 @@ func TestSendPaymentErrorNonFinalTimeLockErrors(t *testing.T) {
 	}
 
 	// Once again, Roasbeef should route around Goku since they disagree
-	// w.r.t to the block height, and instead go through Pham Nuwen.
+	// w.r.t to the block height, and instead go through Pham Nuwen. As the
+	// first payment succeeded, we'll use a new payment hash, since paying
+	// the same payment hash twice isn't allowed.
+	payment.PaymentHash[0] ^= 1
 	paymentPreImage, route, err = ctx.router.SendPayment(&payment)
 	if err != nil {
 		t.Fatalf("unable to send payment: %v", err)
@@ -551,7 +695,7 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 	//
 	// TODO(roasbeef): filtering should be intelligent enough so just not
 	// go through satoshi at all at this point.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
@@ -577,7 +721,9 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		return preImage, nil
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// When we try to dispatch that payment, we should receive an error as
 	// both attempts should fail and cause both routes to be pruned.
@@ -592,12 +738,14 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		t.Fatalf("expected UnknownNextPeer instead got: %v", err)
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// Next, we'll modify the SendToSwitch method to indicate that luo ji
 	// wasn't originally online. This should also halt the send all
 	// together as all paths contain luoji and he can't be reached.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 
 		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
@@ -620,11 +768,13 @@ func TestSendPaymentErrorPathPruning(t *testing.T) {
 		t.Fatalf("expected UnknownNextPeer instead got: %v", err)
 	}
 
-	ctx.router.missionControl.ResetHistory()
+	if err := ctx.router.missionControl.ResetHistory(); err != nil {
+		t.Fatalf("unable to reset mission control: %v", err)
+	}
 
 	// Finally, we'll modify the SendToSwitch function to indicate that the
 	// roasbeef -> luoji channel has insufficient capacity.
-	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
 		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
 		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
 			// We'll first simulate an error from the first
@@ -952,7 +1102,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 	// We should now be able to find two routes to node 2.
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	targetNode := priv2.PubKey()
-	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt,
+	routes, err := ctx.router.FindRoutes(targetNode, paymentAmt, nil,
 		defaultNumRoutes, DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -995,7 +1145,7 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 
 	// Should still be able to find the routes, and the info should be
 	// updated.
-	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt,
+	routes, err = ctx.router.FindRoutes(targetNode, paymentAmt, nil,
 		defaultNumRoutes, DefaultFinalCLTVDelta)
 	if err != nil {
 		t.Fatalf("unable to find any routes: %v", err)
@@ -1191,10 +1341,18 @@ func TestWakeUpOnStaleBranch(t *testing.T) {
 		Graph:     ctx.graph,
 		Chain:     ctx.chain,
 		ChainView: ctx.chainView,
-		SendToSwitch: func(_ [33]byte,
-			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+		SendToSwitch: func(_ [33]byte, _ uint64,
+			_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte,
+			error) {
+
 			return [32]byte{}, nil
 		},
+		GetPaymentResult:    mockGetPaymentResult,
+		NextPaymentID:       nextTestPaymentID,
+		CleanPaymentResults: mockCleanPaymentResults,
+		Control: NewControlTower(
+			channeldb.NewPaymentControl(ctx.graph.Database()),
+		),
 		ChannelPruneExpiry: time.Hour * 24,
 		GraphPruneInterval: time.Hour * 2,
 	})
@@ -1605,8 +1763,8 @@ func TestFindPathFeeWeighting(t *testing.T) {
 	// the edge weighting, we should select the direct path over the 2 hop
 	// path even though the direct path has a higher potential time lock.
 	path, err := findPath(
-		nil, ctx.graph, sourceNode, target, ignoreVertex, ignoreEdge,
-		amt,
+		newTestGraphCache(t, ctx.graph), nil, sourceNode, target,
+		ignoreVertex, ignoreEdge, amt, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find path: %v", err)
@@ -1756,6 +1914,135 @@ func TestIsKnownEdge(t *testing.T) {
 	}
 }
 
+// TestZombieChannelResurrection tests that channels without any recent
+// updates are marked as zombies and removed from path finding, and that a
+// fresh update brings them back to life.
+func TestZombieChannelResurrection(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	var (
+		pub1 [33]byte
+		pub2 [33]byte
+	)
+	copy(pub1[:], priv1.PubKey().SerializeCompressed())
+	copy(pub2[:], priv2.PubKey().SerializeCompressed())
+
+	fundingTx, _, chanID, err := createChannelEdge(ctx,
+		bitcoinKey1.SerializeCompressed(),
+		bitcoinKey2.SerializeCompressed(),
+		10000, 500)
+	if err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	fundingBlock := &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}
+	ctx.chain.addBlock(fundingBlock, chanID.BlockHeight, chanID.BlockHeight)
+
+	edge := &channeldb.ChannelEdgeInfo{
+		ChannelID:        chanID.ToUint64(),
+		NodeKey1Bytes:    pub1,
+		NodeKey2Bytes:    pub2,
+		BitcoinKey1Bytes: pub1,
+		BitcoinKey2Bytes: pub2,
+		AuthProof:        nil,
+	}
+	if err := ctx.router.AddEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	newPolicy := func(lastUpdate time.Time,
+		flags lnwire.ChanUpdateFlag) *channeldb.ChannelEdgePolicy {
+
+		return &channeldb.ChannelEdgePolicy{
+			SigBytes:                  testSig.Serialize(),
+			ChannelID:                 edge.ChannelID,
+			LastUpdate:                lastUpdate,
+			Flags:                     flags,
+			TimeLockDelta:             10,
+			MinHTLC:                   1,
+			FeeBaseMSat:               10,
+			FeeProportionalMillionths: 10000,
+		}
+	}
+
+	// We'll add policies for both directions of the channel which are
+	// older than the prune expiry of the router.
+	staleUpdate := time.Now().Add(-2 * ctx.router.cfg.ChannelPruneExpiry)
+	for _, flags := range []lnwire.ChanUpdateFlag{0, 1} {
+		err := ctx.router.UpdateEdge(newPolicy(staleUpdate, flags))
+		if err != nil {
+			t.Fatalf("unable to update edge policy: %v", err)
+		}
+	}
+
+	// Once we check for zombies, the channel should be marked as one, and
+	// it should no longer be available for path finding.
+	if err := ctx.router.pruneZombieChans(); err != nil {
+		t.Fatalf("unable to prune zombie channels: %v", err)
+	}
+	if !ctx.router.IsZombieEdge(*chanID) {
+		t.Fatalf("channel should be marked as zombie")
+	}
+	if !ctx.router.IsKnownEdge(*chanID) {
+		t.Fatalf("zombie channel should still be known")
+	}
+	_, _, _, err = ctx.router.graphCache.fetchChannel(edge.ChannelID)
+	if err != channeldb.ErrEdgeNotFound {
+		t.Fatalf("zombie channel should be removed from graph cache, "+
+			"instead got: %v", err)
+	}
+
+	// An update that's newer than the known ones, but still older than
+	// the prune expiry, shouldn't bring the channel back to life.
+	outdatedUpdate := staleUpdate.Add(time.Minute)
+	if !ctx.router.IsStaleEdgePolicy(*chanID, outdatedUpdate, 0) {
+		t.Fatalf("outdated zombie update should be considered stale")
+	}
+	err = ctx.router.UpdateEdge(newPolicy(outdatedUpdate, 0))
+	if !IsError(err, ErrOutdated) {
+		t.Fatalf("expected outdated update to be rejected, instead "+
+			"got: %v", err)
+	}
+	if !ctx.router.IsZombieEdge(*chanID) {
+		t.Fatalf("channel should still be marked as zombie")
+	}
+
+	// A fresh update should resurrect the channel, making it available
+	// for path finding again along with both of its policies.
+	freshUpdate := time.Now()
+	if ctx.router.IsStaleEdgePolicy(*chanID, freshUpdate, 0) {
+		t.Fatalf("fresh zombie update shouldn't be considered stale")
+	}
+	if err := ctx.router.UpdateEdge(newPolicy(freshUpdate, 0)); err != nil {
+		t.Fatalf("unable to update edge policy: %v", err)
+	}
+	if ctx.router.IsZombieEdge(*chanID) {
+		t.Fatalf("channel should no longer be marked as zombie")
+	}
+	_, policy1, policy2, err := ctx.router.graphCache.fetchChannel(
+		edge.ChannelID,
+	)
+	if err != nil {
+		t.Fatalf("resurrected channel should be within graph cache: "+
+			"%v", err)
+	}
+	if policy1 == nil || policy1.LastUpdate.Unix() != freshUpdate.Unix() {
+		t.Fatalf("expected fresh policy for first node, got %v",
+			policy1)
+	}
+	if policy2 == nil {
+		t.Fatalf("expected policy for second node to be restored")
+	}
+}
+
 // TestIsStaleEdgePolicy tests that the IsStaleEdgePolicy properly detects
 // stale channel edge update announcements.
 func TestIsStaleEdgePolicy(t *testing.T) {
@@ -1860,3 +2147,409 @@ func TestIsStaleEdgePolicy(t *testing.T) {
 		t.Fatalf("router failed to detect fresh edge policy")
 	}
 }
+
+// TestSendPaymentDuplicate asserts that the router refuses to pay a payment
+// hash that has already been paid, while a payment hash that failed may be
+// paid again.
+func TestSendPaymentDuplicate(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["luoji"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	// We'll first have the final hop reject the payment, which should
+	// fail the payment as a whole.
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		return [32]byte{}, &htlcswitch.ForwardingError{
+			ErrorSource:    ctx.aliases["luoji"],
+			FailureMessage: &lnwire.FailUnknownPaymentHash{},
+		}
+	}
+
+	_, _, err = ctx.router.SendPayment(&payment)
+	if err == nil {
+		t.Fatalf("payment didn't return error")
+	}
+
+	p, err := ctx.router.cfg.Control.FetchPayment(payHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if p.Status != channeldb.StatusFailed {
+		t.Fatalf("expected payment to be failed, got %v", p.Status)
+	}
+	if *p.FailureReason != channeldb.FailureReasonIncorrectPaymentDetails {
+		t.Fatalf("unexpected failure reason: %v", *p.FailureReason)
+	}
+
+	// As the payment failed, we should be able to pay the payment hash
+	// again, which should now succeed.
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		return preImage, nil
+	}
+
+	paymentPreImage, _, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if paymentPreImage != preImage {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	p, err = ctx.router.cfg.Control.FetchPayment(payHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if p.Status != channeldb.StatusSucceeded {
+		t.Fatalf("expected payment to be succeeded, got %v", p.Status)
+	}
+	if len(p.HTLCs) != 1 {
+		t.Fatalf("expected 1 htlc attempt, got %v", len(p.HTLCs))
+	}
+
+	// Finally, paying the payment hash a third time should be refused.
+	_, _, err = ctx.router.SendPayment(&payment)
+	if err != channeldb.ErrAlreadyPaid {
+		t.Fatalf("expected ErrAlreadyPaid, got %v", err)
+	}
+}
+
+// TestResumeInFlightPayments asserts that payments that were in flight when
+// the router was shut down have their outcome recorded once the router is
+// restarted.
+func TestResumeInFlightPayments(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// We'll record a payment along with an attempt in flight, as if the
+	// router went away while waiting for the result of the attempt.
+	control := ctx.router.cfg.Control
+
+	var payHash [32]byte
+	payHash[0] = 1
+	info := &channeldb.PaymentCreationInfo{
+		PaymentHash:  payHash,
+		Value:        lnwire.NewMSatFromSatoshis(1000),
+		CreationDate: time.Now(),
+	}
+	if err := control.InitPayment(payHash, info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	sessionKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create session key: %v", err)
+	}
+	var hop channeldb.PaymentHop
+	copy(hop.PubKeyBytes[:], ctx.aliases["luoji"].SerializeCompressed())
+	attempt := &channeldb.HTLCAttemptInfo{
+		AttemptID:  1,
+		SessionKey: sessionKey,
+		Route: channeldb.PaymentRoute{
+			Hops: []*channeldb.PaymentHop{&hop},
+		},
+		AttemptTime: time.Now(),
+	}
+	if err := control.RegisterAttempt(payHash, attempt); err != nil {
+		t.Fatalf("unable to register attempt: %v", err)
+	}
+
+	// We'll also record a payment without any attempt, as if the router
+	// went away before the first attempt was made.
+	var payHash2 [32]byte
+	payHash2[0] = 2
+	info.PaymentHash = payHash2
+	if err := control.InitPayment(payHash2, info); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	// Upon restart, the payment without an attempt should be failed right
+	// away. The attempt of the other payment is unknown to the test
+	// router's switch, meaning the HTLC never left our node, which should
+	// also fail the payment.
+	if err := ctx.RestartRouter(); err != nil {
+		t.Fatalf("unable to restart router: %v", err)
+	}
+
+	for _, hash := range [][32]byte{payHash, payHash2} {
+		var p *channeldb.Payment
+		for i := 0; i < 50; i++ {
+			p, err = control.FetchPayment(hash)
+			if err != nil {
+				t.Fatalf("unable to fetch payment: %v", err)
+			}
+			if p.Status == channeldb.StatusFailed {
+				break
+			}
+
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		if p.Status != channeldb.StatusFailed {
+			t.Fatalf("expected payment %x to be failed, got %v",
+				hash, p.Status)
+		}
+	}
+}
+
+// TestSendPaymentMultiPath tests that a payment which can't be carried by any
+// single route is split across multiple routes, but only if the target
+// advertises support for multi-path payments.
+func TestSendPaymentMultiPath(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(
+		startingBlockHeight, multiPathGraphFilePath,
+	)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Both routes to satoshi are limited by a channel of 60k satoshis, so
+	// a payment of 100k satoshis can only be made by splitting it.
+	var payHash [32]byte
+	payment := LightningPayment{
+		Target:      ctx.aliases["satoshi"],
+		Amount:      lnwire.NewMSatFromSatoshis(100000),
+		PaymentHash: payHash,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	// As satoshi doesn't yet advertise multi-path payments, the payment
+	// should fail as no route is found.
+	_, _, err = ctx.router.SendPayment(&payment)
+	if !IsError(err, ErrNoPathFound, ErrInsufficientCapacity) {
+		t.Fatalf("expected no path to be found, got %v", err)
+	}
+
+	// We'll now have satoshi advertise multi-path payments, along with
+	// the TLV payloads required to receive the total amount of the
+	// payment.
+	satoshi, err := ctx.graph.FetchLightningNode(ctx.aliases["satoshi"])
+	if err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+	satoshi.Features = lnwire.NewFeatureVector(
+		lnwire.NewRawFeatureVector(
+			lnwire.MultiPathPaymentsOptional,
+			lnwire.TLVOnionPayloadOptional,
+		),
+		lnwire.GlobalFeatures,
+	)
+	satoshi.LastUpdate = satoshi.LastUpdate.Add(time.Second)
+	if err := ctx.router.AddNode(satoshi); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+
+	// The receiver only settles the shards once all of them have arrived,
+	// so we'll only hand out the preimage once both shards are in flight.
+	var (
+		firstHops  = make(map[[33]byte]struct{})
+		numShards  int
+		shardsMtx  sync.Mutex
+		allArrived = make(chan struct{})
+	)
+	ctx.router.cfg.SendToSwitch = func(n [33]byte, _ uint64,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		shardsMtx.Lock()
+		firstHops[n] = struct{}{}
+		numShards++
+		if numShards == 2 {
+			close(allArrived)
+		}
+		shardsMtx.Unlock()
+
+		select {
+		case <-allArrived:
+			return preImage, nil
+		case <-time.After(5 * time.Second):
+			return [32]byte{}, fmt.Errorf("not all shards arrived")
+		}
+	}
+
+	copy(payment.PaymentHash[:], bytes.Repeat([]byte{1}, 32))
+	paymentPreImage, _, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if paymentPreImage != preImage {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// The shards should have been sent through both of our channels.
+	shardsMtx.Lock()
+	defer shardsMtx.Unlock()
+
+	if len(firstHops) != 2 {
+		t.Fatalf("expected shards over 2 first hops, got %v",
+			len(firstHops))
+	}
+	for _, alias := range []string{"songoku", "luoji"} {
+		var pub [33]byte
+		copy(pub[:], ctx.aliases[alias].SerializeCompressed())
+		if _, ok := firstHops[pub]; !ok {
+			t.Fatalf("no shard sent through %v", alias)
+		}
+	}
+
+	// Finally, the attempts recorded for the payment should add up to the
+	// full payment amount.
+	p, err := ctx.router.cfg.Control.FetchPayment(payment.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payment: %v", err)
+	}
+	if len(p.HTLCs) != 2 {
+		t.Fatalf("expected 2 htlc attempts, got %v", len(p.HTLCs))
+	}
+
+	var total lnwire.MilliSatoshi
+	for _, htlc := range p.HTLCs {
+		hops := htlc.Route.Hops
+		total += hops[len(hops)-1].AmtToForward
+	}
+	if total != payment.Amount {
+		t.Fatalf("expected shards to add up to %v, got %v",
+			payment.Amount, total)
+	}
+}
+
+// TestProbeRoutes tests that probing a route which can carry the probed
+// amount reports success, while probing a route that lacks the liquidity
+// narrows down the amount it's able to carry and identifies the bottleneck.
+func TestProbeRoutes(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	sourcePub, err := ctx.router.selfNode.PubKey()
+	if err != nil {
+		t.Fatalf("unable to fetch source pubkey: %v", err)
+	}
+	target := ctx.aliases["luoji"]
+
+	// We'll emulate a route that is only able to carry HTLCs up to the
+	// liquidity limit. Smaller HTLCs reach luo ji, which fails them as it
+	// doesn't know their payment hash.
+	const liquidity = lnwire.MilliSatoshi(600000)
+	ctx.router.cfg.SendToSwitch = func(_ [33]byte, _ uint64,
+		htlcAdd *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte,
+		error) {
+
+		if htlcAdd.Amount > liquidity {
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    sourcePub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return [32]byte{}, &htlcswitch.ForwardingError{
+			ErrorSource:    target,
+			FailureMessage: &lnwire.FailUnknownPaymentHash{},
+		}
+	}
+
+	// A probe of an amount below the limit should succeed.
+	amt := lnwire.NewMSatFromSatoshis(500)
+	results, err := ctx.router.ProbeRoutes(
+		target, amt, &RestrictParams{}, 1, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to probe routes: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 probe result, got %v", len(results))
+	}
+	result := results[0]
+	if !result.Success || result.BottleneckChannel != 0 {
+		t.Fatalf("expected successful probe, got %v",
+			spew.Sdump(result))
+	}
+	finalHop := result.Hops[len(result.Hops)-1]
+	if finalHop.MaxAmt != amt {
+		t.Fatalf("expected %v to reach the destination, got %v", amt,
+			finalHop.MaxAmt)
+	}
+
+	// A probe of an amount above the limit should fail, identify the
+	// channel lacking the liquidity, and estimate the amount the route is
+	// able to carry.
+	amt = lnwire.NewMSatFromSatoshis(1000)
+	results, err = ctx.router.ProbeRoutes(
+		target, amt, &RestrictParams{}, 1, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to probe routes: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 probe result, got %v", len(results))
+	}
+	result = results[0]
+	if result.Success {
+		t.Fatalf("expected failed probe")
+	}
+	if _, ok := result.FailureMessage.(*lnwire.FailTemporaryChannelFailure); !ok {
+		t.Fatalf("expected temporary channel failure, got %v",
+			result.FailureMessage)
+	}
+
+	bottleneck := result.Route.Hops[0].Channel.ChannelID
+	if result.BottleneckChannel != bottleneck {
+		t.Fatalf("expected bottleneck channel %v, got %v", bottleneck,
+			result.BottleneckChannel)
+	}
+
+	// The estimated amount should be within the precision of the binary
+	// search below the limit.
+	precision := amt >> maxLiquidityProbes
+	maxAmt := result.Hops[len(result.Hops)-1].MaxAmt
+	if maxAmt > liquidity || maxAmt < liquidity-precision {
+		t.Fatalf("expected estimated amount within %v of %v, got %v",
+			precision, liquidity, maxAmt)
+	}
+
+	// Finally, mission control should have learned that the bottleneck
+	// channel isn't able to carry the full amount.
+	probability := ctx.router.missionControl.getEdgeProbability(
+		bottleneck, result.Route.channelAmount(bottleneck),
+	)
+	if probability >= minProbability {
+		t.Fatalf("expected bottleneck channel to be penalized, got "+
+			"probability %v", probability)
+	}
+}
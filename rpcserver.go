@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"math"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -21,6 +23,7 @@ import (
 	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
@@ -228,6 +231,14 @@ var (
 			Entity: "invoices",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/SettleInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/CancelInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/SubscribeInvoices": {{
 			Entity: "invoices",
 			Action: "read",
@@ -256,10 +267,30 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/Probe": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/QueryMissionControl": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ResetMissionControl": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetNetworkInfo": {{
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/ExportGraph": {{
+			Entity: "info",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ImportGraph": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/StopDaemon": {{
 			Entity: "info",
 			Action: "write",
@@ -272,6 +303,22 @@ var (
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/TrackPayment": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SendToRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SendToRouteSync": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/Rebalance": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/DeleteAllPayments": {{
 			Entity: "offchain",
 			Action: "write",
@@ -292,6 +339,14 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/UpdateChanStatus": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/UpdateNodeAnnouncement": {{
+			Entity: "info",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ForwardingHistory": {{
 			Entity: "offchain",
 			Action: "read",
@@ -1635,32 +1690,6 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 	return resp, nil
 }
 
-// savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, preImage []byte) error {
-
-	paymentPath := make([][33]byte, len(route.Hops))
-	for i, hop := range route.Hops {
-		hopPub := hop.Channel.Node.PubKeyBytes
-		copy(paymentPath[i][:], hopPub[:])
-	}
-
-	payment := &channeldb.OutgoingPayment{
-		Invoice: channeldb.Invoice{
-			Terms: channeldb.ContractTerm{
-				Value: amount,
-			},
-			CreationDate: time.Now(),
-		},
-		Path:           paymentPath,
-		Fee:            route.TotalFees,
-		TimeLockLength: route.TotalTimeLock,
-	}
-	copy(payment.PaymentPreimage[:], preImage)
-
-	return r.server.chanDB.AddPayment(payment)
-}
-
 // validatePayReqExpiry checks if the passed payment request has expired. In
 // the case it has expired, an error will be returned.
 func validatePayReqExpiry(payReq *zpay32.Invoice) error {
@@ -1673,6 +1702,164 @@ func validatePayReqExpiry(payReq *zpay32.Invoice) error {
 	return nil
 }
 
+// unmarshallRouteHints converts the route hints encoded within a payment
+// request into the set of hop hints understood by the router.
+func unmarshallRouteHints(payReq *zpay32.Invoice) [][]routing.HopHint {
+	routeHints := make([][]routing.HopHint, 0, len(payReq.RouteHints))
+	for _, extraRoute := range payReq.RouteHints {
+		routeHint := make([]routing.HopHint, 0, len(extraRoute))
+		for _, hop := range extraRoute {
+			routeHint = append(routeHint, routing.HopHint{
+				NodeID:                    hop.PubKey,
+				ChannelID:                 hop.ShortChanID,
+				FeeBaseMSat:               hop.FeeBaseMsat,
+				FeeProportionalMillionths: hop.FeeProportionalMillionths,
+				CLTVExpiryDelta:           hop.CltvExpDelta,
+			})
+		}
+
+		routeHints = append(routeHints, routeHint)
+	}
+
+	return routeHints
+}
+
+// calculateFeeLimit returns the maximum fee in milli-satoshis that may be paid
+// for a payment of the given amount, according to the fee limit specified over
+// RPC. If no fee limit is specified, then nil is returned, which signals that
+// the fee isn't limited.
+func calculateFeeLimit(feeLimit *lnrpc.FeeLimit,
+	amount lnwire.MilliSatoshi) (*lnwire.MilliSatoshi, error) {
+
+	var limit lnwire.MilliSatoshi
+	switch feeLimit.GetLimit().(type) {
+	case *lnrpc.FeeLimit_FixedMsat:
+		fixed := feeLimit.GetFixedMsat()
+		if fixed < 0 {
+			return nil, fmt.Errorf("fee limit of %v msat must not "+
+				"be negative", fixed)
+		}
+		limit = lnwire.MilliSatoshi(fixed)
+
+	case *lnrpc.FeeLimit_Percent:
+		percent := feeLimit.GetPercent()
+		if percent < 0 {
+			return nil, fmt.Errorf("fee limit of %v%% must not be "+
+				"negative", percent)
+		}
+		limit = amount * lnwire.MilliSatoshi(percent) / 100
+
+	default:
+		return nil, nil
+	}
+
+	return &limit, nil
+}
+
+// unmarshallRestrictParams parses the optional restrictions on the route of a
+// payment of the given amount, as specified over RPC. Zero or empty values
+// signal that the corresponding restriction isn't enforced. If no cost model
+// is specified, then the one configured for the node is used.
+func unmarshallRestrictParams(amount lnwire.MilliSatoshi,
+	feeLimit *lnrpc.FeeLimit, cltvLimit uint32, outgoingChanID uint64,
+	lastHopPubkey []byte,
+	costModel *lnrpc.CostModel) (*routing.RestrictParams, error) {
+
+	var (
+		restrictions routing.RestrictParams
+		err          error
+	)
+	restrictions.FeeLimit, err = calculateFeeLimit(feeLimit, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if cltvLimit != 0 {
+		restrictions.CltvLimit = &cltvLimit
+	}
+	if outgoingChanID != 0 {
+		restrictions.OutgoingChannelID = &outgoingChanID
+	}
+
+	if len(lastHopPubkey) != 0 {
+		lastHop, err := btcec.ParsePubKey(lastHopPubkey, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse last hop "+
+				"pubkey: %v", err)
+		}
+
+		lastHopVertex := routing.NewVertex(lastHop)
+		restrictions.LastHop = &lastHopVertex
+	}
+
+	restrictions.CostModel, err = unmarshallCostModel(costModel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restrictions, nil
+}
+
+// unmarshallCostModel parses the cost model used to weigh the channels of a
+// route, as specified over RPC. If the cost model isn't specified, then the
+// one configured for the node is returned.
+func unmarshallCostModel(
+	rpcCostModel *lnrpc.CostModel) (*routing.CostModel, error) {
+
+	if rpcCostModel == nil {
+		return &routing.CostModel{
+			FeeWeight:          cfg.Routing.FeeWeight,
+			TimeLockRiskFactor: cfg.Routing.TimeLockRiskFactor,
+			AttemptCost: lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(cfg.Routing.AttemptCost),
+			),
+		}, nil
+	}
+
+	// As path finding relies on non-negative edge weights, none of the
+	// weights may be negative.
+	if rpcCostModel.FeeWeight < 0 || rpcCostModel.TimeLockRiskFactor < 0 ||
+		rpcCostModel.AttemptCost < 0 {
+
+		return nil, fmt.Errorf("cost model weights must be " +
+			"non-negative")
+	}
+
+	return &routing.CostModel{
+		FeeWeight:          rpcCostModel.FeeWeight,
+		TimeLockRiskFactor: rpcCostModel.TimeLockRiskFactor,
+		AttemptCost: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(rpcCostModel.AttemptCost),
+		),
+	}, nil
+}
+
+// unmarshallKeysendPreimage parses the optional keysend preimage of a payment,
+// as specified over RPC. As a keysend payment pays to the hash of its
+// preimage, a payment hash specified along with the preimage must match it.
+func unmarshallKeysendPreimage(rawPreimage,
+	paymentHash []byte) (*[32]byte, error) {
+
+	if len(rawPreimage) == 0 {
+		return nil, nil
+	}
+	if len(rawPreimage) != 32 {
+		return nil, fmt.Errorf("keysend preimage must be 32 bytes, "+
+			"got %v", len(rawPreimage))
+	}
+
+	var preimage [32]byte
+	copy(preimage[:], rawPreimage)
+
+	hash := sha256.Sum256(preimage[:])
+	if len(paymentHash) != 0 && !bytes.Equal(hash[:], paymentHash) {
+		return nil, fmt.Errorf("payment hash %x doesn't match "+
+			"keysend preimage", paymentHash)
+	}
+
+	return &preimage, nil
+}
+
 // SendPayment dispatches a bi-directional streaming RPC for sending payments
 // through the Lightning Network. A single RPC invocation creates a persistent
 // bi-directional stream allowing clients to rapidly send payments through the
@@ -1681,17 +1868,22 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 	// For each payment we need to know the msat amount, the destination
 	// public key, and the payment hash.
 	type payment struct {
-		msat      lnwire.MilliSatoshi
-		dest      []byte
-		pHash     []byte
-		cltvDelta uint16
+		msat       lnwire.MilliSatoshi
+		dest       []byte
+		pHash      []byte
+		cltvDelta  uint16
+		routeHints [][]routing.HopHint
+		payReq     []byte
+		feeLimit   *lnrpc.FeeLimit
+		cltvLimit  uint32
+		outgoingID uint64
+		lastHop    []byte
+		keysend    []byte
+		costModel  *lnrpc.CostModel
 	}
 	payChan := make(chan *payment)
 	errChan := make(chan error, 1)
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
-
 	// We don't allow payments to be sent while the daemon itself is still
 	// syncing as we may be trying to sent a payment over a "stale"
 	// channel.
@@ -1747,7 +1939,14 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// Populate the next payment, either from the
 				// payment request, or from the explicitly set
 				// fields.
-				p := &payment{}
+				p := &payment{
+					feeLimit:   nextPayment.FeeLimit,
+					cltvLimit:  nextPayment.CltvLimit,
+					outgoingID: nextPayment.OutgoingChanId,
+					lastHop:    nextPayment.LastHopPubkey,
+					keysend:    nextPayment.KeysendPreimage,
+					costModel:  nextPayment.CostModel,
+				}
 
 				// If the payment request field isn't blank,
 				// then the details of the invoice are encoded
@@ -1779,6 +1978,7 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 						return
 					}
 					p.dest = payReq.Destination.SerializeCompressed()
+					p.payReq = []byte(nextPayment.PaymentRequest)
 
 					// If the amount was not included in the
 					// invoice, then we let the payee
@@ -1796,6 +1996,7 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 					p.pHash = payReq.PaymentHash[:]
 					p.cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+					p.routeHints = unmarshallRouteHints(payReq)
 				} else {
 					// If the payment request field was not
 					// specified, construct the payment from
@@ -1841,6 +2042,35 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				continue
 			}
 
+			// Now that the amount of the payment is known, we can
+			// parse the restrictions on its route. Invalid
+			// restrictions are reported to the caller without
+			// terminating the stream.
+			restrictions, err := unmarshallRestrictParams(
+				p.msat, p.feeLimit, p.cltvLimit, p.outgoingID,
+				p.lastHop, p.costModel,
+			)
+			if err != nil {
+				if err := paymentStream.Send(&lnrpc.SendResponse{
+					PaymentError: err.Error(),
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
+			keysendPreimage, err := unmarshallKeysendPreimage(
+				p.keysend, p.pHash,
+			)
+			if err != nil {
+				if err := paymentStream.Send(&lnrpc.SendResponse{
+					PaymentError: err.Error(),
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+
 			// Parse the details of the payment which include the
 			// pubkey of the destination and the payment amount.
 			destNode, err := btcec.ParsePubKey(p.dest, btcec.S256())
@@ -1848,13 +2078,18 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				return err
 			}
 
-			// If we're in debug HTLC mode, then all outgoing HTLCs
-			// will pay to the same debug rHash. Otherwise, we pay
-			// to the rHash specified within the RPC request.
+			// A keysend payment pays to the hash of the preimage
+			// chosen by us. Otherwise, if we're in debug HTLC
+			// mode, then all outgoing HTLCs will pay to the same
+			// debug rHash. Otherwise, we pay to the rHash
+			// specified within the RPC request.
 			var rHash [32]byte
-			if cfg.DebugHTLC && len(p.pHash) == 0 {
+			switch {
+			case keysendPreimage != nil:
+				rHash = sha256.Sum256(keysendPreimage[:])
+			case cfg.DebugHTLC && len(p.pHash) == 0:
 				rHash = debugHash
-			} else {
+			default:
 				copy(rHash[:], p.pHash)
 			}
 
@@ -1876,9 +2111,17 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// returned. Otherwise, we'll get a non-nil
 				// error.
 				payment := &routing.LightningPayment{
-					Target:      destNode,
-					Amount:      p.msat,
-					PaymentHash: rHash,
+					Target:            destNode,
+					Amount:            p.msat,
+					PaymentHash:       rHash,
+					RouteHints:        p.routeHints,
+					PaymentRequest:    p.payReq,
+					FeeLimit:          restrictions.FeeLimit,
+					CltvLimit:         restrictions.CltvLimit,
+					OutgoingChannelID: restrictions.OutgoingChannelID,
+					LastHop:           restrictions.LastHop,
+					CostModel:         restrictions.CostModel,
+					KeysendPreimage:   keysendPreimage,
 				}
 				if p.cltvDelta != 0 {
 					payment.FinalCLTVDelta = &p.cltvDelta
@@ -1897,13 +2140,6 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 					return
 				}
 
-				// Save the completed payment to the database
-				// for record keeping purposes.
-				if err := r.savePayment(route, p.msat, preImage[:]); err != nil {
-					errChan <- err
-					return
-				}
-
 				err = paymentStream.Send(&lnrpc.SendResponse{
 					PaymentPreimage: preImage[:],
 					PaymentRoute:    marshallRoute(route),
@@ -1924,9 +2160,6 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	nextPayment *lnrpc.SendRequest) (*lnrpc.SendResponse, error) {
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
-
 	// We don't allow payments to be sent while the daemon itself is still
 	// syncing as we may be trying to sent a payment over a "stale"
 	// channel.
@@ -1936,10 +2169,12 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 	}
 
 	var (
-		destPub   *btcec.PublicKey
-		amtMSat   lnwire.MilliSatoshi
-		rHash     [32]byte
-		cltvDelta uint16
+		destPub         *btcec.PublicKey
+		amtMSat         lnwire.MilliSatoshi
+		rHash           [32]byte
+		cltvDelta       uint16
+		routeHints      [][]routing.HopHint
+		keysendPreimage *[32]byte
 	)
 
 	// If the proto request has an encoded payment request, then we we'll
@@ -1972,21 +2207,40 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 
 		rHash = *payReq.PaymentHash
 		cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+		routeHints = unmarshallRouteHints(payReq)
+
+		keysendPreimage, err = unmarshallKeysendPreimage(
+			nextPayment.KeysendPreimage, rHash[:],
+		)
+		if err != nil {
+			return nil, err
+		}
 
 		// Otherwise, the payment conditions have been manually
 		// specified in the proto.
 	} else {
-		// If we're in debug HTLC mode, then all outgoing HTLCs will
-		// pay to the same debug rHash. Otherwise, we pay to the rHash
-		// specified within the RPC request.
-		if cfg.DebugHTLC && nextPayment.PaymentHashString == "" {
-			rHash = debugHash
-		} else {
-			paymentHash, err := hex.DecodeString(nextPayment.PaymentHashString)
-			if err != nil {
-				return nil, err
-			}
+		paymentHash, err := hex.DecodeString(nextPayment.PaymentHashString)
+		if err != nil {
+			return nil, err
+		}
 
+		keysendPreimage, err = unmarshallKeysendPreimage(
+			nextPayment.KeysendPreimage, paymentHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// A keysend payment pays to the hash of the preimage chosen by
+		// us. Otherwise, if we're in debug HTLC mode, then all
+		// outgoing HTLCs will pay to the same debug rHash. Otherwise,
+		// we pay to the rHash specified within the RPC request.
+		switch {
+		case keysendPreimage != nil:
+			rHash = sha256.Sum256(keysendPreimage[:])
+		case cfg.DebugHTLC && nextPayment.PaymentHashString == "":
+			rHash = debugHash
+		default:
 			copy(rHash[:], paymentHash)
 		}
 
@@ -2015,13 +2269,30 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		}, nil
 	}
 
+	restrictions, err := unmarshallRestrictParams(
+		amtMSat, nextPayment.FeeLimit, nextPayment.CltvLimit,
+		nextPayment.OutgoingChanId, nextPayment.LastHopPubkey,
+		nextPayment.CostModel,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Finally, send a payment request to the channel router. If the
 	// payment succeeds, then the returned route will be that was used
 	// successfully within the payment.
 	payment := &routing.LightningPayment{
-		Target:      destPub,
-		Amount:      amtMSat,
-		PaymentHash: rHash,
+		Target:            destPub,
+		Amount:            amtMSat,
+		PaymentHash:       rHash,
+		RouteHints:        routeHints,
+		PaymentRequest:    []byte(nextPayment.PaymentRequest),
+		FeeLimit:          restrictions.FeeLimit,
+		CltvLimit:         restrictions.CltvLimit,
+		OutgoingChannelID: restrictions.OutgoingChannelID,
+		LastHop:           restrictions.LastHop,
+		CostModel:         restrictions.CostModel,
+		KeysendPreimage:   keysendPreimage,
 	}
 	if cltvDelta != 0 {
 		payment.FinalCLTVDelta = &cltvDelta
@@ -2033,83 +2304,408 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 		}, nil
 	}
 
-	// With the payment completed successfully, we now ave the details of
-	// the completed payment to the database for historical record keeping.
-	if err := r.savePayment(route, amtMSat, preImage[:]); err != nil {
-		return nil, err
-	}
-
 	return &lnrpc.SendResponse{
 		PaymentPreimage: preImage[:],
 		PaymentRoute:    marshallRoute(route),
 	}, nil
 }
 
-// AddInvoice attempts to add a new invoice to the invoice database. Any
-// duplicated invoices are rejected, therefore all invoices *must* have a
-// unique payment preimage.
-func (r *rpcServer) AddInvoice(ctx context.Context,
-	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
+// SendToRoute dispatches a bi-directional streaming RPC for sending payments
+// over a set of routes specified by the caller, rather than routes found
+// through path finding. A single RPC invocation creates a persistent
+// bi-directional stream allowing clients to send several payments over a
+// single persistent connection.
+func (r *rpcServer) SendToRoute(stream lnrpc.Lightning_SendToRouteServer) error {
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
 
-	var paymentPreimage [32]byte
+	// As with SendPayment, we'll limit the number of payments that can be
+	// outstanding at once.
+	const numOutstandingPayments = 2000
+	htlcSema := make(chan struct{}, numOutstandingPayments)
+	for i := 0; i < numOutstandingPayments; i++ {
+		htlcSema <- struct{}{}
+	}
 
-	switch {
-	// If a preimage wasn't specified, then we'll generate a new preimage
-	// from fresh cryptographic randomness.
-	case len(invoice.RPreimage) == 0:
-		if _, err := rand.Read(paymentPreimage[:]); err != nil {
-			return nil, err
+	errChan := make(chan error, 1)
+	for {
+		select {
+		case err := <-errChan:
+			return err
+		case <-r.quit:
+			return nil
+		default:
 		}
 
-	// Otherwise, if a preimage was specified, then it MUST be exactly
-	// 32-bytes.
-	case len(invoice.RPreimage) > 0 && len(invoice.RPreimage) != 32:
-		return nil, fmt.Errorf("payment preimage must be exactly "+
-			"32 bytes, is instead %v", len(invoice.RPreimage))
+		// Receive the next set of routes within the stream sent by the
+		// client. If we read the EOF sentinel, then the client has
+		// closed the stream, and we can exit normally.
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
 
-	// If the preimage meets the size specifications, then it can be used
-	// as is.
-	default:
-		copy(paymentPreimage[:], invoice.RPreimage[:])
-	}
+		// Any request that can't be parsed is reported to the caller
+		// without terminating the stream.
+		paymentHash, routes, err := r.unmarshallSendToRouteRequest(req)
+		if err != nil {
+			if err := stream.Send(&lnrpc.SendResponse{
+				PaymentError: err.Error(),
+			}); err != nil {
+				return err
+			}
+			continue
+		}
 
-	// The size of the memo, receipt and description hash attached must not
-	// exceed the maximum values for either of the fields.
-	if len(invoice.Memo) > channeldb.MaxMemoSize {
-		return nil, fmt.Errorf("memo too large: %v bytes "+
-			"(maxsize=%v)", len(invoice.Memo), channeldb.MaxMemoSize)
+		// We launch a new goroutine to execute the current payment so
+		// we can continue to serve requests while this payment is
+		// being dispatched.
+		go func() {
+			<-htlcSema
+			defer func() {
+				htlcSema <- struct{}{}
+			}()
+
+			resp := r.sendToRoute(routes, paymentHash)
+			if err := stream.Send(resp); err != nil {
+				select {
+				case errChan <- err:
+				default:
+				}
+			}
+		}()
 	}
-	if len(invoice.Receipt) > channeldb.MaxReceiptSize {
-		return nil, fmt.Errorf("receipt too large: %v bytes "+
-			"(maxsize=%v)", len(invoice.Receipt), channeldb.MaxReceiptSize)
+}
+
+// SendToRouteSync is the synchronous non-streaming version of SendToRoute.
+// This RPC is intended to be consumed by clients of the REST proxy.
+func (r *rpcServer) SendToRouteSync(ctx context.Context,
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
 	}
-	if len(invoice.DescriptionHash) > 0 && len(invoice.DescriptionHash) != 32 {
-		return nil, fmt.Errorf("description hash is %v bytes, must be %v",
-			len(invoice.DescriptionHash), channeldb.MaxPaymentRequestSize)
+
+	paymentHash, routes, err := r.unmarshallSendToRouteRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
-	amt := btcutil.Amount(invoice.Value)
-	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	return r.sendToRoute(routes, paymentHash), nil
+}
 
-	// The value of the invoice must also not exceed the current soft-limit
-	// on the largest payment within the network.
-	if amtMSat > maxPaymentMSat {
-		return nil, fmt.Errorf("payment of %v is too large, max "+
-			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
-	}
+// unmarshallSendToRouteRequest parses the payment hash and the routes of the
+// passed SendToRoute request, validating each route against the channel
+// graph.
+func (r *rpcServer) unmarshallSendToRouteRequest(
+	req *lnrpc.SendToRouteRequest) ([32]byte, []*routing.Route, error) {
 
-	// Next, generate the payment hash itself from the preimage. This will
-	// be used by clients to query for the state of a particular invoice.
-	rHash := sha256.Sum256(paymentPreimage[:])
+	var paymentHash [32]byte
+	if len(req.Routes) == 0 {
+		return paymentHash, nil, fmt.Errorf("unable to send, no routes " +
+			"provided")
+	}
 
-	// We also create an encoded payment request which allows the
-	// caller to compactly send the invoice to the payer. We'll create a
-	// list of options to be added to the encoded payment request. For now
-	// we only support the required fields description/description_hash,
-	// expiry, fallback address, and the amount field.
-	var options []func(*zpay32.Invoice)
+	// The payment hash may either be provided as raw bytes, or as a hex
+	// encoded string.
+	switch {
+	case len(req.PaymentHash) != 0:
+		if len(req.PaymentHash) != sha256.Size {
+			return paymentHash, nil, fmt.Errorf("payment hash "+
+				"must be exactly %v bytes", sha256.Size)
+		}
+		copy(paymentHash[:], req.PaymentHash)
 
-	// We only include the amount in the invoice if it is greater than 0.
+	case req.PaymentHashString != "":
+		hash, err := hex.DecodeString(req.PaymentHashString)
+		if err != nil {
+			return paymentHash, nil, err
+		}
+		if len(hash) != sha256.Size {
+			return paymentHash, nil, fmt.Errorf("payment hash "+
+				"must be exactly %v bytes", sha256.Size)
+		}
+		copy(paymentHash[:], hash)
+
+	case cfg.DebugHTLC:
+		paymentHash = debugHash
+
+	default:
+		return paymentHash, nil, fmt.Errorf("payment hash must be " +
+			"specified")
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+	routes := make([]*routing.Route, 0, len(req.Routes))
+	for i, rpcRoute := range req.Routes {
+		route, err := unmarshallRoute(rpcRoute, graph)
+		if err != nil {
+			return paymentHash, nil, fmt.Errorf("invalid route "+
+				"%v: %v", i, err)
+		}
+
+		// Currently, within the bootstrap phase of the network, we
+		// limit the largest payment size allotted to (2^32) - 1 mSAT
+		// or 4.29 million satoshis.
+		if route.TotalAmount > maxPaymentMSat {
+			return paymentHash, nil, fmt.Errorf("payment of %v "+
+				"over route %v is too large, max payment "+
+				"allowed is %v", route.TotalAmount, i,
+				maxPaymentMSat)
+		}
+
+		routes = append(routes, route)
+	}
+
+	return paymentHash, routes, nil
+}
+
+// sendToRoute sends a payment with the given payment hash over the passed
+// routes, and returns the outcome as a SendResponse. Payment failures are
+// reported within the response rather than as an error.
+func (r *rpcServer) sendToRoute(routes []*routing.Route,
+	paymentHash [32]byte) *lnrpc.SendResponse {
+
+	preImage, route, err := r.server.chanRouter.SendToRoute(
+		routes, paymentHash,
+	)
+	if err != nil {
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshallRoute(route),
+	}
+}
+
+// Rebalance shifts funds from one of our channels to another one of our
+// channels, by paying an internal invoice over a circular route that leaves
+// through the outgoing channel and returns through the incoming channel. The
+// fee paid to the nodes along the route is reported in the response, and
+// successful rebalances are recorded within the rebalance log, separately
+// from regular payments.
+func (r *rpcServer) Rebalance(ctx context.Context,
+	req *lnrpc.RebalanceRequest) (*lnrpc.RebalanceResponse, error) {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	if req.Amt <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if req.MaxFee < 0 {
+		return nil, fmt.Errorf("max fee must not be negative")
+	}
+
+	amtMSat := lnwire.NewMSatFromSatoshis(btcutil.Amount(req.Amt))
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("rebalance of %v is too large, max "+
+			"payment allowed is %v", amtMSat, maxPaymentMSat)
+	}
+
+	var restrictions routing.RestrictParams
+	if req.MaxFee != 0 {
+		feeLimit := lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(req.MaxFee),
+		)
+		restrictions.FeeLimit = &feeLimit
+	}
+
+	// The final hop of the route is ourselves, so we'll require the same
+	// CLTV delta we'd require for any of our own invoices.
+	finalCLTVDelta := uint16(cfg.Bitcoin.TimeLockDelta)
+	if registeredChains.PrimaryChain() == litecoinChain {
+		finalCLTVDelta = uint16(cfg.Litecoin.TimeLockDelta)
+	}
+
+	route, err := r.server.chanRouter.FindCircularRoute(
+		req.OutgoingChanId, req.IncomingChanId, amtMSat,
+		&restrictions, finalCLTVDelta,
+	)
+	if err != nil {
+		return &lnrpc.RebalanceResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
+
+	// With the route found, we'll add the internal invoice that the
+	// rebalance will pay. The invoice has no payment request, as it's
+	// never handed out.
+	var paymentPreimage [32]byte
+	if _, err := rand.Read(paymentPreimage[:]); err != nil {
+		return nil, err
+	}
+	paymentHash := sha256.Sum256(paymentPreimage[:])
+
+	invoice := &channeldb.Invoice{
+		CreationDate: time.Now(),
+		Memo: []byte(fmt.Sprintf("rebalance from channel %v to "+
+			"channel %v", req.OutgoingChanId, req.IncomingChanId)),
+		Terms: channeldb.ContractTerm{
+			Value:           amtMSat,
+			PaymentPreimage: paymentPreimage,
+		},
+	}
+	err = r.server.invoices.AddInvoice(invoice, paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	preImage, route, err := r.server.chanRouter.SendToRoute(
+		[]*routing.Route{route}, paymentHash,
+	)
+	if err != nil {
+		// As the internal invoice won't be paid anymore, we'll cancel
+		// it.
+		cancelErr := r.server.invoices.CancelInvoice(paymentHash)
+		if cancelErr != nil {
+			rpcsLog.Errorf("Unable to cancel rebalance invoice "+
+				"%x: %v", paymentHash, cancelErr)
+		}
+
+		return &lnrpc.RebalanceResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
+
+	rebalance := &channeldb.Rebalance{
+		PaymentHash: paymentHash,
+		OutgoingChanID: lnwire.NewShortChanIDFromInt(
+			req.OutgoingChanId,
+		),
+		IncomingChanID: lnwire.NewShortChanIDFromInt(
+			req.IncomingChanId,
+		),
+		Amount:    amtMSat,
+		Fee:       route.TotalFees,
+		Timestamp: time.Now(),
+	}
+	if err := r.server.chanDB.AddRebalance(rebalance); err != nil {
+		rpcsLog.Errorf("Unable to record rebalance %x: %v",
+			paymentHash, err)
+	}
+
+	return &lnrpc.RebalanceResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshallRoute(route),
+		Fee:             int64(route.TotalFees.ToSatoshis()),
+		FeeMsat:         int64(route.TotalFees),
+	}, nil
+}
+
+// AddInvoice attempts to add a new invoice to the invoice database. Any
+// duplicated invoices are rejected, therefore all invoices *must* have a
+// unique payment preimage.
+func (r *rpcServer) AddInvoice(ctx context.Context,
+	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
+
+	var (
+		paymentPreimage [32]byte
+		rHash           [32]byte
+	)
+
+	switch {
+	// If only a payment hash was specified, then this is a hold invoice
+	// for which the preimage will be supplied once an HTLC paying to it
+	// has been accepted.
+	case len(invoice.RPreimage) == 0 && len(invoice.RHash) > 0:
+		if len(invoice.RHash) != 32 {
+			return nil, fmt.Errorf("payment hash must be exactly "+
+				"32 bytes, is instead %v", len(invoice.RHash))
+		}
+		copy(rHash[:], invoice.RHash)
+		paymentPreimage = channeldb.UnknownPreimage
+
+	// If a preimage wasn't specified, then we'll generate a new preimage
+	// from fresh cryptographic randomness.
+	case len(invoice.RPreimage) == 0:
+		if _, err := rand.Read(paymentPreimage[:]); err != nil {
+			return nil, err
+		}
+
+	// Otherwise, if a preimage was specified, then it MUST be exactly
+	// 32-bytes.
+	case len(invoice.RPreimage) > 0 && len(invoice.RPreimage) != 32:
+		return nil, fmt.Errorf("payment preimage must be exactly "+
+			"32 bytes, is instead %v", len(invoice.RPreimage))
+
+	// A preimage and a payment hash can't both be specified.
+	case len(invoice.RHash) > 0:
+		return nil, fmt.Errorf("only one of the payment preimage and " +
+			"payment hash can be specified")
+
+	// If the preimage meets the size specifications, then it can be used
+	// as is.
+	default:
+		copy(paymentPreimage[:], invoice.RPreimage[:])
+	}
+
+	// The all-zeroes preimage is reserved to mark hold invoices.
+	if len(invoice.RPreimage) > 0 &&
+		paymentPreimage == channeldb.UnknownPreimage {
+
+		return nil, fmt.Errorf("payment preimage must not be all zeroes")
+	}
+
+	// The size of the memo, receipt and description hash attached must not
+	// exceed the maximum values for either of the fields.
+	if len(invoice.Memo) > channeldb.MaxMemoSize {
+		return nil, fmt.Errorf("memo too large: %v bytes "+
+			"(maxsize=%v)", len(invoice.Memo), channeldb.MaxMemoSize)
+	}
+	if len(invoice.Receipt) > channeldb.MaxReceiptSize {
+		return nil, fmt.Errorf("receipt too large: %v bytes "+
+			"(maxsize=%v)", len(invoice.Receipt), channeldb.MaxReceiptSize)
+	}
+	if len(invoice.DescriptionHash) > 0 && len(invoice.DescriptionHash) != 32 {
+		return nil, fmt.Errorf("description hash is %v bytes, must be %v",
+			len(invoice.DescriptionHash), channeldb.MaxPaymentRequestSize)
+	}
+
+	amt := btcutil.Amount(invoice.Value)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+
+	// The value of the invoice must also not exceed the current soft-limit
+	// on the largest payment within the network.
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max "+
+			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+	}
+
+	// Next, generate the payment hash itself from the preimage, unless
+	// this is a hold invoice. This will be used by clients to query for
+	// the state of a particular invoice.
+	if paymentPreimage != channeldb.UnknownPreimage {
+		rHash = sha256.Sum256(paymentPreimage[:])
+	}
+
+	// We also create an encoded payment request which allows the
+	// caller to compactly send the invoice to the payer. We'll create a
+	// list of options to be added to the encoded payment request. For now
+	// we only support the required fields description/description_hash,
+	// expiry, fallback address, and the amount field.
+	var options []func(*zpay32.Invoice)
+
+	// We only include the amount in the invoice if it is greater than 0.
 	// By not including the amount, we enable the creation of invoices that
 	// allow the payee to specify the amount of satoshis they wish to send.
 	if amtMSat > 0 {
@@ -2178,6 +2774,20 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 		options = append(options, zpay32.CLTVExpiry(uint64(defaultDelta)))
 	}
 
+	// If we were requested to include routing hints in the invoice, then
+	// we'll select a set of our private channels and create routing hints
+	// for them.
+	if invoice.Private {
+		routeHints, err := r.selectHopHints(amtMSat)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, routeHint := range routeHints {
+			options = append(options, zpay32.RouteHint(routeHint))
+		}
+	}
+
 	// Create and encode the payment request as a bech32 (zpay32) string.
 	creationDate := time.Now()
 	payReq, err := zpay32.NewInvoice(
@@ -2217,16 +2827,180 @@ func (r *rpcServer) AddInvoice(ctx context.Context,
 	)
 
 	// With all sanity checks passed, write the invoice to the database.
-	if err := r.server.invoices.AddInvoice(i); err != nil {
+	if err := r.server.invoices.AddInvoice(i, rHash); err != nil {
 		return nil, err
 	}
 
 	return &lnrpc.AddInvoiceResponse{
 		RHash:          rHash[:],
 		PaymentRequest: payReqString,
+		AddIndex:       i.AddIndex,
 	}, nil
 }
 
+// maxHopHints is the maximum number of route hints that will be included
+// within an invoice in order to avoid creating overly large payment requests.
+const maxHopHints = 20
+
+// selectHopHints returns a set of route hints, each made up of a single hop
+// hint, for the private channels that could be used by the payer to reach us.
+// Only channels that are active and for which we know the remote peer's latest
+// routing policy are considered. Channels with enough inbound capacity to
+// carry the invoice amount are preferred over those that don't.
+func (r *rpcServer) selectHopHints(
+	amtMSat lnwire.MilliSatoshi) ([][]zpay32.ExtraRoutingInfo, error) {
+
+	openChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch channels: %v", err)
+	}
+
+	isActive := func(chanID lnwire.ChannelID) bool {
+		link, err := r.server.htlcSwitch.GetLink(chanID)
+		if err != nil {
+			rpcsLog.Debugf("Skipping channel %v as a hop hint: "+
+				"unable to get link: %v", chanID, err)
+			return false
+		}
+		if !link.EligibleToForward() {
+			rpcsLog.Debugf("Skipping channel %v as a hop hint: "+
+				"link not eligible to forward", chanID)
+			return false
+		}
+
+		return true
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+
+	return hopHintsForChannels(
+		amtMSat, openChannels, isActive, graph.FetchChannelEdgesByID,
+	), nil
+}
+
+// hopHintsForChannels selects the hop hints for an invoice of the given amount
+// among our open channels. The isActive closure reports whether the payer can
+// currently reach us through a channel, and fetchEdges looks up the edge info
+// and policies of a channel by its short channel ID.
+func hopHintsForChannels(amtMSat lnwire.MilliSatoshi,
+	openChannels []*channeldb.OpenChannel,
+	isActive func(lnwire.ChannelID) bool,
+	fetchEdges func(uint64) (*channeldb.ChannelEdgeInfo,
+		*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy,
+		error)) [][]zpay32.ExtraRoutingInfo {
+
+	// hintCandidate couples a hop hint with the remote balance of the
+	// channel it was derived from so we can rank the candidates.
+	type hintCandidate struct {
+		hint          zpay32.ExtraRoutingInfo
+		remoteBalance lnwire.MilliSatoshi
+	}
+
+	var candidates []hintCandidate
+	for _, channel := range openChannels {
+		// Since we're only interested in our private channels, we'll
+		// skip the public ones.
+		if channel.ChannelFlags&lnwire.FFAnnounceChannel != 0 {
+			continue
+		}
+
+		// Make sure the channel is active, otherwise the payer won't
+		// be able to reach us through it.
+		chanID := lnwire.NewChanIDFromOutPoint(&channel.FundingOutpoint)
+		if !isActive(chanID) {
+			continue
+		}
+
+		// Fetch the policies for each end of the channel, as we'll
+		// need to use the one advertised by the remote peer.
+		shortChanID := channel.ShortChanID.ToUint64()
+		info, p1, p2, err := fetchEdges(shortChanID)
+		if err != nil {
+			rpcsLog.Debugf("Skipping channel %v as a hop hint: "+
+				"unable to fetch edge policies: %v", chanID, err)
+			continue
+		}
+
+		remotePub := channel.IdentityPub.SerializeCompressed()
+		remotePolicy := p2
+		if bytes.Equal(remotePub, info.NodeKey1Bytes[:]) {
+			remotePolicy = p1
+		}
+
+		// If for some reason we don't yet have the edge for the remote
+		// party, then we'll just skip adding this channel as a hint.
+		if remotePolicy == nil {
+			continue
+		}
+
+		candidates = append(candidates, hintCandidate{
+			hint: zpay32.ExtraRoutingInfo{
+				PubKey:      channel.IdentityPub,
+				ShortChanID: shortChanID,
+				FeeBaseMsat: uint32(remotePolicy.FeeBaseMSat),
+				FeeProportionalMillionths: uint32(
+					remotePolicy.FeeProportionalMillionths,
+				),
+				CltvExpDelta: remotePolicy.TimeLockDelta,
+			},
+			remoteBalance: channel.LocalCommitment.RemoteBalance,
+		})
+	}
+
+	// We'll now rank the candidates by the inbound capacity they offer,
+	// such that channels able to carry the invoice amount come first.
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].remoteBalance > candidates[j].remoteBalance
+	})
+
+	// If at least one channel can carry the full amount, then we'll only
+	// include the channels that are able to do so. Otherwise, we'll fall
+	// back to advertising the channels with the most inbound capacity.
+	if len(candidates) > 0 && candidates[0].remoteBalance >= amtMSat {
+		numSufficient := sort.Search(len(candidates), func(i int) bool {
+			return candidates[i].remoteBalance < amtMSat
+		})
+		candidates = candidates[:numSufficient]
+	}
+
+	if len(candidates) > maxHopHints {
+		candidates = candidates[:maxHopHints]
+	}
+
+	routeHints := make([][]zpay32.ExtraRoutingInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		routeHints = append(
+			routeHints, []zpay32.ExtraRoutingInfo{candidate.hint},
+		)
+	}
+
+	return routeHints
+}
+
+// marshallRouteHints converts the route hints encoded within a payment request
+// into their RPC representation.
+func marshallRouteHints(routeHints [][]zpay32.ExtraRoutingInfo) []*lnrpc.RouteHint {
+	resp := make([]*lnrpc.RouteHint, 0, len(routeHints))
+	for _, routeHint := range routeHints {
+		hopHints := make([]*lnrpc.HopHint, 0, len(routeHint))
+		for _, hop := range routeHint {
+			hopHints = append(hopHints, &lnrpc.HopHint{
+				NodeId: hex.EncodeToString(
+					hop.PubKey.SerializeCompressed(),
+				),
+				ChanId:                    hop.ShortChanID,
+				FeeBaseMsat:               hop.FeeBaseMsat,
+				FeeProportionalMillionths: hop.FeeProportionalMillionths,
+				CltvExpiryDelta:           uint32(hop.CltvExpDelta),
+			})
+		}
+
+		resp = append(resp, &lnrpc.RouteHint{HopHints: hopHints})
+	}
+
+	return resp
+}
+
 // createRPCInvoice creates an *lnrpc.Invoice from the *channeldb.Invoice.
 func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
 	paymentRequest := string(invoice.PaymentRequest)
@@ -2258,26 +3032,67 @@ func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
 	// The expiry will default to 9 blocks if not specified explicitly.
 	cltvExpiry := decoded.MinFinalCLTVExpiry()
 
-	preimage := invoice.Terms.PaymentPreimage
+	// Convert between the zpay32 routing hints and their RPC
+	// representation, an invoice is considered private if it includes any
+	// of them.
+	routeHints := marshallRouteHints(decoded.RouteHints)
+
+	// The preimage of a hold invoice is only known once it's been
+	// settled.
+	var preimage []byte
+	if invoice.Terms.PaymentPreimage != channeldb.UnknownPreimage {
+		preimage = invoice.Terms.PaymentPreimage[:]
+	}
 	satAmt := invoice.Terms.Value.ToSatoshis()
 
+	state, err := marshallInvoiceState(invoice.Terms.State)
+	if err != nil {
+		return nil, err
+	}
+
 	return &lnrpc.Invoice{
 		Memo:            string(invoice.Memo[:]),
 		Receipt:         invoice.Receipt[:],
 		RHash:           decoded.PaymentHash[:],
-		RPreimage:       preimage[:],
+		RPreimage:       preimage,
 		Value:           int64(satAmt),
 		CreationDate:    invoice.CreationDate.Unix(),
 		SettleDate:      settleDate,
-		Settled:         invoice.Terms.Settled,
+		Settled:         invoice.Terms.State == channeldb.ContractSettled,
 		PaymentRequest:  paymentRequest,
 		DescriptionHash: descHash,
 		Expiry:          expiry,
 		CltvExpiry:      cltvExpiry,
 		FallbackAddr:    fallbackAddr,
+		RouteHints:      routeHints,
+		Private:         len(routeHints) > 0,
+		State:           state,
+		AddIndex:        invoice.AddIndex,
+		SettleIndex:     invoice.SettleIndex,
 	}, nil
 }
 
+// marshallInvoiceState converts the channeldb invoice state to its RPC
+// counterpart.
+func marshallInvoiceState(state channeldb.ContractState) (
+	lnrpc.Invoice_InvoiceState, error) {
+
+	switch state {
+	case channeldb.ContractOpen:
+		return lnrpc.Invoice_OPEN, nil
+	case channeldb.ContractSettled:
+		return lnrpc.Invoice_SETTLED, nil
+	case channeldb.ContractCanceled:
+		return lnrpc.Invoice_CANCELED, nil
+	case channeldb.ContractAccepted:
+		return lnrpc.Invoice_ACCEPTED, nil
+	case channeldb.ContractExpired:
+		return lnrpc.Invoice_EXPIRED, nil
+	default:
+		return 0, fmt.Errorf("unknown invoice state %v", state)
+	}
+}
+
 // LookupInvoice attempts to look up an invoice according to its payment hash.
 // The passed payment hash *must* be exactly 32 bytes, if not an error is
 // returned.
@@ -2328,18 +3143,80 @@ func (r *rpcServer) LookupInvoice(ctx context.Context,
 	return rpcInvoice, nil
 }
 
+// SettleInvoice settles an accepted hold invoice using the supplied preimage.
+// All HTLCs that are being held for the invoice are settled with it.
+func (r *rpcServer) SettleInvoice(ctx context.Context,
+	req *lnrpc.SettleInvoiceRequest) (*lnrpc.SettleInvoiceResponse, error) {
+
+	if len(req.Preimage) != 32 {
+		return nil, fmt.Errorf("payment preimage must be exactly "+
+			"32 bytes, is instead %v", len(req.Preimage))
+	}
+
+	var preimage [32]byte
+	copy(preimage[:], req.Preimage)
+
+	rpcsLog.Debugf("[settleinvoice] settling hold invoice %x",
+		sha256.Sum256(preimage[:]))
+
+	if err := r.server.invoices.SettleHoldInvoice(preimage); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SettleInvoiceResponse{}, nil
+}
+
+// CancelInvoice cancels an open or accepted invoice. All HTLCs that are being
+// held for the invoice are failed back to their senders.
+func (r *rpcServer) CancelInvoice(ctx context.Context,
+	req *lnrpc.CancelInvoiceRequest) (*lnrpc.CancelInvoiceResponse, error) {
+
+	if len(req.PaymentHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(req.PaymentHash))
+	}
+
+	var payHash chainhash.Hash
+	copy(payHash[:], req.PaymentHash)
+
+	rpcsLog.Debugf("[cancelinvoice] canceling invoice %v", payHash)
+
+	if err := r.server.invoices.CancelInvoice(payHash); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.CancelInvoiceResponse{}, nil
+}
+
 // ListInvoices returns a list of all the invoices currently stored within the
-// database. Any active debug invoices are ignored.
+// database. Any active debug invoices are ignored. The invoices can be
+// paginated through their add index, and filtered by their state and creation
+// date.
 func (r *rpcServer) ListInvoices(ctx context.Context,
 	req *lnrpc.ListInvoiceRequest) (*lnrpc.ListInvoiceResponse, error) {
 
-	dbInvoices, err := r.server.chanDB.FetchAllInvoices(req.PendingOnly)
+	// We'll map the RPC request to a query to the invoice database, which
+	// only loads the invoices that are part of the requested page.
+	q := channeldb.InvoiceQuery{
+		IndexOffset:    req.IndexOffset,
+		NumMaxInvoices: req.NumMaxInvoices,
+		PendingOnly:    req.PendingOnly,
+		Reversed:       req.Reversed,
+	}
+	if req.CreationDateStart != 0 {
+		q.CreationDateStart = time.Unix(int64(req.CreationDateStart), 0)
+	}
+	if req.CreationDateEnd != 0 {
+		q.CreationDateEnd = time.Unix(int64(req.CreationDateEnd), 0)
+	}
+
+	invoiceSlice, err := r.server.chanDB.QueryInvoices(q)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("unable to query invoices: %v", err)
 	}
 
-	invoices := make([]*lnrpc.Invoice, len(dbInvoices))
-	for i, dbInvoice := range dbInvoices {
+	invoices := make([]*lnrpc.Invoice, len(invoiceSlice.Invoices))
+	for i, dbInvoice := range invoiceSlice.Invoices {
 
 		rpcInvoice, err := createRPCInvoice(dbInvoice)
 		if err != nil {
@@ -2350,34 +3227,92 @@ func (r *rpcServer) ListInvoices(ctx context.Context,
 	}
 
 	return &lnrpc.ListInvoiceResponse{
-		Invoices: invoices,
+		Invoices:         invoices,
+		FirstIndexOffset: invoiceSlice.FirstIndexOffset,
+		LastIndexOffset:  invoiceSlice.LastIndexOffset,
 	}, nil
 }
 
 // SubscribeInvoices returns a uni-directional stream (server -> client) for
-// notifying the client of newly added/settled invoices.
+// notifying the client of newly added/settled invoices. Accepted hold
+// invoices, as well as canceled and expired invoices are sent as well. If the
+// client specifies the last add and/or settle index it has seen, then all
+// invoices added or settled since are replayed from disk before any live
+// events are sent.
 func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
 	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
 
+	// We'll register for live notifications before consulting the
+	// database, such that no events are missed while the backlog is being
+	// replayed.
 	invoiceClient := r.server.invoices.SubscribeNotifications()
 	defer invoiceClient.Cancel()
 
+	sendInvoice := func(invoice *channeldb.Invoice) error {
+		rpcInvoice, err := createRPCInvoice(invoice)
+		if err != nil {
+			return err
+		}
+
+		return updateStream.Send(rpcInvoice)
+	}
+
+	// First, we'll replay all invoices added since the add index
+	// specified by the client, followed by all invoices settled since the
+	// specified settle index. We keep track of the latest indexes sent,
+	// such that any live events which were already covered by the replay
+	// are skipped.
+	lastAddIndex := req.AddIndex
+	lastSettleIndex := req.SettleIndex
+
+	addedInvoices, err := r.server.chanDB.InvoicesAddedSince(req.AddIndex)
+	if err != nil {
+		return err
+	}
+	for _, invoice := range addedInvoices {
+		if err := sendInvoice(invoice); err != nil {
+			return err
+		}
+		lastAddIndex = invoice.AddIndex
+	}
+
+	settledInvoices, err := r.server.chanDB.InvoicesSettledSince(
+		req.SettleIndex,
+	)
+	if err != nil {
+		return err
+	}
+	for _, invoice := range settledInvoices {
+		if err := sendInvoice(invoice); err != nil {
+			return err
+		}
+		lastSettleIndex = invoice.SettleIndex
+	}
+
 	for {
-		select {
-		// TODO(roasbeef): include newly added invoices?
-		case settledInvoice := <-invoiceClient.SettledInvoices:
+		var invoice *channeldb.Invoice
 
-			rpcInvoice, err := createRPCInvoice(settledInvoice)
-			if err != nil {
-				return err
+		select {
+		case invoice = <-invoiceClient.NewInvoices:
+			if invoice.AddIndex <= lastAddIndex {
+				continue
 			}
 
-			if err := updateStream.Send(rpcInvoice); err != nil {
-				return err
+		case invoice = <-invoiceClient.SettledInvoices:
+			if invoice.SettleIndex <= lastSettleIndex {
+				continue
 			}
+
+		case invoice = <-invoiceClient.AcceptedInvoices:
+		case invoice = <-invoiceClient.CanceledInvoices:
+		case invoice = <-invoiceClient.ExpiredInvoices:
 		case <-r.quit:
 			return nil
 		}
+
+		if err := sendInvoice(invoice); err != nil {
+			return err
+		}
 	}
 }
 
@@ -2469,15 +3404,14 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 
 	resp := &lnrpc.ChannelGraph{}
 
-	// Obtain the pointer to the global singleton channel graph, this will
-	// provide a consistent view of the graph due to bolt db's
-	// transactional model.
-	graph := r.server.chanDB.ChannelGraph()
+	// The graph is served from the router's in-memory copy of the channel
+	// graph, which spares us from reading the entire graph from disk.
+	router := r.server.chanRouter
 
 	// First iterate through all the known nodes (connected or unconnected
 	// within the graph), collating their current state into the RPC
 	// response.
-	err := graph.ForEachNode(nil, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
+	err := router.ForEachNode(func(node *channeldb.LightningNode) error {
 		nodeAddrs := make([]*lnrpc.NodeAddress, 0)
 		for _, addr := range node.Addresses {
 			nodeAddr := &lnrpc.NodeAddress{
@@ -2505,7 +3439,7 @@ func (r *rpcServer) DescribeGraph(ctx context.Context,
 	// Next, for each active channel we know of within the graph, create a
 	// similar response which details both the edge information as well as
 	// the routing policies of th nodes connecting the two edges.
-	err = graph.ForEachChannel(func(edgeInfo *channeldb.ChannelEdgeInfo,
+	err = router.ForEachChannel(func(edgeInfo *channeldb.ChannelEdgeInfo,
 		c1, c2 *channeldb.ChannelEdgePolicy) error {
 
 		edge := marshalDbEdge(edgeInfo, c1, c2)
@@ -2628,44 +3562,391 @@ func (r *rpcServer) GetNodeInfo(ctx context.Context,
 		return nil, err
 	}
 
-	nodeAddrs := make([]*lnrpc.NodeAddress, 0)
-	for _, addr := range node.Addresses {
-		nodeAddr := &lnrpc.NodeAddress{
-			Network: addr.Network(),
-			Addr:    addr.String(),
+	nodeAddrs := make([]*lnrpc.NodeAddress, 0)
+	for _, addr := range node.Addresses {
+		nodeAddr := &lnrpc.NodeAddress{
+			Network: addr.Network(),
+			Addr:    addr.String(),
+		}
+		nodeAddrs = append(nodeAddrs, nodeAddr)
+	}
+	// TODO(roasbeef): list channels as well?
+
+	nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
+	return &lnrpc.NodeInfo{
+		Node: &lnrpc.LightningNode{
+			LastUpdate: uint32(node.LastUpdate.Unix()),
+			PubKey:     in.PubKey,
+			Addresses:  nodeAddrs,
+			Alias:      node.Alias,
+			Color:      nodeColor,
+		},
+		NumChannels:   numChannels,
+		TotalCapacity: int64(totalCapacity),
+	}, nil
+}
+
+// QueryRoutes attempts to query the daemons' Channel Router for a possible
+// route to a target destination capable of carrying a specific amount of
+// satoshis within the route's flow. The retuned route contains the full
+// details required to craft and send an HTLC, also including the necessary
+// information that should be present within the Sphinx packet encapsulated
+// within the HTLC.
+//
+// TODO(roasbeef): should return a slice of routes in reality
+//   - create separate PR to send based on well formatted route
+func (r *rpcServer) QueryRoutes(ctx context.Context,
+	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
+
+	// First parse the hex-encoded public key into a full public key object
+	// we can properly manipulate.
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	// Currently, within the bootstrap phase of the network, we limit the
+	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
+	// satoshis.
+	amt := btcutil.Amount(in.Amt)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+	}
+
+	restrictions, err := unmarshallRestrictParams(
+		amtMSat, in.FeeLimit, in.CltvLimit, in.OutgoingChanId,
+		in.LastHopPubkey, in.CostModel,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Query the channel router for a possible path to the destination that
+	// can carry `in.Amt` satoshis _including_ the total fee required on
+	// the route.
+	routes, err := r.server.chanRouter.FindRoutes(
+		pubKey, amtMSat, restrictions, uint32(in.NumRoutes),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// As the number of returned routes can be less than the number of
+	// requested routes, we'll clamp down the length of the response to the
+	// minimum of the two.
+	numRoutes := int32(len(routes))
+	if in.NumRoutes < numRoutes {
+		numRoutes = in.NumRoutes
+	}
+
+	// For each valid route, we'll convert the result into the format
+	// required by the RPC system.
+	routeResp := &lnrpc.QueryRoutesResponse{
+		Routes: make([]*lnrpc.Route, 0, in.NumRoutes),
+	}
+	for i := int32(0); i < numRoutes; i++ {
+		routeResp.Routes = append(
+			routeResp.Routes, marshallRoute(routes[i]),
+		)
+	}
+
+	return routeResp, nil
+}
+
+func marshallRoute(route *routing.Route) *lnrpc.Route {
+	resp := &lnrpc.Route{
+		TotalTimeLock: route.TotalTimeLock,
+		TotalFees:     int64(route.TotalFees.ToSatoshis()),
+		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
+		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+	}
+	for i, hop := range route.Hops {
+		resp.Hops[i] = &lnrpc.Hop{
+			ChanId:           hop.Channel.ChannelID,
+			ChanCapacity:     int64(hop.Channel.Capacity),
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(hop.Fee.ToSatoshis()),
+			Expiry:           uint32(hop.OutgoingTimeLock),
+			PubKey:           hex.EncodeToString(hop.Channel.Node.PubKeyBytes[:]),
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(hop.Fee),
+		}
+	}
+	resp.TotalFeesMsat = int64(route.TotalFees)
+	resp.TotalAmtMsat = int64(route.TotalAmount)
+
+	return resp
+}
+
+// unmarshallRoute converts a route specified over RPC into a route that can be
+// used by the router. Each hop is validated against the channel graph: its
+// channel must be known and lead away from the node at the end of the prior
+// hop, and the policy of that direction of the channel must be known. Amounts
+// specified in milli-satoshis take precedence over those in satoshis.
+func unmarshallRoute(rpcRoute *lnrpc.Route,
+	graph *channeldb.ChannelGraph) (*routing.Route, error) {
+
+	if len(rpcRoute.Hops) == 0 {
+		return nil, fmt.Errorf("route must have at least one hop")
+	}
+
+	sourceNode, err := graph.SourceNode()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch source node from "+
+			"graph: %v", err)
+	}
+	sourceVertex := routing.Vertex(sourceNode.PubKeyBytes)
+
+	prevNode := sourceNode.PubKeyBytes
+	hops := make([]*routing.Hop, len(rpcRoute.Hops))
+	for i, rpcHop := range rpcRoute.Hops {
+		edgeInfo, policy1, policy2, err := graph.FetchChannelEdgesByID(
+			rpcHop.ChanId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch channel %v: %v",
+				rpcHop.ChanId, err)
+		}
+
+		// The policy we'll use for this hop is the one of the node at
+		// the start of the hop, which is the end of the prior hop.
+		var policy *channeldb.ChannelEdgePolicy
+		switch prevNode {
+		case edgeInfo.NodeKey1Bytes:
+			policy = policy1
+		case edgeInfo.NodeKey2Bytes:
+			policy = policy2
+		default:
+			return nil, fmt.Errorf("channel %v of hop %v is not "+
+				"connected to the prior hop", rpcHop.ChanId, i)
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("no policy known for channel "+
+				"%v of hop %v", rpcHop.ChanId, i)
+		}
+
+		// If the caller specified the node at the end of the hop, then
+		// it must match the graph.
+		nextNode := policy.Node.PubKeyBytes
+		if rpcHop.PubKey != "" &&
+			rpcHop.PubKey != hex.EncodeToString(nextNode[:]) {
+
+			return nil, fmt.Errorf("channel %v of hop %v does not "+
+				"lead to node %v", rpcHop.ChanId, i,
+				rpcHop.PubKey)
+		}
+
+		amtToForward := lnwire.MilliSatoshi(rpcHop.AmtToForwardMsat)
+		if amtToForward == 0 {
+			amtToForward = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(rpcHop.AmtToForward),
+			)
+		}
+		fee := lnwire.MilliSatoshi(rpcHop.FeeMsat)
+		if fee == 0 {
+			fee = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(rpcHop.Fee),
+			)
+		}
+
+		hops[i] = &routing.Hop{
+			Channel: &routing.ChannelHop{
+				Capacity:          edgeInfo.Capacity,
+				Chain:             edgeInfo.ChainHash,
+				ChannelEdgePolicy: policy,
+			},
+			OutgoingTimeLock: rpcHop.Expiry,
+			AmtToForward:     amtToForward,
+			Fee:              fee,
+		}
+
+		prevNode = nextNode
+	}
+
+	totalAmt := lnwire.MilliSatoshi(rpcRoute.TotalAmtMsat)
+	if totalAmt == 0 {
+		totalAmt = lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(rpcRoute.TotalAmt),
+		)
+	}
+
+	return routing.NewRouteFromHops(
+		totalAmt, rpcRoute.TotalTimeLock, sourceVertex, hops,
+	)
+}
+
+// marshallPaymentRoute converts the route of a payment attempt, as recorded by
+// the control tower, into its RPC representation.
+func marshallPaymentRoute(route *channeldb.PaymentRoute) *lnrpc.Route {
+	resp := &lnrpc.Route{
+		TotalTimeLock: route.TotalTimeLock,
+		TotalFees:     int64(route.TotalFees.ToSatoshis()),
+		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
+		TotalFeesMsat: int64(route.TotalFees),
+		TotalAmtMsat:  int64(route.TotalAmount),
+		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+	}
+
+	// The fee of each hop is the difference between the amount it
+	// receives and the amount it forwards. The first hop receives the
+	// total amount of the route.
+	incomingAmt := route.TotalAmount
+	for i, hop := range route.Hops {
+		fee := incomingAmt - hop.AmtToForward
+		resp.Hops[i] = &lnrpc.Hop{
+			ChanId:           hop.ChannelID,
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(fee.ToSatoshis()),
+			Expiry:           hop.OutgoingTimeLock,
+			PubKey:           hex.EncodeToString(hop.PubKeyBytes[:]),
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(fee),
+		}
+		incomingAmt = hop.AmtToForward
+	}
+
+	return resp
+}
+
+// marshallHTLCAttempt converts an HTLC attempt of a payment into its RPC
+// representation.
+func marshallHTLCAttempt(htlc *channeldb.HTLCAttempt) *lnrpc.HTLCAttempt {
+	resp := &lnrpc.HTLCAttempt{
+		AttemptId:     htlc.AttemptID,
+		Status:        lnrpc.HTLCAttempt_IN_FLIGHT,
+		Route:         marshallPaymentRoute(&htlc.Route),
+		AttemptTimeNs: marshallTimeNano(htlc.AttemptTime),
+	}
+
+	switch {
+	case htlc.Settle != nil:
+		resp.Status = lnrpc.HTLCAttempt_SUCCEEDED
+		resp.ResolveTimeNs = marshallTimeNano(htlc.Settle.SettleTime)
+
+	case htlc.Failure != nil:
+		resp.Status = lnrpc.HTLCAttempt_FAILED
+		resp.ResolveTimeNs = marshallTimeNano(htlc.Failure.FailTime)
+		resp.Failure = marshallHTLCFailure(htlc.Failure)
+	}
+
+	return resp
+}
+
+// marshallHTLCFailure converts the failure info of a failed HTLC attempt into
+// its RPC representation.
+func marshallHTLCFailure(failure *channeldb.HTLCFailInfo) *lnrpc.HTLCFailure {
+	resp := &lnrpc.HTLCFailure{
+		FailureSourceIndex: failure.FailureSourceIndex,
+	}
+
+	switch failure.Reason {
+	case channeldb.HTLCFailInternal:
+		resp.Reason = lnrpc.HTLCFailure_INTERNAL
+	case channeldb.HTLCFailMessage:
+		resp.Reason = lnrpc.HTLCFailure_MESSAGE
+	default:
+		resp.Reason = lnrpc.HTLCFailure_UNKNOWN
+	}
+
+	if failure.Message != nil {
+		resp.Code = uint32(failure.Message.Code())
+		resp.Message = failure.Message.Error()
+	}
+
+	return resp
+}
+
+// marshallPayment converts a payment tracked by the control tower into its RPC
+// representation.
+func marshallPayment(payment *channeldb.Payment) *lnrpc.Payment {
+	resp := &lnrpc.Payment{
+		PaymentHash:    hex.EncodeToString(payment.Info.PaymentHash[:]),
+		Value:          int64(payment.Info.Value.ToSatoshis()),
+		ValueMsat:      int64(payment.Info.Value),
+		CreationDate:   payment.Info.CreationDate.Unix(),
+		CreationTimeNs: marshallTimeNano(payment.Info.CreationDate),
+		PaymentRequest: string(payment.Info.PaymentRequest),
+		PaymentIndex:   payment.SequenceNum,
+		Htlcs:          make([]*lnrpc.HTLCAttempt, len(payment.HTLCs)),
+	}
+
+	for i := range payment.HTLCs {
+		resp.Htlcs[i] = marshallHTLCAttempt(&payment.HTLCs[i])
+	}
+
+	switch payment.Status {
+	case channeldb.StatusInFlight:
+		resp.Status = lnrpc.Payment_IN_FLIGHT
+	case channeldb.StatusSucceeded:
+		resp.Status = lnrpc.Payment_SUCCEEDED
+	case channeldb.StatusFailed:
+		resp.Status = lnrpc.Payment_FAILED
+	default:
+		resp.Status = lnrpc.Payment_UNKNOWN
+	}
+
+	if payment.FailureReason != nil {
+		switch *payment.FailureReason {
+		case channeldb.FailureReasonTimeout:
+			resp.FailureReason = lnrpc.Payment_FAILURE_REASON_TIMEOUT
+		case channeldb.FailureReasonNoRoute:
+			resp.FailureReason = lnrpc.Payment_FAILURE_REASON_NO_ROUTE
+		case channeldb.FailureReasonIncorrectPaymentDetails:
+			resp.FailureReason = lnrpc.Payment_FAILURE_REASON_INCORRECT_PAYMENT_DETAILS
+		default:
+			resp.FailureReason = lnrpc.Payment_FAILURE_REASON_ERROR
+		}
+	}
+
+	// The path, fee and preimage of the payment are those of the attempt
+	// that settled it.
+	if settled := payment.SettledHTLC(); settled != nil {
+		route := settled.Route
+		resp.Path = make([]string, len(route.Hops))
+		for i, hop := range route.Hops {
+			resp.Path[i] = hex.EncodeToString(hop.PubKeyBytes[:])
 		}
-		nodeAddrs = append(nodeAddrs, nodeAddr)
+		resp.Fee = int64(route.TotalFees.ToSatoshis())
+		resp.FeeMsat = int64(route.TotalFees)
+		resp.PaymentPreimage = hex.EncodeToString(
+			settled.Settle.Preimage[:],
+		)
 	}
-	// TODO(roasbeef): list channels as well?
 
-	nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
-	return &lnrpc.NodeInfo{
-		Node: &lnrpc.LightningNode{
-			LastUpdate: uint32(node.LastUpdate.Unix()),
-			PubKey:     in.PubKey,
-			Addresses:  nodeAddrs,
-			Alias:      node.Alias,
-			Color:      nodeColor,
-		},
-		NumChannels:   numChannels,
-		TotalCapacity: int64(totalCapacity),
-	}, nil
+	return resp
 }
 
-// QueryRoutes attempts to query the daemons' Channel Router for a possible
-// route to a target destination capable of carrying a specific amount of
-// satoshis within the route's flow. The retuned route contains the full
-// details required to craft and send an HTLC, also including the necessary
-// information that should be present within the Sphinx packet encapsulated
-// within the HTLC.
-//
-// TODO(roasbeef): should return a slice of routes in reality
-//  * create separate PR to send based on well formatted route
-func (r *rpcServer) QueryRoutes(ctx context.Context,
-	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
+// marshallTimeNano converts the passed time into UNIX nanoseconds, with the
+// zero time converted to zero.
+func marshallTimeNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
+
+// Probe estimates whether a payment of a specific amount is able to reach a
+// target destination, without actually paying it. An HTLC with a random
+// payment hash is sent along each candidate route, and the failures they're
+// sent back with reveal how far along each route the amount was able to
+// reach.
+func (r *rpcServer) Probe(ctx context.Context,
+	in *lnrpc.ProbeRequest) (*lnrpc.ProbeResponse, error) {
+
+	// Probes are sent just like payments, so we don't allow them while the
+	// daemon itself is still syncing.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
 
-	// First parse the hex-encoded public key into a full public key object
-	// we can properly manipulate.
 	pubKeyBytes, err := hex.DecodeString(in.PubKey)
 	if err != nil {
 		return nil, err
@@ -2675,66 +3956,128 @@ func (r *rpcServer) QueryRoutes(ctx context.Context,
 		return nil, err
 	}
 
-	// Currently, within the bootstrap phase of the network, we limit the
-	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
-	// satoshis.
+	if in.Amt <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	if in.NumRoutes <= 0 {
+		return nil, fmt.Errorf("number of routes must be positive")
+	}
+	if in.FinalCltvDelta < 0 {
+		return nil, fmt.Errorf("final cltv delta must not be negative")
+	}
+
 	amt := btcutil.Amount(in.Amt)
 	amtMSat := lnwire.NewMSatFromSatoshis(amt)
 	if amtMSat > maxPaymentMSat {
-		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+		return nil, fmt.Errorf("probe of %v is too large, max payment "+
 			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
 	}
 
-	// Query the channel router for a possible path to the destination that
-	// can carry `in.Amt` satoshis _including_ the total fee required on
-	// the route.
-	routes, err := r.server.chanRouter.FindRoutes(
-		pubKey, amtMSat, uint32(in.NumRoutes),
+	restrictions, err := unmarshallRestrictParams(
+		amtMSat, in.FeeLimit, in.CltvLimit, 0, nil, nil,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// As the number of returned routes can be less than the number of
-	// requested routes, we'll clamp down the length of the response to the
-	// minimum of the two.
-	numRoutes := int32(len(routes))
-	if in.NumRoutes < numRoutes {
-		numRoutes = in.NumRoutes
+	finalCLTVDelta := uint16(routing.DefaultFinalCLTVDelta)
+	if in.FinalCltvDelta != 0 {
+		finalCLTVDelta = uint16(in.FinalCltvDelta)
 	}
 
-	// For each valid route, we'll convert the result into the format
-	// required by the RPC system.
-	routeResp := &lnrpc.QueryRoutesResponse{
-		Routes: make([]*lnrpc.Route, 0, in.NumRoutes),
+	results, err := r.server.chanRouter.ProbeRoutes(
+		pubKey, amtMSat, restrictions, uint32(in.NumRoutes),
+		finalCLTVDelta,
+	)
+	if err != nil {
+		return nil, err
 	}
-	for i := int32(0); i < numRoutes; i++ {
-		routeResp.Routes = append(
-			routeResp.Routes, marshallRoute(routes[i]),
-		)
+
+	resp := &lnrpc.ProbeResponse{
+		Results: make([]*lnrpc.ProbeRouteResult, 0, len(results)),
 	}
+	for _, result := range results {
+		rpcResult := &lnrpc.ProbeRouteResult{
+			Route:            marshallRoute(result.Route),
+			Success:          result.Success,
+			BottleneckChanId: result.BottleneckChannel,
+			Hops:             make([]*lnrpc.ProbeHop, len(result.Hops)),
+		}
+		if result.FailureMessage != nil {
+			rpcResult.Failure = result.FailureMessage.Error()
+		}
+		for i, hop := range result.Hops {
+			rpcResult.Hops[i] = &lnrpc.ProbeHop{
+				ChanId:     hop.ChannelID,
+				PubKey:     hex.EncodeToString(hop.PubKey[:]),
+				MaxAmtMsat: int64(hop.MaxAmt),
+			}
+		}
 
-	return routeResp, nil
+		resp.Results = append(resp.Results, rpcResult)
+	}
+
+	return resp, nil
 }
 
-func marshallRoute(route *routing.Route) *lnrpc.Route {
-	resp := &lnrpc.Route{
-		TotalTimeLock: route.TotalTimeLock,
-		TotalFees:     int64(route.TotalFees.ToSatoshis()),
-		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
-		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+// QueryMissionControl exposes the internal mission control state to callers.
+// It is a development feature, useful to inspect which channels and nodes the
+// router learned to avoid from past payment attempts.
+func (r *rpcServer) QueryMissionControl(ctx context.Context,
+	req *lnrpc.QueryMissionControlRequest) (
+	*lnrpc.QueryMissionControlResponse, error) {
+
+	snapshot := r.server.chanRouter.QueryMissionControl()
+
+	rpcNodes := make([]*lnrpc.NodeHistory, 0, len(snapshot.Nodes))
+	for _, node := range snapshot.Nodes {
+		pubkey := node.PubKey
+
+		rpcNodes = append(rpcNodes, &lnrpc.NodeHistory{
+			Pubkey:       pubkey[:],
+			LastFailTime: unixOrZero(node.LastFailTime),
+		})
 	}
-	for i, hop := range route.Hops {
-		resp.Hops[i] = &lnrpc.Hop{
-			ChanId:       hop.Channel.ChannelID,
-			ChanCapacity: int64(hop.Channel.Capacity),
-			AmtToForward: int64(hop.AmtToForward.ToSatoshis()),
-			Fee:          int64(hop.Fee.ToSatoshis()),
-			Expiry:       uint32(hop.OutgoingTimeLock),
-		}
+
+	rpcChannels := make([]*lnrpc.ChannelHistory, 0, len(snapshot.Channels))
+	for _, channel := range snapshot.Channels {
+		rpcChannels = append(rpcChannels, &lnrpc.ChannelHistory{
+			ChanId:             channel.ChannelID,
+			LastFailTime:       unixOrZero(channel.LastFailTime),
+			MinPenalizeAmtMsat: int64(channel.MinPenalizeAmt),
+			LastSuccessTime:    unixOrZero(channel.LastSuccessTime),
+			MaxSuccessAmtMsat:  int64(channel.MaxSuccessAmt),
+			SuccessProb:        float32(channel.SuccessProb),
+		})
 	}
 
-	return resp
+	return &lnrpc.QueryMissionControlResponse{
+		Nodes:    rpcNodes,
+		Channels: rpcChannels,
+	}, nil
+}
+
+// unixOrZero returns the unix timestamp of the given time, or zero if the time
+// isn't set.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// ResetMissionControl clears all mission control state, both in memory and on
+// disk, so that path finding starts over from the a priori probabilities.
+func (r *rpcServer) ResetMissionControl(ctx context.Context,
+	req *lnrpc.ResetMissionControlRequest) (
+	*lnrpc.ResetMissionControlResponse, error) {
+
+	if err := r.server.chanRouter.ResetMissionControl(); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.ResetMissionControlResponse{}, nil
 }
 
 // GetNetworkInfo returns some basic stats about the known channel graph from
@@ -2842,6 +4185,14 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 		MaxChannelSize: int64(maxChannelSize),
 	}
 
+	// We'll also report how the gossiper has treated the channel updates
+	// it received, which gives some insight into the amount of spam
+	// within the network.
+	updateStats := r.server.authGossiper.UpdateStats()
+	netInfo.NumStaleUpdates = updateStats.NumStaleUpdates
+	netInfo.NumChanRateLimitedUpdates = updateStats.NumChanRateLimited
+	netInfo.NumPeerRateLimitedUpdates = updateStats.NumPeerRateLimited
+
 	// Similarly, if we don't have any channels, then we'll also set the
 	// average channel size to zero in order to avoid weird JSON encoding
 	// outputs.
@@ -2852,6 +4203,70 @@ func (r *rpcServer) GetNetworkInfo(ctx context.Context,
 	return netInfo, nil
 }
 
+// ExportGraph returns a compact snapshot of the public channel graph as known
+// by the responding node, which can be imported by another node in order to
+// quickly bootstrap its view of the graph.
+func (r *rpcServer) ExportGraph(ctx context.Context,
+	_ *lnrpc.ExportGraphRequest) (*lnrpc.ExportGraphResponse, error) {
+
+	msgs, err := r.server.authGossiper.ExportGraph()
+	if err != nil {
+		return nil, fmt.Errorf("unable to export graph: %v", err)
+	}
+
+	var b bytes.Buffer
+	err = discovery.WriteGraphSnapshot(
+		&b, *activeNetParams.GenesisHash, msgs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to write graph snapshot: %v",
+			err)
+	}
+
+	rpcsLog.Debugf("[exportgraph] exported %v announcements (%v bytes)",
+		len(msgs), b.Len())
+
+	return &lnrpc.ExportGraphResponse{
+		Snapshot: b.Bytes(),
+	}, nil
+}
+
+// ImportGraph imports a graph snapshot as returned by ExportGraph. Each of the
+// announcements within the snapshot is fully validated before being applied
+// to the channel graph, and none of them are relayed to our peers.
+func (r *rpcServer) ImportGraph(ctx context.Context,
+	in *lnrpc.ImportGraphRequest) (*lnrpc.ImportGraphResponse, error) {
+
+	chain, msgs, err := discovery.ReadGraphSnapshot(
+		bytes.NewReader(in.Snapshot),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read graph snapshot: %v", err)
+	}
+
+	// We'll refuse to import a snapshot of the graph of another chain, as
+	// none of its channels would be found within our chain.
+	if chain != *activeNetParams.GenesisHash {
+		return nil, fmt.Errorf("graph snapshot is for chain %v, "+
+			"expected %v", chain, activeNetParams.GenesisHash)
+	}
+
+	rpcsLog.Infof("[importgraph] importing %v announcements", len(msgs))
+
+	stats, err := r.server.authGossiper.ImportGraph(msgs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to import graph: %v", err)
+	}
+
+	return &lnrpc.ImportGraphResponse{
+		NumChannels: stats.NumChannels,
+		NumUpdates:  stats.NumUpdates,
+		NumNodes:    stats.NumNodes,
+		NumSkipped:  stats.NumSkipped,
+		NumInvalid:  stats.NumInvalid,
+	}, nil
+}
+
 // StopDaemon will send a shutdown request to the interrupt handler, triggering
 // a graceful shutdown of the daemon.
 func (r *rpcServer) StopDaemon(ctx context.Context,
@@ -2983,40 +4398,90 @@ func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopol
 	}
 }
 
-// ListPayments returns a list of all outgoing payments.
+// ListPayments returns a list of outgoing payments. The payments can be
+// paginated through their index, and filtered by their creation date and
+// status.
 func (r *rpcServer) ListPayments(ctx context.Context,
-	_ *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+	req *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
 
 	rpcsLog.Debugf("[ListPayments]")
 
-	payments, err := r.server.chanDB.FetchAllPayments()
-	if err != nil && err != channeldb.ErrNoPaymentsCreated {
+	q := channeldb.PaymentsQuery{
+		IndexOffset:       req.IndexOffset,
+		MaxPayments:       req.MaxPayments,
+		Reversed:          req.Reversed,
+		IncludeIncomplete: req.IncludeIncomplete,
+	}
+	if req.CreationDateStart != 0 {
+		q.CreationDateStart = time.Unix(int64(req.CreationDateStart), 0)
+	}
+	if req.CreationDateEnd != 0 {
+		q.CreationDateEnd = time.Unix(int64(req.CreationDateEnd), 0)
+	}
+
+	paymentsSlice, err := r.server.chanDB.QueryPayments(q)
+	if err != nil {
 		return nil, err
 	}
+	payments := paymentsSlice.Payments
 
 	paymentsResp := &lnrpc.ListPaymentsResponse{
-		Payments: make([]*lnrpc.Payment, len(payments)),
+		Payments:         make([]*lnrpc.Payment, len(payments)),
+		FirstIndexOffset: paymentsSlice.FirstIndexOffset,
+		LastIndexOffset:  paymentsSlice.LastIndexOffset,
 	}
 	for i, payment := range payments {
-		path := make([]string, len(payment.Path))
-		for i, hop := range payment.Path {
-			path[i] = hex.EncodeToString(hop[:])
-		}
-
-		paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
-		paymentsResp.Payments[i] = &lnrpc.Payment{
-			PaymentHash:     hex.EncodeToString(paymentHash[:]),
-			Value:           int64(payment.Terms.Value.ToSatoshis()),
-			CreationDate:    payment.CreationDate.Unix(),
-			Path:            path,
-			Fee:             int64(payment.Fee.ToSatoshis()),
-			PaymentPreimage: hex.EncodeToString(payment.PaymentPreimage[:]),
-		}
+		paymentsResp.Payments[i] = marshallPayment(payment)
 	}
 
 	return paymentsResp, nil
 }
 
+// TrackPayment returns a stream of updates for the outgoing payment to the
+// given payment hash. The current state of the payment is sent first, and the
+// stream is closed once the payment has succeeded or failed.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
+
+	if len(req.PaymentHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 bytes, "+
+			"is instead %v", len(req.PaymentHash))
+	}
+
+	var paymentHash [32]byte
+	copy(paymentHash[:], req.PaymentHash)
+
+	rpcsLog.Debugf("[TrackPayment] payment_hash=%x", paymentHash)
+
+	subscription, err := r.server.controlTower.SubscribePayment(paymentHash)
+	if err != nil {
+		return err
+	}
+	defer subscription.Cancel()
+
+	for {
+		select {
+		case payment, ok := <-subscription.Updates:
+			// The subscription is closed once the payment has
+			// reached its final state.
+			if !ok {
+				return nil
+			}
+
+			err := updateStream.Send(marshallPayment(payment))
+			if err != nil {
+				return err
+			}
+
+		case <-updateStream.Context().Done():
+			return updateStream.Context().Err()
+
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
 // DeleteAllPayments deletes all outgoing payments from DB.
 func (r *rpcServer) DeleteAllPayments(ctx context.Context,
 	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
@@ -3108,6 +4573,7 @@ func (r *rpcServer) DecodePayReq(ctx context.Context,
 		FallbackAddr:    fallbackAddr,
 		Expiry:          expiry,
 		CltvExpiry:      int64(payReq.MinFinalCLTVExpiry()),
+		RouteHints:      marshallRouteHints(payReq.RouteHints),
 	}, nil
 }
 
@@ -3352,6 +4818,188 @@ func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
 	return &lnrpc.PolicyUpdateResponse{}, nil
 }
 
+// UpdateChanStatus allows the caller to manually disable or enable one of our
+// public channels, by broadcasting a channel update with the disabled flag set
+// accordingly. A manually set status overrides the status derived from the
+// liveness of the channel's peer until the AUTO action is requested.
+func (r *rpcServer) UpdateChanStatus(ctx context.Context,
+	req *lnrpc.UpdateChanStatusRequest) (*lnrpc.UpdateChanStatusResponse, error) {
+
+	if req.ChanPoint == nil {
+		return nil, fmt.Errorf("chan_point must be specified")
+	}
+
+	txidHash, err := getChanPointFundingTxid(req.ChanPoint)
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: req.ChanPoint.OutputIndex,
+	}
+
+	var action chanStatusAction
+	switch req.Action {
+	case lnrpc.UpdateChanStatusRequest_ENABLE:
+		action = chanStatusActionEnable
+	case lnrpc.UpdateChanStatusRequest_DISABLE:
+		action = chanStatusActionDisable
+	case lnrpc.UpdateChanStatusRequest_AUTO:
+		action = chanStatusActionAuto
+	default:
+		return nil, fmt.Errorf("unknown action: %v", req.Action)
+	}
+
+	rpcsLog.Debugf("[updatechanstatus] chan_point=%v, action=%v",
+		chanPoint, req.Action)
+
+	err = r.server.chanStatusMgr.UpdateChanStatus(chanPoint, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.UpdateChanStatusResponse{}, nil
+}
+
+// UpdateNodeAnnouncement allows the caller to update the alias, color,
+// advertised addresses and global features of our node announcement at
+// runtime. The updated announcement is re-signed and broadcast to the network.
+func (r *rpcServer) UpdateNodeAnnouncement(ctx context.Context,
+	req *lnrpc.UpdateNodeAnnouncementRequest) (
+	*lnrpc.UpdateNodeAnnouncementResponse, error) {
+
+	var modifiers []nodeAnnModifier
+
+	if req.Alias != "" {
+		alias, err := lnwire.NewNodeAlias(req.Alias)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias: %v", err)
+		}
+		modifiers = append(modifiers, nodeAnnSetAlias(alias))
+	}
+
+	if req.Color != "" {
+		color, err := parseHexColor(req.Color)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color: %v", err)
+		}
+		modifiers = append(modifiers, nodeAnnSetColor(color))
+	}
+
+	// The addresses are resolved up front, while the modifiers apply
+	// them to the announcement that is current at the time of the
+	// update.
+	resolveAddrs := func(rawAddrs []string) ([]net.Addr, error) {
+		addrs := make([]net.Addr, 0, len(rawAddrs))
+		for _, rawAddr := range rawAddrs {
+			addr, err := resolveExternalAddr(rawAddr)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve "+
+					"address %v: %v", rawAddr, err)
+			}
+			addrs = append(addrs, addr)
+		}
+		return addrs, nil
+	}
+
+	if len(req.RemoveAddresses) != 0 {
+		addrs, err := resolveAddrs(req.RemoveAddresses)
+		if err != nil {
+			return nil, err
+		}
+		modifiers = append(modifiers, nodeAnnRemoveAddrs(addrs))
+	}
+
+	if len(req.AddAddresses) != 0 {
+		addrs, err := resolveAddrs(req.AddAddresses)
+		if err != nil {
+			return nil, err
+		}
+		modifiers = append(modifiers, nodeAnnAddAddrs(addrs))
+	}
+
+	parseFeatureBits := func(rawBits []uint32) ([]lnwire.FeatureBit,
+		error) {
+
+		bits := make([]lnwire.FeatureBit, 0, len(rawBits))
+		for _, bit := range rawBits {
+			if bit > math.MaxUint16 {
+				return nil, fmt.Errorf("invalid feature bit: "+
+					"%v", bit)
+			}
+			bits = append(bits, lnwire.FeatureBit(bit))
+		}
+		return bits, nil
+	}
+
+	if len(req.SetFeatureBits) != 0 {
+		bits, err := parseFeatureBits(req.SetFeatureBits)
+		if err != nil {
+			return nil, err
+		}
+
+		// Following the "it's OK to be odd" rule, peers that don't
+		// understand a required feature bit would refuse to connect
+		// to us, so we only allow setting the even bits we know of.
+		for _, bit := range bits {
+			_, known := lnwire.GlobalFeatures[bit]
+			if bit%2 == 0 && !known {
+				return nil, fmt.Errorf("unknown required "+
+					"feature bit: %v", bit)
+			}
+		}
+		modifiers = append(modifiers, nodeAnnSetFeatureBits(bits))
+	}
+
+	if len(req.UnsetFeatureBits) != 0 {
+		bits, err := parseFeatureBits(req.UnsetFeatureBits)
+		if err != nil {
+			return nil, err
+		}
+		modifiers = append(modifiers, nodeAnnUnsetFeatureBits(bits))
+	}
+
+	if len(modifiers) == 0 {
+		return nil, fmt.Errorf("no node announcement updates " +
+			"requested")
+	}
+
+	rpcsLog.Debugf("[updatenodeannouncement] alias=%v, color=%v, "+
+		"add_addresses=%v, remove_addresses=%v, set_feature_bits=%v, "+
+		"unset_feature_bits=%v", req.Alias, req.Color,
+		req.AddAddresses, req.RemoveAddresses, req.SetFeatureBits,
+		req.UnsetFeatureBits)
+
+	nodeAnn, err := r.server.updateNodeAnnouncement(modifiers...)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeAddrs := make([]*lnrpc.NodeAddress, 0, len(nodeAnn.Addresses))
+	for _, addr := range nodeAnn.Addresses {
+		nodeAddrs = append(nodeAddrs, &lnrpc.NodeAddress{
+			Network: addr.Network(),
+			Addr:    addr.String(),
+		})
+	}
+
+	nodeColor := fmt.Sprintf("#%02x%02x%02x", nodeAnn.RGBColor.R,
+		nodeAnn.RGBColor.G, nodeAnn.RGBColor.B)
+	return &lnrpc.UpdateNodeAnnouncementResponse{
+		Node: &lnrpc.LightningNode{
+			LastUpdate: nodeAnn.Timestamp,
+			PubKey:     hex.EncodeToString(nodeAnn.NodeID[:]),
+			Addresses:  nodeAddrs,
+			Alias:      nodeAnn.Alias.String(),
+			Color:      nodeColor,
+		},
+	}, nil
+}
+
 // ForwardingHistory allows the caller to query the htlcswitch for a record of
 // all HTLC's forwarded within the target time range, and integer offset within
 // that time range. If no time-range is specified, then the first chunk of the
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// hopHintChannel describes one of our channels for the hop hint selection
+// tests.
+type hopHintChannel struct {
+	public        bool
+	inactive      bool
+	noPolicy      bool
+	remoteBalance lnwire.MilliSatoshi
+}
+
+// TestHopHintsForChannels tests that only our active private channels for
+// which we know the remote policy are selected as hop hints, that channels
+// able to carry the invoice amount are preferred, and that the number of hints
+// is capped.
+func TestHopHintsForChannels(t *testing.T) {
+	t.Parallel()
+
+	const amt = lnwire.MilliSatoshi(100000)
+
+	manyChannels := make([]hopHintChannel, maxHopHints+5)
+	manyHints := make([]uint64, maxHopHints)
+	for i := range manyChannels {
+		manyChannels[i].remoteBalance = amt + lnwire.MilliSatoshi(i)
+	}
+	for i := range manyHints {
+		manyHints[i] = uint64(len(manyChannels) - i)
+	}
+
+	tests := []struct {
+		name     string
+		channels []hopHintChannel
+		amt      lnwire.MilliSatoshi
+
+		// expected holds the short channel IDs of the selected hints
+		// in order, the channel at index i having ID i+1.
+		expected []uint64
+	}{
+		{
+			name: "no channels",
+			amt:  amt,
+		},
+		{
+			name: "private channels only",
+			channels: []hopHintChannel{
+				{remoteBalance: amt},
+				{public: true, remoteBalance: amt},
+			},
+			amt:      amt,
+			expected: []uint64{1},
+		},
+		{
+			name: "active links only",
+			channels: []hopHintChannel{
+				{inactive: true, remoteBalance: amt},
+				{remoteBalance: amt},
+			},
+			amt:      amt,
+			expected: []uint64{2},
+		},
+		{
+			name: "remote policy known only",
+			channels: []hopHintChannel{
+				{remoteBalance: amt},
+				{noPolicy: true, remoteBalance: amt},
+			},
+			amt:      amt,
+			expected: []uint64{1},
+		},
+		{
+			name: "sufficient bandwidth preferred",
+			channels: []hopHintChannel{
+				{remoteBalance: amt - 1},
+				{remoteBalance: amt},
+				{remoteBalance: amt * 2},
+			},
+			amt:      amt,
+			expected: []uint64{3, 2},
+		},
+		{
+			name: "insufficient bandwidth ranked",
+			channels: []hopHintChannel{
+				{remoteBalance: amt / 2},
+				{remoteBalance: amt - 1},
+				{remoteBalance: amt / 4},
+			},
+			amt:      amt,
+			expected: []uint64{2, 1, 3},
+		},
+		{
+			name:     "hint count capped",
+			channels: manyChannels,
+			amt:      amt,
+			expected: manyHints,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testHopHintsForChannels(
+				t, test.channels, test.amt, test.expected,
+			)
+		})
+	}
+}
+
+func testHopHintsForChannels(t *testing.T, channels []hopHintChannel,
+	amt lnwire.MilliSatoshi, expected []uint64) {
+
+	t.Helper()
+
+	localKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	var (
+		openChannels []*channeldb.OpenChannel
+		inactive     = make(map[lnwire.ChannelID]struct{})
+		edges        = make(map[uint64]*channeldb.ChannelEdgeInfo)
+		policies     = make(map[uint64]*channeldb.ChannelEdgePolicy)
+	)
+	for i, c := range channels {
+		remoteKey, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+
+		shortChanID := uint64(i + 1)
+		channel := &channeldb.OpenChannel{
+			ShortChanID: lnwire.NewShortChanIDFromInt(shortChanID),
+			FundingOutpoint: wire.OutPoint{
+				Index: uint32(i),
+			},
+			IdentityPub: remoteKey.PubKey(),
+			LocalCommitment: channeldb.ChannelCommitment{
+				RemoteBalance: c.remoteBalance,
+			},
+		}
+		if c.public {
+			channel.ChannelFlags |= lnwire.FFAnnounceChannel
+		}
+		openChannels = append(openChannels, channel)
+
+		if c.inactive {
+			chanID := lnwire.NewChanIDFromOutPoint(
+				&channel.FundingOutpoint,
+			)
+			inactive[chanID] = struct{}{}
+		}
+
+		// Alternate the side of the remote node within the edge to
+		// make sure its policy is the one used.
+		edge := &channeldb.ChannelEdgeInfo{}
+		if i%2 == 0 {
+			copy(edge.NodeKey1Bytes[:],
+				remoteKey.PubKey().SerializeCompressed())
+			copy(edge.NodeKey2Bytes[:],
+				localKey.PubKey().SerializeCompressed())
+		} else {
+			copy(edge.NodeKey1Bytes[:],
+				localKey.PubKey().SerializeCompressed())
+			copy(edge.NodeKey2Bytes[:],
+				remoteKey.PubKey().SerializeCompressed())
+		}
+		edges[shortChanID] = edge
+
+		if !c.noPolicy {
+			fee := lnwire.MilliSatoshi(shortChanID)
+			policies[shortChanID] = &channeldb.ChannelEdgePolicy{
+				FeeBaseMSat:               fee,
+				FeeProportionalMillionths: fee,
+				TimeLockDelta:             uint16(shortChanID),
+			}
+		}
+	}
+
+	isActive := func(chanID lnwire.ChannelID) bool {
+		_, ok := inactive[chanID]
+		return !ok
+	}
+
+	// Our own policy is always known, so a channel without the remote
+	// policy only lacks the one on the remote node's side.
+	ourPolicy := &channeldb.ChannelEdgePolicy{}
+	fetchEdges := func(shortChanID uint64) (*channeldb.ChannelEdgeInfo,
+		*channeldb.ChannelEdgePolicy, *channeldb.ChannelEdgePolicy,
+		error) {
+
+		edge, ok := edges[shortChanID]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("unknown channel %v",
+				shortChanID)
+		}
+
+		remotePolicy := policies[shortChanID]
+		if shortChanID%2 == 1 {
+			return edge, remotePolicy, ourPolicy, nil
+		}
+		return edge, ourPolicy, remotePolicy, nil
+	}
+
+	routeHints := hopHintsForChannels(
+		amt, openChannels, isActive, fetchEdges,
+	)
+	if len(routeHints) != len(expected) {
+		t.Fatalf("expected %v hints, got %v", len(expected),
+			len(routeHints))
+	}
+
+	for i, routeHint := range routeHints {
+		if len(routeHint) != 1 {
+			t.Fatalf("expected single hop hint, got %v",
+				len(routeHint))
+		}
+		hint := routeHint[0]
+
+		if hint.ShortChanID != expected[i] {
+			t.Fatalf("expected hint %v to be for channel %v, "+
+				"got %v", i, expected[i], hint.ShortChanID)
+		}
+
+		channel := openChannels[hint.ShortChanID-1]
+		if !hint.PubKey.IsEqual(channel.IdentityPub) {
+			t.Fatalf("hint %v has wrong node key", i)
+		}
+
+		policy := policies[hint.ShortChanID]
+		if hint.FeeBaseMsat != uint32(policy.FeeBaseMSat) ||
+			hint.FeeProportionalMillionths !=
+				uint32(policy.FeeProportionalMillionths) ||
+			hint.CltvExpDelta != policy.TimeLockDelta {
+
+			t.Fatalf("hint %v doesn't use the remote policy", i)
+		}
+	}
+}
@@ -109,8 +109,12 @@ type server struct {
 
 	chanRouter *routing.ChannelRouter
 
+	controlTower routing.ControlTower
+
 	authGossiper *discovery.AuthenticatedGossiper
 
+	chanStatusMgr *chanStatusManager
+
 	utxoNursery *utxoNursery
 
 	chainArb *contractcourt.ChainArbitrator
@@ -128,6 +132,11 @@ type server struct {
 	// changed since last start.
 	currentNodeAnn *lnwire.NodeAnnouncement
 
+	// nodeAnnMtx serializes the refreshes and updates of currentNodeAnn,
+	// which may span broadcasting the new announcement. It must be
+	// acquired before mu.
+	nodeAnnMtx sync.Mutex
+
 	quit chan struct{}
 
 	wg sync.WaitGroup
@@ -151,7 +160,14 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		}
 	}
 
-	globalFeatures := lnwire.NewRawFeatureVector()
+	// We'll advertise that we accept payments that are split across
+	// multiple HTLCs, as the invoice registry holds partial HTLCs until
+	// the invoice is covered. We also understand TLV encoded hop payloads,
+	// so senders may use them for payments to us.
+	globalFeatures := lnwire.NewRawFeatureVector(
+		lnwire.MultiPathPaymentsOptional,
+		lnwire.TLVOnionPayloadOptional,
+	)
 
 	serializedPubKey := privKey.PubKey().SerializeCompressed()
 
@@ -167,7 +183,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		chanDB: chanDB,
 		cc:     cc,
 
-		invoices: newInvoiceRegistry(chanDB),
+		invoices: newInvoiceRegistry(chanDB, cfg.AcceptKeysend),
 
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
@@ -249,15 +265,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	// CAN be passed into the ExternalIPs configuration option.
 	selfAddrs := make([]net.Addr, 0, len(cfg.ExternalIPs))
 	for _, ip := range cfg.ExternalIPs {
-		var addr string
-		_, _, err = net.SplitHostPort(ip)
-		if err != nil {
-			addr = net.JoinHostPort(ip, strconv.Itoa(defaultPeerPort))
-		} else {
-			addr = ip
-		}
-
-		lnAddr, err := cfg.net.ResolveTCPAddr("tcp", addr)
+		lnAddr, err := resolveExternalAddr(ip)
 		if err != nil {
 			return nil, err
 		}
@@ -325,11 +333,15 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	if err != nil {
 		return nil, err
 	}
+	s.controlTower = routing.NewControlTower(
+		channeldb.NewPaymentControl(chanDB),
+	)
+
 	s.chanRouter, err = routing.New(routing.Config{
 		Graph:     chanGraph,
 		Chain:     cc.chainIO,
 		ChainView: cc.chainView,
-		SendToSwitch: func(firstHopPub [33]byte,
+		SendToSwitch: func(firstHopPub [33]byte, paymentID uint64,
 			htlcAdd *lnwire.UpdateAddHTLC,
 			circuit *sphinx.Circuit) ([32]byte, error) {
 
@@ -340,10 +352,26 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 				OnionErrorDecrypter: sphinx.NewOnionErrorDecrypter(circuit),
 			}
 
-			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
+			return s.htlcSwitch.SendHTLC(
+				firstHopPub, paymentID, htlcAdd, errorDecryptor,
+			)
+		},
+		GetPaymentResult: func(paymentID uint64, paymentHash [32]byte,
+			circuit *sphinx.Circuit) ([32]byte, error) {
+
+			errorDecryptor := &htlcswitch.SphinxErrorDecrypter{
+				OnionErrorDecrypter: sphinx.NewOnionErrorDecrypter(circuit),
+			}
+
+			return s.htlcSwitch.GetPaymentResult(
+				paymentID, paymentHash, errorDecryptor,
+			)
 		},
-		ChannelPruneExpiry: time.Duration(time.Hour * 24 * 14),
-		GraphPruneInterval: time.Duration(time.Hour),
+		NextPaymentID:       s.htlcSwitch.NextPaymentID,
+		CleanPaymentResults: s.htlcSwitch.CleanStore,
+		Control:             s.controlTower,
+		ChannelPruneExpiry:  time.Duration(time.Hour * 24 * 14),
+		GraphPruneInterval:  time.Duration(time.Hour),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
@@ -361,6 +389,13 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		RetransmitDelay:  time.Minute * 30,
 		DB:               chanDB,
 		AnnSigner:        s.nodeSigner,
+		ChanSeries:       discovery.NewChanSeries(chanDB.ChannelGraph()),
+		UpdateRateLimits: discovery.UpdateRateLimits{
+			MaxChannelUpdateBurst: discovery.DefaultMaxChannelUpdateBurst,
+			ChannelUpdateInterval: discovery.DefaultChannelUpdateInterval,
+			MaxPeerUpdateBurst:    discovery.DefaultMaxPeerUpdateBurst,
+			PeerUpdateInterval:    discovery.DefaultPeerUpdateInterval,
+		},
 	},
 		s.identityPriv.PubKey(),
 	)
@@ -368,6 +403,21 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		return nil, err
 	}
 
+	s.chanStatusMgr = newChanStatusManager(&chanStatusConfig{
+		DisableTimeout: cfg.ChanDisableTimeout,
+		SampleInterval: defaultChanStatusSampleInterval,
+		FetchChannels:  chanDB.FetchAllChannels,
+		IsChannelActive: func(chanID lnwire.ChannelID) bool {
+			link, err := s.htlcSwitch.GetLink(chanID)
+			if err != nil {
+				return false
+			}
+
+			return link.EligibleToForward()
+		},
+		ApplyChannelStatus: s.authGossiper.PropagateChanStatusUpdate,
+	})
+
 	utxnStore, err := newNurseryStore(activeNetParams.GenesisHash, chanDB)
 	if err != nil {
 		srvrLog.Errorf("unable to create nursery store: %v", err)
@@ -513,6 +563,9 @@ func (s *server) Start() error {
 	if err := s.sphinx.Start(); err != nil {
 		return err
 	}
+	if err := s.invoices.Start(); err != nil {
+		return err
+	}
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
@@ -531,6 +584,9 @@ func (s *server) Start() error {
 	if err := s.chanRouter.Start(); err != nil {
 		return err
 	}
+	if err := s.chanStatusMgr.Start(); err != nil {
+		return err
+	}
 
 	// With all the relevant sub-systems started, we'll now attempt to
 	// establish persistent connections to our direct channel collaborators
@@ -575,8 +631,10 @@ func (s *server) Stop() error {
 
 	// Shutdown the wallet, funding manager, and the rpc server.
 	s.cc.chainNotifier.Stop()
+	s.chanStatusMgr.Stop()
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
+	s.invoices.Stop()
 	s.sphinx.Stop()
 	s.utxoNursery.Stop()
 	s.breachArbiter.Stop()
@@ -621,7 +679,7 @@ func initNetworkBootstrappers(s *server) ([]discovery.NetworkPeerBootstrapper, e
 	// First, we'll create an instance of the ChannelGraphBootstrapper as
 	// this can be used by default if we've already partially seeded the
 	// network.
-	chanGraph := autopilot.ChannelGraphFromDatabase(s.chanDB.ChannelGraph())
+	chanGraph := autopilot.ChannelGraphFromSource(s.chanRouter)
 	graphBootstrapper, err := discovery.NewGraphBootstrapper(chanGraph)
 	if err != nil {
 		return nil, err
@@ -806,11 +864,110 @@ func (s *server) peerBootstrapper(numTargetPeers uint32,
 	}
 }
 
+// nodeAnnModifier is a closure that makes an in-place modification to our
+// node announcement. An error is returned if the modification can't be
+// applied to the announcement.
+type nodeAnnModifier func(*lnwire.NodeAnnouncement) error
+
+// nodeAnnSetAlias is a modifier that sets the alias of the node announcement.
+func nodeAnnSetAlias(alias lnwire.NodeAlias) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		nodeAnn.Alias = alias
+		return nil
+	}
+}
+
+// nodeAnnSetColor is a modifier that sets the color of the node announcement.
+func nodeAnnSetColor(rgb color.RGBA) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		nodeAnn.RGBColor = rgb
+		return nil
+	}
+}
+
+// nodeAnnAddAddrs is a modifier that adds the passed addresses to the node
+// announcement. Addresses that are already advertised are skipped.
+func nodeAnnAddAddrs(addrs []net.Addr) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		for _, addr := range addrs {
+			if nodeAnnAddrIndex(nodeAnn, addr) != -1 {
+				continue
+			}
+			nodeAnn.Addresses = append(nodeAnn.Addresses, addr)
+		}
+		return nil
+	}
+}
+
+// nodeAnnRemoveAddrs is a modifier that removes the passed addresses from the
+// node announcement. All of the addresses must currently be advertised.
+func nodeAnnRemoveAddrs(addrs []net.Addr) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		for _, addr := range addrs {
+			i := nodeAnnAddrIndex(nodeAnn, addr)
+			if i == -1 {
+				return fmt.Errorf("address %v isn't currently "+
+					"advertised", addr)
+			}
+			nodeAnn.Addresses = append(
+				nodeAnn.Addresses[:i], nodeAnn.Addresses[i+1:]...,
+			)
+		}
+		return nil
+	}
+}
+
+// nodeAnnAddrIndex returns the index of the passed address within the
+// addresses of the node announcement, or -1 if it isn't advertised.
+// Addresses are compared by their string representation.
+func nodeAnnAddrIndex(nodeAnn *lnwire.NodeAnnouncement, addr net.Addr) int {
+	for i, a := range nodeAnn.Addresses {
+		if a.String() == addr.String() {
+			return i
+		}
+	}
+	return -1
+}
+
+// nodeAnnSetFeatureBits is a modifier that sets the passed bits within the
+// feature vector of the node announcement.
+func nodeAnnSetFeatureBits(bits []lnwire.FeatureBit) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		if nodeAnn.Features == nil {
+			nodeAnn.Features = lnwire.NewRawFeatureVector()
+		}
+		for _, bit := range bits {
+			nodeAnn.Features.Set(bit)
+		}
+		return nil
+	}
+}
+
+// nodeAnnUnsetFeatureBits is a modifier that unsets the passed bits within
+// the feature vector of the node announcement.
+func nodeAnnUnsetFeatureBits(bits []lnwire.FeatureBit) nodeAnnModifier {
+	return func(nodeAnn *lnwire.NodeAnnouncement) error {
+		if nodeAnn.Features == nil {
+			return nil
+		}
+		for _, bit := range bits {
+			nodeAnn.Features.Unset(bit)
+		}
+		return nil
+	}
+}
+
 // genNodeAnnouncement generates and returns the current fully signed node
 // announcement. If refresh is true, then the time stamp of the announcement
-// will be updated in order to ensure it propagates through the network.
-func (s *server) genNodeAnnouncement(
-	refresh bool) (lnwire.NodeAnnouncement, error) {
+// will be updated in order to ensure it propagates through the network, and
+// the passed modifiers are applied to the announcement before it's re-signed.
+func (s *server) genNodeAnnouncement(refresh bool,
+	modifiers ...nodeAnnModifier) (lnwire.NodeAnnouncement, error) {
+
+	if refresh {
+		s.nodeAnnMtx.Lock()
+		defer s.nodeAnnMtx.Unlock()
+	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -819,27 +976,120 @@ func (s *server) genNodeAnnouncement(
 		return *s.currentNodeAnn, nil
 	}
 
-	var err error
+	nodeAnn, err := s.signNodeAnnouncement(modifiers...)
+	if err != nil {
+		return lnwire.NodeAnnouncement{}, err
+	}
+	s.currentNodeAnn = nodeAnn
+
+	return *s.currentNodeAnn, nil
+}
+
+// signNodeAnnouncement returns a copy of our current node announcement with
+// the passed modifiers applied, an updated time stamp, and a fresh signature.
+// The current node announcement itself is left untouched, so the caller
+// decides whether the new announcement replaces it.
+//
+// NOTE: This method MUST be called with s.mu held.
+func (s *server) signNodeAnnouncement(
+	modifiers ...nodeAnnModifier) (*lnwire.NodeAnnouncement, error) {
+
+	// The modifiers are applied to a copy of the announcement, including
+	// its addresses and features, such that a failure along the way
+	// doesn't leave the current announcement partially modified.
+	nodeAnn := *s.currentNodeAnn
+	nodeAnn.Addresses = append([]net.Addr(nil), nodeAnn.Addresses...)
+	if nodeAnn.Features != nil {
+		nodeAnn.Features = nodeAnn.Features.Clone()
+	}
+
+	for _, modifier := range modifiers {
+		if err := modifier(&nodeAnn); err != nil {
+			return nil, err
+		}
+	}
 
 	newStamp := uint32(time.Now().Unix())
 	if newStamp <= s.currentNodeAnn.Timestamp {
 		newStamp = s.currentNodeAnn.Timestamp + 1
 	}
+	nodeAnn.Timestamp = newStamp
 
-	s.currentNodeAnn.Timestamp = newStamp
 	sig, err := discovery.SignAnnouncement(
-		s.nodeSigner, s.identityPriv.PubKey(), s.currentNodeAnn,
+		s.nodeSigner, s.identityPriv.PubKey(), &nodeAnn,
 	)
 	if err != nil {
-		return lnwire.NodeAnnouncement{}, err
+		return nil, err
 	}
 
-	s.currentNodeAnn.Signature, err = lnwire.NewSigFromSignature(sig)
+	nodeAnn.Signature, err = lnwire.NewSigFromSignature(sig)
 	if err != nil {
-		return lnwire.NodeAnnouncement{}, err
+		return nil, err
 	}
 
-	return *s.currentNodeAnn, nil
+	return &nodeAnn, nil
+}
+
+// updateNodeAnnouncement applies the passed modifiers to our node
+// announcement, re-signs it with a fresh timestamp, and broadcasts it to the
+// network. The gossiper commits the new announcement to the channel graph,
+// after which it's also persisted as our source node.
+func (s *server) updateNodeAnnouncement(
+	modifiers ...nodeAnnModifier) (*lnwire.NodeAnnouncement, error) {
+
+	// Updates are serialized, such that each one builds upon the
+	// announcement committed by the previous one.
+	s.nodeAnnMtx.Lock()
+	defer s.nodeAnnMtx.Unlock()
+
+	s.mu.RLock()
+	nodeAnn, err := s.signNodeAnnouncement(modifiers...)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate node "+
+			"announcement: %v", err)
+	}
+
+	errChan := s.authGossiper.ProcessLocalAnnouncement(
+		nodeAnn, s.identityPriv.PubKey(),
+	)
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return nil, fmt.Errorf("unable to broadcast node "+
+				"announcement: %v", err)
+		}
+	case <-s.quit:
+		return nil, ErrServerShuttingDown
+	}
+
+	// Now that the gossiper has accepted the new announcement, it
+	// replaces our current one.
+	s.mu.Lock()
+	s.currentNodeAnn = nodeAnn
+	s.mu.Unlock()
+
+	selfNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Unix(int64(nodeAnn.Timestamp), 0),
+		Addresses:            nodeAnn.Addresses,
+		PubKeyBytes:          nodeAnn.NodeID,
+		Alias:                nodeAnn.Alias.String(),
+		AuthSigBytes:         nodeAnn.Signature.ToSignatureBytes(),
+		Features: lnwire.NewFeatureVector(
+			nodeAnn.Features, lnwire.GlobalFeatures,
+		),
+		Color: nodeAnn.RGBColor,
+	}
+	if err := s.chanDB.ChannelGraph().SetSourceNode(selfNode); err != nil {
+		return nil, fmt.Errorf("unable to persist source node: %v",
+			err)
+	}
+
+	srvrLog.Infof("Updated node announcement: alias=%v, color=%v, "+
+		"addrs=%v", selfNode.Alias, selfNode.Color, selfNode.Addresses)
+
+	return nodeAnn, nil
 }
 
 type nodeAddresses struct {
@@ -1243,6 +1493,11 @@ func (s *server) peerTerminationWatcher(p *peer) {
 	// available for use.
 	s.fundingMgr.CancelPeerReservations(p.PubKey())
 
+	// The links of all channels with this peer are about to be removed,
+	// so we'll let the channel status manager know that their grace
+	// period before being disabled starts now.
+	s.chanStatusMgr.PeerDisconnected(p.addr.IdentityKey)
+
 	// Tell the switch to remove all links associated with this peer.
 	// Passing nil as the target link indicates that all links associated
 	// with this interface should be closed.
@@ -1390,8 +1645,14 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 	// feature vector to advertise to the remote node.
 	localFeatures := lnwire.NewRawFeatureVector()
 
+	// We'll signal that we understand the gossip queries, such that peers
+	// that do too can reconcile their channel graph with ours rather than
+	// requesting a complete dump of it.
+	localFeatures.Set(lnwire.GossipQueriesOptional)
+
 	// We'll only request a full channel graph sync if we detect that that
-	// we aren't fully synced yet.
+	// we aren't fully synced yet. This is only honored by peers that don't
+	// understand the gossip queries.
 	if s.shouldRequestGraphSync() {
 		localFeatures.Set(lnwire.InitialRoutingSync)
 	}
@@ -1680,10 +1941,26 @@ func (s *server) addPeer(p *peer) {
 	s.wg.Add(1)
 	go s.peerTerminationWatcher(p)
 
-	// If the remote peer has the initial sync feature bit set, then we'll
-	// being the synchronization protocol to exchange authenticated channel
-	// graph edges/vertexes
-	if p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync) {
+	switch {
+	// If the remote peer knows of the gossip queries feature, then we'll
+	// create a new gossipSyncer in the AuthenticatedGossiper for it. The
+	// syncer will reconcile our channel graph with the peer's, and only
+	// exchange the announcements missing on either side.
+	case p.remoteLocalFeatures.HasFeature(lnwire.GossipQueriesOptional):
+		srvrLog.Infof("Negotiated gossip queries with %x",
+			p.addr.IdentityKey.SerializeCompressed())
+
+		err := s.authGossiper.InitSyncState(p.addr.IdentityKey, true)
+		if err != nil {
+			srvrLog.Errorf("unable to init gossip sync state "+
+				"for %x: %v",
+				p.addr.IdentityKey.SerializeCompressed(), err)
+		}
+
+	// Otherwise, if the remote peer has the initial sync feature bit set,
+	// then we'll being the synchronization protocol to exchange
+	// authenticated channel graph edges/vertexes.
+	case p.remoteLocalFeatures.HasFeature(lnwire.InitialRoutingSync):
 		go s.authGossiper.SynchronizeNode(p.addr.IdentityKey)
 	}
 
@@ -1721,6 +1998,10 @@ func (s *server) removePeer(p *peer) {
 
 	delete(s.peersByPub, pubStr)
 
+	// If the peer had a gossip syncer, then it's no longer needed now
+	// that the peer is gone.
+	s.authGossiper.PruneSyncState(p.addr.IdentityKey)
+
 	if p.inbound {
 		delete(s.inboundPeers, pubStr)
 	} else {
@@ -1954,6 +2235,19 @@ func (s *server) Peers() []*peer {
 	return peers
 }
 
+// resolveExternalAddr resolves an address that we advertise to the network in
+// our node announcement. If the address lacks a port, then the default peer
+// port is used. We need to use the cfg.net.ResolveTCPAddr function in case we
+// wish to resolve hosts over Tor since domains CAN be advertised.
+func resolveExternalAddr(ip string) (net.Addr, error) {
+	addr := ip
+	if _, _, err := net.SplitHostPort(ip); err != nil {
+		addr = net.JoinHostPort(ip, strconv.Itoa(defaultPeerPort))
+	}
+
+	return cfg.net.ResolveTCPAddr("tcp", addr)
+}
+
 // parseHexColor takes a hex string representation of a color in the
 // form "#RRGGBB", parses the hex color values, and returns a color.RGBA
 // struct of the same color.
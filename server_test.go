@@ -1,6 +1,25 @@
 package main
 
-import "testing"
+import (
+	"fmt"
+	"image/color"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/discovery"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/lightningnetwork/lnd/torsvc"
+	"github.com/roasbeef/btcd/btcec"
+)
 
 func TestParseHexColor(t *testing.T) {
 	empty := ""
@@ -32,3 +51,237 @@ func TestParseHexColor(t *testing.T) {
 		t.Fatalf("Color %s incorrectly parsed as %v", valid, color)
 	}
 }
+
+// mockNodeAnnGraph is a channel graph source that only handles the node
+// announcements processed by the gossiper, which it records. Announcements
+// are rejected while rejectErr is set.
+type mockNodeAnnGraph struct {
+	routing.ChannelGraphSource
+
+	mu        sync.Mutex
+	nodes     []*channeldb.LightningNode
+	rejectErr error
+}
+
+func (g *mockNodeAnnGraph) CurrentBlockHeight() (uint32, error) {
+	return 0, nil
+}
+
+func (g *mockNodeAnnGraph) ForAllOutgoingChannels(
+	cb func(*channeldb.ChannelEdgeInfo,
+		*channeldb.ChannelEdgePolicy) error) error {
+
+	return nil
+}
+
+func (g *mockNodeAnnGraph) AddNode(node *channeldb.LightningNode) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.rejectErr != nil {
+		return g.rejectErr
+	}
+	g.nodes = append(g.nodes, node)
+	return nil
+}
+
+// TestUpdateNodeAnnouncement tests that updating our node announcement
+// applies the modifiers to the current announcement, bumps its time stamp,
+// re-signs it, and persists it as our source node, and that a failed update
+// leaves the current announcement untouched.
+func TestUpdateNodeAnnouncement(t *testing.T) {
+	channeldb.UseLogger(btclog.Disabled)
+	discovery.UseLogger(btclog.Disabled)
+	srvrLog = btclog.Disabled
+
+	// Addresses are resolved using the network of the global config.
+	cfg = &config{
+		net: &torsvc.RegularNet{},
+	}
+
+	tempDir, err := ioutil.TempDir("", "nodeann")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := channeldb.Open(tempDir)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	graph := &mockNodeAnnGraph{}
+	gossiper, err := discovery.New(discovery.Config{
+		Router:   graph,
+		Notifier: &mockNotfier{},
+		Broadcast: func(map[routing.Vertex]struct{},
+			...lnwire.Message) error {
+
+			return nil
+		},
+		SendToPeer: func(*btcec.PublicKey, ...lnwire.Message) error {
+			return nil
+		},
+		TrickleDelay:    time.Millisecond,
+		RetransmitDelay: time.Hour,
+		DB:              db,
+	}, priv.PubKey())
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	// An address without a port should be resolved to the default peer
+	// port, while an explicit port is kept.
+	oldAddr, err := resolveExternalAddr("127.0.0.1")
+	if err != nil {
+		t.Fatalf("unable to resolve address: %v", err)
+	}
+	if oldAddr.String() != net.JoinHostPort("127.0.0.1",
+		strconv.Itoa(defaultPeerPort)) {
+
+		t.Fatalf("expected default peer port, got %v", oldAddr)
+	}
+	newAddr, err := resolveExternalAddr("10.0.0.1:9736")
+	if err != nil {
+		t.Fatalf("unable to resolve address: %v", err)
+	}
+	if newAddr.String() != "10.0.0.1:9736" {
+		t.Fatalf("expected port to be kept, got %v", newAddr)
+	}
+
+	// Our current announcement carries a time stamp in the future, so
+	// the new one should only bump it by one.
+	var nodeID [33]byte
+	copy(nodeID[:], priv.PubKey().SerializeCompressed())
+	alias, _ := lnwire.NewNodeAlias("alice")
+	currentAnn := &lnwire.NodeAnnouncement{
+		Timestamp: uint32(time.Now().Unix()) + 1000,
+		NodeID:    nodeID,
+		Alias:     alias,
+		Addresses: []net.Addr{oldAddr},
+		Features: lnwire.NewRawFeatureVector(
+			lnwire.TLVOnionPayloadOptional,
+		),
+	}
+
+	s := &server{
+		identityPriv:   priv,
+		nodeSigner:     newNodeSigner(priv),
+		chanDB:         db,
+		authGossiper:   gossiper,
+		currentNodeAnn: currentAnn,
+		quit:           make(chan struct{}),
+	}
+
+	newAlias, _ := lnwire.NewNodeAlias("bob")
+	nodeAnn, err := s.updateNodeAnnouncement(
+		nodeAnnSetAlias(newAlias),
+		nodeAnnSetColor(color.RGBA{R: 1, G: 2, B: 3}),
+		nodeAnnRemoveAddrs([]net.Addr{oldAddr}),
+		nodeAnnAddAddrs([]net.Addr{newAddr, newAddr}),
+		nodeAnnSetFeatureBits([]lnwire.FeatureBit{
+			lnwire.MultiPathPaymentsOptional,
+		}),
+		nodeAnnUnsetFeatureBits([]lnwire.FeatureBit{
+			lnwire.TLVOnionPayloadOptional,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unable to update node announcement: %v", err)
+	}
+
+	// The modifiers should have been applied, and the time stamp bumped.
+	if nodeAnn.Alias != newAlias {
+		t.Fatalf("expected alias %v, got %v", newAlias, nodeAnn.Alias)
+	}
+	if nodeAnn.RGBColor != (color.RGBA{R: 1, G: 2, B: 3}) {
+		t.Fatalf("unexpected color: %v", nodeAnn.RGBColor)
+	}
+	if len(nodeAnn.Addresses) != 1 ||
+		nodeAnn.Addresses[0].String() != newAddr.String() {
+
+		t.Fatalf("expected addresses [%v], got %v", newAddr,
+			nodeAnn.Addresses)
+	}
+	if !nodeAnn.Features.IsSet(lnwire.MultiPathPaymentsOptional) ||
+		nodeAnn.Features.IsSet(lnwire.TLVOnionPayloadOptional) {
+
+		t.Fatalf("unexpected features: %v", nodeAnn.Features)
+	}
+	if nodeAnn.Timestamp != currentAnn.Timestamp+1 {
+		t.Fatalf("expected time stamp %v, got %v",
+			currentAnn.Timestamp+1, nodeAnn.Timestamp)
+	}
+
+	// The new announcement should carry a valid signature, and have been
+	// handed to the graph by the gossiper.
+	if err := discovery.ValidateNodeAnn(nodeAnn); err != nil {
+		t.Fatalf("invalid node announcement signature: %v", err)
+	}
+	graph.mu.Lock()
+	numNodes := len(graph.nodes)
+	graph.mu.Unlock()
+	if numNodes != 1 {
+		t.Fatalf("expected 1 node added to graph, got %v", numNodes)
+	}
+
+	// It should also have replaced our current announcement, while the
+	// original one is left untouched.
+	if s.currentNodeAnn != nodeAnn {
+		t.Fatalf("current node announcement wasn't replaced")
+	}
+	if currentAnn.Alias != alias || len(currentAnn.Addresses) != 1 ||
+		!currentAnn.Features.IsSet(lnwire.TLVOnionPayloadOptional) {
+
+		t.Fatalf("original node announcement was modified")
+	}
+
+	// Finally, the new announcement should have been persisted as our
+	// source node.
+	sourceNode, err := db.ChannelGraph().SourceNode()
+	if err != nil {
+		t.Fatalf("unable to fetch source node: %v", err)
+	}
+	if sourceNode.Alias != "bob" ||
+		sourceNode.LastUpdate.Unix() != int64(nodeAnn.Timestamp) ||
+		len(sourceNode.Addresses) != 1 ||
+		sourceNode.Addresses[0].String() != newAddr.String() {
+
+		t.Fatalf("unexpected source node: %v", spew.Sdump(sourceNode))
+	}
+
+	// An update with a modifier that can't be applied should fail, and
+	// leave the current announcement untouched.
+	_, err = s.updateNodeAnnouncement(
+		nodeAnnSetAlias(alias), nodeAnnRemoveAddrs([]net.Addr{oldAddr}),
+	)
+	if err == nil {
+		t.Fatalf("expected removing unknown address to fail")
+	}
+	if s.currentNodeAnn != nodeAnn || nodeAnn.Alias != newAlias {
+		t.Fatalf("current node announcement was modified")
+	}
+
+	// The same holds if the gossiper rejects the new announcement.
+	graph.mu.Lock()
+	graph.rejectErr = fmt.Errorf("rejected")
+	graph.mu.Unlock()
+
+	_, err = s.updateNodeAnnouncement(nodeAnnSetAlias(alias))
+	if err == nil {
+		t.Fatalf("expected rejected announcement to fail")
+	}
+	if s.currentNodeAnn != nodeAnn || nodeAnn.Alias != newAlias {
+		t.Fatalf("current node announcement was modified")
+	}
+}
@@ -141,10 +141,12 @@ type Invoice struct {
 	// Optional.
 	FallbackAddr btcutil.Address
 
-	// RoutingInfo is one or more entries containing extra routing
-	// information for a private route to the target node.
+	// RouteHints represents one or more different route hints. Each route
+	// hint can be individually used to reach the destination. These
+	// usually represent private routes, each made up of one or more
+	// entries of extra routing information sorted in forward order.
 	// Optional.
-	RoutingInfo []ExtraRoutingInfo
+	RouteHints [][]ExtraRoutingInfo
 }
 
 // ExtraRoutingInfo holds the information needed to route a payment along one
@@ -231,12 +233,13 @@ func FallbackAddr(fallbackAddr btcutil.Address) func(*Invoice) {
 	}
 }
 
-// RoutingInfo is a functional option that allows callers of NewInvoice to set
-// one or more entries containing extra routing information for a private route
-// to the target node.
-func RoutingInfo(routingInfo []ExtraRoutingInfo) func(*Invoice) {
+// RouteHint is a functional option that allows callers of NewInvoice to add
+// a route hint, made up of one or more entries containing extra routing
+// information for a private route to the target node. This option can be
+// provided multiple times in order to include several distinct routes.
+func RouteHint(routeHint []ExtraRoutingInfo) func(*Invoice) {
 	return func(i *Invoice) {
-		i.RoutingInfo = routingInfo
+		i.RouteHints = append(i.RouteHints, routeHint)
 	}
 }
 
@@ -524,10 +527,12 @@ func validateInvoice(invoice *Invoice) error {
 		return fmt.Errorf("neither description nor description hash set")
 	}
 
-	// Can have at most 20 extra hops for routing.
-	if len(invoice.RoutingInfo) > 20 {
-		return fmt.Errorf("too many extra hops: %d",
-			len(invoice.RoutingInfo))
+	// Each route hint can have at most 20 extra hops for routing.
+	for _, routeHint := range invoice.RouteHints {
+		if len(routeHint) > 20 {
+			return fmt.Errorf("too many extra hops: %d",
+				len(routeHint))
+		}
 	}
 
 	// Check that we support the field lengths.
@@ -665,13 +670,18 @@ func parseTaggedFields(invoice *Invoice, fields []byte, net *chaincfg.Params) er
 
 			invoice.FallbackAddr, err = parseFallbackAddr(base32Data, net)
 		case fieldTypeR:
-			if invoice.RoutingInfo != nil {
-				// We skip the field if we have already seen a
-				// supported one.
-				continue
+			// Unlike the other fields, multiple route hints may
+			// be included within an invoice, each of them
+			// representing a distinct route to the destination.
+			var routeHint []ExtraRoutingInfo
+			routeHint, err = parseRoutingInfo(base32Data)
+			if err != nil {
+				return err
 			}
 
-			invoice.RoutingInfo, err = parseRoutingInfo(base32Data)
+			invoice.RouteHints = append(
+				invoice.RouteHints, routeHint,
+			)
 		default:
 			// Ignore unknown type.
 		}
@@ -976,10 +986,10 @@ func writeTaggedFields(bufferBase32 *bytes.Buffer, invoice *Invoice) error {
 		}
 	}
 
-	if len(invoice.RoutingInfo) > 0 {
+	for _, routeHint := range invoice.RouteHints {
 		// Each extra routing info is encoded using 51 bytes.
-		routingDataBase256 := make([]byte, 0, 51*len(invoice.RoutingInfo))
-		for _, r := range invoice.RoutingInfo {
+		routingDataBase256 := make([]byte, 0, 51*len(routeHint))
+		for _, r := range routeHint {
 			base256 := make([]byte, 51)
 			copy(base256[:33], r.PubKey.SerializeCompressed())
 			binary.BigEndian.PutUint64(base256[33:41], r.ShortChanID)
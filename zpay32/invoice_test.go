@@ -413,7 +413,7 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo:     testSingleHop,
+					RouteHints:      [][]ExtraRoutingInfo{testSingleHop},
 				}
 			},
 			beforeEncoding: func(i *Invoice) {
@@ -436,7 +436,7 @@ func TestDecodeEncode(t *testing.T) {
 					DescriptionHash: &testDescriptionHash,
 					Destination:     testPubKey,
 					FallbackAddr:    testRustyAddr,
-					RoutingInfo:     testDoubleHop,
+					RouteHints:      [][]ExtraRoutingInfo{testDoubleHop},
 				}
 			},
 			beforeEncoding: func(i *Invoice) {
@@ -680,7 +680,7 @@ func TestNewInvoice(t *testing.T) {
 					Amount(testMillisat20mBTC),
 					DescriptionHash(testDescriptionHash),
 					FallbackAddr(testRustyAddr),
-					RoutingInfo(testDoubleHop),
+					RouteHint(testDoubleHop),
 				)
 			},
 			valid:          true,
@@ -778,7 +778,7 @@ func compareInvoices(expected, actual *Invoice) error {
 			expected.FallbackAddr, actual.FallbackAddr)
 	}
 
-	return compareRoutingInfos(expected.RoutingInfo, actual.RoutingInfo)
+	return compareRouteHints(expected.RouteHints, actual.RouteHints)
 }
 
 func comparePubkeys(a, b *btcec.PublicKey) bool {
@@ -807,6 +807,21 @@ func compareHashes(a, b *[32]byte) bool {
 	return bytes.Equal(a[:], b[:])
 }
 
+func compareRouteHints(a, b [][]ExtraRoutingInfo) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("expected len routeHints %d, got %d",
+			len(a), len(b))
+	}
+
+	for i := 0; i < len(a); i++ {
+		if err := compareRoutingInfos(a[i], b[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func compareRoutingInfos(a, b []ExtraRoutingInfo) error {
 	if len(a) != len(b) {
 		return fmt.Errorf("expected len routingInfo %d, got %d",